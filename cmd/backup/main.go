@@ -1,27 +1,39 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/crypto"
 	"timelocker-backend/pkg/database"
-	"timelocker-backend/pkg/database/migrations"
 	"timelocker-backend/pkg/logger"
-
-	"gorm.io/gorm"
 )
 
 func main() {
 	// 解析命令行参数
 	var (
-		action     = flag.String("action", "", "Action Type: backup, restore, validate, info, reset")
+		action     = flag.String("action", "", "Action Type: backup, restore, validate, info, list, prune, rekey, keygen")
 		backupPath = flag.String("file", "", "Backup File Path")
 		clearData  = flag.Bool("clear", false, "Clear Existing Data When Restore")
 		conflict   = flag.String("conflict", "skip", "Conflict Resolution Strategy: skip, replace, error")
 		autoMode   = flag.Bool("auto", false, "Auto Mode (skip user confirmation)")
+		recipients = flag.String("recipients", "", "Comma-separated X25519 recipients to encrypt backups for (overrides config)")
+		identities = flag.String("identity", "", "Comma-separated identity files to decrypt backups with (overrides config)")
+		tables     = flag.String("tables", "", "Comma-separated tables to backup or restore (default all)")
+		excludes   = flag.String("exclude-tables", "", "Comma-separated tables to skip")
+		incr       = flag.Bool("incremental", false, "Create an incremental backup based on the latest backup in the same directory")
+		user       = flag.String("user", "", "Only restore data owned by this wallet address")
+		remote     = flag.Bool("remote", false, "Use the configured backup storage (restore: -file is a backup ID or file name; list: list remote backups)")
+		dryRun     = flag.Bool("dry-run", false, "Validate the restore and print the report without writing data")
 		help       = flag.Bool("help", false, "Show Help")
 	)
 	flag.Parse()
@@ -34,6 +46,12 @@ func main() {
 	// 初始化日志
 	logger.Init(logger.DefaultConfig())
 
+	// 生成密钥对不需要数据库连接
+	if *action == "keygen" {
+		handleKeygen(*backupPath)
+		return
+	}
+
 	// 加载配置
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -41,6 +59,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	// 创建远程备份存储，未配置时为nil
+	backupStorage, err := database.NewBackupStorage(&cfg.Backup.Storage)
+	if err != nil {
+		logger.Error("Failed to create backup storage", err)
+		os.Exit(1)
+	}
+
+	// 查看备份目录不需要数据库连接
+	if *action == "list" {
+		handleList(*backupPath, backupStorage, *remote)
+		return
+	}
+
 	// 连接数据库
 	db, err := database.NewPostgresConnection(&cfg.Database)
 	if err != nil {
@@ -48,20 +79,47 @@ func main() {
 		os.Exit(1)
 	}
 
+	// 加载通知密钥加密主密钥
+	secretKeyring, err := crypto.LoadSecretKeyring(&cfg.Secrets)
+	if err != nil {
+		logger.Error("Failed to load secret keyring", err)
+		os.Exit(1)
+	}
+
+	// 加载备份加密配置，命令行参数优先于配置文件
+	if *recipients != "" {
+		cfg.Backup.Encryption.Recipients = strings.Split(*recipients, ",")
+	}
+	if *identities != "" {
+		cfg.Backup.Encryption.IdentityFiles = strings.Split(*identities, ",")
+	}
+	backupEncryption, err := crypto.LoadBackupEncryption(&cfg.Backup.Encryption)
+	if err != nil {
+		logger.Error("Failed to load backup encryption", err)
+		os.Exit(1)
+	}
+
 	// Create backup manager
-	backupManager := database.NewBackupManager(db)
+	backupManager := database.NewBackupManager(db, secretKeyring, backupEncryption, backupStorage)
+
+	selection := database.TableSelection{
+		Tables:        database.ParseTableList(*tables),
+		ExcludeTables: database.ParseTableList(*excludes),
+	}
 
 	switch *action {
 	case "backup":
-		handleBackup(backupManager, *backupPath)
+		handleBackup(backupManager, *backupPath, database.BackupOptions{Incremental: *incr, Selection: selection}, cfg.Backup.Retention)
 	case "restore":
-		handleRestore(backupManager, *backupPath, *clearData, *conflict, *autoMode)
+		handleRestore(backupManager, *backupPath, *clearData, *conflict, *autoMode, selection, *user, *remote, *dryRun)
+	case "prune":
+		handlePrune(backupManager, *backupPath, cfg.Backup.Retention)
 	case "validate":
 		handleValidate(backupManager, *backupPath)
 	case "info":
 		handleInfo(backupManager, *backupPath)
-	case "reset":
-		handleReset(db)
+	case "rekey":
+		handleRekey(backupManager, *backupPath, *autoMode)
 	default:
 		fmt.Printf("Error: Unsupported action '%s'\n", *action)
 		showHelp()
@@ -69,25 +127,62 @@ func main() {
 	}
 }
 
-func handleBackup(bm *database.BackupManager, backupPath string) {
+func handleBackup(bm *database.BackupManager, backupPath string, options database.BackupOptions, retention config.BackupRetentionConfig) {
 	if backupPath == "" {
 		// 生成默认备份文件名
-		backupPath = fmt.Sprintf("./backups/timelocker_backup_%s.json",
-			time.Now().Format("20060102_150405"))
+		kind := "backup"
+		if options.Incremental {
+			kind = "incr"
+		}
+		backupPath = fmt.Sprintf("./backups/timelocker_%s_%s.tar",
+			kind, time.Now().Format("20060102_150405"))
 	}
 
 	fmt.Printf("Creating backup to: %s\n", backupPath)
 
-	if err := bm.CreateBackup(backupPath); err != nil {
+	entry, err := bm.CreateBackup(context.Background(), backupPath, options)
+	if err != nil {
 		logger.Error("Backup failed", err)
 		fmt.Printf("Backup failed: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Backup created successfully: %s\n", backupPath)
+	fmt.Printf("Backup ID: %s (%s)\n", entry.ID, entry.Type)
+	if entry.ParentID != "" {
+		fmt.Printf("Parent backup: %s\n", entry.ParentID)
+	}
+	fmt.Printf("Size: %d bytes, SHA-256: %s\n", entry.Size, entry.SHA256)
+
+	// 备份成功后按保留策略清理旧备份，清理失败不影响本次备份
+	removed, err := bm.ApplyRetention(context.Background(), filepath.Dir(backupPath), retention)
+	if err != nil {
+		logger.Error("Prune failed", err)
+		fmt.Printf("Warning: failed to prune old backups: %v\n", err)
+	} else if len(removed) > 0 {
+		fmt.Printf("Pruned %d old backups\n", len(removed))
+	}
+}
+
+func handlePrune(bm *database.BackupManager, backupDir string, retention config.BackupRetentionConfig) {
+	if backupDir == "" {
+		backupDir = "./backups"
+	}
+
+	removed, err := bm.ApplyRetention(context.Background(), backupDir, retention)
+	if err != nil {
+		logger.Error("Prune failed", err)
+		fmt.Printf("Prune failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, entry := range removed {
+		fmt.Printf("Removed %s (%s, %s)\n", entry.ID, entry.Type, entry.File)
+	}
+	fmt.Printf("Prune completed, %d backups removed\n", len(removed))
 }
 
-func handleRestore(bm *database.BackupManager, backupPath string, clearData bool, conflictStr string, autoMode bool) {
+func handleRestore(bm *database.BackupManager, backupPath string, clearData bool, conflictStr string, autoMode bool, selection database.TableSelection, userAddress string, remote bool, dryRun bool) {
 	if backupPath == "" {
 		fmt.Println("Error: Backup file path is required")
 		os.Exit(1)
@@ -110,16 +205,34 @@ func handleRestore(bm *database.BackupManager, backupPath string, clearData bool
 	options := database.RestoreOptions{
 		ClearExisting: clearData,
 		OnConflict:    conflictAction,
+		Selection:     selection,
+		UserAddress:   userAddress,
+		DryRun:        dryRun,
 	}
 
-	fmt.Printf("Restoring data from backup: %s\n", backupPath)
+	if remote {
+		fmt.Printf("Restoring data from remote backup: %s\n", backupPath)
+	} else {
+		fmt.Printf("Restoring data from backup: %s\n", backupPath)
+	}
+	if len(selection.Tables) > 0 {
+		fmt.Printf("Tables: %s\n", strings.Join(selection.Tables, ", "))
+	}
+	if len(selection.ExcludeTables) > 0 {
+		fmt.Printf("Excluded tables: %s\n", strings.Join(selection.ExcludeTables, ", "))
+	}
+	if userAddress != "" {
+		fmt.Printf("User: %s\n", userAddress)
+	}
 	if clearData {
-		fmt.Println("Warning: Existing user data will be cleared")
+		fmt.Println("Warning: Existing data in the selected tables will be cleared")
 	}
 	fmt.Printf("Conflict strategy: %s\n", conflictStr)
 
-	// 询问用户确认（除非是自动模式）
-	if !autoMode {
+	// 询问用户确认（除非是自动模式或试运行）
+	if dryRun {
+		fmt.Println("Dry run: validating without writing data...")
+	} else if !autoMode {
 		fmt.Print("Continue? (y/N): ")
 		var confirm string
 		fmt.Scanln(&confirm)
@@ -131,15 +244,89 @@ func handleRestore(bm *database.BackupManager, backupPath string, clearData bool
 		fmt.Println("Auto mode: proceeding with restore...")
 	}
 
-	if err := bm.RestoreBackup(backupPath, options); err != nil {
+	// 中断信号取消下载和恢复，恢复事务随之回滚
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 远程备份先下载到临时目录（含备份链），恢复后删除
+	if remote {
+		dir, err := os.MkdirTemp("", "timelocker-restore-*")
+		if err != nil {
+			fmt.Printf("Failed to create download directory: %v\n", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(dir)
+
+		localPath, err := bm.FetchBackup(ctx, backupPath, dir)
+		if err != nil {
+			logger.Error("Download failed", err)
+			fmt.Printf("Download failed: %v\n", err)
+			os.RemoveAll(dir)
+			os.Exit(1)
+		}
+		backupPath = localPath
+	}
+
+	report, err := bm.RestoreBackup(ctx, backupPath, options)
+	if report != nil {
+		printRestoreReport(report)
+	}
+	if err != nil {
 		logger.Error("Restore failed", err)
-		fmt.Printf("Restore failed: %v\n", err)
+		if errors.Is(err, database.ErrRestoreValidation) {
+			fmt.Println("Restore failed: backup data does not fit the current schema, no data written")
+		} else {
+			fmt.Printf("Restore failed: %v\n", err)
+		}
+		if remote {
+			os.RemoveAll(filepath.Dir(backupPath))
+		}
 		os.Exit(1)
 	}
 
+	if dryRun {
+		fmt.Println("Dry run completed, backup can be restored")
+		return
+	}
 	fmt.Println("Data restored successfully")
 }
 
+// printRestoreReport 输出恢复校验报告
+func printRestoreReport(report *database.RestoreReport) {
+	fmt.Printf("\nBackup schema: %04d, database schema: %04d\n", report.BackupSchemaVersion, report.SchemaVersion)
+	for _, transform := range report.Transforms {
+		fmt.Printf("Transform: %s\n", transform)
+	}
+
+	fmt.Printf("\n=== Restore Report ===\n")
+	fmt.Printf("%-36s %10s %10s\n", "TABLE", "ROWS", "WRITTEN")
+	for _, table := range report.Tables {
+		written := fmt.Sprintf("%d", table.Written)
+		if report.DryRun || !report.Valid() {
+			written = "-"
+		}
+		fmt.Printf("%-36s %10d %10s\n", table.Name, table.Rows, written)
+		if table.Note != "" {
+			fmt.Printf("    note: %s\n", table.Note)
+		}
+		for _, conflict := range table.Conflicts {
+			fmt.Printf("    %s: %d rows conflict on %s\n", conflict.Action, conflict.Rows, conflict.Constraint)
+		}
+		for _, issue := range table.Issues {
+			fmt.Printf("    [%s] %s: %d rows", issue.Kind, issue.Message, issue.Rows)
+			if len(issue.SampleIDs) > 0 {
+				ids := make([]string, len(issue.SampleIDs))
+				for i, id := range issue.SampleIDs {
+					ids[i] = fmt.Sprintf("%d", id)
+				}
+				fmt.Printf(" (ids: %s)", strings.Join(ids, ", "))
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+}
+
 func handleValidate(bm *database.BackupManager, backupPath string) {
 	if backupPath == "" {
 		fmt.Println("Error: Backup file path is required")
@@ -171,55 +358,136 @@ func handleInfo(bm *database.BackupManager, backupPath string) {
 	}
 
 	fmt.Printf("\nBackup file info:\n")
+	fmt.Printf("Format: %s\n", info.Format)
+	if info.ID != "" {
+		fmt.Printf("ID: %s\n", info.ID)
+	}
+	fmt.Printf("Type: %s\n", info.Type)
+	if info.ParentID != "" {
+		fmt.Printf("Parent: %s\n", info.ParentID)
+	}
 	fmt.Printf("Version: %s\n", info.Version)
+	if info.SchemaVersion > 0 {
+		fmt.Printf("Schema version: %04d\n", info.SchemaVersion)
+	} else {
+		fmt.Printf("Schema version: legacy\n")
+	}
 	fmt.Printf("Created at: %s\n", info.Timestamp.Format("2006-01-02 15:04:05"))
-	fmt.Printf("\n=== User Data ===\n")
-	fmt.Printf("Users: %d\n", len(info.Users))
-	fmt.Printf("Emails: %d\n", len(info.Emails))
-	fmt.Printf("User Emails: %d\n", len(info.UserEmails))
-	fmt.Printf("Email Verification Codes: %d\n", len(info.EmailVerificationCodes))
-	fmt.Printf("Auth Nonces: %d\n", len(info.AuthNonces))
-	fmt.Printf("\n=== Safe Wallets ===\n")
-	fmt.Printf("Safe Wallets: %d\n", len(info.SafeWallets))
-	fmt.Printf("\n=== System Data ===\n")
-	fmt.Printf("Support Chains: %d\n", len(info.SupportChains))
-	fmt.Printf("Sponsors: %d\n", len(info.Sponsors))
-	fmt.Printf("\n=== ABIs ===\n")
-	fmt.Printf("User Custom ABIs: %d\n", len(info.ABIs))
-	fmt.Printf("\n=== Timelock Contracts ===\n")
-	fmt.Printf("Compound Timelocks: %d\n", len(info.CompoundTimelocks))
-	fmt.Printf("OpenZeppelin Timelocks: %d\n", len(info.OpenzeppelinTimelocks))
-	fmt.Printf("\n=== Transaction Records ===\n")
-	fmt.Printf("Compound Timelock Transactions: %d\n", len(info.CompoundTimelockTransactions))
-	fmt.Printf("OpenZeppelin Timelock Transactions: %d\n", len(info.OpenzeppelinTimelockTransactions))
-	fmt.Printf("Timelock Transaction Flows: %d\n", len(info.TimelockTransactionFlows))
-	fmt.Printf("Block Scan Progress: %d\n", len(info.BlockScanProgress))
-	fmt.Printf("\n=== Notifications ===\n")
-	fmt.Printf("Email Send Logs: %d\n", len(info.EmailSendLogs))
-	fmt.Printf("Telegram Configs: %d\n", len(info.TelegramConfigs))
-	fmt.Printf("Lark Configs: %d\n", len(info.LarkConfigs))
-	fmt.Printf("Feishu Configs: %d\n", len(info.FeishuConfigs))
-	fmt.Printf("Notification Logs: %d\n", len(info.NotificationLogs))
+	if info.Encrypted {
+		fmt.Printf("Encrypted: yes (%s)\n", strings.Join(info.KeyTypes, ", "))
+	} else {
+		fmt.Printf("Encrypted: no\n")
+	}
+	fmt.Printf("\n=== Tables ===\n")
+	var total int64
+	for _, table := range info.Tables {
+		fmt.Printf("%-36s %d\n", table.Name+":", table.Rows)
+		total += table.Rows
+	}
+	fmt.Printf("\nTotal records: %d\n", total)
 }
 
-func handleReset(db *gorm.DB) {
-	fmt.Println("Warning: This operation will delete all database tables and data!")
-	fmt.Print("Continue? Please enter 'RESET' to confirm: ")
+func handleList(backupDir string, storage database.BackupStorage, remote bool) {
+	if backupDir == "" {
+		backupDir = "./backups"
+	}
 
-	var confirm string
-	fmt.Scanln(&confirm)
-	if confirm != "RESET" {
-		fmt.Println("Operation cancelled")
+	var (
+		catalog  *database.BackupCatalog
+		location = backupDir
+		err      error
+	)
+	if remote {
+		if storage == nil {
+			fmt.Println("Error: backup.storage is not configured")
+			os.Exit(1)
+		}
+		location = storage.Location()
+		catalog, err = database.LoadStorageCatalog(context.Background(), storage)
+	} else {
+		catalog, err = database.LoadBackupCatalog(backupDir)
+	}
+	if err != nil {
+		fmt.Printf("Failed to read backup catalog: %v\n", err)
+		os.Exit(1)
+	}
+	if len(catalog.Backups) == 0 {
+		fmt.Printf("No backups recorded in %s\n", location)
 		return
 	}
 
-	if err := migrations.ResetDangerous(db); err != nil {
-		logger.Error("Reset failed", err)
-		fmt.Printf("Reset failed: %v\n", err)
+	fmt.Printf("%-26s %-12s %-26s %-20s %10s %12s  %s\n", "ID", "TYPE", "PARENT", "CREATED", "ROWS", "SIZE", "FILE")
+	for _, entry := range catalog.Backups {
+		parent := entry.ParentID
+		if parent == "" {
+			parent = "-"
+		}
+		fmt.Printf("%-26s %-12s %-26s %-20s %10d %12d  %s\n",
+			entry.ID, entry.Type, parent, entry.CreatedAt.Local().Format("2006-01-02 15:04:05"), entry.Rows, entry.Size, entry.File)
+	}
+}
+
+func handleRekey(bm *database.BackupManager, backupPath string, autoMode bool) {
+	// 指定备份文件时只重新加密备份文件，否则重新加密数据库中的通知密钥
+	if backupPath != "" {
+		fmt.Printf("Re-encrypting notification secrets in backup file: %s\n", backupPath)
+	} else {
+		fmt.Println("Re-encrypting notification secrets in database with the active key")
+	}
+
+	if !autoMode {
+		fmt.Print("Continue? (y/N): ")
+		var confirm string
+		fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			fmt.Println("Operation cancelled")
+			return
+		}
+	}
+
+	var (
+		updated int
+		err     error
+	)
+	if backupPath != "" {
+		updated, err = bm.RekeyBackup(backupPath)
+	} else {
+		updated, err = bm.RekeySecrets(context.Background())
+	}
+	if err != nil {
+		logger.Error("Rekey failed", err)
+		fmt.Printf("Rekey failed: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("Database reset successfully")
+	fmt.Printf("Rekey completed, %d records updated\n", updated)
+}
+
+func handleKeygen(identityPath string) {
+	if identityPath == "" {
+		fmt.Println("Error: Identity file path is required")
+		os.Exit(1)
+	}
+	if _, err := os.Stat(identityPath); err == nil {
+		fmt.Printf("Error: Identity file already exists: %s\n", identityPath)
+		os.Exit(1)
+	}
+
+	identity, recipient, err := crypto.GenerateBackupIdentity()
+	if err != nil {
+		fmt.Printf("Keygen failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	content := fmt.Sprintf("# created: %s\n# recipient: %s\n%s\n", time.Now().Format(time.RFC3339), recipient, identity)
+	if err := os.WriteFile(identityPath, []byte(content), 0600); err != nil {
+		fmt.Printf("Failed to write identity file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Identity written to: %s\n", identityPath)
+	fmt.Printf("Recipient: %s\n", recipient)
+	fmt.Println("Add the recipient to backup.encryption.recipients and keep the identity file offline; it is required to restore.")
 }
 
 func showHelp() {
@@ -233,34 +501,109 @@ Actions:
   restore   Restore from backup
   validate  Validate backup file
   info      Display backup file info
-  reset     Reset database (dangerous operation)
+  list      List backups recorded in the catalog of a backup directory (-file, default ./backups), or remote storage with -remote
+  prune     Apply backup.retention to remote storage, or to the backup directory (-file) when no storage is configured
+  rekey     Re-encrypt notification secrets with the active key (database, or backup file with -file)
+  keygen    Generate an X25519 identity file (-file) for encrypted backups and print its recipient
+
+Backup format:
+  Backups are tar archives with one gzip-compressed NDJSON file per table and a
+  manifest recording row counts and SHA-256 checksums. Legacy single-file JSON
+  backups (*.json) can still be restored, validated and inspected.
+
+Encryption:
+  Backups are encrypted when a passphrase (env TIMELOCKER_BACKUP_PASSPHRASE or
+  backup.encryption.passphrase_file) or recipients are configured. info and validate
+  read the manifest without decrypting table data; restore refuses tampered archives.
+
+Incremental and selective backups:
+  -incremental stores only rows created or updated since the latest backup in the same
+  directory, tracked in <dir>/catalog.json. Restoring an incremental backup restores
+  the whole chain from its full backup. Deleted rows are not captured; take a full
+  backup periodically. -tables/-exclude-tables select tables for backup or restore and
+  are ordered by foreign key dependencies; -user restores only one user's data.
+
+Remote storage and retention:
+  When backup.storage is configured (local directory or S3-compatible such as MinIO),
+  each backup is uploaded after it is written locally and recorded in the storage's
+  catalog.json. backup.retention keeps the newest backup of each of the last N days,
+  weeks and months (and every backup a kept incremental depends on); it is applied
+  after each backup and by the prune action.
+
+Schema-aware restore:
+  Backups record the database migration version. Restore converts rows from the
+  backup's schema to the current one (added, renamed or removed columns), stages them
+  in temporary tables and checks NOT NULL, CHECK, unique and foreign key constraints
+  against the rows that will remain. Nothing is written unless every check passes;
+  -dry-run prints the report only. Backups without a schema version are treated as
+  data version 2.0.0 (migration 0005).
+
+Database reset:
+  Resetting the database is done with the migrate tool (migrate reset), which rolls
+  back all schema migrations and requires TIMELOCKER_RESET_CONFIRM=<database name>.
 
 Options:
   -file=<path>        Backup file path
   -clear             Clear existing data when restore (only for restore)
   -conflict=<strategy>    Conflict resolution strategy: skip|replace|error (only for restore)
   -auto              Auto mode (skip user confirmation)
+  -recipients=<keys>  Comma-separated recipients (x25519-pub:...) to encrypt for
+  -identity=<files>   Comma-separated identity files used to decrypt
+  -tables=<names>     Comma-separated tables to backup or restore (default all)
+  -exclude-tables=<names>  Comma-separated tables to skip
+  -incremental       Create an incremental backup (only for backup)
+  -user=<address>     Only restore rows owned by this wallet address (only for restore)
+  -remote            Use remote storage: restore downloads -file (backup ID or file name) and its chain; list shows remote backups
+  -dry-run           Validate the restore and print the report without writing data (only for restore)
   -help              Display this help message
 
 Examples:
   # Create backup
   %s -action=backup
-  %s -action=backup -file=./my_backup.json
+  %s -action=backup -file=./my_backup.tar
 
   # Restore from backup (skip conflicts)
-  %s -action=restore -file=./my_backup.json -conflict=skip
+  %s -action=restore -file=./my_backup.tar -conflict=skip
 
   # Restore from backup (clear existing data)
-  %s -action=restore -file=./my_backup.json -clear -conflict=replace
+  %s -action=restore -file=./my_backup.tar -clear -conflict=replace
+
+  # Check whether a backup can be restored into the current schema
+  %s -action=restore -file=./my_backup.tar -clear -conflict=replace -dry-run
+
+  # Create an incremental backup and list the backup chain
+  %s -action=backup -incremental
+  %s -action=list
+
+  # Restore one user's Telegram configs
+  %s -action=restore -file=./backups/timelocker_incr_20250101_030000.tar -tables=telegram_configs -user=0xabc... -conflict=replace
+
+  # List remote backups and restore one directly from remote storage
+  %s -action=list -remote
+  %s -action=restore -remote -file=20250101T030000Z-a1b2c3 -clear -conflict=replace
+
+  # Apply the retention policy
+  %s -action=prune
+
+  # Restore from a legacy JSON backup
+  %s -action=restore -file=./old_backup.json -conflict=skip
+
+  # Create a backup encrypted for a recipient, then restore it with the identity
+  %s -action=keygen -file=./backup-identity.txt
+  %s -action=backup -recipients=x25519-pub:...
+  %s -action=restore -file=./my_backup.tar -identity=./backup-identity.txt
 
   # Validate backup file
-  %s -action=validate -file=./my_backup.json
+  %s -action=validate -file=./my_backup.tar
 
   # Display backup file info
-  %s -action=info -file=./my_backup.json
+  %s -action=info -file=./my_backup.tar
+
+  # Re-encrypt notification secrets in database after key rotation
+  %s -action=rekey
 
-  # Reset database (dangerous)
-  %s -action=reset
+  # Re-encrypt notification secrets in a backup file
+  %s -action=rekey -file=./my_backup.tar
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
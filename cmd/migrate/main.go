@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/database"
+	"timelocker-backend/pkg/database/migrations"
+	"timelocker-backend/pkg/logger"
+)
+
+func main() {
+	// 解析命令行参数
+	var (
+		dir  = flag.String("dir", "pkg/database/migrations/sql", "Migrations directory (only for create)")
+		help = flag.Bool("help", false, "Show Help")
+	)
+	flag.Parse()
+
+	args := flag.Args()
+	if *help || len(args) == 0 {
+		showHelp()
+		return
+	}
+
+	// 初始化日志
+	logger.Init(logger.DefaultConfig())
+
+	command := args[0]
+
+	// 创建迁移文件不需要数据库连接
+	if command == "create" {
+		if len(args) < 2 {
+			fmt.Println("Error: Migration name is required")
+			os.Exit(1)
+		}
+		handleCreate(*dir, args[1])
+		return
+	}
+
+	// 加载配置
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Error("Failed to load config", err)
+		os.Exit(1)
+	}
+
+	// 连接数据库，迁移工具自行控制迁移，不在连接时自动执行
+	db, err := database.OpenPostgres(&cfg.Database)
+	if err != nil {
+		logger.Error("Failed to connect to database", err)
+		os.Exit(1)
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		logger.Error("Failed to load migrations", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		handleUp(ctx, migrator, parseSteps(args, 0))
+	case "down":
+		handleDown(ctx, migrator, parseSteps(args, 1))
+	case "status":
+		handleStatus(ctx, migrator)
+	case "redo":
+		handleRedo(ctx, migrator)
+	case "reset":
+		handleReset(ctx, migrator)
+	default:
+		fmt.Printf("Error: Unsupported command '%s'\n", command)
+		showHelp()
+		os.Exit(1)
+	}
+}
+
+// parseSteps 解析命令的可选步数参数
+func parseSteps(args []string, defaultSteps int) int {
+	if len(args) < 2 {
+		return defaultSteps
+	}
+	steps, err := strconv.Atoi(args[1])
+	if err != nil || steps < 0 {
+		fmt.Printf("Error: Invalid steps '%s'\n", args[1])
+		os.Exit(1)
+	}
+	return steps
+}
+
+func handleUp(ctx context.Context, migrator *migrations.Migrator, steps int) {
+	count, err := migrator.Up(ctx, steps)
+	if err != nil {
+		fmt.Printf("Migrate up failed after %d migrations: %v\n", count, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Applied %d migrations\n", count)
+}
+
+func handleDown(ctx context.Context, migrator *migrations.Migrator, steps int) {
+	if steps == 0 {
+		fmt.Println("Error: Down steps must be at least 1")
+		os.Exit(1)
+	}
+	count, err := migrator.Down(ctx, steps)
+	if err != nil {
+		fmt.Printf("Migrate down failed after %d migrations: %v\n", count, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Rolled back %d migrations\n", count)
+}
+
+func handleStatus(ctx context.Context, migrator *migrations.Migrator) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		fmt.Printf("Failed to get migration status: %v\n", err)
+		os.Exit(1)
+	}
+
+	pending := 0
+	fmt.Printf("%-8s %-10s %-22s %s\n", "VERSION", "STATE", "APPLIED AT", "NAME")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.State == migrations.MigrationStatePending {
+			pending++
+		}
+		fmt.Printf("%-8s %-10s %-22s %s\n", status.Version, status.State, appliedAt, status.Name)
+	}
+	fmt.Printf("\n%d migrations, %d pending\n", len(statuses), pending)
+}
+
+func handleRedo(ctx context.Context, migrator *migrations.Migrator) {
+	migration, err := migrator.Redo(ctx)
+	if err != nil {
+		fmt.Printf("Redo failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Redone migration %s\n", migration.FileName())
+}
+
+func handleReset(ctx context.Context, migrator *migrations.Migrator) {
+	fmt.Println("Warning: This operation will roll back all migrations and delete all database tables and data!")
+
+	count, err := migrator.Reset(ctx)
+	if err != nil {
+		if errors.Is(err, migrations.ErrResetNotConfirmed) {
+			fmt.Printf("Reset refused: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Error("Reset failed", err)
+		fmt.Printf("Reset failed after %d migrations: %v\n", count, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Database reset successfully, %d migrations rolled back\n", count)
+}
+
+func handleCreate(dir, name string) {
+	upPath, downPath, err := migrations.CreateMigration(dir, name)
+	if err != nil {
+		fmt.Printf("Create failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s\n", upPath)
+	fmt.Printf("Created %s\n", downPath)
+}
+
+func showHelp() {
+	fmt.Printf(`TimeLocker Database Migration Tool
+
+Usage:
+  %s [options] <command> [args]
+
+Commands:
+  up [N]         Apply all pending migrations, or only the next N
+  down [N]       Roll back the last N applied migrations (default 1)
+  status         Show applied, pending, modified and missing migrations
+  redo           Roll back and re-apply the last applied migration
+  create <name>  Create the next numbered up/down migration files in -dir
+  reset          Roll back all migrations and delete all data (dangerous)
+
+Migrations:
+  Migrations are numbered <version>_<name>.up.sql / .down.sql files embedded in the
+  binary from pkg/database/migrations/sql; rebuild after creating or editing one.
+  The checksum of each applied up script is recorded in schema_migrations, and
+  migrating refuses to continue when an applied script has been modified. A
+  PostgreSQL advisory lock ensures only one migrator runs at a time; the server
+  applies pending migrations on startup using the same lock.
+
+Reset:
+  reset only runs when %s is set to the name of the target database.
+
+Options:
+  -dir=<path>   Migrations directory used by create (default pkg/database/migrations/sql)
+  -help         Display this help message
+
+Examples:
+  %s status
+  %s up
+  %s down 2
+  %s redo
+  %s create add_user_nickname
+  %s=timelocker_db %s reset
+
+`, os.Args[0], migrations.ResetConfirmEnv, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], migrations.ResetConfirmEnv, os.Args[0])
+}
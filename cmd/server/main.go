@@ -11,42 +11,66 @@ import (
 
 	"timelocker-backend/docs"
 	abiHandler "timelocker-backend/internal/api/abi"
+	adminHandler "timelocker-backend/internal/api/admin"
+	apiKeyHandler "timelocker-backend/internal/api/apikey"
+	auditHandler "timelocker-backend/internal/api/audit"
 	authHandler "timelocker-backend/internal/api/auth"
 	chainHandler "timelocker-backend/internal/api/chain"
 	emailHandler "timelocker-backend/internal/api/email"
 	flowHandler "timelocker-backend/internal/api/flow"
 	notificationHandler "timelocker-backend/internal/api/notification"
+	organizationHandler "timelocker-backend/internal/api/organization"
 	sponsorHandler "timelocker-backend/internal/api/sponsor"
 	timelockHandler "timelocker-backend/internal/api/timelock"
 
 	"timelocker-backend/internal/config"
 	abiRepo "timelocker-backend/internal/repository/abi"
+	adminRepo "timelocker-backend/internal/repository/admin"
+	apiKeyRepo "timelocker-backend/internal/repository/apikey"
+	auditRepo "timelocker-backend/internal/repository/audit"
+	backupRepo "timelocker-backend/internal/repository/backup"
 	chainRepo "timelocker-backend/internal/repository/chain"
 	emailRepo "timelocker-backend/internal/repository/email"
 
 	notificationRepo "timelocker-backend/internal/repository/notification"
+	organizationRepo "timelocker-backend/internal/repository/organization"
+	retentionRepo "timelocker-backend/internal/repository/retention"
 	safeRepo "timelocker-backend/internal/repository/safe"
 	scannerRepo "timelocker-backend/internal/repository/scanner"
+	sessionRepo "timelocker-backend/internal/repository/session"
 	sponsorRepo "timelocker-backend/internal/repository/sponsor"
 	timelockRepo "timelocker-backend/internal/repository/timelock"
 
 	userRepo "timelocker-backend/internal/repository/user"
 	abiService "timelocker-backend/internal/service/abi"
+	adminService "timelocker-backend/internal/service/admin"
+	apiKeyService "timelocker-backend/internal/service/apikey"
+	auditService "timelocker-backend/internal/service/audit"
 	authService "timelocker-backend/internal/service/auth"
+	backupService "timelocker-backend/internal/service/backup"
 	chainService "timelocker-backend/internal/service/chain"
 	emailService "timelocker-backend/internal/service/email"
 	flowService "timelocker-backend/internal/service/flow"
 	notificationService "timelocker-backend/internal/service/notification"
+	organizationService "timelocker-backend/internal/service/organization"
+	retentionService "timelocker-backend/internal/service/retention"
 	scannerService "timelocker-backend/internal/service/scanner"
 	sponsorService "timelocker-backend/internal/service/sponsor"
 	timelockService "timelocker-backend/internal/service/timelock"
 
+	"timelocker-backend/internal/middleware"
+
+	"timelocker-backend/pkg/crypto"
 	"timelocker-backend/pkg/database"
 
 	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/ratelimit"
+	"timelocker-backend/pkg/tracing"
 	"timelocker-backend/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	// swaggerFiles "github.com/swaggo/files"
 	// ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -61,6 +85,10 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description Personal API key with the scope required by the endpoint.
 
 // updateAllScannersStatusToPaused 更新所有扫链器状态为暂停
 func updateAllScannersStatusToPaused(ctx context.Context, progressRepo scannerRepo.ProgressRepository) error {
@@ -87,19 +115,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	// release模式下拒绝使用默认HS256密钥
+	if err := cfg.JWT.Validate(cfg.Server.Mode); err != nil {
+		logger.Error("Invalid jwt config: ", err)
+		os.Exit(1)
+	}
+
+	// 初始化链路追踪（exporter为none时不创建span）
+	shutdownTracing, err := tracing.Init(ctx, &cfg.Tracing)
+	if err != nil {
+		logger.Error("Failed to init tracing: ", err)
+		os.Exit(1)
+	}
+
+	// 加载通知密钥加密主密钥
+	secretKeyring, err := crypto.LoadSecretKeyring(&cfg.Secrets)
+	if err != nil {
+		logger.Error("Failed to load secret keyring: ", err)
+		os.Exit(1)
+	}
+	if !secretKeyring.Enabled() {
+		logger.Warn("Secret encryption key not configured, notification secrets will be stored in plaintext")
+	}
+
 	// 2. 连接数据库
 	db, err := database.NewPostgresConnection(&cfg.Database)
 	if err != nil {
 		logger.Error("Failed to connect to database: ", err)
 		os.Exit(1)
 	}
+	if tracing.Enabled(&cfg.Tracing) {
+		if err := db.Use(tracing.NewGormPlugin()); err != nil {
+			logger.Error("Failed to register gorm tracing plugin: ", err)
+			os.Exit(1)
+		}
+	}
 
-	// 3. 连接Redis
-	// redisClient, err := database.NewRedisConnection(&cfg.Redis)
-	// if err != nil {
-	// 	logger.Error("Failed to connect to Redis: ", err)
-	// 	os.Exit(1)
-	// }
+	// 3. 连接Redis（仅在访问令牌黑名单或限流使用Redis存储时需要）
+	var redisClient *redis.Client
+	if cfg.Session.DenylistBackend == "redis" || cfg.RateLimit.Backend == "redis" {
+		redisClient, err = database.NewRedisConnection(&cfg.Redis)
+		if err != nil {
+			logger.Error("Failed to connect to Redis: ", err)
+			os.Exit(1)
+		}
+		defer redisClient.Close()
+	}
 
 	// 4. 初始化仓库层
 	userRepository := userRepo.NewRepository(db)
@@ -109,7 +170,14 @@ func main() {
 	timelockRepository := timelockRepo.NewRepository(db)
 	emailRepository := emailRepo.NewEmailRepository(db)
 	notificationRepository := notificationRepo.NewRepository(db)
+	outboxRepository := notificationRepo.NewOutboxRepository(db)
+	digestRepository := notificationRepo.NewDigestRepository(db)
 	safeRepository := safeRepo.NewRepository(db)
+	sessionRepository := sessionRepo.NewRepository(db)
+	apiKeyRepository := apiKeyRepo.NewRepository(db)
+	organizationRepository := organizationRepo.NewRepository(db)
+	adminAuditRepository := adminRepo.NewAuditLogRepository(db)
+	auditRepository := auditRepo.NewRepository(db)
 
 	// 扫链相关仓库
 	progressRepository := scannerRepo.NewProgressRepository(db)
@@ -117,29 +185,84 @@ func main() {
 	flowRepository := scannerRepo.NewFlowRepository(db)
 
 	// 5. 初始化JWT管理器
-	jwtManager := utils.NewJWTManager(
-		cfg.JWT.Secret,
-		cfg.JWT.AccessExpiry,
-		cfg.JWT.RefreshExpiry,
-	)
+	jwtManager, err := utils.NewJWTManager(&cfg.JWT)
+	if err != nil {
+		logger.Error("Failed to initialize jwt manager: ", err)
+		os.Exit(1)
+	}
+	logger.Info("JWT manager initialized", "algorithm", jwtManager.SigningAlgorithm(), "active_key_id", cfg.JWT.ActiveKeyID)
+
+	// 访问令牌黑名单（登出和刷新令牌重放时撤销访问令牌）
+	var tokenDenylist authService.TokenDenylist
+	if cfg.Session.DenylistBackend == "redis" {
+		tokenDenylist = authService.NewRedisDenylist(redisClient)
+	} else {
+		tokenDenylist = authService.NewDatabaseDenylist(sessionRepository)
+	}
+	logger.Info("Token denylist initialized", "backend", cfg.Session.DenylistBackend)
 
 	// 6. 初始化服务层（注意：authSvc需要在RPC管理器启动后初始化）
-	abiSvc := abiService.NewService(abiRepository)
+	auditSvc := auditService.NewService(auditRepository, organizationRepository, userRepository)
+	abiSvc := abiService.NewService(abiRepository, organizationRepository, userRepository, auditSvc)
+	apiKeySvc := apiKeyService.NewService(apiKeyRepository)
+	organizationSvc := organizationService.NewService(organizationRepository, userRepository, auditSvc)
 	chainSvc := chainService.NewService(chainRepository)
 	sponsorSvc := sponsorService.NewService(sponsorRepository)
-	emailSvc := emailService.NewEmailService(emailRepository, chainRepository, timelockRepository, transactionRepository, cfg)
-	flowSvc := flowService.NewFlowService(flowRepository, timelockRepository)
-	notificationSvc := notificationService.NewNotificationService(notificationRepository, chainRepository, timelockRepository, transactionRepository, cfg)
+	emailSvc := emailService.NewEmailService(emailRepository, chainRepository, timelockRepository, transactionRepository, organizationRepository, auditSvc, cfg)
+	flowSvc := flowService.NewFlowService(flowRepository, timelockRepository, organizationRepository, userRepository)
+	notificationSvc := notificationService.NewNotificationService(notificationRepository, outboxRepository, chainRepository, timelockRepository, transactionRepository, organizationRepository, userRepository, auditSvc, cfg, secretKeyring)
+
+	// 备份管理器，定时备份和历史数据归档共用
+	var backupManager *database.BackupManager
+	if cfg.Backup.Schedule.Enabled || cfg.Retention.Enabled {
+		backupEncryption, err := crypto.LoadBackupEncryption(&cfg.Backup.Encryption)
+		if err != nil {
+			logger.Error("Failed to load backup encryption: ", err)
+			os.Exit(1)
+		}
+		backupStorage, err := database.NewBackupStorage(&cfg.Backup.Storage)
+		if err != nil {
+			logger.Error("Failed to create backup storage: ", err)
+			os.Exit(1)
+		}
+		backupManager = database.NewBackupManager(db, secretKeyring, backupEncryption, backupStorage)
+	}
+
+	// 定时备份（可选），失败时通过平台管理员的通知渠道告警
+	var backupScheduler *backupService.Scheduler
+	if cfg.Backup.Schedule.Enabled {
+		backupScheduler = backupService.NewScheduler(&cfg.Backup, backupManager, backupRepo.NewRunRepository(db), notificationSvc)
+	}
+
+	// 历史数据清理（可选），超过保留期的交易和日志按策略归档后删除
+	var retentionJanitor *retentionService.Janitor
+	if cfg.Retention.Enabled {
+		retentionJanitor = retentionService.NewJanitor(&cfg.Retention, retentionRepo.NewRepository(db), backupManager)
+	}
 
 	// 7. 设置Gin和路由
 	gin.SetMode(cfg.Server.Mode)
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Error("Failed to set trusted proxies: ", err)
+		os.Exit(1)
+	}
+
+	// 请求指标在CORS和限流之前记录，被拒绝的请求也会计入
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.MetricsMiddleware())
+	}
+
+	// 请求span同样在CORS和限流之前创建，下游服务和仓库的span挂在请求span之下
+	if tracing.Enabled(&cfg.Tracing) {
+		router.Use(middleware.TracingMiddleware())
+	}
 
 	// 8. 添加CORS中间件
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -149,6 +272,18 @@ func main() {
 		c.Next()
 	})
 
+	// 添加限流中间件（按客户端IP，认证后再按钱包地址）
+	if cfg.RateLimit.Enabled {
+		var limiter ratelimit.Limiter
+		if cfg.RateLimit.Backend == "redis" {
+			limiter = ratelimit.NewRedisLimiter(redisClient)
+		} else {
+			limiter = ratelimit.NewMemoryLimiter()
+		}
+		router.Use(middleware.RateLimitMiddleware(middleware.NewRateLimiter(&cfg.RateLimit, limiter)))
+		logger.Info("Rate limiter initialized", "backend", cfg.RateLimit.Backend, "routes", len(cfg.RateLimit.Routes))
+	}
+
 	// 9. 创建API路由组
 	v1 := router.Group("/api/v1")
 	{
@@ -166,7 +301,14 @@ func main() {
 	// router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	// 健康检查端点
 	router.GET("/api/v1/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		response := gin.H{"status": "ok"}
+		if backupScheduler != nil {
+			response["backup"] = backupScheduler.Health()
+		}
+		if retentionJanitor != nil {
+			response["retention"] = retentionJanitor.Health()
+		}
+		c.JSON(http.StatusOK, response)
 	})
 
 	// 11. 启动RPC管理器
@@ -186,8 +328,6 @@ func main() {
 		transactionRepository,
 		flowRepository,
 		rpcManager,
-		emailSvc,
-		notificationSvc,
 	)
 	if err := scannerManager.Start(ctx); err != nil {
 		logger.Error("Failed to start scanner manager", err)
@@ -195,13 +335,51 @@ func main() {
 		logger.Info("Scanner Manager started successfully")
 	}
 
+	// 启动通知发件箱投递worker（扫链只写发件箱，由worker异步发送邮件和渠道通知）
+	outboxWorker := notificationService.NewOutboxWorker(&cfg.Notification, outboxRepository, emailSvc, notificationSvc)
+	outboxWorker.Start(ctx)
+
+	// 启动通知摘要调度（为daily/weekly摘要模式的渠道配置和邮箱按周期汇总发送）
+	digestScheduler := notificationService.NewDigestScheduler(cfg, digestRepository, chainRepository, secretKeyring)
+	digestScheduler.Start(ctx)
+
+	// 启动定时备份调度
+	if backupScheduler != nil {
+		if err := backupScheduler.Start(ctx); err != nil {
+			logger.Error("Failed to start backup scheduler: ", err)
+			backupScheduler = nil
+		}
+	}
+
+	// 启动历史数据清理调度
+	if retentionJanitor != nil {
+		if err := retentionJanitor.Start(ctx); err != nil {
+			logger.Error("Failed to start retention janitor: ", err)
+			retentionJanitor = nil
+		}
+	}
+
 	// 13. 初始化需要RPC管理器的服务和处理器
-	authSvc := authService.NewService(userRepository, safeRepository, rpcManager, jwtManager)
-	timelockSvc := timelockService.NewService(timelockRepository, chainRepository, flowRepository, rpcManager, cfg)
+	authSvc := authService.NewService(userRepository, safeRepository, chainRepository, sessionRepository, apiKeyRepository, rpcManager, jwtManager, tokenDenylist, &cfg.SIWE, auditSvc)
+	timelockSvc := timelockService.NewService(timelockRepository, chainRepository, flowRepository, organizationRepository, userRepository, rpcManager, auditSvc, cfg)
+	adminSvc := adminService.NewService(chainRepository, sponsorRepository, abiRepository, userRepository, adminAuditRepository, scannerManager, cfg)
 
 	// 14. 初始化处理器并注册路由
 	authHandler := authHandler.NewHandler(authSvc)
 	authHandler.RegisterRoutes(v1)
+	authHandler.RegisterWellKnownRoutes(router)
+
+	apiKeyHdl := apiKeyHandler.NewHandler(apiKeySvc, authSvc)
+	apiKeyHdl.RegisterRoutes(v1)
+
+	organizationHdl := organizationHandler.NewHandler(organizationSvc, authSvc)
+	organizationHdl.RegisterRoutes(v1)
+
+	adminHdl := adminHandler.NewHandler(adminSvc, auditSvc, authSvc)
+	adminHdl.RegisterRoutes(v1)
+
+	auditHdl := auditHandler.NewHandler(auditSvc, authSvc)
+	auditHdl.RegisterRoutes(v1)
 
 	abiHandler := abiHandler.NewHandler(abiSvc, authSvc)
 	abiHandler.RegisterRoutes(v1)
@@ -266,6 +444,35 @@ func main() {
 		}
 	}()
 
+	// 启动登录会话清理定时任务（过期会话和访问令牌黑名单）
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer logger.Info("Session cleanup task stopped")
+
+		ticker := time.NewTicker(cfg.Session.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := authSvc.CleanupSessions(ctx); err != nil {
+					logger.Error("Failed to clean expired sessions", err)
+				}
+			}
+		}
+	}()
+
+	// 启动Prometheus指标服务（独立端口）
+	var metricsServer *metrics.Server
+	if cfg.Metrics.Enabled {
+		metrics.SetFlowStatusSource(flowRepository.CountFlowsByStatus)
+		metricsServer = metrics.NewServer(&cfg.Metrics)
+		metricsServer.Start()
+	}
+
 	// 17. 启动HTTP服务器
 	addr := ":" + cfg.Server.Port
 	srv := &http.Server{
@@ -299,6 +506,11 @@ func main() {
 	} else {
 		logger.Info("HTTP server stopped")
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Metrics server shutdown error: ", err)
+		}
+	}
 	shutdownCancel()
 
 	// Step 2: 取消context，通知所有扫链组件停止
@@ -309,11 +521,25 @@ func main() {
 	logger.Info("Stopping scanner manager...")
 	scannerManager.Stop()
 
-	// Step 4: 停止RPC管理器
+	// Step 4: 停止通知发件箱投递worker和摘要调度
+	logger.Info("Stopping notification outbox workers...")
+	outboxWorker.Stop()
+	logger.Info("Stopping notification digest scheduler...")
+	digestScheduler.Stop()
+	if backupScheduler != nil {
+		logger.Info("Stopping backup scheduler...")
+		backupScheduler.Stop()
+	}
+	if retentionJanitor != nil {
+		logger.Info("Stopping retention janitor...")
+		retentionJanitor.Stop()
+	}
+
+	// Step 5: 停止RPC管理器
 	logger.Info("Stopping RPC manager...")
 	rpcManager.Stop()
 
-	// Step 5: 确保所有扫链器状态已更新为paused（兜底保护）
+	// Step 6: 确保所有扫链器状态已更新为paused（兜底保护）
 	logger.Info("Ensuring all scanner status updated to paused...")
 	shutdownCtx, shutdownCancel = context.WithTimeout(context.Background(), 3*time.Second)
 	if err := updateAllScannersStatusToPaused(shutdownCtx, progressRepository); err != nil {
@@ -321,7 +547,7 @@ func main() {
 	}
 	shutdownCancel()
 
-	// Step 6: 等待所有goroutine结束
+	// Step 7: 等待所有goroutine结束
 	logger.Info("Waiting for all goroutines to finish...")
 	done := make(chan struct{})
 	go func() {
@@ -335,4 +561,11 @@ func main() {
 	case <-time.After(15 * time.Second):
 		logger.Error("Timeout waiting for services to stop, forcing exit", nil)
 	}
+
+	// Step 8: 导出剩余的span
+	shutdownCtx, shutdownCancel = context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Tracing shutdown error: ", err)
+	}
+	shutdownCancel()
 }
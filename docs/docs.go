@@ -15,14 +15,37 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "返回当前所有可用于验证访问令牌和刷新令牌的公钥（RFC 7517），令牌头中的kid对应公钥的kid。其他服务可通过此端点验证本服务签发的令牌（同时需校验iss和type=access）。密钥轮换期间会同时返回新旧公钥。仅使用HS256密钥签名时返回空列表。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "获取令牌验证公钥（JWKS）",
+                "responses": {
+                    "200": {
+                        "description": "公钥集合",
+                        "schema": {
+                            "$ref": "#/definitions/types.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/abi": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "用户创建新的智能合约ABI。系统会验证ABI格式的正确性。每个用户在同一名称下只能创建一个ABI。名称长度1-200；描述≤500。",
+                "description": "用户创建新的智能合约ABI。系统会验证ABI格式的正确性。每个用户在同一名称下只能创建一个ABI。传入organization_id时创建到组织ABI库（需要组织编辑者角色，组织内名称唯一）。名称长度1-200；描述≤500。",
                 "consumes": [
                     "application/json"
                 ],
@@ -161,9 +184,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "删除用户创建的ABI。用户只能删除自己创建的ABI，不能删除平台共享的ABI。删除操作是不可逆的。",
+                "description": "删除用户创建的ABI。用户只能删除自己创建的ABI，组织ABI需要组织编辑者角色，不能删除平台共享的ABI。删除操作是不可逆的。",
                 "consumes": [
                     "application/json"
                 ],
@@ -290,9 +316,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "根据ABI ID获取详细信息。用户只能访问自己创建的ABI或平台共享的ABI。",
+                "description": "根据ABI ID获取详细信息。用户只能访问自己创建的ABI、所在组织的ABI或平台共享的ABI。",
                 "consumes": [
                     "application/json"
                 ],
@@ -431,9 +460,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "获取用户可访问的ABI列表，包括用户自己创建的ABI和平台共享的ABI（合并在一起，利用is_shared字段区分，地址全0也表示共享ABI）。",
+                "description": "获取用户可访问的ABI列表，包括用户自己创建的ABI、用户所在组织的ABI（organization_id非0）和平台共享的ABI（合并在一起，利用is_shared字段区分，地址全0也表示共享ABI）。",
                 "consumes": [
                     "application/json"
                 ],
@@ -507,9 +539,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "更新用户创建的ABI。系统会重新验证ABI格式。用户只能更新自己创建的ABI。名称长度1-200；描述≤500。",
+                "description": "更新用户创建的ABI。系统会重新验证ABI格式。用户只能更新自己创建的ABI，组织ABI需要组织编辑者角色。名称长度1-200；描述≤500。",
                 "consumes": [
                     "application/json"
                 ],
@@ -684,6 +719,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
                 "description": "验证智能合约ABI的格式正确性，返回详细的验证结果。此接口可用于在创建或更新ABI前进行预验证。",
@@ -786,6 +824,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "429": {
+                        "description": "请求过于频繁（RATE_LIMITED），响应头Retry-After为需等待的秒数",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -807,9 +863,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/nonce": {
+        "/api/v1/admin/abis/create": {
             "post": {
-                "description": "获取用于钱包签名认证的随机nonce。前端需要先调用此接口获取nonce和消息，然后让用户对消息进行签名，最后调用wallet-connect接口完成认证。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建平台共享ABI，ABI内容需通过格式校验，名称在共享ABI中唯一",
                 "consumes": [
                     "application/json"
                 ],
@@ -817,23 +878,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "获取认证nonce",
+                "summary": "创建共享ABI（管理员）",
                 "parameters": [
                     {
-                        "description": "获取nonce请求",
+                        "description": "创建共享ABI请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetNonceRequest"
+                            "$ref": "#/definitions/types.CreateSharedABIRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取nonce和签名消息",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -843,7 +904,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.GetNonceResponse"
+                                            "$ref": "#/definitions/types.ABI"
                                         }
                                     }
                                 }
@@ -851,7 +912,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或ABI格式无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -868,8 +929,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -885,49 +946,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/profile": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取当前认证用户的详细资料信息，包括钱包地址、创建时间等。需要有效的JWT令牌。",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "获取用户资料",
-                "responses": {
-                    "200": {
-                        "description": "成功获取用户资料",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.UserProfile"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "401": {
-                        "description": "未认证或令牌无效",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -944,8 +965,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "409": {
+                        "description": "共享ABI名称已存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -983,9 +1004,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/refresh-token": {
+        "/api/v1/admin/abis/delete": {
             "post": {
-                "description": "使用刷新令牌获取新的访问令牌。当访问令牌过期时，前端可以使用此接口通过刷新令牌重新获取新的访问令牌和刷新令牌，无需重新进行钱包签名认证。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除平台共享ABI，只能删除共享ABI",
                 "consumes": [
                     "application/json"
                 ],
@@ -993,23 +1019,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "刷新访问令牌",
+                "summary": "删除共享ABI（管理员）",
                 "parameters": [
                     {
-                        "description": "刷新令牌请求体",
+                        "description": "删除共享ABI请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.RefreshTokenRequest"
+                            "$ref": "#/definitions/types.DeleteABIRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "刷新成功，返回新的访问令牌和刷新令牌",
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -1018,16 +1050,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.WalletConnectResponse"
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1044,8 +1076,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "刷新令牌无效或已过期",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1063,7 +1095,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "用户不存在",
+                        "description": "共享ABI不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -1101,9 +1133,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/wallet-connect": {
+        "/api/v1/admin/abis/list": {
             "post": {
-                "description": "通过钱包进行用户认证。EOA钱包：1.先调用/auth/nonce获取随机nonce和消息 2.让用户对消息进行签名 3.调用此接口完成认证。Safe钱包：直接提供Safe地址和chain_id即可，系统会验证地址是否为有效的Safe合约。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取平台共享ABI列表，共享ABI对所有用户可见",
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,23 +1148,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "钱包连接认证（支持EOA和Safe钱包）",
-                "parameters": [
-                    {
-                        "description": "钱包连接认证请求体。EOA钱包需要nonce、message和signature。Safe钱包只需要wallet_address、wallet_type='safe'和chain_id",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.WalletConnectRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "获取共享ABI列表（管理员）",
                 "responses": {
                     "200": {
-                        "description": "认证成功，返回访问令牌和用户信息",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1137,15 +1163,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.WalletConnectResponse"
+                                            "$ref": "#/definitions/types.ABIListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_WALLET_ADDRESS: 钱包地址格式无效; MISSING_REQUIRED_FIELDS: EOA钱包缺少必需字段; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1162,8 +1188,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "认证失败 - INVALID_SIGNATURE: 签名验证失败; SIGNATURE_RECOVERY_FAILED: 无法从签名恢复地址; INVALID_NONCE: nonce无效或已过期; NONCE_ALREADY_USED: nonce已被使用",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1181,7 +1207,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误 - INTERNAL_ERROR: 服务器内部错误; DATABASE_ERROR: 数据库操作失败; TOKEN_GENERATION_FAILED: JWT令牌生成失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -1201,9 +1227,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/chain/chainid": {
+        "/api/v1/admin/abis/update": {
             "post": {
-                "description": "根据指定的链ID（如1代表以太坊主网）获取单个支持链的详细信息，包括链名称、原生代币、Logo等基本信息。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新平台共享ABI的名称、内容和描述，只能更新共享ABI",
                 "consumes": [
                     "application/json"
                 ],
@@ -1211,23 +1242,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chain"
+                    "Admin"
                 ],
-                "summary": "根据ChainID获取链信息",
+                "summary": "更新共享ABI（管理员）",
                 "parameters": [
                     {
-                        "description": "按ChainID获取请求体",
+                        "description": "更新共享ABI请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetChainByChainIDRequest"
+                            "$ref": "#/definitions/types.UpdateABIWithIDRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取链信息",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1237,7 +1268,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.SupportChainResponse"
+                                            "$ref": "#/definitions/types.ABI"
                                         }
                                     }
                                 }
@@ -1245,7 +1276,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或ABI格式无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1262,8 +1293,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "链不存在",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1280,8 +1311,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1297,36 +1328,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/chain/list": {
-            "post": {
-                "description": "获取所有支持的区块链列表，可根据是否测试网和是否激活状态进行筛选。返回链的详细信息包括名称、链ID、原生代币、Logo等信息。",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Chain"
-                ],
-                "summary": "获取支持的区块链列表",
-                "parameters": [
-                    {
-                        "description": "筛选参数",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/types.GetSupportChainsRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "成功获取支持链列表",
+                    },
+                    "404": {
+                        "description": "共享ABI不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -1335,16 +1339,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.GetSupportChainsResponse"
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "409": {
+                        "description": "共享ABI名称已存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -1382,9 +1386,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/chain/wallet-config": {
+        "/api/v1/admin/audit-events/list": {
             "post": {
-                "description": "获取指定链ID的钱包插件配置数据，包括chainId、chainName、nativeCurrency、rpcUrls、blockExplorerUrls等信息，用于帮助用户在钱包插件中添加该链。",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页查询全平台用户的状态变更操作和管理员后台操作，scope区分记录来源（user/admin），可按来源、操作者地址、组织、资源类型、资源ID、操作类型和时间范围筛选；按组织筛选时不包含管理员操作。请求体可为空。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1392,23 +1401,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chain"
+                    "Admin"
                 ],
-                "summary": "获取钱包插件添加链的配置数据",
+                "summary": "查询操作记录（管理员）",
                 "parameters": [
                     {
-                        "description": "获取钱包配置请求体",
+                        "description": "筛选和分页参数",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetWalletChainConfigRequest"
+                            "$ref": "#/definitions/types.GetAdminAuditEventsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取钱包配置数据",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1418,7 +1426,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.WalletChainConfig"
+                                            "$ref": "#/definitions/types.GetAdminAuditEventsResponse"
                                         }
                                     }
                                 }
@@ -1443,8 +1451,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "链不存在",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1461,8 +1469,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1478,18 +1486,36 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/emails": {
-            "post": {
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/audit-logs/list": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取当前用户的所有邮箱地址",
+                "description": "分页查询平台管理员的操作记录，按时间倒序，可按管理员地址和资源类型筛选。请求体可为空。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1497,22 +1523,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Email"
+                    "Admin"
                 ],
-                "summary": "获取用户邮箱列表",
+                "summary": "查询管理员审计日志（管理员）",
                 "parameters": [
                     {
-                        "description": "分页参数",
+                        "description": "筛选和分页参数",
                         "name": "request",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/types.GetEmailsRequest"
+                            "$ref": "#/definitions/types.GetAdminAuditLogsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1522,7 +1548,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.EmailListResponse"
+                                            "$ref": "#/definitions/types.GetAdminAuditLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
                                         }
                                     }
                                 }
@@ -1530,7 +1574,25 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权",
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1568,14 +1630,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/emails/delete": {
+        "/api/v1/admin/chains/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "删除指定的邮箱地址",
+                "description": "新增支持链。RPC模板需包含 {API_KEY} 占位符；链处于激活且启用RPC状态时立即热启动该链的扫描器，扫描器启动失败时配置仍会保存并在scanner_error中返回原因。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1583,29 +1645,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Email"
+                    "Admin"
                 ],
-                "summary": "删除邮箱",
+                "summary": "创建支持链（管理员）",
                 "parameters": [
                     {
-                        "description": "删除邮箱请求（包含ID）",
+                        "description": "创建支持链请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.DeleteEmailRequest"
+                            "$ref": "#/definitions/types.CreateChainRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.AdminChainResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或RPC模板无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1623,7 +1697,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权",
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1641,7 +1715,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "无权限操作该邮箱",
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1658,8 +1732,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "邮箱不存在",
+                    "409": {
+                        "description": "链名称或ChainID已存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -1697,14 +1771,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/emails/remark": {
+        "/api/v1/admin/chains/delete": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新指定邮箱的备注信息",
+                "description": "删除支持链并停止其扫描器。链上仍有导入的timelock合约时拒绝删除，此时应改为停用。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1712,23 +1786,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Email"
+                    "Admin"
                 ],
-                "summary": "更新邮箱备注",
+                "summary": "删除支持链（管理员）",
                 "parameters": [
                     {
-                        "description": "更新备注请求（包含ID）",
+                        "description": "删除支持链请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateEmailRemarkWithIDRequest"
+                            "$ref": "#/definitions/types.DeleteChainRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/types.APIResponse"
                         }
@@ -1752,7 +1826,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权",
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1770,7 +1844,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "无权限操作该邮箱",
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1788,7 +1862,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "邮箱不存在",
+                        "description": "链不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -1805,8 +1879,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "参数校验失败",
+                    "409": {
+                        "description": "链上仍有timelock合约",
                         "schema": {
                             "allOf": [
                                 {
@@ -1844,14 +1918,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/emails/send-verification": {
+        "/api/v1/admin/chains/list": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "基于邮箱发送验证码。后端会自动创建/复用未验证记录，并允许更新备注。email 必填，remark 最长200字符。",
+                "description": "获取数据库中的全部支持链配置，包括停用的链，RPC URL和区块浏览器URL保持JSON数组字符串原样返回。请求体可为空。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1859,47 +1933,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Email"
+                    "Admin"
                 ],
-                "summary": "发送验证码",
+                "summary": "获取全部支持链（管理员）",
                 "parameters": [
                     {
-                        "description": "发送验证码请求（email 必填，remark 可选，最长200）",
+                        "description": "筛选条件",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.SendVerificationCodeRequest"
+                            "$ref": "#/definitions/types.GetSupportChainsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误（缺少email）",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1908,16 +1957,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
+                                        "data": {
+                                            "$ref": "#/definitions/types.AdminChainListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "邮箱不存在",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -1934,8 +1983,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "参数校验失败（INVALID_EMAIL_FORMAT / INVALID_REMARK）",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -1952,8 +2001,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "429": {
-                        "description": "发送过于频繁",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -1991,14 +2040,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/emails/verify": {
+        "/api/v1/admin/chains/set-status": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "使用验证码验证邮箱地址。email 必填，code 为6位数字。",
+                "description": "设置链的激活状态。启用后立即热启动该链的扫描器，停用后立即停止扫描器并关闭RPC连接，无需重启服务。",
                 "consumes": [
                     "application/json"
                 ],
@@ -2006,25 +2055,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Email"
+                    "Admin"
                 ],
-                "summary": "验证邮箱",
+                "summary": "启用/停用支持链（管理员）",
                 "parameters": [
                     {
-                        "description": "验证邮箱请求（email+code）",
+                        "description": "启用/停用请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.VerifyEmailRequest"
+                            "$ref": "#/definitions/types.SetChainStatusRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "设置成功",
                         "schema": {
-                            "$ref": "#/definitions/types.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.AdminChainResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2046,7 +2107,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权",
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2063,8 +2124,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "邮箱不存在",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2081,8 +2142,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "验证码无效或已过期 / 参数校验失败（INVALID_EMAIL_FORMAT / INVALID_CODE_FORMAT）",
+                    "404": {
+                        "description": "链不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -2120,14 +2181,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/flows/list": {
+        "/api/v1/admin/chains/update": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取与用户相关的timelock流程列表，包括发起的和有权限管理的",
+                "description": "按字段更新支持链配置，未传字段保持不变。修改Alchemy RPC模板、测试网标记或RPC开关后会重建该链的扫描器和RPC连接。",
                 "consumes": [
                     "application/json"
                 ],
@@ -2135,22 +2196,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Flow"
+                    "Admin"
                 ],
-                "summary": "获取与用户相关的流程列表",
+                "summary": "更新支持链（管理员）",
                 "parameters": [
                     {
-                        "description": "查询参数",
+                        "description": "更新支持链请求",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetCompoundFlowListRequest"
+                            "$ref": "#/definitions/types.UpdateChainRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2160,7 +2222,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.GetCompoundFlowListResponse"
+                                            "$ref": "#/definitions/types.AdminChainResponse"
                                         }
                                     }
                                 }
@@ -2168,7 +2230,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或RPC模板无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2203,6 +2265,42 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "非平台管理员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "链不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2224,14 +2322,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/flows/list/count": {
+        "/api/v1/admin/sponsors/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取与用户相关的timelock流程数量统计，包括发起的和有权限管理的，按状态分组统计",
+                "description": "创建赞助方或生态伙伴，创建后默认激活并出现在公开列表中",
                 "consumes": [
                     "application/json"
                 ],
@@ -2239,22 +2337,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Flow"
+                    "Admin"
                 ],
-                "summary": "获取与用户相关的流程数量统计",
+                "summary": "创建赞助方（管理员）",
                 "parameters": [
                     {
-                        "description": "查询参数",
+                        "description": "创建赞助方请求",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetCompoundFlowListCountRequest"
+                            "$ref": "#/definitions/types.CreateSponsorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2264,7 +2363,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.GetCompoundFlowListCountResponse"
+                                            "$ref": "#/definitions/types.Sponsor"
                                         }
                                     }
                                 }
@@ -2307,8 +2406,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2324,55 +2423,66 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/flows/transaction/detail": {
-            "post": {
-                "description": "根据交易哈希和标准获取交易详情。standard 仅支持 compound/openzeppelin；tx_hash 必须为 0x 开头的64位十六进制。",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/sponsors/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除赞助方或生态伙伴；仅需临时下线时建议通过更新接口将is_active设为false",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Flow"
+                    "Admin"
                 ],
-                "summary": "获取交易详情",
+                "summary": "删除赞助方（管理员）",
                 "parameters": [
                     {
-                        "description": "请求体",
+                        "description": "删除赞助方请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetTransactionDetailRequest"
+                            "$ref": "#/definitions/types.DeleteSponsorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.GetTransactionDetailResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/types.APIResponse"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误（INVALID_STANDARD / INVALID_TX_HASH）",
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2389,8 +2499,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "交易不存在",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2407,8 +2517,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2424,44 +2534,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/notifications/configs": {
-            "post": {
-                "description": "获取当前用户的所有通知渠道配置，如果用户没有任何配置则返回空列表",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Notification"
-                ],
-                "summary": "获取所有通知配置",
-                "responses": {
-                    "200": {
-                        "description": "获取成功，返回所有配置或空列表",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.NotificationConfigListResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "401": {
-                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                    "404": {
+                        "description": "赞助方不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -2479,7 +2554,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误 - INTERNAL_ERROR: 获取配置失败; DATABASE_ERROR: 数据库访问失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2499,9 +2574,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/notifications/create": {
+        "/api/v1/admin/sponsors/list": {
             "post": {
-                "description": "为当前用户创建新的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取赞助方和生态伙伴，包括未激活的记录，按排序权重降序。请求体可为空。",
                 "consumes": [
                     "application/json"
                 ],
@@ -2509,23 +2589,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "Admin"
                 ],
-                "summary": "创建通知配置",
+                "summary": "获取赞助方列表（管理员）",
                 "parameters": [
                     {
-                        "description": "创建请求",
+                        "description": "筛选和分页参数",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateNotificationRequest"
+                            "$ref": "#/definitions/types.GetSponsorsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2535,7 +2614,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/types.GetSponsorsResponse"
                                         }
                                     }
                                 }
@@ -2543,7 +2622,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; MISSING_TELEGRAM_FIELDS: 缺少telegram必填字段; MISSING_WEBHOOK_URL: 缺少webhook_url字段; MISSING_REQUIRED_FIELDS: 缺少必填字段",
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2561,7 +2640,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2578,8 +2657,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "409": {
-                        "description": "配置冲突 - CONFIG_ALREADY_EXISTS: 同名配置已存在",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2597,7 +2676,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误 - INTERNAL_ERROR: 创建配置失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2617,9 +2696,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/notifications/delete": {
+        "/api/v1/admin/sponsors/reorder": {
             "post": {
-                "description": "删除当前用户的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在同一事务中设置多个赞助方的排序权重（数值越大越靠前），任一赞助方不存在时整体不生效",
                 "consumes": [
                     "application/json"
                 ],
@@ -2627,23 +2711,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "Admin"
                 ],
-                "summary": "删除通知配置",
+                "summary": "批量调整赞助方排序（管理员）",
                 "parameters": [
                     {
-                        "description": "删除请求",
+                        "description": "排序请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.DeleteNotificationRequest"
+                            "$ref": "#/definitions/types.ReorderSponsorsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "调整成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2652,16 +2742,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object"
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2678,8 +2768,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2697,7 +2787,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在",
+                        "description": "赞助方不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -2715,7 +2805,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误 - INTERNAL_ERROR: 删除配置失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2735,9 +2825,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/notifications/update": {
+        "/api/v1/admin/sponsors/update": {
             "post": {
-                "description": "更新当前用户的通知配置, 如果不需要更新某个字段, 可以不传该字段, 但至少传一个字段",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按字段更新赞助方信息，可同时调整排序权重和激活状态，未传字段保持不变",
                 "consumes": [
                     "application/json"
                 ],
@@ -2745,17 +2840,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "Admin"
                 ],
-                "summary": "更新通知配置",
+                "summary": "更新赞助方（管理员）",
                 "parameters": [
                     {
-                        "description": "更新请求",
+                        "description": "更新赞助方请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateNotificationRequest"
+                            "$ref": "#/definitions/types.UpdateSponsorWithIDRequest"
                         }
                     }
                 ],
@@ -2771,7 +2866,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/types.Sponsor"
                                         }
                                     }
                                 }
@@ -2779,7 +2874,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; NO_FIELDS_TO_UPDATE: 至少需要提供一个字段进行更新",
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2797,7 +2892,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -2814,8 +2909,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在",
+                    "403": {
+                        "description": "非平台管理员",
                         "schema": {
                             "allOf": [
                                 {
@@ -2832,8 +2927,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误 - INTERNAL_ERROR: 更新配置失败",
+                    "404": {
+                        "description": "赞助方不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -2849,44 +2944,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/sponsors/public": {
-            "post": {
-                "description": "获取所有激活的赞助方和生态伙伴信息，用于在前端展示。返回的数据按照排序权重和创建时间排序。",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sponsors"
-                ],
-                "summary": "获取公开的赞助方和生态伙伴列表",
-                "responses": {
-                    "200": {
-                        "description": "成功获取赞助方和生态伙伴列表",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/types.GetPublicSponsorsResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
                     "500": {
-                        "description": "获取赞助方列表失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -2906,14 +2966,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/timelock/create-or-import": {
+        "/api/v1/api-keys/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建新的或导入已存在的timelock合约记录。系统会从链上读取合约数据并验证其是否为有效的timelock合约。支持Compound和OpenZeppelin两种标准。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "description": "为当前用户创建用于程序化访问的API Key。明文密钥仅在创建时返回一次，服务端只保存哈希。请求时通过X-API-Key请求头携带。可选权限范围：flows:read, timelocks:read, timelocks:write, abi:read, abi:write, notifications:manage, emails:manage（写权限包含同一资源的读权限）。Safe会话创建的API Key只能访问会话绑定的链。",
                 "consumes": [
                     "application/json"
                 ],
@@ -2921,23 +2981,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
+                    "API Key"
                 ],
-                "summary": "创建或导入timelock合约记录",
+                "summary": "创建个人API Key",
                 "parameters": [
                     {
-                        "description": "创建或导入timelock合约的请求体（地址从鉴权获取）",
+                        "description": "创建API Key请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateOrImportTimelockContractRequest"
+                            "$ref": "#/definitions/types.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功创建或导入timelock合约记录",
+                        "description": "创建成功，返回明文密钥",
                         "schema": {
                             "allOf": [
                                 {
@@ -2947,7 +3007,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/types.CreateAPIKeyResponse"
                                         }
                                     }
                                 }
@@ -2955,7 +3015,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数错误; INVALID_SCOPE: 权限范围无效; API_KEY_LIMIT_EXCEEDED: 有效API Key数量已达上限",
                         "schema": {
                             "allOf": [
                                 {
@@ -2990,8 +3050,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "409": {
-                        "description": "timelock合约已存在",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3007,9 +3067,31 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    },
-                    "422": {
-                        "description": "参数校验失败",
+                    }
+                }
+            }
+        },
+        "/api/v1/api-keys/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户的所有API Key（不含明文密钥），包括权限范围、过期时间、最后使用时间和撤销状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Key"
+                ],
+                "summary": "获取个人API Key列表",
+                "responses": {
+                    "200": {
+                        "description": "成功获取API Key列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -3018,16 +3100,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
+                                        "data": {
+                                            "$ref": "#/definitions/types.APIKeyListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -3043,18 +3125,36 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
         },
-        "/api/v1/timelock/delete": {
+        "/api/v1/api-keys/revoke": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "硬删除指定的timelock合约记录。只有合约的创建者/导入者才能删除合约记录。删除操作是硬删除，数据从数据库中删除。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "description": "撤销当前用户的指定API Key，撤销后立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -3062,23 +3162,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
+                    "API Key"
                 ],
-                "summary": "删除timelock合约记录",
+                "summary": "撤销个人API Key",
                 "parameters": [
                     {
-                        "description": "删除请求体（地址从鉴权获取）",
+                        "description": "撤销API Key请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.DeleteTimeLockRequest"
+                            "$ref": "#/definitions/types.RevokeAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功删除timelock合约记录",
+                        "description": "撤销成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3087,16 +3193,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object"
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -3113,8 +3219,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未认证或令牌无效",
+                    "404": {
+                        "description": "API Key不存在或已撤销",
                         "schema": {
                             "allOf": [
                                 {
@@ -3131,8 +3237,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "无权访问此timelock合约",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3148,9 +3254,59 @@ const docTemplate = `{
                                 }
                             ]
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/audit/events/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页查询当前钱包发起的状态变更操作（timelock导入/删除、通知配置、邮箱、ABI、组织等），按时间倒序，包含变更前后的状态快照。actor_address参数在此接口中无效。请求体可为空。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "查询我的操作记录",
+                "parameters": [
+                    {
+                        "description": "筛选和分页参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetAuditEventsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetAuditEventsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "404": {
-                        "description": "timelock合约不存在",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3167,8 +3323,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "参数校验失败",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -3206,14 +3362,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/timelock/detail": {
+        "/api/v1/audit/organization-events/list": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取指定timelock合约的完整详细信息，包括合约的基本信息、治理参数以及用户权限信息。只有具有相应权限的用户才能查看详细信息。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "description": "分页查询组织资源上的全部状态变更操作，可按操作者地址、资源类型、资源ID、操作类型和时间范围筛选，仅组织所有者可查看",
                 "consumes": [
                     "application/json"
                 ],
@@ -3221,23 +3377,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
+                    "Audit"
                 ],
-                "summary": "获取timelock合约详细信息",
+                "summary": "查询组织操作记录",
                 "parameters": [
                     {
-                        "description": "获取详情请求体",
+                        "description": "组织ID、筛选和分页参数",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.GetTimeLockDetailRequest"
+                            "$ref": "#/definitions/types.GetOrganizationAuditEventsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取timelock合约详情",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3247,7 +3403,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.GetTimeLockDetailResponse"
+                                            "$ref": "#/definitions/types.GetAuditEventsResponse"
                                         }
                                     }
                                 }
@@ -3255,7 +3411,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "description": "请求参数错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3291,43 +3447,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "无权访问此timelock合约",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "404": {
-                        "description": "timelock合约不存在",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "422": {
-                        "description": "参数校验失败",
+                        "description": "不是组织所有者",
                         "schema": {
                             "allOf": [
                                 {
@@ -3365,14 +3485,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/timelock/list": {
+        "/api/v1/auth/logout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取当前用户在所有链上有权限访问的timelock合约列表。支持按合约标准和状态进行筛选。返回的列表根据用户权限进行精细控制，只显示用户作为创建者、管理员、提议者、执行者的合约。",
+                "description": "撤销当前登录会话：当前访问令牌立即失效，该次登录轮换产生的所有刷新令牌均不可再使用。需要有效的JWT令牌。",
                 "consumes": [
                     "application/json"
                 ],
@@ -3380,22 +3500,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
-                ],
-                "summary": "获取用户timelock合约列表（按权限筛选，所有链）",
-                "parameters": [
-                    {
-                        "description": "查询参数",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/types.GetTimeLockListRequest"
-                        }
-                    }
+                    "Authentication"
                 ],
+                "summary": "登出当前会话",
                 "responses": {
                     "200": {
-                        "description": "成功获取timelock合约列表",
+                        "description": "登出成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3405,25 +3515,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/types.GetTimeLockListResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误或标准无效",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
+                                            "$ref": "#/definitions/types.LogoutResponse"
                                         }
                                     }
                                 }
@@ -3469,14 +3561,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/timelock/refresh-permissions": {
+        "/api/v1/auth/logout-all": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "刷新该用户在库中所有timelock合约的权限，将合约中所有角色获取一遍然后更新数据库。这个操作会从区块链上重新读取用户在各个timelock合约中的最新权限信息。",
+                "description": "撤销当前用户在所有设备上的登录会话：所有访问令牌立即失效，所有刷新令牌均不可再使用。需要有效的JWT令牌。",
                 "consumes": [
                     "application/json"
                 ],
@@ -3484,12 +3576,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
+                    "Authentication"
                 ],
-                "summary": "刷新用户所有timelock合约权限",
+                "summary": "登出所有设备",
                 "responses": {
                     "200": {
-                        "description": "成功刷新权限",
+                        "description": "登出成功，返回撤销的会话数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -3499,7 +3591,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/types.LogoutResponse"
                                         }
                                     }
                                 }
@@ -3545,14 +3637,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/timelock/update": {
+        "/api/v1/auth/nonce": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "更新指定timelock合约的备注信息。只有合约的创建者/导入者才能更新备注。备注信息用于帮助用户管理和识别不同的timelock合约。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "description": "获取用于钱包签名认证的随机nonce和SIWE (EIP-4361) 格式的签名消息。前端需要先调用此接口获取nonce和消息，然后让用户对消息进行签名，最后调用wallet-connect接口完成认证。chain_id必须在支持链列表中，domain必须在服务端配置的域名白名单中。",
                 "consumes": [
                     "application/json"
                 ],
@@ -3560,23 +3647,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Timelock"
+                    "Authentication"
                 ],
-                "summary": "更新timelock合约备注",
+                "summary": "获取认证nonce",
                 "parameters": [
                     {
-                        "description": "更新请求体（地址从鉴权获取）",
+                        "description": "获取nonce请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateTimeLockRequest"
+                            "$ref": "#/definitions/types.GetNonceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功更新timelock合约备注",
+                        "description": "成功获取nonce和签名消息",
                         "schema": {
                             "allOf": [
                                 {
@@ -3586,7 +3673,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/types.GetNonceResponse"
                                         }
                                     }
                                 }
@@ -3594,7 +3681,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "description": "请求参数错误 - INVALID_WALLET_ADDRESS: 钱包地址格式无效; INVALID_DOMAIN: 域名不在白名单中; UNSUPPORTED_CHAIN: 不支持的链",
                         "schema": {
                             "allOf": [
                                 {
@@ -3611,8 +3698,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未认证或令牌无效",
+                    "429": {
+                        "description": "请求过于频繁（RATE_LIMITED），响应头Retry-After为需等待的秒数",
                         "schema": {
                             "allOf": [
                                 {
@@ -3629,8 +3716,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "无权访问此timelock合约",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "allOf": [
                                 {
@@ -3646,9 +3733,31 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    },
-                    "404": {
-                        "description": "timelock合约不存在",
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/profile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前认证用户的详细资料信息，包括钱包地址、创建时间等。需要有效的JWT令牌。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "获取用户资料",
+                "responses": {
+                    "200": {
+                        "description": "成功获取用户资料",
                         "schema": {
                             "allOf": [
                                 {
@@ -3657,16 +3766,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/types.APIError"
+                                        "data": {
+                                            "$ref": "#/definitions/types.UserProfile"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "422": {
-                        "description": "参数校验失败",
+                    "401": {
+                        "description": "未认证或令牌无效",
                         "schema": {
                             "allOf": [
                                 {
@@ -3683,8 +3792,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "用户不存在",
                         "schema": {
                             "allOf": [
                                 {
@@ -3700,291 +3809,7853 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/refresh-token": {
+            "post": {
+                "description": "使用刷新令牌获取新的访问令牌。当访问令牌过期时，前端可以使用此接口通过刷新令牌重新获取新的访问令牌和刷新令牌，无需重新进行钱包签名认证。刷新令牌只能使用一次，每次刷新都会返回新的刷新令牌；已使用过的刷新令牌再次提交会被视为重放，该次登录的所有会话将被撤销，需要重新签名登录。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "刷新访问令牌",
+                "parameters": [
+                    {
+                        "description": "刷新令牌请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "刷新成功，返回新的访问令牌和刷新令牌",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.WalletConnectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "INVALID_REFRESH_TOKEN: 刷新令牌无效或已过期; TOKEN_REVOKED: 会话已登出或被撤销; TOKEN_REUSED: 刷新令牌被重复使用，会话已全部撤销",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/wallet-connect": {
+            "post": {
+                "description": "通过钱包进行用户认证。1.先调用/auth/nonce获取随机nonce和SIWE消息 2.让用户对消息进行签名 3.调用此接口完成认证。服务端会严格校验SIWE消息的域名、URI、有效期、链ID和nonce，令牌中记录签名时的链ID和域名。Safe钱包需要通过Safe对消息签名（链上signMessage时signature传\"0x\"，或提交按地址升序拼接的owner签名），系统通过EIP-1271 isValidSignature或owner阈值校验签名，签发的令牌绑定到签名消息中的链。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "钱包连接认证（支持EOA和Safe钱包）",
+                "parameters": [
+                    {
+                        "description": "钱包连接认证请求体。需要nonce、message和signature，Safe钱包还需要wallet_type='safe'",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WalletConnectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "认证成功，返回访问令牌和用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.WalletConnectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_WALLET_ADDRESS: 钱包地址格式无效; MISSING_REQUIRED_FIELDS: 缺少必需字段; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约; UNSUPPORTED_CHAIN: 不支持的链",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "认证失败 - INVALID_SIGNATURE: 签名验证失败; SIGNATURE_RECOVERY_FAILED: 无法从签名恢复地址; INVALID_NONCE: nonce无效或已过期; NONCE_ALREADY_USED: nonce已被使用; CHAIN_MISMATCH: chain_id与签名消息不一致; INVALID_SIWE_MESSAGE: 签名消息格式错误或已过期; INVALID_DOMAIN: 签名消息域名不在白名单中",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 服务器内部错误; DATABASE_ERROR: 数据库操作失败; TOKEN_GENERATION_FAILED: JWT令牌生成失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/wallets/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "提交待关联钱包对关联签名消息的签名，证明对该钱包的控制权后将其关联到当前账户。EOA直接校验签名，Safe钱包（wallet_type='safe'）通过EIP-1271 isValidSignature或owner阈值签名校验。关联后使用该钱包登录将进入当前账户，timelock列表、详情权限和流程列表按账户下所有钱包合并计算。被关联钱包原有账户的邮箱、通知配置等数据不会合并。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "关联钱包",
+                "parameters": [
+                    {
+                        "description": "待关联的钱包地址、签名消息、签名和nonce",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LinkWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "关联成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.LinkedWallet"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_WALLET_ADDRESS; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约; CANNOT_LINK_PRIMARY; LINKED_WALLET_LIMIT",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或签名校验失败 - INVALID_SIGNATURE; SIGNATURE_RECOVERY_FAILED; INVALID_NONCE; NONCE_ALREADY_USED; INVALID_SIWE_MESSAGE: 签名消息无效或不是针对当前账户的关联消息; INVALID_DOMAIN",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "WALLET_ALREADY_LINKED; WALLET_HAS_LINKED_WALLETS; ACCOUNT_IS_LINKED",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/wallets/link-nonce": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为待关联的钱包生成SIWE (EIP-4361) 格式的签名消息，消息声明为\"Link this wallet to TimeLocker account \u003c主钱包地址\u003e\"，需由待关联的钱包签名后调用/auth/wallets/link。待关联钱包不能是当前账户的主钱包、不能已关联到其他账户，也不能是已有关联钱包的账户主钱包；每个账户最多关联20个钱包。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "获取关联钱包签名消息",
+                "parameters": [
+                    {
+                        "description": "待关联的钱包地址和链ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LinkWalletNonceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取签名消息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetNonceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_WALLET_ADDRESS; INVALID_DOMAIN; UNSUPPORTED_CHAIN; CANNOT_LINK_PRIMARY: 不能关联主钱包; LINKED_WALLET_LIMIT: 关联数量已达上限",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "WALLET_ALREADY_LINKED: 钱包已被关联; WALLET_HAS_LINKED_WALLETS: 钱包是已有关联钱包的账户主钱包; ACCOUNT_IS_LINKED: 当前账户已被关联到其他账户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/wallets/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前账户的主钱包地址和所有关联钱包",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "获取账户钱包列表",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.LinkedWalletListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/wallets/unlink": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "从当前账户解除关联钱包，使用该钱包登录签发的令牌随之失效。不能解除账户的主钱包。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "解除关联钱包",
+                "parameters": [
+                    {
+                        "description": "待解除关联的钱包地址",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UnlinkWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - CANNOT_LINK_PRIMARY: 不能解除主钱包",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "LINKED_WALLET_NOT_FOUND: 钱包未关联到当前账户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/chain/chainid": {
+            "post": {
+                "description": "根据指定的链ID（如1代表以太坊主网）获取单个支持链的详细信息，包括链名称、原生代币、Logo等基本信息。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chain"
+                ],
+                "summary": "根据ChainID获取链信息",
+                "parameters": [
+                    {
+                        "description": "按ChainID获取请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GetChainByChainIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取链信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.SupportChainResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "链不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/chain/list": {
+            "post": {
+                "description": "获取所有支持的区块链列表，可根据是否测试网和是否激活状态进行筛选。返回链的详细信息包括名称、链ID、原生代币、Logo等信息。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chain"
+                ],
+                "summary": "获取支持的区块链列表",
+                "parameters": [
+                    {
+                        "description": "筛选参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetSupportChainsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取支持链列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetSupportChainsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/chain/wallet-config": {
+            "post": {
+                "description": "获取指定链ID的钱包插件配置数据，包括chainId、chainName、nativeCurrency、rpcUrls、blockExplorerUrls等信息，用于帮助用户在钱包插件中添加该链。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chain"
+                ],
+                "summary": "获取钱包插件添加链的配置数据",
+                "parameters": [
+                    {
+                        "description": "获取钱包配置请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GetWalletChainConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取钱包配置数据",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.WalletChainConfig"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "链不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的所有邮箱地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "获取用户邮箱列表",
+                "parameters": [
+                    {
+                        "description": "分页参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetEmailsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.EmailListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "删除指定的邮箱地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "删除邮箱",
+                "parameters": [
+                    {
+                        "description": "删除邮箱请求（包含ID）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限操作该邮箱",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/digest": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "设置指定邮箱的通知方式：off 逐条实时发送；daily/weekly 不再逐条发送，改为每日/每周汇总一封摘要邮件（新排队、当前可执行、即将过期、已执行、已取消）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "更新邮箱摘要模式",
+                "parameters": [
+                    {
+                        "description": "更新摘要模式请求（包含ID）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateEmailDigestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/filters": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "设置指定邮箱接收哪些流程通知，可按合约地址、链ID、目标状态、函数选择器或签名、最小原生代币数量(wei)过滤。filters 不填或为空对象时清除过滤，接收全部通知",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "更新邮箱订阅过滤规则",
+                "parameters": [
+                    {
+                        "description": "更新过滤规则请求（包含ID）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateEmailFiltersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "过滤规则校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/remark": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "更新指定邮箱的备注信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "更新邮箱备注",
+                "parameters": [
+                    {
+                        "description": "更新备注请求（包含ID）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateEmailRemarkWithIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限操作该邮箱",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/send-verification": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "基于邮箱发送验证码。后端会自动创建/复用未验证记录，并允许更新备注。email 必填，remark 最长200字符。传入 organization_id 时添加为组织共享邮箱，需要组织编辑者及以上角色。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "发送验证码",
+                "parameters": [
+                    {
+                        "description": "发送验证码请求（email 必填，remark 可选，最长200）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SendVerificationCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误（缺少email）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足（INSUFFICIENT_ORGANIZATION_ROLE）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败（INVALID_EMAIL_FORMAT / INVALID_REMARK）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "发送过于频繁（RATE_LIMITED时响应头Retry-After为需等待的秒数）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/template": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "设置指定邮箱的流程通知邮件模板（Go html/template），保存前会使用示例数据试渲染，可用变量与限制见 /api/v1/notifications/template/preview。template 不填或为空时恢复默认模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "更新邮箱自定义邮件模板",
+                "parameters": [
+                    {
+                        "description": "更新模板请求（包含ID）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateEmailTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "模板校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/emails/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "使用验证码验证邮箱地址。email 必填，code 为6位数字。组织共享邮箱需传入与发送验证码时一致的 organization_id。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email"
+                ],
+                "summary": "验证邮箱",
+                "parameters": [
+                    {
+                        "description": "验证邮箱请求（email+code）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足（INSUFFICIENT_ORGANIZATION_ROLE）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邮箱不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "验证码无效或已过期 / 参数校验失败（INVALID_EMAIL_FORMAT / INVALID_CODE_FORMAT）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/flows/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取与用户相关的timelock流程列表，包括发起的和有权限管理的",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flow"
+                ],
+                "summary": "获取与用户相关的流程列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetCompoundFlowListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetCompoundFlowListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/flows/list/count": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取与用户相关的timelock流程数量统计，包括发起的和有权限管理的，按状态分组统计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flow"
+                ],
+                "summary": "获取与用户相关的流程数量统计",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetCompoundFlowListCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetCompoundFlowListCountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/flows/transaction/detail": {
+            "post": {
+                "description": "根据交易哈希和标准获取交易详情。standard 仅支持 compound/openzeppelin；tx_hash 必须为 0x 开头的64位十六进制。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Flow"
+                ],
+                "summary": "获取交易详情",
+                "parameters": [
+                    {
+                        "description": "请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GetTransactionDetailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetTransactionDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误（INVALID_STANDARD / INVALID_TX_HASH）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "交易不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/configs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的所有通知渠道配置，包含用户所在组织的共享配置（organization_id 不为0），如果用户没有任何配置则返回空列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "获取所有通知配置",
+                "responses": {
+                    "200": {
+                        "description": "获取成功，返回所有配置或空列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.NotificationConfigListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 获取配置失败; DATABASE_ERROR: 数据库访问失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "为当前用户创建新的通知配置, 传入 organization_id 时创建为组织共享配置（需要组织编辑者及以上角色），组织关注的合约状态变化时会通过共享配置通知, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证。可通过 filters 设置订阅过滤规则（合约地址、链ID、目标状态、函数选择器或签名、最小原生代币数量wei），不填则接收全部通知。可通过 message_template 设置 text/template 格式的自定义消息模板，可用变量见模板预览接口。digest_mode 为 daily/weekly 时不再逐条发送，改为按周期汇总发送摘要",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "创建通知配置",
+                "parameters": [
+                    {
+                        "description": "创建请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; MISSING_TELEGRAM_FIELDS: 缺少telegram必填字段; MISSING_WEBHOOK_URL: 缺少webhook_url字段; MISSING_REQUIRED_FIELDS: 缺少必填字段; INVALID_DIGEST_MODE: 无效的摘要模式",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "配置冲突 - CONFIG_ALREADY_EXISTS: 同名配置已存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "过滤规则或模板错误 - INVALID_FILTERS: 订阅过滤规则校验失败; INVALID_TEMPLATE: 消息模板校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 创建配置失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "删除当前用户的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证。组织共享配置需传入 organization_id 且需要组织编辑者及以上角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "删除通知配置",
+                "parameters": [
+                    {
+                        "description": "删除请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 删除配置失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/outbox/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "分页获取与当前用户相关合约的通知投递记录，可按状态过滤（pending, processing, sent, dead），用于排查投递失败的通知",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "获取通知发件箱列表",
+                "parameters": [
+                    {
+                        "description": "查询请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GetNotificationOutboxListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetNotificationOutboxListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_STATUS: 无效的状态",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 获取发件箱失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/outbox/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "将超过最大重试次数的通知（dead状态）重置为待投递，由后台worker重新发送，已成功送达的接收方不会重复发送",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "重新投递死信通知",
+                "parameters": [
+                    {
+                        "description": "重新投递请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RedeliverNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已加入投递队列",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; NOT_DEAD_LETTER: 该记录不是死信状态",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "记录不存在 - OUTBOX_NOT_FOUND: 记录不存在或与当前用户无关",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 重新投递失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/template/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "使用示例流程数据渲染自定义模板。telegram/lark/feishu 使用 Go text/template，email 使用 Go html/template。可用变量：.StatusFrom .StatusTo .Standard .Network .Contract .Remark .Caller .Target .Value .Function .CalldataParams（每项含 .Name .Type .Value） .TxHash .TxUrl .DashboardUrl，邮件模板还可使用 .BgColorFrom .TextColorFrom .BgColorTo .TextColorTo。模板最大16KB，渲染结果最大64KB，渲染超时2秒，不支持 define/template/block，range 最多嵌套2层",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "预览消息模板",
+                "parameters": [
+                    {
+                        "description": "预览请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.PreviewNotificationTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "渲染成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.PreviewNotificationTemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_CHANNEL: 无效的通知渠道",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "模板错误 - INVALID_TEMPLATE: 模板解析或渲染失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 渲染失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "更新当前用户的通知配置, 如果不需要更新某个字段, 可以不传该字段, 但至少传一个字段。组织共享配置需传入 organization_id 且需要组织编辑者及以上角色。filters 传空对象表示清除订阅过滤规则, message_template 传空字符串表示恢复默认消息格式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "更新通知配置",
+                "parameters": [
+                    {
+                        "description": "更新请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; NO_FIELDS_TO_UPDATE: 至少需要提供一个字段进行更新; INVALID_DIGEST_MODE: 无效的摘要模式",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证 - UNAUTHORIZED: 用户未认证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "过滤规则或模板错误 - INVALID_FILTERS: 订阅过滤规则校验失败; INVALID_TEMPLATE: 消息模板校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误 - INTERNAL_ERROR: 更新配置失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/accept-invitation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "接受发给当前钱包地址的待处理邀请，以邀请中的角色成为组织成员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "接受组织邀请",
+                "parameters": [
+                    {
+                        "description": "邀请ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationInvitationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "接受成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邀请不存在、已过期或已处理",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "已是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建新的组织，创建者自动成为组织所有者。组织成员共享组织名下的timelock合约、ABI、通知配置和通知邮箱。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "创建组织",
+                "parameters": [
+                    {
+                        "description": "创建组织请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.OrganizationInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/decline-invitation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "拒绝发给当前钱包地址的待处理邀请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "拒绝组织邀请",
+                "parameters": [
+                    {
+                        "description": "邀请ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationInvitationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "拒绝成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邀请不存在、已过期或已处理",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除组织及其成员和邀请，仅所有者可操作。组织名下仍有timelock合约、ABI、通知配置或邮箱时不允许删除。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "删除组织",
+                "parameters": [
+                    {
+                        "description": "组织ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在或不是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "组织名下仍有资源",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/detail": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取组织信息和成员列表，所有者还可以看到待处理的邀请。仅组织成员可访问。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "获取组织详情",
+                "parameters": [
+                    {
+                        "description": "组织ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取组织详情",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.OrganizationDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在或不是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取发给当前钱包地址且尚未过期的待处理组织邀请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "获取收到的组织邀请",
+                "responses": {
+                    "200": {
+                        "description": "成功获取邀请列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.OrganizationInvitationListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/invite": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "邀请指定钱包地址以owner、editor或viewer角色加入组织，仅所有者可操作。邀请7天内有效，受邀钱包登录后可接受或拒绝。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "邀请钱包加入组织",
+                "parameters": [
+                    {
+                        "description": "邀请成员请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.InviteOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "邀请成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.OrganizationInvitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误 - INVALID_REQUEST: 请求参数错误; INVALID_ROLE: 角色无效; INVALID_WALLET_ADDRESS: 钱包地址无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在或不是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "ALREADY_MEMBER: 已是组织成员; INVITATION_EXISTS: 已有待处理的邀请",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "当前用户退出组织，组织的最后一个所有者不能退出",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "退出组织",
+                "parameters": [
+                    {
+                        "description": "组织ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "退出成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在或不是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "组织需要保留至少一个所有者",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户所在的所有组织，包含当前用户在各组织中的角色和成员数量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "获取所在组织列表",
+                "responses": {
+                    "200": {
+                        "description": "成功获取组织列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.OrganizationListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/remove-member": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将成员移出组织，仅所有者可操作。组织必须保留至少一个所有者。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "移除组织成员",
+                "parameters": [
+                    {
+                        "description": "移除成员请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RemoveOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移除成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织或成员不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "组织需要保留至少一个所有者",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/revoke-invitation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销组织发出的待处理邀请，仅所有者可操作",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "撤销组织邀请",
+                "parameters": [
+                    {
+                        "description": "邀请ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OrganizationInvitationIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "撤销成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "邀请或组织不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新组织名称和描述，仅所有者可操作",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "更新组织信息",
+                "parameters": [
+                    {
+                        "description": "更新组织请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在或不是组织成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/update-member": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改成员的角色（owner、editor、viewer），仅所有者可操作。组织必须保留至少一个所有者。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "修改组织成员角色",
+                "parameters": [
+                    {
+                        "description": "修改成员角色请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/types.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "组织或成员不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "组织需要保留至少一个所有者",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sponsors/public": {
+            "post": {
+                "description": "获取所有激活的赞助方和生态伙伴信息，用于在前端展示。返回的数据按照排序权重和创建时间排序。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sponsors"
+                ],
+                "summary": "获取公开的赞助方和生态伙伴列表",
+                "responses": {
+                    "200": {
+                        "description": "成功获取赞助方和生态伙伴列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetPublicSponsorsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "获取赞助方列表失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/create-or-import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "创建新的或导入已存在的timelock合约记录。系统会从链上读取合约数据并验证其是否为有效的timelock合约。支持Compound和OpenZeppelin两种标准。合约地址必须为有效以太坊地址（0x + 40位十六进制）。传入organization_id时添加到组织的共享监控列表，需要组织编辑者角色。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "创建或导入timelock合约记录",
+                "parameters": [
+                    {
+                        "description": "创建或导入timelock合约的请求体（地址从鉴权获取）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrImportTimelockContractRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功创建或导入timelock合约记录",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "不是组织成员或组织角色权限不足",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "timelock合约已存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "硬删除指定的timelock合约记录。只有合约的创建者/导入者才能删除合约记录，组织合约需要组织编辑者角色。删除操作是硬删除，数据从数据库中删除。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "删除timelock合约记录",
+                "parameters": [
+                    {
+                        "description": "删除请求体（地址从鉴权获取）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteTimeLockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功删除timelock合约记录",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权访问此timelock合约",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "timelock合约不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/detail": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取指定timelock合约的完整详细信息，包括合约的基本信息、治理参数以及用户权限信息。只有具有相应权限的用户才能查看详细信息。传入organization_id时查看组织共享的合约，组织成员均可查看。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "获取timelock合约详细信息",
+                "parameters": [
+                    {
+                        "description": "获取详情请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GetTimeLockDetailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取timelock合约详情",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetTimeLockDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权访问此timelock合约",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "timelock合约不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户在所有链上有权限访问的timelock合约列表。支持按合约标准和状态进行筛选。返回的列表根据用户权限进行精细控制，只显示用户作为创建者、管理员、提议者、执行者的合约，以及用户所在组织共享的合约（权限列表附加org_owner/org_editor/org_viewer）。传入organization_id时只返回该组织的合约。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "获取用户timelock合约列表（按权限筛选，所有链）",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetTimeLockListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取timelock合约列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/types.GetTimeLockListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或标准无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "不是指定组织的成员",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/refresh-permissions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "刷新该用户在库中所有timelock合约的权限，将合约中所有角色获取一遍然后更新数据库。这个操作会从区块链上重新读取用户在各个timelock合约中的最新权限信息。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "刷新用户所有timelock合约权限",
+                "responses": {
+                    "200": {
+                        "description": "成功刷新权限",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timelock/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "更新指定timelock合约的备注信息。只有合约的创建者/导入者才能更新备注，组织合约需要组织编辑者角色。备注信息用于帮助用户管理和识别不同的timelock合约。合约地址必须为有效以太坊地址（0x + 40位十六进制）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timelock"
+                ],
+                "summary": "更新timelock合约备注",
+                "parameters": [
+                    {
+                        "description": "更新请求体（地址从鉴权获取）",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateTimeLockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功更新timelock合约备注",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证或令牌无效",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权访问此timelock合约",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "timelock合约不存在",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "参数校验失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/types.APIError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
         "types.ABI": {
             "type": "object",
             "properties": {
-                "abi_content": {
-                    "description": "ABI JSON内容",
-                    "type": "string"
+                "abi_content": {
+                    "description": "ABI JSON内容",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "ABI描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_shared": {
+                    "description": "是否为共享ABI",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "ABI名称",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人ABI",
+                    "type": "integer"
+                },
+                "owner": {
+                    "description": "所有者地址，全0表示共享ABI",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ABIListResponse": {
+            "type": "object",
+            "properties": {
+                "abis": {
+                    "description": "用户创建的ABI、所在组织的ABI及平台共享的ABI",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ABI"
+                    }
+                }
+            }
+        },
+        "types.ABIResponse": {
+            "type": "object",
+            "properties": {
+                "abi_content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_shared": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ABIValidationResult": {
+            "type": "object",
+            "properties": {
+                "error_message": {
+                    "type": "string"
+                },
+                "event_count": {
+                    "type": "integer"
+                },
+                "function_count": {
+                    "type": "integer"
+                },
+                "is_valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.APIKeyInfo": {
+            "type": "object",
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.APIKeyInfo"
+                    }
+                }
+            }
+        },
+        "types.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/types.APIError"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.AdminAuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型，如 timelock.delete",
+                    "type": "string"
+                },
+                "actor_address": {
+                    "description": "操作者钱包地址",
+                    "type": "string"
+                },
+                "actor_user_id": {
+                    "description": "操作者用户ID",
+                    "type": "integer"
+                },
+                "after": {
+                    "description": "变更后状态(JSON)，删除时为空",
+                    "type": "string"
+                },
+                "auth_type": {
+                    "description": "认证方式：access（钱包会话）或 api_key",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "变更前状态(JSON)，新建时为空",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "资源所属组织ID，0表示个人资源",
+                    "type": "integer"
+                },
+                "resource_id": {
+                    "description": "资源标识",
+                    "type": "string"
+                },
+                "resource_type": {
+                    "description": "资源类型",
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "记录来源：user 或 admin，同一来源内ID唯一",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AdminAuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型，如 chain.create",
+                    "type": "string"
+                },
+                "admin_address": {
+                    "description": "执行操作的管理员钱包地址",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "操作详情（请求内容/变更字段）",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "description": "资源ID，批量操作为0",
+                    "type": "integer"
+                },
+                "resource_type": {
+                    "description": "资源类型",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AdminChainListResponse": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SupportChain"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.AdminChainResponse": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "$ref": "#/definitions/types.SupportChain"
+                },
+                "scanner_error": {
+                    "description": "扫描器启动失败原因，配置已保存",
+                    "type": "string"
+                },
+                "scanner_running": {
+                    "description": "该链扫描器当前是否在运行",
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型，如 timelock.delete",
+                    "type": "string"
+                },
+                "actor_address": {
+                    "description": "操作者钱包地址",
+                    "type": "string"
+                },
+                "actor_user_id": {
+                    "description": "操作者用户ID",
+                    "type": "integer"
+                },
+                "after": {
+                    "description": "变更后状态(JSON)，删除时为空",
+                    "type": "string"
+                },
+                "auth_type": {
+                    "description": "认证方式：access（钱包会话）或 api_key",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "变更前状态(JSON)，新建时为空",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "资源所属组织ID，0表示个人资源",
+                    "type": "integer"
+                },
+                "resource_id": {
+                    "description": "资源标识",
+                    "type": "string"
+                },
+                "resource_type": {
+                    "description": "资源类型",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CalldataParam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "param[0],param[1]...",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "address,bool,uint256,int256,uint64,int64,uint8,int8,string,bytes...",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "值",
+                    "type": "string"
+                }
+            }
+        },
+        "types.CompoundFlowResponse": {
+            "type": "object",
+            "properties": {
+                "call_data_hex": {
+                    "description": "调用数据",
+                    "type": "string"
+                },
+                "cancel_tx_hash": {
+                    "description": "取消交易哈希",
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "description": "取消时间",
+                    "type": "string"
+                },
+                "chain_id": {
+                    "description": "链ID",
+                    "type": "integer"
+                },
+                "contract_address": {
+                    "description": "合约地址",
+                    "type": "string"
+                },
+                "contract_remark": {
+                    "description": "合约备注",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "eta": {
+                    "description": "执行时间",
+                    "type": "string"
+                },
+                "execute_tx_hash": {
+                    "description": "执行交易哈希",
+                    "type": "string"
+                },
+                "executed_at": {
+                    "description": "执行时间",
+                    "type": "string"
+                },
+                "expired_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "flow_id": {
+                    "description": "流程ID",
+                    "type": "string"
+                },
+                "function_signature": {
+                    "description": "函数签名",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID",
+                    "type": "integer"
+                },
+                "initiator_address": {
+                    "description": "发起者地址(FromAddress)",
+                    "type": "string"
+                },
+                "queue_tx_hash": {
+                    "description": "排队交易哈希",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "target_address": {
+                    "description": "目标地址",
+                    "type": "string"
+                },
+                "timelock_standard": {
+                    "description": "Timelock标准",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "价值",
+                    "type": "string"
+                }
+            }
+        },
+        "types.CompoundTimeLockWithPermission": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "description": "管理员地址，从链上读取",
+                    "type": "string"
+                },
+                "chain_id": {
+                    "description": "所在链ID",
+                    "type": "integer"
+                },
+                "chain_name": {
+                    "description": "链名称",
+                    "type": "string"
+                },
+                "contract_address": {
+                    "description": "合约地址",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_address": {
+                    "description": "创建者/导入者地址",
+                    "type": "string"
+                },
+                "delay": {
+                    "description": "延迟时间（秒），从链上读取",
+                    "type": "integer"
+                },
+                "grace_period": {
+                    "description": "宽限期（秒），从链上读取",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_imported": {
+                    "description": "是否导入的合约",
+                    "type": "boolean"
+                },
+                "maximum_delay": {
+                    "description": "最大延迟时间（秒），从链上读取",
+                    "type": "integer"
+                },
+                "minimum_delay": {
+                    "description": "最小延迟时间（秒），从链上读取",
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人合约",
+                    "type": "integer"
+                },
+                "pending_admin": {
+                    "description": "待定管理员地址，从链上读取",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态（active, inactive, deleted）",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_permissions": {
+                    "description": "creator, admin, pending_admin, org_owner, org_editor, org_viewer",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.CompoundTimelockTransactionDetail": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "description": "区块高度",
+                    "type": "integer"
+                },
+                "block_timestamp": {
+                    "description": "区块时间",
+                    "type": "string"
+                },
+                "chain_id": {
+                    "description": "链ID",
+                    "type": "integer"
+                },
+                "chain_name": {
+                    "description": "链名称",
+                    "type": "string"
+                },
+                "contract_address": {
+                    "description": "合约地址",
+                    "type": "string"
+                },
+                "event_call_data": {
+                    "description": "事件调用参数数据",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "event_eta": {
+                    "description": "事件ETA（预计执行时间）",
+                    "type": "integer"
+                },
+                "event_function_signature": {
+                    "description": "事件函数签名",
+                    "type": "string"
+                },
+                "event_target": {
+                    "description": "事件目标地址",
+                    "type": "string"
+                },
+                "event_tx_hash": {
+                    "description": "事件交易哈希",
+                    "type": "string"
+                },
+                "event_value": {
+                    "description": "事件价值",
+                    "type": "string"
+                },
+                "from_address": {
+                    "description": "发起地址",
+                    "type": "string"
+                },
+                "to_address": {
+                    "description": "接收地址",
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "description": "交易哈希",
+                    "type": "string"
+                },
+                "tx_status": {
+                    "description": "交易状态（success, failed）",
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateABIRequest": {
+            "type": "object",
+            "required": [
+                "abi_content",
+                "name"
+            ],
+            "properties": {
+                "abi_content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "organization_id": {
+                    "description": "创建到组织的ABI库，0表示个人",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "有效天数，为空表示永不过期",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/types.APIKeyInfo"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateChainRequest": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "chain_name",
+                "display_name",
+                "native_currency_name",
+                "native_currency_symbol"
+            ],
+            "properties": {
+                "alchemy_rpc_template": {
+                    "description": "包含 {API_KEY} 占位符",
+                    "type": "string"
+                },
+                "block_explorer_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "chain_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "chain_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "infura_rpc_template": {
+                    "description": "包含 {API_KEY} 占位符",
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_testnet": {
+                    "type": "boolean"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "native_currency_decimals": {
+                    "description": "默认18",
+                    "type": "integer",
+                    "maximum": 36,
+                    "minimum": 0
+                },
+                "native_currency_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "native_currency_symbol": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "official_rpc_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rpc_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.CreateNotificationRequest": {
+            "type": "object",
+            "required": [
+                "channel",
+                "name"
+            ],
+            "properties": {
+                "bot_token": {
+                    "description": "telegram",
+                    "type": "string"
+                },
+                "channel": {
+                    "description": "渠道,telegram,lark,feishu",
+                    "type": "string"
+                },
+                "chat_id": {
+                    "description": "聊天ID",
+                    "type": "string"
+                },
+                "digest_mode": {
+                    "description": "摘要模式,off,daily,weekly，不填为off（逐条实时发送）",
+                    "type": "string"
+                },
+                "filters": {
+                    "description": "订阅过滤规则，不填表示接收全部通知",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NotificationFilter"
+                        }
+                    ]
+                },
+                "message_template": {
+                    "description": "自定义消息模板(text/template)，不填使用默认格式",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "通用",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色",
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "签名验证时的密钥",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "description": "lark feishu",
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateOrImportTimelockContractRequest": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "contract_address",
+                "standard"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "is_imported": {
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "description": "添加到组织的共享监控列表，0表示个人",
+                    "type": "integer"
+                },
+                "remark": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "standard": {
+                    "type": "string",
+                    "enum": [
+                        "compound",
+                        "openzeppelin"
+                    ]
+                }
+            }
+        },
+        "types.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "types.CreateSharedABIRequest": {
+            "type": "object",
+            "required": [
+                "abi_content",
+                "name"
+            ],
+            "properties": {
+                "abi_content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                }
+            }
+        },
+        "types.CreateSponsorRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "link",
+                "logo_url",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "link": {
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "type": {
+                    "enum": [
+                        "sponsor",
+                        "partner"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SponsorType"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.DeleteABIRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DeleteChainRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DeleteEmailRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DeleteNotificationRequest": {
+            "type": "object",
+            "required": [
+                "channel",
+                "name"
+            ],
+            "properties": {
+                "channel": {
+                    "description": "渠道,telegram,lark,feishu",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "通用",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DeleteSponsorRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DeleteTimeLockRequest": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "contract_address",
+                "standard"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "组织合约所属组织，0表示个人",
+                    "type": "integer"
+                },
+                "standard": {
+                    "type": "string",
+                    "enum": [
+                        "compound",
+                        "openzeppelin"
+                    ]
+                }
+            }
+        },
+        "types.EmailListResponse": {
+            "type": "object",
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.UserEmailResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.FeishuConfig": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "digest_mode": {
+                    "description": "摘要模式,off实时发送,daily每日摘要,weekly每周摘要",
+                    "type": "string"
+                },
+                "filters": {
+                    "description": "订阅过滤规则(JSON)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "是否激活",
+                    "type": "boolean"
+                },
+                "message_template": {
+                    "description": "自定义消息模板(text/template)",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人配置",
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "签名验证时的密钥（加密存储）",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "user_address": {
+                    "description": "用户地址",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "description": "网络钩子URL（加密存储）",
+                    "type": "string"
+                }
+            }
+        },
+        "types.FlowStatusCount": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "description": "已取消",
+                    "type": "integer"
+                },
+                "count": {
+                    "description": "总数",
+                    "type": "integer"
+                },
+                "executed": {
+                    "description": "已执行",
+                    "type": "integer"
+                },
+                "expired": {
+                    "description": "已过期",
+                    "type": "integer"
+                },
+                "ready": {
+                    "description": "准备中",
+                    "type": "integer"
+                },
+                "waiting": {
+                    "description": "等待中",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetABIByIDRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetAdminAuditEventsRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_address": {
+                    "description": "仅组织和管理员视图有效，个人视图固定为当前用户",
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "不传表示不按组织筛选，0表示只看个人资源；按组织筛选时不包含管理员操作",
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "enum": [
+                        "timelock",
+                        "notification_config",
+                        "email",
+                        "abi",
+                        "organization",
+                        "linked_wallet",
+                        "chain",
+                        "sponsor",
+                        "shared_abi"
+                    ]
+                },
+                "scope": {
+                    "description": "不传表示用户操作和管理员操作都查询",
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ]
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetAdminAuditEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AdminAuditEvent"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetAdminAuditLogsRequest": {
+            "type": "object",
+            "properties": {
+                "admin_address": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "resource_type": {
+                    "type": "string",
+                    "enum": [
+                        "chain",
+                        "sponsor",
+                        "shared_abi"
+                    ]
+                }
+            }
+        },
+        "types.GetAdminAuditLogsResponse": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AdminAuditLog"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetAuditEventsRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_address": {
+                    "description": "仅组织和管理员视图有效，个人视图固定为当前用户",
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "enum": [
+                        "timelock",
+                        "notification_config",
+                        "email",
+                        "abi",
+                        "organization",
+                        "linked_wallet",
+                        "chain",
+                        "sponsor",
+                        "shared_abi"
+                    ]
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetAuditEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AuditEvent"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetChainByChainIDRequest": {
+            "type": "object",
+            "required": [
+                "chain_id"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetCompoundFlowListCountRequest": {
+            "type": "object",
+            "properties": {
+                "standard": {
+                    "description": "标准compound, openzeppelin",
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetCompoundFlowListCountResponse": {
+            "type": "object",
+            "properties": {
+                "flow_count": {
+                    "description": "流程数量",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.FlowStatusCount"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.GetCompoundFlowListRequest": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "页码，默认为1",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "每页大小，默认为10，最大100",
+                    "type": "integer"
+                },
+                "standard": {
+                    "description": "标准compound, openzeppelin",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态all, waiting, ready, executed, cancelled, expired",
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetCompoundFlowListResponse": {
+            "type": "object",
+            "properties": {
+                "flows": {
+                    "description": "流程列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CompoundFlowResponse"
+                    }
+                },
+                "total": {
+                    "description": "总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetEmailsRequest": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetNonceRequest": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "wallet_address"
+            ],
+            "properties": {
+                "chain_id": {
+                    "description": "签名消息绑定的链ID，必须在支持链列表中",
+                    "type": "integer"
+                },
+                "domain": {
+                    "description": "发起登录的前端域名（可含端口），为空时使用默认域名",
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "登录主体的URI，为空时为 https://\u003cdomain\u003e",
+                    "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetNonceResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "签名消息过期时间",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "SIWE (EIP-4361) 格式的待签名消息",
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "随机nonce",
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetNotificationOutboxListRequest": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "页码，默认为1",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "每页大小，默认为10，最大100",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 pending, processing, sent, dead，为空表示全部",
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetNotificationOutboxListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "发件箱记录",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.NotificationOutbox"
+                    }
+                },
+                "total": {
+                    "description": "总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetOrganizationAuditEventsRequest": {
+            "type": "object",
+            "required": [
+                "organization_id"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_address": {
+                    "description": "仅组织和管理员视图有效，个人视图固定为当前用户",
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "enum": [
+                        "timelock",
+                        "notification_config",
+                        "email",
+                        "abi",
+                        "organization",
+                        "linked_wallet",
+                        "chain",
+                        "sponsor",
+                        "shared_abi"
+                    ]
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetPublicSponsorsResponse": {
+            "type": "object",
+            "properties": {
+                "partners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SponsorInfo"
+                    }
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SponsorInfo"
+                    }
+                }
+            }
+        },
+        "types.GetSponsorsRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "type": {
+                    "enum": [
+                        "sponsor",
+                        "partner"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SponsorType"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.GetSponsorsResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Sponsor"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetSupportChainsRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "description": "筛选激活状态",
+                    "type": "boolean"
+                },
+                "is_testnet": {
+                    "description": "筛选测试网/主网",
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.GetSupportChainsResponse": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SupportChainResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetTimeLockDetailRequest": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "contract_address",
+                "standard"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "查看组织共享的合约，0表示个人或有链上权限的合约",
+                    "type": "integer"
+                },
+                "standard": {
+                    "type": "string",
+                    "enum": [
+                        "compound",
+                        "openzeppelin"
+                    ]
+                }
+            }
+        },
+        "types.GetTimeLockDetailResponse": {
+            "type": "object",
+            "properties": {
+                "compound_data": {
+                    "$ref": "#/definitions/types.CompoundTimeLockWithPermission"
+                },
+                "openzeppelin_data": {
+                    "$ref": "#/definitions/types.OpenzeppelinTimeLockWithPermission"
+                },
+                "standard": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetTimeLockListRequest": {
+            "type": "object",
+            "properties": {
+                "organization_id": {
+                    "description": "仅返回指定组织的合约，0表示不过滤",
+                    "type": "integer"
+                },
+                "standard": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetTimeLockListResponse": {
+            "type": "object",
+            "properties": {
+                "compound_timelocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CompoundTimeLockWithPermission"
+                    }
+                },
+                "openzeppelin_timelocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OpenzeppelinTimeLockWithPermission"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.GetTransactionDetailRequest": {
+            "type": "object",
+            "required": [
+                "standard",
+                "tx_hash"
+            ],
+            "properties": {
+                "standard": {
+                    "description": "标准",
+                    "type": "string",
+                    "enum": [
+                        "compound",
+                        "openzeppelin"
+                    ]
+                },
+                "tx_hash": {
+                    "description": "交易哈希",
+                    "type": "string"
+                }
+            }
+        },
+        "types.GetTransactionDetailResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "交易详情",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.CompoundTimelockTransactionDetail"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.GetWalletChainConfigRequest": {
+            "type": "object",
+            "required": [
+                "chain_id"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.InviteOrganizationMemberRequest": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "role",
+                "wallet_address"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                },
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "description": "签名算法：ES256 或 EdDSA",
                     "type": "string"
                 },
-                "description": {
-                    "description": "ABI描述",
+                "crv": {
+                    "description": "曲线：P-256 或 Ed25519",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "kid": {
+                    "description": "密钥ID，与令牌头中的kid对应",
+                    "type": "string"
                 },
-                "is_shared": {
-                    "description": "是否为共享ABI",
-                    "type": "boolean"
+                "kty": {
+                    "description": "密钥类型：EC 或 OKP",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "ABI名称",
+                "use": {
+                    "description": "固定为sig",
                     "type": "string"
                 },
-                "owner": {
-                    "description": "所有者地址，全0表示共享ABI",
+                "x": {
+                    "description": "公钥坐标（base64url）",
                     "type": "string"
                 },
-                "updated_at": {
+                "y": {
+                    "description": "EC公钥的Y坐标（base64url）",
                     "type": "string"
                 }
             }
         },
-        "types.ABIListResponse": {
+        "types.JWKSet": {
             "type": "object",
             "properties": {
-                "abis": {
-                    "description": "用户创建的ABI及平台共享的ABI",
+                "keys": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.ABI"
+                        "$ref": "#/definitions/types.JWK"
                     }
                 }
             }
         },
-        "types.ABIResponse": {
+        "types.LarkConfig": {
             "type": "object",
             "properties": {
-                "abi_content": {
+                "created_at": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "created_at": {
+                "digest_mode": {
+                    "description": "摘要模式,off实时发送,daily每日摘要,weekly每周摘要",
                     "type": "string"
                 },
-                "description": {
+                "filters": {
+                    "description": "订阅过滤规则(JSON)",
                     "type": "string"
                 },
                 "id": {
+                    "description": "ID",
                     "type": "integer"
                 },
-                "is_shared": {
+                "is_active": {
+                    "description": "是否激活",
                     "type": "boolean"
                 },
+                "message_template": {
+                    "description": "自定义消息模板(text/template)",
+                    "type": "string"
+                },
                 "name": {
+                    "description": "名称",
                     "type": "string"
                 },
-                "owner": {
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人配置",
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "签名验证时的密钥（加密存储）",
                     "type": "string"
                 },
                 "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "user_address": {
+                    "description": "用户地址",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "description": "网络钩子URL（加密存储）",
                     "type": "string"
                 }
             }
         },
-        "types.ABIValidationResult": {
+        "types.LinkWalletNonceRequest": {
             "type": "object",
+            "required": [
+                "chain_id",
+                "wallet_address"
+            ],
             "properties": {
-                "error_message": {
-                    "type": "string"
-                },
-                "event_count": {
+                "chain_id": {
+                    "description": "签名消息绑定的链ID，Safe钱包为Safe所在链",
                     "type": "integer"
                 },
-                "function_count": {
-                    "type": "integer"
+                "domain": {
+                    "description": "发起关联的前端域名，为空时使用默认域名",
+                    "type": "string"
                 },
-                "is_valid": {
-                    "type": "boolean"
+                "uri": {
+                    "description": "为空时为 https://\u003cdomain\u003e",
+                    "type": "string"
                 },
-                "warnings": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "wallet_address": {
+                    "description": "待关联的钱包地址",
+                    "type": "string"
                 }
             }
         },
-        "types.APIError": {
+        "types.LinkWalletRequest": {
             "type": "object",
+            "required": [
+                "message",
+                "nonce",
+                "signature",
+                "wallet_address"
+            ],
             "properties": {
-                "code": {
+                "label": {
+                    "description": "钱包备注，如\"硬件钱包\"",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "message": {
+                    "description": "获取关联签名消息时返回的消息",
                     "type": "string"
                 },
-                "details": {
+                "nonce": {
                     "type": "string"
                 },
-                "message": {
+                "signature": {
+                    "description": "待关联钱包对消息的签名，Safe链上signMessage时为\"0x\"",
                     "type": "string"
-                }
-            }
-        },
-        "types.APIResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "$ref": "#/definitions/types.APIError"
                 },
-                "success": {
-                    "type": "boolean"
+                "wallet_address": {
+                    "type": "string"
+                },
+                "wallet_type": {
+                    "description": "默认为eoa",
+                    "type": "string",
+                    "enum": [
+                        "eoa",
+                        "safe"
+                    ]
                 }
             }
         },
-        "types.CompoundFlowResponse": {
+        "types.LinkedWallet": {
             "type": "object",
             "properties": {
-                "call_data_hex": {
-                    "description": "调用数据",
-                    "type": "string"
-                },
-                "cancel_tx_hash": {
-                    "description": "取消交易哈希",
-                    "type": "string"
-                },
-                "cancelled_at": {
-                    "description": "取消时间",
-                    "type": "string"
-                },
                 "chain_id": {
-                    "description": "链ID",
+                    "description": "验证签名时的链ID，Safe钱包登录时必须使用该链",
                     "type": "integer"
                 },
-                "contract_address": {
-                    "description": "合约地址",
-                    "type": "string"
-                },
-                "contract_remark": {
-                    "description": "合约备注",
-                    "type": "string"
-                },
                 "created_at": {
-                    "description": "创建时间",
                     "type": "string"
                 },
-                "eta": {
-                    "description": "执行时间",
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "execute_tx_hash": {
-                    "description": "执行交易哈希",
+                "label": {
                     "type": "string"
                 },
-                "executed_at": {
-                    "description": "执行时间",
-                    "type": "string"
+                "user_id": {
+                    "description": "所属账户（主钱包用户ID）",
+                    "type": "integer"
                 },
-                "expired_at": {
-                    "description": "过期时间",
+                "wallet_address": {
+                    "description": "关联的钱包地址，全局唯一",
                     "type": "string"
                 },
-                "flow_id": {
-                    "description": "流程ID",
+                "wallet_type": {
                     "type": "string"
-                },
-                "function_signature": {
-                    "description": "函数签名",
+                }
+            }
+        },
+        "types.LinkedWalletListResponse": {
+            "type": "object",
+            "properties": {
+                "primary_wallet": {
+                    "description": "账户主钱包地址",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID",
+                "wallets": {
+                    "description": "已关联的钱包",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LinkedWallet"
+                    }
+                }
+            }
+        },
+        "types.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "revoked_sessions": {
+                    "description": "撤销的会话（令牌族）数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.NativeCurrencyConfig": {
+            "type": "object",
+            "properties": {
+                "decimals": {
                     "type": "integer"
                 },
-                "initiator_address": {
-                    "description": "发起者地址(FromAddress)",
-                    "type": "string"
-                },
-                "queue_tx_hash": {
-                    "description": "排队交易哈希",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "状态",
-                    "type": "string"
-                },
-                "target_address": {
-                    "description": "目标地址",
+                "name": {
                     "type": "string"
                 },
-                "timelock_standard": {
-                    "description": "Timelock标准",
+                "symbol": {
                     "type": "string"
+                }
+            }
+        },
+        "types.NotificationConfigListResponse": {
+            "type": "object",
+            "properties": {
+                "feishu_configs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.FeishuConfig"
+                    }
                 },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
+                "lark_configs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LarkConfig"
+                    }
                 },
-                "value": {
-                    "description": "价值",
-                    "type": "string"
+                "telegram_configs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TelegramConfig"
+                    }
                 }
             }
         },
-        "types.CompoundTimeLockWithPermission": {
+        "types.NotificationData": {
             "type": "object",
             "properties": {
-                "admin": {
-                    "description": "管理员地址，从链上读取",
+                "bg_color_from": {
+                    "description": "原状态背景色（邮件使用）",
                     "type": "string"
                 },
-                "chain_id": {
-                    "description": "所在链ID",
-                    "type": "integer"
+                "bg_color_to": {
+                    "description": "目标状态背景色（邮件使用）",
+                    "type": "string"
                 },
-                "chain_name": {
-                    "description": "链名称",
+                "calldata_params": {
+                    "description": "解析后的调用参数，每项包含 Name / Type / Value",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CalldataParam"
+                    }
+                },
+                "caller": {
+                    "description": "发起地址",
                     "type": "string"
                 },
-                "contract_address": {
-                    "description": "合约地址",
+                "contract": {
+                    "description": "时间锁合约地址",
                     "type": "string"
                 },
-                "created_at": {
+                "dashboard_url": {
+                    "description": "控制台地址",
                     "type": "string"
                 },
-                "creator_address": {
-                    "description": "创建者/导入者地址",
+                "function": {
+                    "description": "函数签名",
                     "type": "string"
                 },
-                "delay": {
-                    "description": "延迟时间（秒），从链上读取",
-                    "type": "integer"
+                "network": {
+                    "description": "网络名称",
+                    "type": "string"
                 },
-                "grace_period": {
-                    "description": "宽限期（秒），从链上读取",
-                    "type": "integer"
+                "remark": {
+                    "description": "合约备注",
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "standard": {
+                    "description": "时间锁标准，COMPOUND / OPENZEPPELIN",
+                    "type": "string"
                 },
-                "is_imported": {
-                    "description": "是否导入的合约",
-                    "type": "boolean"
+                "status_from": {
+                    "description": "原状态，如 WAITING",
+                    "type": "string"
                 },
-                "maximum_delay": {
-                    "description": "最大延迟时间（秒），从链上读取",
-                    "type": "integer"
+                "status_to": {
+                    "description": "目标状态，如 READY",
+                    "type": "string"
                 },
-                "minimum_delay": {
-                    "description": "最小延迟时间（秒），从链上读取",
-                    "type": "integer"
+                "target": {
+                    "description": "调用目标地址",
+                    "type": "string"
                 },
-                "pending_admin": {
-                    "description": "待定管理员地址，从链上读取",
+                "text_color_from": {
+                    "description": "原状态文字颜色（邮件使用）",
                     "type": "string"
                 },
-                "remark": {
-                    "description": "备注",
+                "text_color_to": {
+                    "description": "目标状态文字颜色（邮件使用）",
                     "type": "string"
                 },
-                "status": {
-                    "description": "状态（active, inactive, deleted）",
+                "tx_hash": {
+                    "description": "交易哈希（简化显示）",
                     "type": "string"
                 },
-                "updated_at": {
+                "tx_url": {
+                    "description": "区块浏览器交易链接",
                     "type": "string"
                 },
-                "user_permissions": {
-                    "description": "creator, admin, pending_admin",
+                "value": {
+                    "description": "原生代币数量（已格式化，如 0.100000 ETH）",
+                    "type": "string"
+                }
+            }
+        },
+        "types.NotificationFilter": {
+            "type": "object",
+            "properties": {
+                "chain_ids": {
+                    "description": "链ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "contract_addresses": {
+                    "description": "合约地址",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "functions": {
+                    "description": "函数选择器(0xa9059cbb)或函数签名(transfer(address,uint256))",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "min_value": {
+                    "description": "最小原生代币数量(wei)",
+                    "type": "string"
+                },
+                "status_to": {
+                    "description": "目标状态,waiting,ready,executed,cancelled,expired",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -3992,301 +11663,199 @@ const docTemplate = `{
                 }
             }
         },
-        "types.CompoundTimelockTransactionDetail": {
+        "types.NotificationOutbox": {
             "type": "object",
             "properties": {
-                "block_number": {
-                    "description": "区块高度",
+                "attempts": {
+                    "description": "已尝试次数",
                     "type": "integer"
                 },
-                "block_timestamp": {
-                    "description": "区块时间",
-                    "type": "string"
-                },
                 "chain_id": {
                     "description": "链ID",
                     "type": "integer"
                 },
-                "chain_name": {
-                    "description": "链名称",
+                "channel": {
+                    "description": "投递渠道 email / im",
                     "type": "string"
                 },
                 "contract_address": {
                     "description": "合约地址",
                     "type": "string"
                 },
-                "event_call_data": {
-                    "description": "事件调用参数数据",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "event_eta": {
-                    "description": "事件ETA（预计执行时间）",
+                "flow_id": {
+                    "description": "流程ID",
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "event_function_signature": {
-                    "description": "事件函数签名",
+                "initiator_address": {
+                    "description": "发起者地址",
                     "type": "string"
                 },
-                "event_target": {
-                    "description": "事件目标地址",
+                "last_error": {
+                    "description": "最近一次错误",
                     "type": "string"
                 },
-                "event_tx_hash": {
-                    "description": "事件交易哈希",
+                "locked_at": {
+                    "description": "被worker领取的时间",
                     "type": "string"
                 },
-                "event_value": {
-                    "description": "事件价值",
+                "max_attempts": {
+                    "description": "最大尝试次数",
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "description": "下次尝试时间",
                     "type": "string"
                 },
-                "from_address": {
-                    "description": "发起地址",
+                "sent_at": {
+                    "description": "投递成功时间",
                     "type": "string"
                 },
-                "to_address": {
-                    "description": "接收地址",
+                "status": {
+                    "description": "投递状态",
                     "type": "string"
                 },
-                "tx_hash": {
-                    "description": "交易哈希",
+                "status_from": {
+                    "description": "状态从",
                     "type": "string"
                 },
-                "tx_status": {
-                    "description": "交易状态（success, failed）",
+                "status_to": {
+                    "description": "状态到",
                     "type": "string"
-                }
-            }
-        },
-        "types.CreateABIRequest": {
-            "type": "object",
-            "required": [
-                "abi_content",
-                "name"
-            ],
-            "properties": {
-                "abi_content": {
+                },
+                "timelock_standard": {
+                    "description": "时间锁标准",
                     "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
+                "tx_hash": {
+                    "description": "交易哈希",
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 1
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "types.CreateNotificationRequest": {
+        "types.OpenzeppelinTimeLockWithPermission": {
             "type": "object",
-            "required": [
-                "channel",
-                "name"
-            ],
             "properties": {
-                "bot_token": {
-                    "description": "telegram",
+                "admin": {
+                    "description": "管理员地址，从链上读取",
                     "type": "string"
                 },
-                "channel": {
-                    "description": "渠道,telegram,lark,feishu",
-                    "type": "string"
+                "chain_id": {
+                    "description": "所在链ID",
+                    "type": "integer"
                 },
-                "chat_id": {
-                    "description": "聊天ID",
+                "chain_name": {
+                    "description": "链名称",
                     "type": "string"
                 },
-                "name": {
-                    "description": "通用",
+                "contract_address": {
+                    "description": "合约地址",
                     "type": "string"
                 },
-                "secret": {
-                    "description": "签名验证时的密钥",
+                "created_at": {
                     "type": "string"
                 },
-                "webhook_url": {
-                    "description": "lark feishu",
+                "creator_address": {
+                    "description": "创建者/导入者地址",
                     "type": "string"
-                }
-            }
-        },
-        "types.CreateOrImportTimelockContractRequest": {
-            "type": "object",
-            "required": [
-                "chain_id",
-                "contract_address",
-                "standard"
-            ],
-            "properties": {
-                "chain_id": {
+                },
+                "delay": {
+                    "description": "延迟时间（秒），从链上读取",
                     "type": "integer"
                 },
-                "contract_address": {
+                "executors": {
+                    "description": "执行者地址列表（JSON），从链上读取",
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "is_imported": {
+                    "description": "是否导入的合约",
                     "type": "boolean"
                 },
-                "remark": {
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "standard": {
-                    "type": "string",
-                    "enum": [
-                        "compound",
-                        "openzeppelin"
-                    ]
-                }
-            }
-        },
-        "types.DeleteABIRequest": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "types.DeleteEmailRequest": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "id": {
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人合约",
                     "type": "integer"
-                }
-            }
-        },
-        "types.DeleteNotificationRequest": {
-            "type": "object",
-            "required": [
-                "channel",
-                "name"
-            ],
-            "properties": {
-                "channel": {
-                    "description": "渠道,telegram,lark,feishu",
+                },
+                "proposers": {
+                    "description": "提议者地址列表（JSON），从链上读取",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
                     "type": "string"
                 },
-                "name": {
-                    "description": "通用",
+                "status": {
+                    "description": "状态（active, inactive, deleted）",
                     "type": "string"
-                }
-            }
-        },
-        "types.DeleteTimeLockRequest": {
-            "type": "object",
-            "required": [
-                "chain_id",
-                "contract_address",
-                "standard"
-            ],
-            "properties": {
-                "chain_id": {
-                    "type": "integer"
                 },
-                "contract_address": {
+                "updated_at": {
                     "type": "string"
                 },
-                "standard": {
-                    "type": "string",
-                    "enum": [
-                        "compound",
-                        "openzeppelin"
-                    ]
-                }
-            }
-        },
-        "types.EmailListResponse": {
-            "type": "object",
-            "properties": {
-                "emails": {
+                "user_permissions": {
+                    "description": "creator, proposer, executor, canceller, org_owner, org_editor, org_viewer",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.UserEmailResponse"
+                        "type": "string"
                     }
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "types.FeishuConfig": {
+        "types.Organization": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "创建时间",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID",
-                    "type": "integer"
-                },
-                "is_active": {
-                    "description": "是否激活",
-                    "type": "boolean"
-                },
-                "name": {
-                    "description": "名称",
+                "created_by": {
+                    "description": "创建者钱包地址",
                     "type": "string"
                 },
-                "secret": {
-                    "description": "签名验证时的密钥",
+                "description": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "user_address": {
-                    "description": "用户地址",
+                "name": {
                     "type": "string"
                 },
-                "webhook_url": {
-                    "description": "网络钩子URL",
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "types.FlowStatusCount": {
+        "types.OrganizationDetailResponse": {
             "type": "object",
             "properties": {
-                "cancelled": {
-                    "description": "已取消",
-                    "type": "integer"
-                },
-                "count": {
-                    "description": "总数",
-                    "type": "integer"
-                },
-                "executed": {
-                    "description": "已执行",
-                    "type": "integer"
-                },
-                "expired": {
-                    "description": "已过期",
-                    "type": "integer"
+                "invitations": {
+                    "description": "待处理的邀请，仅所有者可见",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrganizationInvitation"
+                    }
                 },
-                "ready": {
-                    "description": "准备中",
-                    "type": "integer"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrganizationMember"
+                    }
                 },
-                "waiting": {
-                    "description": "等待中",
-                    "type": "integer"
+                "organization": {
+                    "$ref": "#/definitions/types.OrganizationInfo"
                 }
             }
         },
-        "types.GetABIByIDRequest": {
+        "types.OrganizationIDRequest": {
             "type": "object",
             "required": [
                 "id"
@@ -4297,468 +11866,446 @@ const docTemplate = `{
                 }
             }
         },
-        "types.GetChainByChainIDRequest": {
+        "types.OrganizationInfo": {
             "type": "object",
-            "required": [
-                "chain_id"
-            ],
             "properties": {
-                "chain_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "创建者钱包地址",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
-                }
-            }
-        },
-        "types.GetCompoundFlowListCountRequest": {
-            "type": "object",
-            "properties": {
-                "standard": {
-                    "description": "标准compound, openzeppelin",
+                },
+                "member_count": {
+                    "description": "成员数量",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "当前用户在组织中的角色",
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "types.GetCompoundFlowListCountResponse": {
+        "types.OrganizationInvitation": {
             "type": "object",
             "properties": {
-                "flow_count": {
-                    "description": "流程数量",
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invited_by": {
+                    "description": "邀请者钱包地址",
+                    "type": "string"
+                },
+                "organization": {
+                    "description": "关联",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/types.FlowStatusCount"
+                            "$ref": "#/definitions/types.Organization"
                         }
                     ]
-                }
-            }
-        },
-        "types.GetCompoundFlowListRequest": {
-            "type": "object",
-            "properties": {
-                "page": {
-                    "description": "页码，默认为1",
-                    "type": "integer"
                 },
-                "page_size": {
-                    "description": "每页大小，默认为10，最大100",
+                "organization_id": {
                     "type": "integer"
                 },
-                "standard": {
-                    "description": "标准compound, openzeppelin",
+                "responded_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "接受后获得的角色",
                     "type": "string"
                 },
                 "status": {
-                    "description": "状态all, waiting, ready, executed, cancelled, expired",
                     "type": "string"
-                }
-            }
-        },
-        "types.GetCompoundFlowListResponse": {
-            "type": "object",
-            "properties": {
-                "flows": {
-                    "description": "流程列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.CompoundFlowResponse"
-                    }
                 },
-                "total": {
-                    "description": "总数",
-                    "type": "integer"
-                }
-            }
-        },
-        "types.GetEmailsRequest": {
-            "type": "object",
-            "properties": {
-                "page": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
-                }
-            }
-        },
-        "types.GetNonceRequest": {
-            "type": "object",
-            "required": [
-                "wallet_address"
-            ],
-            "properties": {
                 "wallet_address": {
+                    "description": "受邀钱包地址",
                     "type": "string"
                 }
             }
         },
-        "types.GetNonceResponse": {
+        "types.OrganizationInvitationIDRequest": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "nonce": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 }
             }
         },
-        "types.GetPublicSponsorsResponse": {
+        "types.OrganizationInvitationListResponse": {
             "type": "object",
             "properties": {
-                "partners": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.SponsorInfo"
-                    }
-                },
-                "sponsors": {
+                "invitations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.SponsorInfo"
+                        "$ref": "#/definitions/types.OrganizationInvitation"
                     }
                 }
             }
         },
-        "types.GetSupportChainsRequest": {
-            "type": "object",
-            "properties": {
-                "is_active": {
-                    "description": "筛选激活状态",
-                    "type": "boolean"
-                },
-                "is_testnet": {
-                    "description": "筛选测试网/主网",
-                    "type": "boolean"
-                }
-            }
-        },
-        "types.GetSupportChainsResponse": {
+        "types.OrganizationListResponse": {
             "type": "object",
             "properties": {
-                "chains": {
+                "organizations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.SupportChainResponse"
+                        "$ref": "#/definitions/types.OrganizationInfo"
                     }
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "types.GetTimeLockDetailRequest": {
+        "types.OrganizationMember": {
             "type": "object",
-            "required": [
-                "chain_id",
-                "contract_address",
-                "standard"
-            ],
             "properties": {
-                "chain_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "contract_address": {
+                "invited_by": {
+                    "description": "邀请者钱包地址，创建者为空",
                     "type": "string"
                 },
-                "standard": {
-                    "type": "string",
-                    "enum": [
-                        "compound",
-                        "openzeppelin"
-                    ]
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "owner, editor, viewer",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "wallet_address": {
+                    "type": "string"
                 }
             }
         },
-        "types.GetTimeLockDetailResponse": {
+        "types.PreviewNotificationTemplateRequest": {
             "type": "object",
+            "required": [
+                "channel",
+                "template"
+            ],
             "properties": {
-                "compound_data": {
-                    "$ref": "#/definitions/types.CompoundTimeLockWithPermission"
-                },
-                "openzeppelin_data": {
-                    "$ref": "#/definitions/types.OpenzeppelinTimeLockWithPermission"
+                "channel": {
+                    "description": "渠道,telegram,lark,feishu使用text/template,email使用html/template",
+                    "type": "string"
                 },
-                "standard": {
+                "template": {
+                    "description": "模板内容",
                     "type": "string"
                 }
             }
         },
-        "types.GetTimeLockListRequest": {
+        "types.PreviewNotificationTemplateResponse": {
             "type": "object",
             "properties": {
-                "standard": {
+                "rendered": {
+                    "description": "渲染结果",
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "sample_data": {
+                    "description": "渲染使用的示例数据，即模板可用的变量",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NotificationData"
+                        }
+                    ]
                 }
             }
         },
-        "types.GetTimeLockListResponse": {
+        "types.RedeliverNotificationRequest": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "compound_timelocks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.CompoundTimeLockWithPermission"
-                    }
-                },
-                "openzeppelin_timelocks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.OpenzeppelinTimeLockWithPermission"
-                    }
-                },
-                "total": {
+                "id": {
+                    "description": "发件箱记录ID",
                     "type": "integer"
                 }
             }
         },
-        "types.GetTransactionDetailRequest": {
+        "types.RefreshTokenRequest": {
             "type": "object",
             "required": [
-                "standard",
-                "tx_hash"
+                "refresh_token"
             ],
             "properties": {
-                "standard": {
-                    "description": "标准",
-                    "type": "string",
-                    "enum": [
-                        "compound",
-                        "openzeppelin"
-                    ]
-                },
-                "tx_hash": {
-                    "description": "交易哈希",
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "types.GetTransactionDetailResponse": {
+        "types.RemoveOrganizationMemberRequest": {
             "type": "object",
+            "required": [
+                "organization_id",
+                "wallet_address"
+            ],
             "properties": {
-                "detail": {
-                    "description": "交易详情",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/types.CompoundTimelockTransactionDetail"
-                        }
-                    ]
+                "organization_id": {
+                    "type": "integer"
+                },
+                "wallet_address": {
+                    "type": "string"
                 }
             }
         },
-        "types.GetWalletChainConfigRequest": {
+        "types.ReorderSponsorsRequest": {
             "type": "object",
             "required": [
-                "chain_id"
+                "items"
             ],
             "properties": {
-                "chain_id": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/types.SponsorSortItem"
+                    }
                 }
             }
         },
-        "types.LarkConfig": {
+        "types.RevokeAPIKeyRequest": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "created_at": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
                 "id": {
-                    "description": "ID",
                     "type": "integer"
-                },
-                "is_active": {
-                    "description": "是否激活",
-                    "type": "boolean"
-                },
-                "name": {
-                    "description": "名称",
-                    "type": "string"
-                },
-                "secret": {
-                    "description": "签名验证时的密钥",
-                    "type": "string"
-                },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "user_address": {
-                    "description": "用户地址",
-                    "type": "string"
-                },
-                "webhook_url": {
-                    "description": "网络钩子URL",
-                    "type": "string"
                 }
             }
         },
-        "types.NativeCurrencyConfig": {
+        "types.SendVerificationCodeRequest": {
             "type": "object",
             "properties": {
-                "decimals": {
-                    "type": "integer"
-                },
-                "name": {
+                "email": {
                     "type": "string"
                 },
-                "symbol": {
+                "organization_id": {
+                    "description": "所属组织ID，不填或0表示个人邮箱，需要组织编辑者及以上角色",
+                    "type": "integer"
+                },
+                "remark": {
                     "type": "string"
                 }
             }
         },
-        "types.NotificationConfigListResponse": {
+        "types.SetChainStatusRequest": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "feishu_configs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.FeishuConfig"
-                    }
-                },
-                "lark_configs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.LarkConfig"
-                    }
+                "id": {
+                    "type": "integer"
                 },
-                "telegram_configs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.TelegramConfig"
-                    }
+                "is_active": {
+                    "type": "boolean"
                 }
             }
         },
-        "types.OpenzeppelinTimeLockWithPermission": {
+        "types.Sponsor": {
             "type": "object",
             "properties": {
-                "admin": {
-                    "description": "管理员地址，从链上读取",
+                "created_at": {
                     "type": "string"
                 },
-                "chain_id": {
-                    "description": "所在链ID",
+                "description": {
+                    "description": "英文介绍",
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "chain_name": {
-                    "description": "链名称",
-                    "type": "string"
+                "is_active": {
+                    "description": "是否激活",
+                    "type": "boolean"
                 },
-                "contract_address": {
-                    "description": "合约地址",
+                "link": {
+                    "description": "链接",
                     "type": "string"
                 },
-                "created_at": {
+                "logo_url": {
+                    "description": "Logo URL",
                     "type": "string"
                 },
-                "creator_address": {
-                    "description": "创建者/导入者地址",
+                "name": {
+                    "description": "名称",
                     "type": "string"
                 },
-                "delay": {
-                    "description": "延迟时间（秒），从链上读取",
+                "sort_order": {
+                    "description": "排序权重，数值越大越靠前",
                     "type": "integer"
                 },
-                "executors": {
-                    "description": "执行者地址列表（JSON），从链上读取",
+                "type": {
+                    "description": "类型：sponsor/partner",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SponsorType"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.SponsorInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_imported": {
-                    "description": "是否导入的合约",
-                    "type": "boolean"
-                },
-                "proposers": {
-                    "description": "提议者地址列表（JSON），从链上读取",
+                "link": {
                     "type": "string"
                 },
-                "remark": {
-                    "description": "备注",
+                "logo_url": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "状态（active, inactive, deleted）",
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "sort_order": {
+                    "type": "integer"
                 },
-                "user_permissions": {
-                    "description": "creator, proposer, executor, canceller",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "type": {
+                    "$ref": "#/definitions/types.SponsorType"
                 }
             }
         },
-        "types.RefreshTokenRequest": {
+        "types.SponsorSortItem": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "id"
             ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
                 }
             }
         },
-        "types.SendVerificationCodeRequest": {
+        "types.SponsorType": {
+            "type": "string",
+            "enum": [
+                "partner",
+                "sponsor"
+            ],
+            "x-enum-comments": {
+                "SponsorTypePartner": "生态伙伴",
+                "SponsorTypeSponsor": "赞助方"
+            },
+            "x-enum-varnames": [
+                "SponsorTypePartner",
+                "SponsorTypeSponsor"
+            ]
+        },
+        "types.SupportChain": {
             "type": "object",
             "properties": {
-                "email": {
+                "alchemy_rpc_template": {
+                    "description": "Alchemy RPC URL模板",
                     "type": "string"
                 },
-                "remark": {
+                "block_explorer_urls": {
+                    "description": "区块浏览器URLs (JSON数组)",
+                    "type": "string"
+                },
+                "chain_id": {
+                    "description": "链ID",
+                    "type": "integer"
+                },
+                "chain_name": {
+                    "description": "Covalent API的chainName",
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
-                }
-            }
-        },
-        "types.SponsorInfo": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "display_name": {
+                    "description": "显示名称",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "link": {
+                "infura_rpc_template": {
+                    "description": "Infura RPC URL模板",
                     "type": "string"
                 },
+                "is_active": {
+                    "description": "是否激活",
+                    "type": "boolean"
+                },
+                "is_testnet": {
+                    "description": "是否是测试网",
+                    "type": "boolean"
+                },
                 "logo_url": {
+                    "description": "链Logo URL",
                     "type": "string"
                 },
-                "name": {
+                "native_currency_decimals": {
+                    "description": "原生货币精度",
+                    "type": "integer"
+                },
+                "native_currency_name": {
+                    "description": "原生货币名称",
                     "type": "string"
                 },
-                "sort_order": {
-                    "type": "integer"
+                "native_currency_symbol": {
+                    "description": "原生货币符号",
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/types.SponsorType"
+                "official_rpc_urls": {
+                    "description": "官方RPC URLs (JSON数组)",
+                    "type": "string"
+                },
+                "rpc_enabled": {
+                    "description": "是否启用RPC功能",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "types.SponsorType": {
-            "type": "string",
-            "enum": [
-                "partner",
-                "sponsor"
-            ],
-            "x-enum-comments": {
-                "SponsorTypePartner": "生态伙伴",
-                "SponsorTypeSponsor": "赞助方"
-            },
-            "x-enum-varnames": [
-                "SponsorTypePartner",
-                "SponsorTypeSponsor"
-            ]
-        },
         "types.SupportChainResponse": {
             "type": "object",
             "properties": {
@@ -4817,7 +12364,7 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "bot_token": {
-                    "description": "机器人token",
+                    "description": "机器人token（加密存储）",
                     "type": "string"
                 },
                 "chat_id": {
@@ -4828,6 +12375,14 @@ const docTemplate = `{
                     "description": "创建时间",
                     "type": "string"
                 },
+                "digest_mode": {
+                    "description": "摘要模式,off实时发送,daily每日摘要,weekly每周摘要",
+                    "type": "string"
+                },
+                "filters": {
+                    "description": "订阅过滤规则(JSON)",
+                    "type": "string"
+                },
                 "id": {
                     "description": "ID",
                     "type": "integer"
@@ -4836,10 +12391,18 @@ const docTemplate = `{
                     "description": "是否激活",
                     "type": "boolean"
                 },
+                "message_template": {
+                    "description": "自定义消息模板(text/template)",
+                    "type": "string"
+                },
                 "name": {
                     "description": "名称",
                     "type": "string"
                 },
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人配置",
+                    "type": "integer"
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
@@ -4850,6 +12413,17 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UnlinkWalletRequest": {
+            "type": "object",
+            "required": [
+                "wallet_address"
+            ],
+            "properties": {
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
         "types.UpdateABIWithIDRequest": {
             "type": "object",
             "required": [
@@ -4875,6 +12449,96 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UpdateChainRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "alchemy_rpc_template": {
+                    "type": "string"
+                },
+                "block_explorer_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "infura_rpc_template": {
+                    "type": "string"
+                },
+                "is_testnet": {
+                    "type": "boolean"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "native_currency_decimals": {
+                    "type": "integer",
+                    "maximum": 36,
+                    "minimum": 0
+                },
+                "native_currency_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "native_currency_symbol": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "official_rpc_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rpc_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.UpdateEmailDigestRequest": {
+            "type": "object",
+            "required": [
+                "digest_mode",
+                "id"
+            ],
+            "properties": {
+                "digest_mode": {
+                    "description": "off,daily,weekly",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.UpdateEmailFiltersRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "filters": {
+                    "description": "不填或传空对象表示清除过滤",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NotificationFilter"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
         "types.UpdateEmailRemarkWithIDRequest": {
             "type": "object",
             "required": [
@@ -4889,6 +12553,21 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UpdateEmailTemplateRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "template": {
+                    "description": "html/template 模板，不填或传空字符串表示恢复默认模板",
+                    "type": "string"
+                }
+            }
+        },
         "types.UpdateNotificationRequest": {
             "type": "object",
             "required": [
@@ -4908,14 +12587,34 @@ const docTemplate = `{
                     "description": "聊天ID",
                     "type": "string"
                 },
+                "digest_mode": {
+                    "description": "摘要模式,off,daily,weekly",
+                    "type": "string"
+                },
+                "filters": {
+                    "description": "订阅过滤规则，传空对象表示清除过滤",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NotificationFilter"
+                        }
+                    ]
+                },
                 "is_active": {
                     "description": "是否激活",
                     "type": "boolean"
                 },
+                "message_template": {
+                    "description": "自定义消息模板(text/template)，传空字符串表示恢复默认格式",
+                    "type": "string"
+                },
                 "name": {
                     "description": "通用",
                     "type": "string"
                 },
+                "organization_id": {
+                    "description": "所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色",
+                    "type": "integer"
+                },
                 "secret": {
                     "description": "签名验证时的密钥",
                     "type": "string"
@@ -4926,6 +12625,92 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UpdateOrganizationMemberRequest": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "role",
+                "wallet_address"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                },
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.UpdateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "types.UpdateSponsorWithIDRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "type": {
+                    "enum": [
+                        "sponsor",
+                        "partner"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SponsorType"
+                        }
+                    ]
+                }
+            }
+        },
         "types.UpdateTimeLockRequest": {
             "type": "object",
             "required": [
@@ -4940,6 +12725,10 @@ const docTemplate = `{
                 "contract_address": {
                     "type": "string"
                 },
+                "organization_id": {
+                    "description": "组织合约所属组织，0表示个人",
+                    "type": "integer"
+                },
                 "remark": {
                     "type": "string",
                     "maxLength": 500
@@ -4995,9 +12784,18 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "digest_mode": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "email_template": {
+                    "type": "string"
+                },
+                "filters": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -5007,6 +12805,10 @@ const docTemplate = `{
                 "last_verified_at": {
                     "type": "string"
                 },
+                "organization_id": {
+                    "description": "所属组织ID，0表示个人邮箱",
+                    "type": "integer"
+                },
                 "remark": {
                     "type": "string"
                 }
@@ -5021,6 +12823,13 @@ const docTemplate = `{
                 "last_login": {
                     "type": "string"
                 },
+                "linked_wallets": {
+                    "description": "关联到该账户的其他钱包",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LinkedWallet"
+                    }
+                },
                 "wallet_address": {
                     "type": "string"
                 }
@@ -5034,6 +12843,10 @@ const docTemplate = `{
                 },
                 "email": {
                     "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织ID，需与发送验证码时一致",
+                    "type": "integer"
                 }
             }
         },
@@ -5067,19 +12880,19 @@ const docTemplate = `{
             ],
             "properties": {
                 "chain_id": {
-                    "description": "Safe需要指定链ID",
+                    "description": "可选，填写时需与签名消息中的链ID一致",
                     "type": "integer"
                 },
                 "message": {
-                    "description": "EOA钱包需要，Safe钱包可选",
+                    "description": "获取nonce时返回的签名消息",
                     "type": "string"
                 },
                 "nonce": {
-                    "description": "EOA钱包需要nonce，Safe钱包不需要",
+                    "description": "获取nonce时返回的nonce，EOA和Safe均需要",
                     "type": "string"
                 },
                 "signature": {
-                    "description": "EOA钱包需要，Safe钱包可选",
+                    "description": "EOA为owner签名；Safe为owner拼接签名，链上signMessage时为\"0x\"",
                     "type": "string"
                 },
                 "wallet_address": {
@@ -5110,6 +12923,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "Personal API key with the scope required by the endpoint.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token.",
             "type": "apiKey",
@@ -33,7 +33,7 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	abiGroup := router.Group("/abi")
 	{
 		// 需要认证的端点
-		abiGroup.Use(middleware.AuthMiddleware(h.authService))
+		abiGroup.Use(middleware.AuthMiddleware(h.authService, types.APIKeyScopeABIRead))
 
 		// 获取ABI列表（用户的+共享的）
 		// POST /api/v1/abi/list
@@ -41,7 +41,7 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 
 		// 创建新的ABI
 		// POST /api/v1/abi
-		abiGroup.POST("", h.CreateABI)
+		abiGroup.POST("", middleware.RequireScope(types.APIKeyScopeABIWrite), h.CreateABI)
 
 		// 验证ABI格式
 		// POST /api/v1/abi/validate
@@ -53,21 +53,22 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 
 		// 更新ABI
 		// POST /api/v1/abi/update
-		abiGroup.POST("/update", h.UpdateABI)
+		abiGroup.POST("/update", middleware.RequireScope(types.APIKeyScopeABIWrite), h.UpdateABI)
 
 		// 删除ABI
 		// POST /api/v1/abi/delete
-		abiGroup.POST("/delete", h.DeleteABI)
+		abiGroup.POST("/delete", middleware.RequireScope(types.APIKeyScopeABIWrite), h.DeleteABI)
 	}
 }
 
 // CreateABI 创建新的ABI
 // @Summary 创建ABI
-// @Description 用户创建新的智能合约ABI。系统会验证ABI格式的正确性。每个用户在同一名称下只能创建一个ABI。名称长度1-200；描述≤500。
+// @Description 用户创建新的智能合约ABI。系统会验证ABI格式的正确性。每个用户在同一名称下只能创建一个ABI。传入organization_id时创建到组织ABI库（需要组织编辑者角色，组织内名称唯一）。名称长度1-200；描述≤500。
 // @Tags ABI
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.CreateABIRequest true "创建ABI请求体"
 // @Success 201 {object} types.APIResponse{data=types.ABIResponse} "ABI创建成功"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或ABI格式无效"
@@ -144,11 +145,12 @@ func (h *Handler) CreateABI(c *gin.Context) {
 
 // GetABIList 获取ABI列表
 // @Summary 获取ABI列表
-// @Description 获取用户可访问的ABI列表，包括用户自己创建的ABI和平台共享的ABI（合并在一起，利用is_shared字段区分，地址全0也表示共享ABI）。
+// @Description 获取用户可访问的ABI列表，包括用户自己创建的ABI、用户所在组织的ABI（organization_id非0）和平台共享的ABI（合并在一起，利用is_shared字段区分，地址全0也表示共享ABI）。
 // @Tags ABI
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Success 200 {object} types.APIResponse{data=types.ABIListResponse} "获取ABI列表成功"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
@@ -191,11 +193,12 @@ func (h *Handler) GetABIList(c *gin.Context) {
 
 // GetABIByID 根据ID获取ABI详情
 // @Summary 获取ABI详情
-// @Description 根据ABI ID获取详细信息。用户只能访问自己创建的ABI或平台共享的ABI。
+// @Description 根据ABI ID获取详细信息。用户只能访问自己创建的ABI、所在组织的ABI或平台共享的ABI。
 // @Tags ABI
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetABIByIDRequest true "获取ABI详情请求体（包含ID）"
 // @Success 200 {object} types.APIResponse{data=types.ABIResponse} "获取ABI详情成功"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "无效的ABI ID"
@@ -272,11 +275,12 @@ func (h *Handler) GetABIByID(c *gin.Context) {
 
 // UpdateABI 更新ABI
 // @Summary 更新ABI
-// @Description 更新用户创建的ABI。系统会重新验证ABI格式。用户只能更新自己创建的ABI。名称长度1-200；描述≤500。
+// @Description 更新用户创建的ABI。系统会重新验证ABI格式。用户只能更新自己创建的ABI，组织ABI需要组织编辑者角色。名称长度1-200；描述≤500。
 // @Tags ABI
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.UpdateABIWithIDRequest true "更新ABI请求体（包含ID）"
 // @Success 200 {object} types.APIResponse{data=types.ABIResponse} "ABI更新成功"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或ABI格式无效"
@@ -361,11 +365,12 @@ func (h *Handler) UpdateABI(c *gin.Context) {
 
 // DeleteABI 删除ABI
 // @Summary 删除ABI
-// @Description 删除用户创建的ABI。用户只能删除自己创建的ABI，不能删除平台共享的ABI。删除操作是不可逆的。
+// @Description 删除用户创建的ABI。用户只能删除自己创建的ABI，组织ABI需要组织编辑者角色，不能删除平台共享的ABI。删除操作是不可逆的。
 // @Tags ABI
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.DeleteABIRequest true "删除ABI请求体（包含ID）"
 // @Success 200 {object} types.APIResponse "ABI删除成功"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "无效的ABI ID"
@@ -448,11 +453,13 @@ func (h *Handler) DeleteABI(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body object{abi_content=string} true "验证ABI请求体"
 // @Success 200 {object} types.APIResponse{data=types.ABIValidationResult} "ABI验证完成"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
 // @Failure 422 {object} types.APIResponse{error=types.APIError} "参数校验失败"
+// @Failure 429 {object} types.APIResponse{error=types.APIError} "请求过于频繁（RATE_LIMITED），响应头Retry-After为需等待的秒数"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
 // @Router /api/v1/abi/validate [post]
 func (h *Handler) ValidateABI(c *gin.Context) {
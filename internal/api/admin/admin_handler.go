@@ -0,0 +1,786 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"timelocker-backend/internal/middleware"
+	"timelocker-backend/internal/service/admin"
+	"timelocker-backend/internal/service/audit"
+	"timelocker-backend/internal/service/auth"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler 平台管理员处理器
+type Handler struct {
+	adminService admin.Service
+	auditService audit.Service
+	authService  auth.Service
+}
+
+// NewHandler 创建平台管理员处理器
+func NewHandler(adminService admin.Service, auditService audit.Service, authService auth.Service) *Handler {
+	return &Handler{
+		adminService: adminService,
+		auditService: auditService,
+		authService:  authService,
+	}
+}
+
+// RegisterRoutes 注册平台管理员路由
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// 管理接口只允许白名单钱包的签名会话访问，不允许使用API Key
+	adminGroup := router.Group("/admin", middleware.AuthMiddleware(h.authService), middleware.AdminMiddleware(h.adminService))
+	{
+		// 获取全部支持链（含停用链）
+		// POST /api/v1/admin/chains/list
+		// http://localhost:8080/api/v1/admin/chains/list
+		adminGroup.POST("/chains/list", h.ListChains)
+
+		// 创建支持链
+		// POST /api/v1/admin/chains/create
+		// http://localhost:8080/api/v1/admin/chains/create
+		adminGroup.POST("/chains/create", h.CreateChain)
+
+		// 更新支持链
+		// POST /api/v1/admin/chains/update
+		// http://localhost:8080/api/v1/admin/chains/update
+		adminGroup.POST("/chains/update", h.UpdateChain)
+
+		// 启用/停用支持链
+		// POST /api/v1/admin/chains/set-status
+		// http://localhost:8080/api/v1/admin/chains/set-status
+		adminGroup.POST("/chains/set-status", h.SetChainStatus)
+
+		// 删除支持链
+		// POST /api/v1/admin/chains/delete
+		// http://localhost:8080/api/v1/admin/chains/delete
+		adminGroup.POST("/chains/delete", h.DeleteChain)
+
+		// 获取赞助方列表（含未激活）
+		// POST /api/v1/admin/sponsors/list
+		// http://localhost:8080/api/v1/admin/sponsors/list
+		adminGroup.POST("/sponsors/list", h.ListSponsors)
+
+		// 创建赞助方
+		// POST /api/v1/admin/sponsors/create
+		// http://localhost:8080/api/v1/admin/sponsors/create
+		adminGroup.POST("/sponsors/create", h.CreateSponsor)
+
+		// 更新赞助方
+		// POST /api/v1/admin/sponsors/update
+		// http://localhost:8080/api/v1/admin/sponsors/update
+		adminGroup.POST("/sponsors/update", h.UpdateSponsor)
+
+		// 删除赞助方
+		// POST /api/v1/admin/sponsors/delete
+		// http://localhost:8080/api/v1/admin/sponsors/delete
+		adminGroup.POST("/sponsors/delete", h.DeleteSponsor)
+
+		// 批量调整赞助方排序
+		// POST /api/v1/admin/sponsors/reorder
+		// http://localhost:8080/api/v1/admin/sponsors/reorder
+		adminGroup.POST("/sponsors/reorder", h.ReorderSponsors)
+
+		// 获取共享ABI列表
+		// POST /api/v1/admin/abis/list
+		// http://localhost:8080/api/v1/admin/abis/list
+		adminGroup.POST("/abis/list", h.ListSharedABIs)
+
+		// 创建共享ABI
+		// POST /api/v1/admin/abis/create
+		// http://localhost:8080/api/v1/admin/abis/create
+		adminGroup.POST("/abis/create", h.CreateSharedABI)
+
+		// 更新共享ABI
+		// POST /api/v1/admin/abis/update
+		// http://localhost:8080/api/v1/admin/abis/update
+		adminGroup.POST("/abis/update", h.UpdateSharedABI)
+
+		// 删除共享ABI
+		// POST /api/v1/admin/abis/delete
+		// http://localhost:8080/api/v1/admin/abis/delete
+		adminGroup.POST("/abis/delete", h.DeleteSharedABI)
+
+		// 查询管理员审计日志
+		// POST /api/v1/admin/audit-logs/list
+		// http://localhost:8080/api/v1/admin/audit-logs/list
+		adminGroup.POST("/audit-logs/list", h.ListAuditLogs)
+
+		// 查询全平台用户操作记录
+		// POST /api/v1/admin/audit-events/list
+		// http://localhost:8080/api/v1/admin/audit-events/list
+		adminGroup.POST("/audit-events/list", h.ListAuditEvents)
+	}
+}
+
+// ListChains 获取全部支持链
+// @Summary 获取全部支持链（管理员）
+// @Description 获取数据库中的全部支持链配置，包括停用的链，RPC URL和区块浏览器URL保持JSON数组字符串原样返回。请求体可为空。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetSupportChainsRequest false "筛选条件"
+// @Success 200 {object} types.APIResponse{data=types.AdminChainListResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/chains/list [post]
+func (h *Handler) ListChains(c *gin.Context) {
+	var req types.GetSupportChainsRequest
+	if !bindOptionalRequest(c, "Admin ListChains", &req) {
+		return
+	}
+
+	response, err := h.adminService.ListChains(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Admin ListChains", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// CreateChain 创建支持链
+// @Summary 创建支持链（管理员）
+// @Description 新增支持链。RPC模板需包含 {API_KEY} 占位符；链处于激活且启用RPC状态时立即热启动该链的扫描器，扫描器启动失败时配置仍会保存并在scanner_error中返回原因。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.CreateChainRequest true "创建支持链请求"
+// @Success 200 {object} types.APIResponse{data=types.AdminChainResponse} "创建成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或RPC模板无效"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "链名称或ChainID已存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/chains/create [post]
+func (h *Handler) CreateChain(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.CreateChainRequest
+	if !bindRequest(c, "Admin CreateChain", &req) {
+		return
+	}
+
+	response, err := h.adminService.CreateChain(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin CreateChain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// UpdateChain 更新支持链
+// @Summary 更新支持链（管理员）
+// @Description 按字段更新支持链配置，未传字段保持不变。修改Alchemy RPC模板、测试网标记或RPC开关后会重建该链的扫描器和RPC连接。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UpdateChainRequest true "更新支持链请求"
+// @Success 200 {object} types.APIResponse{data=types.AdminChainResponse} "更新成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或RPC模板无效"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "链不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/chains/update [post]
+func (h *Handler) UpdateChain(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateChainRequest
+	if !bindRequest(c, "Admin UpdateChain", &req) {
+		return
+	}
+
+	response, err := h.adminService.UpdateChain(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin UpdateChain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// SetChainStatus 启用/停用支持链
+// @Summary 启用/停用支持链（管理员）
+// @Description 设置链的激活状态。启用后立即热启动该链的扫描器，停用后立即停止扫描器并关闭RPC连接，无需重启服务。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.SetChainStatusRequest true "启用/停用请求"
+// @Success 200 {object} types.APIResponse{data=types.AdminChainResponse} "设置成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "链不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/chains/set-status [post]
+func (h *Handler) SetChainStatus(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.SetChainStatusRequest
+	if !bindRequest(c, "Admin SetChainStatus", &req) {
+		return
+	}
+
+	response, err := h.adminService.SetChainStatus(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin SetChainStatus", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// DeleteChain 删除支持链
+// @Summary 删除支持链（管理员）
+// @Description 删除支持链并停止其扫描器。链上仍有导入的timelock合约时拒绝删除，此时应改为停用。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.DeleteChainRequest true "删除支持链请求"
+// @Success 200 {object} types.APIResponse "删除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "链不存在"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "链上仍有timelock合约"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/chains/delete [post]
+func (h *Handler) DeleteChain(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.DeleteChainRequest
+	if !bindRequest(c, "Admin DeleteChain", &req) {
+		return
+	}
+
+	if err := h.adminService.DeleteChain(c.Request.Context(), meta, req.ID); err != nil {
+		respondError(c, "Admin DeleteChain", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Chain deleted successfully"},
+	})
+}
+
+// ListSponsors 获取赞助方列表
+// @Summary 获取赞助方列表（管理员）
+// @Description 分页获取赞助方和生态伙伴，包括未激活的记录，按排序权重降序。请求体可为空。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetSponsorsRequest false "筛选和分页参数"
+// @Success 200 {object} types.APIResponse{data=types.GetSponsorsResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/sponsors/list [post]
+func (h *Handler) ListSponsors(c *gin.Context) {
+	var req types.GetSponsorsRequest
+	if !bindOptionalRequest(c, "Admin ListSponsors", &req) {
+		return
+	}
+
+	response, err := h.adminService.ListSponsors(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Admin ListSponsors", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// CreateSponsor 创建赞助方
+// @Summary 创建赞助方（管理员）
+// @Description 创建赞助方或生态伙伴，创建后默认激活并出现在公开列表中
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.CreateSponsorRequest true "创建赞助方请求"
+// @Success 200 {object} types.APIResponse{data=types.Sponsor} "创建成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/sponsors/create [post]
+func (h *Handler) CreateSponsor(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.CreateSponsorRequest
+	if !bindRequest(c, "Admin CreateSponsor", &req) {
+		return
+	}
+
+	response, err := h.adminService.CreateSponsor(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin CreateSponsor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// UpdateSponsor 更新赞助方
+// @Summary 更新赞助方（管理员）
+// @Description 按字段更新赞助方信息，可同时调整排序权重和激活状态，未传字段保持不变
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UpdateSponsorWithIDRequest true "更新赞助方请求"
+// @Success 200 {object} types.APIResponse{data=types.Sponsor} "更新成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "赞助方不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/sponsors/update [post]
+func (h *Handler) UpdateSponsor(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateSponsorWithIDRequest
+	if !bindRequest(c, "Admin UpdateSponsor", &req) {
+		return
+	}
+
+	response, err := h.adminService.UpdateSponsor(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin UpdateSponsor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// DeleteSponsor 删除赞助方
+// @Summary 删除赞助方（管理员）
+// @Description 删除赞助方或生态伙伴；仅需临时下线时建议通过更新接口将is_active设为false
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.DeleteSponsorRequest true "删除赞助方请求"
+// @Success 200 {object} types.APIResponse "删除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "赞助方不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/sponsors/delete [post]
+func (h *Handler) DeleteSponsor(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.DeleteSponsorRequest
+	if !bindRequest(c, "Admin DeleteSponsor", &req) {
+		return
+	}
+
+	if err := h.adminService.DeleteSponsor(c.Request.Context(), meta, req.ID); err != nil {
+		respondError(c, "Admin DeleteSponsor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Sponsor deleted successfully"},
+	})
+}
+
+// ReorderSponsors 批量调整赞助方排序
+// @Summary 批量调整赞助方排序（管理员）
+// @Description 在同一事务中设置多个赞助方的排序权重（数值越大越靠前），任一赞助方不存在时整体不生效
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.ReorderSponsorsRequest true "排序请求"
+// @Success 200 {object} types.APIResponse "调整成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "赞助方不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/sponsors/reorder [post]
+func (h *Handler) ReorderSponsors(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.ReorderSponsorsRequest
+	if !bindRequest(c, "Admin ReorderSponsors", &req) {
+		return
+	}
+
+	if err := h.adminService.ReorderSponsors(c.Request.Context(), meta, &req); err != nil {
+		respondError(c, "Admin ReorderSponsors", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Sponsors reordered successfully"},
+	})
+}
+
+// ListSharedABIs 获取共享ABI列表
+// @Summary 获取共享ABI列表（管理员）
+// @Description 获取平台共享ABI列表，共享ABI对所有用户可见
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.ABIListResponse} "获取成功"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/abis/list [post]
+func (h *Handler) ListSharedABIs(c *gin.Context) {
+	response, err := h.adminService.ListSharedABIs(c.Request.Context())
+	if err != nil {
+		respondError(c, "Admin ListSharedABIs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// CreateSharedABI 创建共享ABI
+// @Summary 创建共享ABI（管理员）
+// @Description 创建平台共享ABI，ABI内容需通过格式校验，名称在共享ABI中唯一
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.CreateSharedABIRequest true "创建共享ABI请求"
+// @Success 200 {object} types.APIResponse{data=types.ABI} "创建成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或ABI格式无效"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "共享ABI名称已存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/abis/create [post]
+func (h *Handler) CreateSharedABI(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.CreateSharedABIRequest
+	if !bindRequest(c, "Admin CreateSharedABI", &req) {
+		return
+	}
+
+	response, err := h.adminService.CreateSharedABI(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin CreateSharedABI", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// UpdateSharedABI 更新共享ABI
+// @Summary 更新共享ABI（管理员）
+// @Description 更新平台共享ABI的名称、内容和描述，只能更新共享ABI
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UpdateABIWithIDRequest true "更新共享ABI请求"
+// @Success 200 {object} types.APIResponse{data=types.ABI} "更新成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或ABI格式无效"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "共享ABI不存在"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "共享ABI名称已存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/abis/update [post]
+func (h *Handler) UpdateSharedABI(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateABIWithIDRequest
+	if !bindRequest(c, "Admin UpdateSharedABI", &req) {
+		return
+	}
+
+	response, err := h.adminService.UpdateSharedABI(c.Request.Context(), meta, &req)
+	if err != nil {
+		respondError(c, "Admin UpdateSharedABI", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// DeleteSharedABI 删除共享ABI
+// @Summary 删除共享ABI（管理员）
+// @Description 删除平台共享ABI，只能删除共享ABI
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.DeleteABIRequest true "删除共享ABI请求"
+// @Success 200 {object} types.APIResponse "删除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "共享ABI不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/abis/delete [post]
+func (h *Handler) DeleteSharedABI(c *gin.Context) {
+	meta, ok := getAuditMeta(c)
+	if !ok {
+		return
+	}
+
+	var req types.DeleteABIRequest
+	if !bindRequest(c, "Admin DeleteSharedABI", &req) {
+		return
+	}
+
+	if err := h.adminService.DeleteSharedABI(c.Request.Context(), meta, req.ID); err != nil {
+		respondError(c, "Admin DeleteSharedABI", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Shared ABI deleted successfully"},
+	})
+}
+
+// ListAuditLogs 查询管理员审计日志
+// @Summary 查询管理员审计日志（管理员）
+// @Description 分页查询平台管理员的操作记录，按时间倒序，可按管理员地址和资源类型筛选。请求体可为空。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetAdminAuditLogsRequest false "筛选和分页参数"
+// @Success 200 {object} types.APIResponse{data=types.GetAdminAuditLogsResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/audit-logs/list [post]
+func (h *Handler) ListAuditLogs(c *gin.Context) {
+	var req types.GetAdminAuditLogsRequest
+	if !bindOptionalRequest(c, "Admin ListAuditLogs", &req) {
+		return
+	}
+
+	response, err := h.adminService.GetAuditLogs(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Admin ListAuditLogs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListAuditEvents 查询全平台操作记录
+// @Summary 查询操作记录（管理员）
+// @Description 分页查询全平台用户的状态变更操作和管理员后台操作，scope区分记录来源（user/admin），可按来源、操作者地址、组织、资源类型、资源ID、操作类型和时间范围筛选；按组织筛选时不包含管理员操作。请求体可为空。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetAdminAuditEventsRequest false "筛选和分页参数"
+// @Success 200 {object} types.APIResponse{data=types.GetAdminAuditEventsResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "非平台管理员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/admin/audit-events/list [post]
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	var req types.GetAdminAuditEventsRequest
+	if !bindOptionalRequest(c, "Admin ListAuditEvents", &req) {
+		return
+	}
+
+	response, err := h.auditService.GetAllEvents(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Admin ListAuditEvents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// getAuditMeta 从上下文中提取审计日志所需的管理员地址和请求来源
+func getAuditMeta(c *gin.Context) (*types.AdminAuditMeta, bool) {
+	_, walletAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		return nil, false
+	}
+	return &types.AdminAuditMeta{
+		AdminAddress: walletAddress,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}, true
+}
+
+// bindRequest 绑定请求参数，失败时写入错误响应
+func bindRequest(c *gin.Context, operation string, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error(operation+" Error: ", errors.New("invalid request parameters"), "error: ", err)
+		return false
+	}
+	return true
+}
+
+// bindOptionalRequest 绑定可选的请求体，空请求体按默认值处理
+func bindOptionalRequest(c *gin.Context, operation string, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error(operation+" Error: ", errors.New("invalid request parameters"), "error: ", err)
+		return false
+	}
+	return true
+}
+
+// respondError 将服务层错误映射为HTTP状态码和错误码
+func respondError(c *gin.Context, operation string, err error) {
+	var statusCode int
+	var errorCode string
+
+	switch {
+	case errors.Is(err, admin.ErrInvalidRPCTemplate):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_RPC_TEMPLATE"
+	case errors.Is(err, admin.ErrInvalidABI):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_ABI"
+	case errors.Is(err, admin.ErrChainNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "CHAIN_NOT_FOUND"
+	case errors.Is(err, admin.ErrSponsorNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "SPONSOR_NOT_FOUND"
+	case errors.Is(err, admin.ErrSharedABINotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "ABI_NOT_FOUND"
+	case errors.Is(err, admin.ErrChainExists):
+		statusCode = http.StatusConflict
+		errorCode = "CHAIN_EXISTS"
+	case errors.Is(err, admin.ErrChainInUse):
+		statusCode = http.StatusConflict
+		errorCode = "CHAIN_IN_USE"
+	case errors.Is(err, admin.ErrSharedABINameExists):
+		statusCode = http.StatusConflict
+		errorCode = "ABI_NAME_EXISTS"
+	default:
+		statusCode = http.StatusInternalServerError
+		errorCode = "INTERNAL_ERROR"
+	}
+
+	logger.Error(operation+" Error: ", err, "errorCode: ", errorCode)
+	c.JSON(statusCode, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    errorCode,
+			Message: err.Error(),
+		},
+	})
+}
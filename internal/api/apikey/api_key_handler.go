@@ -0,0 +1,242 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+
+	"timelocker-backend/internal/middleware"
+	"timelocker-backend/internal/service/apikey"
+	"timelocker-backend/internal/service/auth"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler API Key处理器
+type Handler struct {
+	apiKeyService apikey.Service
+	authService   auth.Service
+}
+
+// NewHandler 创建API Key处理器
+func NewHandler(apiKeyService apikey.Service, authService auth.Service) *Handler {
+	return &Handler{
+		apiKeyService: apiKeyService,
+		authService:   authService,
+	}
+}
+
+// RegisterRoutes 注册API Key路由
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// API Key管理只允许钱包签名会话访问，不允许使用API Key
+	apiKeyGroup := router.Group("/api-keys", middleware.AuthMiddleware(h.authService))
+	{
+		// 创建API Key
+		// POST /api/v1/api-keys/create
+		// http://localhost:8080/api/v1/api-keys/create
+		apiKeyGroup.POST("/create", h.CreateAPIKey)
+
+		// 获取API Key列表
+		// POST /api/v1/api-keys/list
+		// http://localhost:8080/api/v1/api-keys/list
+		apiKeyGroup.POST("/list", h.ListAPIKeys)
+
+		// 撤销API Key
+		// POST /api/v1/api-keys/revoke
+		// http://localhost:8080/api/v1/api-keys/revoke
+		apiKeyGroup.POST("/revoke", h.RevokeAPIKey)
+	}
+}
+
+// CreateAPIKey 创建API Key
+// @Summary 创建个人API Key
+// @Description 为当前用户创建用于程序化访问的API Key。明文密钥仅在创建时返回一次，服务端只保存哈希。请求时通过X-API-Key请求头携带。可选权限范围：flows:read, timelocks:read, timelocks:write, abi:read, abi:write, notifications:manage, emails:manage（写权限包含同一资源的读权限）。Safe会话创建的API Key只能访问会话绑定的链。
+// @Tags API Key
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.CreateAPIKeyRequest true "创建API Key请求"
+// @Success 200 {object} types.APIResponse{data=types.CreateAPIKeyResponse} "创建成功，返回明文密钥"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数错误; INVALID_SCOPE: 权限范围无效; API_KEY_LIMIT_EXCEEDED: 有效API Key数量已达上限"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/api-keys/create [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	userID, _, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		return
+	}
+
+	var req types.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("CreateAPIKey Error: ", errors.New("invalid request parameters"), "error: ", err)
+		return
+	}
+
+	// Safe会话创建的Key绑定到会话链
+	chainID := 0
+	if claims, ok := middleware.GetClaimsFromContext(c); ok && claims.Safe {
+		chainID = claims.ChainID
+	}
+
+	response, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), userID, chainID, &req)
+	if err != nil {
+		var statusCode int
+		var errorCode string
+
+		switch {
+		case errors.Is(err, apikey.ErrInvalidScope), errors.Is(err, apikey.ErrDuplicateScope):
+			statusCode = http.StatusBadRequest
+			errorCode = "INVALID_SCOPE"
+		case errors.Is(err, apikey.ErrInvalidKeyName):
+			statusCode = http.StatusBadRequest
+			errorCode = "INVALID_REQUEST"
+		case errors.Is(err, apikey.ErrAPIKeyLimit):
+			statusCode = http.StatusBadRequest
+			errorCode = "API_KEY_LIMIT_EXCEEDED"
+		default:
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		logger.Error("CreateAPIKey Error: ", err, "errorCode: ", errorCode)
+		c.JSON(statusCode, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    errorCode,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListAPIKeys 获取API Key列表
+// @Summary 获取个人API Key列表
+// @Description 获取当前用户的所有API Key（不含明文密钥），包括权限范围、过期时间、最后使用时间和撤销状态
+// @Tags API Key
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.APIKeyListResponse} "成功获取API Key列表"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/api-keys/list [post]
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	userID, _, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		return
+	}
+
+	response, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("ListAPIKeys Error: ", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to get api keys",
+				Details: err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RevokeAPIKey 撤销API Key
+// @Summary 撤销个人API Key
+// @Description 撤销当前用户的指定API Key，撤销后立即失效
+// @Tags API Key
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.RevokeAPIKeyRequest true "撤销API Key请求"
+// @Success 200 {object} types.APIResponse "撤销成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "API Key不存在或已撤销"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/api-keys/revoke [post]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	userID, _, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		return
+	}
+
+	var req types.RevokeAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), userID, req.ID); err != nil {
+		statusCode := http.StatusInternalServerError
+		errorCode := "INTERNAL_ERROR"
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			statusCode = http.StatusNotFound
+			errorCode = "API_KEY_NOT_FOUND"
+		}
+
+		logger.Error("RevokeAPIKey Error: ", err, "errorCode: ", errorCode)
+		c.JSON(statusCode, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    errorCode,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "API key revoked successfully"},
+	})
+}
@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"timelocker-backend/internal/middleware"
+	"timelocker-backend/internal/service/audit"
+	"timelocker-backend/internal/service/auth"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler 操作审计处理器
+type Handler struct {
+	auditService audit.Service
+	authService  auth.Service
+}
+
+// NewHandler 创建操作审计处理器
+func NewHandler(auditService audit.Service, authService auth.Service) *Handler {
+	return &Handler{
+		auditService: auditService,
+		authService:  authService,
+	}
+}
+
+// RegisterRoutes 注册操作审计路由
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// 审计记录只允许钱包签名会话查看，不允许使用API Key
+	auditGroup := router.Group("/audit", middleware.AuthMiddleware(h.authService))
+	{
+		// 查询自己的操作记录
+		// POST /api/v1/audit/events/list
+		// http://localhost:8080/api/v1/audit/events/list
+		auditGroup.POST("/events/list", h.ListMyEvents)
+
+		// 查询组织的操作记录（仅所有者）
+		// POST /api/v1/audit/organization-events/list
+		// http://localhost:8080/api/v1/audit/organization-events/list
+		auditGroup.POST("/organization-events/list", h.ListOrganizationEvents)
+	}
+}
+
+// ListMyEvents 查询自己的操作记录
+// @Summary 查询我的操作记录
+// @Description 分页查询当前钱包发起的状态变更操作（timelock导入/删除、通知配置、邮箱、ABI、组织等），按时间倒序，包含变更前后的状态快照。actor_address参数在此接口中无效。请求体可为空。
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetAuditEventsRequest false "筛选和分页参数"
+// @Success 200 {object} types.APIResponse{data=types.GetAuditEventsResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/audit/events/list [post]
+func (h *Handler) ListMyEvents(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.GetAuditEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondBindError(c, "ListMyEvents", err)
+		return
+	}
+
+	response, err := h.auditService.GetMyEvents(c.Request.Context(), walletAddress, &req)
+	if err != nil {
+		respondError(c, "ListMyEvents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListOrganizationEvents 查询组织的操作记录
+// @Summary 查询组织操作记录
+// @Description 分页查询组织资源上的全部状态变更操作，可按操作者地址、资源类型、资源ID、操作类型和时间范围筛选，仅组织所有者可查看
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.GetOrganizationAuditEventsRequest true "组织ID、筛选和分页参数"
+// @Success 200 {object} types.APIResponse{data=types.GetAuditEventsResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "不是组织所有者"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/audit/organization-events/list [post]
+func (h *Handler) ListOrganizationEvents(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.GetOrganizationAuditEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, "ListOrganizationEvents", err)
+		return
+	}
+
+	response, err := h.auditService.GetOrganizationEvents(c.Request.Context(), walletAddress, &req)
+	if err != nil {
+		respondError(c, "ListOrganizationEvents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// getUser 从上下文获取当前用户，未认证时写入错误响应
+func getUser(c *gin.Context) (int64, string, bool) {
+	userID, walletAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+	}
+	return userID, walletAddress, ok
+}
+
+// respondBindError 写入请求参数错误响应
+func respondBindError(c *gin.Context, operation string, err error) {
+	c.JSON(http.StatusBadRequest, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    "INVALID_REQUEST",
+			Message: "Invalid request parameters",
+			Details: err.Error(),
+		},
+	})
+	logger.Error(operation+" Error: ", errors.New("invalid request parameters"), "error: ", err)
+}
+
+// respondError 将服务层错误映射为HTTP状态码和错误码
+func respondError(c *gin.Context, operation string, err error) {
+	var statusCode int
+	var errorCode string
+
+	switch {
+	case errors.Is(err, audit.ErrPermissionDenied):
+		statusCode = http.StatusForbidden
+		errorCode = "PERMISSION_DENIED"
+	default:
+		statusCode = http.StatusInternalServerError
+		errorCode = "INTERNAL_ERROR"
+	}
+
+	logger.Error(operation+" Error: ", err, "errorCode: ", errorCode)
+	c.JSON(statusCode, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    errorCode,
+			Message: err.Error(),
+		},
+	})
+}
@@ -49,18 +49,73 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		// POST /api/v1/auth/profile
 		// http://localhost:8080/api/v1/auth/profile
 		authGroup.POST("/profile", middleware.AuthMiddleware(h.authService), h.GetProfile)
+
+		// 登出当前会话
+		// POST /api/v1/auth/logout
+		// http://localhost:8080/api/v1/auth/logout
+		authGroup.POST("/logout", middleware.AuthMiddleware(h.authService), h.Logout)
+
+		// 登出所有设备
+		// POST /api/v1/auth/logout-all
+		// http://localhost:8080/api/v1/auth/logout-all
+		authGroup.POST("/logout-all", middleware.AuthMiddleware(h.authService), h.LogoutAll)
+	}
+
+	// 关联钱包API组，只允许钱包签名会话操作，不允许使用API Key
+	walletGroup := router.Group("/auth/wallets", middleware.AuthMiddleware(h.authService))
+	{
+		// 获取关联钱包的签名消息
+		// POST /api/v1/auth/wallets/link-nonce
+		// http://localhost:8080/api/v1/auth/wallets/link-nonce
+		walletGroup.POST("/link-nonce", h.GetLinkWalletNonce)
+
+		// 关联钱包
+		// POST /api/v1/auth/wallets/link
+		// http://localhost:8080/api/v1/auth/wallets/link
+		walletGroup.POST("/link", h.LinkWallet)
+
+		// 解除关联钱包
+		// POST /api/v1/auth/wallets/unlink
+		// http://localhost:8080/api/v1/auth/wallets/unlink
+		walletGroup.POST("/unlink", h.UnlinkWallet)
+
+		// 获取账户的钱包列表
+		// POST /api/v1/auth/wallets/list
+		// http://localhost:8080/api/v1/auth/wallets/list
+		walletGroup.POST("/list", h.ListLinkedWallets)
 	}
 }
 
+// RegisterWellKnownRoutes 注册根路径下的公开发现端点
+func (h *Handler) RegisterWellKnownRoutes(router gin.IRoutes) {
+	// 令牌验证公钥集合
+	// GET /.well-known/jwks.json
+	// http://localhost:8080/.well-known/jwks.json
+	router.GET("/.well-known/jwks.json", h.GetJWKS)
+}
+
+// GetJWKS 获取令牌验证公钥集合
+// @Summary 获取令牌验证公钥（JWKS）
+// @Description 返回当前所有可用于验证访问令牌和刷新令牌的公钥（RFC 7517），令牌头中的kid对应公钥的kid。其他服务可通过此端点验证本服务签发的令牌（同时需校验iss和type=access）。密钥轮换期间会同时返回新旧公钥。仅使用HS256密钥签名时返回空列表。
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} types.JWKSet "公钥集合"
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.GetJWKS())
+}
+
 // GetNonce 获取认证nonce
 // @Summary 获取认证nonce
-// @Description 获取用于钱包签名认证的随机nonce。前端需要先调用此接口获取nonce和消息，然后让用户对消息进行签名，最后调用wallet-connect接口完成认证。
+// @Description 获取用于钱包签名认证的随机nonce和SIWE (EIP-4361) 格式的签名消息。前端需要先调用此接口获取nonce和消息，然后让用户对消息进行签名，最后调用wallet-connect接口完成认证。chain_id必须在支持链列表中，domain必须在服务端配置的域名白名单中。
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param request body types.GetNonceRequest true "获取nonce请求"
 // @Success 200 {object} types.APIResponse{data=types.GetNonceResponse} "成功获取nonce和签名消息"
-// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_WALLET_ADDRESS: 钱包地址格式无效; INVALID_DOMAIN: 域名不在白名单中; UNSUPPORTED_CHAIN: 不支持的链"
+// @Failure 429 {object} types.APIResponse{error=types.APIError} "请求过于频繁（RATE_LIMITED），响应头Retry-After为需等待的秒数"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
 // @Router /api/v1/auth/nonce [post]
 func (h *Handler) GetNonce(c *gin.Context) {
@@ -83,10 +138,16 @@ func (h *Handler) GetNonce(c *gin.Context) {
 		var statusCode int
 		var errorCode string
 
-		switch err {
-		case auth.ErrInvalidAddress:
+		switch {
+		case errors.Is(err, auth.ErrInvalidAddress):
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_WALLET_ADDRESS"
+		case errors.Is(err, auth.ErrInvalidDomain):
+			statusCode = http.StatusBadRequest
+			errorCode = "INVALID_DOMAIN"
+		case errors.Is(err, auth.ErrUnsupportedChain):
+			statusCode = http.StatusBadRequest
+			errorCode = "UNSUPPORTED_CHAIN"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -112,14 +173,14 @@ func (h *Handler) GetNonce(c *gin.Context) {
 
 // WalletConnect 钱包连接认证
 // @Summary 钱包连接认证（支持EOA和Safe钱包）
-// @Description 通过钱包进行用户认证。EOA钱包：1.先调用/auth/nonce获取随机nonce和消息 2.让用户对消息进行签名 3.调用此接口完成认证。Safe钱包：直接提供Safe地址和chain_id即可，系统会验证地址是否为有效的Safe合约。
+// @Description 通过钱包进行用户认证。1.先调用/auth/nonce获取随机nonce和SIWE消息 2.让用户对消息进行签名 3.调用此接口完成认证。服务端会严格校验SIWE消息的域名、URI、有效期、链ID和nonce，令牌中记录签名时的链ID和域名。Safe钱包需要通过Safe对消息签名（链上signMessage时signature传"0x"，或提交按地址升序拼接的owner签名），系统通过EIP-1271 isValidSignature或owner阈值校验签名，签发的令牌绑定到签名消息中的链。
 // @Tags Authentication
 // @Accept json
 // @Produce json
-// @Param request body types.WalletConnectRequest true "钱包连接认证请求体。EOA钱包需要nonce、message和signature。Safe钱包只需要wallet_address、wallet_type='safe'和chain_id"
+// @Param request body types.WalletConnectRequest true "钱包连接认证请求体。需要nonce、message和signature，Safe钱包还需要wallet_type='safe'"
 // @Success 200 {object} types.APIResponse{data=types.WalletConnectResponse} "认证成功，返回访问令牌和用户信息"
-// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_WALLET_ADDRESS: 钱包地址格式无效; MISSING_REQUIRED_FIELDS: EOA钱包缺少必需字段; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约"
-// @Failure 401 {object} types.APIResponse{error=types.APIError} "认证失败 - INVALID_SIGNATURE: 签名验证失败; SIGNATURE_RECOVERY_FAILED: 无法从签名恢复地址; INVALID_NONCE: nonce无效或已过期; NONCE_ALREADY_USED: nonce已被使用"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_WALLET_ADDRESS: 钱包地址格式无效; MISSING_REQUIRED_FIELDS: 缺少必需字段; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约; UNSUPPORTED_CHAIN: 不支持的链"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "认证失败 - INVALID_SIGNATURE: 签名验证失败; SIGNATURE_RECOVERY_FAILED: 无法从签名恢复地址; INVALID_NONCE: nonce无效或已过期; NONCE_ALREADY_USED: nonce已被使用; CHAIN_MISMATCH: chain_id与签名消息不一致; INVALID_SIWE_MESSAGE: 签名消息格式错误或已过期; INVALID_DOMAIN: 签名消息域名不在白名单中"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 服务器内部错误; DATABASE_ERROR: 数据库操作失败; TOKEN_GENERATION_FAILED: JWT令牌生成失败"
 // @Router /api/v1/auth/wallet-connect [post]
 func (h *Handler) WalletConnect(c *gin.Context) {
@@ -138,6 +199,9 @@ func (h *Handler) WalletConnect(c *gin.Context) {
 		return
 	}
 
+	// 记录客户端信息到会话
+	req.Client = sessionClient(c)
+
 	// 调用认证服务，返回响应数据
 	response, err := h.authService.WalletConnect(c.Request.Context(), &req)
 	if err != nil {
@@ -149,10 +213,10 @@ func (h *Handler) WalletConnect(c *gin.Context) {
 		case err == auth.ErrInvalidAddress:
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_WALLET_ADDRESS"
-		case err == auth.ErrInvalidSignature:
+		case errors.Is(err, auth.ErrInvalidSignature):
 			statusCode = http.StatusUnauthorized
 			errorCode = "INVALID_SIGNATURE"
-		case err == auth.ErrSignatureRecovery:
+		case errors.Is(err, auth.ErrSignatureRecovery):
 			statusCode = http.StatusUnauthorized
 			errorCode = "SIGNATURE_RECOVERY_FAILED"
 		case err == auth.ErrInvalidNonce:
@@ -161,7 +225,19 @@ func (h *Handler) WalletConnect(c *gin.Context) {
 		case err == auth.ErrNonceUsed:
 			statusCode = http.StatusUnauthorized
 			errorCode = "NONCE_ALREADY_USED"
-		case strings.Contains(err.Error(), "EOA wallet requires"):
+		case errors.Is(err, auth.ErrChainMismatch):
+			statusCode = http.StatusUnauthorized
+			errorCode = "CHAIN_MISMATCH"
+		case errors.Is(err, auth.ErrInvalidSiwe):
+			statusCode = http.StatusUnauthorized
+			errorCode = "INVALID_SIWE_MESSAGE"
+		case errors.Is(err, auth.ErrInvalidDomain):
+			statusCode = http.StatusUnauthorized
+			errorCode = "INVALID_DOMAIN"
+		case errors.Is(err, auth.ErrUnsupportedChain):
+			statusCode = http.StatusBadRequest
+			errorCode = "UNSUPPORTED_CHAIN"
+		case strings.Contains(err.Error(), "wallet requires"):
 			statusCode = http.StatusBadRequest
 			errorCode = "MISSING_REQUIRED_FIELDS"
 		case strings.Contains(err.Error(), "not a valid Safe contract"):
@@ -170,7 +246,7 @@ func (h *Handler) WalletConnect(c *gin.Context) {
 		case strings.Contains(err.Error(), "failed to create user"):
 			statusCode = http.StatusInternalServerError
 			errorCode = "DATABASE_ERROR"
-		case strings.Contains(err.Error(), "failed to generate jwt tokens"):
+		case strings.Contains(err.Error(), "failed to generate jwt tokens"), strings.Contains(err.Error(), "failed to create session"):
 			statusCode = http.StatusInternalServerError
 			errorCode = "TOKEN_GENERATION_FAILED"
 		case strings.Contains(err.Error(), "database error"):
@@ -200,14 +276,14 @@ func (h *Handler) WalletConnect(c *gin.Context) {
 
 // RefreshToken 刷新访问令牌
 // @Summary 刷新访问令牌
-// @Description 使用刷新令牌获取新的访问令牌。当访问令牌过期时，前端可以使用此接口通过刷新令牌重新获取新的访问令牌和刷新令牌，无需重新进行钱包签名认证。
+// @Description 使用刷新令牌获取新的访问令牌。当访问令牌过期时，前端可以使用此接口通过刷新令牌重新获取新的访问令牌和刷新令牌，无需重新进行钱包签名认证。刷新令牌只能使用一次，每次刷新都会返回新的刷新令牌；已使用过的刷新令牌再次提交会被视为重放，该次登录的所有会话将被撤销，需要重新签名登录。
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param request body types.RefreshTokenRequest true "刷新令牌请求体"
 // @Success 200 {object} types.APIResponse{data=types.WalletConnectResponse} "刷新成功，返回新的访问令牌和刷新令牌"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
-// @Failure 401 {object} types.APIResponse{error=types.APIError} "刷新令牌无效或已过期"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "INVALID_REFRESH_TOKEN: 刷新令牌无效或已过期; TOKEN_REVOKED: 会话已登出或被撤销; TOKEN_REUSED: 刷新令牌被重复使用，会话已全部撤销"
 // @Failure 404 {object} types.APIResponse{error=types.APIError} "用户不存在"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
 // @Router /api/v1/auth/refresh-token [post]
@@ -227,17 +303,26 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	// 记录客户端信息到会话
+	req.Client = sessionClient(c)
+
 	// 调用认证服务
 	response, err := h.authService.RefreshToken(c.Request.Context(), &req)
 	if err != nil {
 		var statusCode int
 		var errorCode string
 
-		switch err {
-		case auth.ErrInvalidToken:
+		switch {
+		case errors.Is(err, auth.ErrTokenReused):
+			statusCode = http.StatusUnauthorized
+			errorCode = "TOKEN_REUSED"
+		case errors.Is(err, auth.ErrTokenRevoked):
+			statusCode = http.StatusUnauthorized
+			errorCode = "TOKEN_REVOKED"
+		case errors.Is(err, auth.ErrInvalidToken):
 			statusCode = http.StatusUnauthorized
 			errorCode = "INVALID_REFRESH_TOKEN"
-		case auth.ErrUserNotFound:
+		case errors.Is(err, auth.ErrUserNotFound):
 			statusCode = http.StatusNotFound
 			errorCode = "USER_NOT_FOUND"
 		default:
@@ -320,3 +405,343 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		Data:    profile,
 	})
 }
+
+// Logout 登出当前会话
+// @Summary 登出当前会话
+// @Description 撤销当前登录会话：当前访问令牌立即失效，该次登录轮换产生的所有刷新令牌均不可再使用。需要有效的JWT令牌。
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.LogoutResponse} "登出成功"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	claims, ok := middleware.GetClaimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		logger.Error("Logout Error: ", errors.New("user not authenticated"))
+		return
+	}
+
+	response, err := h.authService.Logout(c.Request.Context(), claims)
+	if err != nil {
+		logger.Error("Logout Error: ", err, "user_id", claims.UserID)
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	logger.Info("Logout: ", "user_id", claims.UserID)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// LogoutAll 登出所有设备
+// @Summary 登出所有设备
+// @Description 撤销当前用户在所有设备上的登录会话：所有访问令牌立即失效，所有刷新令牌均不可再使用。需要有效的JWT令牌。
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.LogoutResponse} "登出成功，返回撤销的会话数量"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	claims, ok := middleware.GetClaimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		logger.Error("LogoutAll Error: ", errors.New("user not authenticated"))
+		return
+	}
+
+	response, err := h.authService.LogoutAll(c.Request.Context(), claims)
+	if err != nil {
+		logger.Error("LogoutAll Error: ", err, "user_id", claims.UserID)
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	logger.Info("LogoutAll: ", "user_id", claims.UserID, "revoked_sessions", response.RevokedSessions)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetLinkWalletNonce 获取关联钱包的签名消息
+// @Summary 获取关联钱包签名消息
+// @Description 为待关联的钱包生成SIWE (EIP-4361) 格式的签名消息，消息声明为"Link this wallet to TimeLocker account <主钱包地址>"，需由待关联的钱包签名后调用/auth/wallets/link。待关联钱包不能是当前账户的主钱包、不能已关联到其他账户，也不能是已有关联钱包的账户主钱包；每个账户最多关联20个钱包。
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.LinkWalletNonceRequest true "待关联的钱包地址和链ID"
+// @Success 200 {object} types.APIResponse{data=types.GetNonceResponse} "成功获取签名消息"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_WALLET_ADDRESS; INVALID_DOMAIN; UNSUPPORTED_CHAIN; CANNOT_LINK_PRIMARY: 不能关联主钱包; LINKED_WALLET_LIMIT: 关联数量已达上限"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "WALLET_ALREADY_LINKED: 钱包已被关联; WALLET_HAS_LINKED_WALLETS: 钱包是已有关联钱包的账户主钱包; ACCOUNT_IS_LINKED: 当前账户已被关联到其他账户"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/wallets/link-nonce [post]
+func (h *Handler) GetLinkWalletNonce(c *gin.Context) {
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.LinkWalletNonceRequest
+	if !bindRequest(c, "GetLinkWalletNonce", &req) {
+		return
+	}
+
+	response, err := h.authService.GetLinkWalletNonce(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondLinkedWalletError(c, "GetLinkWalletNonce", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// LinkWallet 关联钱包
+// @Summary 关联钱包
+// @Description 提交待关联钱包对关联签名消息的签名，证明对该钱包的控制权后将其关联到当前账户。EOA直接校验签名，Safe钱包（wallet_type='safe'）通过EIP-1271 isValidSignature或owner阈值签名校验。关联后使用该钱包登录将进入当前账户，timelock列表、详情权限和流程列表按账户下所有钱包合并计算。被关联钱包原有账户的邮箱、通知配置等数据不会合并。
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.LinkWalletRequest true "待关联的钱包地址、签名消息、签名和nonce"
+// @Success 200 {object} types.APIResponse{data=types.LinkedWallet} "关联成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_WALLET_ADDRESS; INVALID_SAFE_CONTRACT: 地址不是有效的Safe合约; CANNOT_LINK_PRIMARY; LINKED_WALLET_LIMIT"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或签名校验失败 - INVALID_SIGNATURE; SIGNATURE_RECOVERY_FAILED; INVALID_NONCE; NONCE_ALREADY_USED; INVALID_SIWE_MESSAGE: 签名消息无效或不是针对当前账户的关联消息; INVALID_DOMAIN"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "WALLET_ALREADY_LINKED; WALLET_HAS_LINKED_WALLETS; ACCOUNT_IS_LINKED"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/wallets/link [post]
+func (h *Handler) LinkWallet(c *gin.Context) {
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.LinkWalletRequest
+	if !bindRequest(c, "LinkWallet", &req) {
+		return
+	}
+
+	linked, err := h.authService.LinkWallet(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondLinkedWalletError(c, "LinkWallet", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    linked,
+	})
+}
+
+// UnlinkWallet 解除关联钱包
+// @Summary 解除关联钱包
+// @Description 从当前账户解除关联钱包，使用该钱包登录签发的令牌随之失效。不能解除账户的主钱包。
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UnlinkWalletRequest true "待解除关联的钱包地址"
+// @Success 200 {object} types.APIResponse "解除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - CANNOT_LINK_PRIMARY: 不能解除主钱包"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "LINKED_WALLET_NOT_FOUND: 钱包未关联到当前账户"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/wallets/unlink [post]
+func (h *Handler) UnlinkWallet(c *gin.Context) {
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.UnlinkWalletRequest
+	if !bindRequest(c, "UnlinkWallet", &req) {
+		return
+	}
+
+	if err := h.authService.UnlinkWallet(c.Request.Context(), userID, &req); err != nil {
+		respondLinkedWalletError(c, "UnlinkWallet", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+	})
+}
+
+// ListLinkedWallets 获取账户的钱包列表
+// @Summary 获取账户钱包列表
+// @Description 获取当前账户的主钱包地址和所有关联钱包
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.LinkedWalletListResponse} "获取成功"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/auth/wallets/list [post]
+func (h *Handler) ListLinkedWallets(c *gin.Context) {
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.authService.GetLinkedWallets(c.Request.Context(), userID)
+	if err != nil {
+		respondLinkedWalletError(c, "ListLinkedWallets", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// getUser 从上下文获取当前用户，未认证时写入错误响应
+func getUser(c *gin.Context) (int64, string, bool) {
+	userID, walletAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+	}
+	return userID, walletAddress, ok
+}
+
+// bindRequest 绑定请求参数，失败时写入错误响应
+func bindRequest(c *gin.Context, operation string, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error(operation+" Error: ", errors.New("invalid request parameters"), "error: ", err)
+		return false
+	}
+	return true
+}
+
+// respondLinkedWalletError 将关联钱包相关的服务层错误映射为HTTP状态码和错误码
+func respondLinkedWalletError(c *gin.Context, operation string, err error) {
+	var statusCode int
+	var errorCode string
+
+	switch {
+	case errors.Is(err, auth.ErrInvalidAddress):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_WALLET_ADDRESS"
+	case errors.Is(err, auth.ErrCannotLinkPrimary):
+		statusCode = http.StatusBadRequest
+		errorCode = "CANNOT_LINK_PRIMARY"
+	case errors.Is(err, auth.ErrLinkedWalletLimit):
+		statusCode = http.StatusBadRequest
+		errorCode = "LINKED_WALLET_LIMIT"
+	case errors.Is(err, auth.ErrUnsupportedChain):
+		statusCode = http.StatusBadRequest
+		errorCode = "UNSUPPORTED_CHAIN"
+	case errors.Is(err, auth.ErrWalletAlreadyLinked):
+		statusCode = http.StatusConflict
+		errorCode = "WALLET_ALREADY_LINKED"
+	case errors.Is(err, auth.ErrWalletHasLinkedWallets):
+		statusCode = http.StatusConflict
+		errorCode = "WALLET_HAS_LINKED_WALLETS"
+	case errors.Is(err, auth.ErrAccountIsLinked):
+		statusCode = http.StatusConflict
+		errorCode = "ACCOUNT_IS_LINKED"
+	case errors.Is(err, auth.ErrLinkedWalletNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "LINKED_WALLET_NOT_FOUND"
+	case errors.Is(err, auth.ErrUserNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "USER_NOT_FOUND"
+	case errors.Is(err, auth.ErrInvalidSignature):
+		statusCode = http.StatusUnauthorized
+		errorCode = "INVALID_SIGNATURE"
+	case errors.Is(err, auth.ErrSignatureRecovery):
+		statusCode = http.StatusUnauthorized
+		errorCode = "SIGNATURE_RECOVERY_FAILED"
+	case errors.Is(err, auth.ErrInvalidNonce):
+		statusCode = http.StatusUnauthorized
+		errorCode = "INVALID_NONCE"
+	case errors.Is(err, auth.ErrNonceUsed):
+		statusCode = http.StatusUnauthorized
+		errorCode = "NONCE_ALREADY_USED"
+	case errors.Is(err, auth.ErrChainMismatch):
+		statusCode = http.StatusUnauthorized
+		errorCode = "CHAIN_MISMATCH"
+	case errors.Is(err, auth.ErrInvalidSiwe):
+		statusCode = http.StatusUnauthorized
+		errorCode = "INVALID_SIWE_MESSAGE"
+	case errors.Is(err, auth.ErrInvalidDomain):
+		statusCode = http.StatusUnauthorized
+		errorCode = "INVALID_DOMAIN"
+	case strings.Contains(err.Error(), "not a valid Safe contract"):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_SAFE_CONTRACT"
+	default:
+		statusCode = http.StatusInternalServerError
+		errorCode = "INTERNAL_ERROR"
+	}
+
+	logger.Error(operation+" Error: ", err, "errorCode: ", errorCode)
+	c.JSON(statusCode, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    errorCode,
+			Message: err.Error(),
+		},
+	})
+}
+
+// sessionClient 获取请求的客户端信息
+func sessionClient(c *gin.Context) types.SessionClient {
+	return types.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
@@ -30,7 +30,7 @@ func NewEmailHandler(emailService email.EmailService, authService auth.Service)
 // RegisterRoutes 注册邮箱相关路由
 func (h *EmailHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// 邮箱API组 - 需要认证
-	emailGroup := router.Group("/emails", middleware.AuthMiddleware(h.authService))
+	emailGroup := router.Group("/emails", middleware.AuthMiddleware(h.authService, types.APIKeyScopeEmailsManage))
 	{
 		// 邮箱管理：不再暴露单独的添加邮箱接口，改为通过发送验证码自动创建/复用未验证记录
 		// 获取邮箱列表
@@ -41,6 +41,18 @@ func (h *EmailHandler) RegisterRoutes(router *gin.RouterGroup) {
 		// POST /api/v1/emails/remark
 		// http://localhost:8080/api/v1/emails/remark
 		emailGroup.POST("/remark", h.UpdateEmailRemark)
+		// 更新邮箱订阅过滤规则
+		// POST /api/v1/emails/filters
+		// http://localhost:8080/api/v1/emails/filters
+		emailGroup.POST("/filters", h.UpdateEmailFilters)
+		// 更新邮箱自定义邮件模板
+		// POST /api/v1/emails/template
+		// http://localhost:8080/api/v1/emails/template
+		emailGroup.POST("/template", h.UpdateEmailTemplate)
+		// 更新邮箱摘要模式
+		// POST /api/v1/emails/digest
+		// http://localhost:8080/api/v1/emails/digest
+		emailGroup.POST("/digest", h.UpdateEmailDigest)
 		// 删除邮箱
 		// POST /api/v1/emails/delete
 		// http://localhost:8080/api/v1/emails/delete
@@ -69,6 +81,7 @@ func (h *EmailHandler) RegisterRoutes(router *gin.RouterGroup) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetEmailsRequest false "分页参数"
 // @Success 200 {object} types.APIResponse{data=types.EmailListResponse}
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
@@ -119,6 +132,7 @@ func (h *EmailHandler) GetEmails(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.UpdateEmailRemarkWithIDRequest true "更新备注请求（包含ID）"
 // @Success 200 {object} types.APIResponse
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
@@ -174,6 +188,179 @@ func (h *EmailHandler) UpdateEmailRemark(c *gin.Context) {
 	})
 }
 
+// UpdateEmailFilters 更新邮箱订阅过滤规则
+// @Summary 更新邮箱订阅过滤规则
+// @Description 设置指定邮箱接收哪些流程通知，可按合约地址、链ID、目标状态、函数选择器或签名、最小原生代币数量(wei)过滤。filters 不填或为空对象时清除过滤，接收全部通知
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.UpdateEmailFiltersRequest true "更新过滤规则请求（包含ID）"
+// @Success 200 {object} types.APIResponse
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邮箱不存在"
+// @Failure 422 {object} types.APIResponse{error=types.APIError} "过滤规则校验失败"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/emails/filters [post]
+func (h *EmailHandler) UpdateEmailFilters(c *gin.Context) {
+	// 获取用户ID
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{Success: false, Error: &types.APIError{Code: "UNAUTHORIZED", Message: "User not authenticated"}})
+		return
+	}
+
+	userIDInt, ok := userID.(int64)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Invalid user ID format"}})
+		return
+	}
+
+	var req types.UpdateEmailFiltersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Invalid request body", err)
+		c.JSON(http.StatusBadRequest, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_REQUEST", Message: "Invalid request body", Details: err.Error()}})
+		return
+	}
+
+	err := h.emailService.UpdateEmailFilters(c.Request.Context(), req.ID, userIDInt, req.Filters)
+	if err != nil {
+		logger.Error("Failed to update email filters", err, "userID", userIDInt, "userEmailID", req.ID)
+		if err.Error() == "user email not found" {
+			c.JSON(http.StatusNotFound, types.APIResponse{Success: false, Error: &types.APIError{Code: "EMAIL_NOT_FOUND", Message: "Email not found"}})
+			return
+		}
+		if strings.Contains(err.Error(), "invalid filters") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_FILTERS", Message: "Invalid filters", Details: err.Error()}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Failed to update email filters", Details: err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Email filters updated successfully"},
+	})
+}
+
+// UpdateEmailTemplate 更新邮箱自定义邮件模板
+// @Summary 更新邮箱自定义邮件模板
+// @Description 设置指定邮箱的流程通知邮件模板（Go html/template），保存前会使用示例数据试渲染，可用变量与限制见 /api/v1/notifications/template/preview。template 不填或为空时恢复默认模板
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.UpdateEmailTemplateRequest true "更新模板请求（包含ID）"
+// @Success 200 {object} types.APIResponse
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邮箱不存在"
+// @Failure 422 {object} types.APIResponse{error=types.APIError} "模板校验失败"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/emails/template [post]
+func (h *EmailHandler) UpdateEmailTemplate(c *gin.Context) {
+	// 获取用户ID
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{Success: false, Error: &types.APIError{Code: "UNAUTHORIZED", Message: "User not authenticated"}})
+		return
+	}
+
+	userIDInt, ok := userID.(int64)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Invalid user ID format"}})
+		return
+	}
+
+	var req types.UpdateEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Invalid request body", err)
+		c.JSON(http.StatusBadRequest, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_REQUEST", Message: "Invalid request body", Details: err.Error()}})
+		return
+	}
+
+	err := h.emailService.UpdateEmailTemplate(c.Request.Context(), req.ID, userIDInt, req.Template)
+	if err != nil {
+		logger.Error("Failed to update email template", err, "userID", userIDInt, "userEmailID", req.ID)
+		if err.Error() == "user email not found" {
+			c.JSON(http.StatusNotFound, types.APIResponse{Success: false, Error: &types.APIError{Code: "EMAIL_NOT_FOUND", Message: "Email not found"}})
+			return
+		}
+		if strings.Contains(err.Error(), "invalid template") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_TEMPLATE", Message: "Invalid email template", Details: err.Error()}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Failed to update email template", Details: err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Email template updated successfully"},
+	})
+}
+
+// UpdateEmailDigest 更新邮箱摘要模式
+// @Summary 更新邮箱摘要模式
+// @Description 设置指定邮箱的通知方式：off 逐条实时发送；daily/weekly 不再逐条发送，改为每日/每周汇总一封摘要邮件（新排队、当前可执行、即将过期、已执行、已取消）
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.UpdateEmailDigestRequest true "更新摘要模式请求（包含ID）"
+// @Success 200 {object} types.APIResponse
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邮箱不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/emails/digest [post]
+func (h *EmailHandler) UpdateEmailDigest(c *gin.Context) {
+	// 获取用户ID
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{Success: false, Error: &types.APIError{Code: "UNAUTHORIZED", Message: "User not authenticated"}})
+		return
+	}
+
+	userIDInt, ok := userID.(int64)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Invalid user ID format"}})
+		return
+	}
+
+	var req types.UpdateEmailDigestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Invalid request body", err)
+		c.JSON(http.StatusBadRequest, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_REQUEST", Message: "Invalid request body", Details: err.Error()}})
+		return
+	}
+
+	err := h.emailService.UpdateEmailDigestMode(c.Request.Context(), req.ID, userIDInt, req.DigestMode)
+	if err != nil {
+		logger.Error("Failed to update email digest mode", err, "userID", userIDInt, "userEmailID", req.ID)
+		if err.Error() == "user email not found" {
+			c.JSON(http.StatusNotFound, types.APIResponse{Success: false, Error: &types.APIError{Code: "EMAIL_NOT_FOUND", Message: "Email not found"}})
+			return
+		}
+		if strings.Contains(err.Error(), "invalid digest mode") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{Success: false, Error: &types.APIError{Code: "INVALID_DIGEST_MODE", Message: "Invalid digest mode. Supported modes: off, daily, weekly", Details: err.Error()}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.APIResponse{Success: false, Error: &types.APIError{Code: "INTERNAL_ERROR", Message: "Failed to update email digest mode", Details: err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Email digest mode updated successfully"},
+	})
+}
+
 // DeleteEmail 删除邮箱
 // @Summary 删除邮箱
 // @Description 删除指定的邮箱地址
@@ -181,6 +368,7 @@ func (h *EmailHandler) UpdateEmailRemark(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.DeleteEmailRequest true "删除邮箱请求（包含ID）"
 // @Success 200 {object} types.APIResponse
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
@@ -230,17 +418,19 @@ func (h *EmailHandler) DeleteEmail(c *gin.Context) {
 
 // SendVerificationCode 发送验证码
 // @Summary 发送验证码
-// @Description 基于邮箱发送验证码。后端会自动创建/复用未验证记录，并允许更新备注。email 必填，remark 最长200字符。
+// @Description 基于邮箱发送验证码。后端会自动创建/复用未验证记录，并允许更新备注。email 必填，remark 最长200字符。传入 organization_id 时添加为组织共享邮箱，需要组织编辑者及以上角色。
 // @Tags Email
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.SendVerificationCodeRequest true "发送验证码请求（email 必填，remark 可选，最长200）"
 // @Success 200 {object} types.APIResponse
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误（缺少email）"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "权限不足（INSUFFICIENT_ORGANIZATION_ROLE）"
 // @Failure 404 {object} types.APIResponse{error=types.APIError} "邮箱不存在"
-// @Failure 429 {object} types.APIResponse{error=types.APIError} "发送过于频繁"
+// @Failure 429 {object} types.APIResponse{error=types.APIError} "发送过于频繁（RATE_LIMITED时响应头Retry-After为需等待的秒数）"
 // @Failure 422 {object} types.APIResponse{error=types.APIError} "参数校验失败（INVALID_EMAIL_FORMAT / INVALID_REMARK）"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
 // @Router /api/v1/emails/send-verification [post]
@@ -288,10 +478,13 @@ func (h *EmailHandler) SendVerificationCode(c *gin.Context) {
 		return
 	}
 
-	err := h.emailService.SendVerificationCodeByEmail(c.Request.Context(), userIDInt, req.Email, req.Remark)
+	err := h.emailService.SendVerificationCodeByEmail(c.Request.Context(), userIDInt, req.OrganizationID, req.Email, req.Remark)
 	if err != nil {
 		logger.Error("Failed to send verification code", err, "userID", userIDInt, "email", req.Email)
 		switch err.Error() {
+		case "insufficient organization role":
+			c.JSON(http.StatusForbidden, types.APIResponse{Success: false, Error: &types.APIError{Code: "INSUFFICIENT_ORGANIZATION_ROLE", Message: "Organization editor role is required to add shared emails", Details: err.Error()}})
+			return
 		case "email already verified":
 			c.JSON(http.StatusConflict, types.APIResponse{Success: false, Error: &types.APIError{Code: "EMAIL_ALREADY_VERIFIED", Message: "Email already verified", Details: err.Error()}})
 			return
@@ -312,15 +505,17 @@ func (h *EmailHandler) SendVerificationCode(c *gin.Context) {
 
 // VerifyEmail 验证邮箱
 // @Summary 验证邮箱
-// @Description 使用验证码验证邮箱地址。email 必填，code 为6位数字。
+// @Description 使用验证码验证邮箱地址。email 必填，code 为6位数字。组织共享邮箱需传入与发送验证码时一致的 organization_id。
 // @Tags Email
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.VerifyEmailRequest true "验证邮箱请求（email+code）"
 // @Success 200 {object} types.APIResponse
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未授权"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "权限不足（INSUFFICIENT_ORGANIZATION_ROLE）"
 // @Failure 404 {object} types.APIResponse{error=types.APIError} "邮箱不存在"
 // @Failure 422 {object} types.APIResponse{error=types.APIError} "验证码无效或已过期 / 参数校验失败（INVALID_EMAIL_FORMAT / INVALID_CODE_FORMAT）"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
@@ -366,9 +561,13 @@ func (h *EmailHandler) VerifyEmail(c *gin.Context) {
 		return
 	}
 
-	err := h.emailService.VerifyEmailByEmail(c.Request.Context(), userIDInt, req.Email, req.Code)
+	err := h.emailService.VerifyEmailByEmail(c.Request.Context(), userIDInt, req.OrganizationID, req.Email, req.Code)
 	if err != nil {
 		logger.Error("Failed to verify email", err, "userID", userIDInt, "email", req.Email)
+		if err.Error() == "insufficient organization role" {
+			c.JSON(http.StatusForbidden, types.APIResponse{Success: false, Error: &types.APIError{Code: "INSUFFICIENT_ORGANIZATION_ROLE", Message: "Organization editor role is required to verify shared emails"}})
+			return
+		}
 		if err.Error() == "user email not found" {
 			c.JSON(http.StatusNotFound, types.APIResponse{Success: false, Error: &types.APIError{Code: "EMAIL_NOT_FOUND", Message: "Email not found"}})
 			return
@@ -35,11 +35,11 @@ func (h *FlowHandler) RegisterRoutes(router *gin.RouterGroup) {
 		// 获取与用户相关的流程列表（需要鉴权）
 		// POST /api/v1/flows/list
 		// http://localhost:8080/api/v1/flows/list
-		flows.POST("/list", middleware.AuthMiddleware(h.authService), h.GetFlowList)
+		flows.POST("/list", middleware.AuthMiddleware(h.authService, types.APIKeyScopeFlowsRead), h.GetFlowList)
 		// 获取与用户相关的流程数量统计（需要鉴权）
 		// POST /api/v1/flows/list/count
 		// http://localhost:8080/api/v1/flows/list/count
-		flows.POST("/list/count", middleware.AuthMiddleware(h.authService), h.GetFlowListCount)
+		flows.POST("/list/count", middleware.AuthMiddleware(h.authService, types.APIKeyScopeFlowsRead), h.GetFlowListCount)
 		// 获取交易详情
 		// POST /api/v1/flows/transaction/detail
 		// http://localhost:8080/api/v1/flows/transaction/detail
@@ -54,6 +54,7 @@ func (h *FlowHandler) RegisterRoutes(router *gin.RouterGroup) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetCompoundFlowListRequest false "查询参数"
 // @Success 200 {object} types.APIResponse{data=types.GetCompoundFlowListResponse}
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
@@ -119,6 +120,7 @@ func (h *FlowHandler) GetFlowList(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetCompoundFlowListCountRequest false "查询参数"
 // @Success 200 {object} types.APIResponse{data=types.GetCompoundFlowListCountResponse}
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
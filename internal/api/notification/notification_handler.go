@@ -31,7 +31,7 @@ func NewNotificationHandler(notificationService notification.NotificationService
 // RegisterRoutes 注册通知相关路由
 func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// 通知API组 - 需要认证
-	notificationGroup := router.Group("/notifications", middleware.AuthMiddleware(h.authService))
+	notificationGroup := router.Group("/notifications", middleware.AuthMiddleware(h.authService, types.APIKeyScopeNotificationsManage))
 	{
 		// 获取所有通知配置
 		// POST /api/v1/notifications/configs
@@ -52,6 +52,21 @@ func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
 		// POST /api/v1/notifications/delete
 		// http://localhost:8080/api/v1/notifications/delete
 		notificationGroup.POST("/delete", h.DeleteNotificationConfig)
+
+		// 预览消息模板
+		// POST /api/v1/notifications/template/preview
+		// http://localhost:8080/api/v1/notifications/template/preview
+		notificationGroup.POST("/template/preview", h.PreviewTemplate)
+
+		// 获取通知发件箱列表
+		// POST /api/v1/notifications/outbox/list
+		// http://localhost:8080/api/v1/notifications/outbox/list
+		notificationGroup.POST("/outbox/list", h.GetOutboxList)
+
+		// 重新投递死信通知
+		// POST /api/v1/notifications/outbox/redeliver
+		// http://localhost:8080/api/v1/notifications/outbox/redeliver
+		notificationGroup.POST("/outbox/redeliver", h.RedeliverOutbox)
 	}
 }
 
@@ -59,10 +74,12 @@ func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
 
 // GetAllNotificationConfigs 获取所有通知配置
 // @Summary 获取所有通知配置
-// @Description 获取当前用户的所有通知渠道配置，如果用户没有任何配置则返回空列表
+// @Description 获取当前用户的所有通知渠道配置，包含用户所在组织的共享配置（organization_id 不为0），如果用户没有任何配置则返回空列表
 // @Tags Notification
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Success 200 {object} types.APIResponse{data=types.NotificationConfigListResponse} "获取成功，返回所有配置或空列表"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 获取配置失败; DATABASE_ERROR: 数据库访问失败"
@@ -136,15 +153,19 @@ func (h *NotificationHandler) GetAllNotificationConfigs(c *gin.Context) {
 
 // CreateNotificationConfig 创建通知配置
 // @Summary 创建通知配置
-// @Description 为当前用户创建新的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证
+// @Description 为当前用户创建新的通知配置, 传入 organization_id 时创建为组织共享配置（需要组织编辑者及以上角色），组织关注的合约状态变化时会通过共享配置通知, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证。可通过 filters 设置订阅过滤规则（合约地址、链ID、目标状态、函数选择器或签名、最小原生代币数量wei），不填则接收全部通知。可通过 message_template 设置 text/template 格式的自定义消息模板，可用变量见模板预览接口。digest_mode 为 daily/weekly 时不再逐条发送，改为按周期汇总发送摘要
 // @Tags Notification
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.CreateNotificationRequest true "创建请求"
 // @Success 200 {object} types.APIResponse{data=object} "创建成功"
-// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; MISSING_TELEGRAM_FIELDS: 缺少telegram必填字段; MISSING_WEBHOOK_URL: 缺少webhook_url字段; MISSING_REQUIRED_FIELDS: 缺少必填字段"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; MISSING_TELEGRAM_FIELDS: 缺少telegram必填字段; MISSING_WEBHOOK_URL: 缺少webhook_url字段; MISSING_REQUIRED_FIELDS: 缺少必填字段; INVALID_DIGEST_MODE: 无效的摘要模式"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色"
 // @Failure 409 {object} types.APIResponse{error=types.APIError} "配置冲突 - CONFIG_ALREADY_EXISTS: 同名配置已存在"
+// @Failure 422 {object} types.APIResponse{error=types.APIError} "过滤规则或模板错误 - INVALID_FILTERS: 订阅过滤规则校验失败; INVALID_TEMPLATE: 消息模板校验失败"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 创建配置失败"
 // @Router /api/v1/notifications/create [post]
 func (h *NotificationHandler) CreateNotificationConfig(c *gin.Context) {
@@ -234,6 +255,58 @@ func (h *NotificationHandler) CreateNotificationConfig(c *gin.Context) {
 	err := h.notificationService.CreateNotificationConfig(c.Request.Context(), userAddress, &req)
 	if err != nil {
 		// 处理特定错误类型
+		if strings.Contains(err.Error(), "insufficient organization role") {
+			c.JSON(http.StatusForbidden, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INSUFFICIENT_ORGANIZATION_ROLE",
+					Message: "Organization editor role is required to manage shared notification configs",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("CreateNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel, "organization_id", req.OrganizationID)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid filters") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_FILTERS",
+					Message: "Invalid notification filters",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("CreateNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid template") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_TEMPLATE",
+					Message: "Invalid message template",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("CreateNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid digest mode") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_DIGEST_MODE",
+					Message: "Invalid digest mode. Supported modes: off, daily, weekly",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("CreateNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel)
+			return
+		}
+
 		if strings.Contains(err.Error(), "already exists") {
 			c.JSON(http.StatusConflict, types.APIResponse{
 				Success: false,
@@ -296,15 +369,19 @@ func (h *NotificationHandler) CreateNotificationConfig(c *gin.Context) {
 
 // UpdateNotificationConfig 更新通知配置
 // @Summary 更新通知配置
-// @Description 更新当前用户的通知配置, 如果不需要更新某个字段, 可以不传该字段, 但至少传一个字段
+// @Description 更新当前用户的通知配置, 如果不需要更新某个字段, 可以不传该字段, 但至少传一个字段。组织共享配置需传入 organization_id 且需要组织编辑者及以上角色。filters 传空对象表示清除订阅过滤规则, message_template 传空字符串表示恢复默认消息格式
 // @Tags Notification
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.UpdateNotificationRequest true "更新请求"
 // @Success 200 {object} types.APIResponse{data=object} "更新成功"
-// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; NO_FIELDS_TO_UPDATE: 至少需要提供一个字段进行更新"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道; NO_FIELDS_TO_UPDATE: 至少需要提供一个字段进行更新; INVALID_DIGEST_MODE: 无效的摘要模式"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色"
 // @Failure 404 {object} types.APIResponse{error=types.APIError} "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在"
+// @Failure 422 {object} types.APIResponse{error=types.APIError} "过滤规则或模板错误 - INVALID_FILTERS: 订阅过滤规则校验失败; INVALID_TEMPLATE: 消息模板校验失败"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 更新配置失败"
 // @Router /api/v1/notifications/update [post]
 func (h *NotificationHandler) UpdateNotificationConfig(c *gin.Context) {
@@ -401,6 +478,58 @@ func (h *NotificationHandler) UpdateNotificationConfig(c *gin.Context) {
 	err := h.notificationService.UpdateNotificationConfig(c.Request.Context(), userAddress, &req)
 	if err != nil {
 		// 处理特定错误类型
+		if strings.Contains(err.Error(), "insufficient organization role") {
+			c.JSON(http.StatusForbidden, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INSUFFICIENT_ORGANIZATION_ROLE",
+					Message: "Organization editor role is required to manage shared notification configs",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("UpdateNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel, "organization_id", req.OrganizationID)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid filters") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_FILTERS",
+					Message: "Invalid notification filters",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("UpdateNotificationConfig error", err, "user_address", userAddress, "name", *req.Name, "channel", *req.Channel)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid template") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_TEMPLATE",
+					Message: "Invalid message template",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("UpdateNotificationConfig error", err, "user_address", userAddress, "name", *req.Name, "channel", *req.Channel)
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid digest mode") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_DIGEST_MODE",
+					Message: "Invalid digest mode. Supported modes: off, daily, weekly",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("UpdateNotificationConfig error", err, "user_address", userAddress, "name", *req.Name, "channel", *req.Channel)
+			return
+		}
+
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, types.APIResponse{
 				Success: false,
@@ -463,14 +592,17 @@ func (h *NotificationHandler) UpdateNotificationConfig(c *gin.Context) {
 
 // DeleteNotificationConfig 删除通知配置
 // @Summary 删除通知配置
-// @Description 删除当前用户的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证
+// @Description 删除当前用户的通知配置, 名字的空格会被自动去除, 防止攻击者通过空格来绕过名称验证。组织共享配置需传入 organization_id 且需要组织编辑者及以上角色
 // @Tags Notification
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.DeleteNotificationRequest true "删除请求"
 // @Success 200 {object} types.APIResponse{data=object} "删除成功"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_NAME: 名称不能为空; INVALID_CHANNEL: 无效的通知渠道"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "权限不足 - INSUFFICIENT_ORGANIZATION_ROLE: 管理组织共享配置需要组织编辑者及以上角色"
 // @Failure 404 {object} types.APIResponse{error=types.APIError} "配置不存在 - CONFIG_NOT_FOUND: 指定的通知配置不存在"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 删除配置失败"
 // @Router /api/v1/notifications/delete [post]
@@ -535,6 +667,19 @@ func (h *NotificationHandler) DeleteNotificationConfig(c *gin.Context) {
 	err := h.notificationService.DeleteNotificationConfig(c.Request.Context(), userAddress, &req)
 	if err != nil {
 		// 处理特定错误类型
+		if strings.Contains(err.Error(), "insufficient organization role") {
+			c.JSON(http.StatusForbidden, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INSUFFICIENT_ORGANIZATION_ROLE",
+					Message: "Organization editor role is required to manage shared notification configs",
+					Details: err.Error(),
+				},
+			})
+			logger.Error("DeleteNotificationConfig error", err, "user_address", userAddress, "name", req.Name, "channel", req.Channel, "organization_id", req.OrganizationID)
+			return
+		}
+
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, types.APIResponse{
 				Success: false,
@@ -580,3 +725,259 @@ func (h *NotificationHandler) DeleteNotificationConfig(c *gin.Context) {
 		Data:    gin.H{"message": "Notification config deleted successfully"},
 	})
 }
+
+// ===== 消息模板API =====
+
+// PreviewTemplate 预览消息模板
+// @Summary 预览消息模板
+// @Description 使用示例流程数据渲染自定义模板。telegram/lark/feishu 使用 Go text/template，email 使用 Go html/template。可用变量：.StatusFrom .StatusTo .Standard .Network .Contract .Remark .Caller .Target .Value .Function .CalldataParams（每项含 .Name .Type .Value） .TxHash .TxUrl .DashboardUrl，邮件模板还可使用 .BgColorFrom .TextColorFrom .BgColorTo .TextColorTo。模板最大16KB，渲染结果最大64KB，渲染超时2秒，不支持 define/template/block，range 最多嵌套2层
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.PreviewNotificationTemplateRequest true "预览请求"
+// @Success 200 {object} types.APIResponse{data=types.PreviewNotificationTemplateResponse} "渲染成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_CHANNEL: 无效的通知渠道"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 422 {object} types.APIResponse{error=types.APIError} "模板错误 - INVALID_TEMPLATE: 模板解析或渲染失败"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 渲染失败"
+// @Router /api/v1/notifications/template/preview [post]
+func (h *NotificationHandler) PreviewTemplate(c *gin.Context) {
+	// 从上下文获取用户信息
+	_, userAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		logger.Error("PreviewTemplate error", nil, "message", "user not authenticated")
+		return
+	}
+
+	var req types.PreviewNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("PreviewTemplate error", err, "message", "invalid request parameters", "user_address", userAddress)
+		return
+	}
+
+	response, err := h.notificationService.PreviewTemplate(c.Request.Context(), &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid channel") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_CHANNEL",
+					Message: "Invalid notification channel",
+					Details: err.Error(),
+				},
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "invalid template") {
+			c.JSON(http.StatusUnprocessableEntity, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_TEMPLATE",
+					Message: "Invalid message template",
+					Details: err.Error(),
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to preview template",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("PreviewTemplate error", err, "user_address", userAddress)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ===== 通知发件箱API =====
+
+// GetOutboxList 获取通知发件箱列表
+// @Summary 获取通知发件箱列表
+// @Description 分页获取与当前用户相关合约的通知投递记录，可按状态过滤（pending, processing, sent, dead），用于排查投递失败的通知
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.GetNotificationOutboxListRequest true "查询请求"
+// @Success 200 {object} types.APIResponse{data=types.GetNotificationOutboxListResponse} "获取成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; INVALID_STATUS: 无效的状态"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 获取发件箱失败"
+// @Router /api/v1/notifications/outbox/list [post]
+func (h *NotificationHandler) GetOutboxList(c *gin.Context) {
+	// 从上下文获取用户信息
+	_, userAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		logger.Error("GetOutboxList error", nil, "message", "user not authenticated")
+		return
+	}
+
+	var req types.GetNotificationOutboxListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("GetOutboxList error", err, "message", "invalid request parameters", "user_address", userAddress)
+		return
+	}
+
+	response, err := h.notificationService.GetOutboxList(c.Request.Context(), userAddress, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid status") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INVALID_STATUS",
+					Message: "Invalid status. Supported statuses: pending, processing, sent, dead",
+					Details: err.Error(),
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to get notification outbox",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("GetOutboxList error", err, "user_address", userAddress)
+		return
+	}
+
+	logger.Info("GetOutboxList success", "user_address", userAddress, "total", response.Total)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RedeliverOutbox 重新投递死信通知
+// @Summary 重新投递死信通知
+// @Description 将超过最大重试次数的通知（dead状态）重置为待投递，由后台worker重新发送，已成功送达的接收方不会重复发送
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param request body types.RedeliverNotificationRequest true "重新投递请求"
+// @Success 200 {object} types.APIResponse{data=object} "已加入投递队列"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数格式错误; NOT_DEAD_LETTER: 该记录不是死信状态"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证 - UNAUTHORIZED: 用户未认证"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "记录不存在 - OUTBOX_NOT_FOUND: 记录不存在或与当前用户无关"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误 - INTERNAL_ERROR: 重新投递失败"
+// @Router /api/v1/notifications/outbox/redeliver [post]
+func (h *NotificationHandler) RedeliverOutbox(c *gin.Context) {
+	// 从上下文获取用户信息
+	_, userAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+		logger.Error("RedeliverOutbox error", nil, "message", "user not authenticated")
+		return
+	}
+
+	var req types.RedeliverNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("RedeliverOutbox error", err, "message", "invalid request parameters", "user_address", userAddress)
+		return
+	}
+
+	if err := h.notificationService.RedeliverOutbox(c.Request.Context(), userAddress, req.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "OUTBOX_NOT_FOUND",
+					Message: "Outbox record not found",
+					Details: err.Error(),
+				},
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not in dead state") {
+			c.JSON(http.StatusBadRequest, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "NOT_DEAD_LETTER",
+					Message: "Only dead notifications can be redelivered",
+					Details: err.Error(),
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to redeliver notification",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("RedeliverOutbox error", err, "user_address", userAddress, "id", req.ID)
+		return
+	}
+
+	logger.Info("RedeliverOutbox success", "user_address", userAddress, "id", req.ID)
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Notification scheduled for redelivery"},
+	})
+}
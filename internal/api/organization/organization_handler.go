@@ -0,0 +1,654 @@
+package organization
+
+import (
+	"errors"
+	"net/http"
+
+	"timelocker-backend/internal/middleware"
+	"timelocker-backend/internal/service/auth"
+	"timelocker-backend/internal/service/organization"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler 组织处理器
+type Handler struct {
+	organizationService organization.Service
+	authService         auth.Service
+}
+
+// NewHandler 创建组织处理器
+func NewHandler(organizationService organization.Service, authService auth.Service) *Handler {
+	return &Handler{
+		organizationService: organizationService,
+		authService:         authService,
+	}
+}
+
+// RegisterRoutes 注册组织路由
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	// 组织和成员管理只允许钱包签名会话访问，不允许使用API Key
+	organizationGroup := router.Group("/organizations", middleware.AuthMiddleware(h.authService))
+	{
+		// 创建组织
+		// POST /api/v1/organizations/create
+		// http://localhost:8080/api/v1/organizations/create
+		organizationGroup.POST("/create", h.CreateOrganization)
+
+		// 获取所在组织列表
+		// POST /api/v1/organizations/list
+		// http://localhost:8080/api/v1/organizations/list
+		organizationGroup.POST("/list", h.ListOrganizations)
+
+		// 获取组织详情
+		// POST /api/v1/organizations/detail
+		// http://localhost:8080/api/v1/organizations/detail
+		organizationGroup.POST("/detail", h.GetOrganizationDetail)
+
+		// 更新组织信息
+		// POST /api/v1/organizations/update
+		// http://localhost:8080/api/v1/organizations/update
+		organizationGroup.POST("/update", h.UpdateOrganization)
+
+		// 删除组织
+		// POST /api/v1/organizations/delete
+		// http://localhost:8080/api/v1/organizations/delete
+		organizationGroup.POST("/delete", h.DeleteOrganization)
+
+		// 退出组织
+		// POST /api/v1/organizations/leave
+		// http://localhost:8080/api/v1/organizations/leave
+		organizationGroup.POST("/leave", h.LeaveOrganization)
+
+		// 邀请成员
+		// POST /api/v1/organizations/invite
+		// http://localhost:8080/api/v1/organizations/invite
+		organizationGroup.POST("/invite", h.InviteMember)
+
+		// 获取收到的待处理邀请
+		// POST /api/v1/organizations/invitations
+		// http://localhost:8080/api/v1/organizations/invitations
+		organizationGroup.POST("/invitations", h.ListMyInvitations)
+
+		// 接受邀请
+		// POST /api/v1/organizations/accept-invitation
+		// http://localhost:8080/api/v1/organizations/accept-invitation
+		organizationGroup.POST("/accept-invitation", h.AcceptInvitation)
+
+		// 拒绝邀请
+		// POST /api/v1/organizations/decline-invitation
+		// http://localhost:8080/api/v1/organizations/decline-invitation
+		organizationGroup.POST("/decline-invitation", h.DeclineInvitation)
+
+		// 撤销邀请
+		// POST /api/v1/organizations/revoke-invitation
+		// http://localhost:8080/api/v1/organizations/revoke-invitation
+		organizationGroup.POST("/revoke-invitation", h.RevokeInvitation)
+
+		// 修改成员角色
+		// POST /api/v1/organizations/update-member
+		// http://localhost:8080/api/v1/organizations/update-member
+		organizationGroup.POST("/update-member", h.UpdateMemberRole)
+
+		// 移除成员
+		// POST /api/v1/organizations/remove-member
+		// http://localhost:8080/api/v1/organizations/remove-member
+		organizationGroup.POST("/remove-member", h.RemoveMember)
+	}
+}
+
+// CreateOrganization 创建组织
+// @Summary 创建组织
+// @Description 创建新的组织，创建者自动成为组织所有者。组织成员共享组织名下的timelock合约、ABI、通知配置和通知邮箱。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.CreateOrganizationRequest true "创建组织请求"
+// @Success 200 {object} types.APIResponse{data=types.OrganizationInfo} "创建成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/create [post]
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	userID, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.CreateOrganizationRequest
+	if !bindRequest(c, "CreateOrganization", &req) {
+		return
+	}
+
+	response, err := h.organizationService.CreateOrganization(c.Request.Context(), userID, walletAddress, &req)
+	if err != nil {
+		respondError(c, "CreateOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListOrganizations 获取所在组织列表
+// @Summary 获取所在组织列表
+// @Description 获取当前用户所在的所有组织，包含当前用户在各组织中的角色和成员数量
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.OrganizationListResponse} "成功获取组织列表"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/list [post]
+func (h *Handler) ListOrganizations(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.organizationService.GetOrganizationList(c.Request.Context(), walletAddress)
+	if err != nil {
+		respondError(c, "ListOrganizations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetOrganizationDetail 获取组织详情
+// @Summary 获取组织详情
+// @Description 获取组织信息和成员列表，所有者还可以看到待处理的邀请。仅组织成员可访问。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationIDRequest true "组织ID"
+// @Success 200 {object} types.APIResponse{data=types.OrganizationDetailResponse} "成功获取组织详情"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织不存在或不是组织成员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/detail [post]
+func (h *Handler) GetOrganizationDetail(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationIDRequest
+	if !bindRequest(c, "GetOrganizationDetail", &req) {
+		return
+	}
+
+	response, err := h.organizationService.GetOrganizationDetail(c.Request.Context(), walletAddress, req.ID)
+	if err != nil {
+		respondError(c, "GetOrganizationDetail", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// UpdateOrganization 更新组织信息
+// @Summary 更新组织信息
+// @Description 更新组织名称和描述，仅所有者可操作
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UpdateOrganizationRequest true "更新组织请求"
+// @Success 200 {object} types.APIResponse "更新成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织不存在或不是组织成员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/update [post]
+func (h *Handler) UpdateOrganization(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateOrganizationRequest
+	if !bindRequest(c, "UpdateOrganization", &req) {
+		return
+	}
+
+	if err := h.organizationService.UpdateOrganization(c.Request.Context(), walletAddress, &req); err != nil {
+		respondError(c, "UpdateOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Organization updated successfully"},
+	})
+}
+
+// DeleteOrganization 删除组织
+// @Summary 删除组织
+// @Description 删除组织及其成员和邀请，仅所有者可操作。组织名下仍有timelock合约、ABI、通知配置或邮箱时不允许删除。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationIDRequest true "组织ID"
+// @Success 200 {object} types.APIResponse "删除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织不存在或不是组织成员"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "组织名下仍有资源"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/delete [post]
+func (h *Handler) DeleteOrganization(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationIDRequest
+	if !bindRequest(c, "DeleteOrganization", &req) {
+		return
+	}
+
+	if err := h.organizationService.DeleteOrganization(c.Request.Context(), walletAddress, req.ID); err != nil {
+		respondError(c, "DeleteOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Organization deleted successfully"},
+	})
+}
+
+// LeaveOrganization 退出组织
+// @Summary 退出组织
+// @Description 当前用户退出组织，组织的最后一个所有者不能退出
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationIDRequest true "组织ID"
+// @Success 200 {object} types.APIResponse "退出成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织不存在或不是组织成员"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "组织需要保留至少一个所有者"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/leave [post]
+func (h *Handler) LeaveOrganization(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationIDRequest
+	if !bindRequest(c, "LeaveOrganization", &req) {
+		return
+	}
+
+	if err := h.organizationService.LeaveOrganization(c.Request.Context(), walletAddress, req.ID); err != nil {
+		respondError(c, "LeaveOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Left organization successfully"},
+	})
+}
+
+// InviteMember 邀请成员
+// @Summary 邀请钱包加入组织
+// @Description 邀请指定钱包地址以owner、editor或viewer角色加入组织，仅所有者可操作。邀请7天内有效，受邀钱包登录后可接受或拒绝。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.InviteOrganizationMemberRequest true "邀请成员请求"
+// @Success 200 {object} types.APIResponse{data=types.OrganizationInvitation} "邀请成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误 - INVALID_REQUEST: 请求参数错误; INVALID_ROLE: 角色无效; INVALID_WALLET_ADDRESS: 钱包地址无效"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织不存在或不是组织成员"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "ALREADY_MEMBER: 已是组织成员; INVITATION_EXISTS: 已有待处理的邀请"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/invite [post]
+func (h *Handler) InviteMember(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.InviteOrganizationMemberRequest
+	if !bindRequest(c, "InviteMember", &req) {
+		return
+	}
+
+	response, err := h.organizationService.InviteMember(c.Request.Context(), walletAddress, &req)
+	if err != nil {
+		respondError(c, "InviteMember", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListMyInvitations 获取收到的待处理邀请
+// @Summary 获取收到的组织邀请
+// @Description 获取发给当前钱包地址且尚未过期的待处理组织邀请
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.APIResponse{data=types.OrganizationInvitationListResponse} "成功获取邀请列表"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/invitations [post]
+func (h *Handler) ListMyInvitations(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.organizationService.GetMyInvitations(c.Request.Context(), walletAddress)
+	if err != nil {
+		respondError(c, "ListMyInvitations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// AcceptInvitation 接受邀请
+// @Summary 接受组织邀请
+// @Description 接受发给当前钱包地址的待处理邀请，以邀请中的角色成为组织成员
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationInvitationIDRequest true "邀请ID"
+// @Success 200 {object} types.APIResponse "接受成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邀请不存在、已过期或已处理"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "已是组织成员"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/accept-invitation [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	userID, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationInvitationIDRequest
+	if !bindRequest(c, "AcceptInvitation", &req) {
+		return
+	}
+
+	if err := h.organizationService.AcceptInvitation(c.Request.Context(), userID, walletAddress, req.ID); err != nil {
+		respondError(c, "AcceptInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Invitation accepted successfully"},
+	})
+}
+
+// DeclineInvitation 拒绝邀请
+// @Summary 拒绝组织邀请
+// @Description 拒绝发给当前钱包地址的待处理邀请
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationInvitationIDRequest true "邀请ID"
+// @Success 200 {object} types.APIResponse "拒绝成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邀请不存在、已过期或已处理"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/decline-invitation [post]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationInvitationIDRequest
+	if !bindRequest(c, "DeclineInvitation", &req) {
+		return
+	}
+
+	if err := h.organizationService.DeclineInvitation(c.Request.Context(), walletAddress, req.ID); err != nil {
+		respondError(c, "DeclineInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Invitation declined successfully"},
+	})
+}
+
+// RevokeInvitation 撤销邀请
+// @Summary 撤销组织邀请
+// @Description 撤销组织发出的待处理邀请，仅所有者可操作
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.OrganizationInvitationIDRequest true "邀请ID"
+// @Success 200 {object} types.APIResponse "撤销成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "邀请或组织不存在"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/revoke-invitation [post]
+func (h *Handler) RevokeInvitation(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.OrganizationInvitationIDRequest
+	if !bindRequest(c, "RevokeInvitation", &req) {
+		return
+	}
+
+	if err := h.organizationService.RevokeInvitation(c.Request.Context(), walletAddress, req.ID); err != nil {
+		respondError(c, "RevokeInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Invitation revoked successfully"},
+	})
+}
+
+// UpdateMemberRole 修改成员角色
+// @Summary 修改组织成员角色
+// @Description 修改成员的角色（owner、editor、viewer），仅所有者可操作。组织必须保留至少一个所有者。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.UpdateOrganizationMemberRequest true "修改成员角色请求"
+// @Success 200 {object} types.APIResponse "修改成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织或成员不存在"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "组织需要保留至少一个所有者"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/update-member [post]
+func (h *Handler) UpdateMemberRole(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateOrganizationMemberRequest
+	if !bindRequest(c, "UpdateMemberRole", &req) {
+		return
+	}
+
+	if err := h.organizationService.UpdateMemberRole(c.Request.Context(), walletAddress, &req); err != nil {
+		respondError(c, "UpdateMemberRole", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Member role updated successfully"},
+	})
+}
+
+// RemoveMember 移除成员
+// @Summary 移除组织成员
+// @Description 将成员移出组织，仅所有者可操作。组织必须保留至少一个所有者。
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body types.RemoveOrganizationMemberRequest true "移除成员请求"
+// @Success 200 {object} types.APIResponse "移除成功"
+// @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误"
+// @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "组织角色权限不足"
+// @Failure 404 {object} types.APIResponse{error=types.APIError} "组织或成员不存在"
+// @Failure 409 {object} types.APIResponse{error=types.APIError} "组织需要保留至少一个所有者"
+// @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
+// @Router /api/v1/organizations/remove-member [post]
+func (h *Handler) RemoveMember(c *gin.Context) {
+	_, walletAddress, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	var req types.RemoveOrganizationMemberRequest
+	if !bindRequest(c, "RemoveMember", &req) {
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request.Context(), walletAddress, &req); err != nil {
+		respondError(c, "RemoveMember", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.APIResponse{
+		Success: true,
+		Data:    gin.H{"message": "Member removed successfully"},
+	})
+}
+
+// getUser 从上下文获取用户信息，失败时写入错误响应
+func getUser(c *gin.Context) (int64, string, bool) {
+	userID, walletAddress, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+		})
+	}
+	return userID, walletAddress, ok
+}
+
+// bindRequest 绑定请求参数，失败时写入错误响应
+func bindRequest(c *gin.Context, operation string, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request parameters",
+				Details: err.Error(),
+			},
+		})
+		logger.Error(operation+" Error: ", errors.New("invalid request parameters"), "error: ", err)
+		return false
+	}
+	return true
+}
+
+// respondError 将服务层错误映射为HTTP状态码和错误码
+func respondError(c *gin.Context, operation string, err error) {
+	var statusCode int
+	var errorCode string
+
+	switch {
+	case errors.Is(err, organization.ErrInvalidName):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_REQUEST"
+	case errors.Is(err, organization.ErrInvalidRole):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_ROLE"
+	case errors.Is(err, organization.ErrInvalidWallet):
+		statusCode = http.StatusBadRequest
+		errorCode = "INVALID_WALLET_ADDRESS"
+	case errors.Is(err, organization.ErrPermissionDenied):
+		statusCode = http.StatusForbidden
+		errorCode = "INSUFFICIENT_ROLE"
+	case errors.Is(err, organization.ErrOrganizationNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "ORGANIZATION_NOT_FOUND"
+	case errors.Is(err, organization.ErrMemberNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "MEMBER_NOT_FOUND"
+	case errors.Is(err, organization.ErrInvitationNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "INVITATION_NOT_FOUND"
+	case errors.Is(err, organization.ErrAlreadyMember):
+		statusCode = http.StatusConflict
+		errorCode = "ALREADY_MEMBER"
+	case errors.Is(err, organization.ErrInvitationExists):
+		statusCode = http.StatusConflict
+		errorCode = "INVITATION_EXISTS"
+	case errors.Is(err, organization.ErrLastOwner):
+		statusCode = http.StatusConflict
+		errorCode = "LAST_OWNER"
+	case errors.Is(err, organization.ErrOrganizationNotEmpty):
+		statusCode = http.StatusConflict
+		errorCode = "ORGANIZATION_NOT_EMPTY"
+	default:
+		statusCode = http.StatusInternalServerError
+		errorCode = "INTERNAL_ERROR"
+	}
+
+	logger.Error(operation+" Error: ", err, "errorCode: ", errorCode)
+	c.JSON(statusCode, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    errorCode,
+			Message: err.Error(),
+		},
+	})
+}
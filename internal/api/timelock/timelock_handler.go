@@ -32,12 +32,12 @@ func NewHandler(timeLockService timelock.Service, authService auth.Service) *Han
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	// 创建timelock路由组
 	timeLockGroup := router.Group("/timelock")
-	timeLockGroup.Use(middleware.AuthMiddleware(h.authService))
+	timeLockGroup.Use(middleware.AuthMiddleware(h.authService, types.APIKeyScopeTimelocksRead))
 	{
 		// 创建或导入timelock合约
 		// POST /api/v1/timelock/create-or-import
 		// http://localhost:8080/api/v1/timelock/create-or-import
-		timeLockGroup.POST("/create-or-import", h.CreateOrImportTimeLock)
+		timeLockGroup.POST("/create-or-import", middleware.RequireScope(types.APIKeyScopeTimelocksWrite), h.CreateOrImportTimeLock)
 
 		// 获取timelock列表（根据用户权限筛选）
 		// POST /api/v1/timelock/list
@@ -52,31 +52,33 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		// 更新timelock备注
 		// POST /api/v1/timelock/update
 		// http://localhost:8080/api/v1/timelock/update
-		timeLockGroup.POST("/update", h.UpdateTimeLock)
+		timeLockGroup.POST("/update", middleware.RequireScope(types.APIKeyScopeTimelocksWrite), h.UpdateTimeLock)
 
 		// 删除timelock
 		// POST /api/v1/timelock/delete
 		// http://localhost:8080/api/v1/timelock/delete
-		timeLockGroup.POST("/delete", h.DeleteTimeLock)
+		timeLockGroup.POST("/delete", middleware.RequireScope(types.APIKeyScopeTimelocksWrite), h.DeleteTimeLock)
 
 		// 刷新用户所有timelock合约权限
 		// POST /api/v1/timelock/refresh-permissions
 		// http://localhost:8080/api/v1/timelock/refresh-permissions
-		timeLockGroup.POST("/refresh-permissions", h.RefreshTimeLockPermissions)
+		timeLockGroup.POST("/refresh-permissions", middleware.RequireScope(types.APIKeyScopeTimelocksWrite), h.RefreshTimeLockPermissions)
 	}
 }
 
 // CreateOrImportTimeLock 创建或导入timelock合约
 // @Summary 创建或导入timelock合约记录
-// @Description 创建新的或导入已存在的timelock合约记录。系统会从链上读取合约数据并验证其是否为有效的timelock合约。支持Compound和OpenZeppelin两种标准。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
+// @Description 创建新的或导入已存在的timelock合约记录。系统会从链上读取合约数据并验证其是否为有效的timelock合约。支持Compound和OpenZeppelin两种标准。合约地址必须为有效以太坊地址（0x + 40位十六进制）。传入organization_id时添加到组织的共享监控列表，需要组织编辑者角色。
 // @Tags Timelock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.CreateOrImportTimelockContractRequest true "创建或导入timelock合约的请求体（地址从鉴权获取）"
 // @Success 200 {object} types.APIResponse{data=object} "成功创建或导入timelock合约记录"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "不是组织成员或组织角色权限不足"
 // @Failure 409 {object} types.APIResponse{error=types.APIError} "timelock合约已存在"
 // @Failure 422 {object} types.APIResponse{error=types.APIError} "参数校验失败"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
@@ -146,6 +148,12 @@ func (h *Handler) CreateOrImportTimeLock(c *gin.Context) {
 		case timelock.ErrContractNotTimelock:
 			statusCode = http.StatusBadRequest
 			errorCode = "CONTRACT_NOT_TIMELOCK"
+		case timelock.ErrUnauthorized:
+			statusCode = http.StatusForbidden
+			errorCode = "UNAUTHORIZED_ACCESS"
+		case timelock.ErrInvalidPermissions:
+			statusCode = http.StatusForbidden
+			errorCode = "INSUFFICIENT_ORGANIZATION_ROLE"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -171,15 +179,17 @@ func (h *Handler) CreateOrImportTimeLock(c *gin.Context) {
 
 // GetTimeLockList 获取timelock列表
 // @Summary 获取用户timelock合约列表（按权限筛选，所有链）
-// @Description 获取当前用户在所有链上有权限访问的timelock合约列表。支持按合约标准和状态进行筛选。返回的列表根据用户权限进行精细控制，只显示用户作为创建者、管理员、提议者、执行者的合约。
+// @Description 获取当前用户在所有链上有权限访问的timelock合约列表。支持按合约标准和状态进行筛选。返回的列表根据用户权限进行精细控制，只显示用户作为创建者、管理员、提议者、执行者的合约，以及用户所在组织共享的合约（权限列表附加org_owner/org_editor/org_viewer）。传入organization_id时只返回该组织的合约。
 // @Tags Timelock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetTimeLockListRequest false "查询参数"
 // @Success 200 {object} types.APIResponse{data=types.GetTimeLockListResponse} "成功获取timelock合约列表"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或标准无效"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
+// @Failure 403 {object} types.APIResponse{error=types.APIError} "不是指定组织的成员"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
 // @Router /api/v1/timelock/list [post]
 func (h *Handler) GetTimeLockList(c *gin.Context) {
@@ -225,6 +235,9 @@ func (h *Handler) GetTimeLockList(c *gin.Context) {
 		case timelock.ErrInvalidStandard:
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_STANDARD"
+		case timelock.ErrUnauthorized:
+			statusCode = http.StatusForbidden
+			errorCode = "UNAUTHORIZED_ACCESS"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -250,11 +263,12 @@ func (h *Handler) GetTimeLockList(c *gin.Context) {
 
 // GetTimeLockDetail 获取timelock详情
 // @Summary 获取timelock合约详细信息
-// @Description 获取指定timelock合约的完整详细信息，包括合约的基本信息、治理参数以及用户权限信息。只有具有相应权限的用户才能查看详细信息。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
+// @Description 获取指定timelock合约的完整详细信息，包括合约的基本信息、治理参数以及用户权限信息。只有具有相应权限的用户才能查看详细信息。传入organization_id时查看组织共享的合约，组织成员均可查看。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
 // @Tags Timelock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.GetTimeLockDetailRequest true "获取详情请求体"
 // @Success 200 {object} types.APIResponse{data=types.GetTimeLockDetailResponse} "成功获取timelock合约详情"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）"
@@ -330,6 +344,9 @@ func (h *Handler) GetTimeLockDetail(c *gin.Context) {
 		case timelock.ErrInvalidStandard:
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_STANDARD"
+		case timelock.ErrInvalidPermissions:
+			statusCode = http.StatusForbidden
+			errorCode = "INSUFFICIENT_ORGANIZATION_ROLE"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -355,11 +372,12 @@ func (h *Handler) GetTimeLockDetail(c *gin.Context) {
 
 // UpdateTimeLock 更新timelock备注
 // @Summary 更新timelock合约备注
-// @Description 更新指定timelock合约的备注信息。只有合约的创建者/导入者才能更新备注。备注信息用于帮助用户管理和识别不同的timelock合约。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
+// @Description 更新指定timelock合约的备注信息。只有合约的创建者/导入者才能更新备注，组织合约需要组织编辑者角色。备注信息用于帮助用户管理和识别不同的timelock合约。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
 // @Tags Timelock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.UpdateTimeLockRequest true "更新请求体（地址从鉴权获取）"
 // @Success 200 {object} types.APIResponse{data=object} "成功更新timelock合约备注"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）"
@@ -438,6 +456,9 @@ func (h *Handler) UpdateTimeLock(c *gin.Context) {
 		case timelock.ErrInvalidRemark:
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_REMARK"
+		case timelock.ErrInvalidPermissions:
+			statusCode = http.StatusForbidden
+			errorCode = "INSUFFICIENT_ORGANIZATION_ROLE"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -463,11 +484,12 @@ func (h *Handler) UpdateTimeLock(c *gin.Context) {
 
 // DeleteTimeLock 删除timelock
 // @Summary 删除timelock合约记录
-// @Description 硬删除指定的timelock合约记录。只有合约的创建者/导入者才能删除合约记录。删除操作是硬删除，数据从数据库中删除。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
+// @Description 硬删除指定的timelock合约记录。只有合约的创建者/导入者才能删除合约记录，组织合约需要组织编辑者角色。删除操作是硬删除，数据从数据库中删除。合约地址必须为有效以太坊地址（0x + 40位十六进制）。
 // @Tags Timelock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Param request body types.DeleteTimeLockRequest true "删除请求体（地址从鉴权获取）"
 // @Success 200 {object} types.APIResponse{data=object} "成功删除timelock合约记录"
 // @Failure 400 {object} types.APIResponse{error=types.APIError} "请求参数错误或标准/地址无效（INVALID_STANDARD / INVALID_CONTRACT_ADDRESS）"
@@ -543,6 +565,9 @@ func (h *Handler) DeleteTimeLock(c *gin.Context) {
 		case timelock.ErrInvalidStandard:
 			statusCode = http.StatusBadRequest
 			errorCode = "INVALID_STANDARD"
+		case timelock.ErrInvalidPermissions:
+			statusCode = http.StatusForbidden
+			errorCode = "INSUFFICIENT_ORGANIZATION_ROLE"
 		default:
 			statusCode = http.StatusInternalServerError
 			errorCode = "INTERNAL_ERROR"
@@ -573,6 +598,7 @@ func (h *Handler) DeleteTimeLock(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Success 200 {object} types.APIResponse{data=object} "成功刷新权限"
 // @Failure 401 {object} types.APIResponse{error=types.APIError} "未认证或令牌无效"
 // @Failure 500 {object} types.APIResponse{error=types.APIError} "服务器内部错误"
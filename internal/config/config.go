@@ -13,18 +13,29 @@ import (
 
 // Config 应用配置
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	RPC      RPCConfig      `mapstructure:"rpc"`
-	Email    EmailConfig    `mapstructure:"email"`
-	Scanner  ScannerConfig  `mapstructure:"scanner"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Database     DatabaseConfig     `mapstructure:"database"`
+	Redis        RedisConfig        `mapstructure:"redis"`
+	JWT          JWTConfig          `mapstructure:"jwt"`
+	SIWE         SIWEConfig         `mapstructure:"siwe"`
+	Session      SessionConfig      `mapstructure:"session"`
+	RPC          RPCConfig          `mapstructure:"rpc"`
+	Email        EmailConfig        `mapstructure:"email"`
+	Scanner      ScannerConfig      `mapstructure:"scanner"`
+	Notification NotificationConfig `mapstructure:"notification"`
+	Secrets      SecretsConfig      `mapstructure:"secrets"`
+	Admin        AdminConfig        `mapstructure:"admin"`
+	RateLimit    RateLimitConfig    `mapstructure:"rate_limit"`
+	Backup       BackupConfig       `mapstructure:"backup"`
+	Retention    RetentionConfig    `mapstructure:"retention"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
 }
 
 type ServerConfig struct {
-	Port string `mapstructure:"port"`
-	Mode string `mapstructure:"mode"`
+	Port           string   `mapstructure:"port"`
+	Mode           string   `mapstructure:"mode"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // 受信任的反向代理，用于从X-Forwarded-For解析客户端IP
 }
 
 type DatabaseConfig struct {
@@ -43,10 +54,65 @@ type RedisConfig struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// DefaultJWTSecret 内置的HS256默认密钥，仅用于本地开发，release模式下拒绝使用
+const DefaultJWTSecret = "timelocker-jwt-secret-v1"
+
+// JWTConfig 令牌签名配置
+// 配置active_key_id后使用非对称密钥（ES256/EdDSA）签名，所有配置的密钥均可用于验证并通过JWKS公开
 type JWTConfig struct {
-	Secret        string        `mapstructure:"secret"`
-	AccessExpiry  time.Duration `mapstructure:"access_expiry"`
-	RefreshExpiry time.Duration `mapstructure:"refresh_expiry"`
+	Secret             string         `mapstructure:"secret"` // HS256密钥，未配置active_key_id时用于签名和验证
+	AccessExpiry       time.Duration  `mapstructure:"access_expiry"`
+	RefreshExpiry      time.Duration  `mapstructure:"refresh_expiry"`
+	Issuer             string         `mapstructure:"issuer"`               // 令牌签发者(iss)，其他服务验证令牌时需校验
+	ActiveKeyID        string         `mapstructure:"active_key_id"`        // 当前签名密钥ID，为空时使用HS256密钥
+	Keys               []JWTKeyConfig `mapstructure:"keys"`                 // 签名和验证密钥，轮换期间可同时配置多个
+	AcceptLegacySecret bool           `mapstructure:"accept_legacy_secret"` // 切换到非对称密钥后是否仍接受HS256签名的旧令牌
+}
+
+// JWTKeyConfig 非对称签名密钥，密钥类型决定算法：ECDSA P-256为ES256，Ed25519为EdDSA
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`               // 密钥ID，写入令牌头的kid
+	PrivateKeyFile string `mapstructure:"private_key_file"` // PEM格式私钥（PKCS#8或SEC1），可用于签名
+	PublicKeyFile  string `mapstructure:"public_key_file"`  // PEM格式公钥（PKIX），仅用于验证已退役密钥签发的令牌
+}
+
+// usesSecret 是否使用HS256密钥签名或验证令牌
+func (c *JWTConfig) usesSecret() bool {
+	return c.ActiveKeyID == "" || c.AcceptLegacySecret
+}
+
+// Validate 校验令牌签名配置，release模式下不允许使用空密钥或内置默认密钥
+func (c *JWTConfig) Validate(mode string) error {
+	if c.ActiveKeyID != "" {
+		found := false
+		for _, key := range c.Keys {
+			if key.ID == c.ActiveKeyID {
+				found = key.PrivateKeyFile != ""
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("jwt active key %q must be configured with a private_key_file", c.ActiveKeyID)
+		}
+	}
+	if mode == "release" && c.usesSecret() && (c.Secret == "" || c.Secret == DefaultJWTSecret) {
+		return errors.New("refusing to start in release mode with an empty or default jwt secret, set JWT_SECRET or configure asymmetric jwt.keys")
+	}
+	return nil
+}
+
+// SIWEConfig Sign-In with Ethereum (EIP-4361) 登录配置
+type SIWEConfig struct {
+	Domains     []string      `mapstructure:"domains"`      // 允许签名登录的域名（可含端口），第一个为默认域名
+	Statement   string        `mapstructure:"statement"`    // 签名消息中的说明文字
+	NonceExpiry time.Duration `mapstructure:"nonce_expiry"` // 签名消息有效期
+	ClockSkew   time.Duration `mapstructure:"clock_skew"`   // 允许的时钟偏差
+}
+
+// SessionConfig 登录会话配置
+type SessionConfig struct {
+	DenylistBackend string        `mapstructure:"denylist_backend"` // 访问令牌黑名单存储：database 或 redis
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"` // 过期会话和黑名单清理间隔
 }
 
 // RPCConfig RPC配置
@@ -87,6 +153,146 @@ type ScannerConfig struct {
 	FlowRefreshBatchSize int           `mapstructure:"flow_refresh_batch_size"`
 }
 
+// NotificationConfig 通知投递配置
+type NotificationConfig struct {
+	// 发件箱worker配置
+	OutboxWorkers      int           `mapstructure:"outbox_workers"`
+	OutboxPollInterval time.Duration `mapstructure:"outbox_poll_interval"`
+	OutboxBatchSize    int           `mapstructure:"outbox_batch_size"`
+	OutboxLockTimeout  time.Duration `mapstructure:"outbox_lock_timeout"`
+
+	// 重试配置（指数退避）
+	OutboxMaxAttempts int           `mapstructure:"outbox_max_attempts"`
+	OutboxBaseBackoff time.Duration `mapstructure:"outbox_base_backoff"`
+	OutboxMaxBackoff  time.Duration `mapstructure:"outbox_max_backoff"`
+
+	// 摘要配置（时间均为UTC）
+	DigestCheckInterval time.Duration `mapstructure:"digest_check_interval"`
+	DigestHour          int           `mapstructure:"digest_hour"`
+	DigestWeekday       int           `mapstructure:"digest_weekday"`
+	DigestMaxRetries    int           `mapstructure:"digest_max_retries"`
+}
+
+// SecretsConfig 通知密钥加密配置
+type SecretsConfig struct {
+	ActiveKeyID string `mapstructure:"active_key_id"` // 当前用于加密的主密钥ID
+	KeyFile     string `mapstructure:"key_file"`      // 主密钥文件，每行一个 key_id:base64
+	KeyEnv      string `mapstructure:"key_env"`       // 存放主密钥的环境变量名，逗号分隔多个 key_id:base64
+}
+
+// AdminConfig 平台管理员配置
+type AdminConfig struct {
+	Wallets []string `mapstructure:"wallets"` // 平台管理员钱包地址白名单
+}
+
+// RateLimitConfig 接口限流配置（令牌桶），同时按客户端IP和已认证钱包地址计数
+type RateLimitConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	Backend string          `mapstructure:"backend"` // 令牌桶存储：memory（单实例）或 redis（多实例共享）
+	Default RateLimitRule   `mapstructure:"default"` // 未单独配置的路由共用的规则
+	Routes  []RateLimitRule `mapstructure:"routes"`  // 路由级规则，每个路由独立计数
+}
+
+// RateLimitRule 限流规则：每个周期补充requests个令牌，桶容量为burst
+type RateLimitRule struct {
+	Path     string        `mapstructure:"path"`     // 完整路由路径，如 /api/v1/auth/nonce
+	Requests int           `mapstructure:"requests"` // 每个周期允许的请求数
+	Period   time.Duration `mapstructure:"period"`   // 统计周期
+	Burst    int           `mapstructure:"burst"`    // 允许的突发请求数，未配置时等于requests
+}
+
+// BackupConfig 数据库备份配置
+type BackupConfig struct {
+	Encryption BackupEncryptionConfig `mapstructure:"encryption"`
+	Storage    BackupStorageConfig    `mapstructure:"storage"`
+	Retention  BackupRetentionConfig  `mapstructure:"retention"`
+	Schedule   BackupScheduleConfig   `mapstructure:"schedule"`
+}
+
+// BackupScheduleConfig 服务内定时备份配置，cron表达式为标准5段格式，可用 CRON_TZ=Asia/Shanghai 前缀指定时区
+type BackupScheduleConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	Cron            string        `mapstructure:"cron"`             // 全量备份时间
+	IncrementalCron string        `mapstructure:"incremental_cron"` // 增量备份时间，为空时不做增量备份
+	Dir             string        `mapstructure:"dir"`              // 本地备份目录
+	Prefix          string        `mapstructure:"prefix"`           // 备份文件名前缀
+	Timeout         time.Duration `mapstructure:"timeout"`          // 单次备份超时时间
+	MaxAge          time.Duration `mapstructure:"max_age"`          // 最近一次成功备份超过该时长时健康检查报告stale
+}
+
+// BackupStorageConfig 备份远程存储配置，备份先写入本地目录，配置存储后再上传
+type BackupStorageConfig struct {
+	Type  string                   `mapstructure:"type"` // none, local, s3
+	Local BackupLocalStorageConfig `mapstructure:"local"`
+	S3    BackupS3StorageConfig    `mapstructure:"s3"`
+}
+
+// BackupLocalStorageConfig 本地目录存储，例如挂载的NFS目录
+type BackupLocalStorageConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+// BackupS3StorageConfig S3兼容对象存储配置（AWS S3、MinIO等）
+type BackupS3StorageConfig struct {
+	Endpoint       string `mapstructure:"endpoint"`         // 如 s3.amazonaws.com、localhost:9000
+	Region         string `mapstructure:"region"`           // 区域，MinIO可留空
+	Bucket         string `mapstructure:"bucket"`           // 存储桶，需提前创建
+	Prefix         string `mapstructure:"prefix"`           // 对象键前缀，如 timelocker/prod
+	AccessKeyEnv   string `mapstructure:"access_key_env"`   // 存放Access Key的环境变量名
+	SecretKeyEnv   string `mapstructure:"secret_key_env"`   // 存放Secret Key的环境变量名
+	UseSSL         bool   `mapstructure:"use_ssl"`          // 是否使用HTTPS
+	ForcePathStyle bool   `mapstructure:"force_path_style"` // 使用路径风格访问，MinIO通常需要开启
+}
+
+// BackupRetentionConfig 备份保留策略，分别保留最近N天、N周、N月中每个周期最新的备份，全部为0时不清理
+type BackupRetentionConfig struct {
+	Daily   int `mapstructure:"daily"`
+	Weekly  int `mapstructure:"weekly"`
+	Monthly int `mapstructure:"monthly"`
+}
+
+// BackupEncryptionConfig 备份加密配置，配置口令或接收方公钥时备份会被加密
+type BackupEncryptionConfig struct {
+	PassphraseEnv  string   `mapstructure:"passphrase_env"`  // 存放备份口令的环境变量名
+	PassphraseFile string   `mapstructure:"passphrase_file"` // 备份口令文件，环境变量为空时使用
+	Recipients     []string `mapstructure:"recipients"`      // X25519接收方公钥，格式 x25519-pub:<base64url>
+	IdentityFiles  []string `mapstructure:"identity_files"`  // 解密用私钥文件，由 backup -action=keygen 生成
+}
+
+// MetricsConfig Prometheus指标配置，指标在独立端口提供，不经过API的中间件和限流
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Address string `mapstructure:"address"` // 监听地址，例如 :9090 或 127.0.0.1:9090
+	Path    string `mapstructure:"path"`    // 指标路径
+}
+
+// TracingConfig OpenTelemetry链路追踪配置，exporter为none时不创建任何span
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`     // none、otlp或stdout，stdout将span打印到标准输出，仅用于本地调试
+	Endpoint    string  `mapstructure:"endpoint"`     // OTLP HTTP接收地址，例如 localhost:4318
+	Insecure    bool    `mapstructure:"insecure"`     // OTLP使用HTTP而非HTTPS
+	ServiceName string  `mapstructure:"service_name"` // 上报的服务名
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 根span采样比例，0到1，子span跟随父span的采样结果
+}
+
+// RetentionConfig 历史数据保留配置，由服务按cron表达式清理超过保留期的交易和日志
+type RetentionConfig struct {
+	Enabled    bool              `mapstructure:"enabled"`
+	Cron       string            `mapstructure:"cron"`        // 清理时间，标准5段格式
+	BatchSize  int               `mapstructure:"batch_size"`  // 每次DELETE的最大行数
+	Timeout    time.Duration     `mapstructure:"timeout"`     // 单次清理超时时间
+	ArchiveDir string            `mapstructure:"archive_dir"` // 删除前归档文件的本地目录，配置了备份存储时归档后上传
+	Policies   []RetentionPolicy `mapstructure:"policies"`
+}
+
+// RetentionPolicy 单张表的保留策略
+type RetentionPolicy struct {
+	Table    string        `mapstructure:"table"`    // 表名
+	MaxAge   time.Duration `mapstructure:"max_age"`  // 超过该时长的行可被清理
+	Statuses []string      `mapstructure:"statuses"` // 只清理这些状态的行，为空时不按状态过滤
+	Archive  bool          `mapstructure:"archive"`  // 删除前写入归档
+}
+
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -96,6 +302,7 @@ func LoadConfig() (*Config, error) {
 	// Set defaults
 	viper.SetDefault("server.port", "8080")
 	viper.SetDefault("server.mode", "debug")
+	viper.SetDefault("server.trusted_proxies", []string{})
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 5432)
 	viper.SetDefault("database.user", "timelocker")
@@ -106,9 +313,18 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("redis.port", 6379)
 	viper.SetDefault("redis.password", "")
 	viper.SetDefault("redis.db", 0)
-	viper.SetDefault("jwt.secret", "timelocker-jwt-secret-v1")
+	viper.SetDefault("jwt.secret", DefaultJWTSecret)
 	viper.SetDefault("jwt.access_expiry", time.Hour*24)
 	viper.SetDefault("jwt.refresh_expiry", time.Hour*24*7)
+	viper.SetDefault("jwt.issuer", "timelocker-backend")
+	viper.SetDefault("jwt.active_key_id", "")
+	viper.SetDefault("jwt.accept_legacy_secret", false)
+	viper.SetDefault("siwe.domains", []string{"app.timelock.live"})
+	viper.SetDefault("siwe.statement", "Sign in to TimeLocker. This request will not trigger a blockchain transaction or cost any gas fees.")
+	viper.SetDefault("siwe.nonce_expiry", time.Minute*5)
+	viper.SetDefault("siwe.clock_skew", time.Minute)
+	viper.SetDefault("session.denylist_backend", "database")
+	viper.SetDefault("session.cleanup_interval", time.Hour)
 
 	// Email defaults
 	viper.SetDefault("email.smtp_host", "smtp.gmail.com")
@@ -130,8 +346,89 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("scanner.scan_confirmations", 12)
 	viper.SetDefault("scanner.flow_refresh_interval", time.Second*60)
 
+	// Notification defaults
+	viper.SetDefault("notification.outbox_workers", 2)
+	viper.SetDefault("notification.outbox_poll_interval", time.Second*5)
+	viper.SetDefault("notification.outbox_batch_size", 20)
+	viper.SetDefault("notification.outbox_lock_timeout", time.Minute*5)
+	viper.SetDefault("notification.outbox_max_attempts", 8)
+	viper.SetDefault("notification.outbox_base_backoff", time.Second*30)
+	viper.SetDefault("notification.outbox_max_backoff", time.Hour*2)
+	viper.SetDefault("notification.digest_check_interval", time.Minute*10)
+	viper.SetDefault("notification.digest_hour", 9)
+	viper.SetDefault("notification.digest_weekday", 1)
+	viper.SetDefault("notification.digest_max_retries", 5)
+
+	// Secrets defaults
+	viper.SetDefault("secrets.active_key_id", "")
+	viper.SetDefault("secrets.key_file", "")
+	viper.SetDefault("secrets.key_env", "TIMELOCKER_SECRET_KEYS")
+
+	// Admin defaults
+	viper.SetDefault("admin.wallets", []string{})
+
+	// Rate limit defaults
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.backend", "memory")
+	viper.SetDefault("rate_limit.default.requests", 300)
+	viper.SetDefault("rate_limit.default.period", time.Minute)
+	viper.SetDefault("rate_limit.routes", []map[string]interface{}{
+		{"path": "/api/v1/auth/nonce", "requests": 10, "period": time.Minute},
+		{"path": "/api/v1/emails/send-verification", "requests": 5, "period": time.Hour, "burst": 2},
+		{"path": "/api/v1/abi/validate", "requests": 30, "period": time.Minute},
+	})
+
+	// Backup defaults
+	viper.SetDefault("backup.encryption.passphrase_env", "TIMELOCKER_BACKUP_PASSPHRASE")
+	viper.SetDefault("backup.encryption.passphrase_file", "")
+	viper.SetDefault("backup.encryption.recipients", []string{})
+	viper.SetDefault("backup.encryption.identity_files", []string{})
+	viper.SetDefault("backup.storage.type", "none")
+	viper.SetDefault("backup.storage.local.dir", "")
+	viper.SetDefault("backup.storage.s3.endpoint", "")
+	viper.SetDefault("backup.storage.s3.region", "")
+	viper.SetDefault("backup.storage.s3.bucket", "")
+	viper.SetDefault("backup.storage.s3.prefix", "")
+	viper.SetDefault("backup.storage.s3.access_key_env", "TIMELOCKER_BACKUP_S3_ACCESS_KEY")
+	viper.SetDefault("backup.storage.s3.secret_key_env", "TIMELOCKER_BACKUP_S3_SECRET_KEY")
+	viper.SetDefault("backup.storage.s3.use_ssl", true)
+	viper.SetDefault("backup.storage.s3.force_path_style", false)
+	viper.SetDefault("backup.retention.daily", 0)
+	viper.SetDefault("backup.retention.weekly", 0)
+	viper.SetDefault("backup.retention.monthly", 0)
+	viper.SetDefault("backup.schedule.enabled", false)
+	viper.SetDefault("backup.schedule.cron", "0 3 * * *")
+	viper.SetDefault("backup.schedule.incremental_cron", "")
+	viper.SetDefault("backup.schedule.dir", "./backups")
+	viper.SetDefault("backup.schedule.prefix", "timelocker_auto")
+	viper.SetDefault("backup.schedule.timeout", 2*time.Hour)
+	viper.SetDefault("backup.schedule.max_age", 26*time.Hour)
+
+	// Retention defaults
+	viper.SetDefault("retention.enabled", false)
+	viper.SetDefault("retention.cron", "30 4 * * *")
+	viper.SetDefault("retention.batch_size", 1000)
+	viper.SetDefault("retention.timeout", time.Hour)
+	viper.SetDefault("retention.archive_dir", "./backups/archive")
+
+	// Metrics defaults
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.address", ":9090")
+	viper.SetDefault("metrics.path", "/metrics")
+
+	// Tracing defaults
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.service_name", "timelocker-backend")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
 	// Read environment variables
 	viper.AutomaticEnv()
+	// 密钥不写入配置文件，通过环境变量提供
+	if err := viper.BindEnv("jwt.secret", "JWT_SECRET"); err != nil {
+		return nil, err
+	}
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -140,6 +437,15 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// 本地开发覆盖配置（config.local.yaml，不提交），例如切换到debug模式
+	viper.SetConfigName("config.local")
+	if err := viper.MergeInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			logger.Error("LoadConfig Error: ", errors.New("failed to merge local config"), "error: ", err)
+			return nil, err
+		}
+	}
+
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		logger.Error("LoadConfig Error: ", errors.New("failed to unmarshal config"), "error: ", err)
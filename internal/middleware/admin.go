@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"timelocker-backend/internal/service/admin"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware 平台管理员校验中间件，需放在 AuthMiddleware 之后；账户下没有钱包在 admin.wallets 白名单中时返回403
+func AdminMiddleware(adminService admin.Service) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		_, walletAddress, ok := GetUserFromContext(c)
+		if !ok || !adminService.IsAdmin(c.Request.Context(), walletAddress) {
+			c.JSON(http.StatusForbidden, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "ADMIN_REQUIRED",
+					Message: "Platform admin role required",
+				},
+			})
+			logger.Error("AdminMiddleware Error: ", errors.New("wallet is not a platform admin"), "wallet_address", walletAddress)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	})
+}
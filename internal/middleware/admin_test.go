@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubAccountWallets 按钱包返回所属账户全部钱包的用户仓库，未登记的钱包只返回其自身
+type stubAccountWallets struct {
+	user.Repository
+	accounts map[string][]string
+}
+
+func (r *stubAccountWallets) GetAccountWallets(ctx context.Context, walletAddress string) ([]string, error) {
+	if wallets, ok := r.accounts[walletAddress]; ok {
+		return wallets, nil
+	}
+	return []string{walletAddress}, nil
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		adminChecksum = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		adminLower    = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+		otherWallet   = "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"
+		linkedOwner   = "0xdbf03b407c01e7cd3cbea99509d93f8dddc8c6fb" // 关联了管理员钱包的账户主钱包
+	)
+	accounts := &stubAccountWallets{accounts: map[string][]string{
+		linkedOwner: {linkedOwner, adminLower},
+	}}
+
+	tests := []struct {
+		name       string
+		wallets    []string
+		wallet     string // 为空时上下文中没有认证信息
+		wantStatus int
+	}{
+		{"admin", []string{adminChecksum}, adminLower, http.StatusOK},
+		{"admin with checksum session address", []string{adminLower}, adminChecksum, http.StatusOK},
+		{"admin listed with whitespace", []string{" " + adminChecksum + " ", ""}, adminLower, http.StatusOK},
+		{"one of several admins", []string{otherWallet, adminChecksum}, adminLower, http.StatusOK},
+		{"not an admin", []string{adminChecksum}, otherWallet, http.StatusForbidden},
+		{"admin wallet linked to the account", []string{adminChecksum}, linkedOwner, http.StatusOK},
+		{"no admins configured", nil, adminLower, http.StatusForbidden},
+		{"unauthenticated", []string{adminChecksum}, "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Admin: config.AdminConfig{Wallets: tt.wallets}}
+			adminService := admin.NewService(nil, nil, nil, accounts, nil, nil, cfg)
+
+			router := gin.New()
+			router.GET("/admin",
+				func(c *gin.Context) {
+					if tt.wallet != "" {
+						c.Set("user_id", int64(1))
+						c.Set("wallet_address", tt.wallet)
+					}
+				},
+				AdminMiddleware(adminService),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
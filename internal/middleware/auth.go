@@ -3,8 +3,10 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/service/auth"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
@@ -12,85 +14,227 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// APIKeyHeader 个人API Key请求头
+const APIKeyHeader = "X-API-Key"
+
 // AuthMiddleware JWT认证中间件
-// 1. 从请求头获取Authorization
-// 2. 检查Bearer前缀
-// 3. 提取token
-// 4. 验证token
-// 5. 将用户信息存储到上下文中
-// 6. 继续处理请求
-func AuthMiddleware(authService auth.Service) gin.HandlerFunc {
+// 1. 请求携带X-API-Key时按API Key认证，scopes为该路由组允许API Key访问所需的权限范围，未指定时不允许API Key访问
+// 2. 否则从请求头获取Authorization
+// 3. 检查Bearer前缀
+// 4. 提取token
+// 5. 验证token（包括访问令牌黑名单检查）
+// 6. Safe会话校验请求的链与会话绑定的链一致
+// 7. 按钱包地址限流（需挂载RateLimitMiddleware）
+// 8. 将用户信息存储到上下文中
+// 9. 继续处理请求
+func AuthMiddleware(authService auth.Service, scopes ...string) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		// 从请求头获取Authorization
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, types.APIResponse{
-				Success: false,
-				Error: &types.APIError{
-					Code:    "MISSING_AUTH_HEADER",
-					Message: "Authorization header is required",
-				},
-			})
-			logger.Error("AuthMiddleware Error: ", errors.New("missing authorization header"))
+		var claims *types.JWTClaims
+		var ok bool
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+			claims, ok = authenticateAPIKey(c, authService, apiKey, scopes)
+		} else {
+			claims, ok = authenticateBearer(c, authService)
+		}
+		if !ok {
 			c.Abort()
 			return
 		}
 
-		// 检查Bearer前缀
-		const bearerPrefix = "Bearer "
-		if !strings.HasPrefix(authHeader, bearerPrefix) {
-			c.JSON(http.StatusUnauthorized, types.APIResponse{
-				Success: false,
-				Error: &types.APIError{
-					Code:    "INVALID_AUTH_FORMAT",
-					Message: "Authorization header must start with Bearer",
-				},
-			})
-			logger.Error("AuthMiddleware Error: ", errors.New("authorization header must start with Bearer"))
-			c.Abort()
+		// Safe会话只能访问验证签名时所在链的数据
+		if claims.Safe {
+			if chainID := c.Query("chain_id"); chainID != "" && chainID != strconv.Itoa(claims.ChainID) {
+				c.JSON(http.StatusForbidden, types.APIResponse{
+					Success: false,
+					Error: &types.APIError{
+						Code:    "CHAIN_MISMATCH",
+						Message: "Session is bound to another chain",
+						Details: "session chain_id: " + strconv.Itoa(claims.ChainID),
+					},
+				})
+				logger.Error("AuthMiddleware Error: ", errors.New("chain id does not match session"), "session_chain_id", claims.ChainID, "request_chain_id", chainID)
+				c.Abort()
+				return
+			}
+		}
+
+		// 已认证请求再按钱包地址限流，防止更换IP绕过限制
+		if !allowWallet(c, claims.WalletAddress) {
 			return
 		}
 
-		// 提取token
-		token := strings.TrimPrefix(authHeader, bearerPrefix)
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, types.APIResponse{
-				Success: false,
-				Error: &types.APIError{
-					Code:    "MISSING_TOKEN",
-					Message: "JWT token is required",
-				},
-			})
-			logger.Error("AuthMiddleware Error: ", errors.New("JWT token is required"))
+		// 将用户信息存储到上下文中
+		c.Set("user_id", claims.UserID)
+		c.Set("wallet_address", claims.WalletAddress)
+		c.Set("chain_id", claims.ChainID)
+		if claims.Type != "api_key" {
+			c.Set("jwt_claims", claims)
+		}
+		// 操作者信息写入请求上下文，供服务层记录审计事件
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), types.AuditActor{
+			UserID:        claims.UserID,
+			WalletAddress: claims.WalletAddress,
+			AuthType:      claims.Type,
+			IPAddress:     c.ClientIP(),
+			UserAgent:     c.Request.UserAgent(),
+		}))
+
+		logger.Info("AuthMiddleware: ", "auth middleware success", "user_id: ", claims.UserID, "wallet_address: ", claims.WalletAddress, "auth_type", claims.Type)
+		// 继续处理请求
+		c.Next()
+	})
+}
+
+// RequireScope API Key权限范围校验中间件，用于路由组内需要额外权限的路由；JWT认证的请求不受限制
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		value, exists := c.Get("api_key")
+		if !exists {
+			c.Next()
+			return
+		}
+		key, ok := value.(*types.APIKey)
+		if !ok {
+			logger.Error("RequireScope Error: ", errors.New("api_key is not a *types.APIKey"))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if !checkScopes(c, key, scopes) {
 			c.Abort()
 			return
 		}
+		c.Next()
+	})
+}
 
-		// 验证token
-		claims, err := authService.VerifyToken(c.Request.Context(), token)
-		if err != nil {
+// authenticateBearer 验证Bearer JWT，失败时写入错误响应
+func authenticateBearer(c *gin.Context, authService auth.Service) (*types.JWTClaims, bool) {
+	// 从请求头获取Authorization
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "MISSING_AUTH_HEADER",
+				Message: "Authorization header is required",
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("missing authorization header"))
+		return nil, false
+	}
+
+	// 检查Bearer前缀
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(authHeader, bearerPrefix) {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_AUTH_FORMAT",
+				Message: "Authorization header must start with Bearer",
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("authorization header must start with Bearer"))
+		return nil, false
+	}
+
+	// 提取token
+	token := strings.TrimPrefix(authHeader, bearerPrefix)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "MISSING_TOKEN",
+				Message: "JWT token is required",
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("JWT token is required"))
+		return nil, false
+	}
+
+	// 验证token
+	claims, err := authService.VerifyToken(c.Request.Context(), token)
+	if err != nil {
+		// 已登出或被撤销的令牌（访问令牌黑名单）
+		if errors.Is(err, auth.ErrTokenRevoked) {
 			c.JSON(http.StatusUnauthorized, types.APIResponse{
 				Success: false,
 				Error: &types.APIError{
-					Code:    "INVALID_TOKEN",
-					Message: "Invalid or expired token",
+					Code:    "TOKEN_REVOKED",
+					Message: "Token has been revoked",
 					Details: err.Error(),
 				},
 			})
-			logger.Error("AuthMiddleware Error: ", errors.New("invalid or expired token"), "error: ", err)
-			c.Abort()
-			return
+			logger.Error("AuthMiddleware Error: ", errors.New("token has been revoked"), "error: ", err)
+			return nil, false
 		}
 
-		// 将用户信息存储到上下文中
-		c.Set("user_id", claims.UserID)
-		c.Set("wallet_address", claims.WalletAddress)
-		c.Set("jwt_claims", claims)
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_TOKEN",
+				Message: "Invalid or expired token",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("invalid or expired token"), "error: ", err)
+		return nil, false
+	}
+	return claims, true
+}
 
-		logger.Info("AuthMiddleware: ", "auth middleware success", "user_id: ", claims.UserID, "wallet_address: ", claims.WalletAddress)
-		// 继续处理请求
-		c.Next()
-	})
+// authenticateAPIKey 验证个人API Key及其权限范围，失败时写入错误响应
+func authenticateAPIKey(c *gin.Context, authService auth.Service, apiKey string, scopes []string) (*types.JWTClaims, bool) {
+	if len(scopes) == 0 {
+		c.JSON(http.StatusForbidden, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "API_KEY_NOT_ALLOWED",
+				Message: "This endpoint requires a wallet-signed session",
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("api key is not allowed for this endpoint"), "path", c.FullPath())
+		return nil, false
+	}
+
+	claims, key, err := authService.VerifyAPIKey(c.Request.Context(), apiKey, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, types.APIResponse{
+			Success: false,
+			Error: &types.APIError{
+				Code:    "INVALID_API_KEY",
+				Message: "Invalid, expired or revoked API key",
+				Details: err.Error(),
+			},
+		})
+		logger.Error("AuthMiddleware Error: ", errors.New("invalid api key"), "error: ", err)
+		return nil, false
+	}
+
+	if !checkScopes(c, key, scopes) {
+		return nil, false
+	}
+
+	c.Set("api_key", key)
+	return claims, true
+}
+
+// checkScopes 校验API Key拥有全部所需权限范围，失败时写入错误响应
+func checkScopes(c *gin.Context, key *types.APIKey, scopes []string) bool {
+	for _, scope := range scopes {
+		if !key.HasScope(scope) {
+			c.JSON(http.StatusForbidden, types.APIResponse{
+				Success: false,
+				Error: &types.APIError{
+					Code:    "INSUFFICIENT_SCOPE",
+					Message: "API key does not have the required scope",
+					Details: "required scope: " + scope,
+				},
+			})
+			logger.Error("AuthMiddleware Error: ", errors.New("insufficient api key scope"), "key_id", key.ID, "required_scope", scope)
+			return false
+		}
+	}
+	return true
 }
 
 // GetUserFromContext 从gin上下文获取用户信息
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"timelocker-backend/internal/service/auth"
+	"timelocker-backend/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testAccessToken = "valid-access-token"
+	testWallet      = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+)
+
+// stubAuthService 只实现令牌和API Key验证的认证服务
+type stubAuthService struct {
+	auth.Service
+	keys map[string]*types.APIKey // 按明文Key索引
+}
+
+func (s *stubAuthService) VerifyToken(ctx context.Context, tokenString string) (*types.JWTClaims, error) {
+	switch tokenString {
+	case testAccessToken:
+		return &types.JWTClaims{UserID: 1, WalletAddress: testWallet, Type: "access"}, nil
+	case "revoked-access-token":
+		return nil, auth.ErrTokenRevoked
+	default:
+		return nil, auth.ErrInvalidToken
+	}
+}
+
+func (s *stubAuthService) VerifyAPIKey(ctx context.Context, rawKey string, clientIP string) (*types.JWTClaims, *types.APIKey, error) {
+	key, ok := s.keys[rawKey]
+	if !ok {
+		return nil, nil, auth.ErrInvalidAPIKey
+	}
+	return &types.JWTClaims{
+		UserID:        key.UserID,
+		WalletAddress: testWallet,
+		ChainID:       key.ChainID,
+		Safe:          key.ChainID != 0,
+		Type:          "api_key",
+	}, key, nil
+}
+
+func TestAuthMiddlewareScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := &stubAuthService{keys: map[string]*types.APIKey{
+		"read-key":  {ID: 1, UserID: 1, Scopes: types.APIKeyScopeTimelocksRead + "," + types.APIKeyScopeFlowsRead},
+		"write-key": {ID: 2, UserID: 1, Scopes: types.APIKeyScopeTimelocksWrite},
+		"abi-key":   {ID: 3, UserID: 1, Scopes: types.APIKeyScopeABIWrite},
+		"safe-key":  {ID: 4, UserID: 1, Scopes: types.APIKeyScopeTimelocksRead, ChainID: 56},
+	}}
+
+	tests := []struct {
+		name       string
+		groupScope []string // AuthMiddleware允许API Key访问所需的权限范围
+		routeScope []string // RequireScope额外要求的权限范围
+		apiKey     string
+		authHeader string
+		query      string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "jwt", authHeader: "Bearer " + testAccessToken, wantStatus: http.StatusOK},
+		{name: "jwt ignores route scope", groupScope: []string{types.APIKeyScopeTimelocksRead}, routeScope: []string{types.APIKeyScopeTimelocksWrite}, authHeader: "Bearer " + testAccessToken, wantStatus: http.StatusOK},
+		{name: "missing authorization", wantStatus: http.StatusUnauthorized, wantCode: "MISSING_AUTH_HEADER"},
+		{name: "not bearer", authHeader: "Basic abc", wantStatus: http.StatusUnauthorized, wantCode: "INVALID_AUTH_FORMAT"},
+		{name: "invalid token", authHeader: "Bearer expired", wantStatus: http.StatusUnauthorized, wantCode: "INVALID_TOKEN"},
+		{name: "revoked token", authHeader: "Bearer revoked-access-token", wantStatus: http.StatusUnauthorized, wantCode: "TOKEN_REVOKED"},
+		{name: "api key on session-only route", apiKey: "write-key", wantStatus: http.StatusForbidden, wantCode: "API_KEY_NOT_ALLOWED"},
+		{name: "unknown api key", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "unknown", wantStatus: http.StatusUnauthorized, wantCode: "INVALID_API_KEY"},
+		{name: "api key with scope", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "read-key", wantStatus: http.StatusOK},
+		{name: "write scope implies read", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "write-key", wantStatus: http.StatusOK},
+		{name: "read scope does not imply write", groupScope: []string{types.APIKeyScopeTimelocksWrite}, apiKey: "read-key", wantStatus: http.StatusForbidden, wantCode: "INSUFFICIENT_SCOPE"},
+		{name: "scope of another resource", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "abi-key", wantStatus: http.StatusForbidden, wantCode: "INSUFFICIENT_SCOPE"},
+		{name: "all group scopes required", groupScope: []string{types.APIKeyScopeTimelocksRead, types.APIKeyScopeABIRead}, apiKey: "read-key", wantStatus: http.StatusForbidden, wantCode: "INSUFFICIENT_SCOPE"},
+		{name: "route scope granted", groupScope: []string{types.APIKeyScopeTimelocksRead}, routeScope: []string{types.APIKeyScopeFlowsRead}, apiKey: "read-key", wantStatus: http.StatusOK},
+		{name: "route scope missing", groupScope: []string{types.APIKeyScopeTimelocksRead}, routeScope: []string{types.APIKeyScopeTimelocksWrite}, apiKey: "read-key", wantStatus: http.StatusForbidden, wantCode: "INSUFFICIENT_SCOPE"},
+		{name: "api key takes precedence over bearer", apiKey: "read-key", authHeader: "Bearer " + testAccessToken, wantStatus: http.StatusForbidden, wantCode: "API_KEY_NOT_ALLOWED"},
+		{name: "chain bound key on its chain", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "safe-key", query: "?chain_id=56", wantStatus: http.StatusOK},
+		{name: "chain bound key on another chain", groupScope: []string{types.APIKeyScopeTimelocksRead}, apiKey: "safe-key", query: "?chain_id=1", wantStatus: http.StatusForbidden, wantCode: "CHAIN_MISMATCH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			handlers := []gin.HandlerFunc{AuthMiddleware(authService, tt.groupScope...)}
+			if len(tt.routeScope) > 0 {
+				handlers = append(handlers, RequireScope(tt.routeScope...))
+			}
+			handlers = append(handlers, func(c *gin.Context) {
+				userID, wallet, ok := GetUserFromContext(c)
+				if !ok || userID != 1 || wallet != testWallet {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+			router.GET("/resource", handlers...)
+
+			req := httptest.NewRequest(http.MethodGet, "/resource"+tt.query, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var resp types.APIResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode {
+				t.Fatalf("error %+v, want code %s", resp.Error, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes string
+		scope  string
+		want   bool
+	}{
+		{"exact", types.APIKeyScopeABIRead, types.APIKeyScopeABIRead, true},
+		{"write implies read", types.APIKeyScopeABIWrite, types.APIKeyScopeABIRead, true},
+		{"read does not imply write", types.APIKeyScopeABIRead, types.APIKeyScopeABIWrite, false},
+		{"write of another resource", types.APIKeyScopeTimelocksWrite, types.APIKeyScopeABIRead, false},
+		{"one of several", types.APIKeyScopeFlowsRead + "," + types.APIKeyScopeEmailsManage, types.APIKeyScopeEmailsManage, true},
+		{"no scopes", "", types.APIKeyScopeFlowsRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := &types.APIKey{Scopes: tt.scopes}
+			if got := key.HasScope(tt.scope); got != tt.want {
+				t.Fatalf("HasScope(%q) with %q = %v, want %v", tt.scope, tt.scopes, got, tt.want)
+			}
+		})
+	}
+}
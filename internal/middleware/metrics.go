@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"time"
+
+	"timelocker-backend/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute 未匹配任何路由的请求使用的路由标签，避免按原始路径产生无限多的标签值
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware 按方法、路由模板和状态码记录请求耗时
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
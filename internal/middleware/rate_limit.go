@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimiterContextKey 上下文中保存当前请求限流规则的键，供认证成功后按钱包限流
+const rateLimiterContextKey = "rate_limit_rule"
+
+// defaultRateLimitScope 未单独配置的路由共用的计数范围
+const defaultRateLimitScope = "*"
+
+// RateLimiter 按路由规则对客户端IP和已认证钱包限流
+type RateLimiter struct {
+	limiter     ratelimit.Limiter
+	defaultRule *ratelimit.Rule
+	routes      map[string]ratelimit.Rule
+}
+
+// rateLimitScope 当前请求匹配到的限流规则
+type rateLimitScope struct {
+	limiter *RateLimiter
+	scope   string
+	rule    ratelimit.Rule
+}
+
+// NewRateLimiter 根据配置创建限流器，requests或period无效的规则会被忽略
+func NewRateLimiter(cfg *config.RateLimitConfig, limiter ratelimit.Limiter) *RateLimiter {
+	rl := &RateLimiter{
+		limiter: limiter,
+		routes:  make(map[string]ratelimit.Rule),
+	}
+	if rule, ok := toLimiterRule(cfg.Default); ok {
+		rl.defaultRule = &rule
+	}
+	for _, route := range cfg.Routes {
+		rule, ok := toLimiterRule(route)
+		if !ok || route.Path == "" {
+			logger.Warn("Invalid rate limit rule ignored", "path", route.Path, "requests", route.Requests, "period", route.Period)
+			continue
+		}
+		rl.routes[route.Path] = rule
+	}
+	return rl
+}
+
+// toLimiterRule 将配置规则转换为令牌桶参数
+func toLimiterRule(rule config.RateLimitRule) (ratelimit.Rule, bool) {
+	if rule.Requests <= 0 || rule.Period <= 0 {
+		return ratelimit.Rule{}, false
+	}
+	burst := rule.Burst
+	if burst <= 0 {
+		burst = rule.Requests
+	}
+	return ratelimit.Rule{
+		Rate:  float64(rule.Requests) / rule.Period.Seconds(),
+		Burst: burst,
+	}, true
+}
+
+// RateLimitMiddleware 限流中间件，需在注册路由之前挂载到路由器上
+// 1. 按路由路径匹配规则，未单独配置的路由使用默认规则
+// 2. 按客户端IP计数，超限返回429
+// 3. 将规则写入上下文，AuthMiddleware认证成功后再按钱包地址计数
+func RateLimitMiddleware(rl *RateLimiter) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		scope, ok := rl.match(c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if !scope.allow(c, "ip", c.ClientIP()) {
+			return
+		}
+		c.Set(rateLimiterContextKey, scope)
+
+		c.Next()
+	})
+}
+
+// allowWallet 按钱包地址计数，由AuthMiddleware在认证成功后调用；被限流时已写入响应
+func allowWallet(c *gin.Context, walletAddress string) bool {
+	value, exists := c.Get(rateLimiterContextKey)
+	if !exists {
+		return true
+	}
+	scope, ok := value.(*rateLimitScope)
+	if !ok {
+		return true
+	}
+	return scope.allow(c, "wallet", strings.ToLower(walletAddress))
+}
+
+// match 获取路由对应的限流规则
+func (rl *RateLimiter) match(path string) (*rateLimitScope, bool) {
+	if rule, ok := rl.routes[path]; ok {
+		return &rateLimitScope{limiter: rl, scope: path, rule: rule}, true
+	}
+	if rl.defaultRule != nil {
+		return &rateLimitScope{limiter: rl, scope: defaultRateLimitScope, rule: *rl.defaultRule}, true
+	}
+	return nil, false
+}
+
+// allow 消耗一个令牌，被限流时返回429并中止请求；限流存储不可用时放行
+func (s *rateLimitScope) allow(c *gin.Context, dimension, value string) bool {
+	key := s.scope + ":" + dimension + ":" + value
+	result, err := s.limiter.limiter.Allow(c.Request.Context(), key, s.rule)
+	if err != nil {
+		logger.Error("RateLimitMiddleware Error: ", err, "key", key)
+		return true
+	}
+	if result.Allowed {
+		return true
+	}
+
+	retryAfter := int64(math.Ceil(result.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	c.JSON(http.StatusTooManyRequests, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    "RATE_LIMITED",
+			Message: "Too many requests",
+			Details: "retry after " + (time.Duration(retryAfter) * time.Second).String(),
+		},
+	})
+	logger.Error("RateLimitMiddleware Error: ", errors.New("rate limit exceeded"), "scope", s.scope, dimension, value)
+	c.Abort()
+	return false
+}
@@ -20,11 +20,13 @@ type Repository interface {
 	GetABIByID(ctx context.Context, id int64) (*types.ABI, error)
 	GetUserABIs(ctx context.Context, walletAddress string) ([]types.ABI, error)
 	GetSharedABIs(ctx context.Context) ([]types.ABI, error)
+	GetOrganizationABIs(ctx context.Context, organizationIDs []int64) ([]types.ABI, error)
 	GetABIsByOwner(ctx context.Context, walletAddress string) ([]types.ABI, error)
 	UpdateABI(ctx context.Context, abi *types.ABI) error
-	DeleteABI(ctx context.Context, id int64, walletAddress string) error
+	DeleteABI(ctx context.Context, id int64, walletAddress string, organizationID int64) error
 	CheckABIOwnership(ctx context.Context, id int64, walletAddress string) (bool, error)
 	GetABIByNameAndOwner(ctx context.Context, name string, owner string) (*types.ABI, error)
+	GetABIByNameAndOrganization(ctx context.Context, name string, organizationID int64) (*types.ABI, error)
 }
 
 type repository struct {
@@ -64,12 +66,12 @@ func (r *repository) GetABIByID(ctx context.Context, id int64) (*types.ABI, erro
 	return &abi, nil
 }
 
-// GetUserABIs 获取用户创建的ABI列表
+// GetUserABIs 获取用户创建的个人ABI列表
 func (r *repository) GetUserABIs(ctx context.Context, walletAddress string) ([]types.ABI, error) {
 	var abis []types.ABI
 	normalizedWalletAddress := strings.ToLower(walletAddress)
 	err := r.db.WithContext(ctx).
-		Where("LOWER(owner) = ?", normalizedWalletAddress).
+		Where("LOWER(owner) = ? AND organization_id = 0", normalizedWalletAddress).
 		Order("created_at DESC").
 		Find(&abis).Error
 
@@ -99,6 +101,26 @@ func (r *repository) GetSharedABIs(ctx context.Context) ([]types.ABI, error) {
 	return abis, nil
 }
 
+// GetOrganizationABIs 获取组织ABI库中的ABI列表
+func (r *repository) GetOrganizationABIs(ctx context.Context, organizationIDs []int64) ([]types.ABI, error) {
+	var abis []types.ABI
+	if len(organizationIDs) == 0 {
+		return abis, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("organization_id IN ?", organizationIDs).
+		Order("created_at DESC").
+		Find(&abis).Error
+
+	if err != nil {
+		logger.Error("GetOrganizationABIs Error:", err, "organization_ids", organizationIDs)
+		return nil, err
+	}
+
+	logger.Info("GetOrganizationABIs Success:", "organization_ids", organizationIDs, "count", len(abis))
+	return abis, nil
+}
+
 // GetABIsByOwner 获取指定所有者的ABI列表
 func (r *repository) GetABIsByOwner(ctx context.Context, walletAddress string) ([]types.ABI, error) {
 	var abis []types.ABI
@@ -139,14 +161,17 @@ func (r *repository) UpdateABI(ctx context.Context, abi *types.ABI) error {
 	return nil
 }
 
-// DeleteABI 删除ABI（硬删除）
-func (r *repository) DeleteABI(ctx context.Context, id int64, walletAddress string) error {
-	logger.Info("DeleteABI:", "id", id, "wallet_address", walletAddress)
-	normalizedWalletAddress := strings.ToLower(walletAddress)
-	// 确保只能删除自己的ABI
-	result := r.db.WithContext(ctx).
-		Where("id = ? AND LOWER(owner) = ?", id, normalizedWalletAddress).
-		Delete(&types.ABI{})
+// DeleteABI 删除ABI（硬删除），组织ABI按组织ID匹配（组织角色由服务层校验）
+func (r *repository) DeleteABI(ctx context.Context, id int64, walletAddress string, organizationID int64) error {
+	logger.Info("DeleteABI:", "id", id, "wallet_address", walletAddress, "organization_id", organizationID)
+	query := r.db.WithContext(ctx).Where("id = ?", id)
+	if organizationID > 0 {
+		query = query.Where("organization_id = ?", organizationID)
+	} else {
+		// 确保只能删除自己的ABI
+		query = query.Where("LOWER(owner) = ? AND organization_id = 0", strings.ToLower(walletAddress))
+	}
+	result := query.Delete(&types.ABI{})
 
 	if result.Error != nil {
 		logger.Error("DeleteABI Error:", result.Error, "id", id, "wallet_address", walletAddress)
@@ -182,12 +207,12 @@ func (r *repository) CheckABIOwnership(ctx context.Context, id int64, walletAddr
 	return isOwner, nil
 }
 
-// GetABIByNameAndOwner 根据名称和所有者获取ABI（用于检查重复）
+// GetABIByNameAndOwner 根据名称和所有者获取个人ABI（用于检查重复）
 func (r *repository) GetABIByNameAndOwner(ctx context.Context, name string, owner string) (*types.ABI, error) {
 	var abi types.ABI
 	normalizedOwner := strings.ToLower(owner)
 	err := r.db.WithContext(ctx).
-		Where("name = ? AND LOWER(owner) = ?", name, normalizedOwner).
+		Where("name = ? AND LOWER(owner) = ? AND organization_id = 0", name, normalizedOwner).
 		First(&abi).Error
 
 	if err != nil {
@@ -202,3 +227,20 @@ func (r *repository) GetABIByNameAndOwner(ctx context.Context, name string, owne
 	logger.Info("GetABIByNameAndOwner Success:", "id", abi.ID, "name", abi.Name, "owner", abi.Owner)
 	return &abi, nil
 }
+
+// GetABIByNameAndOrganization 根据名称获取组织ABI（用于检查重复）
+func (r *repository) GetABIByNameAndOrganization(ctx context.Context, name string, organizationID int64) (*types.ABI, error) {
+	var abi types.ABI
+	err := r.db.WithContext(ctx).
+		Where("name = ? AND organization_id = ?", name, organizationID).
+		First(&abi).Error
+
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logger.Error("GetABIByNameAndOrganization Error:", err, "name", name, "organization_id", organizationID)
+		}
+		return nil, err
+	}
+
+	return &abi, nil
+}
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository 管理员审计日志仓库接口
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, log *types.AdminAuditLog) error
+	GetAuditLogs(ctx context.Context, req *types.GetAdminAuditLogsRequest) ([]types.AdminAuditLog, int64, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository 创建管理员审计日志仓库实例
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+// CreateAuditLog 写入一条审计日志
+func (r *auditLogRepository) CreateAuditLog(ctx context.Context, log *types.AdminAuditLog) error {
+	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+		logger.Error("CreateAuditLog Error: ", err, "admin_address", log.AdminAddress, "action", log.Action)
+		return err
+	}
+	return nil
+}
+
+// GetAuditLogs 分页查询审计日志，按时间倒序
+func (r *auditLogRepository) GetAuditLogs(ctx context.Context, req *types.GetAdminAuditLogsRequest) ([]types.AdminAuditLog, int64, error) {
+	var logs []types.AdminAuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&types.AdminAuditLog{})
+	if req.AdminAddress != "" {
+		query = query.Where("admin_address = ?", strings.ToLower(req.AdminAddress))
+	}
+	if req.ResourceType != "" {
+		query = query.Where("resource_type = ?", req.ResourceType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("GetAuditLogs Count Error: ", err)
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(req.PageSize).Find(&logs).Error; err != nil {
+		logger.Error("GetAuditLogs Error: ", err)
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
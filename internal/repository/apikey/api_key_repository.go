@@ -0,0 +1,116 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// Repository API Key仓库接口
+type Repository interface {
+	CreateAPIKey(ctx context.Context, key *types.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error)
+	GetAPIKeysByUser(ctx context.Context, userID int64) ([]types.APIKey, error)
+	CountActiveAPIKeys(ctx context.Context, userID int64) (int64, error)
+	RevokeAPIKey(ctx context.Context, userID int64, id int64) (bool, error)
+	UpdateLastUsed(ctx context.Context, id int64, ipAddress string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 创建API Key仓库实例
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// CreateAPIKey 创建API Key
+func (r *repository) CreateAPIKey(ctx context.Context, key *types.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		logger.Error("CreateAPIKey Error: ", err, "user_id", key.UserID)
+		return err
+	}
+	logger.Info("CreateAPIKey: ", "id", key.ID, "user_id", key.UserID, "prefix", key.KeyPrefix)
+	return nil
+}
+
+// GetAPIKeyByHash 根据哈希获取API Key
+func (r *repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error) {
+	var key types.APIKey
+	err := r.db.WithContext(ctx).
+		Where("key_hash = ?", keyHash).
+		First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetAPIKeysByUser 获取用户的所有API Key
+func (r *repository) GetAPIKeysByUser(ctx context.Context, userID int64) ([]types.APIKey, error) {
+	var keys []types.APIKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	if err != nil {
+		logger.Error("GetAPIKeysByUser Error: ", err, "user_id", userID)
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CountActiveAPIKeys 统计用户未撤销且未过期的API Key数量
+func (r *repository) CountActiveAPIKeys(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&types.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("CountActiveAPIKeys Error: ", err, "user_id", userID)
+		return 0, err
+	}
+	return count, nil
+}
+
+// RevokeAPIKey 撤销用户的API Key，返回是否撤销成功
+func (r *repository) RevokeAPIKey(ctx context.Context, userID int64, id int64) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&types.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		logger.Error("RevokeAPIKey Error: ", result.Error, "id", id, "user_id", userID)
+		return false, result.Error
+	}
+	logger.Info("RevokeAPIKey: ", "id", id, "user_id", userID, "revoked", result.RowsAffected)
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateLastUsed 更新API Key最后使用时间和IP
+func (r *repository) UpdateLastUsed(ctx context.Context, id int64, ipAddress string) error {
+	err := r.db.WithContext(ctx).
+		Model(&types.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"last_used_at": time.Now(),
+			"last_used_ip": ipAddress,
+		}).Error
+	if err != nil {
+		logger.Error("UpdateLastUsed Error: ", err, "id", id)
+		return err
+	}
+	return nil
+}
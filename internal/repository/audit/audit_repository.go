@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"context"
+	"strings"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// Repository 用户操作审计事件仓库接口
+type Repository interface {
+	CreateEvent(ctx context.Context, event *types.AuditEvent) error
+	GetEvents(ctx context.Context, filter *types.AuditEventFilter) ([]types.AuditEvent, int64, error)
+	GetAllEvents(ctx context.Context, filter *types.AuditEventFilter) ([]types.AdminAuditEvent, int64, error)
+}
+
+// allEventsSource 管理员视图的数据源，用户操作和管理员后台操作按audit_events的列合并；
+// 管理员操作不属于任何组织，资源ID转为字符串，操作详情作为变更后状态
+const allEventsSource = `(SELECT 'user' AS scope, id, actor_user_id, actor_address, auth_type, organization_id, action,
+		resource_type, resource_id, before, after, ip_address, user_agent, created_at
+	FROM audit_events
+	UNION ALL
+	SELECT 'admin' AS scope, id, 0 AS actor_user_id, admin_address AS actor_address, NULL AS auth_type, 0 AS organization_id, action,
+		resource_type, resource_id::TEXT AS resource_id, NULL::JSONB AS before, details AS after, ip_address, user_agent, created_at
+	FROM admin_audit_logs) AS events`
+
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 创建审计事件仓库实例
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// CreateEvent 写入一条审计事件
+func (r *repository) CreateEvent(ctx context.Context, event *types.AuditEvent) error {
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		logger.Error("CreateEvent Error: ", err, "actor_address", event.ActorAddress, "action", event.Action)
+		return err
+	}
+	return nil
+}
+
+// GetEvents 按条件分页查询审计事件，按时间倒序
+func (r *repository) GetEvents(ctx context.Context, filter *types.AuditEventFilter) ([]types.AuditEvent, int64, error) {
+	var events []types.AuditEvent
+	var total int64
+
+	query := applyEventFilter(r.db.WithContext(ctx).Model(&types.AuditEvent{}), filter)
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("GetEvents Count Error: ", err)
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(filter.PageSize).Find(&events).Error; err != nil {
+		logger.Error("GetEvents Error: ", err)
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// GetAllEvents 管理员视图分页查询用户操作和管理员后台操作，按时间倒序；按组织筛选时只查询用户操作
+func (r *repository) GetAllEvents(ctx context.Context, filter *types.AuditEventFilter) ([]types.AdminAuditEvent, int64, error) {
+	var events []types.AdminAuditEvent
+	var total int64
+
+	query := applyEventFilter(r.db.WithContext(ctx).Table(allEventsSource), filter)
+	if filter.Scope != "" {
+		query = query.Where("scope = ?", filter.Scope)
+	}
+	if filter.OrganizationID != nil {
+		query = query.Where("scope = ?", types.AuditScopeUser)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("GetAllEvents Count Error: ", err)
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(filter.PageSize).Find(&events).Error; err != nil {
+		logger.Error("GetAllEvents Error: ", err)
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// applyEventFilter 添加审计事件的公共查询条件
+func applyEventFilter(query *gorm.DB, filter *types.AuditEventFilter) *gorm.DB {
+	if filter.ActorAddress != "" {
+		query = query.Where("actor_address = ?", strings.ToLower(filter.ActorAddress))
+	}
+	if filter.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *filter.OrganizationID)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("created_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("created_at < ?", *filter.EndTime)
+	}
+	return query
+}
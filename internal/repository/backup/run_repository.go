@@ -0,0 +1,88 @@
+package backup
+
+import (
+	"context"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// RunRepository 备份运行记录仓库接口
+type RunRepository interface {
+	CreateRun(ctx context.Context, run *types.BackupRun) error
+	UpdateRun(ctx context.Context, run *types.BackupRun) error
+	GetLatestRun(ctx context.Context) (*types.BackupRun, error)
+	GetLatestSuccessfulRun(ctx context.Context) (*types.BackupRun, error)
+	FailRunningRuns(ctx context.Context, message string) (int64, error)
+}
+
+type runRepository struct {
+	db *gorm.DB
+}
+
+// NewRunRepository 创建备份运行记录仓库实例
+func NewRunRepository(db *gorm.DB) RunRepository {
+	return &runRepository{
+		db: db,
+	}
+}
+
+// CreateRun 写入一条运行记录
+func (r *runRepository) CreateRun(ctx context.Context, run *types.BackupRun) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		logger.Error("CreateRun Error: ", err, "backup_type", run.BackupType)
+		return err
+	}
+	return nil
+}
+
+// UpdateRun 保存运行结果
+func (r *runRepository) UpdateRun(ctx context.Context, run *types.BackupRun) error {
+	if err := r.db.WithContext(ctx).Save(run).Error; err != nil {
+		logger.Error("UpdateRun Error: ", err, "id", run.ID, "status", run.Status)
+		return err
+	}
+	return nil
+}
+
+// GetLatestRun 获取最近一次运行记录，没有记录时返回nil
+func (r *runRepository) GetLatestRun(ctx context.Context) (*types.BackupRun, error) {
+	var run types.BackupRun
+	err := r.db.WithContext(ctx).Order("started_at DESC, id DESC").First(&run).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error("GetLatestRun Error: ", err)
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetLatestSuccessfulRun 获取最近一次成功的运行记录，没有记录时返回nil
+func (r *runRepository) GetLatestSuccessfulRun(ctx context.Context) (*types.BackupRun, error) {
+	var run types.BackupRun
+	err := r.db.WithContext(ctx).Where("status = ?", types.BackupRunStatusSuccess).Order("started_at DESC, id DESC").First(&run).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error("GetLatestSuccessfulRun Error: ", err)
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FailRunningRuns 将服务重启前未完成的运行记录标记为失败
+func (r *runRepository) FailRunningRuns(ctx context.Context, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&types.BackupRun{}).
+		Where("status = ?", types.BackupRunStatusRunning).
+		Updates(map[string]interface{}{"status": types.BackupRunStatusFailed, "error_message": message})
+	if result.Error != nil {
+		logger.Error("FailRunningRuns Error: ", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
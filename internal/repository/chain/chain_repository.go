@@ -24,6 +24,13 @@ type Repository interface {
 
 	// RPC配置相关
 	GetRPCEnabledChains(ctx context.Context, includeTestnets bool) ([]types.ChainRPCInfo, error)
+
+	// 管理员维护
+	GetChainByID(ctx context.Context, id int64) (*types.SupportChain, error)
+	CreateChain(ctx context.Context, chain *types.SupportChain) error
+	UpdateChain(ctx context.Context, id int64, updates map[string]interface{}) error
+	DeleteChain(ctx context.Context, id int64) error
+	CountChainTimelocks(ctx context.Context, chainID int64) (int64, error)
 }
 
 // repository 支持链仓库实现
@@ -169,3 +176,75 @@ func (r *repository) GetRPCEnabledChains(ctx context.Context, includeTestnets bo
 	logger.Info("GetRPCEnabledChains Success: ", "count", len(chains), "include_testnets", includeTestnets)
 	return chains, nil
 }
+
+// GetChainByID 根据主键ID获取链信息，不存在时返回 gorm.ErrRecordNotFound
+func (r *repository) GetChainByID(ctx context.Context, id int64) (*types.SupportChain, error) {
+	var chain types.SupportChain
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&chain).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("GetChainByID Error: ", err, "id", id)
+		}
+		return nil, err
+	}
+
+	return &chain, nil
+}
+
+// CreateChain 创建支持链
+func (r *repository) CreateChain(ctx context.Context, chain *types.SupportChain) error {
+	if err := r.db.WithContext(ctx).Create(chain).Error; err != nil {
+		logger.Error("CreateChain Error: ", err, "chain_name", chain.ChainName, "chain_id", chain.ChainID)
+		return err
+	}
+
+	logger.Info("CreateChain: ", "id", chain.ID, "chain_name", chain.ChainName, "chain_id", chain.ChainID)
+	return nil
+}
+
+// UpdateChain 按字段更新支持链
+func (r *repository) UpdateChain(ctx context.Context, id int64, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&types.SupportChain{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		logger.Error("UpdateChain Error: ", result.Error, "id", id)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	logger.Info("UpdateChain: ", "id", id, "fields", len(updates))
+	return nil
+}
+
+// DeleteChain 删除支持链
+func (r *repository) DeleteChain(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&types.SupportChain{})
+	if result.Error != nil {
+		logger.Error("DeleteChain Error: ", result.Error, "id", id)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	logger.Info("DeleteChain: ", "id", id)
+	return nil
+}
+
+// CountChainTimelocks 统计链上已导入的timelock合约数量（Compound与OpenZeppelin合计）
+func (r *repository) CountChainTimelocks(ctx context.Context, chainID int64) (int64, error) {
+	var compoundCount, openzeppelinCount int64
+
+	if err := r.db.WithContext(ctx).Table("compound_timelocks").Where("chain_id = ?", chainID).Count(&compoundCount).Error; err != nil {
+		logger.Error("CountChainTimelocks Error: ", err, "chain_id", chainID, "standard", "compound")
+		return 0, err
+	}
+	if err := r.db.WithContext(ctx).Table("openzeppelin_timelocks").Where("chain_id = ?", chainID).Count(&openzeppelinCount).Error; err != nil {
+		logger.Error("CountChainTimelocks Error: ", err, "chain_id", chainID, "standard", "openzeppelin")
+		return 0, err
+	}
+
+	return compoundCount + openzeppelinCount, nil
+}
@@ -8,6 +8,7 @@ import (
 	"timelocker-backend/internal/types"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // EmailRepository 邮箱仓储接口
@@ -18,15 +19,21 @@ type EmailRepository interface {
 	GetEmailByID(ctx context.Context, emailID int64) (*types.Email, error)
 
 	// UserEmail 相关
-	AddUserEmail(ctx context.Context, userID int64, emailID int64, remark *string) (*types.UserEmail, error)
-	GetUserEmails(ctx context.Context, userID int64, offset, limit int) ([]types.UserEmail, int64, error)
-	GetUserEmailByID(ctx context.Context, userEmailID int64, userID int64) (*types.UserEmail, error)
-	// 通过 userID + emailID 查询用户邮箱关系
-	GetUserEmailByUserAndEmailID(ctx context.Context, userID int64, emailID int64) (*types.UserEmail, error)
-	UpdateUserEmailRemark(ctx context.Context, userEmailID int64, userID int64, remark *string) error
-	DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64) error
-	VerifyUserEmail(ctx context.Context, userEmailID int64, userID int64) error
-	CheckUserEmailExists(ctx context.Context, userID int64, emailID int64) (bool, error)
+	// organizationID 为0表示个人邮箱
+	AddUserEmail(ctx context.Context, userID int64, organizationID int64, emailID int64, remark *string) (*types.UserEmail, error)
+	// organizationIDs 为用户所在组织，组织邮箱对成员可见
+	GetUserEmails(ctx context.Context, userID int64, organizationIDs []int64, offset, limit int) ([]types.UserEmail, int64, error)
+	// 以下按ID操作的方法中 organizationIDs 为用户可管理的组织，个人邮箱仅添加者可管理
+	GetUserEmailByID(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) (*types.UserEmail, error)
+	// 通过 userID + emailID 查询用户邮箱关系，organizationID 不为0时查询组织邮箱关系
+	GetUserEmailByUserAndEmailID(ctx context.Context, userID int64, organizationID int64, emailID int64) (*types.UserEmail, error)
+	UpdateUserEmailRemark(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, remark *string) error
+	UpdateUserEmailFilters(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, filters *string) error
+	UpdateUserEmailTemplate(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, emailTemplate *string) error
+	UpdateUserEmailDigestMode(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, digestMode string) error
+	DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) error
+	VerifyUserEmail(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) error
+	CheckUserEmailExists(ctx context.Context, userID int64, organizationID int64, emailID int64) (bool, error)
 
 	// EmailVerificationCode 相关
 	CreateVerificationCode(ctx context.Context, userEmailID int64, code string, expiresAt time.Time) error
@@ -35,7 +42,7 @@ type EmailRepository interface {
 	CleanExpiredCodes(ctx context.Context) error
 
 	// 通知查询相关（按合约相关用户的已验证邮箱）
-	GetContractRelatedVerifiedEmailIDs(ctx context.Context, standard string, chainID int, contractAddress string) ([]int64, error)
+	GetContractRelatedVerifiedEmails(ctx context.Context, standard string, chainID int, contractAddress string) ([]types.RelatedVerifiedEmail, error)
 
 	// EmailSendLog 相关
 	CreateSendLog(ctx context.Context, log *types.EmailSendLog) error
@@ -101,13 +108,34 @@ func (r *emailRepository) GetEmailByID(ctx context.Context, emailID int64) (*typ
 }
 
 // ===== UserEmail 相关方法 =====
+// userEmailByIDScope 限定用户可管理的邮箱关系：本人添加的个人邮箱或可管理组织的邮箱
+func userEmailByIDScope(userEmailID int64, userID int64, organizationIDs []int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(organizationIDs) == 0 {
+			return db.Where("id = ? AND user_id = ? AND organization_id = 0", userEmailID, userID)
+		}
+		return db.Where("id = ? AND ((user_id = ? AND organization_id = 0) OR organization_id IN ?)", userEmailID, userID, organizationIDs)
+	}
+}
+
+// userEmailOwnerScope 限定邮箱关系的归属：组织邮箱按组织ID匹配，个人邮箱按用户ID匹配
+func userEmailOwnerScope(userID int64, organizationID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if organizationID > 0 {
+			return db.Where("organization_id = ?", organizationID)
+		}
+		return db.Where("user_id = ? AND organization_id = 0", userID)
+	}
+}
+
 // AddUserEmail 添加用户邮箱
-func (r *emailRepository) AddUserEmail(ctx context.Context, userID int64, emailID int64, remark *string) (*types.UserEmail, error) {
+func (r *emailRepository) AddUserEmail(ctx context.Context, userID int64, organizationID int64, emailID int64, remark *string) (*types.UserEmail, error) {
 	userEmail := &types.UserEmail{
-		UserID:     userID,
-		EmailID:    emailID,
-		Remark:     remark,
-		IsVerified: false,
+		UserID:         userID,
+		EmailID:        emailID,
+		OrganizationID: organizationID,
+		Remark:         remark,
+		IsVerified:     false,
 	}
 
 	if err := r.db.WithContext(ctx).Create(userEmail).Error; err != nil {
@@ -118,17 +146,25 @@ func (r *emailRepository) AddUserEmail(ctx context.Context, userID int64, emailI
 }
 
 // GetUserEmails 获取用户邮箱
-func (r *emailRepository) GetUserEmails(ctx context.Context, userID int64, offset, limit int) ([]types.UserEmail, int64, error) {
+// 包含个人邮箱以及用户所在组织的邮箱
+func (r *emailRepository) GetUserEmails(ctx context.Context, userID int64, organizationIDs []int64, offset, limit int) ([]types.UserEmail, int64, error) {
 	var userEmails []types.UserEmail
 	var total int64
 
+	visible := func(db *gorm.DB) *gorm.DB {
+		if len(organizationIDs) == 0 {
+			return db.Where("user_id = ? AND organization_id = 0", userID)
+		}
+		return db.Where("(user_id = ? AND organization_id = 0) OR organization_id IN ?", userID, organizationIDs)
+	}
+
 	// 计算总数，不包含is_verified为false的记录
-	if err := r.db.WithContext(ctx).Model(&types.UserEmail{}).Where("user_id = ? AND is_verified = ?", userID, true).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&types.UserEmail{}).Scopes(visible).Where("is_verified = ?", true).Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count user emails: %w", err)
 	}
 
 	// 查询数据，不包含is_verified为false的记录
-	query := r.db.WithContext(ctx).Preload("Email").Where("user_id = ? AND is_verified = ?", userID, true)
+	query := r.db.WithContext(ctx).Preload("Email").Scopes(visible).Where("is_verified = ?", true)
 	if limit > 0 {
 		query = query.Offset(offset).Limit(limit)
 	}
@@ -141,10 +177,10 @@ func (r *emailRepository) GetUserEmails(ctx context.Context, userID int64, offse
 }
 
 // GetUserEmailByID 根据用户邮箱ID获取用户邮箱
-func (r *emailRepository) GetUserEmailByID(ctx context.Context, userEmailID int64, userID int64) (*types.UserEmail, error) {
+func (r *emailRepository) GetUserEmailByID(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) (*types.UserEmail, error) {
 	var userEmail types.UserEmail
 	err := r.db.WithContext(ctx).Preload("Email").
-		Where("id = ? AND user_id = ?", userEmailID, userID).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
 		First(&userEmail).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user email: %w", err)
@@ -153,10 +189,11 @@ func (r *emailRepository) GetUserEmailByID(ctx context.Context, userEmailID int6
 }
 
 // GetUserEmailByUserAndEmailID 通过 userID + emailID 获取用户邮箱关系
-func (r *emailRepository) GetUserEmailByUserAndEmailID(ctx context.Context, userID int64, emailID int64) (*types.UserEmail, error) {
+func (r *emailRepository) GetUserEmailByUserAndEmailID(ctx context.Context, userID int64, organizationID int64, emailID int64) (*types.UserEmail, error) {
 	var ue types.UserEmail
 	if err := r.db.WithContext(ctx).
-		Where("user_id = ? AND email_id = ?", userID, emailID).
+		Scopes(userEmailOwnerScope(userID, organizationID)).
+		Where("email_id = ?", emailID).
 		First(&ue).Error; err != nil {
 		return nil, err
 	}
@@ -164,9 +201,9 @@ func (r *emailRepository) GetUserEmailByUserAndEmailID(ctx context.Context, user
 }
 
 // UpdateUserEmailRemark 更新用户邮箱备注
-func (r *emailRepository) UpdateUserEmailRemark(ctx context.Context, userEmailID int64, userID int64, remark *string) error {
+func (r *emailRepository) UpdateUserEmailRemark(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, remark *string) error {
 	result := r.db.WithContext(ctx).Model(&types.UserEmail{}).
-		Where("id = ? AND user_id = ?", userEmailID, userID).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
 		Update("remark", remark)
 
 	if result.Error != nil {
@@ -180,10 +217,61 @@ func (r *emailRepository) UpdateUserEmailRemark(ctx context.Context, userEmailID
 	return nil
 }
 
+// UpdateUserEmailFilters 更新用户邮箱订阅过滤规则，filters为nil表示清除
+func (r *emailRepository) UpdateUserEmailFilters(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, filters *string) error {
+	result := r.db.WithContext(ctx).Model(&types.UserEmail{}).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
+		Update("filters", filters)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user email filters: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateUserEmailTemplate 更新用户邮箱自定义邮件模板，emailTemplate为nil表示恢复默认模板
+func (r *emailRepository) UpdateUserEmailTemplate(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, emailTemplate *string) error {
+	result := r.db.WithContext(ctx).Model(&types.UserEmail{}).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
+		Update("email_template", emailTemplate)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user email template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateUserEmailDigestMode 更新用户邮箱摘要模式
+func (r *emailRepository) UpdateUserEmailDigestMode(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64, digestMode string) error {
+	result := r.db.WithContext(ctx).Model(&types.UserEmail{}).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
+		Update("digest_mode", digestMode)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user email digest mode: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
 // DeleteUserEmail 删除用户邮箱
-func (r *emailRepository) DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64) error {
+func (r *emailRepository) DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) error {
 	result := r.db.WithContext(ctx).
-		Where("id = ? AND user_id = ?", userEmailID, userID).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
 		Delete(&types.UserEmail{})
 
 	if result.Error != nil {
@@ -198,10 +286,10 @@ func (r *emailRepository) DeleteUserEmail(ctx context.Context, userEmailID int64
 }
 
 // VerifyUserEmail 验证用户邮箱
-func (r *emailRepository) VerifyUserEmail(ctx context.Context, userEmailID int64, userID int64) error {
+func (r *emailRepository) VerifyUserEmail(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) error {
 	now := time.Now()
 	result := r.db.WithContext(ctx).Model(&types.UserEmail{}).
-		Where("id = ? AND user_id = ?", userEmailID, userID).
+		Scopes(userEmailByIDScope(userEmailID, userID, organizationIDs)).
 		Updates(map[string]interface{}{
 			"is_verified":      true,
 			"last_verified_at": &now,
@@ -219,10 +307,11 @@ func (r *emailRepository) VerifyUserEmail(ctx context.Context, userEmailID int64
 }
 
 // CheckUserEmailExists 检查用户邮箱是否存在
-func (r *emailRepository) CheckUserEmailExists(ctx context.Context, userID int64, emailID int64) (bool, error) {
+func (r *emailRepository) CheckUserEmailExists(ctx context.Context, userID int64, organizationID int64, emailID int64) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&types.UserEmail{}).
-		Where("user_id = ? AND email_id = ?", userID, emailID).
+		Scopes(userEmailOwnerScope(userID, organizationID)).
+		Where("email_id = ?", emailID).
 		Count(&count).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to check user email exists: %w", err)
@@ -297,51 +386,79 @@ func (r *emailRepository) CleanExpiredCodes(ctx context.Context) error {
 }
 
 // ===== 通知查询相关方法 =====
-// GetContractRelatedVerifiedEmailIDs 获取与指定合约相关用户的已验证邮箱ID列表
-func (r *emailRepository) GetContractRelatedVerifiedEmailIDs(ctx context.Context, standard string, chainID int, contractAddress string) ([]int64, error) {
-	var emailIDs []int64
+// GetContractRelatedVerifiedEmails 获取与指定合约相关用户的已验证邮箱及其订阅过滤规则
+// 同一邮箱可能被多个相关用户或组织绑定，每个绑定关系各返回一条记录
+func (r *emailRepository) GetContractRelatedVerifiedEmails(ctx context.Context, standard string, chainID int, contractAddress string) ([]types.RelatedVerifiedEmail, error) {
+	var emails []types.RelatedVerifiedEmail
 
 	normalizedContractAddress := strings.ToLower(contractAddress)
+	var timelockTable string
 	switch strings.ToLower(standard) {
 	case "compound":
+		timelockTable = "compound_timelocks"
 		// 用户是该合约的 admin 或 pending_admin
 		sql := `
-            SELECT DISTINCT e.id
+            SELECT DISTINCT ue.id AS user_email_id, e.id AS email_id, ue.filters::text AS filters, ue.email_template, ue.digest_mode
             FROM users u
-            JOIN user_emails ue ON ue.user_id = u.id AND ue.is_verified = TRUE
+            JOIN user_emails ue ON ue.user_id = u.id AND ue.organization_id = 0 AND ue.is_verified = TRUE
             JOIN emails e ON e.id = ue.email_id
             JOIN compound_timelocks t ON t.chain_id = ? AND LOWER(t.contract_address) = ?
             WHERE LOWER(u.wallet_address) = LOWER(t.admin)
                OR (t.pending_admin IS NOT NULL AND LOWER(u.wallet_address) = LOWER(t.pending_admin))
         `
-		if err := r.db.WithContext(ctx).Raw(sql, chainID, normalizedContractAddress).Pluck("id", &emailIDs).Error; err != nil {
+		if err := r.db.WithContext(ctx).Raw(sql, chainID, normalizedContractAddress).Scan(&emails).Error; err != nil {
 			return nil, fmt.Errorf("failed to query compound related emails: %w", err)
 		}
 	case "openzeppelin":
+		timelockTable = "openzeppelin_timelocks"
 		// 用户地址出现在 proposers 或 executors JSON 字符串中
 		sql := `
-            SELECT DISTINCT e.id
+            SELECT DISTINCT ue.id AS user_email_id, e.id AS email_id, ue.filters::text AS filters, ue.email_template, ue.digest_mode
             FROM users u
-            JOIN user_emails ue ON ue.user_id = u.id AND ue.is_verified = TRUE
+            JOIN user_emails ue ON ue.user_id = u.id AND ue.organization_id = 0 AND ue.is_verified = TRUE
             JOIN emails e ON e.id = ue.email_id
             JOIN openzeppelin_timelocks t ON t.chain_id = ? AND LOWER(t.contract_address) = ?
             WHERE LOWER(t.proposers) LIKE ('%' || LOWER(u.wallet_address) || '%')
                OR LOWER(t.executors) LIKE ('%' || LOWER(u.wallet_address) || '%')
         `
-		if err := r.db.WithContext(ctx).Raw(sql, chainID, normalizedContractAddress).Pluck("id", &emailIDs).Error; err != nil {
+		if err := r.db.WithContext(ctx).Raw(sql, chainID, normalizedContractAddress).Scan(&emails).Error; err != nil {
 			return nil, fmt.Errorf("failed to query openzeppelin related emails: %w", err)
 		}
 	default:
-		return []int64{}, nil
+		return []types.RelatedVerifiedEmail{}, nil
+	}
+
+	// 关注该合约的组织的已验证邮箱
+	var orgEmails []types.RelatedVerifiedEmail
+	sql := fmt.Sprintf(`
+        SELECT DISTINCT ue.id AS user_email_id, e.id AS email_id, ue.filters::text AS filters, ue.email_template, ue.digest_mode
+        FROM user_emails ue
+        JOIN emails e ON e.id = ue.email_id
+        WHERE ue.organization_id IN (
+            SELECT t.organization_id FROM %s t
+            WHERE t.organization_id > 0 AND t.chain_id = ? AND LOWER(t.contract_address) = ?
+        ) AND ue.is_verified = TRUE
+    `, timelockTable)
+	if err := r.db.WithContext(ctx).Raw(sql, chainID, normalizedContractAddress).Scan(&orgEmails).Error; err != nil {
+		return nil, fmt.Errorf("failed to query organization related emails: %w", err)
 	}
 
-	return emailIDs, nil
+	return append(emails, orgEmails...), nil
 }
 
 // ===== EmailSendLog 相关方法 =====
 // CreateSendLog 创建发送日志
+// 同一邮箱、流程、目标状态只保留一条日志，重试时覆盖之前的失败记录并累加重试次数
 func (r *emailRepository) CreateSendLog(ctx context.Context, log *types.EmailSendLog) error {
-	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "email_id"}, {Name: "flow_id"}, {Name: "status_to"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"send_status":   log.SendStatus,
+			"error_message": log.ErrorMessage,
+			"retry_count":   gorm.Expr("email_send_logs.retry_count + 1"),
+			"sent_at":       time.Now(),
+		}),
+	}).Create(log).Error; err != nil {
 		return fmt.Errorf("failed to create send log: %w", err)
 	}
 	return nil
@@ -351,7 +468,7 @@ func (r *emailRepository) CreateSendLog(ctx context.Context, log *types.EmailSen
 func (r *emailRepository) CheckSendLogExists(ctx context.Context, emailID int64, flowID string, statusTo string) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&types.EmailSendLog{}).
-		Where("email_id = ? AND flow_id = ? AND status_to = ?", emailID, flowID, statusTo).
+		Where("email_id = ? AND flow_id = ? AND status_to = ? AND send_status = ?", emailID, flowID, statusTo, "success").
 		Count(&count).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to check send log exists: %w", err)
@@ -0,0 +1,204 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// digestFlowLimit 单个摘要最多包含的流程数量
+const digestFlowLimit = 500
+
+// DigestRepository 通知摘要仓库接口
+type DigestRepository interface {
+	// 摘要接收方
+	GetDigestConfigs(ctx context.Context, mode string) (*types.UserNotificationConfigs, error)
+	GetDigestEmailRecipients(ctx context.Context, mode string) ([]types.DigestEmailRecipient, error)
+
+	// 摘要内容
+	GetUserDigestFlows(ctx context.Context, userAddress string, periodStart, periodEnd time.Time) ([]types.TimelockTransactionFlow, error)
+	GetOrganizationDigestFlows(ctx context.Context, organizationID int64, periodStart, periodEnd time.Time) ([]types.TimelockTransactionFlow, error)
+
+	// 摘要发送日志
+	GetDigestSendLog(ctx context.Context, channel string, configID int64, periodType string, periodStart time.Time) (*types.DigestSendLog, error)
+	ClaimDigestSend(ctx context.Context, log *types.DigestSendLog, maxRetries int) (bool, error)
+	SaveDigestSendLog(ctx context.Context, log *types.DigestSendLog) error
+}
+
+// digestRepository 通知摘要仓库实现
+type digestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository 创建通知摘要仓库实例
+func NewDigestRepository(db *gorm.DB) DigestRepository {
+	return &digestRepository{db: db}
+}
+
+// GetDigestConfigs 获取指定摘要模式下所有激活的渠道配置
+func (r *digestRepository) GetDigestConfigs(ctx context.Context, mode string) (*types.UserNotificationConfigs, error) {
+	configs := &types.UserNotificationConfigs{}
+
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND digest_mode = ?", true, mode).
+		Find(&configs.TelegramConfigs).Error; err != nil {
+		logger.Error("GetDigestConfigs error", err, "channel", "telegram", "mode", mode)
+		return nil, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND digest_mode = ?", true, mode).
+		Find(&configs.LarkConfigs).Error; err != nil {
+		logger.Error("GetDigestConfigs error", err, "channel", "lark", "mode", mode)
+		return nil, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND digest_mode = ?", true, mode).
+		Find(&configs.FeishuConfigs).Error; err != nil {
+		logger.Error("GetDigestConfigs error", err, "channel", "feishu", "mode", mode)
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+// GetDigestEmailRecipients 获取指定摘要模式下所有已验证的用户邮箱
+func (r *digestRepository) GetDigestEmailRecipients(ctx context.Context, mode string) ([]types.DigestEmailRecipient, error) {
+	var recipients []types.DigestEmailRecipient
+	sql := `
+        SELECT ue.id AS user_email_id, e.email, LOWER(u.wallet_address) AS wallet_address, ue.organization_id, ue.filters::text AS filters
+        FROM user_emails ue
+        JOIN users u ON u.id = ue.user_id
+        JOIN emails e ON e.id = ue.email_id
+        WHERE ue.is_verified = TRUE AND ue.digest_mode = ?
+    `
+	if err := r.db.WithContext(ctx).Raw(sql, mode).Scan(&recipients).Error; err != nil {
+		logger.Error("GetDigestEmailRecipients error", err, "mode", mode)
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// GetUserDigestFlows 获取与用户相关、在周期内有状态变化或当前可执行的流程
+func (r *digestRepository) GetUserDigestFlows(ctx context.Context, userAddress string, periodStart, periodEnd time.Time) ([]types.TimelockTransactionFlow, error) {
+	flows, err := r.getDigestFlows(ctx, userRelatedContractCondition, userRelatedContractArgs(userAddress), periodStart, periodEnd)
+	if err != nil {
+		logger.Error("GetUserDigestFlows error", err, "user_address", userAddress, "period_start", periodStart, "period_end", periodEnd)
+		return nil, err
+	}
+	return flows, nil
+}
+
+// GetOrganizationDigestFlows 获取组织关注的合约在周期内有状态变化或当前可执行的流程
+func (r *digestRepository) GetOrganizationDigestFlows(ctx context.Context, organizationID int64, periodStart, periodEnd time.Time) ([]types.TimelockTransactionFlow, error) {
+	flows, err := r.getDigestFlows(ctx, organizationContractCondition, []interface{}{organizationID, organizationID}, periodStart, periodEnd)
+	if err != nil {
+		logger.Error("GetOrganizationDigestFlows error", err, "organization_id", organizationID, "period_start", periodStart, "period_end", periodEnd)
+		return nil, err
+	}
+	return flows, nil
+}
+
+// organizationContractCondition 组织关注的合约条件，o 为包含 timelock_standard/chain_id/contract_address 列的表别名
+const organizationContractCondition = `(
+    (o.timelock_standard = 'compound' AND EXISTS (
+        SELECT 1 FROM compound_timelocks t
+        WHERE t.chain_id = o.chain_id AND LOWER(t.contract_address) = LOWER(o.contract_address) AND t.organization_id = ?
+    ))
+    OR
+    (o.timelock_standard = 'openzeppelin' AND EXISTS (
+        SELECT 1 FROM openzeppelin_timelocks t
+        WHERE t.chain_id = o.chain_id AND LOWER(t.contract_address) = LOWER(o.contract_address) AND t.organization_id = ?
+    ))
+)`
+
+// getDigestFlows 按合约条件查询周期内有状态变化或当前可执行的流程
+func (r *digestRepository) getDigestFlows(ctx context.Context, condition string, conditionArgs []interface{}, periodStart, periodEnd time.Time) ([]types.TimelockTransactionFlow, error) {
+	var flows []types.TimelockTransactionFlow
+	args := append(conditionArgs,
+		periodStart, periodEnd,
+		periodStart, periodEnd,
+		periodStart, periodEnd,
+	)
+	err := r.db.WithContext(ctx).
+		Table("timelock_transaction_flows o").
+		Where(condition+` AND (
+            (o.queued_at >= ? AND o.queued_at < ?)
+            OR (o.executed_at >= ? AND o.executed_at < ?)
+            OR (o.cancelled_at >= ? AND o.cancelled_at < ?)
+            OR o.status = 'ready'
+        )`, args...).
+		Order("o.chain_id ASC, o.contract_address ASC, o.queued_at ASC").
+		Limit(digestFlowLimit).
+		Find(&flows).Error
+	return flows, err
+}
+
+// GetDigestSendLog 获取摘要发送日志
+func (r *digestRepository) GetDigestSendLog(ctx context.Context, channel string, configID int64, periodType string, periodStart time.Time) (*types.DigestSendLog, error) {
+	var log types.DigestSendLog
+	err := r.db.WithContext(ctx).
+		Where("channel = ? AND config_id = ? AND period_type = ? AND period_start = ?", channel, configID, periodType, periodStart).
+		First(&log).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("GetDigestSendLog error", err, "channel", channel, "config_id", configID, "period_type", periodType)
+		}
+		return nil, err
+	}
+	return &log, nil
+}
+
+// ClaimDigestSend 在发送前认领接收方某个周期的摘要，返回是否认领成功
+// 首次发送插入pending记录；记录已存在时只有失败且未超过重试次数的记录可以被重新认领并累加重试次数。
+// 多个调度实例并发处理同一周期时只有一个能认领成功，认领后进程中断的记录保持pending，不会重复发送
+func (r *digestRepository) ClaimDigestSend(ctx context.Context, log *types.DigestSendLog, maxRetries int) (bool, error) {
+	log.SendStatus = types.DigestSendStatusPending
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel"}, {Name: "config_id"}, {Name: "period_type"}, {Name: "period_start"}},
+		DoNothing: true,
+	}).Create(log)
+	if result.Error != nil {
+		logger.Error("ClaimDigestSend error", result.Error, "channel", log.Channel, "config_id", log.ConfigID, "period_type", log.PeriodType)
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = r.db.WithContext(ctx).Model(&types.DigestSendLog{}).
+		Where("channel = ? AND config_id = ? AND period_type = ? AND period_start = ? AND send_status = ? AND retry_count < ?",
+			log.Channel, log.ConfigID, log.PeriodType, log.PeriodStart, types.DigestSendStatusFailed, maxRetries).
+		Updates(map[string]interface{}{
+			"send_status": types.DigestSendStatusPending,
+			"retry_count": gorm.Expr("retry_count + 1"),
+			"sent_at":     log.SentAt,
+		})
+	if result.Error != nil {
+		logger.Error("ClaimDigestSend error", result.Error, "channel", log.Channel, "config_id", log.ConfigID, "period_type", log.PeriodType)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SaveDigestSendLog 保存已认领摘要的发送结果
+func (r *digestRepository) SaveDigestSendLog(ctx context.Context, log *types.DigestSendLog) error {
+	if err := r.db.WithContext(ctx).Model(&types.DigestSendLog{}).
+		Where("channel = ? AND config_id = ? AND period_type = ? AND period_start = ?", log.Channel, log.ConfigID, log.PeriodType, log.PeriodStart).
+		Updates(map[string]interface{}{
+			"flow_count":    log.FlowCount,
+			"send_status":   log.SendStatus,
+			"error_message": log.ErrorMessage,
+			"sent_at":       log.SentAt,
+		}).Error; err != nil {
+		logger.Error("SaveDigestSendLog error", err, "channel", log.Channel, "config_id", log.ConfigID, "period_type", log.PeriodType)
+		return err
+	}
+	return nil
+}
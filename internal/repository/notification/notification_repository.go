@@ -8,30 +8,31 @@ import (
 	"timelocker-backend/pkg/logger"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // NotificationRepository 通知渠道仓库接口
 type NotificationRepository interface {
 	// Telegram配置管理
 	CreateTelegramConfig(ctx context.Context, config *types.TelegramConfig) error
-	GetTelegramConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.TelegramConfig, error)
-	GetTelegramConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.TelegramConfig, error)
-	UpdateTelegramConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error
-	DeleteTelegramConfig(ctx context.Context, userAddress, name string) error
+	GetTelegramConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.TelegramConfig, error)
+	GetTelegramConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.TelegramConfig, error)
+	UpdateTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error
+	DeleteTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string) error
 
 	// Lark配置管理
 	CreateLarkConfig(ctx context.Context, config *types.LarkConfig) error
-	GetLarkConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.LarkConfig, error)
-	GetLarkConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.LarkConfig, error)
-	UpdateLarkConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error
-	DeleteLarkConfig(ctx context.Context, userAddress, name string) error
+	GetLarkConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.LarkConfig, error)
+	GetLarkConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.LarkConfig, error)
+	UpdateLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error
+	DeleteLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string) error
 
 	// Feishu配置管理
 	CreateFeishuConfig(ctx context.Context, config *types.FeishuConfig) error
-	GetFeishuConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.FeishuConfig, error)
-	GetFeishuConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.FeishuConfig, error)
-	UpdateFeishuConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error
-	DeleteFeishuConfig(ctx context.Context, userAddress, name string) error
+	GetFeishuConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.FeishuConfig, error)
+	GetFeishuConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.FeishuConfig, error)
+	UpdateFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error
+	DeleteFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string) error
 
 	// 通知日志管理
 	CreateNotificationLog(ctx context.Context, log *types.NotificationLog) error
@@ -39,6 +40,8 @@ type NotificationRepository interface {
 
 	// 获取用户的所有激活通知配置
 	GetUserActiveNotificationConfigs(ctx context.Context, userAddress string) (*types.UserNotificationConfigs, error)
+	// 获取关注了指定合约的组织的所有激活通知配置
+	GetOrganizationActiveNotificationConfigs(ctx context.Context, standard string, chainID int, contractAddress string) (*types.UserNotificationConfigs, error)
 
 	// 获取与合约相关的用户地址
 	GetContractRelatedUserAddresses(ctx context.Context, standard string, chainID int, contractAddress string) ([]string, error)
@@ -54,6 +57,27 @@ func NewRepository(db *gorm.DB) NotificationRepository {
 	return &notificationRepository{db: db}
 }
 
+// ownerConfigScope 限定可管理的配置：组织配置按组织ID匹配，个人配置按用户地址匹配
+func ownerConfigScope(userAddress string, organizationID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if organizationID > 0 {
+			return db.Where("organization_id = ?", organizationID)
+		}
+		return db.Where("LOWER(user_address) = ? AND organization_id = 0", strings.ToLower(userAddress))
+	}
+}
+
+// visibleConfigScope 限定用户可见的配置：个人配置及所在组织的配置
+func visibleConfigScope(userAddress string, organizationIDs []int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		normalizedUserAddress := strings.ToLower(userAddress)
+		if len(organizationIDs) == 0 {
+			return db.Where("LOWER(user_address) = ? AND organization_id = 0", normalizedUserAddress)
+		}
+		return db.Where("(LOWER(user_address) = ? AND organization_id = 0) OR organization_id IN ?", normalizedUserAddress, organizationIDs)
+	}
+}
+
 // ===== Telegram配置管理 =====
 // CreateTelegramConfig 创建Telegram配置
 func (r *notificationRepository) CreateTelegramConfig(ctx context.Context, config *types.TelegramConfig) error {
@@ -66,11 +90,11 @@ func (r *notificationRepository) CreateTelegramConfig(ctx context.Context, confi
 }
 
 // GetTelegramConfigsByUserAddress 根据用户地址获取Telegram配置
-func (r *notificationRepository) GetTelegramConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.TelegramConfig, error) {
+// 包含用户个人配置以及用户所在组织的共享配置
+func (r *notificationRepository) GetTelegramConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.TelegramConfig, error) {
 	var configs []*types.TelegramConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ?", normalizedUserAddress).
+		Scopes(visibleConfigScope(userAddress, organizationIDs)).
 		Order("created_at DESC").
 		Find(&configs).Error; err != nil {
 		logger.Error("GetTelegramConfigsByUserAddress error", err, "user_address", userAddress)
@@ -81,11 +105,11 @@ func (r *notificationRepository) GetTelegramConfigsByUserAddress(ctx context.Con
 }
 
 // GetTelegramConfigByUserAddressAndName 根据用户地址和名称获取Telegram配置
-func (r *notificationRepository) GetTelegramConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.TelegramConfig, error) {
+func (r *notificationRepository) GetTelegramConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.TelegramConfig, error) {
 	var config types.TelegramConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		First(&config).Error; err != nil {
 		logger.Error("GetTelegramConfigByUserAddressAndName error", err, "user_address", userAddress, "name", name)
 		return nil, err
@@ -95,11 +119,11 @@ func (r *notificationRepository) GetTelegramConfigByUserAddressAndName(ctx conte
 }
 
 // UpdateTelegramConfig 更新Telegram配置
-func (r *notificationRepository) UpdateTelegramConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) UpdateTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error {
 	if err := r.db.WithContext(ctx).
 		Model(&types.TelegramConfig{}).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Updates(updates).Error; err != nil {
 		logger.Error("UpdateTelegramConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -109,10 +133,10 @@ func (r *notificationRepository) UpdateTelegramConfig(ctx context.Context, userA
 }
 
 // DeleteTelegramConfig 删除Telegram配置
-func (r *notificationRepository) DeleteTelegramConfig(ctx context.Context, userAddress, name string) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) DeleteTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Delete(&types.TelegramConfig{}).Error; err != nil {
 		logger.Error("DeleteTelegramConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -133,11 +157,11 @@ func (r *notificationRepository) CreateLarkConfig(ctx context.Context, config *t
 }
 
 // GetLarkConfigsByUserAddress 根据用户地址获取Lark配置
-func (r *notificationRepository) GetLarkConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.LarkConfig, error) {
+// 包含用户个人配置以及用户所在组织的共享配置
+func (r *notificationRepository) GetLarkConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.LarkConfig, error) {
 	var configs []*types.LarkConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ?", normalizedUserAddress).
+		Scopes(visibleConfigScope(userAddress, organizationIDs)).
 		Order("created_at DESC").
 		Find(&configs).Error; err != nil {
 		logger.Error("GetLarkConfigsByUserAddress error", err, "user_address", userAddress)
@@ -148,11 +172,11 @@ func (r *notificationRepository) GetLarkConfigsByUserAddress(ctx context.Context
 }
 
 // GetLarkConfigByUserAddressAndName 根据用户地址和名称获取Lark配置
-func (r *notificationRepository) GetLarkConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.LarkConfig, error) {
+func (r *notificationRepository) GetLarkConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.LarkConfig, error) {
 	var config types.LarkConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		First(&config).Error; err != nil {
 		logger.Error("GetLarkConfigByUserAddressAndName error", err, "user_address", userAddress, "name", name)
 		return nil, err
@@ -162,11 +186,11 @@ func (r *notificationRepository) GetLarkConfigByUserAddressAndName(ctx context.C
 }
 
 // UpdateLarkConfig 更新Lark配置
-func (r *notificationRepository) UpdateLarkConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) UpdateLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error {
 	if err := r.db.WithContext(ctx).
 		Model(&types.LarkConfig{}).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Updates(updates).Error; err != nil {
 		logger.Error("UpdateLarkConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -176,10 +200,10 @@ func (r *notificationRepository) UpdateLarkConfig(ctx context.Context, userAddre
 }
 
 // DeleteLarkConfig 删除Lark配置
-func (r *notificationRepository) DeleteLarkConfig(ctx context.Context, userAddress, name string) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) DeleteLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Delete(&types.LarkConfig{}).Error; err != nil {
 		logger.Error("DeleteLarkConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -200,11 +224,11 @@ func (r *notificationRepository) CreateFeishuConfig(ctx context.Context, config
 }
 
 // GetFeishuConfigsByUserAddress 根据用户地址获取Feishu配置
-func (r *notificationRepository) GetFeishuConfigsByUserAddress(ctx context.Context, userAddress string) ([]*types.FeishuConfig, error) {
+// 包含用户个人配置以及用户所在组织的共享配置
+func (r *notificationRepository) GetFeishuConfigsByUserAddress(ctx context.Context, userAddress string, organizationIDs []int64) ([]*types.FeishuConfig, error) {
 	var configs []*types.FeishuConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ?", normalizedUserAddress).
+		Scopes(visibleConfigScope(userAddress, organizationIDs)).
 		Order("created_at DESC").
 		Find(&configs).Error; err != nil {
 		logger.Error("GetFeishuConfigsByUserAddress error", err, "user_address", userAddress)
@@ -215,11 +239,11 @@ func (r *notificationRepository) GetFeishuConfigsByUserAddress(ctx context.Conte
 }
 
 // GetFeishuConfigByUserAddressAndName 根据用户地址和名称获取Feishu配置
-func (r *notificationRepository) GetFeishuConfigByUserAddressAndName(ctx context.Context, userAddress, name string) (*types.FeishuConfig, error) {
+func (r *notificationRepository) GetFeishuConfigByUserAddressAndName(ctx context.Context, userAddress string, organizationID int64, name string) (*types.FeishuConfig, error) {
 	var config types.FeishuConfig
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		First(&config).Error; err != nil {
 		logger.Error("GetFeishuConfigByUserAddressAndName error", err, "user_address", userAddress, "name", name)
 		return nil, err
@@ -229,11 +253,11 @@ func (r *notificationRepository) GetFeishuConfigByUserAddressAndName(ctx context
 }
 
 // UpdateFeishuConfig 更新Feishu配置
-func (r *notificationRepository) UpdateFeishuConfig(ctx context.Context, userAddress, name string, updates map[string]interface{}) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) UpdateFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string, updates map[string]interface{}) error {
 	if err := r.db.WithContext(ctx).
 		Model(&types.FeishuConfig{}).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Updates(updates).Error; err != nil {
 		logger.Error("UpdateFeishuConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -243,10 +267,10 @@ func (r *notificationRepository) UpdateFeishuConfig(ctx context.Context, userAdd
 }
 
 // DeleteFeishuConfig 删除Feishu配置
-func (r *notificationRepository) DeleteFeishuConfig(ctx context.Context, userAddress, name string) error {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *notificationRepository) DeleteFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND name = ?", normalizedUserAddress, name).
+		Scopes(ownerConfigScope(userAddress, organizationID)).
+		Where("name = ?", name).
 		Delete(&types.FeishuConfig{}).Error; err != nil {
 		logger.Error("DeleteFeishuConfig error", err, "user_address", userAddress, "name", name)
 		return err
@@ -257,8 +281,12 @@ func (r *notificationRepository) DeleteFeishuConfig(ctx context.Context, userAdd
 
 // ===== 通知日志管理 =====
 // CreateNotificationLog 创建通知日志
+// 同一渠道配置、流程、目标状态只保留一条日志，重试时覆盖之前的失败记录
 func (r *notificationRepository) CreateNotificationLog(ctx context.Context, log *types.NotificationLog) error {
-	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel"}, {Name: "config_id"}, {Name: "flow_id"}, {Name: "status_to"}},
+		DoUpdates: clause.AssignmentColumns([]string{"send_status", "error_message", "tx_hash", "sent_at"}),
+	}).Create(log).Error; err != nil {
 		logger.Error("CreateNotificationLog error", err, "user_address", log.UserAddress, "channel", log.Channel, "config_id", log.ConfigID, "flow_id", log.FlowID, "status_to", log.StatusTo)
 		return err
 	}
@@ -283,12 +311,13 @@ func (r *notificationRepository) CheckNotificationLogExists(ctx context.Context,
 
 // ===== 获取用户的所有激活通知配置 =====
 // GetUserActiveNotificationConfigs 获取用户的所有激活通知配置
+// 仅包含个人配置，组织共享配置通过 GetOrganizationActiveNotificationConfigs 获取
 func (r *notificationRepository) GetUserActiveNotificationConfigs(ctx context.Context, userAddress string) (*types.UserNotificationConfigs, error) {
 	configs := &types.UserNotificationConfigs{}
 	normalizedUserAddress := strings.ToLower(userAddress)
 	// 获取激活的Telegram配置
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND is_active = ?", normalizedUserAddress, true).
+		Where("LOWER(user_address) = ? AND organization_id = 0 AND is_active = ?", normalizedUserAddress, true).
 		Find(&configs.TelegramConfigs).Error; err != nil {
 		logger.Error("GetUserActiveNotificationConfigs error", err, "user_address", userAddress, "is_active", true)
 		return nil, err
@@ -296,7 +325,7 @@ func (r *notificationRepository) GetUserActiveNotificationConfigs(ctx context.Co
 
 	// 获取激活的Lark配置
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND is_active = ?", normalizedUserAddress, true).
+		Where("LOWER(user_address) = ? AND organization_id = 0 AND is_active = ?", normalizedUserAddress, true).
 		Find(&configs.LarkConfigs).Error; err != nil {
 		logger.Error("GetUserActiveNotificationConfigs error", err, "user_address", userAddress, "is_active", true)
 		return nil, err
@@ -304,7 +333,7 @@ func (r *notificationRepository) GetUserActiveNotificationConfigs(ctx context.Co
 
 	// 获取激活的Feishu配置
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(user_address) = ? AND is_active = ?", normalizedUserAddress, true).
+		Where("LOWER(user_address) = ? AND organization_id = 0 AND is_active = ?", normalizedUserAddress, true).
 		Find(&configs.FeishuConfigs).Error; err != nil {
 		logger.Error("GetUserActiveNotificationConfigs error", err, "user_address", userAddress, "is_active", true)
 		return nil, err
@@ -314,6 +343,54 @@ func (r *notificationRepository) GetUserActiveNotificationConfigs(ctx context.Co
 	return configs, nil
 }
 
+// GetOrganizationActiveNotificationConfigs 获取关注了指定合约的组织的所有激活通知配置
+func (r *notificationRepository) GetOrganizationActiveNotificationConfigs(ctx context.Context, standard string, chainID int, contractAddress string) (*types.UserNotificationConfigs, error) {
+	configs := &types.UserNotificationConfigs{}
+
+	var table string
+	switch strings.ToLower(standard) {
+	case "compound":
+		table = "compound_timelocks"
+	case "openzeppelin":
+		table = "openzeppelin_timelocks"
+	default:
+		logger.Debug("GetOrganizationActiveNotificationConfigs unsupported standard", "standard", standard)
+		return configs, nil
+	}
+
+	organizationIDs := r.db.WithContext(ctx).
+		Table(table).
+		Select("DISTINCT organization_id").
+		Where("organization_id > 0 AND chain_id = ? AND LOWER(contract_address) = ?", chainID, strings.ToLower(contractAddress))
+
+	// 获取激活的Telegram配置
+	if err := r.db.WithContext(ctx).
+		Where("organization_id IN (?) AND is_active = ?", organizationIDs, true).
+		Find(&configs.TelegramConfigs).Error; err != nil {
+		logger.Error("GetOrganizationActiveNotificationConfigs error", err, "standard", standard, "chainID", chainID, "contract", contractAddress)
+		return nil, err
+	}
+
+	// 获取激活的Lark配置
+	if err := r.db.WithContext(ctx).
+		Where("organization_id IN (?) AND is_active = ?", organizationIDs, true).
+		Find(&configs.LarkConfigs).Error; err != nil {
+		logger.Error("GetOrganizationActiveNotificationConfigs error", err, "standard", standard, "chainID", chainID, "contract", contractAddress)
+		return nil, err
+	}
+
+	// 获取激活的Feishu配置
+	if err := r.db.WithContext(ctx).
+		Where("organization_id IN (?) AND is_active = ?", organizationIDs, true).
+		Find(&configs.FeishuConfigs).Error; err != nil {
+		logger.Error("GetOrganizationActiveNotificationConfigs error", err, "standard", standard, "chainID", chainID, "contract", contractAddress)
+		return nil, err
+	}
+
+	logger.Info("GetOrganizationActiveNotificationConfigs success", "standard", standard, "chainID", chainID, "contract", contractAddress)
+	return configs, nil
+}
+
 // GetContractRelatedUserAddresses 获取与指定合约相关的用户地址列表
 func (r *notificationRepository) GetContractRelatedUserAddresses(ctx context.Context, standard string, chainID int, contractAddress string) ([]string, error) {
 	var userAddresses []string
@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository 通知发件箱仓库接口
+type OutboxRepository interface {
+	// worker使用
+	ClaimDueOutbox(ctx context.Context, now time.Time, limit int, lockTimeout time.Duration) ([]types.NotificationOutbox, error)
+	MarkOutboxSent(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error
+
+	// 管理使用
+	GetUserOutboxByID(ctx context.Context, userAddress string, id int64) (*types.NotificationOutbox, error)
+	GetUserOutboxList(ctx context.Context, userAddress string, status string, offset int, limit int) ([]types.NotificationOutbox, int64, error)
+	ResetOutboxForRedelivery(ctx context.Context, id int64) error
+}
+
+// outboxRepository 通知发件箱仓库实现
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository 创建通知发件箱仓库实例
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// userRelatedContractCondition 与用户相关的合约条件（与通知接收人的判定规则保持一致），o 为包含 timelock_standard/chain_id/contract_address 列的表别名
+const userRelatedContractCondition = `(
+    (o.timelock_standard = 'compound' AND EXISTS (
+        SELECT 1 FROM compound_timelocks t
+        WHERE t.chain_id = o.chain_id AND LOWER(t.contract_address) = LOWER(o.contract_address)
+          AND (LOWER(t.creator_address) = ? OR LOWER(t.admin) = ? OR LOWER(COALESCE(t.pending_admin, '')) = ?)
+    ))
+    OR
+    (o.timelock_standard = 'openzeppelin' AND EXISTS (
+        SELECT 1 FROM openzeppelin_timelocks t
+        WHERE t.chain_id = o.chain_id AND LOWER(t.contract_address) = LOWER(o.contract_address)
+          AND (LOWER(t.creator_address) = ? OR LOWER(t.proposers) LIKE ? OR LOWER(t.executors) LIKE ?)
+    ))
+)`
+
+// userRelatedContractArgs 构建用户相关条件的参数
+func userRelatedContractArgs(userAddress string) []interface{} {
+	normalizedUserAddress := strings.ToLower(userAddress)
+	likePattern := "%" + normalizedUserAddress + "%"
+	return []interface{}{
+		normalizedUserAddress, normalizedUserAddress, normalizedUserAddress,
+		normalizedUserAddress, likePattern, likePattern,
+	}
+}
+
+// ClaimDueOutbox 领取到期的待投递记录（包括领取超时的投递中记录），使用 SKIP LOCKED 保证多个worker互不冲突
+func (r *outboxRepository) ClaimDueOutbox(ctx context.Context, now time.Time, limit int, lockTimeout time.Duration) ([]types.NotificationOutbox, error) {
+	var items []types.NotificationOutbox
+	sql := `
+        UPDATE notification_outbox SET status = ?, locked_at = ?, updated_at = ?
+        WHERE id IN (
+            SELECT id FROM notification_outbox
+            WHERE (status = ? AND next_attempt_at <= ?)
+               OR (status = ? AND locked_at IS NOT NULL AND locked_at <= ?)
+            ORDER BY next_attempt_at ASC
+            LIMIT ?
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING *`
+	if err := r.db.WithContext(ctx).Raw(sql,
+		types.OutboxStatusProcessing, now, now,
+		types.OutboxStatusPending, now,
+		types.OutboxStatusProcessing, now.Add(-lockTimeout),
+		limit,
+	).Scan(&items).Error; err != nil {
+		logger.Error("ClaimDueOutbox error", err, "limit", limit)
+		return nil, err
+	}
+	return items, nil
+}
+
+// MarkOutboxSent 标记投递成功
+func (r *outboxRepository) MarkOutboxSent(ctx context.Context, id int64) error {
+	now := time.Now()
+	if err := r.db.WithContext(ctx).
+		Model(&types.NotificationOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     types.OutboxStatusSent,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": nil,
+			"locked_at":  nil,
+			"sent_at":    now,
+		}).Error; err != nil {
+		logger.Error("MarkOutboxSent error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+// MarkOutboxFailed 标记投递失败，dead为true时进入死信状态，否则等待下次重试
+func (r *outboxRepository) MarkOutboxFailed(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	status := types.OutboxStatusPending
+	if dead {
+		status = types.OutboxStatusDead
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&types.NotificationOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+			"locked_at":       nil,
+		}).Error; err != nil {
+		logger.Error("MarkOutboxFailed error", err, "id", id, "attempts", attempts, "dead", dead)
+		return err
+	}
+	return nil
+}
+
+// GetUserOutboxByID 根据ID获取与用户相关的发件箱记录
+func (r *outboxRepository) GetUserOutboxByID(ctx context.Context, userAddress string, id int64) (*types.NotificationOutbox, error) {
+	var item types.NotificationOutbox
+	args := append([]interface{}{id}, userRelatedContractArgs(userAddress)...)
+	err := r.db.WithContext(ctx).
+		Table("notification_outbox o").
+		Where("o.id = ? AND "+userRelatedContractCondition, args...).
+		Take(&item).Error
+	if err != nil {
+		logger.Error("GetUserOutboxByID error", err, "user_address", userAddress, "id", id)
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetUserOutboxList 分页获取与用户相关的发件箱记录
+func (r *outboxRepository) GetUserOutboxList(ctx context.Context, userAddress string, status string, offset int, limit int) ([]types.NotificationOutbox, int64, error) {
+	var items []types.NotificationOutbox
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Table("notification_outbox o").
+		Where(userRelatedContractCondition, userRelatedContractArgs(userAddress)...)
+	if status != "" {
+		query = query.Where("o.status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("GetUserOutboxList count error", err, "user_address", userAddress, "status", status)
+		return nil, 0, fmt.Errorf("failed to count outbox: %w", err)
+	}
+
+	if err := query.Order("o.created_at DESC").Offset(offset).Limit(limit).Find(&items).Error; err != nil {
+		logger.Error("GetUserOutboxList error", err, "user_address", userAddress, "status", status)
+		return nil, 0, fmt.Errorf("failed to get outbox: %w", err)
+	}
+
+	logger.Info("GetUserOutboxList success", "user_address", userAddress, "status", status, "total", total)
+	return items, total, nil
+}
+
+// ResetOutboxForRedelivery 重置记录以便重新投递（仅限死信记录）
+func (r *outboxRepository) ResetOutboxForRedelivery(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&types.NotificationOutbox{}).
+		Where("id = ? AND status = ?", id, types.OutboxStatusDead).
+		Updates(map[string]interface{}{
+			"status":          types.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"locked_at":       nil,
+		})
+	if result.Error != nil {
+		logger.Error("ResetOutboxForRedelivery error", result.Error, "id", id)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("outbox record %d is not in dead state", id)
+	}
+	logger.Info("ResetOutboxForRedelivery success", "id", id)
+	return nil
+}
@@ -0,0 +1,482 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// organizationOwnedTables 归属于组织的资源表，组织仍拥有资源时不允许删除
+var organizationOwnedTables = []string{
+	"compound_timelocks",
+	"openzeppelin_timelocks",
+	"abis",
+	"telegram_configs",
+	"lark_configs",
+	"feishu_configs",
+	"user_emails",
+}
+
+// Repository 组织仓库接口
+type Repository interface {
+	// 组织
+	CreateOrganization(ctx context.Context, org *types.Organization, owner *types.OrganizationMember) error
+	GetOrganizationByID(ctx context.Context, id int64) (*types.Organization, error)
+	GetOrganizationsByWallets(ctx context.Context, walletAddresses []string) ([]types.OrganizationInfo, error)
+	UpdateOrganization(ctx context.Context, id int64, name, description string) error
+	DeleteOrganization(ctx context.Context, id int64) error
+	CountOrganizationResources(ctx context.Context, id int64) (int64, error)
+
+	// 成员
+	GetMember(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationID int64) ([]types.OrganizationMember, error)
+	CountMembers(ctx context.Context, organizationID int64) (int64, error)
+	CountOwners(ctx context.Context, organizationID int64) (int64, error)
+	UpdateMemberRole(ctx context.Context, organizationID int64, walletAddress, role string) error
+	RemoveMember(ctx context.Context, organizationID int64, walletAddress string) error
+
+	// 成员权限查询（供其他服务校验组织权限）
+	// 按钱包查询的方法传入账户下的全部钱包（见user仓库GetAccountWallets），任一钱包的成员身份都计入账户
+	GetMemberRoleByWallets(ctx context.Context, organizationID int64, walletAddresses []string) (string, error)
+	GetMemberRoleByUserID(ctx context.Context, organizationID int64, userID int64) (string, error)
+	GetMemberRolesByWallets(ctx context.Context, walletAddresses []string) (map[int64]string, error)
+	GetMemberRolesByUserID(ctx context.Context, userID int64) (map[int64]string, error)
+	GetOrganizationIDsByWallets(ctx context.Context, walletAddresses []string) ([]int64, error)
+	GetOrganizationIDsByUserID(ctx context.Context, userID int64) ([]int64, error)
+
+	// 邀请
+	CreateInvitation(ctx context.Context, invitation *types.OrganizationInvitation) error
+	GetInvitationByID(ctx context.Context, id int64) (*types.OrganizationInvitation, error)
+	GetPendingInvitation(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationInvitation, error)
+	GetPendingInvitationsByWallets(ctx context.Context, walletAddresses []string) ([]types.OrganizationInvitation, error)
+	GetPendingInvitationsByOrganization(ctx context.Context, organizationID int64) ([]types.OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, invitation *types.OrganizationInvitation, member *types.OrganizationMember) error
+	UpdateInvitationStatus(ctx context.Context, id int64, fromStatus, toStatus string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 创建组织仓库实例
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// CreateOrganization 创建组织，并在同一事务中写入创建者的所有者成员记录
+func (r *repository) CreateOrganization(ctx context.Context, org *types.Organization, owner *types.OrganizationMember) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = org.ID
+		return tx.Create(owner).Error
+	})
+	if err != nil {
+		logger.Error("CreateOrganization Error: ", err, "name", org.Name, "created_by", org.CreatedBy)
+		return err
+	}
+
+	logger.Info("CreateOrganization: ", "id", org.ID, "name", org.Name, "created_by", org.CreatedBy)
+	return nil
+}
+
+// GetOrganizationByID 根据ID获取组织
+func (r *repository) GetOrganizationByID(ctx context.Context, id int64) (*types.Organization, error) {
+	var org types.Organization
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&org).Error; err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// GetOrganizationsByWallets 获取钱包所在的组织列表，多个钱包是同一组织成员时取最高角色
+func (r *repository) GetOrganizationsByWallets(ctx context.Context, walletAddresses []string) ([]types.OrganizationInfo, error) {
+	var rows []types.OrganizationInfo
+	sql := `
+        SELECT o.*, m.role,
+            (SELECT COUNT(*) FROM organization_members c WHERE c.organization_id = o.id) AS member_count
+        FROM organizations o
+        JOIN organization_members m ON m.organization_id = o.id
+        WHERE LOWER(m.wallet_address) IN ?
+        ORDER BY o.created_at DESC
+    `
+	if err := r.db.WithContext(ctx).Raw(sql, lowerAddresses(walletAddresses)).Scan(&rows).Error; err != nil {
+		logger.Error("GetOrganizationsByWallets Error: ", err, "wallet_addresses", walletAddresses)
+		return nil, err
+	}
+
+	orgs := make([]types.OrganizationInfo, 0, len(rows))
+	index := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		if i, ok := index[row.ID]; ok {
+			orgs[i].Role = types.HigherOrganizationRole(orgs[i].Role, row.Role)
+			continue
+		}
+		index[row.ID] = len(orgs)
+		orgs = append(orgs, row)
+	}
+	return orgs, nil
+}
+
+// UpdateOrganization 更新组织名称和描述
+func (r *repository) UpdateOrganization(ctx context.Context, id int64, name, description string) error {
+	err := r.db.WithContext(ctx).
+		Model(&types.Organization{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"name":        name,
+			"description": description,
+			"updated_at":  time.Now(),
+		}).Error
+	if err != nil {
+		logger.Error("UpdateOrganization Error: ", err, "id", id)
+		return err
+	}
+	logger.Info("UpdateOrganization: ", "id", id, "name", name)
+	return nil
+}
+
+// DeleteOrganization 删除组织及其成员和邀请
+func (r *repository) DeleteOrganization(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", id).Delete(&types.OrganizationInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("organization_id = ?", id).Delete(&types.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&types.Organization{}).Error
+	})
+	if err != nil {
+		logger.Error("DeleteOrganization Error: ", err, "id", id)
+		return err
+	}
+	logger.Info("DeleteOrganization: ", "id", id)
+	return nil
+}
+
+// CountOrganizationResources 统计归属于组织的timelock合约、ABI、通知配置和邮箱数量
+func (r *repository) CountOrganizationResources(ctx context.Context, id int64) (int64, error) {
+	var total int64
+	for _, table := range organizationOwnedTables {
+		var count int64
+		if err := r.db.WithContext(ctx).Table(table).Where("organization_id = ?", id).Count(&count).Error; err != nil {
+			logger.Error("CountOrganizationResources Error: ", err, "id", id, "table", table)
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// GetMember 获取组织成员
+func (r *repository) GetMember(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationMember, error) {
+	var member types.OrganizationMember
+	normalizedWalletAddress := strings.ToLower(walletAddress)
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(wallet_address) = ?", organizationID, normalizedWalletAddress).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMembers 获取组织的所有成员
+func (r *repository) GetMembers(ctx context.Context, organizationID int64) ([]types.OrganizationMember, error) {
+	var members []types.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		logger.Error("GetMembers Error: ", err, "organization_id", organizationID)
+		return nil, err
+	}
+	return members, nil
+}
+
+// CountMembers 统计组织成员数量
+func (r *repository) CountMembers(ctx context.Context, organizationID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Where("organization_id = ?", organizationID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("CountMembers Error: ", err, "organization_id", organizationID)
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountOwners 统计组织所有者数量
+func (r *repository) CountOwners(ctx context.Context, organizationID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, types.OrganizationRoleOwner).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("CountOwners Error: ", err, "organization_id", organizationID)
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateMemberRole 修改成员角色
+func (r *repository) UpdateMemberRole(ctx context.Context, organizationID int64, walletAddress, role string) error {
+	normalizedWalletAddress := strings.ToLower(walletAddress)
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Where("organization_id = ? AND LOWER(wallet_address) = ?", organizationID, normalizedWalletAddress).
+		Updates(map[string]interface{}{
+			"role":       role,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		logger.Error("UpdateMemberRole Error: ", err, "organization_id", organizationID, "wallet_address", walletAddress)
+		return err
+	}
+	logger.Info("UpdateMemberRole: ", "organization_id", organizationID, "wallet_address", walletAddress, "role", role)
+	return nil
+}
+
+// RemoveMember 移除组织成员
+func (r *repository) RemoveMember(ctx context.Context, organizationID int64, walletAddress string) error {
+	normalizedWalletAddress := strings.ToLower(walletAddress)
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(wallet_address) = ?", organizationID, normalizedWalletAddress).
+		Delete(&types.OrganizationMember{}).Error
+	if err != nil {
+		logger.Error("RemoveMember Error: ", err, "organization_id", organizationID, "wallet_address", walletAddress)
+		return err
+	}
+	logger.Info("RemoveMember: ", "organization_id", organizationID, "wallet_address", walletAddress)
+	return nil
+}
+
+// GetMemberRoleByWallets 获取钱包在组织中的最高角色，均不是成员时返回空字符串
+func (r *repository) GetMemberRoleByWallets(ctx context.Context, organizationID int64, walletAddresses []string) (string, error) {
+	var roles []string
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Where("organization_id = ? AND LOWER(wallet_address) IN ?", organizationID, lowerAddresses(walletAddresses)).
+		Pluck("role", &roles).Error
+	if err != nil {
+		logger.Error("GetMemberRoleByWallets Error: ", err, "organization_id", organizationID, "wallet_addresses", walletAddresses)
+		return "", err
+	}
+
+	role := ""
+	for _, candidate := range roles {
+		role = types.HigherOrganizationRole(role, candidate)
+	}
+	return role, nil
+}
+
+// GetMemberRoleByUserID 获取用户在组织中的角色，非成员返回空字符串
+func (r *repository) GetMemberRoleByUserID(ctx context.Context, organizationID int64, userID int64) (string, error) {
+	var member types.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		logger.Error("GetMemberRoleByUserID Error: ", err, "organization_id", organizationID, "user_id", userID)
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// GetMemberRolesByWallets 获取钱包在所在各组织中的最高角色，键为组织ID
+func (r *repository) GetMemberRolesByWallets(ctx context.Context, walletAddresses []string) (map[int64]string, error) {
+	var members []types.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("LOWER(wallet_address) IN ?", lowerAddresses(walletAddresses)).
+		Find(&members).Error
+	if err != nil {
+		logger.Error("GetMemberRolesByWallets Error: ", err, "wallet_addresses", walletAddresses)
+		return nil, err
+	}
+
+	roles := make(map[int64]string, len(members))
+	for _, member := range members {
+		roles[member.OrganizationID] = types.HigherOrganizationRole(roles[member.OrganizationID], member.Role)
+	}
+	return roles, nil
+}
+
+// GetMemberRolesByUserID 获取用户在所在各组织中的角色，键为组织ID
+func (r *repository) GetMemberRolesByUserID(ctx context.Context, userID int64) (map[int64]string, error) {
+	var members []types.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Find(&members).Error
+	if err != nil {
+		logger.Error("GetMemberRolesByUserID Error: ", err, "user_id", userID)
+		return nil, err
+	}
+
+	roles := make(map[int64]string, len(members))
+	for _, member := range members {
+		roles[member.OrganizationID] = member.Role
+	}
+	return roles, nil
+}
+
+// GetOrganizationIDsByWallets 获取钱包所在的组织ID列表
+func (r *repository) GetOrganizationIDsByWallets(ctx context.Context, walletAddresses []string) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Distinct("organization_id").
+		Where("LOWER(wallet_address) IN ?", lowerAddresses(walletAddresses)).
+		Pluck("organization_id", &ids).Error
+	if err != nil {
+		logger.Error("GetOrganizationIDsByWallets Error: ", err, "wallet_addresses", walletAddresses)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetOrganizationIDsByUserID 获取用户所在的组织ID列表
+func (r *repository) GetOrganizationIDsByUserID(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Model(&types.OrganizationMember{}).
+		Where("user_id = ?", userID).
+		Pluck("organization_id", &ids).Error
+	if err != nil {
+		logger.Error("GetOrganizationIDsByUserID Error: ", err, "user_id", userID)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateInvitation 创建邀请
+func (r *repository) CreateInvitation(ctx context.Context, invitation *types.OrganizationInvitation) error {
+	if err := r.db.WithContext(ctx).Create(invitation).Error; err != nil {
+		logger.Error("CreateInvitation Error: ", err, "organization_id", invitation.OrganizationID, "wallet_address", invitation.WalletAddress)
+		return err
+	}
+	logger.Info("CreateInvitation: ", "id", invitation.ID, "organization_id", invitation.OrganizationID, "wallet_address", invitation.WalletAddress, "role", invitation.Role)
+	return nil
+}
+
+// GetInvitationByID 根据ID获取邀请
+func (r *repository) GetInvitationByID(ctx context.Context, id int64) (*types.OrganizationInvitation, error) {
+	var invitation types.OrganizationInvitation
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingInvitation 获取组织对钱包未过期的待处理邀请
+func (r *repository) GetPendingInvitation(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationInvitation, error) {
+	var invitation types.OrganizationInvitation
+	normalizedWalletAddress := strings.ToLower(walletAddress)
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(wallet_address) = ? AND status = ? AND expires_at > ?", organizationID, normalizedWalletAddress, types.InvitationStatusPending, time.Now()).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingInvitationsByWallets 获取发给这些钱包的未过期待处理邀请
+func (r *repository) GetPendingInvitationsByWallets(ctx context.Context, walletAddresses []string) ([]types.OrganizationInvitation, error) {
+	var invitations []types.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("LOWER(wallet_address) IN ? AND status = ? AND expires_at > ?", lowerAddresses(walletAddresses), types.InvitationStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		logger.Error("GetPendingInvitationsByWallets Error: ", err, "wallet_addresses", walletAddresses)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// GetPendingInvitationsByOrganization 获取组织发出的未过期待处理邀请
+func (r *repository) GetPendingInvitationsByOrganization(ctx context.Context, organizationID int64) ([]types.OrganizationInvitation, error) {
+	var invitations []types.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND status = ? AND expires_at > ?", organizationID, types.InvitationStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		logger.Error("GetPendingInvitationsByOrganization Error: ", err, "organization_id", organizationID)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation 接受邀请，在同一事务中更新邀请状态并写入成员记录
+func (r *repository) AcceptInvitation(ctx context.Context, invitation *types.OrganizationInvitation, member *types.OrganizationMember) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&types.OrganizationInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, types.InvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":       types.InvitationStatusAccepted,
+				"responded_at": now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(member).Error
+	})
+	if err != nil {
+		logger.Error("AcceptInvitation Error: ", err, "id", invitation.ID, "wallet_address", member.WalletAddress)
+		return err
+	}
+	logger.Info("AcceptInvitation: ", "id", invitation.ID, "organization_id", member.OrganizationID, "wallet_address", member.WalletAddress, "role", member.Role)
+	return nil
+}
+
+// UpdateInvitationStatus 更新邀请状态（仅当当前状态为fromStatus时），返回是否更新成功
+func (r *repository) UpdateInvitationStatus(ctx context.Context, id int64, fromStatus, toStatus string) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&types.OrganizationInvitation{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(map[string]interface{}{
+			"status":       toStatus,
+			"responded_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		logger.Error("UpdateInvitationStatus Error: ", result.Error, "id", id, "to_status", toStatus)
+		return false, result.Error
+	}
+	logger.Info("UpdateInvitationStatus: ", "id", id, "from_status", fromStatus, "to_status", toStatus, "updated", result.RowsAffected)
+	return result.RowsAffected > 0, nil
+}
+
+// lowerAddresses 钱包地址转为小写，与LOWER(wallet_address)比较
+func lowerAddresses(walletAddresses []string) []string {
+	lowered := make([]string, len(walletAddresses))
+	for i, address := range walletAddresses {
+		lowered[i] = strings.ToLower(address)
+	}
+	return lowered
+}
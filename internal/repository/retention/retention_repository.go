@@ -0,0 +1,212 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// flowActiveStatuses 进行中的流程状态，被这些流程引用的交易和通知日志不会被清理
+var flowActiveStatuses = []string{"waiting", "ready"}
+
+// tableSpec 可清理表的时间列、状态表达式和保护条件
+type tableSpec struct {
+	ageColumn  string   // 按该时间列判断是否超过保留期
+	statusExpr string   // 策略中statuses匹配的SQL表达式
+	statuses   []string // statuses允许的取值
+	guard      string   // 必须满足的保护条件，为空时不保护
+}
+
+var tableSpecs = map[string]tableSpec{
+	"compound_timelock_transactions":     transactionSpec("compound_timelock_transactions", "event_tx_hash", "compound"),
+	"openzeppelin_timelock_transactions": transactionSpec("openzeppelin_timelock_transactions", "event_id", "openzeppelin"),
+	"notification_logs":                  logSpec("notification_logs"),
+	"email_send_logs":                    logSpec("email_send_logs"),
+	"auth_nonces": {
+		ageColumn:  "created_at",
+		statusExpr: "CASE WHEN auth_nonces.is_used THEN 'used' WHEN auth_nonces.expires_at < NOW() THEN 'expired' ELSE 'active' END",
+		statuses:   []string{"used", "expired"},
+		// 未使用且未过期的nonce仍可能用于登录
+		guard: "(auth_nonces.is_used OR auth_nonces.expires_at < NOW())",
+	},
+}
+
+// transactionSpec 交易表按所属流程的状态过滤，未关联流程的交易状态为unlinked
+func transactionSpec(table, flowColumn, standard string) tableSpec {
+	link := fmt.Sprintf("f.timelock_standard = '%s' AND f.flow_id = %s.%s AND f.chain_id = %s.chain_id AND LOWER(f.contract_address) = LOWER(%s.contract_address)",
+		standard, table, flowColumn, table, table)
+	return tableSpec{
+		ageColumn:  table + ".block_timestamp",
+		statusExpr: fmt.Sprintf("COALESCE((SELECT f.status FROM timelock_transaction_flows f WHERE %s ORDER BY f.id LIMIT 1), 'unlinked')", link),
+		statuses:   []string{"executed", "cancelled", "expired", "unlinked"},
+		guard:      activeFlowGuard(link),
+	}
+}
+
+// logSpec 通知日志按发送结果过滤；日志同时用于去重，进行中流程的日志不清理以免重复发送
+func logSpec(table string) tableSpec {
+	link := fmt.Sprintf("f.timelock_standard = %s.timelock_standard AND f.flow_id = %s.flow_id AND f.chain_id = %s.chain_id AND LOWER(f.contract_address) = LOWER(%s.contract_address)",
+		table, table, table, table)
+	return tableSpec{
+		ageColumn:  table + ".sent_at",
+		statusExpr: table + ".send_status",
+		statuses:   []string{"success", "failed"},
+		guard:      activeFlowGuard(link),
+	}
+}
+
+func activeFlowGuard(link string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM timelock_transaction_flows f WHERE %s AND f.status IN ('%s'))", link, strings.Join(flowActiveStatuses, "','"))
+}
+
+// SupportedTables 返回支持配置保留策略的表
+func SupportedTables() []string {
+	tables := make([]string, 0, len(tableSpecs))
+	for table := range tableSpecs {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// CheckPolicy 检查表是否支持清理以及状态过滤值是否有效
+func CheckPolicy(table string, statuses []string) error {
+	spec, ok := tableSpecs[table]
+	if !ok {
+		return fmt.Errorf("table %s does not support retention, supported: %s", table, strings.Join(SupportedTables(), ", "))
+	}
+	for _, status := range statuses {
+		valid := false
+		for _, allowed := range spec.statuses {
+			if status == allowed {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid retention status %q for table %s, allowed: %s", status, table, strings.Join(spec.statuses, ", "))
+		}
+	}
+	return nil
+}
+
+// PruneFilter 生成可清理行的WHERE条件：早于cutoff、满足保护条件，且状态在statuses中（为空时不按状态过滤）
+func PruneFilter(table string, cutoff time.Time, statuses []string) (string, []interface{}, error) {
+	if err := CheckPolicy(table, statuses); err != nil {
+		return "", nil, err
+	}
+	spec := tableSpecs[table]
+
+	conditions := []string{spec.ageColumn + " < ?"}
+	args := []interface{}{cutoff}
+	if spec.guard != "" {
+		conditions = append(conditions, spec.guard)
+	}
+	if len(statuses) > 0 {
+		conditions = append(conditions, spec.statusExpr+" IN ?")
+		args = append(args, statuses)
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// Repository 历史数据清理仓库接口
+type Repository interface {
+	DeleteBatch(ctx context.Context, table, where string, args []interface{}, limit int) (int64, error)
+	DeleteByIDs(ctx context.Context, table string, ids []int64, where string, args []interface{}) (int64, error)
+	CreateRun(ctx context.Context, run *types.RetentionRun) error
+	UpdateRun(ctx context.Context, run *types.RetentionRun) error
+	GetLatestRuns(ctx context.Context) ([]types.RetentionRun, error)
+	FailRunningRuns(ctx context.Context, message string) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 创建历史数据清理仓库实例
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// DeleteBatch 按id顺序删除最多limit行满足条件的数据，返回删除行数
+func (r *repository) DeleteBatch(ctx context.Context, table, where string, args []interface{}, limit int) (int64, error) {
+	if _, ok := tableSpecs[table]; !ok {
+		return 0, fmt.Errorf("table %s does not support retention", table)
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (SELECT id FROM %s WHERE %s ORDER BY id LIMIT ?)", table, table, where)
+	result := r.db.WithContext(ctx).Exec(query, append(append([]interface{}{}, args...), limit)...)
+	if result.Error != nil {
+		logger.Error("DeleteBatch Error: ", result.Error, "table", table)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByIDs 删除ids中仍满足条件的行，用于只删除已归档的行
+func (r *repository) DeleteByIDs(ctx context.Context, table string, ids []int64, where string, args []interface{}) (int64, error) {
+	if _, ok := tableSpecs[table]; !ok {
+		return 0, fmt.Errorf("table %s does not support retention", table)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN ? AND %s", table, where)
+	result := r.db.WithContext(ctx).Exec(query, append([]interface{}{ids}, args...)...)
+	if result.Error != nil {
+		logger.Error("DeleteByIDs Error: ", result.Error, "table", table, "ids", len(ids))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CreateRun 写入一条运行记录
+func (r *repository) CreateRun(ctx context.Context, run *types.RetentionRun) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		logger.Error("CreateRun Error: ", err, "table", run.TargetTable)
+		return err
+	}
+	return nil
+}
+
+// UpdateRun 保存运行结果
+func (r *repository) UpdateRun(ctx context.Context, run *types.RetentionRun) error {
+	if err := r.db.WithContext(ctx).Save(run).Error; err != nil {
+		logger.Error("UpdateRun Error: ", err, "id", run.ID, "status", run.Status)
+		return err
+	}
+	return nil
+}
+
+// GetLatestRuns 获取每张表最近一次运行记录
+func (r *repository) GetLatestRuns(ctx context.Context) ([]types.RetentionRun, error) {
+	var runs []types.RetentionRun
+	err := r.db.WithContext(ctx).
+		Raw("SELECT DISTINCT ON (target_table) * FROM retention_runs ORDER BY target_table, started_at DESC, id DESC").
+		Scan(&runs).Error
+	if err != nil {
+		logger.Error("GetLatestRuns Error: ", err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+// FailRunningRuns 将服务重启前未完成的运行记录标记为失败
+func (r *repository) FailRunningRuns(ctx context.Context, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&types.RetentionRun{}).
+		Where("status = ?", types.RetentionRunStatusRunning).
+		Updates(map[string]interface{}{"status": types.RetentionRunStatusFailed, "error_message": message})
+	if result.Error != nil {
+		logger.Error("FailRunningRuns Error: ", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
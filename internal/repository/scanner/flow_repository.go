@@ -13,24 +13,27 @@ import (
 
 // FlowRepository 流程管理仓库接口
 type FlowRepository interface {
-	CreateFlow(ctx context.Context, flow *types.TimelockTransactionFlow) error
+	CreateFlow(ctx context.Context, flow *types.TimelockTransactionFlow, outbox []types.NotificationOutbox) error
 	GetFlowByID(ctx context.Context, flowID, timelockStandard string, chainID int, contractAddress string) (*types.TimelockTransactionFlow, error)
-	UpdateFlow(ctx context.Context, flow *types.TimelockTransactionFlow) error
+	UpdateFlow(ctx context.Context, flow *types.TimelockTransactionFlow, outbox []types.NotificationOutbox) error
 
 	// 状态管理相关方法
 	GetWaitingFlowsDue(ctx context.Context, now time.Time, limit int) ([]types.TimelockTransactionFlow, error)
 	GetCompoundFlowsExpired(ctx context.Context, now time.Time, limit int) ([]types.TimelockTransactionFlow, error)
 	UpdateFlowStatus(ctx context.Context, flowID, timelockStandard string, chainID int, contractAddress string, fromStatus, toStatus string) error
-	BatchUpdateFlowStatus(ctx context.Context, flows []types.TimelockTransactionFlow, toStatus string) error
+	BatchUpdateFlowStatus(ctx context.Context, flows []types.TimelockTransactionFlow, toStatus string, outbox []types.NotificationOutbox) error
 
 	// 新API查询方法
-	GetUserRelatedCompoundFlows(ctx context.Context, userAddress string, status *string, standard *string, offset int, limit int) ([]types.TimelockTransactionFlow, int64, error)
-	GetUserRelatedCompoundFlowsCount(ctx context.Context, userAddress string, standard *string) (*types.FlowStatusCount, error)
+	GetUserRelatedCompoundFlows(ctx context.Context, userAddresses []string, organizationIDs []int64, status *string, standard *string, offset int, limit int) ([]types.TimelockTransactionFlow, int64, error)
+	GetUserRelatedCompoundFlowsCount(ctx context.Context, userAddresses []string, organizationIDs []int64, standard *string) (*types.FlowStatusCount, error)
 	GetCompoundTransactionDetail(ctx context.Context, standard string, txHash string) (*types.CompoundTimelockTransactionDetail, error)
 	GetCompoundQueueTransactionFunctionSignature(ctx context.Context, queueTxHash string, contractAddress string) (*string, error)
 
 	// GRACE_PERIOD相关方法
 	RefreshCompoundFlowsExpiredAt(ctx context.Context, chainID int, contractAddress string, gracePeriodSeconds int64) (int64, error)
+
+	// 监控指标
+	CountFlowsByStatus(ctx context.Context) ([]types.FlowStatusTotal, error)
 }
 
 type flowRepository struct {
@@ -44,9 +47,15 @@ func NewFlowRepository(db *gorm.DB) FlowRepository {
 	}
 }
 
-// CreateFlow 创建交易流程记录
-func (r *flowRepository) CreateFlow(ctx context.Context, flow *types.TimelockTransactionFlow) error {
-	if err := r.db.WithContext(ctx).Create(flow).Error; err != nil {
+// CreateFlow 创建交易流程记录，并在同一事务中写入通知发件箱
+func (r *flowRepository) CreateFlow(ctx context.Context, flow *types.TimelockTransactionFlow, outbox []types.NotificationOutbox) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(flow).Error; err != nil {
+			return err
+		}
+		return createOutbox(tx, outbox)
+	})
+	if err != nil {
 		logger.Error("CreateFlow Error", err, "flow_id", flow.FlowID, "standard", flow.TimelockStandard)
 		return err
 	}
@@ -54,6 +63,17 @@ func (r *flowRepository) CreateFlow(ctx context.Context, flow *types.TimelockTra
 	return nil
 }
 
+// createOutbox 在事务中写入通知发件箱记录
+func createOutbox(tx *gorm.DB, outbox []types.NotificationOutbox) error {
+	if len(outbox) == 0 {
+		return nil
+	}
+	if err := tx.Create(&outbox).Error; err != nil {
+		return fmt.Errorf("failed to create notification outbox: %w", err)
+	}
+	return nil
+}
+
 // GetFlowByID 根据流程ID获取交易流程
 func (r *flowRepository) GetFlowByID(ctx context.Context, flowID, timelockStandard string, chainID int, contractAddress string) (*types.TimelockTransactionFlow, error) {
 	var flow types.TimelockTransactionFlow
@@ -74,9 +94,15 @@ func (r *flowRepository) GetFlowByID(ctx context.Context, flowID, timelockStanda
 	return &flow, nil
 }
 
-// UpdateFlow 更新交易流程
-func (r *flowRepository) UpdateFlow(ctx context.Context, flow *types.TimelockTransactionFlow) error {
-	if err := r.db.WithContext(ctx).Save(flow).Error; err != nil {
+// UpdateFlow 更新交易流程，并在同一事务中写入通知发件箱
+func (r *flowRepository) UpdateFlow(ctx context.Context, flow *types.TimelockTransactionFlow, outbox []types.NotificationOutbox) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(flow).Error; err != nil {
+			return err
+		}
+		return createOutbox(tx, outbox)
+	})
+	if err != nil {
 		logger.Error("UpdateFlow Error", err, "flow_id", flow.FlowID)
 		return err
 	}
@@ -146,8 +172,8 @@ func (r *flowRepository) UpdateFlowStatus(ctx context.Context, flowID, timelockS
 	return nil
 }
 
-// BatchUpdateFlowStatus 批量更新流程状态
-func (r *flowRepository) BatchUpdateFlowStatus(ctx context.Context, flows []types.TimelockTransactionFlow, toStatus string) error {
+// BatchUpdateFlowStatus 批量更新流程状态，并在同一事务中写入通知发件箱
+func (r *flowRepository) BatchUpdateFlowStatus(ctx context.Context, flows []types.TimelockTransactionFlow, toStatus string, outbox []types.NotificationOutbox) error {
 	if len(flows) == 0 {
 		return nil
 	}
@@ -164,62 +190,92 @@ func (r *flowRepository) BatchUpdateFlowStatus(ctx context.Context, flows []type
 
 	whereClause := "(" + strings.Join(conditions, " OR ") + ")"
 
-	result := r.db.WithContext(ctx).
-		Model(&types.TimelockTransactionFlow{}).
-		Where(whereClause, args...).
-		Update("status", toStatus)
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&types.TimelockTransactionFlow{}).
+			Where(whereClause, args...).
+			Update("status", toStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+		return createOutbox(tx, outbox)
+	})
 
-	if result.Error != nil {
-		logger.Error("BatchUpdateFlowStatus Error", result.Error, "count", len(flows), "to_status", toStatus)
-		return result.Error
+	if err != nil {
+		logger.Error("BatchUpdateFlowStatus Error", err, "count", len(flows), "to_status", toStatus)
+		return err
 	}
 
-	logger.Info("BatchUpdateFlowStatus completed", "updated", result.RowsAffected, "to_status", toStatus)
+	logger.Info("BatchUpdateFlowStatus completed", "updated", rowsAffected, "to_status", toStatus, "outbox", len(outbox))
 	return nil
 }
 
-// GetUserRelatedCompoundFlows 获取与用户相关的流程列表
-func (r *flowRepository) GetUserRelatedCompoundFlows(ctx context.Context, userAddress string, status *string, standard *string, offset int, limit int) ([]types.TimelockTransactionFlow, int64, error) {
-	normalizedUserAddress := strings.ToLower(userAddress)
-
-	// 构建查询条件
-	query := r.db.WithContext(ctx).Model(&types.TimelockTransactionFlow{})
-
-	// 构建WHERE条件，包含两种情况：
-	// 1. initiator_address是该地址
-	// 2. 该flow的合约中，该地址是管理员或有权限的用户
-	whereConditions := []string{}
-	args := []interface{}{}
-
-	// 第一种情况：initiator_address是该地址
-	whereConditions = append(whereConditions, "LOWER(initiator_address) = ?")
-	args = append(args, normalizedUserAddress)
+// organizationFlowCondition 合约属于指定组织的流程条件，参数为两次组织ID列表
+const organizationFlowCondition = `(
+		(timelock_standard = 'compound' AND (chain_id, contract_address) IN (
+			SELECT chain_id, contract_address FROM compound_timelocks
+			WHERE organization_id IN ? AND status != 'deleted'
+		)) OR
+		(timelock_standard = 'openzeppelin' AND (chain_id, contract_address) IN (
+			SELECT chain_id, contract_address FROM openzeppelin_timelocks
+			WHERE organization_id IN ? AND status != 'deleted'
+		))
+	)`
 
-	// 第二种情况：根据合约权限查询
-	// 需要联表查询compound_timelocks和openzeppelin_timelocks
+// userRelatedFlowConditions 构建与用户相关的流程条件（各条件之间为OR），userAddresses为账户下的全部钱包：
+// 1. initiator_address是任一钱包
+// 2. 该flow的合约中，任一钱包是管理员或有权限的用户
+// 3. 合约在用户所在组织的共享监控列表中
+func userRelatedFlowConditions(userAddresses []string, organizationIDs []int64) ([]string, []interface{}) {
+	whereConditions := []string{"LOWER(initiator_address) IN ?"}
+	args := []interface{}{userAddresses}
 
 	// Compound权限查询：admin或pending_admin
 	compoundCondition := `(
 		timelock_standard = 'compound' AND 
 		(chain_id, contract_address) IN (
 			SELECT chain_id, contract_address FROM compound_timelocks 
-			WHERE LOWER(admin) = ? OR LOWER(pending_admin) = ?
+			WHERE LOWER(admin) IN ? OR LOWER(pending_admin) IN ?
 		)
 	)`
 	whereConditions = append(whereConditions, compoundCondition)
-	args = append(args, normalizedUserAddress, normalizedUserAddress)
+	args = append(args, userAddresses, userAddresses)
 
-	// OpenZeppelin权限查询：proposers或executors中的一个
+	// OpenZeppelin权限查询：proposers或executors中包含任一钱包
+	roleConditions := make([]string, 0, len(userAddresses)*2)
+	for _, address := range userAddresses {
+		roleConditions = append(roleConditions, "LOWER(proposers) LIKE '%' || ? || '%'", "LOWER(executors) LIKE '%' || ? || '%'")
+		args = append(args, address, address)
+	}
 	ozCondition := `(
 		timelock_standard = 'openzeppelin' AND 
 		(chain_id, contract_address) IN (
 			SELECT chain_id, contract_address FROM openzeppelin_timelocks 
-			WHERE LOWER(proposers) LIKE '%' || ? || '%' OR
-				LOWER(executors) LIKE '%' || ? || '%'
+			WHERE ` + strings.Join(roleConditions, " OR ") + `
 		)
 	)`
 	whereConditions = append(whereConditions, ozCondition)
-	args = append(args, normalizedUserAddress, normalizedUserAddress)
+
+	if len(organizationIDs) > 0 {
+		whereConditions = append(whereConditions, organizationFlowCondition)
+		args = append(args, organizationIDs, organizationIDs)
+	}
+
+	return whereConditions, args
+}
+
+// GetUserRelatedCompoundFlows 获取与用户相关的流程列表
+func (r *flowRepository) GetUserRelatedCompoundFlows(ctx context.Context, userAddresses []string, organizationIDs []int64, status *string, standard *string, offset int, limit int) ([]types.TimelockTransactionFlow, int64, error) {
+	normalizedAddresses := make([]string, len(userAddresses))
+	for i, address := range userAddresses {
+		normalizedAddresses[i] = strings.ToLower(address)
+	}
+
+	// 构建查询条件
+	query := r.db.WithContext(ctx).Model(&types.TimelockTransactionFlow{})
+
+	whereConditions, args := userRelatedFlowConditions(normalizedAddresses, organizationIDs)
 
 	// 组合所有条件
 	finalWhere := "(" + strings.Join(whereConditions, " OR ") + ")"
@@ -239,7 +295,7 @@ func (r *flowRepository) GetUserRelatedCompoundFlows(ctx context.Context, userAd
 	// 计算总数
 	var total int64
 	if err := query.Where(finalWhere, args...).Count(&total).Error; err != nil {
-		logger.Error("GetUserRelatedCompoundFlows Count Error", err, "user", userAddress)
+		logger.Error("GetUserRelatedCompoundFlows Count Error", err, "user", normalizedAddresses)
 		return nil, 0, err
 	}
 
@@ -256,7 +312,7 @@ func (r *flowRepository) GetUserRelatedCompoundFlows(ctx context.Context, userAd
 	err := dataQuery.Find(&flows).Error
 
 	if err != nil {
-		logger.Error("GetUserRelatedCompoundFlows Error", err, "user", userAddress)
+		logger.Error("GetUserRelatedCompoundFlows Error", err, "user", normalizedAddresses)
 		return nil, 0, err
 	}
 
@@ -264,45 +320,16 @@ func (r *flowRepository) GetUserRelatedCompoundFlows(ctx context.Context, userAd
 }
 
 // GetUserRelatedCompoundFlowsCount 获取与用户相关的流程数量统计
-func (r *flowRepository) GetUserRelatedCompoundFlowsCount(ctx context.Context, userAddress string, standard *string) (*types.FlowStatusCount, error) {
-	normalizedUserAddress := strings.ToLower(userAddress)
+func (r *flowRepository) GetUserRelatedCompoundFlowsCount(ctx context.Context, userAddresses []string, organizationIDs []int64, standard *string) (*types.FlowStatusCount, error) {
+	normalizedAddresses := make([]string, len(userAddresses))
+	for i, address := range userAddresses {
+		normalizedAddresses[i] = strings.ToLower(address)
+	}
 
 	// 构建基础查询条件，复用GetUserRelatedCompoundFlows的逻辑
 	query := r.db.WithContext(ctx).Model(&types.TimelockTransactionFlow{})
 
-	// 构建WHERE条件，包含两种情况：
-	// 1. initiator_address是该地址
-	// 2. 该flow的合约中，该地址是管理员或有权限的用户
-	whereConditions := []string{}
-	args := []interface{}{}
-
-	// 第一种情况：initiator_address是该地址
-	whereConditions = append(whereConditions, "LOWER(initiator_address) = ?")
-	args = append(args, normalizedUserAddress)
-
-	// 第二种情况：根据合约权限查询
-	// Compound权限查询：admin或pending_admin
-	compoundCondition := `(
-		timelock_standard = 'compound' AND 
-		(chain_id, contract_address) IN (
-			SELECT chain_id, contract_address FROM compound_timelocks 
-			WHERE LOWER(admin) = ? OR LOWER(pending_admin) = ?
-		)
-	)`
-	whereConditions = append(whereConditions, compoundCondition)
-	args = append(args, normalizedUserAddress, normalizedUserAddress)
-
-	// OpenZeppelin权限查询：proposers或executors中的一个
-	ozCondition := `(
-		timelock_standard = 'openzeppelin' AND 
-		(chain_id, contract_address) IN (
-			SELECT chain_id, contract_address FROM openzeppelin_timelocks 
-			WHERE LOWER(proposers) LIKE '%' || ? || '%' OR
-				LOWER(executors) LIKE '%' || ? || '%'
-		)
-	)`
-	whereConditions = append(whereConditions, ozCondition)
-	args = append(args, normalizedUserAddress, normalizedUserAddress)
+	whereConditions, args := userRelatedFlowConditions(normalizedAddresses, organizationIDs)
 
 	// 组合所有条件
 	finalWhere := "(" + strings.Join(whereConditions, " OR ") + ")"
@@ -318,7 +345,7 @@ func (r *flowRepository) GetUserRelatedCompoundFlowsCount(ctx context.Context, u
 
 	// 总数
 	if err := query.Where(finalWhere, args...).Count(&result.Count).Error; err != nil {
-		logger.Error("GetUserRelatedCompoundFlowsCount Total Error", err, "user", userAddress)
+		logger.Error("GetUserRelatedCompoundFlowsCount Total Error", err, "user", normalizedAddresses)
 		return nil, err
 	}
 
@@ -336,7 +363,7 @@ func (r *flowRepository) GetUserRelatedCompoundFlowsCount(ctx context.Context, u
 		statusArgs := append(args, status)
 
 		if err := query.Where(statusWhere, statusArgs...).Count(count).Error; err != nil {
-			logger.Error("GetUserRelatedCompoundFlowsCount Status Error", err, "user", userAddress, "status", status)
+			logger.Error("GetUserRelatedCompoundFlowsCount Status Error", err, "user", normalizedAddresses, "status", status)
 			return nil, err
 		}
 	}
@@ -429,3 +456,18 @@ func (r *flowRepository) RefreshCompoundFlowsExpiredAt(ctx context.Context, chai
 
 	return result.RowsAffected, nil
 }
+
+// CountFlowsByStatus 按标准和状态统计全部流程数量
+func (r *flowRepository) CountFlowsByStatus(ctx context.Context) ([]types.FlowStatusTotal, error) {
+	var totals []types.FlowStatusTotal
+	err := r.db.WithContext(ctx).
+		Model(&types.TimelockTransactionFlow{}).
+		Select("timelock_standard, status, COUNT(*) AS count").
+		Group("timelock_standard, status").
+		Scan(&totals).Error
+	if err != nil {
+		logger.Error("CountFlowsByStatus Error", err)
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,187 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository 登录会话仓库接口
+type Repository interface {
+	CreateSession(ctx context.Context, session *types.UserSession) error
+	GetSessionByRefreshJTI(ctx context.Context, refreshJTI string) (*types.UserSession, error)
+	MarkSessionRotated(ctx context.Context, id int64) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, reason string) (int64, error)
+	RevokeUserSessions(ctx context.Context, userID int64, reason string) ([]string, error)
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+
+	// 访问令牌黑名单（数据库存储）
+	AddRevokedToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 创建登录会话仓库实例
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// CreateSession 创建会话记录
+func (r *repository) CreateSession(ctx context.Context, session *types.UserSession) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		logger.Error("CreateSession Error: ", err, "user_id", session.UserID, "family_id", session.FamilyID)
+		return err
+	}
+	logger.Info("CreateSession: ", "session_id", session.ID, "user_id", session.UserID, "family_id", session.FamilyID)
+	return nil
+}
+
+// GetSessionByRefreshJTI 根据刷新令牌jti获取会话
+func (r *repository) GetSessionByRefreshJTI(ctx context.Context, refreshJTI string) (*types.UserSession, error) {
+	var session types.UserSession
+	err := r.db.WithContext(ctx).
+		Where("refresh_jti = ?", refreshJTI).
+		First(&session).Error
+	if err != nil {
+		logger.Error("GetSessionByRefreshJTI Error: ", err, "refresh_jti", refreshJTI)
+		return nil, err
+	}
+	return &session, nil
+}
+
+// MarkSessionRotated 标记刷新令牌已轮换，仅当会话未轮换且未撤销时成功，用于检测并发重放
+func (r *repository) MarkSessionRotated(ctx context.Context, id int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&types.UserSession{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"rotated_at": time.Now(),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		logger.Error("MarkSessionRotated Error: ", result.Error, "session_id", id)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily 撤销令牌族下所有未撤销的会话，返回撤销的记录数
+func (r *repository) RevokeFamily(ctx context.Context, familyID string, reason string) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&types.UserSession{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"revoked_at":    now,
+			"revoke_reason": reason,
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		logger.Error("RevokeFamily Error: ", result.Error, "family_id", familyID)
+		return 0, result.Error
+	}
+	logger.Info("RevokeFamily: ", "family_id", familyID, "reason", reason, "revoked", result.RowsAffected)
+	return result.RowsAffected, nil
+}
+
+// RevokeUserSessions 撤销用户所有未撤销的会话，返回被撤销的令牌族ID
+func (r *repository) RevokeUserSessions(ctx context.Context, userID int64, reason string) ([]string, error) {
+	var familyIDs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&types.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+			Distinct().
+			Pluck("family_id", &familyIDs).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&types.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"revoked_at":    now,
+				"revoke_reason": reason,
+				"updated_at":    now,
+			}).Error
+	})
+	if err != nil {
+		logger.Error("RevokeUserSessions Error: ", err, "user_id", userID)
+		return nil, err
+	}
+	logger.Info("RevokeUserSessions: ", "user_id", userID, "reason", reason, "families", len(familyIDs))
+	return familyIDs, nil
+}
+
+// DeleteExpiredSessions 删除已过期的会话记录
+func (r *repository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&types.UserSession{})
+	if result.Error != nil {
+		logger.Error("DeleteExpiredSessions Error: ", result.Error)
+		return 0, result.Error
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("DeleteExpiredSessions: ", "count", result.RowsAffected)
+	}
+	return result.RowsAffected, nil
+}
+
+// AddRevokedToken 将令牌ID加入黑名单，已存在时延长过期时间
+func (r *repository) AddRevokedToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	token := &types.RevokedToken{
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"expires_at": gorm.Expr("GREATEST(revoked_tokens.expires_at, EXCLUDED.expires_at)")}),
+		}).
+		Create(token).Error
+	if err != nil {
+		logger.Error("AddRevokedToken Error: ", err, "token_id", tokenID)
+		return err
+	}
+	return nil
+}
+
+// IsTokenRevoked 检查令牌ID是否在黑名单中
+func (r *repository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&types.RevokedToken{}).
+		Where("token_id = ? AND expires_at > ?", tokenID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("IsTokenRevoked Error: ", err, "token_id", tokenID)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DeleteExpiredRevokedTokens 删除已过期的黑名单记录
+func (r *repository) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&types.RevokedToken{})
+	if result.Error != nil {
+		logger.Error("DeleteExpiredRevokedTokens Error: ", result.Error)
+		return 0, result.Error
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("DeleteExpiredRevokedTokens: ", "count", result.RowsAffected)
+	}
+	return result.RowsAffected, nil
+}
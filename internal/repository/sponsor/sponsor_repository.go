@@ -1,6 +1,8 @@
 package sponsor
 
 import (
+	"context"
+
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
 
@@ -11,6 +13,14 @@ import (
 type Repository interface {
 	// 获取所有激活的赞助方（公开接口使用）
 	GetAllActive() ([]*types.Sponsor, error)
+
+	// 管理员维护
+	List(ctx context.Context, req *types.GetSponsorsRequest) ([]types.Sponsor, int64, error)
+	GetByID(ctx context.Context, id int64) (*types.Sponsor, error)
+	Create(ctx context.Context, sponsor *types.Sponsor) error
+	Update(ctx context.Context, id int64, updates map[string]interface{}) error
+	Delete(ctx context.Context, id int64) error
+	UpdateSortOrders(ctx context.Context, items []types.SponsorSortItem) error
 }
 
 // repository 赞助方存储库实现
@@ -40,3 +50,105 @@ func (r *repository) GetAllActive() ([]*types.Sponsor, error) {
 	logger.Info("Got all active sponsors", "count", len(sponsors))
 	return sponsors, nil
 }
+
+// List 分页获取赞助方列表（含未激活），排序与公开接口一致
+func (r *repository) List(ctx context.Context, req *types.GetSponsorsRequest) ([]types.Sponsor, int64, error) {
+	var sponsors []types.Sponsor
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&types.Sponsor{})
+	if req.Type != nil {
+		query = query.Where("type = ?", *req.Type)
+	}
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("List sponsors count error", err)
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	if err := query.Order("sort_order DESC, created_at DESC").Offset(offset).Limit(req.PageSize).Find(&sponsors).Error; err != nil {
+		logger.Error("List sponsors error", err)
+		return nil, 0, err
+	}
+
+	return sponsors, total, nil
+}
+
+// GetByID 根据ID获取赞助方，不存在时返回 gorm.ErrRecordNotFound
+func (r *repository) GetByID(ctx context.Context, id int64) (*types.Sponsor, error) {
+	var sponsor types.Sponsor
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&sponsor).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logger.Error("GetByID sponsor error", err, "id", id)
+		}
+		return nil, err
+	}
+	return &sponsor, nil
+}
+
+// Create 创建赞助方
+func (r *repository) Create(ctx context.Context, sponsor *types.Sponsor) error {
+	if err := r.db.WithContext(ctx).Create(sponsor).Error; err != nil {
+		logger.Error("Create sponsor error", err, "name", sponsor.Name)
+		return err
+	}
+	logger.Info("Created sponsor", "id", sponsor.ID, "name", sponsor.Name)
+	return nil
+}
+
+// Update 按字段更新赞助方
+func (r *repository) Update(ctx context.Context, id int64, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&types.Sponsor{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		logger.Error("Update sponsor error", result.Error, "id", id)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	logger.Info("Updated sponsor", "id", id)
+	return nil
+}
+
+// Delete 删除赞助方
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&types.Sponsor{})
+	if result.Error != nil {
+		logger.Error("Delete sponsor error", result.Error, "id", id)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	logger.Info("Deleted sponsor", "id", id)
+	return nil
+}
+
+// UpdateSortOrders 在事务中批量更新排序权重，任一赞助方不存在则整体回滚
+func (r *repository) UpdateSortOrders(ctx context.Context, items []types.SponsorSortItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			result := tx.Model(&types.Sponsor{}).Where("id = ?", item.ID).Update("sort_order", item.SortOrder)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logger.Error("UpdateSortOrders sponsors error", err, "count", len(items))
+		}
+		return err
+	}
+
+	logger.Info("Updated sponsor sort orders", "count", len(items))
+	return nil
+}
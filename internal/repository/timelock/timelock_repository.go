@@ -19,26 +19,28 @@ type Repository interface {
 	CreateCompoundTimeLock(ctx context.Context, timeLock *types.CompoundTimeLock) error
 	GetCompoundTimeLockByChainAndAddress(ctx context.Context, chainID int, contractAddress string) (*types.CompoundTimeLock, error)
 	UpdateCompoundTimeLock(ctx context.Context, timeLock *types.CompoundTimeLock) error
-	DeleteCompoundTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string) error
-	UpdateCompoundTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, remark string) error
+	GetCompoundTimeLockByOrganization(ctx context.Context, organizationID int64, chainID int, contractAddress string) (*types.CompoundTimeLock, error)
+	DeleteCompoundTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) error
+	UpdateCompoundTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64, remark string) error
 
 	// OpenZeppelin Timelock操作
 	CreateOpenzeppelinTimeLock(ctx context.Context, timeLock *types.OpenzeppelinTimeLock) error
 	GetOpenzeppelinTimeLockByChainAndAddress(ctx context.Context, chainID int, contractAddress string) (*types.OpenzeppelinTimeLock, error)
 	UpdateOpenzeppelinTimeLock(ctx context.Context, timeLock *types.OpenzeppelinTimeLock) error
-	DeleteOpenzeppelinTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string) error
-	UpdateOpenzeppelinTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, remark string) error
+	GetOpenzeppelinTimeLockByOrganization(ctx context.Context, organizationID int64, chainID int, contractAddress string) (*types.OpenzeppelinTimeLock, error)
+	DeleteOpenzeppelinTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) error
+	UpdateOpenzeppelinTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64, remark string) error
 
 	// 查询操作
-	CheckCompoundTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error)
-	CheckOpenzeppelinTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error)
+	CheckCompoundTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error)
+	CheckOpenzeppelinTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error)
 
 	// 权限相关查询
-	GetTimeLocksByUserPermissions(ctx context.Context, userAddress string, req *types.GetTimeLockListRequest) ([]types.CompoundTimeLockWithPermission, []types.OpenzeppelinTimeLockWithPermission, int64, error)
+	GetTimeLocksByUserPermissions(ctx context.Context, userAddresses []string, organizationIDs []int64, req *types.GetTimeLockListRequest) ([]types.CompoundTimeLockWithPermission, []types.OpenzeppelinTimeLockWithPermission, int64, error)
 
 	// 验证操作
-	ValidateCompoundOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error)
-	ValidateOpenzeppelinOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error)
+	ValidateCompoundOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error)
+	ValidateOpenzeppelinOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error)
 
 	// 获取用户相关的timelock合约（用于权限刷新）
 	GetAllCompoundTimeLocksByUser(ctx context.Context, userAddress string) ([]types.CompoundTimeLock, error)
@@ -50,6 +52,7 @@ type Repository interface {
 
 	// 通用方法：根据标准、链ID和合约地址获取合约备注
 	GetContractRemarkByStandardAndAddress(ctx context.Context, standard string, chainID int, contractAddress string) (string, error)
+	GetOwnedTimeLockRemark(ctx context.Context, standard string, chainID int, contractAddress string, userAddress string, organizationID int64) (string, error)
 }
 
 type repository struct {
@@ -94,6 +97,23 @@ func (r *repository) GetCompoundTimeLockByChainAndAddress(ctx context.Context, c
 	return &timeLock, nil
 }
 
+// GetCompoundTimeLockByOrganization 获取组织监控列表中的compound timelock合约
+func (r *repository) GetCompoundTimeLockByOrganization(ctx context.Context, organizationID int64, chainID int, contractAddress string) (*types.CompoundTimeLock, error) {
+	var timeLock types.CompoundTimeLock
+	normalizedContractAddress := strings.ToLower(contractAddress)
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND chain_id = ? AND LOWER(contract_address) = ? AND status != ?", organizationID, chainID, normalizedContractAddress, "deleted").
+		First(&timeLock).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("GetCompoundTimeLockByOrganization error", err, "organization_id", organizationID, "chain_id", chainID, "contract_address", contractAddress)
+		}
+		return nil, err
+	}
+
+	return &timeLock, nil
+}
+
 // UpdateCompoundTimeLock 更新compound timelock合约信息
 func (r *repository) UpdateCompoundTimeLock(ctx context.Context, timeLock *types.CompoundTimeLock) error {
 	if err := r.db.WithContext(ctx).Save(timeLock).Error; err != nil {
@@ -106,11 +126,11 @@ func (r *repository) UpdateCompoundTimeLock(ctx context.Context, timeLock *types
 }
 
 // DeleteCompoundTimeLock 硬删除 compound timelock 合约（仅删除合约行，不清理其他表）
-func (r *repository) DeleteCompoundTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string) error {
+func (r *repository) DeleteCompoundTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) error {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ?", chainID, normalizedContractAddress, normalizedUserAddress).
+		Where("chain_id = ? AND LOWER(contract_address) = ?", chainID, normalizedContractAddress).
+		Scopes(ownerScope(userAddress, organizationID)).
 		Delete(&types.CompoundTimeLock{}).Error; err != nil {
 		logger.Error("DeleteCompoundTimeLock error", err, "chain_id", chainID, "contract_address", contractAddress, "user_address", userAddress)
 		return err
@@ -120,12 +140,12 @@ func (r *repository) DeleteCompoundTimeLock(ctx context.Context, chainID int, co
 }
 
 // UpdateCompoundTimeLockRemark 更新compound timelock备注
-func (r *repository) UpdateCompoundTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, remark string) error {
+func (r *repository) UpdateCompoundTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64, remark string) error {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
 		Model(&types.CompoundTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ?", chainID, normalizedContractAddress, normalizedUserAddress).
+		Where("chain_id = ? AND LOWER(contract_address) = ?", chainID, normalizedContractAddress).
+		Scopes(ownerScope(userAddress, organizationID)).
 		Update("remark", remark).Error; err != nil {
 		logger.Error("UpdateCompoundTimeLockRemark error", err, "chain_id", chainID, "contract_address", contractAddress, "user_address", userAddress)
 		return err
@@ -166,6 +186,23 @@ func (r *repository) GetOpenzeppelinTimeLockByChainAndAddress(ctx context.Contex
 	return &timeLock, nil
 }
 
+// GetOpenzeppelinTimeLockByOrganization 获取组织监控列表中的openzeppelin timelock合约
+func (r *repository) GetOpenzeppelinTimeLockByOrganization(ctx context.Context, organizationID int64, chainID int, contractAddress string) (*types.OpenzeppelinTimeLock, error) {
+	var timeLock types.OpenzeppelinTimeLock
+	normalizedContractAddress := strings.ToLower(contractAddress)
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND chain_id = ? AND LOWER(contract_address) = ? AND status != ?", organizationID, chainID, normalizedContractAddress, "deleted").
+		First(&timeLock).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("GetOpenzeppelinTimeLockByOrganization error", err, "organization_id", organizationID, "chain_id", chainID, "contract_address", contractAddress)
+		}
+		return nil, err
+	}
+
+	return &timeLock, nil
+}
+
 // UpdateOpenzeppelinTimeLock 更新openzeppelin timelock合约信息
 func (r *repository) UpdateOpenzeppelinTimeLock(ctx context.Context, timeLock *types.OpenzeppelinTimeLock) error {
 	if err := r.db.WithContext(ctx).Save(timeLock).Error; err != nil {
@@ -178,11 +215,11 @@ func (r *repository) UpdateOpenzeppelinTimeLock(ctx context.Context, timeLock *t
 }
 
 // DeleteOpenzeppelinTimeLock 硬删除 openzeppelin timelock 合约（仅删除合约行，不清理其他表）
-func (r *repository) DeleteOpenzeppelinTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string) error {
+func (r *repository) DeleteOpenzeppelinTimeLock(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) error {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ?", chainID, normalizedContractAddress, normalizedUserAddress).
+		Where("chain_id = ? AND LOWER(contract_address) = ?", chainID, normalizedContractAddress).
+		Scopes(ownerScope(userAddress, organizationID)).
 		Delete(&types.OpenzeppelinTimeLock{}).Error; err != nil {
 		logger.Error("DeleteOpenzeppelinTimeLock error", err, "chain_id", chainID, "contract_address", contractAddress, "user_address", userAddress)
 		return err
@@ -192,12 +229,12 @@ func (r *repository) DeleteOpenzeppelinTimeLock(ctx context.Context, chainID int
 }
 
 // UpdateOpenzeppelinTimeLockRemark 更新openzeppelin timelock备注
-func (r *repository) UpdateOpenzeppelinTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, remark string) error {
+func (r *repository) UpdateOpenzeppelinTimeLockRemark(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64, remark string) error {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	if err := r.db.WithContext(ctx).
 		Model(&types.OpenzeppelinTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ?", chainID, normalizedContractAddress, normalizedUserAddress).
+		Where("chain_id = ? AND LOWER(contract_address) = ?", chainID, normalizedContractAddress).
+		Scopes(ownerScope(userAddress, organizationID)).
 		Update("remark", remark).Error; err != nil {
 		logger.Error("UpdateOpenzeppelinTimeLockRemark error", err, "chain_id", chainID, "contract_address", contractAddress, "user_address", userAddress)
 		return err
@@ -208,13 +245,13 @@ func (r *repository) UpdateOpenzeppelinTimeLockRemark(ctx context.Context, chain
 }
 
 // CheckCompoundTimeLockExists 检查compound timelock合约是否已存在
-func (r *repository) CheckCompoundTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error) {
+func (r *repository) CheckCompoundTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error) {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&types.CompoundTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ? AND status != ?", chainID, normalizedContractAddress, normalizedUserAddress, "deleted").
+		Where("chain_id = ? AND LOWER(contract_address) = ? AND status != ?", chainID, normalizedContractAddress, "deleted").
+		Scopes(ownerScope(userAddress, organizationID)).
 		Count(&count).Error
 
 	if err != nil {
@@ -228,13 +265,13 @@ func (r *repository) CheckCompoundTimeLockExists(ctx context.Context, chainID in
 }
 
 // CheckOpenzeppelinTimeLockExists 检查openzeppelin timelock合约是否已存在
-func (r *repository) CheckOpenzeppelinTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error) {
+func (r *repository) CheckOpenzeppelinTimeLockExists(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error) {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&types.OpenzeppelinTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ? AND status != ?", chainID, normalizedContractAddress, normalizedUserAddress, "deleted").
+		Where("chain_id = ? AND LOWER(contract_address) = ? AND status != ?", chainID, normalizedContractAddress, "deleted").
+		Scopes(ownerScope(userAddress, organizationID)).
 		Count(&count).Error
 
 	if err != nil {
@@ -248,11 +285,15 @@ func (r *repository) CheckOpenzeppelinTimeLockExists(ctx context.Context, chainI
 }
 
 // GetTimeLocksByUserPermissions 根据用户权限获取timelock列表
-func (r *repository) GetTimeLocksByUserPermissions(ctx context.Context, userAddress string, req *types.GetTimeLockListRequest) ([]types.CompoundTimeLockWithPermission, []types.OpenzeppelinTimeLockWithPermission, int64, error) {
+// userAddresses为用户账户下的全部钱包（主钱包和关联钱包），任一钱包有权限即可见
+func (r *repository) GetTimeLocksByUserPermissions(ctx context.Context, userAddresses []string, organizationIDs []int64, req *types.GetTimeLockListRequest) ([]types.CompoundTimeLockWithPermission, []types.OpenzeppelinTimeLockWithPermission, int64, error) {
 	var compoundTimeLocks []types.CompoundTimeLock
 	var openzeppelinTimeLocks []types.OpenzeppelinTimeLock
 	var totalCount int64
-	normalizedUserAddress := strings.ToLower(userAddress)
+	normalizedAddresses := make([]string, len(userAddresses))
+	for i, address := range userAddresses {
+		normalizedAddresses[i] = strings.ToLower(address)
+	}
 
 	// 构建查询基础条件
 	baseQuery := "status != ?"
@@ -264,39 +305,63 @@ func (r *repository) GetTimeLocksByUserPermissions(ctx context.Context, userAddr
 		baseArgs = append(baseArgs, req.Status)
 	}
 
-	// 查询Compound timelocks - 用户是创建者、管理员或待定管理员
-	compoundQuery := r.db.WithContext(ctx).
-		Model(&types.CompoundTimeLock{}).
-		Where(baseQuery+" AND (LOWER(creator_address) = ? OR LOWER(admin) = ? OR LOWER(pending_admin) = ?)",
-			append(baseArgs, normalizedUserAddress, normalizedUserAddress, normalizedUserAddress)...)
+	// 指定组织时只返回该组织的合约（成员资格由服务层校验），否则额外包含用户所在组织的合约
+	var compoundQuery, openzeppelinQuery *gorm.DB
+	if req.OrganizationID > 0 {
+		orgArgs := append(append([]interface{}{}, baseArgs...), req.OrganizationID)
+		compoundQuery = r.db.WithContext(ctx).
+			Model(&types.CompoundTimeLock{}).
+			Where(baseQuery+" AND organization_id = ?", orgArgs...)
+		openzeppelinQuery = r.db.WithContext(ctx).
+			Model(&types.OpenzeppelinTimeLock{}).
+			Where(baseQuery+" AND organization_id = ?", orgArgs...)
+	} else {
+		orgCondition := ""
+		if len(organizationIDs) > 0 {
+			orgCondition = " OR organization_id IN ?"
+		}
+
+		// 查询Compound timelocks - 用户是创建者、管理员或待定管理员，或合约属于用户所在组织
+		compoundArgs := append(append([]interface{}{}, baseArgs...), normalizedAddresses, normalizedAddresses, normalizedAddresses)
+		// 查询OpenZeppelin timelocks - 用户是创建者、提议者或执行者，或合约属于用户所在组织
+		proposerCondition, proposerArgs := likeAnyAddress("proposers", normalizedAddresses)
+		executorCondition, executorArgs := likeAnyAddress("executors", normalizedAddresses)
+		openzeppelinArgs := append(append([]interface{}{}, baseArgs...), normalizedAddresses)
+		openzeppelinArgs = append(append(openzeppelinArgs, proposerArgs...), executorArgs...)
+		if len(organizationIDs) > 0 {
+			compoundArgs = append(compoundArgs, organizationIDs)
+			openzeppelinArgs = append(openzeppelinArgs, organizationIDs)
+		}
+
+		compoundQuery = r.db.WithContext(ctx).
+			Model(&types.CompoundTimeLock{}).
+			Where(baseQuery+" AND (LOWER(creator_address) IN ? OR LOWER(admin) IN ? OR LOWER(pending_admin) IN ?"+orgCondition+")", compoundArgs...)
+		openzeppelinQuery = r.db.WithContext(ctx).
+			Model(&types.OpenzeppelinTimeLock{}).
+			Where(baseQuery+" AND (LOWER(creator_address) IN ? OR "+proposerCondition+" OR "+executorCondition+orgCondition+")", openzeppelinArgs...)
+	}
 
 	var compoundCount int64
 	if err := compoundQuery.Count(&compoundCount).Error; err != nil {
-		logger.Error("GetTimeLocksByUserPermissions compound count error", err, "user_address", userAddress)
+		logger.Error("GetTimeLocksByUserPermissions compound count error", err, "user_addresses", normalizedAddresses)
 		return nil, nil, 0, err
 	}
 
 	// 查询所有Compound timelocks（无分页）
 	if err := compoundQuery.Order("created_at DESC").Find(&compoundTimeLocks).Error; err != nil {
-		logger.Error("GetTimeLocksByUserPermissions compound query error", err, "user_address", userAddress)
+		logger.Error("GetTimeLocksByUserPermissions compound query error", err, "user_addresses", normalizedAddresses)
 		return nil, nil, 0, err
 	}
 
-	// 查询OpenZeppelin timelocks - 用户是创建者、提议者或执行者
-	openzeppelinQuery := r.db.WithContext(ctx).
-		Model(&types.OpenzeppelinTimeLock{}).
-		Where(baseQuery+" AND (LOWER(creator_address) = ? OR LOWER(proposers) LIKE ? OR LOWER(executors) LIKE ?)",
-			append(baseArgs, normalizedUserAddress, "%"+normalizedUserAddress+"%", "%"+normalizedUserAddress+"%")...)
-
 	var openzeppelinCount int64
 	if err := openzeppelinQuery.Count(&openzeppelinCount).Error; err != nil {
-		logger.Error("GetTimeLocksByUserPermissions openzeppelin count error", err, "user_address", userAddress)
+		logger.Error("GetTimeLocksByUserPermissions openzeppelin count error", err, "user_addresses", normalizedAddresses)
 		return nil, nil, 0, err
 	}
 
 	// 查询所有OpenZeppelin timelocks（无分页）
 	if err := openzeppelinQuery.Order("created_at DESC").Find(&openzeppelinTimeLocks).Error; err != nil {
-		logger.Error("GetTimeLocksByUserPermissions openzeppelin query error", err, "user_address", userAddress)
+		logger.Error("GetTimeLocksByUserPermissions openzeppelin query error", err, "user_addresses", normalizedAddresses)
 		return nil, nil, 0, err
 	}
 
@@ -305,7 +370,7 @@ func (r *repository) GetTimeLocksByUserPermissions(ctx context.Context, userAddr
 	// 构建带权限信息的响应
 	compoundWithPermissions := make([]types.CompoundTimeLockWithPermission, len(compoundTimeLocks))
 	for i, tl := range compoundTimeLocks {
-		permissions := r.getCompoundUserPermissions(tl, userAddress)
+		permissions := r.getCompoundUserPermissions(tl, normalizedAddresses)
 		compoundWithPermissions[i] = types.CompoundTimeLockWithPermission{
 			CompoundTimeLock: tl,
 			UserPermissions:  permissions,
@@ -314,25 +379,25 @@ func (r *repository) GetTimeLocksByUserPermissions(ctx context.Context, userAddr
 
 	openzeppelinWithPermissions := make([]types.OpenzeppelinTimeLockWithPermission, len(openzeppelinTimeLocks))
 	for i, tl := range openzeppelinTimeLocks {
-		permissions := r.getOpenzeppelinUserPermissions(tl, userAddress)
+		permissions := r.getOpenzeppelinUserPermissions(tl, normalizedAddresses)
 		openzeppelinWithPermissions[i] = types.OpenzeppelinTimeLockWithPermission{
 			OpenzeppelinTimeLock: tl,
 			UserPermissions:      permissions,
 		}
 	}
 
-	logger.Info("GetTimeLocksByUserPermissions success", "user_address", userAddress, "compound_count", len(compoundWithPermissions), "openzeppelin_count", len(openzeppelinWithPermissions), "total", totalCount)
+	logger.Info("GetTimeLocksByUserPermissions success", "user_addresses", normalizedAddresses, "compound_count", len(compoundWithPermissions), "openzeppelin_count", len(openzeppelinWithPermissions), "total", totalCount)
 	return compoundWithPermissions, openzeppelinWithPermissions, totalCount, nil
 }
 
 // ValidateCompoundOwnership 验证compound timelock合约的所有权
-func (r *repository) ValidateCompoundOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error) {
+func (r *repository) ValidateCompoundOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error) {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&types.CompoundTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ? AND status != ?", chainID, normalizedContractAddress, normalizedUserAddress, "deleted").
+		Where("chain_id = ? AND LOWER(contract_address) = ? AND status != ?", chainID, normalizedContractAddress, "deleted").
+		Scopes(ownerScope(userAddress, organizationID)).
 		Count(&count).Error
 
 	if err != nil {
@@ -346,13 +411,13 @@ func (r *repository) ValidateCompoundOwnership(ctx context.Context, chainID int,
 }
 
 // ValidateOpenzeppelinOwnership 验证openzeppelin timelock合约的所有权
-func (r *repository) ValidateOpenzeppelinOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string) (bool, error) {
+func (r *repository) ValidateOpenzeppelinOwnership(ctx context.Context, chainID int, contractAddress string, userAddress string, organizationID int64) (bool, error) {
 	normalizedContractAddress := strings.ToLower(contractAddress)
-	normalizedUserAddress := strings.ToLower(userAddress)
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&types.OpenzeppelinTimeLock{}).
-		Where("chain_id = ? AND LOWER(contract_address) = ? AND LOWER(creator_address) = ? AND status != ?", chainID, normalizedContractAddress, normalizedUserAddress, "deleted").
+		Where("chain_id = ? AND LOWER(contract_address) = ? AND status != ?", chainID, normalizedContractAddress, "deleted").
+		Scopes(ownerScope(userAddress, organizationID)).
 		Count(&count).Error
 
 	if err != nil {
@@ -480,51 +545,104 @@ func (r *repository) GetContractRemarkByStandardAndAddress(ctx context.Context,
 	}
 }
 
-// getCompoundUserPermissions 获取compound timelock合约的用户权限
-func (r *repository) getCompoundUserPermissions(tl types.CompoundTimeLock, userAddress string) []string {
+// likeAnyAddress 构建JSON地址列表列包含任一地址的条件
+func likeAnyAddress(column string, addresses []string) (string, []interface{}) {
+	conditions := make([]string, len(addresses))
+	args := make([]interface{}, len(addresses))
+	for i, address := range addresses {
+		conditions[i] = "LOWER(" + column + ") LIKE ?"
+		args[i] = "%" + address + "%"
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// getCompoundUserPermissions 获取compound timelock合约的用户权限，任一钱包满足即拥有该权限
+func (r *repository) getCompoundUserPermissions(tl types.CompoundTimeLock, userAddresses []string) []string {
 	var permissions []string
 
-	if strings.EqualFold(tl.CreatorAddress, userAddress) {
+	if equalsAnyAddress(tl.CreatorAddress, userAddresses) {
 		permissions = append(permissions, "creator")
 	}
-	if strings.EqualFold(tl.Admin, userAddress) {
+	if equalsAnyAddress(tl.Admin, userAddresses) {
 		permissions = append(permissions, "admin")
 	}
-	if tl.PendingAdmin != nil && strings.EqualFold(*tl.PendingAdmin, userAddress) {
+	if tl.PendingAdmin != nil && equalsAnyAddress(*tl.PendingAdmin, userAddresses) {
 		permissions = append(permissions, "pending_admin")
 	}
 
 	return permissions
 }
 
-// getOpenzeppelinUserPermissions 获取openzeppelin timelock合约的用户权限
-func (r *repository) getOpenzeppelinUserPermissions(tl types.OpenzeppelinTimeLock, userAddress string) []string {
+// getOpenzeppelinUserPermissions 获取openzeppelin timelock合约的用户权限，任一钱包满足即拥有该权限
+func (r *repository) getOpenzeppelinUserPermissions(tl types.OpenzeppelinTimeLock, userAddresses []string) []string {
 	var permissions []string
 
-	if strings.EqualFold(tl.CreatorAddress, userAddress) {
+	if equalsAnyAddress(tl.CreatorAddress, userAddresses) {
 		permissions = append(permissions, "creator")
 	}
-	if r.containsAddress(tl.Proposers, userAddress) {
+	if r.containsAnyAddress(tl.Proposers, userAddresses) {
 		permissions = append(permissions, "proposer")
 	}
-	if r.containsAddress(tl.Executors, userAddress) {
+	if r.containsAnyAddress(tl.Executors, userAddresses) {
 		permissions = append(permissions, "executor")
 	}
 
 	return permissions
 }
 
-// containsAddress 检查地址是否在列表中
-func (r *repository) containsAddress(jsonAddresses, address string) bool {
-	var addresses []string
-	if err := json.Unmarshal([]byte(jsonAddresses), &addresses); err != nil {
+// equalsAnyAddress 检查地址是否与任一地址相同（忽略大小写）
+func equalsAnyAddress(address string, addresses []string) bool {
+	for _, candidate := range addresses {
+		if strings.EqualFold(address, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyAddress 检查列表中是否包含任一地址
+func (r *repository) containsAnyAddress(jsonAddresses string, addresses []string) bool {
+	var list []string
+	if err := json.Unmarshal([]byte(jsonAddresses), &list); err != nil {
 		return false
 	}
 
-	for _, addr := range addresses {
-		if strings.EqualFold(addr, address) {
+	for _, addr := range list {
+		if equalsAnyAddress(addr, addresses) {
 			return true
 		}
 	}
 	return false
 }
+
+// GetOwnedTimeLockRemark 获取个人（按创建者）或组织名下合约的备注，用于记录审计快照
+func (r *repository) GetOwnedTimeLockRemark(ctx context.Context, standard string, chainID int, contractAddress string, userAddress string, organizationID int64) (string, error) {
+	table := "compound_timelocks"
+	if standard == "openzeppelin" {
+		table = "openzeppelin_timelocks"
+	}
+
+	var remark string
+	err := r.db.WithContext(ctx).
+		Table(table).
+		Select("remark").
+		Where("chain_id = ? AND LOWER(contract_address) = ?", chainID, strings.ToLower(contractAddress)).
+		Scopes(ownerScope(userAddress, organizationID)).
+		Limit(1).
+		Scan(&remark).Error
+	if err != nil {
+		logger.Error("GetOwnedTimeLockRemark error", err, "standard", standard, "chain_id", chainID, "contract_address", contractAddress)
+		return "", err
+	}
+	return remark, nil
+}
+
+// ownerScope 合约归属条件：组织合约按组织ID匹配（组织角色由服务层校验），个人合约按创建者匹配
+func ownerScope(userAddress string, organizationID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if organizationID > 0 {
+			return db.Where("organization_id = ?", organizationID)
+		}
+		return db.Where("LOWER(creator_address) = ? AND organization_id = 0", strings.ToLower(userAddress))
+	}
+}
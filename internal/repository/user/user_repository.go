@@ -25,9 +25,17 @@ type Repository interface {
 	// Nonce相关方法
 	CreateAuthNonce(ctx context.Context, nonce *types.AuthNonce) error
 	GetAuthNonce(ctx context.Context, walletAddress string, nonce string) (*types.AuthNonce, error)
-	MarkNonceAsUsed(ctx context.Context, nonceID int64) error
+	MarkNonceAsUsed(ctx context.Context, nonceID int64) (bool, error)
 	DeleteExpiredNonces(ctx context.Context, walletAddress string) error
 	DeleteAllNonces(ctx context.Context, walletAddress string) error
+
+	// 关联钱包相关方法
+	CreateLinkedWallet(ctx context.Context, wallet *types.LinkedWallet) error
+	GetLinkedWalletByAddress(ctx context.Context, walletAddress string) (*types.LinkedWallet, error)
+	GetLinkedWalletsByUserID(ctx context.Context, userID int64) ([]types.LinkedWallet, error)
+	CountLinkedWallets(ctx context.Context, userID int64) (int64, error)
+	DeleteLinkedWallet(ctx context.Context, userID int64, walletAddress string) (bool, error)
+	GetAccountWallets(ctx context.Context, walletAddress string) ([]string, error)
 }
 
 type repository struct {
@@ -156,21 +164,22 @@ func (r *repository) GetAuthNonce(ctx context.Context, walletAddress string, non
 	return &authNonce, nil
 }
 
-// MarkNonceAsUsed 标记nonce为已使用
-func (r *repository) MarkNonceAsUsed(ctx context.Context, nonceID int64) error {
+// MarkNonceAsUsed 将未使用的nonce标记为已使用，返回是否由本次调用标记
+// 条件更新保证并发提交同一nonce时只有一个请求成功
+func (r *repository) MarkNonceAsUsed(ctx context.Context, nonceID int64) (bool, error) {
 	logger.Info("MarkNonceAsUsed", "nonce_id", nonceID)
 
 	result := r.db.WithContext(ctx).
 		Model(&types.AuthNonce{}).
-		Where("id = ?", nonceID).
+		Where("id = ? AND is_used = ?", nonceID, false).
 		Update("is_used", true)
 
 	if result.Error != nil {
 		logger.Error("Failed to mark nonce as used", result.Error)
-		return result.Error
+		return false, result.Error
 	}
 
-	return nil
+	return result.RowsAffected > 0, nil
 }
 
 // DeleteExpiredNonces 删除过期的nonce
@@ -212,3 +221,96 @@ func (r *repository) DeleteAllNonces(ctx context.Context, walletAddress string)
 
 	return nil
 }
+
+// CreateLinkedWallet 创建关联钱包
+func (r *repository) CreateLinkedWallet(ctx context.Context, wallet *types.LinkedWallet) error {
+	wallet.WalletAddress = strings.ToLower(wallet.WalletAddress)
+	logger.Info("CreateLinkedWallet: ", "user_id", wallet.UserID, "wallet_address", wallet.WalletAddress)
+	return r.db.WithContext(ctx).Create(wallet).Error
+}
+
+// GetLinkedWalletByAddress 根据钱包地址获取关联记录
+func (r *repository) GetLinkedWalletByAddress(ctx context.Context, walletAddress string) (*types.LinkedWallet, error) {
+	var wallet types.LinkedWallet
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", strings.ToLower(walletAddress)).
+		First(&wallet).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// GetLinkedWalletsByUserID 获取账户的所有关联钱包
+func (r *repository) GetLinkedWalletsByUserID(ctx context.Context, userID int64) ([]types.LinkedWallet, error) {
+	var wallets []types.LinkedWallet
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&wallets).Error
+	if err != nil {
+		logger.Error("GetLinkedWalletsByUserID Error: ", err, "user_id", userID)
+		return nil, err
+	}
+	return wallets, nil
+}
+
+// CountLinkedWallets 统计账户的关联钱包数量
+func (r *repository) CountLinkedWallets(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&types.LinkedWallet{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteLinkedWallet 解除账户的关联钱包，返回是否存在该关联
+func (r *repository) DeleteLinkedWallet(ctx context.Context, userID int64, walletAddress string) (bool, error) {
+	logger.Info("DeleteLinkedWallet: ", "user_id", userID, "wallet_address", walletAddress)
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND wallet_address = ?", userID, strings.ToLower(walletAddress)).
+		Delete(&types.LinkedWallet{})
+	if result.Error != nil {
+		logger.Error("DeleteLinkedWallet Error: ", result.Error, "user_id", userID, "wallet_address", walletAddress)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetAccountWallets 获取钱包所属账户的全部钱包地址（主钱包和所有关联钱包，小写）
+// 地址既不是已注册用户也不是关联钱包时只返回其自身
+func (r *repository) GetAccountWallets(ctx context.Context, walletAddress string) ([]string, error) {
+	normalizedAddress := strings.ToLower(walletAddress)
+
+	var primary string
+	err := r.db.WithContext(ctx).
+		Model(&types.User{}).
+		Select("users.wallet_address").
+		Joins("JOIN user_linked_wallets ON user_linked_wallets.user_id = users.id").
+		Where("user_linked_wallets.wallet_address = ?", normalizedAddress).
+		Limit(1).
+		Scan(&primary).Error
+	if err != nil {
+		logger.Error("GetAccountWallets Error: ", err, "wallet_address", walletAddress)
+		return nil, err
+	}
+	if primary == "" {
+		primary = normalizedAddress
+	}
+	primary = strings.ToLower(primary)
+
+	var linked []string
+	err = r.db.WithContext(ctx).
+		Model(&types.LinkedWallet{}).
+		Joins("JOIN users ON users.id = user_linked_wallets.user_id").
+		Where("LOWER(users.wallet_address) = ?", primary).
+		Order("user_linked_wallets.created_at ASC").
+		Pluck("user_linked_wallets.wallet_address", &linked).Error
+	if err != nil {
+		logger.Error("GetAccountWallets Error: ", err, "wallet_address", walletAddress)
+		return nil, err
+	}
+
+	return append([]string{primary}, linked...), nil
+}
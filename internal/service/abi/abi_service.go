@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	abiRepo "timelocker-backend/internal/repository/abi"
+	"timelocker-backend/internal/repository/organization"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
 	"timelocker-backend/pkg/utils"
@@ -32,12 +36,18 @@ type Service interface {
 }
 
 type service struct {
-	abiRepo abiRepo.Repository
+	abiRepo  abiRepo.Repository
+	orgRepo  organization.Repository
+	userRepo user.Repository
+	auditor  audit.Recorder
 }
 
-func NewService(abiRepo abiRepo.Repository) Service {
+func NewService(abiRepo abiRepo.Repository, orgRepo organization.Repository, userRepo user.Repository, auditor audit.Recorder) Service {
 	return &service{
-		abiRepo: abiRepo,
+		abiRepo:  abiRepo,
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		auditor:  auditor,
 	}
 }
 
@@ -57,8 +67,16 @@ func (s *service) CreateABI(ctx context.Context, walletAddress string, req *type
 		return nil, fmt.Errorf("%w: %s", ErrInvalidABI, validation.ErrorMessage)
 	}
 
-	// 3. 检查名称是否重复（同一用户下）
-	existingABI, err := s.abiRepo.GetABIByNameAndOwner(ctx, req.Name, walletAddress)
+	// 2. 创建到组织ABI库需要编辑者角色
+	if req.OrganizationID > 0 {
+		if err := s.checkOrganizationRole(ctx, req.OrganizationID, walletAddress, types.OrganizationRoleEditor); err != nil {
+			logger.Error("CreateABI organization access denied:", err, "wallet_address", walletAddress, "organization_id", req.OrganizationID)
+			return nil, err
+		}
+	}
+
+	// 3. 检查名称是否重复（同一用户或同一组织下）
+	existingABI, err := s.getABIByName(ctx, req.Name, walletAddress, req.OrganizationID)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		logger.Error("CreateABI check name error:", err, "wallet_address", walletAddress, "name", req.Name)
 		return nil, fmt.Errorf("failed to check ABI name: %w", err)
@@ -71,35 +89,38 @@ func (s *service) CreateABI(ctx context.Context, walletAddress string, req *type
 
 	// 4. 创建ABI记录
 	newABI := &types.ABI{
-		Name:        req.Name,
-		ABIContent:  req.ABIContent,
-		Owner:       walletAddress,
-		Description: req.Description,
-		IsShared:    false, // 用户创建的ABI默认不共享
+		Name:           req.Name,
+		ABIContent:     req.ABIContent,
+		Owner:          walletAddress,
+		Description:    req.Description,
+		IsShared:       false, // 用户创建的ABI默认不共享
+		OrganizationID: req.OrganizationID,
 	}
 
 	if err := s.abiRepo.CreateABI(ctx, newABI); err != nil {
 		logger.Error("CreateABI database error:", err, "wallet_address", walletAddress, "name", req.Name)
 		return nil, fmt.Errorf("failed to create ABI: %w", err)
 	}
+	s.auditor.Record(ctx, types.AuditActionABICreate, types.AuditResourceABI, strconv.FormatInt(newABI.ID, 10), newABI.OrganizationID, nil, newABI)
 
 	// 5. 返回响应
 	response := &types.ABIResponse{
-		ID:          newABI.ID,
-		Name:        newABI.Name,
-		ABIContent:  newABI.ABIContent,
-		Owner:       newABI.Owner,
-		Description: newABI.Description,
-		IsShared:    newABI.IsShared,
-		CreatedAt:   newABI.CreatedAt,
-		UpdatedAt:   newABI.UpdatedAt,
+		ID:             newABI.ID,
+		Name:           newABI.Name,
+		ABIContent:     newABI.ABIContent,
+		Owner:          newABI.Owner,
+		Description:    newABI.Description,
+		IsShared:       newABI.IsShared,
+		OrganizationID: newABI.OrganizationID,
+		CreatedAt:      newABI.CreatedAt,
+		UpdatedAt:      newABI.UpdatedAt,
 	}
 
 	logger.Info("CreateABI Success:", "id", newABI.ID, "wallet_address", walletAddress, "name", req.Name)
 	return response, nil
 }
 
-// GetABIList 获取ABI列表（用户的+共享的）
+// GetABIList 获取ABI列表（用户的+所在组织的+共享的）
 func (s *service) GetABIList(ctx context.Context, walletAddress string) (*types.ABIListResponse, error) {
 	logger.Info("GetABIList:", "wallet_address", walletAddress)
 
@@ -110,18 +131,36 @@ func (s *service) GetABIList(ctx context.Context, walletAddress string) (*types.
 		return nil, fmt.Errorf("failed to get user ABIs: %w", err)
 	}
 
-	// 2. 获取共享ABI
+	// 2. 获取用户账户所在组织的ABI
+	wallets, err := s.userRepo.GetAccountWallets(ctx, walletAddress)
+	if err != nil {
+		logger.Error("GetABIList account wallets error:", err, "wallet_address", walletAddress)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	orgIDs, err := s.orgRepo.GetOrganizationIDsByWallets(ctx, wallets)
+	if err != nil {
+		logger.Error("GetABIList organizations error:", err, "wallet_address", walletAddress)
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+	orgABIs, err := s.abiRepo.GetOrganizationABIs(ctx, orgIDs)
+	if err != nil {
+		logger.Error("GetABIList organization ABIs error:", err, "wallet_address", walletAddress)
+		return nil, fmt.Errorf("failed to get organization ABIs: %w", err)
+	}
+
+	// 3. 获取共享ABI
 	sharedABIs, err := s.abiRepo.GetSharedABIs(ctx)
 	if err != nil {
 		logger.Error("GetABIList shared ABIs error:", err, "wallet_address", walletAddress)
 		return nil, fmt.Errorf("failed to get shared ABIs: %w", err)
 	}
 
+	abis := append(userABIs, orgABIs...)
 	response := &types.ABIListResponse{
-		ABIs: append(userABIs, sharedABIs...),
+		ABIs: append(abis, sharedABIs...),
 	}
 
-	logger.Info("GetABIList Success:", "wallet_address", walletAddress, "user_count", len(userABIs), "shared_count", len(sharedABIs))
+	logger.Info("GetABIList Success:", "wallet_address", walletAddress, "user_count", len(userABIs), "organization_count", len(orgABIs), "shared_count", len(sharedABIs))
 	return response, nil
 }
 
@@ -140,21 +179,24 @@ func (s *service) GetABIByID(ctx context.Context, id int64, walletAddress string
 		return nil, fmt.Errorf("failed to get ABI: %w", err)
 	}
 
-	// 检查访问权限：用户只能访问自己的ABI或共享ABI
-	if abi.Owner != walletAddress && abi.Owner != abiRepo.SharedABIOwner {
-		logger.Error("GetABIByID access denied:", ErrAccessDenied, "id", id, "wallet_address", walletAddress, "owner", abi.Owner)
-		return nil, ErrAccessDenied
+	// 检查访问权限：用户只能访问自己的ABI、所在组织的ABI或共享ABI
+	if abi.Owner != abiRepo.SharedABIOwner {
+		if err := s.checkABIAccess(ctx, abi, walletAddress, types.OrganizationRoleViewer); err != nil {
+			logger.Error("GetABIByID access denied:", err, "id", id, "wallet_address", walletAddress, "owner", abi.Owner)
+			return nil, err
+		}
 	}
 
 	response := &types.ABIResponse{
-		ID:          abi.ID,
-		Name:        abi.Name,
-		ABIContent:  abi.ABIContent,
-		Owner:       abi.Owner,
-		Description: abi.Description,
-		IsShared:    abi.IsShared,
-		CreatedAt:   abi.CreatedAt,
-		UpdatedAt:   abi.UpdatedAt,
+		ID:             abi.ID,
+		Name:           abi.Name,
+		ABIContent:     abi.ABIContent,
+		Owner:          abi.Owner,
+		Description:    abi.Description,
+		IsShared:       abi.IsShared,
+		OrganizationID: abi.OrganizationID,
+		CreatedAt:      abi.CreatedAt,
+		UpdatedAt:      abi.UpdatedAt,
 	}
 
 	logger.Info("GetABIByID Success:", "id", id, "wallet_address", walletAddress, "name", abi.Name)
@@ -176,10 +218,10 @@ func (s *service) UpdateABI(ctx context.Context, id int64, walletAddress string,
 		return nil, fmt.Errorf("failed to get ABI: %w", err)
 	}
 
-	// 2. 检查所有权
-	if existingABI.Owner != walletAddress {
-		logger.Error("UpdateABI access denied:", ErrAccessDenied, "id", id, "wallet_address", walletAddress, "owner", existingABI.Owner)
-		return nil, ErrAccessDenied
+	// 2. 检查所有权（个人ABI的所有者或组织ABI的编辑者）
+	if err := s.checkABIAccess(ctx, existingABI, walletAddress, types.OrganizationRoleEditor); err != nil {
+		logger.Error("UpdateABI access denied:", err, "id", id, "wallet_address", walletAddress, "owner", existingABI.Owner)
+		return nil, err
 	}
 
 	// 3. 验证新的ABI格式
@@ -196,7 +238,7 @@ func (s *service) UpdateABI(ctx context.Context, id int64, walletAddress string,
 
 	// 5. 检查名称是否与其他ABI重复（排除当前ABI）
 	if req.Name != existingABI.Name {
-		duplicateABI, err := s.abiRepo.GetABIByNameAndOwner(ctx, req.Name, walletAddress)
+		duplicateABI, err := s.getABIByName(ctx, req.Name, existingABI.Owner, existingABI.OrganizationID)
 		if err != nil && err != gorm.ErrRecordNotFound {
 			logger.Error("UpdateABI check name error:", err, "id", id, "wallet_address", walletAddress)
 			return nil, fmt.Errorf("failed to check ABI name: %w", err)
@@ -209,6 +251,7 @@ func (s *service) UpdateABI(ctx context.Context, id int64, walletAddress string,
 	}
 
 	// 6. 更新ABI
+	before := *existingABI
 	existingABI.Name = req.Name
 	existingABI.ABIContent = req.ABIContent
 	existingABI.Description = req.Description
@@ -217,17 +260,19 @@ func (s *service) UpdateABI(ctx context.Context, id int64, walletAddress string,
 		logger.Error("UpdateABI database error:", err, "id", id, "wallet_address", walletAddress)
 		return nil, fmt.Errorf("failed to update ABI: %w", err)
 	}
+	s.auditor.Record(ctx, types.AuditActionABIUpdate, types.AuditResourceABI, strconv.FormatInt(id, 10), existingABI.OrganizationID, before, existingABI)
 
 	// 7. 返回响应
 	response := &types.ABIResponse{
-		ID:          existingABI.ID,
-		Name:        existingABI.Name,
-		ABIContent:  existingABI.ABIContent,
-		Owner:       existingABI.Owner,
-		Description: existingABI.Description,
-		IsShared:    existingABI.IsShared,
-		CreatedAt:   existingABI.CreatedAt,
-		UpdatedAt:   existingABI.UpdatedAt,
+		ID:             existingABI.ID,
+		Name:           existingABI.Name,
+		ABIContent:     existingABI.ABIContent,
+		Owner:          existingABI.Owner,
+		Description:    existingABI.Description,
+		IsShared:       existingABI.IsShared,
+		OrganizationID: existingABI.OrganizationID,
+		CreatedAt:      existingABI.CreatedAt,
+		UpdatedAt:      existingABI.UpdatedAt,
 	}
 
 	logger.Info("UpdateABI Success:", "id", id, "wallet_address", walletAddress, "name", req.Name)
@@ -249,10 +294,10 @@ func (s *service) DeleteABI(ctx context.Context, id int64, walletAddress string)
 		return fmt.Errorf("failed to get ABI: %w", err)
 	}
 
-	// 2. 检查所有权
-	if existingABI.Owner != walletAddress {
-		logger.Error("DeleteABI access denied:", ErrAccessDenied, "id", id, "wallet_address", walletAddress, "owner", existingABI.Owner)
-		return ErrAccessDenied
+	// 2. 检查所有权（个人ABI的所有者或组织ABI的编辑者）
+	if err := s.checkABIAccess(ctx, existingABI, walletAddress, types.OrganizationRoleEditor); err != nil {
+		logger.Error("DeleteABI access denied:", err, "id", id, "wallet_address", walletAddress, "owner", existingABI.Owner)
+		return err
 	}
 
 	// 3. 不允许删除共享ABI
@@ -262,10 +307,11 @@ func (s *service) DeleteABI(ctx context.Context, id int64, walletAddress string)
 	}
 
 	// 4. 执行删除
-	if err := s.abiRepo.DeleteABI(ctx, id, walletAddress); err != nil {
+	if err := s.abiRepo.DeleteABI(ctx, id, walletAddress, existingABI.OrganizationID); err != nil {
 		logger.Error("DeleteABI database error:", err, "id", id, "wallet_address", walletAddress)
 		return fmt.Errorf("failed to delete ABI: %w", err)
 	}
+	s.auditor.Record(ctx, types.AuditActionABIDelete, types.AuditResourceABI, strconv.FormatInt(id, 10), existingABI.OrganizationID, existingABI, nil)
 
 	logger.Info("DeleteABI Success:", "id", id, "wallet_address", walletAddress)
 	return nil
@@ -284,3 +330,38 @@ func (s *service) ValidateABI(ctx context.Context, abiContent string) (*types.AB
 	logger.Info("ValidateABI Success:", "is_valid", validation.IsValid, "function_count", validation.FunctionCount, "event_count", validation.EventCount)
 	return validation, nil
 }
+
+// checkABIAccess 检查用户对ABI的访问权限：个人ABI仅所有者可访问，组织ABI需要至少required角色
+func (s *service) checkABIAccess(ctx context.Context, abi *types.ABI, walletAddress string, required string) error {
+	if abi.OrganizationID > 0 {
+		return s.checkOrganizationRole(ctx, abi.OrganizationID, walletAddress, required)
+	}
+	if abi.Owner != walletAddress {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// checkOrganizationRole 检查用户账户在组织中至少拥有required角色
+func (s *service) checkOrganizationRole(ctx context.Context, organizationID int64, walletAddress string, required string) error {
+	wallets, err := s.userRepo.GetAccountWallets(ctx, walletAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, organizationID, wallets)
+	if err != nil {
+		return fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if !types.HasOrganizationRole(role, required) {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// getABIByName 按名称查找同一作用域（个人或组织）下的ABI，用于检查重复
+func (s *service) getABIByName(ctx context.Context, name string, owner string, organizationID int64) (*types.ABI, error) {
+	if organizationID > 0 {
+		return s.abiRepo.GetABIByNameAndOrganization(ctx, name, organizationID)
+	}
+	return s.abiRepo.GetABIByNameAndOwner(ctx, name, owner)
+}
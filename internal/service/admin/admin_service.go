@@ -0,0 +1,685 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"timelocker-backend/internal/config"
+	abiRepo "timelocker-backend/internal/repository/abi"
+	adminRepo "timelocker-backend/internal/repository/admin"
+	"timelocker-backend/internal/repository/chain"
+	"timelocker-backend/internal/repository/sponsor"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrChainNotFound       = errors.New("chain not found")
+	ErrChainExists         = errors.New("chain name or chain id already exists")
+	ErrChainInUse          = errors.New("chain still has imported timelocks, disable it instead")
+	ErrInvalidRPCTemplate  = errors.New("rpc template must contain the {API_KEY} placeholder")
+	ErrSponsorNotFound     = errors.New("sponsor not found")
+	ErrSharedABINotFound   = errors.New("shared ABI not found")
+	ErrSharedABINameExists = errors.New("shared ABI name already exists")
+	ErrInvalidABI          = errors.New("invalid ABI format")
+)
+
+// rpcAPIKeyPlaceholder RPC模板中API Key的占位符，与RPC管理器的替换规则一致
+const rpcAPIKeyPlaceholder = "{API_KEY}"
+
+// ScannerController 运行期启停单链扫描器，由扫链管理器实现
+type ScannerController interface {
+	StartChainScanner(ctx context.Context, chainID int) error
+	StopChainScanner(chainID int)
+}
+
+// Service 平台管理员服务接口
+type Service interface {
+	IsAdmin(ctx context.Context, walletAddress string) bool
+
+	// 支持链管理
+	ListChains(ctx context.Context, req *types.GetSupportChainsRequest) (*types.AdminChainListResponse, error)
+	CreateChain(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateChainRequest) (*types.AdminChainResponse, error)
+	UpdateChain(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateChainRequest) (*types.AdminChainResponse, error)
+	SetChainStatus(ctx context.Context, meta *types.AdminAuditMeta, req *types.SetChainStatusRequest) (*types.AdminChainResponse, error)
+	DeleteChain(ctx context.Context, meta *types.AdminAuditMeta, id int64) error
+
+	// 赞助方管理
+	ListSponsors(ctx context.Context, req *types.GetSponsorsRequest) (*types.GetSponsorsResponse, error)
+	CreateSponsor(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateSponsorRequest) (*types.Sponsor, error)
+	UpdateSponsor(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateSponsorWithIDRequest) (*types.Sponsor, error)
+	DeleteSponsor(ctx context.Context, meta *types.AdminAuditMeta, id int64) error
+	ReorderSponsors(ctx context.Context, meta *types.AdminAuditMeta, req *types.ReorderSponsorsRequest) error
+
+	// 共享ABI管理
+	ListSharedABIs(ctx context.Context) (*types.ABIListResponse, error)
+	CreateSharedABI(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateSharedABIRequest) (*types.ABI, error)
+	UpdateSharedABI(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateABIWithIDRequest) (*types.ABI, error)
+	DeleteSharedABI(ctx context.Context, meta *types.AdminAuditMeta, id int64) error
+
+	// 审计日志
+	GetAuditLogs(ctx context.Context, req *types.GetAdminAuditLogsRequest) (*types.GetAdminAuditLogsResponse, error)
+}
+
+type service struct {
+	chainRepo    chain.Repository
+	sponsorRepo  sponsor.Repository
+	abiRepo      abiRepo.Repository
+	userRepo     user.Repository
+	auditRepo    adminRepo.AuditLogRepository
+	scanner      ScannerController
+	rpcConfig    *config.RPCConfig
+	adminWallets map[string]struct{}
+}
+
+// NewService 创建平台管理员服务实例，管理员白名单来自配置 admin.wallets
+func NewService(
+	chainRepo chain.Repository,
+	sponsorRepo sponsor.Repository,
+	abiRepository abiRepo.Repository,
+	userRepo user.Repository,
+	auditRepo adminRepo.AuditLogRepository,
+	scanner ScannerController,
+	cfg *config.Config,
+) Service {
+	adminWallets := make(map[string]struct{}, len(cfg.Admin.Wallets))
+	for _, wallet := range cfg.Admin.Wallets {
+		if wallet = strings.TrimSpace(wallet); wallet != "" {
+			adminWallets[crypto.NormalizeAddress(wallet)] = struct{}{}
+		}
+	}
+	if len(adminWallets) == 0 {
+		logger.Warn("No platform admin wallets configured, admin API is unavailable")
+	}
+
+	return &service{
+		chainRepo:    chainRepo,
+		sponsorRepo:  sponsorRepo,
+		abiRepo:      abiRepository,
+		userRepo:     userRepo,
+		auditRepo:    auditRepo,
+		scanner:      scanner,
+		rpcConfig:    &cfg.RPC,
+		adminWallets: adminWallets,
+	}
+}
+
+// IsAdmin 检查钱包所属账户下是否有钱包在平台管理员白名单中，查询账户钱包失败时视为非管理员
+func (s *service) IsAdmin(ctx context.Context, walletAddress string) bool {
+	if len(s.adminWallets) == 0 {
+		return false
+	}
+	wallets, err := s.userRepo.GetAccountWallets(ctx, crypto.NormalizeAddress(walletAddress))
+	if err != nil {
+		logger.Error("IsAdmin Error: ", err, "wallet_address", walletAddress)
+		return false
+	}
+	for _, wallet := range wallets {
+		if _, ok := s.adminWallets[crypto.NormalizeAddress(wallet)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ListChains 获取全部支持链（含停用链），返回数据库中的原始配置
+func (s *service) ListChains(ctx context.Context, req *types.GetSupportChainsRequest) (*types.AdminChainListResponse, error) {
+	chains, total, err := s.chainRepo.GetSupportChains(ctx, req)
+	if err != nil {
+		logger.Error("Admin ListChains Error: ", err)
+		return nil, fmt.Errorf("failed to get chains: %w", err)
+	}
+
+	return &types.AdminChainListResponse{
+		Chains: chains,
+		Total:  total,
+	}, nil
+}
+
+// CreateChain 创建支持链，链处于激活且启用RPC状态时立即启动扫描器
+func (s *service) CreateChain(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateChainRequest) (*types.AdminChainResponse, error) {
+	logger.Info("Admin CreateChain: ", "admin", meta.AdminAddress, "chain_name", req.ChainName, "chain_id", req.ChainID)
+
+	if err := validateRPCTemplate(req.AlchemyRPCTemplate); err != nil {
+		return nil, err
+	}
+	if err := validateRPCTemplate(req.InfuraRPCTemplate); err != nil {
+		return nil, err
+	}
+	if err := s.checkChainUnique(ctx, 0, req.ChainName, req.ChainID); err != nil {
+		return nil, err
+	}
+
+	decimals := req.NativeCurrencyDecimals
+	if decimals == 0 {
+		decimals = 18
+	}
+
+	newChain := &types.SupportChain{
+		ChainName:              strings.TrimSpace(req.ChainName),
+		DisplayName:            strings.TrimSpace(req.DisplayName),
+		ChainID:                req.ChainID,
+		NativeCurrencyName:     req.NativeCurrencyName,
+		NativeCurrencySymbol:   req.NativeCurrencySymbol,
+		NativeCurrencyDecimals: decimals,
+		LogoURL:                req.LogoURL,
+		IsTestnet:              req.IsTestnet,
+		IsActive:               req.IsActive,
+		AlchemyRPCTemplate:     req.AlchemyRPCTemplate,
+		InfuraRPCTemplate:      req.InfuraRPCTemplate,
+		OfficialRPCUrls:        encodeURLList(req.OfficialRPCUrls),
+		BlockExplorerUrls:      encodeURLList(req.BlockExplorerUrls),
+		RPCEnabled:             req.RPCEnabled,
+	}
+
+	if err := s.chainRepo.CreateChain(ctx, newChain); err != nil {
+		return nil, fmt.Errorf("failed to create chain: %w", err)
+	}
+	// gorm创建时会用列默认值(true)替换bool零值，需要再显式写入false
+	if !req.IsActive || !req.RPCEnabled {
+		updates := map[string]interface{}{"is_active": req.IsActive, "rpc_enabled": req.RPCEnabled}
+		if err := s.chainRepo.UpdateChain(ctx, newChain.ID, updates); err != nil {
+			return nil, fmt.Errorf("failed to create chain: %w", err)
+		}
+	}
+
+	s.audit(ctx, meta, types.AdminActionChainCreate, types.AdminResourceChain, newChain.ID, req)
+
+	return s.syncChainScanner(ctx, newChain.ID)
+}
+
+// UpdateChain 更新支持链配置，RPC相关配置变化后重建该链的扫描器和RPC连接
+func (s *service) UpdateChain(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateChainRequest) (*types.AdminChainResponse, error) {
+	logger.Info("Admin UpdateChain: ", "admin", meta.AdminAddress, "id", req.ID)
+
+	if _, err := s.getChain(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.DisplayName != nil {
+		updates["display_name"] = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.NativeCurrencyName != nil {
+		updates["native_currency_name"] = *req.NativeCurrencyName
+	}
+	if req.NativeCurrencySymbol != nil {
+		updates["native_currency_symbol"] = *req.NativeCurrencySymbol
+	}
+	if req.NativeCurrencyDecimals != nil {
+		updates["native_currency_decimals"] = *req.NativeCurrencyDecimals
+	}
+	if req.LogoURL != nil {
+		updates["logo_url"] = *req.LogoURL
+	}
+	if req.IsTestnet != nil {
+		updates["is_testnet"] = *req.IsTestnet
+	}
+	if req.AlchemyRPCTemplate != nil {
+		if err := validateRPCTemplate(*req.AlchemyRPCTemplate); err != nil {
+			return nil, err
+		}
+		updates["alchemy_rpc_template"] = *req.AlchemyRPCTemplate
+	}
+	if req.InfuraRPCTemplate != nil {
+		if err := validateRPCTemplate(*req.InfuraRPCTemplate); err != nil {
+			return nil, err
+		}
+		updates["infura_rpc_template"] = *req.InfuraRPCTemplate
+	}
+	if req.OfficialRPCUrls != nil {
+		updates["official_rpc_urls"] = encodeURLList(*req.OfficialRPCUrls)
+	}
+	if req.BlockExplorerUrls != nil {
+		updates["block_explorer_urls"] = encodeURLList(*req.BlockExplorerUrls)
+	}
+	if req.RPCEnabled != nil {
+		updates["rpc_enabled"] = *req.RPCEnabled
+	}
+
+	if len(updates) > 0 {
+		if err := s.chainRepo.UpdateChain(ctx, req.ID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update chain: %w", err)
+		}
+		s.audit(ctx, meta, types.AdminActionChainUpdate, types.AdminResourceChain, req.ID, updates)
+	}
+
+	// 只有影响扫链的字段变化时才重建扫描器
+	_, templateChanged := updates["alchemy_rpc_template"]
+	_, testnetChanged := updates["is_testnet"]
+	_, rpcChanged := updates["rpc_enabled"]
+	if templateChanged || testnetChanged || rpcChanged {
+		chainInfo, err := s.getChain(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.scanner.StopChainScanner(int(chainInfo.ChainID))
+	}
+
+	return s.syncChainScanner(ctx, req.ID)
+}
+
+// SetChainStatus 启用或停用支持链，并热启动/停止对应的扫描器
+func (s *service) SetChainStatus(ctx context.Context, meta *types.AdminAuditMeta, req *types.SetChainStatusRequest) (*types.AdminChainResponse, error) {
+	logger.Info("Admin SetChainStatus: ", "admin", meta.AdminAddress, "id", req.ID, "is_active", req.IsActive)
+
+	if _, err := s.getChain(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.chainRepo.UpdateChain(ctx, req.ID, map[string]interface{}{"is_active": req.IsActive}); err != nil {
+		return nil, fmt.Errorf("failed to update chain status: %w", err)
+	}
+
+	action := types.AdminActionChainDisable
+	if req.IsActive {
+		action = types.AdminActionChainEnable
+	}
+	s.audit(ctx, meta, action, types.AdminResourceChain, req.ID, req)
+
+	return s.syncChainScanner(ctx, req.ID)
+}
+
+// DeleteChain 删除支持链，仍有导入的timelock合约时拒绝删除
+func (s *service) DeleteChain(ctx context.Context, meta *types.AdminAuditMeta, id int64) error {
+	logger.Info("Admin DeleteChain: ", "admin", meta.AdminAddress, "id", id)
+
+	existing, err := s.getChain(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.chainRepo.CountChainTimelocks(ctx, existing.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to check chain usage: %w", err)
+	}
+	if count > 0 {
+		return ErrChainInUse
+	}
+
+	s.scanner.StopChainScanner(int(existing.ChainID))
+
+	if err := s.chainRepo.DeleteChain(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrChainNotFound
+		}
+		return fmt.Errorf("failed to delete chain: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionChainDelete, types.AdminResourceChain, id, existing)
+	return nil
+}
+
+// ListSponsors 分页获取赞助方列表（含未激活）
+func (s *service) ListSponsors(ctx context.Context, req *types.GetSponsorsRequest) (*types.GetSponsorsResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+
+	sponsors, total, err := s.sponsorRepo.List(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsors: %w", err)
+	}
+
+	return &types.GetSponsorsResponse{
+		Sponsors: sponsors,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// CreateSponsor 创建赞助方，默认激活
+func (s *service) CreateSponsor(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateSponsorRequest) (*types.Sponsor, error) {
+	logger.Info("Admin CreateSponsor: ", "admin", meta.AdminAddress, "name", req.Name)
+
+	newSponsor := &types.Sponsor{
+		Name:        strings.TrimSpace(req.Name),
+		LogoURL:     req.LogoURL,
+		Link:        req.Link,
+		Description: req.Description,
+		Type:        req.Type,
+		SortOrder:   req.SortOrder,
+		IsActive:    true,
+	}
+	if err := s.sponsorRepo.Create(ctx, newSponsor); err != nil {
+		return nil, fmt.Errorf("failed to create sponsor: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSponsorCreate, types.AdminResourceSponsor, newSponsor.ID, req)
+	return newSponsor, nil
+}
+
+// UpdateSponsor 更新赞助方信息、排序权重或激活状态
+func (s *service) UpdateSponsor(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateSponsorWithIDRequest) (*types.Sponsor, error) {
+	logger.Info("Admin UpdateSponsor: ", "admin", meta.AdminAddress, "id", req.ID)
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.LogoURL != nil {
+		updates["logo_url"] = *req.LogoURL
+	}
+	if req.Link != nil {
+		updates["link"] = *req.Link
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Type != nil {
+		updates["type"] = *req.Type
+	}
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := s.sponsorRepo.Update(ctx, req.ID, updates); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrSponsorNotFound
+			}
+			return nil, fmt.Errorf("failed to update sponsor: %w", err)
+		}
+		s.audit(ctx, meta, types.AdminActionSponsorUpdate, types.AdminResourceSponsor, req.ID, updates)
+	}
+
+	updated, err := s.sponsorRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSponsorNotFound
+		}
+		return nil, fmt.Errorf("failed to get sponsor: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteSponsor 删除赞助方
+func (s *service) DeleteSponsor(ctx context.Context, meta *types.AdminAuditMeta, id int64) error {
+	logger.Info("Admin DeleteSponsor: ", "admin", meta.AdminAddress, "id", id)
+
+	existing, err := s.sponsorRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSponsorNotFound
+		}
+		return fmt.Errorf("failed to get sponsor: %w", err)
+	}
+
+	if err := s.sponsorRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSponsorNotFound
+		}
+		return fmt.Errorf("failed to delete sponsor: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSponsorDelete, types.AdminResourceSponsor, id, existing)
+	return nil
+}
+
+// ReorderSponsors 批量设置赞助方排序权重（数值越大越靠前）
+func (s *service) ReorderSponsors(ctx context.Context, meta *types.AdminAuditMeta, req *types.ReorderSponsorsRequest) error {
+	logger.Info("Admin ReorderSponsors: ", "admin", meta.AdminAddress, "count", len(req.Items))
+
+	if err := s.sponsorRepo.UpdateSortOrders(ctx, req.Items); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSponsorNotFound
+		}
+		return fmt.Errorf("failed to reorder sponsors: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSponsorReorder, types.AdminResourceSponsor, 0, req.Items)
+	return nil
+}
+
+// ListSharedABIs 获取平台共享ABI列表
+func (s *service) ListSharedABIs(ctx context.Context) (*types.ABIListResponse, error) {
+	abis, err := s.abiRepo.GetSharedABIs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared ABIs: %w", err)
+	}
+	return &types.ABIListResponse{ABIs: abis}, nil
+}
+
+// CreateSharedABI 创建平台共享ABI，对所有用户可见
+func (s *service) CreateSharedABI(ctx context.Context, meta *types.AdminAuditMeta, req *types.CreateSharedABIRequest) (*types.ABI, error) {
+	logger.Info("Admin CreateSharedABI: ", "admin", meta.AdminAddress, "name", req.Name)
+
+	if err := validateABIContent(req.ABIContent); err != nil {
+		return nil, err
+	}
+	if err := s.checkSharedABINameUnique(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+
+	newABI := &types.ABI{
+		Name:        req.Name,
+		ABIContent:  req.ABIContent,
+		Owner:       abiRepo.SharedABIOwner,
+		Description: req.Description,
+		IsShared:    true,
+	}
+	if err := s.abiRepo.CreateABI(ctx, newABI); err != nil {
+		return nil, fmt.Errorf("failed to create shared ABI: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSharedABICreate, types.AdminResourceSharedABI, newABI.ID, map[string]interface{}{
+		"name":        newABI.Name,
+		"description": newABI.Description,
+	})
+	return newABI, nil
+}
+
+// UpdateSharedABI 更新平台共享ABI
+func (s *service) UpdateSharedABI(ctx context.Context, meta *types.AdminAuditMeta, req *types.UpdateABIWithIDRequest) (*types.ABI, error) {
+	logger.Info("Admin UpdateSharedABI: ", "admin", meta.AdminAddress, "id", req.ID)
+
+	existing, err := s.getSharedABI(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateABIContent(req.ABIContent); err != nil {
+		return nil, err
+	}
+	if err := s.checkSharedABINameUnique(ctx, req.ID, req.Name); err != nil {
+		return nil, err
+	}
+
+	existing.Name = req.Name
+	existing.ABIContent = req.ABIContent
+	existing.Description = req.Description
+	if err := s.abiRepo.UpdateABI(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update shared ABI: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSharedABIUpdate, types.AdminResourceSharedABI, req.ID, map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+	})
+	return existing, nil
+}
+
+// DeleteSharedABI 删除平台共享ABI
+func (s *service) DeleteSharedABI(ctx context.Context, meta *types.AdminAuditMeta, id int64) error {
+	logger.Info("Admin DeleteSharedABI: ", "admin", meta.AdminAddress, "id", id)
+
+	existing, err := s.getSharedABI(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.abiRepo.DeleteABI(ctx, id, abiRepo.SharedABIOwner, 0); err != nil {
+		return fmt.Errorf("failed to delete shared ABI: %w", err)
+	}
+
+	s.audit(ctx, meta, types.AdminActionSharedABIDelete, types.AdminResourceSharedABI, id, map[string]interface{}{
+		"name": existing.Name,
+	})
+	return nil
+}
+
+// GetAuditLogs 分页查询管理员审计日志
+func (s *service) GetAuditLogs(ctx context.Context, req *types.GetAdminAuditLogsRequest) (*types.GetAdminAuditLogsResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+
+	logs, total, err := s.auditRepo.GetAuditLogs(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+
+	return &types.GetAdminAuditLogsResponse{
+		Logs:     logs,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// audit 写入审计日志；写入失败只记录错误，不回滚已完成的管理操作
+func (s *service) audit(ctx context.Context, meta *types.AdminAuditMeta, action, resourceType string, resourceID int64, details interface{}) {
+	detailsJSON := "{}"
+	if details != nil {
+		if data, err := json.Marshal(details); err == nil {
+			detailsJSON = string(data)
+		} else {
+			logger.Error("Admin audit marshal details error: ", err, "action", action)
+		}
+	}
+
+	entry := &types.AdminAuditLog{
+		AdminAddress: crypto.NormalizeAddress(meta.AdminAddress),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Details:      detailsJSON,
+		IPAddress:    meta.IPAddress,
+		UserAgent:    meta.UserAgent,
+	}
+	if err := s.auditRepo.CreateAuditLog(ctx, entry); err != nil {
+		logger.Error("Admin audit log write failed: ", err, "action", action, "resource_id", resourceID, "admin", meta.AdminAddress)
+	}
+}
+
+// syncChainScanner 按链的最新状态启动或停止扫描器，扫描器启动失败不影响已保存的配置
+func (s *service) syncChainScanner(ctx context.Context, id int64) (*types.AdminChainResponse, error) {
+	current, err := s.getChain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.AdminChainResponse{Chain: *current}
+	chainID := int(current.ChainID)
+
+	scannable := current.IsActive && current.RPCEnabled && (s.rpcConfig.IncludeTestnets || !current.IsTestnet)
+	if !scannable {
+		s.scanner.StopChainScanner(chainID)
+		return response, nil
+	}
+
+	if err := s.scanner.StartChainScanner(ctx, chainID); err != nil {
+		logger.Error("Admin start chain scanner error: ", err, "chain_id", chainID)
+		response.ScannerError = err.Error()
+		return response, nil
+	}
+
+	response.ScannerRunning = true
+	return response, nil
+}
+
+// getChain 获取支持链，不存在时返回 ErrChainNotFound
+func (s *service) getChain(ctx context.Context, id int64) (*types.SupportChain, error) {
+	existing, err := s.chainRepo.GetChainByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChainNotFound
+		}
+		return nil, fmt.Errorf("failed to get chain: %w", err)
+	}
+	return existing, nil
+}
+
+// checkChainUnique 检查链名称和ChainID未被其他链占用
+func (s *service) checkChainUnique(ctx context.Context, excludeID int64, chainName string, chainID int64) error {
+	if existing, err := s.chainRepo.GetChainByChainName(chainName); err == nil && existing != nil && existing.ID != excludeID {
+		return ErrChainExists
+	}
+	if existing, err := s.chainRepo.GetChainByChainID(ctx, chainID); err == nil && existing != nil && existing.ID != excludeID {
+		return ErrChainExists
+	}
+	return nil
+}
+
+// getSharedABI 获取共享ABI，个人和组织ABI视为不存在
+func (s *service) getSharedABI(ctx context.Context, id int64) (*types.ABI, error) {
+	existing, err := s.abiRepo.GetABIByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSharedABINotFound
+		}
+		return nil, fmt.Errorf("failed to get ABI: %w", err)
+	}
+	if existing == nil || !existing.IsShared || existing.Owner != abiRepo.SharedABIOwner {
+		return nil, ErrSharedABINotFound
+	}
+	return existing, nil
+}
+
+// checkSharedABINameUnique 检查共享ABI名称未被其他共享ABI占用
+func (s *service) checkSharedABINameUnique(ctx context.Context, excludeID int64, name string) error {
+	existing, err := s.abiRepo.GetABIByNameAndOwner(ctx, name, abiRepo.SharedABIOwner)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check ABI name: %w", err)
+	}
+	if existing != nil && existing.ID != excludeID {
+		return ErrSharedABINameExists
+	}
+	return nil
+}
+
+// validateABIContent 校验ABI JSON格式
+func validateABIContent(content string) error {
+	validation, err := utils.ValidateABI(content)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidABI, err)
+	}
+	if !validation.IsValid {
+		return fmt.Errorf("%w: %s", ErrInvalidABI, validation.ErrorMessage)
+	}
+	return nil
+}
+
+// validateRPCTemplate 非空的RPC模板必须包含API Key占位符
+func validateRPCTemplate(template string) error {
+	if template != "" && !strings.Contains(template, rpcAPIKeyPlaceholder) {
+		return ErrInvalidRPCTemplate
+	}
+	return nil
+}
+
+// encodeURLList 将URL列表编码为support_chains中使用的JSON数组字符串
+func encodeURLList(urls []string) string {
+	if urls == nil {
+		urls = []string{}
+	}
+	data, _ := json.Marshal(urls)
+	return string(data)
+}
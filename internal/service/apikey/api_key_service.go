@@ -0,0 +1,144 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/repository/apikey"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+)
+
+// maxActiveAPIKeys 每个用户最多可持有的有效API Key数量
+const maxActiveAPIKeys = 20
+
+var (
+	ErrInvalidScope   = errors.New("invalid api key scope")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyLimit    = errors.New("too many active api keys")
+	ErrInvalidKeyName = errors.New("api key name is required")
+	ErrDuplicateScope = errors.New("duplicate api key scope")
+)
+
+// Service API Key服务接口
+type Service interface {
+	CreateAPIKey(ctx context.Context, userID int64, chainID int, req *types.CreateAPIKeyRequest) (*types.CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID int64) (*types.APIKeyListResponse, error)
+	RevokeAPIKey(ctx context.Context, userID int64, id int64) error
+}
+
+type service struct {
+	apiKeyRepo apikey.Repository
+}
+
+// NewService 创建API Key服务实例
+func NewService(apiKeyRepo apikey.Repository) Service {
+	return &service{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateAPIKey 创建API Key，chainID不为0时Key只能访问该链（Safe会话）
+func (s *service) CreateAPIKey(ctx context.Context, userID int64, chainID int, req *types.CreateAPIKeyRequest) (*types.CreateAPIKeyResponse, error) {
+	logger.Info("CreateAPIKey", "user_id", userID, "name", req.Name, "scopes", req.Scopes)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidKeyName
+	}
+
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !types.IsValidAPIKeyScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+		if seen[scope] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateScope, scope)
+		}
+		seen[scope] = true
+	}
+
+	count, err := s.apiKeyRepo.CountActiveAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count api keys: %w", err)
+	}
+	if count >= maxActiveAPIKeys {
+		return nil, fmt.Errorf("%w: at most %d active keys are allowed", ErrAPIKeyLimit, maxActiveAPIKeys)
+	}
+
+	rawKey, prefix, keyHash, err := crypto.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &types.APIKey{
+		UserID:    userID,
+		Name:      name,
+		KeyPrefix: prefix,
+		KeyHash:   keyHash,
+		Scopes:    strings.Join(req.Scopes, ","),
+		ChainID:   chainID,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	logger.Info("CreateAPIKey success", "user_id", userID, "id", key.ID, "prefix", key.KeyPrefix)
+	return &types.CreateAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: toAPIKeyInfo(key),
+	}, nil
+}
+
+// ListAPIKeys 获取用户的API Key列表
+func (s *service) ListAPIKeys(ctx context.Context, userID int64) (*types.APIKeyListResponse, error) {
+	keys, err := s.apiKeyRepo.GetAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	infos := make([]types.APIKeyInfo, 0, len(keys))
+	for i := range keys {
+		infos = append(infos, toAPIKeyInfo(&keys[i]))
+	}
+	return &types.APIKeyListResponse{APIKeys: infos}, nil
+}
+
+// RevokeAPIKey 撤销API Key
+func (s *service) RevokeAPIKey(ctx context.Context, userID int64, id int64) error {
+	logger.Info("RevokeAPIKey", "user_id", userID, "id", id)
+
+	revoked, err := s.apiKeyRepo.RevokeAPIKey(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// toAPIKeyInfo 转换为不含密钥的API Key信息
+func toAPIKeyInfo(key *types.APIKey) types.APIKeyInfo {
+	return types.APIKeyInfo{
+		ID:         key.ID,
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		Scopes:     key.ScopeList(),
+		ChainID:    key.ChainID,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		LastUsedIP: key.LastUsedIP,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
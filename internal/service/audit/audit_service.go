@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"timelocker-backend/internal/repository/audit"
+	"timelocker-backend/internal/repository/organization"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+)
+
+var (
+	ErrPermissionDenied = errors.New("only organization owners can view the organization audit log")
+)
+
+// Recorder 审计事件记录接口，供各业务服务在状态变更成功后调用
+type Recorder interface {
+	// Record 记录一次状态变更，操作者信息从上下文读取；before/after为变更前后的状态快照，会序列化为JSON
+	Record(ctx context.Context, action, resourceType, resourceID string, organizationID int64, before, after interface{})
+}
+
+// Service 审计服务接口
+type Service interface {
+	Recorder
+
+	// 个人视图：当前用户自己发起的操作
+	GetMyEvents(ctx context.Context, walletAddress string, req *types.GetAuditEventsRequest) (*types.GetAuditEventsResponse, error)
+	// 组织视图：组织资源上的全部操作，仅组织所有者可查看
+	GetOrganizationEvents(ctx context.Context, walletAddress string, req *types.GetOrganizationAuditEventsRequest) (*types.GetAuditEventsResponse, error)
+	// 管理员视图：平台全部用户操作和管理员后台操作
+	GetAllEvents(ctx context.Context, req *types.GetAdminAuditEventsRequest) (*types.GetAdminAuditEventsResponse, error)
+}
+
+type service struct {
+	auditRepo audit.Repository
+	orgRepo   organization.Repository
+	userRepo  user.Repository
+}
+
+// NewService 创建审计服务实例
+func NewService(auditRepo audit.Repository, orgRepo organization.Repository, userRepo user.Repository) Service {
+	return &service{
+		auditRepo: auditRepo,
+		orgRepo:   orgRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Record 写入审计事件；写入失败只记录错误，不影响已完成的业务操作
+func (s *service) Record(ctx context.Context, action, resourceType, resourceID string, organizationID int64, before, after interface{}) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		logger.Warn("Audit event skipped: no actor in context", "action", action, "resource_id", resourceID)
+		return
+	}
+
+	event := &types.AuditEvent{
+		ActorUserID:    actor.UserID,
+		ActorAddress:   crypto.NormalizeAddress(actor.WalletAddress),
+		AuthType:       actor.AuthType,
+		OrganizationID: organizationID,
+		Action:         action,
+		ResourceType:   resourceType,
+		ResourceID:     resourceID,
+		Before:         encodeSnapshot(before, action),
+		After:          encodeSnapshot(after, action),
+		IPAddress:      actor.IPAddress,
+		UserAgent:      actor.UserAgent,
+	}
+
+	if err := s.auditRepo.CreateEvent(ctx, event); err != nil {
+		logger.Error("Audit event write failed: ", err, "action", action, "resource_id", resourceID, "actor_address", event.ActorAddress)
+	}
+}
+
+// GetMyEvents 查询当前用户发起的操作记录
+func (s *service) GetMyEvents(ctx context.Context, walletAddress string, req *types.GetAuditEventsRequest) (*types.GetAuditEventsResponse, error) {
+	filter := buildFilter(req)
+	filter.ActorAddress = crypto.NormalizeAddress(walletAddress)
+	return s.getEvents(ctx, filter)
+}
+
+// GetOrganizationEvents 查询组织资源上的操作记录，可按操作者和资源筛选
+func (s *service) GetOrganizationEvents(ctx context.Context, walletAddress string, req *types.GetOrganizationAuditEventsRequest) (*types.GetAuditEventsResponse, error) {
+	wallets, err := s.userRepo.GetAccountWallets(ctx, crypto.NormalizeAddress(walletAddress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, req.OrganizationID, wallets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if !types.HasOrganizationRole(role, types.OrganizationRoleOwner) {
+		return nil, ErrPermissionDenied
+	}
+
+	filter := buildFilter(&req.GetAuditEventsRequest)
+	filter.OrganizationID = &req.OrganizationID
+	return s.getEvents(ctx, filter)
+}
+
+// GetAllEvents 管理员查询全部操作记录，包含admin_audit_logs中的管理员后台操作
+func (s *service) GetAllEvents(ctx context.Context, req *types.GetAdminAuditEventsRequest) (*types.GetAdminAuditEventsResponse, error) {
+	filter := buildFilter(&req.GetAuditEventsRequest)
+	filter.OrganizationID = req.OrganizationID
+	filter.Scope = req.Scope
+
+	events, total, err := s.auditRepo.GetAllEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit events: %w", err)
+	}
+
+	return &types.GetAdminAuditEventsResponse{
+		Events:   events,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// getEvents 执行查询并组装分页响应
+func (s *service) getEvents(ctx context.Context, filter *types.AuditEventFilter) (*types.GetAuditEventsResponse, error) {
+	events, total, err := s.auditRepo.GetEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit events: %w", err)
+	}
+
+	return &types.GetAuditEventsResponse{
+		Events:   events,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// buildFilter 将请求转换为查询条件并补全分页默认值
+func buildFilter(req *types.GetAuditEventsRequest) *types.AuditEventFilter {
+	filter := &types.AuditEventFilter{
+		ActorAddress: req.ActorAddress,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		Action:       req.Action,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Page:         req.Page,
+		PageSize:     req.PageSize,
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	return filter
+}
+
+// encodeSnapshot 序列化状态快照，nil表示无快照
+func encodeSnapshot(snapshot interface{}, action string) *string {
+	if snapshot == nil {
+		return nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		logger.Error("Audit snapshot marshal error: ", err, "action", action)
+		return nil
+	}
+	// nil的map/指针经接口传入时序列化为null，同样视为无快照
+	if string(data) == "null" {
+		return nil
+	}
+	encoded := string(data)
+	return &encoded
+}
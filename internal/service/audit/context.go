@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"context"
+
+	"timelocker-backend/internal/types"
+)
+
+// actorContextKey 请求上下文中操作者信息的键
+type actorContextKey struct{}
+
+// WithActor 将操作者信息写入上下文，认证中间件在认证成功后调用
+func WithActor(ctx context.Context, actor types.AuditActor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext 从上下文中读取操作者信息
+func ActorFromContext(ctx context.Context) (types.AuditActor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(types.AuditActor)
+	return actor, ok
+}
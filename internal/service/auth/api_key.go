@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyLastUsedInterval 最后使用时间的更新间隔，避免每个请求都写库
+const apiKeyLastUsedInterval = time.Minute
+
+// VerifyAPIKey 验证个人API Key，返回Key所属用户的声明和Key信息
+func (s *service) VerifyAPIKey(ctx context.Context, rawKey string, clientIP string) (*types.JWTClaims, *types.APIKey, error) {
+	if !crypto.IsAPIKeyFormat(rawKey) {
+		return nil, nil, fmt.Errorf("%w: malformed key", ErrInvalidAPIKey)
+	}
+
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(ctx, crypto.HashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		logger.Error("VerifyAPIKey Error: ", errors.New("database error"), "error: ", err)
+		return nil, nil, fmt.Errorf("database error: %w", err)
+	}
+	if key.RevokedAt != nil {
+		return nil, nil, fmt.Errorf("%w: key has been revoked", ErrInvalidAPIKey)
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, nil, fmt.Errorf("%w: key has expired", ErrInvalidAPIKey)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, key.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		logger.Error("VerifyAPIKey Error: ", errors.New("database error"), "error: ", err)
+		return nil, nil, fmt.Errorf("database error: %w", err)
+	}
+	if user.Status != 1 {
+		logger.Error("VerifyAPIKey Error: ", errors.New("user account is disabled"))
+		return nil, nil, errors.New("user account is disabled")
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > apiKeyLastUsedInterval {
+		if err := s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, truncate(clientIP, 64)); err != nil {
+			// 不影响认证
+			logger.Error("Failed to update api key last used", err, "id", key.ID)
+		}
+	}
+
+	// 由Safe会话创建的Key与会话一样绑定到链
+	claims := &types.JWTClaims{
+		UserID:        user.ID,
+		WalletAddress: user.WalletAddress,
+		ChainID:       key.ChainID,
+		Safe:          key.ChainID != 0,
+		Type:          "api_key",
+		ExpiresAt:     timeOrZero(key.ExpiresAt),
+	}
+	return claims, key, nil
+}
+
+// timeOrZero 返回时间值，为空时返回零值
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
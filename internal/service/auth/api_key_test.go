@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apiKeyRepo "timelocker-backend/internal/repository/apikey"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+
+	"gorm.io/gorm"
+)
+
+// stubAPIKeyRepo 按哈希查找API Key的仓库，记录最后使用时间的更新
+type stubAPIKeyRepo struct {
+	apiKeyRepo.Repository
+	keys       map[string]*types.APIKey
+	lastUsedIP map[int64]string
+}
+
+func (r *stubAPIKeyRepo) GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error) {
+	if key, ok := r.keys[keyHash]; ok {
+		copied := *key
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubAPIKeyRepo) UpdateLastUsed(ctx context.Context, id int64, ipAddress string) error {
+	r.lastUsedIP[id] = ipAddress
+	return nil
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	recent := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name         string
+		key          *types.APIKey // 为空时仓库中不存在该Key
+		rawKey       string        // 为空时使用新生成的Key
+		userStatus   int
+		wantErr      error
+		wantSafe     bool
+		wantLastUsed bool
+	}{
+		{name: "valid", key: &types.APIKey{ID: 1, UserID: 1}, userStatus: 1, wantLastUsed: true},
+		{name: "valid with expiry", key: &types.APIKey{ID: 1, UserID: 1, ExpiresAt: &future}, userStatus: 1, wantLastUsed: true},
+		{name: "chain bound key", key: &types.APIKey{ID: 1, UserID: 1, ChainID: 56}, userStatus: 1, wantSafe: true, wantLastUsed: true},
+		{name: "recently used", key: &types.APIKey{ID: 1, UserID: 1, LastUsedAt: &recent}, userStatus: 1},
+		{name: "malformed", rawKey: "not-an-api-key", wantErr: ErrInvalidAPIKey},
+		{name: "unknown key", userStatus: 1, wantErr: ErrInvalidAPIKey},
+		{name: "revoked", key: &types.APIKey{ID: 1, UserID: 1, RevokedAt: &past}, userStatus: 1, wantErr: ErrInvalidAPIKey},
+		{name: "expired", key: &types.APIKey{ID: 1, UserID: 1, ExpiresAt: &past}, userStatus: 1, wantErr: ErrInvalidAPIKey},
+		{name: "owner deleted", key: &types.APIKey{ID: 1, UserID: 2}, userStatus: 1, wantErr: ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawKey, _, hash, err := crypto.GenerateAPIKey()
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			if tt.rawKey != "" {
+				rawKey = tt.rawKey
+			}
+
+			keys := &stubAPIKeyRepo{keys: map[string]*types.APIKey{}, lastUsedIP: map[int64]string{}}
+			if tt.key != nil {
+				tt.key.KeyHash = hash
+				keys.keys[hash] = tt.key
+			}
+			user := &types.User{ID: 1, WalletAddress: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Status: tt.userStatus}
+			s := &service{
+				userRepo:   &stubUserRepo{users: map[int64]*types.User{user.ID: user}},
+				apiKeyRepo: keys,
+			}
+
+			claims, key, err := s.VerifyAPIKey(context.Background(), rawKey, "203.0.113.7")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.ID != tt.key.ID {
+				t.Fatalf("got key %d, want %d", key.ID, tt.key.ID)
+			}
+			if claims.Type != "api_key" || claims.UserID != user.ID || claims.WalletAddress != user.WalletAddress {
+				t.Fatalf("unexpected claims: %+v", claims)
+			}
+			if claims.Safe != tt.wantSafe || claims.ChainID != tt.key.ChainID {
+				t.Fatalf("claims safe=%v chain=%d, want safe=%v chain=%d", claims.Safe, claims.ChainID, tt.wantSafe, tt.key.ChainID)
+			}
+			if _, updated := keys.lastUsedIP[key.ID]; updated != tt.wantLastUsed {
+				t.Fatalf("last used updated=%v, want %v", updated, tt.wantLastUsed)
+			}
+		})
+	}
+}
+
+func TestVerifyAPIKeyDisabledUser(t *testing.T) {
+	rawKey, _, hash, err := crypto.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	s := &service{
+		userRepo: &stubUserRepo{users: map[int64]*types.User{1: {ID: 1, Status: 0}}},
+		apiKeyRepo: &stubAPIKeyRepo{
+			keys:       map[string]*types.APIKey{hash: {ID: 1, UserID: 1, KeyHash: hash}},
+			lastUsedIP: map[int64]string{},
+		},
+	}
+	if _, _, err := s.VerifyAPIKey(context.Background(), rawKey, ""); err == nil {
+		t.Fatalf("expected error for disabled user")
+	}
+}
@@ -9,8 +9,13 @@ import (
 	"strings"
 	"time"
 
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/repository/apikey"
+	chainRepo "timelocker-backend/internal/repository/chain"
 	"timelocker-backend/internal/repository/safe"
+	"timelocker-backend/internal/repository/session"
 	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/service/scanner"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/crypto"
@@ -33,6 +38,13 @@ var (
 	ErrSignatureRecovery = errors.New("failed to recover address from signature")
 	ErrInvalidNonce      = errors.New("invalid or expired nonce")
 	ErrNonceUsed         = errors.New("nonce already used")
+	ErrChainMismatch     = errors.New("chain id does not match signed message")
+	ErrInvalidSiwe       = errors.New("invalid sign-in message")
+	ErrInvalidDomain     = errors.New("sign-in domain is not allowed")
+	ErrUnsupportedChain  = errors.New("chain is not supported")
+	ErrTokenRevoked      = errors.New("token has been revoked")
+	ErrTokenReused       = errors.New("refresh token reuse detected, all sessions of this login have been revoked")
+	ErrInvalidAPIKey     = errors.New("invalid api key")
 )
 
 // Service 认证服务接口 - 支持链切换
@@ -42,27 +54,50 @@ type Service interface {
 	RefreshToken(ctx context.Context, req *types.RefreshTokenRequest) (*types.WalletConnectResponse, error)
 	GetProfile(ctx context.Context, walletAddress string) (*types.UserProfile, error)
 	VerifyToken(ctx context.Context, tokenString string) (*types.JWTClaims, error)
+	GetJWKS() *types.JWKSet
+	Logout(ctx context.Context, claims *types.JWTClaims) (*types.LogoutResponse, error)
+	LogoutAll(ctx context.Context, claims *types.JWTClaims) (*types.LogoutResponse, error)
+	CleanupSessions(ctx context.Context) error
+	VerifyAPIKey(ctx context.Context, rawKey string, clientIP string) (*types.JWTClaims, *types.APIKey, error)
+
+	// 关联钱包
+	GetLinkWalletNonce(ctx context.Context, userID int64, req *types.LinkWalletNonceRequest) (*types.GetNonceResponse, error)
+	LinkWallet(ctx context.Context, userID int64, req *types.LinkWalletRequest) (*types.LinkedWallet, error)
+	UnlinkWallet(ctx context.Context, userID int64, req *types.UnlinkWalletRequest) error
+	GetLinkedWallets(ctx context.Context, userID int64) (*types.LinkedWalletListResponse, error)
 }
 
 type service struct {
-	userRepo   user.Repository
-	safeRepo   safe.Repository
-	rpcManager *scanner.RPCManager
-	jwtManager *utils.JWTManager
+	userRepo    user.Repository
+	safeRepo    safe.Repository
+	chainRepo   chainRepo.Repository
+	sessionRepo session.Repository
+	apiKeyRepo  apikey.Repository
+	rpcManager  *scanner.RPCManager
+	jwtManager  *utils.JWTManager
+	denylist    TokenDenylist
+	siweConfig  *config.SIWEConfig
+	auditor     audit.Recorder
 }
 
-func NewService(userRepo user.Repository, safeRepo safe.Repository, rpcManager *scanner.RPCManager, jwtManager *utils.JWTManager) Service {
+func NewService(userRepo user.Repository, safeRepo safe.Repository, chainRepo chainRepo.Repository, sessionRepo session.Repository, apiKeyRepo apikey.Repository, rpcManager *scanner.RPCManager, jwtManager *utils.JWTManager, denylist TokenDenylist, siweConfig *config.SIWEConfig, auditor audit.Recorder) Service {
 	return &service{
-		userRepo:   userRepo,
-		safeRepo:   safeRepo,
-		rpcManager: rpcManager,
-		jwtManager: jwtManager,
+		userRepo:    userRepo,
+		safeRepo:    safeRepo,
+		chainRepo:   chainRepo,
+		sessionRepo: sessionRepo,
+		apiKeyRepo:  apiKeyRepo,
+		rpcManager:  rpcManager,
+		jwtManager:  jwtManager,
+		denylist:    denylist,
+		siweConfig:  siweConfig,
+		auditor:     auditor,
 	}
 }
 
 // GetNonce 获取认证nonce
 func (s *service) GetNonce(ctx context.Context, req *types.GetNonceRequest) (*types.GetNonceResponse, error) {
-	logger.Info("GetNonce", "wallet_address", req.WalletAddress)
+	logger.Info("GetNonce", "wallet_address", req.WalletAddress, "chain_id", req.ChainID)
 
 	// 验证钱包地址格式
 	if !crypto.ValidateEthereumAddress(req.WalletAddress) {
@@ -71,14 +106,42 @@ func (s *service) GetNonce(ctx context.Context, req *types.GetNonceRequest) (*ty
 
 	normalizedAddress := crypto.NormalizeAddress(req.WalletAddress)
 
+	// 验证链是否在支持列表中
+	if err := s.validateSiweChain(ctx, req.ChainID); err != nil {
+		return nil, err
+	}
+
+	// 确定签名域名和URI（域名必须在配置的白名单中）
+	domain := req.Domain
+	if domain == "" && len(s.siweConfig.Domains) > 0 {
+		domain = s.siweConfig.Domains[0]
+	}
+	uri := req.URI
+	if uri == "" {
+		uri = "https://" + domain
+	}
+	if err := s.validateSiweDomain(domain, uri); err != nil {
+		return nil, err
+	}
+
 	// 生成随机nonce
 	nonce := crypto.GenerateNonce()
 
-	// 构造签名消息
-	message := fmt.Sprintf("Welcome to TimeLocker!\n\nClick to sign in and accept the TimeLocker Terms of Service.\n\nThis request will not trigger a blockchain transaction or cost any gas fees.\n\nWallet address:\n%s\n\nNonce:\n%s", normalizedAddress, nonce)
-
-	// 设置过期时间（5分钟）
-	expiresAt := time.Now().Add(5 * time.Minute)
+	// 构造SIWE (EIP-4361) 签名消息
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+	expiresAt := issuedAt.Add(s.siweConfig.NonceExpiry)
+	siweMessage := &utils.SiweMessage{
+		Domain:         domain,
+		Address:        common.HexToAddress(normalizedAddress).Hex(),
+		Statement:      s.siweConfig.Statement,
+		URI:            uri,
+		Version:        "1",
+		ChainID:        req.ChainID,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiresAt,
+	}
+	message := siweMessage.String()
 
 	// 清理该钱包地址的所有现有nonce（避免重复键冲突）
 	if err := s.cleanupAllNonces(ctx, normalizedAddress); err != nil {
@@ -93,6 +156,8 @@ func (s *service) GetNonce(ctx context.Context, req *types.GetNonceRequest) (*ty
 		Message:       message,
 		ExpiresAt:     expiresAt,
 		IsUsed:        false,
+		ChainID:       req.ChainID,
+		Purpose:       types.AuthNoncePurposeLogin,
 	}
 
 	if err := s.userRepo.CreateAuthNonce(ctx, authNonce); err != nil {
@@ -102,8 +167,9 @@ func (s *service) GetNonce(ctx context.Context, req *types.GetNonceRequest) (*ty
 
 	logger.Info("Generated nonce", "wallet_address", normalizedAddress, "nonce", nonce)
 	return &types.GetNonceResponse{
-		Message: message,
-		Nonce:   nonce,
+		Message:   message,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
@@ -119,60 +185,55 @@ func (s *service) WalletConnect(ctx context.Context, req *types.WalletConnectReq
 
 	normalizedAddress := crypto.NormalizeAddress(req.WalletAddress)
 
-	// 2. 根据钱包类型进行不同的验证
-	var isSafeWallet bool
-	var safeThreshold *int
-	var safeOwners *string
+	// 2. 校验SIWE签名消息并使用nonce
+	if req.Nonce == "" || req.Message == "" || req.Signature == "" {
+		logger.Error("WalletConnect Error: ", fmt.Errorf("wallet requires nonce, message and signature"))
+		return nil, fmt.Errorf("wallet requires nonce, message and signature")
+	}
+
+	siweMessage, err := s.validateSiweMessage(ctx, normalizedAddress, req.Message, req.Nonce, req.ChainID)
+	if err != nil {
+		logger.Error("WalletConnect SIWE validation failed", err)
+		return nil, err
+	}
 
-	if req.WalletType == "safe" {
-		// Safe钱包验证：只需要验证地址是否为Safe合约
-		logger.Info("Verifying Safe wallet", "safe_address", normalizedAddress, "chain_id", req.ChainID)
+	if siweMessage.Statement != s.siweConfig.Statement {
+		logger.Error("WalletConnect Error: ", ErrInvalidSiwe, "statement", siweMessage.Statement)
+		return nil, fmt.Errorf("%w: message is not a login request", ErrInvalidSiwe)
+	}
 
-		// 验证是否为Safe合约并获取Safe信息
-		safeInfo, err := s.getSafeInfo(ctx, normalizedAddress, req.ChainID)
-		if err != nil {
-			logger.Error("Failed to verify Safe contract or get Safe info", err)
-			return nil, fmt.Errorf("address is not a valid Safe contract: %w", err)
-		}
+	if err := s.validateAndUseNonce(ctx, normalizedAddress, req.Nonce, req.Message, siweMessage.ChainID, types.AuthNoncePurposeLogin); err != nil {
+		logger.Error("WalletConnect nonce validation failed", err)
+		return nil, err
+	}
+
+	// 3. 根据钱包类型验证签名
+	safeInfo, err := s.verifyWalletSignature(ctx, normalizedAddress, req.WalletType, siweMessage.ChainID, req.Message, req.Signature)
+	if err != nil {
+		logger.Error("WalletConnect signature verification failed", err)
+		return nil, err
+	}
 
-		isSafeWallet = true
+	isSafeWallet := safeInfo != nil
+	var safeThreshold *int
+	var safeOwners *string
+	if isSafeWallet {
 		threshold := safeInfo.Threshold
 		safeThreshold = &threshold
 
 		ownersJSON, _ := json.Marshal(safeInfo.Owners)
 		ownersStr := string(ownersJSON)
 		safeOwners = &ownersStr
+	}
 
-		logger.Info("Safe wallet verified successfully", "safe_address", normalizedAddress, "threshold", threshold, "owners_count", len(safeInfo.Owners))
-
-	} else {
-		// 普通EOA钱包验证：需要nonce和签名验证
-		if req.Nonce == "" || req.Message == "" || req.Signature == "" {
-			logger.Error("WalletConnect Error: ", fmt.Errorf("EOA wallet requires nonce, message and signature"))
-			return nil, fmt.Errorf("EOA wallet requires nonce, message and signature")
-		}
-
-		// 验证nonce
-		if err := s.validateAndUseNonce(ctx, normalizedAddress, req.Nonce, req.Message); err != nil {
-			logger.Error("WalletConnect nonce validation failed", err)
-			return nil, err
-		}
-
-		// 验证签名
-		err := crypto.VerifySignature(req.Message, req.Signature, req.WalletAddress)
-		if err != nil {
-			// 尝试从签名中恢复地址进行二次验证
-			recoveredAddress, recoverErr := crypto.RecoverAddress(req.Message, req.Signature)
-			if recoverErr != nil {
-				logger.Error("WalletConnect Error: ", ErrSignatureRecovery, recoverErr)
-				return nil, fmt.Errorf("%w: %v", ErrSignatureRecovery, recoverErr)
-			}
-
-			if strings.ToLower(recoveredAddress) != normalizedAddress {
-				logger.Error("WalletConnect Error: ", ErrInvalidSignature)
-				return nil, fmt.Errorf("%w: signature does not match wallet address", ErrInvalidSignature)
-			}
-		}
+	// 关联钱包登录进入所属账户，令牌中记录实际签名的钱包
+	linkedAccount, err := s.resolveLinkedAccount(ctx, normalizedAddress)
+	if err != nil {
+		logger.Error("WalletConnect Error: ", errors.New("failed to resolve linked account"), "error: ", err)
+		return nil, err
+	}
+	if linkedAccount != nil {
+		return s.connectLinkedWallet(ctx, linkedAccount, normalizedAddress, siweMessage, isSafeWallet, req.Client)
 	}
 
 	// 4. 查找或创建用户
@@ -221,26 +282,98 @@ func (s *service) WalletConnect(ctx context.Context, req *types.WalletConnectReq
 		logger.Info("WalletConnect: found existing user", "wallet_address", normalizedAddress, "user_id", existingUser.ID, "is_safe", isSafeWallet)
 	}
 
-	// 5. 生成JWT令牌
-	accessToken, refreshToken, expiresAt, err := s.jwtManager.GenerateTokens(
-		currentUser.ID,
-		currentUser.WalletAddress,
-	)
+	// 5. 生成JWT令牌并创建会话（开启新的令牌族）
+	tokens, err := s.issueSession(ctx, currentUser, types.SessionBinding{
+		ChainID: siweMessage.ChainID,
+		Domain:  siweMessage.Domain,
+		Safe:    isSafeWallet,
+	}, "", req.Client)
 	if err != nil {
-		logger.Error("WalletConnect Error: ", errors.New("failed to generate jwt tokens"), "error: ", err)
-		return nil, fmt.Errorf("failed to generate jwt tokens: %w", err)
+		logger.Error("WalletConnect Error: ", errors.New("failed to issue session"), "error: ", err)
+		return nil, err
 	}
 
 	logger.Info("WalletConnect Response:", "User: ", currentUser.WalletAddress, "IsSafe:", isSafeWallet)
 	return &types.WalletConnectResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
 		User:         *currentUser,
 	}, nil
 }
 
+// connectLinkedWallet 使用关联钱包登录所属账户，不修改账户主钱包的Safe信息
+func (s *service) connectLinkedWallet(ctx context.Context, account *types.User, signer string, siweMessage *utils.SiweMessage, isSafeWallet bool, client types.SessionClient) (*types.WalletConnectResponse, error) {
+	if account.Status != 1 {
+		logger.Error("WalletConnect Error: ", errors.New("user account is disabled"), "user_id", account.ID)
+		return nil, errors.New("user account is disabled")
+	}
+
+	if err := s.userRepo.UpdateLastLogin(ctx, account.WalletAddress); err != nil {
+		logger.Error("WalletConnect Error: ", errors.New("failed to update last login"), "error: ", err)
+	}
+
+	tokens, err := s.issueSession(ctx, account, types.SessionBinding{
+		ChainID: siweMessage.ChainID,
+		Domain:  siweMessage.Domain,
+		Safe:    isSafeWallet,
+		Signer:  signer,
+	}, "", client)
+	if err != nil {
+		logger.Error("WalletConnect Error: ", errors.New("failed to issue session"), "error: ", err)
+		return nil, err
+	}
+
+	logger.Info("WalletConnect Response:", "User: ", account.WalletAddress, "Signer: ", signer, "IsSafe:", isSafeWallet)
+	return &types.WalletConnectResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		User:         *account,
+	}, nil
+}
+
+// verifyWalletSignature 验证钱包对消息的签名，Safe钱包返回Safe信息，EOA返回nil
+// Safe钱包通过EIP-1271或owner阈值签名验证，签名绑定到chainID所在链
+func (s *service) verifyWalletSignature(ctx context.Context, walletAddress, walletType string, chainID int, message, signature string) (*types.SafeInfo, error) {
+	if walletType == "safe" {
+		logger.Info("Verifying Safe wallet", "safe_address", walletAddress, "chain_id", chainID)
+
+		// 验证是否为Safe合约并获取Safe信息
+		safeInfo, err := s.getSafeInfo(ctx, walletAddress, chainID)
+		if err != nil {
+			logger.Error("Failed to verify Safe contract or get Safe info", err)
+			return nil, fmt.Errorf("address is not a valid Safe contract: %w", err)
+		}
+
+		// 验证Safe签名
+		if err := s.verifySafeSignature(ctx, walletAddress, chainID, message, signature); err != nil {
+			return nil, err
+		}
+
+		logger.Info("Safe wallet verified successfully", "safe_address", walletAddress, "threshold", safeInfo.Threshold, "owners_count", len(safeInfo.Owners))
+		return safeInfo, nil
+	}
+
+	// 普通EOA钱包验证签名
+	if err := crypto.VerifySignature(message, signature, walletAddress); err != nil {
+		// 尝试从签名中恢复地址进行二次验证
+		recoveredAddress, recoverErr := crypto.RecoverAddress(message, signature)
+		if recoverErr != nil {
+			logger.Error("verifyWalletSignature Error: ", ErrSignatureRecovery, recoverErr)
+			return nil, fmt.Errorf("%w: %v", ErrSignatureRecovery, recoverErr)
+		}
+
+		if strings.ToLower(recoveredAddress) != walletAddress {
+			logger.Error("verifyWalletSignature Error: ", ErrInvalidSignature)
+			return nil, fmt.Errorf("%w: signature does not match wallet address", ErrInvalidSignature)
+		}
+	}
+	return nil, nil
+}
+
 // RefreshToken 刷新访问令牌
+// 刷新令牌一次性使用：每次刷新轮换出新的刷新令牌，旧令牌再次使用时撤销整个令牌族
 func (s *service) RefreshToken(ctx context.Context, req *types.RefreshTokenRequest) (*types.WalletConnectResponse, error) {
 	// 1. 验证刷新令牌
 	claims, err := s.jwtManager.VerifyRefreshToken(req.RefreshToken)
@@ -249,7 +382,13 @@ func (s *service) RefreshToken(ctx context.Context, req *types.RefreshTokenReque
 		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
-	// 2. 获取用户信息
+	// 2. 校验会话并轮换刷新令牌（重放检测）
+	if _, err := s.consumeRefreshSession(ctx, claims); err != nil {
+		logger.Error("RefreshToken Error: ", errors.New("failed to consume refresh session"), "error: ", err)
+		return nil, err
+	}
+
+	// 3. 获取用户信息
 	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -259,23 +398,36 @@ func (s *service) RefreshToken(ctx context.Context, req *types.RefreshTokenReque
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	// 3. 验证用户状态
+	// 4. 验证用户状态
 	if user.Status != 1 {
 		logger.Error("RefreshToken Error: ", errors.New("user account is disabled"))
 		return nil, errors.New("user account is disabled")
 	}
 
-	// 4. 生成新的令牌对
-	accessToken, refreshToken, expiresAt, err := s.jwtManager.GenerateTokens(
-		user.ID,
-		user.WalletAddress,
-	)
+	// 5. Safe会话必须绑定链，关联钱包登录的会话要求签名钱包仍在账户下
+	if err := s.checkSigner(ctx, claims); err != nil {
+		logger.Error("RefreshToken Error: ", err, "user_id", claims.UserID, "signer", claims.Signer)
+		return nil, err
+	}
+	if user.IsSafeWallet && claims.Signer == "" && (!claims.Safe || claims.ChainID == 0) {
+		logger.Error("RefreshToken Error: ", errors.New("safe session is not bound to a chain"))
+		return nil, fmt.Errorf("%w: safe session is not bound to a chain, please sign in again", ErrInvalidToken)
+	}
+
+	// 6. 生成新的令牌对（保持登录时绑定的链、域名和令牌族）
+	tokens, err := s.issueSession(ctx, user, types.SessionBinding{
+		ChainID:   claims.ChainID,
+		Domain:    claims.Domain,
+		Safe:      claims.Safe,
+		Signer:    claims.Signer,
+		SessionID: claims.SessionID,
+	}, claims.ID, req.Client)
 	if err != nil {
-		logger.Error("RefreshToken Error: ", errors.New("failed to generate jwt tokens"), "error: ", err)
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+		logger.Error("RefreshToken Error: ", errors.New("failed to issue session"), "error: ", err)
+		return nil, err
 	}
 
-	// 5. 更新最后登录时间
+	// 7. 更新最后登录时间
 	if err := s.userRepo.UpdateLastLogin(ctx, user.WalletAddress); err != nil {
 		// 登录时间更新失败不应该阻止刷新流程
 		logger.Error("RefreshToken Error: ", errors.New("failed to update last login"), "error: ", err)
@@ -283,9 +435,9 @@ func (s *service) RefreshToken(ctx context.Context, req *types.RefreshTokenReque
 
 	logger.Info("RefreshToken Response:", "User: ", user.WalletAddress)
 	return &types.WalletConnectResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
 		User:         *user,
 	}, nil
 }
@@ -307,10 +459,17 @@ func (s *service) GetProfile(ctx context.Context, walletAddress string) (*types.
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
+	linkedWallets, err := s.userRepo.GetLinkedWalletsByUserID(ctx, user.ID)
+	if err != nil {
+		logger.Error("GetProfile Error: ", errors.New("failed to get linked wallets"), "error: ", err)
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
 	profile := &types.UserProfile{
 		WalletAddress: user.WalletAddress,
 		CreatedAt:     user.CreatedAt,
 		LastLogin:     user.LastLogin,
+		LinkedWallets: linkedWallets,
 	}
 
 	logger.Info("GetProfile: success", "user_id", user.ID, "wallet_address", user.WalletAddress)
@@ -326,6 +485,16 @@ func (s *service) VerifyToken(ctx context.Context, tokenString string) (*types.J
 		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
+	// 令牌必须绑定会话，且未被登出或撤销
+	if claims.ID == "" || claims.SessionID == "" {
+		logger.Error("VerifyToken Error: ", errors.New("token is not bound to a session"))
+		return nil, fmt.Errorf("%w: token is not bound to a session, please sign in again", ErrInvalidToken)
+	}
+	if err := s.checkDenylist(ctx, claims); err != nil {
+		logger.Error("VerifyToken Error: ", err, "user_id", claims.UserID, "sid", claims.SessionID)
+		return nil, err
+	}
+
 	// 验证用户是否存在且有效
 	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
 	if err != nil {
@@ -341,9 +510,26 @@ func (s *service) VerifyToken(ctx context.Context, tokenString string) (*types.J
 		return nil, errors.New("user account is disabled")
 	}
 
+	// 关联钱包登录的会话在钱包解除关联后失效
+	if err := s.checkSigner(ctx, claims); err != nil {
+		logger.Error("VerifyToken Error: ", err, "user_id", claims.UserID, "signer", claims.Signer)
+		return nil, err
+	}
+
+	// Safe会话必须绑定到验证签名时的链，未绑定的旧令牌需重新登录（关联钱包登录时按签名钱包类型绑定）
+	if user.IsSafeWallet && claims.Signer == "" && (!claims.Safe || claims.ChainID == 0) {
+		logger.Error("VerifyToken Error: ", errors.New("safe session is not bound to a chain"))
+		return nil, fmt.Errorf("%w: safe session is not bound to a chain", ErrInvalidToken)
+	}
+
 	return claims, nil
 }
 
+// GetJWKS 获取令牌验证公钥集合，供其他服务验证本服务签发的令牌
+func (s *service) GetJWKS() *types.JWKSet {
+	return s.jwtManager.JWKS()
+}
+
 // getSafeInfo 获取Safe信息（从数据库或链上）
 func (s *service) getSafeInfo(ctx context.Context, safeAddress string, chainID int) (*types.SafeInfo, error) {
 	logger.Info("getSafeInfo", "safe_address", safeAddress, "chain_id", chainID)
@@ -375,7 +561,7 @@ func (s *service) getSafeInfo(ctx context.Context, safeAddress string, chainID i
 	logger.Info("Fetching Safe info from blockchain", "safe_address", normalizedAddress, "chain_id", chainID)
 	var safeInfo *types.SafeInfo
 
-	err = s.rpcManager.ExecuteWithRetry(ctx, chainID, func(client *ethclient.Client) error {
+	err = s.rpcManager.ExecuteWithRetry(ctx, chainID, func(ctx context.Context, client *ethclient.Client) error {
 		info, err := s.getSafeInfoFromContract(ctx, client, normalizedAddress, chainID)
 		if err != nil {
 			return err
@@ -536,8 +722,8 @@ func (s *service) syncSafeInfoToDB(ctx context.Context, safeInfo *types.SafeInfo
 	return s.safeRepo.CreateOrUpdateSafe(ctx, safeWallet)
 }
 
-// validateAndUseNonce 验证并使用nonce
-func (s *service) validateAndUseNonce(ctx context.Context, walletAddress string, nonce string, message string) error {
+// validateAndUseNonce 验证并使用nonce，要求nonce用途与purpose一致，chainID不为0时要求nonce是针对该链获取的
+func (s *service) validateAndUseNonce(ctx context.Context, walletAddress string, nonce string, message string, chainID int, purpose string) error {
 	// 从数据库获取nonce
 	authNonce, err := s.userRepo.GetAuthNonce(ctx, walletAddress, nonce)
 	if err != nil {
@@ -562,10 +748,24 @@ func (s *service) validateAndUseNonce(ctx context.Context, walletAddress string,
 		return fmt.Errorf("message does not match stored nonce message")
 	}
 
-	// 标记nonce为已使用
-	if err := s.userRepo.MarkNonceAsUsed(ctx, authNonce.ID); err != nil {
+	// 验证nonce用途，关联钱包的签名消息不能用于登录，反之亦然
+	if authNonce.Purpose != purpose {
+		return ErrInvalidNonce
+	}
+
+	// 验证签名消息绑定的链
+	if chainID != 0 && authNonce.ChainID != chainID {
+		return ErrChainMismatch
+	}
+
+	// 标记nonce为已使用，并发请求中只有标记成功的一方可以继续
+	marked, err := s.userRepo.MarkNonceAsUsed(ctx, authNonce.ID)
+	if err != nil {
 		logger.Error("Failed to mark nonce as used", err)
-		// 继续流程，但记录错误
+		return ErrNonceUsed
+	}
+	if !marked {
+		return ErrNonceUsed
 	}
 
 	return nil
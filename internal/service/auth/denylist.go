@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"timelocker-backend/internal/repository/session"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenDenylist 访问令牌黑名单
+// 条目为访问令牌的jti或令牌族ID（sid），过期时间不早于相关访问令牌的过期时间
+type TokenDenylist interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// databaseDenylist 基于数据库 revoked_tokens 表的黑名单
+type databaseDenylist struct {
+	sessionRepo session.Repository
+}
+
+// NewDatabaseDenylist 创建数据库存储的访问令牌黑名单
+func NewDatabaseDenylist(sessionRepo session.Repository) TokenDenylist {
+	return &databaseDenylist{sessionRepo: sessionRepo}
+}
+
+func (d *databaseDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return d.sessionRepo.AddRevokedToken(ctx, tokenID, expiresAt)
+}
+
+func (d *databaseDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	return d.sessionRepo.IsTokenRevoked(ctx, tokenID)
+}
+
+// redisDenylistPrefix Redis黑名单键前缀
+const redisDenylistPrefix = "timelocker:auth:denylist:"
+
+// redisDenylist 基于Redis的黑名单，条目随TTL自动过期
+type redisDenylist struct {
+	client *redis.Client
+}
+
+// NewRedisDenylist 创建Redis存储的访问令牌黑名单
+func NewRedisDenylist(client *redis.Client) TokenDenylist {
+	return &redisDenylist{client: client}
+}
+
+func (d *redisDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	key := redisDenylistPrefix + tokenID
+
+	// 已存在更长的TTL时不缩短
+	if current, err := d.client.TTL(ctx, key).Result(); err == nil && current > ttl {
+		return nil
+	}
+	if err := d.client.Set(ctx, key, "1", ttl).Err(); err != nil {
+		return fmt.Errorf("failed to add token to redis denylist: %w", err)
+	}
+	return nil
+}
+
+func (d *redisDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	count, err := d.client.Exists(ctx, redisDenylistPrefix+tokenID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check redis denylist: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWalletAlreadyLinked    = errors.New("wallet is already linked to an account")
+	ErrCannotLinkPrimary      = errors.New("cannot link or unlink the account's primary wallet")
+	ErrWalletHasLinkedWallets = errors.New("wallet is the primary wallet of an account with linked wallets")
+	ErrAccountIsLinked        = errors.New("current account is linked to another account")
+	ErrLinkedWalletLimit      = errors.New("linked wallet limit reached")
+	ErrLinkedWalletNotFound   = errors.New("linked wallet not found")
+)
+
+// linkWalletStatement 关联钱包签名消息中的声明，与登录声明不同，防止登录签名被用于关联
+func linkWalletStatement(primaryWallet string) string {
+	return "Link this wallet to TimeLocker account " + common.HexToAddress(primaryWallet).Hex()
+}
+
+// GetLinkWalletNonce 获取关联钱包的签名消息，消息由待关联钱包签名
+func (s *service) GetLinkWalletNonce(ctx context.Context, userID int64, req *types.LinkWalletNonceRequest) (*types.GetNonceResponse, error) {
+	logger.Info("GetLinkWalletNonce", "user_id", userID, "wallet_address", req.WalletAddress, "chain_id", req.ChainID)
+
+	account, err := s.getLinkableAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !crypto.ValidateEthereumAddress(req.WalletAddress) {
+		return nil, ErrInvalidAddress
+	}
+	normalizedAddress := crypto.NormalizeAddress(req.WalletAddress)
+	if err := s.checkWalletLinkable(ctx, account, normalizedAddress); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSiweChain(ctx, req.ChainID); err != nil {
+		return nil, err
+	}
+	domain := req.Domain
+	if domain == "" && len(s.siweConfig.Domains) > 0 {
+		domain = s.siweConfig.Domains[0]
+	}
+	uri := req.URI
+	if uri == "" {
+		uri = "https://" + domain
+	}
+	if err := s.validateSiweDomain(domain, uri); err != nil {
+		return nil, err
+	}
+
+	nonce := crypto.GenerateNonce()
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+	expiresAt := issuedAt.Add(s.siweConfig.NonceExpiry)
+	siweMessage := &utils.SiweMessage{
+		Domain:         domain,
+		Address:        common.HexToAddress(normalizedAddress).Hex(),
+		Statement:      linkWalletStatement(account.WalletAddress),
+		URI:            uri,
+		Version:        "1",
+		ChainID:        req.ChainID,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiresAt,
+	}
+	message := siweMessage.String()
+
+	if err := s.cleanupAllNonces(ctx, normalizedAddress); err != nil {
+		logger.Error("Failed to cleanup existing nonces", err)
+	}
+
+	authNonce := &types.AuthNonce{
+		WalletAddress: normalizedAddress,
+		Nonce:         nonce,
+		Message:       message,
+		ExpiresAt:     expiresAt,
+		ChainID:       req.ChainID,
+		Purpose:       types.AuthNoncePurposeLink,
+	}
+	if err := s.userRepo.CreateAuthNonce(ctx, authNonce); err != nil {
+		logger.Error("Failed to create auth nonce", err)
+		return nil, fmt.Errorf("failed to create auth nonce: %w", err)
+	}
+
+	return &types.GetNonceResponse{
+		Message:   message,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// LinkWallet 校验待关联钱包的签名（EOA或EIP-1271）后将其关联到当前账户
+// 被关联钱包原有的用户数据（邮箱、通知配置等）不会合并，关联后使用该钱包登录将进入当前账户
+func (s *service) LinkWallet(ctx context.Context, userID int64, req *types.LinkWalletRequest) (*types.LinkedWallet, error) {
+	logger.Info("LinkWallet", "user_id", userID, "wallet_address", req.WalletAddress, "wallet_type", req.WalletType)
+
+	account, err := s.getLinkableAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !crypto.ValidateEthereumAddress(req.WalletAddress) {
+		return nil, ErrInvalidAddress
+	}
+	normalizedAddress := crypto.NormalizeAddress(req.WalletAddress)
+	if err := s.checkWalletLinkable(ctx, account, normalizedAddress); err != nil {
+		return nil, err
+	}
+
+	// 1. 校验签名消息：必须是针对当前账户生成的关联消息
+	siweMessage, err := s.validateSiweMessage(ctx, normalizedAddress, req.Message, req.Nonce, 0)
+	if err != nil {
+		logger.Error("LinkWallet SIWE validation failed", err)
+		return nil, err
+	}
+	if siweMessage.Statement != linkWalletStatement(account.WalletAddress) {
+		logger.Error("LinkWallet Error: ", ErrInvalidSiwe, "statement", siweMessage.Statement)
+		return nil, fmt.Errorf("%w: message is not a wallet link request for this account", ErrInvalidSiwe)
+	}
+	if err := s.validateAndUseNonce(ctx, normalizedAddress, req.Nonce, req.Message, siweMessage.ChainID, types.AuthNoncePurposeLink); err != nil {
+		logger.Error("LinkWallet nonce validation failed", err)
+		return nil, err
+	}
+
+	// 2. 验证待关联钱包的签名
+	walletType := req.WalletType
+	if walletType == "" {
+		walletType = "eoa"
+	}
+	if _, err := s.verifyWalletSignature(ctx, normalizedAddress, walletType, siweMessage.ChainID, req.Message, req.Signature); err != nil {
+		logger.Error("LinkWallet signature verification failed", err)
+		return nil, err
+	}
+
+	// 3. 保存关联
+	linked := &types.LinkedWallet{
+		UserID:        account.ID,
+		WalletAddress: normalizedAddress,
+		WalletType:    walletType,
+		ChainID:       siweMessage.ChainID,
+		Label:         req.Label,
+	}
+	if err := s.userRepo.CreateLinkedWallet(ctx, linked); err != nil {
+		logger.Error("LinkWallet Error: ", errors.New("failed to create linked wallet"), "error: ", err)
+		return nil, fmt.Errorf("failed to create linked wallet: %w", err)
+	}
+
+	s.auditor.Record(ctx, types.AuditActionLinkedWalletLink, types.AuditResourceLinkedWallet, normalizedAddress, 0, nil, linked)
+	logger.Info("LinkWallet: linked wallet", "user_id", account.ID, "wallet_address", normalizedAddress, "wallet_type", walletType)
+	return linked, nil
+}
+
+// UnlinkWallet 解除关联钱包，使用该钱包签发的会话随之失效
+func (s *service) UnlinkWallet(ctx context.Context, userID int64, req *types.UnlinkWalletRequest) error {
+	logger.Info("UnlinkWallet", "user_id", userID, "wallet_address", req.WalletAddress)
+
+	account, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	normalizedAddress := crypto.NormalizeAddress(req.WalletAddress)
+	if normalizedAddress == crypto.NormalizeAddress(account.WalletAddress) {
+		return ErrCannotLinkPrimary
+	}
+
+	linked, err := s.userRepo.GetLinkedWalletByAddress(ctx, normalizedAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLinkedWalletNotFound
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	if linked.UserID != account.ID {
+		return ErrLinkedWalletNotFound
+	}
+
+	if _, err := s.userRepo.DeleteLinkedWallet(ctx, account.ID, normalizedAddress); err != nil {
+		return fmt.Errorf("failed to delete linked wallet: %w", err)
+	}
+
+	s.auditor.Record(ctx, types.AuditActionLinkedWalletUnlink, types.AuditResourceLinkedWallet, normalizedAddress, 0, linked, nil)
+	logger.Info("UnlinkWallet: unlinked wallet", "user_id", account.ID, "wallet_address", normalizedAddress)
+	return nil
+}
+
+// GetLinkedWallets 获取账户的主钱包和关联钱包
+func (s *service) GetLinkedWallets(ctx context.Context, userID int64) (*types.LinkedWalletListResponse, error) {
+	account, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	wallets, err := s.userRepo.GetLinkedWalletsByUserID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked wallets: %w", err)
+	}
+
+	return &types.LinkedWalletListResponse{
+		PrimaryWallet: account.WalletAddress,
+		Wallets:       wallets,
+	}, nil
+}
+
+// getLinkableAccount 获取可以关联新钱包的账户
+// 账户自身已被关联到其他账户时（关联前签发的旧会话）不允许继续关联，避免形成多级关联
+func (s *service) getLinkableAccount(ctx context.Context, userID int64) (*types.User, error) {
+	account, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if _, err := s.userRepo.GetLinkedWalletByAddress(ctx, account.WalletAddress); err == nil {
+		return nil, ErrAccountIsLinked
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	count, err := s.userRepo.CountLinkedWallets(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if count >= types.MaxLinkedWallets {
+		return nil, fmt.Errorf("%w: at most %d wallets", ErrLinkedWalletLimit, types.MaxLinkedWallets)
+	}
+	return account, nil
+}
+
+// checkWalletLinkable 检查钱包可以关联到账户：不是主钱包、未被关联、自身没有关联钱包
+func (s *service) checkWalletLinkable(ctx context.Context, account *types.User, walletAddress string) error {
+	if walletAddress == crypto.NormalizeAddress(account.WalletAddress) {
+		return ErrCannotLinkPrimary
+	}
+
+	if _, err := s.userRepo.GetLinkedWalletByAddress(ctx, walletAddress); err == nil {
+		return ErrWalletAlreadyLinked
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	existing, err := s.userRepo.GetUserByWallet(ctx, walletAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	count, err := s.userRepo.CountLinkedWallets(ctx, existing.ID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if count > 0 {
+		return ErrWalletHasLinkedWallets
+	}
+	return nil
+}
+
+// resolveLinkedAccount 查找签名钱包关联的账户，未关联时返回nil
+func (s *service) resolveLinkedAccount(ctx context.Context, walletAddress string) (*types.User, error) {
+	linked, err := s.userRepo.GetLinkedWalletByAddress(ctx, walletAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	account, err := s.userRepo.GetUserByID(ctx, linked.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return account, nil
+}
+
+// checkSigner 校验关联钱包登录的会话：签名钱包必须仍关联在令牌所属账户下
+func (s *service) checkSigner(ctx context.Context, claims *types.JWTClaims) error {
+	if claims.Signer == "" {
+		return nil
+	}
+	linked, err := s.userRepo.GetLinkedWalletByAddress(ctx, claims.Signer)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: signer wallet has been unlinked", ErrTokenRevoked)
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	if linked.UserID != claims.UserID {
+		return fmt.Errorf("%w: signer wallet has been unlinked", ErrTokenRevoked)
+	}
+	return nil
+}
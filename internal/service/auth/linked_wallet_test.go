@@ -0,0 +1,480 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/utils"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+// stubAccountRepo 在stubUserRepo基础上实现nonce和关联钱包的内存仓库
+type stubAccountRepo struct {
+	*stubUserRepo
+	nonces map[string]*types.AuthNonce // 按钱包地址和nonce索引
+	linked map[string]*types.LinkedWallet
+	nextID int64
+}
+
+func newStubAccountRepo(users ...*types.User) *stubAccountRepo {
+	repo := &stubAccountRepo{
+		stubUserRepo: &stubUserRepo{users: map[int64]*types.User{}},
+		nonces:       map[string]*types.AuthNonce{},
+		linked:       map[string]*types.LinkedWallet{},
+	}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (r *stubAccountRepo) GetUserByWallet(ctx context.Context, walletAddress string) (*types.User, error) {
+	for _, user := range r.users {
+		if user.WalletAddress == walletAddress {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubAccountRepo) CreateAuthNonce(ctx context.Context, nonce *types.AuthNonce) error {
+	r.nextID++
+	nonce.ID = r.nextID
+	copied := *nonce
+	r.nonces[nonce.WalletAddress+"/"+nonce.Nonce] = &copied
+	return nil
+}
+
+func (r *stubAccountRepo) GetAuthNonce(ctx context.Context, walletAddress string, nonce string) (*types.AuthNonce, error) {
+	if authNonce, ok := r.nonces[walletAddress+"/"+nonce]; ok {
+		copied := *authNonce
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubAccountRepo) MarkNonceAsUsed(ctx context.Context, nonceID int64) (bool, error) {
+	for _, authNonce := range r.nonces {
+		if authNonce.ID == nonceID && !authNonce.IsUsed {
+			authNonce.IsUsed = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *stubAccountRepo) DeleteAllNonces(ctx context.Context, walletAddress string) error {
+	for key, authNonce := range r.nonces {
+		if authNonce.WalletAddress == walletAddress {
+			delete(r.nonces, key)
+		}
+	}
+	return nil
+}
+
+func (r *stubAccountRepo) CreateLinkedWallet(ctx context.Context, wallet *types.LinkedWallet) error {
+	copied := *wallet
+	r.linked[wallet.WalletAddress] = &copied
+	return nil
+}
+
+func (r *stubAccountRepo) GetLinkedWalletByAddress(ctx context.Context, walletAddress string) (*types.LinkedWallet, error) {
+	if wallet, ok := r.linked[walletAddress]; ok {
+		copied := *wallet
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubAccountRepo) CountLinkedWallets(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	for _, wallet := range r.linked {
+		if wallet.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *stubAccountRepo) DeleteLinkedWallet(ctx context.Context, userID int64, walletAddress string) (bool, error) {
+	if wallet, ok := r.linked[walletAddress]; ok && wallet.UserID == userID {
+		delete(r.linked, walletAddress)
+		return true, nil
+	}
+	return false, nil
+}
+
+// nopRecorder 丢弃审计事件
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action, resourceType, resourceID string, organizationID int64, before, after interface{}) {
+}
+
+// testWallet 测试用EOA钱包
+type testWallet struct {
+	key     *ecdsa.PrivateKey
+	address string // 小写地址
+}
+
+func newTestWallet(t *testing.T) testWallet {
+	t.Helper()
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return testWallet{key: key, address: crypto.NormalizeAddress(ethcrypto.PubkeyToAddress(key.PublicKey).Hex())}
+}
+
+// sign 生成personal_sign签名
+func (w testWallet) sign(t *testing.T, message string) string {
+	t.Helper()
+	sig, err := ethcrypto.Sign(accounts.TextHash([]byte(message)), w.key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	sig[64] += 27
+	return hexutil.Encode(sig)
+}
+
+// linkHarness 两个账户和两个待关联钱包的测试环境
+type linkHarness struct {
+	t        *testing.T
+	s        *service
+	repo     *stubAccountRepo
+	account  *types.User
+	other    *types.User
+	primary  testWallet // account的主钱包
+	wallet   testWallet // 待关联的钱包
+	stranger testWallet // 无关钱包
+}
+
+func newLinkHarness(t *testing.T) *linkHarness {
+	primary, otherPrimary := newTestWallet(t), newTestWallet(t)
+	account := &types.User{ID: 1, WalletAddress: primary.address, Status: 1}
+	other := &types.User{ID: 2, WalletAddress: otherPrimary.address, Status: 1}
+	repo := newStubAccountRepo(account, other)
+	return &linkHarness{
+		t:    t,
+		repo: repo,
+		s: &service{
+			userRepo: repo,
+			chainRepo: &stubChainRepo{chains: map[int64]*types.SupportChain{
+				1: {ChainID: 1, IsActive: true},
+			}},
+			siweConfig: &config.SIWEConfig{
+				Domains:     []string{"app.timelocker.io"},
+				Statement:   "Sign in to TimeLocker",
+				NonceExpiry: 5 * time.Minute,
+				ClockSkew:   time.Minute,
+			},
+			sessionRepo: newStubSessionRepo(),
+			jwtManager:  newTestJWTManager(t),
+			auditor:     nopRecorder{},
+		},
+		account:  account,
+		other:    other,
+		primary:  primary,
+		wallet:   newTestWallet(t),
+		stranger: newTestWallet(t),
+	}
+}
+
+// linkNonce 以账户身份获取关联钱包的签名消息
+func (h *linkHarness) linkNonce(account *types.User, wallet string) (*types.GetNonceResponse, error) {
+	return h.s.GetLinkWalletNonce(context.Background(), account.ID, &types.LinkWalletNonceRequest{WalletAddress: wallet, ChainID: 1})
+}
+
+func (h *linkHarness) mustLinkNonce(account *types.User, wallet string) *types.GetNonceResponse {
+	h.t.Helper()
+	resp, err := h.linkNonce(account, wallet)
+	if err != nil {
+		h.t.Fatalf("get link nonce: %v", err)
+	}
+	return resp
+}
+
+// link 提交签名证明，将钱包关联到account
+func (h *linkHarness) link(account *types.User, nonce *types.GetNonceResponse, signer testWallet) error {
+	_, err := h.s.LinkWallet(context.Background(), account.ID, &types.LinkWalletRequest{
+		WalletAddress: h.wallet.address,
+		Message:       nonce.Message,
+		Nonce:         nonce.Nonce,
+		Signature:     signer.sign(h.t, nonce.Message),
+	})
+	return err
+}
+
+func TestLinkWalletProof(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(h *linkHarness) error
+		wantErr    error
+		wantLinked bool
+	}{
+		{
+			name: "signed by the wallet",
+			run: func(h *linkHarness) error {
+				return h.link(h.account, h.mustLinkNonce(h.account, h.wallet.address), h.wallet)
+			},
+			wantLinked: true,
+		},
+		{
+			name: "checksum address in request",
+			run: func(h *linkHarness) error {
+				return h.link(h.account, h.mustLinkNonce(h.account, common.HexToAddress(h.wallet.address).Hex()), h.wallet)
+			},
+			wantLinked: true,
+		},
+		{
+			name: "signed by another wallet",
+			run: func(h *linkHarness) error {
+				return h.link(h.account, h.mustLinkNonce(h.account, h.wallet.address), h.stranger)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "signed by the account's primary wallet",
+			run: func(h *linkHarness) error {
+				return h.link(h.account, h.mustLinkNonce(h.account, h.wallet.address), h.primary)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "link message for another account",
+			run: func(h *linkHarness) error {
+				return h.link(h.account, h.mustLinkNonce(h.other, h.wallet.address), h.wallet)
+			},
+			wantErr: ErrInvalidSiwe,
+		},
+		{
+			name: "login message",
+			run: func(h *linkHarness) error {
+				nonce, err := h.s.GetNonce(context.Background(), &types.GetNonceRequest{WalletAddress: h.wallet.address, ChainID: 1})
+				if err != nil {
+					h.t.Fatalf("get nonce: %v", err)
+				}
+				return h.link(h.account, nonce, h.wallet)
+			},
+			wantErr: ErrInvalidSiwe,
+		},
+		{
+			name: "nonce not issued",
+			run: func(h *linkHarness) error {
+				issuedAt := time.Now().UTC().Truncate(time.Second)
+				expiresAt := issuedAt.Add(5 * time.Minute)
+				message := (&utils.SiweMessage{
+					Domain:         "app.timelocker.io",
+					Address:        common.HexToAddress(h.wallet.address).Hex(),
+					Statement:      linkWalletStatement(h.account.WalletAddress),
+					URI:            "https://app.timelocker.io",
+					Version:        "1",
+					ChainID:        1,
+					Nonce:          "a1b2c3d4e5f6",
+					IssuedAt:       issuedAt,
+					ExpirationTime: &expiresAt,
+				}).String()
+				return h.link(h.account, &types.GetNonceResponse{Message: message, Nonce: "a1b2c3d4e5f6"}, h.wallet)
+			},
+			wantErr: ErrInvalidNonce,
+		},
+		{
+			name: "proof replayed after unlink",
+			run: func(h *linkHarness) error {
+				nonce := h.mustLinkNonce(h.account, h.wallet.address)
+				if err := h.link(h.account, nonce, h.wallet); err != nil {
+					h.t.Fatalf("link: %v", err)
+				}
+				if err := h.s.UnlinkWallet(context.Background(), h.account.ID, &types.UnlinkWalletRequest{WalletAddress: h.wallet.address}); err != nil {
+					h.t.Fatalf("unlink: %v", err)
+				}
+				return h.link(h.account, nonce, h.wallet)
+			},
+			wantErr: ErrNonceUsed,
+		},
+		{
+			name: "primary wallet",
+			run: func(h *linkHarness) error {
+				_, err := h.linkNonce(h.account, h.account.WalletAddress)
+				return err
+			},
+			wantErr: ErrCannotLinkPrimary,
+		},
+		{
+			name: "wallet linked to another account",
+			run: func(h *linkHarness) error {
+				h.repo.linked[h.wallet.address] = &types.LinkedWallet{UserID: h.other.ID, WalletAddress: h.wallet.address}
+				_, err := h.linkNonce(h.account, h.wallet.address)
+				return err
+			},
+			wantErr: ErrWalletAlreadyLinked,
+		},
+		{
+			name: "account is itself linked",
+			run: func(h *linkHarness) error {
+				h.repo.linked[h.account.WalletAddress] = &types.LinkedWallet{UserID: h.other.ID, WalletAddress: h.account.WalletAddress}
+				_, err := h.linkNonce(h.account, h.wallet.address)
+				return err
+			},
+			wantErr: ErrAccountIsLinked,
+		},
+		{
+			name: "wallet is primary of an account with linked wallets",
+			run: func(h *linkHarness) error {
+				h.repo.linked[h.stranger.address] = &types.LinkedWallet{UserID: h.other.ID, WalletAddress: h.stranger.address}
+				_, err := h.linkNonce(h.account, h.other.WalletAddress)
+				return err
+			},
+			wantErr: ErrWalletHasLinkedWallets,
+		},
+		{
+			name: "linked wallet limit",
+			run: func(h *linkHarness) error {
+				for i := 0; i < types.MaxLinkedWallets; i++ {
+					address := fmt.Sprintf("0x%040x", i+1)
+					h.repo.linked[address] = &types.LinkedWallet{UserID: h.account.ID, WalletAddress: address}
+				}
+				_, err := h.linkNonce(h.account, h.wallet.address)
+				return err
+			},
+			wantErr: ErrLinkedWalletLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newLinkHarness(t)
+			err := tt.run(h)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wallet, ok := h.repo.linked[h.wallet.address]
+			if got := ok && wallet.UserID == h.account.ID; got != tt.wantLinked {
+				t.Fatalf("wallet linked to account = %v, want %v", got, tt.wantLinked)
+			}
+		})
+	}
+}
+
+// racingNonceRepo 读取nonce后由另一个请求抢先标记为已使用
+type racingNonceRepo struct {
+	*stubAccountRepo
+}
+
+func (r *racingNonceRepo) GetAuthNonce(ctx context.Context, walletAddress string, nonce string) (*types.AuthNonce, error) {
+	authNonce, err := r.stubAccountRepo.GetAuthNonce(ctx, walletAddress, nonce)
+	if err == nil {
+		_, _ = r.stubAccountRepo.MarkNonceAsUsed(ctx, authNonce.ID)
+	}
+	return authNonce, err
+}
+
+// connect 使用签名消息登录
+func (h *linkHarness) connect(wallet testWallet, nonce *types.GetNonceResponse) error {
+	_, err := h.s.WalletConnect(context.Background(), &types.WalletConnectRequest{
+		WalletAddress: wallet.address,
+		Message:       nonce.Message,
+		Nonce:         nonce.Nonce,
+		Signature:     wallet.sign(h.t, nonce.Message),
+		ChainID:       1,
+	})
+	return err
+}
+
+func TestWalletConnectNonce(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(h *linkHarness) error
+		wantErr error
+	}{
+		{
+			name: "login message",
+			run: func(h *linkHarness) error {
+				nonce, err := h.s.GetNonce(context.Background(), &types.GetNonceRequest{WalletAddress: h.primary.address, ChainID: 1})
+				if err != nil {
+					h.t.Fatalf("get nonce: %v", err)
+				}
+				return h.connect(h.primary, nonce)
+			},
+		},
+		{
+			name: "link message signed for another account",
+			run: func(h *linkHarness) error {
+				return h.connect(h.primary, h.mustLinkNonce(h.other, h.primary.address))
+			},
+			wantErr: ErrInvalidSiwe,
+		},
+		{
+			name: "link nonce carrying the login statement",
+			run: func(h *linkHarness) error {
+				issuedAt := time.Now().UTC().Truncate(time.Second)
+				expiresAt := issuedAt.Add(5 * time.Minute)
+				message := (&utils.SiweMessage{
+					Domain:         "app.timelocker.io",
+					Address:        common.HexToAddress(h.primary.address).Hex(),
+					Statement:      h.s.siweConfig.Statement,
+					URI:            "https://app.timelocker.io",
+					Version:        "1",
+					ChainID:        1,
+					Nonce:          "a1b2c3d4e5f6",
+					IssuedAt:       issuedAt,
+					ExpirationTime: &expiresAt,
+				}).String()
+				_ = h.repo.CreateAuthNonce(context.Background(), &types.AuthNonce{
+					WalletAddress: h.primary.address,
+					Nonce:         "a1b2c3d4e5f6",
+					Message:       message,
+					ExpiresAt:     expiresAt,
+					ChainID:       1,
+					Purpose:       types.AuthNoncePurposeLink,
+				})
+				return h.connect(h.primary, &types.GetNonceResponse{Message: message, Nonce: "a1b2c3d4e5f6"})
+			},
+			wantErr: ErrInvalidNonce,
+		},
+		{
+			name: "nonce claimed by a concurrent request",
+			run: func(h *linkHarness) error {
+				nonce, err := h.s.GetNonce(context.Background(), &types.GetNonceRequest{WalletAddress: h.primary.address, ChainID: 1})
+				if err != nil {
+					h.t.Fatalf("get nonce: %v", err)
+				}
+				h.s.userRepo = &racingNonceRepo{h.repo}
+				return h.connect(h.primary, nonce)
+			},
+			wantErr: ErrNonceUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newLinkHarness(t))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// safeSignatureABI Safe签名校验所需的合约方法
+const safeSignatureABI = `[
+	{"constant":true,"inputs":[{"name":"_dataHash","type":"bytes32"},{"name":"_signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"","type":"bytes4"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"domainSeparator","outputs":[{"name":"","type":"bytes32"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"getThreshold","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"getOwners","outputs":[{"name":"","type":"address[]"}],"type":"function"}
+]`
+
+var parsedSafeSignatureABI = mustParseABI(safeSignatureABI)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// verifySafeSignature 验证Safe钱包对登录消息的签名
+// 优先通过Safe合约的EIP-1271 isValidSignature校验（支持链上signMessage的空签名），
+// 合约不支持或校验未通过时，按当前链上的owner和阈值对拼接的owner签名进行离线聚合校验
+func (s *service) verifySafeSignature(ctx context.Context, safeAddress string, chainID int, message, signature string) error {
+	signatureBytes, err := hexutil.Decode(signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature hex", ErrInvalidSignature)
+	}
+
+	messageHash := common.BytesToHash(accounts.TextHash([]byte(message)))
+	contractAddr := common.HexToAddress(safeAddress)
+
+	var verified bool
+	var verifyErr error
+	err = s.rpcManager.ExecuteWithRetry(ctx, chainID, func(ctx context.Context, client *ethclient.Client) error {
+		verified, verifyErr = false, nil
+
+		// EIP-1271 合约校验
+		valid, callErr := callSafeIsValidSignature(ctx, client, contractAddr, messageHash, signatureBytes)
+		if callErr == nil && valid {
+			verified = true
+			return nil
+		}
+		if callErr != nil {
+			logger.Info("Safe isValidSignature call failed, falling back to owner signatures", "safe_address", safeAddress, "chain_id", chainID, "error", callErr)
+		}
+		if len(signatureBytes) == 0 {
+			verifyErr = fmt.Errorf("%w: message is not signed by the Safe", ErrInvalidSignature)
+			return nil
+		}
+
+		// owner阈值签名离线校验，签名错误不重试
+		valid, callErr = verifySafeOwnerSignatures(ctx, client, contractAddr, messageHash, signatureBytes)
+		if callErr != nil {
+			if errors.Is(callErr, ErrInvalidSignature) {
+				verifyErr = callErr
+				return nil
+			}
+			return callErr
+		}
+		verified = valid
+		return nil
+	})
+	if err != nil {
+		logger.Error("verifySafeSignature error", err, "safe_address", safeAddress, "chain_id", chainID)
+		return fmt.Errorf("failed to verify Safe signature on chain %d: %w", chainID, err)
+	}
+	if verifyErr != nil {
+		return verifyErr
+	}
+	if !verified {
+		return fmt.Errorf("%w: Safe signature threshold not met", ErrInvalidSignature)
+	}
+
+	logger.Info("Safe signature verified", "safe_address", safeAddress, "chain_id", chainID)
+	return nil
+}
+
+// callSafeIsValidSignature 调用Safe合约的 isValidSignature(bytes32,bytes)
+func callSafeIsValidSignature(ctx context.Context, client *ethclient.Client, safe common.Address, messageHash common.Hash, signature []byte) (bool, error) {
+	data, err := parsedSafeSignatureABI.Pack("isValidSignature", messageHash, signature)
+	if err != nil {
+		return false, err
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &safe, Data: data}, nil)
+	if err != nil {
+		return false, err
+	}
+	// 返回值为ABI编码的bytes4（左对齐的32字节）
+	if len(result) < 4 {
+		return false, nil
+	}
+	return bytes.Equal(result[:4], crypto.EIP1271MagicValue[:]), nil
+}
+
+// verifySafeOwnerSignatures 按链上owner和阈值校验拼接的owner签名
+// 签名本身无效时返回包装了 ErrInvalidSignature 的错误，其余错误为RPC调用错误
+func verifySafeOwnerSignatures(ctx context.Context, client *ethclient.Client, safe common.Address, messageHash common.Hash, signature []byte) (bool, error) {
+	var domainSeparator [32]byte
+	if err := callSafeView(ctx, client, safe, "domainSeparator", &domainSeparator); err != nil {
+		return false, err
+	}
+	var threshold *big.Int
+	if err := callSafeView(ctx, client, safe, "getThreshold", &threshold); err != nil {
+		return false, err
+	}
+	var owners []common.Address
+	if err := callSafeView(ctx, client, safe, "getOwners", &owners); err != nil {
+		return false, err
+	}
+
+	safeHash := crypto.SafeMessageHash(common.Hash(domainSeparator), messageHash)
+	signers, err := crypto.RecoverSafeSigners(safeHash, signature)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	ownerSet := make(map[common.Address]bool, len(owners))
+	for _, owner := range owners {
+		ownerSet[owner] = true
+	}
+	for _, signer := range signers {
+		if !ownerSet[signer] {
+			return false, fmt.Errorf("%w: signer %s is not a Safe owner", ErrInvalidSignature, signer.Hex())
+		}
+	}
+
+	if threshold == nil || threshold.Sign() <= 0 || big.NewInt(int64(len(signers))).Cmp(threshold) < 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// callSafeView 调用Safe合约的无参只读方法
+func callSafeView(ctx context.Context, client *ethclient.Client, safe common.Address, method string, out interface{}) error {
+	data, err := parsedSafeSignatureABI.Pack(method)
+	if err != nil {
+		return err
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &safe, Data: data}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	return parsedSafeSignatureABI.UnpackIntoInterface(out, method, result)
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// issueSession 生成令牌对并记录会话，binding.SessionID为空时开启新的令牌族
+func (s *service) issueSession(ctx context.Context, user *types.User, binding types.SessionBinding, parentJTI string, client types.SessionClient) (*utils.TokenPair, error) {
+	pair, err := s.jwtManager.GenerateTokens(user.ID, user.WalletAddress, binding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate jwt tokens: %w", err)
+	}
+
+	session := &types.UserSession{
+		UserID:     user.ID,
+		FamilyID:   pair.SessionID,
+		RefreshJTI: pair.RefreshJTI,
+		ParentJTI:  parentJTI,
+		ChainID:    binding.ChainID,
+		Domain:     binding.Domain,
+		IsSafe:     binding.Safe,
+		UserAgent:  truncate(client.UserAgent, 512),
+		IPAddress:  truncate(client.IPAddress, 64),
+		ExpiresAt:  pair.RefreshExpiresAt,
+	}
+	if err := s.sessionRepo.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return pair, nil
+}
+
+// consumeRefreshSession 校验刷新令牌对应的会话并将其标记为已轮换
+// 已轮换的刷新令牌再次使用视为重放，撤销整个令牌族
+func (s *service) consumeRefreshSession(ctx context.Context, claims *types.JWTClaims) (*types.UserSession, error) {
+	if claims.ID == "" || claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: refresh token is not bound to a session, please sign in again", ErrInvalidToken)
+	}
+
+	session, err := s.sessionRepo.GetSessionByRefreshJTI(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: session not found", ErrInvalidToken)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if session.UserID != claims.UserID || session.FamilyID != claims.SessionID {
+		return nil, fmt.Errorf("%w: session does not match token", ErrInvalidToken)
+	}
+	if session.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+
+	if session.RotatedAt == nil {
+		rotated, err := s.sessionRepo.MarkSessionRotated(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		if rotated {
+			return session, nil
+		}
+	}
+
+	// 刷新令牌已被使用（或并发使用），令牌可能已泄露
+	logger.Error("consumeRefreshSession Error: ", ErrTokenReused, "user_id", claims.UserID, "family_id", session.FamilyID)
+	if err := s.revokeFamily(ctx, session.FamilyID, types.SessionRevokeReuse); err != nil {
+		logger.Error("Failed to revoke token family after reuse", err, "family_id", session.FamilyID)
+	}
+	return nil, ErrTokenReused
+}
+
+// revokeFamily 撤销令牌族下的所有会话，并将令牌族加入访问令牌黑名单
+func (s *service) revokeFamily(ctx context.Context, familyID string, reason string) error {
+	if _, err := s.sessionRepo.RevokeFamily(ctx, familyID, reason); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	// 令牌族中最晚签发的访问令牌不会晚于当前时间加访问令牌有效期过期
+	if err := s.denylist.Revoke(ctx, familyID, time.Now().Add(s.jwtManager.AccessExpiry())); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	return nil
+}
+
+// checkDenylist 检查访问令牌及其令牌族是否已被撤销
+func (s *service) checkDenylist(ctx context.Context, claims *types.JWTClaims) error {
+	for _, tokenID := range []string{claims.ID, claims.SessionID} {
+		revoked, err := s.denylist.IsRevoked(ctx, tokenID)
+		if err != nil {
+			return fmt.Errorf("failed to check token denylist: %w", err)
+		}
+		if revoked {
+			return ErrTokenRevoked
+		}
+	}
+	return nil
+}
+
+// Logout 登出当前会话：撤销当前令牌族并使当前访问令牌失效
+func (s *service) Logout(ctx context.Context, claims *types.JWTClaims) (*types.LogoutResponse, error) {
+	logger.Info("Logout", "user_id", claims.UserID, "sid", claims.SessionID)
+
+	if err := s.revokeFamily(ctx, claims.SessionID, types.SessionRevokeLogout); err != nil {
+		logger.Error("Logout Error: ", err, "user_id", claims.UserID)
+		return nil, err
+	}
+	if err := s.denylist.Revoke(ctx, claims.ID, claims.ExpiresAt); err != nil {
+		logger.Error("Logout Error: ", err, "user_id", claims.UserID)
+		return nil, fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return &types.LogoutResponse{RevokedSessions: 1}, nil
+}
+
+// LogoutAll 登出所有设备：撤销用户的所有会话及其访问令牌
+func (s *service) LogoutAll(ctx context.Context, claims *types.JWTClaims) (*types.LogoutResponse, error) {
+	logger.Info("LogoutAll", "user_id", claims.UserID)
+
+	familyIDs, err := s.sessionRepo.RevokeUserSessions(ctx, claims.UserID, types.SessionRevokeLogoutAll)
+	if err != nil {
+		logger.Error("LogoutAll Error: ", err, "user_id", claims.UserID)
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// 当前令牌族的刷新记录可能已过期，始终加入黑名单
+	expiresAt := time.Now().Add(s.jwtManager.AccessExpiry())
+	revoked := map[string]bool{}
+	for _, familyID := range append(familyIDs, claims.SessionID) {
+		if revoked[familyID] {
+			continue
+		}
+		if err := s.denylist.Revoke(ctx, familyID, expiresAt); err != nil {
+			logger.Error("LogoutAll Error: ", err, "user_id", claims.UserID, "family_id", familyID)
+			return nil, fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+		revoked[familyID] = true
+	}
+
+	logger.Info("LogoutAll success", "user_id", claims.UserID, "revoked_sessions", len(revoked))
+	return &types.LogoutResponse{RevokedSessions: int64(len(revoked))}, nil
+}
+
+// CleanupSessions 清理过期的会话记录和黑名单记录
+func (s *service) CleanupSessions(ctx context.Context) error {
+	if _, err := s.sessionRepo.DeleteExpiredSessions(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	if _, err := s.sessionRepo.DeleteExpiredRevokedTokens(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+	return nil
+}
+
+// truncate 截断超长的客户端信息
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return strings.ToValidUTF8(value[:max], "")
+	}
+	return value
+}
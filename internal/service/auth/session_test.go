@@ -0,0 +1,341 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"timelocker-backend/internal/config"
+	sessionRepo "timelocker-backend/internal/repository/session"
+	userRepo "timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// stubUserRepo 只实现按ID查询用户和更新登录时间的用户仓库
+type stubUserRepo struct {
+	userRepo.Repository
+	users map[int64]*types.User
+}
+
+func (r *stubUserRepo) GetUserByID(ctx context.Context, id int64) (*types.User, error) {
+	if user, ok := r.users[id]; ok {
+		copied := *user
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubUserRepo) UpdateLastLogin(ctx context.Context, walletAddress string) error {
+	return nil
+}
+
+// stubSessionRepo 内存会话仓库，按刷新令牌ID索引会话
+type stubSessionRepo struct {
+	sessionRepo.Repository
+	sessions map[string]*types.UserSession
+	revoked  map[string]time.Time
+	nextID   int64
+}
+
+func newStubSessionRepo() *stubSessionRepo {
+	return &stubSessionRepo{
+		sessions: map[string]*types.UserSession{},
+		revoked:  map[string]time.Time{},
+	}
+}
+
+func (r *stubSessionRepo) CreateSession(ctx context.Context, session *types.UserSession) error {
+	r.nextID++
+	session.ID = r.nextID
+	copied := *session
+	r.sessions[session.RefreshJTI] = &copied
+	return nil
+}
+
+func (r *stubSessionRepo) GetSessionByRefreshJTI(ctx context.Context, refreshJTI string) (*types.UserSession, error) {
+	if session, ok := r.sessions[refreshJTI]; ok {
+		copied := *session
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubSessionRepo) MarkSessionRotated(ctx context.Context, id int64) (bool, error) {
+	for _, session := range r.sessions {
+		if session.ID == id && session.RotatedAt == nil && session.RevokedAt == nil {
+			now := time.Now()
+			session.RotatedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *stubSessionRepo) RevokeFamily(ctx context.Context, familyID string, reason string) (int64, error) {
+	var count int64
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil {
+			now := time.Now()
+			session.RevokedAt = &now
+			session.RevokeReason = reason
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *stubSessionRepo) RevokeUserSessions(ctx context.Context, userID int64, reason string) ([]string, error) {
+	var familyIDs []string
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			now := time.Now()
+			session.RevokedAt = &now
+			session.RevokeReason = reason
+			familyIDs = append(familyIDs, session.FamilyID)
+		}
+	}
+	return familyIDs, nil
+}
+
+func (r *stubSessionRepo) AddRevokedToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	r.revoked[tokenID] = expiresAt
+	return nil
+}
+
+func (r *stubSessionRepo) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	expiresAt, ok := r.revoked[tokenID]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+// familyRevokeReason 返回令牌族的撤销原因，未撤销时为空
+func (r *stubSessionRepo) familyRevokeReason(familyID string) string {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID && session.RevokedAt != nil {
+			return session.RevokeReason
+		}
+	}
+	return ""
+}
+
+func newTestJWTManager(t *testing.T) *utils.JWTManager {
+	t.Helper()
+	m, err := utils.NewJWTManager(&config.JWTConfig{Secret: "test-secret", AccessExpiry: 15 * time.Minute, RefreshExpiry: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("new jwt manager: %v", err)
+	}
+	return m
+}
+
+// sessionHarness 使用内存仓库和数据库黑名单的会话测试环境
+type sessionHarness struct {
+	t        *testing.T
+	s        *service
+	sessions *stubSessionRepo
+	user     *types.User
+}
+
+func newSessionHarness(t *testing.T) *sessionHarness {
+	sessions := newStubSessionRepo()
+	user := &types.User{ID: 1, WalletAddress: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Status: 1}
+	return &sessionHarness{
+		t:        t,
+		sessions: sessions,
+		user:     user,
+		s: &service{
+			userRepo:    &stubUserRepo{users: map[int64]*types.User{user.ID: user}},
+			sessionRepo: sessions,
+			jwtManager:  newTestJWTManager(t),
+			denylist:    NewDatabaseDenylist(sessions),
+		},
+	}
+}
+
+// login 开启新的令牌族
+func (h *sessionHarness) login() *utils.TokenPair {
+	h.t.Helper()
+	pair, err := h.s.issueSession(context.Background(), h.user, types.SessionBinding{ChainID: 1, Domain: "app.timelocker.io"}, "", types.SessionClient{})
+	if err != nil {
+		h.t.Fatalf("issue session: %v", err)
+	}
+	return pair
+}
+
+// refresh 使用刷新令牌换取新的令牌对，失败时调用t.Fatalf
+func (h *sessionHarness) refresh(refreshToken string) *types.WalletConnectResponse {
+	h.t.Helper()
+	resp, err := h.s.RefreshToken(context.Background(), &types.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		h.t.Fatalf("refresh: %v", err)
+	}
+	return resp
+}
+
+func (h *sessionHarness) tryRefresh(refreshToken string) error {
+	_, err := h.s.RefreshToken(context.Background(), &types.RefreshTokenRequest{RefreshToken: refreshToken})
+	return err
+}
+
+func (h *sessionHarness) verify(accessToken string) error {
+	_, err := h.s.VerifyToken(context.Background(), accessToken)
+	return err
+}
+
+func (h *sessionHarness) claims(accessToken string) *types.JWTClaims {
+	h.t.Helper()
+	claims, err := h.s.jwtManager.VerifyAccessToken(accessToken)
+	if err != nil {
+		h.t.Fatalf("verify access token: %v", err)
+	}
+	return claims
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(h *sessionHarness) error
+		wantErr error
+	}{
+		{
+			name: "rotated refresh token keeps family",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				resp := h.refresh(pair.RefreshToken)
+				if resp.RefreshToken == pair.RefreshToken {
+					h.t.Fatalf("refresh token was not rotated")
+				}
+				if sid := h.claims(resp.AccessToken).SessionID; sid != pair.SessionID {
+					h.t.Fatalf("session id %s, want %s", sid, pair.SessionID)
+				}
+				h.refresh(resp.RefreshToken)
+				return h.verify(resp.AccessToken)
+			},
+		},
+		{
+			name: "reused refresh token",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				h.refresh(pair.RefreshToken)
+				return h.tryRefresh(pair.RefreshToken)
+			},
+			wantErr: ErrTokenReused,
+		},
+		{
+			name: "reuse revokes rotated descendant",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				resp := h.refresh(pair.RefreshToken)
+				if err := h.tryRefresh(pair.RefreshToken); !errors.Is(err, ErrTokenReused) {
+					h.t.Fatalf("reuse: got %v, want %v", err, ErrTokenReused)
+				}
+				if reason := h.sessions.familyRevokeReason(pair.SessionID); reason != types.SessionRevokeReuse {
+					h.t.Fatalf("revoke reason %q, want %q", reason, types.SessionRevokeReuse)
+				}
+				return h.tryRefresh(resp.RefreshToken)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name: "reuse revokes family access tokens",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				resp := h.refresh(pair.RefreshToken)
+				if err := h.tryRefresh(pair.RefreshToken); !errors.Is(err, ErrTokenReused) {
+					h.t.Fatalf("reuse: got %v, want %v", err, ErrTokenReused)
+				}
+				return h.verify(resp.AccessToken)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name: "reuse leaves other families",
+			run: func(h *sessionHarness) error {
+				stolen := h.login()
+				other := h.login()
+				h.refresh(stolen.RefreshToken)
+				if err := h.tryRefresh(stolen.RefreshToken); !errors.Is(err, ErrTokenReused) {
+					h.t.Fatalf("reuse: got %v, want %v", err, ErrTokenReused)
+				}
+				resp := h.refresh(other.RefreshToken)
+				return h.verify(resp.AccessToken)
+			},
+		},
+		{
+			name: "access token used as refresh token",
+			run: func(h *sessionHarness) error {
+				return h.tryRefresh(h.login().AccessToken)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "refresh token without session",
+			run: func(h *sessionHarness) error {
+				pair, err := h.s.jwtManager.GenerateTokens(h.user.ID, h.user.WalletAddress, types.SessionBinding{ChainID: 1})
+				if err != nil {
+					h.t.Fatalf("generate tokens: %v", err)
+				}
+				return h.tryRefresh(pair.RefreshToken)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "logout revokes refresh token",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				if _, err := h.s.Logout(context.Background(), h.claims(pair.AccessToken)); err != nil {
+					h.t.Fatalf("logout: %v", err)
+				}
+				return h.tryRefresh(pair.RefreshToken)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name: "logout revokes access token",
+			run: func(h *sessionHarness) error {
+				pair := h.login()
+				if _, err := h.s.Logout(context.Background(), h.claims(pair.AccessToken)); err != nil {
+					h.t.Fatalf("logout: %v", err)
+				}
+				return h.verify(pair.AccessToken)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name: "logout all revokes other devices",
+			run: func(h *sessionHarness) error {
+				current := h.login()
+				other := h.login()
+				resp, err := h.s.LogoutAll(context.Background(), h.claims(current.AccessToken))
+				if err != nil {
+					h.t.Fatalf("logout all: %v", err)
+				}
+				if resp.RevokedSessions != 2 {
+					h.t.Fatalf("revoked %d sessions, want 2", resp.RevokedSessions)
+				}
+				if err := h.tryRefresh(other.RefreshToken); !errors.Is(err, ErrTokenRevoked) {
+					h.t.Fatalf("refresh other device: got %v, want %v", err, ErrTokenRevoked)
+				}
+				return h.verify(other.AccessToken)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newSessionHarness(t))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/utils"
+)
+
+// validateSiweMessage 解析并严格校验SIWE (EIP-4361) 签名消息
+// 校验地址、域名白名单、URI、有效期、支持链和nonce，防止钓鱼站点获取的签名被重放
+// chainID不为0时要求与签名消息中的链ID一致
+func (s *service) validateSiweMessage(ctx context.Context, walletAddress, rawMessage, nonce string, chainID int) (*utils.SiweMessage, error) {
+	message, err := utils.ParseSiweMessage(rawMessage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSiwe, err)
+	}
+
+	if strings.ToLower(message.Address) != walletAddress {
+		return nil, fmt.Errorf("%w: address does not match wallet address", ErrInvalidSiwe)
+	}
+	if message.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidSiwe)
+	}
+	if err := s.validateSiweDomain(message.Domain, message.URI); err != nil {
+		return nil, err
+	}
+	if err := message.ValidateTime(time.Now(), s.siweConfig.ClockSkew); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSiwe, err)
+	}
+	if chainID != 0 && chainID != message.ChainID {
+		return nil, ErrChainMismatch
+	}
+	if err := s.validateSiweChain(ctx, message.ChainID); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// validateSiweDomain 校验域名在配置的白名单中，且URI指向该域名
+func (s *service) validateSiweDomain(domain, uri string) error {
+	allowed := false
+	for _, candidate := range s.siweConfig.Domains {
+		if strings.EqualFold(candidate, domain) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		logger.Error("validateSiweDomain Error: ", ErrInvalidDomain, "domain", domain)
+		return fmt.Errorf("%w: %s", ErrInvalidDomain, domain)
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil || !strings.EqualFold(parsed.Host, domain) {
+		logger.Error("validateSiweDomain Error: ", ErrInvalidDomain, "domain", domain, "uri", uri)
+		return fmt.Errorf("%w: uri %s does not belong to %s", ErrInvalidDomain, uri, domain)
+	}
+	return nil
+}
+
+// validateSiweChain 校验链ID在支持链列表中且已激活
+func (s *service) validateSiweChain(ctx context.Context, chainID int) error {
+	if chainID <= 0 {
+		return fmt.Errorf("%w: %d", ErrUnsupportedChain, chainID)
+	}
+	chain, err := s.chainRepo.GetChainByChainID(ctx, int64(chainID))
+	if err != nil || !chain.IsActive {
+		logger.Error("validateSiweChain Error: ", ErrUnsupportedChain, "chain_id", chainID, "error", err)
+		return fmt.Errorf("%w: %d", ErrUnsupportedChain, chainID)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"timelocker-backend/internal/config"
+	chainRepo "timelocker-backend/internal/repository/chain"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// stubChainRepo 只实现GetChainByChainID的链仓库
+type stubChainRepo struct {
+	chainRepo.Repository
+	chains map[int64]*types.SupportChain
+}
+
+func (r *stubChainRepo) GetChainByChainID(ctx context.Context, chainID int64) (*types.SupportChain, error) {
+	if chain, ok := r.chains[chainID]; ok {
+		return chain, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestValidateSiweMessage(t *testing.T) {
+	const (
+		address = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		nonce   = "a1b2c3d4e5f6"
+	)
+	s := &service{
+		chainRepo: &stubChainRepo{chains: map[int64]*types.SupportChain{
+			1:  {ChainID: 1, IsActive: true},
+			56: {ChainID: 56, IsActive: false},
+		}},
+		siweConfig: &config.SIWEConfig{
+			Domains:   []string{"app.timelocker.io", "localhost:3000"},
+			ClockSkew: time.Minute,
+		},
+	}
+
+	newMessage := func(modify func(m *utils.SiweMessage)) string {
+		now := time.Now().UTC().Truncate(time.Second)
+		expiration := now.Add(5 * time.Minute)
+		msg := &utils.SiweMessage{
+			Domain:         "app.timelocker.io",
+			Address:        address,
+			URI:            "https://app.timelocker.io",
+			Version:        "1",
+			ChainID:        1,
+			Nonce:          nonce,
+			IssuedAt:       now,
+			ExpirationTime: &expiration,
+		}
+		if modify != nil {
+			modify(msg)
+		}
+		return msg.String()
+	}
+	expiredAt := func(d time.Duration) func(m *utils.SiweMessage) {
+		return func(m *utils.SiweMessage) {
+			m.IssuedAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+			expiration := time.Now().UTC().Add(-d).Truncate(time.Second)
+			m.ExpirationTime = &expiration
+		}
+	}
+
+	tests := []struct {
+		name    string
+		wallet  string
+		message string
+		nonce   string
+		chainID int
+		wantErr error
+	}{
+		{"valid", strings.ToLower(address), newMessage(nil), nonce, 1, nil},
+		{"valid without requested chain", strings.ToLower(address), newMessage(nil), nonce, 0, nil},
+		{"domain with port", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) {
+			m.Domain = "localhost:3000"
+			m.URI = "http://localhost:3000/login"
+		}), nonce, 1, nil},
+		{"malformed message", strings.ToLower(address), "not a siwe message", nonce, 1, ErrInvalidSiwe},
+		{"address mismatch", "0x0000000000000000000000000000000000000001", newMessage(nil), nonce, 1, ErrInvalidSiwe},
+		{"nonce mismatch", strings.ToLower(address), newMessage(nil), "f6e5d4c3b2a1", 1, ErrInvalidSiwe},
+		{"domain not allowed", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) {
+			m.Domain = "app.timelocker.io.evil.com"
+			m.URI = "https://app.timelocker.io.evil.com"
+		}), nonce, 1, ErrInvalidDomain},
+		{"uri on another host", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) {
+			m.URI = "https://evil.com/app.timelocker.io"
+		}), nonce, 1, ErrInvalidDomain},
+		{"uri on another port", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) {
+			m.Domain = "localhost:3000"
+			m.URI = "http://localhost:4000"
+		}), nonce, 1, ErrInvalidDomain},
+		{"chain mismatch", strings.ToLower(address), newMessage(nil), nonce, 56, ErrChainMismatch},
+		{"inactive chain", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) { m.ChainID = 56 }), nonce, 0, ErrUnsupportedChain},
+		{"unknown chain", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) { m.ChainID = 999 }), nonce, 0, ErrUnsupportedChain},
+		{"expired", strings.ToLower(address), newMessage(expiredAt(5 * time.Minute)), nonce, 1, ErrInvalidSiwe},
+		{"no expiration time", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) { m.ExpirationTime = nil }), nonce, 1, ErrInvalidSiwe},
+		{"issued in the future", strings.ToLower(address), newMessage(func(m *utils.SiweMessage) {
+			m.IssuedAt = time.Now().UTC().Add(10 * time.Minute).Truncate(time.Second)
+			expiration := m.IssuedAt.Add(5 * time.Minute)
+			m.ExpirationTime = &expiration
+		}), nonce, 1, ErrInvalidSiwe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := s.validateSiweMessage(context.Background(), tt.wallet, tt.message, tt.nonce, tt.chainID)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if msg == nil {
+					t.Fatalf("expected parsed message")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
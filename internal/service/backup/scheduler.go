@@ -0,0 +1,269 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"timelocker-backend/internal/config"
+	backupRepo "timelocker-backend/internal/repository/backup"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/database"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/metrics"
+
+	"github.com/robfig/cron/v3"
+)
+
+// AdminNotifier 备份失败时通知平台管理员
+type AdminNotifier interface {
+	SendAdminAlert(ctx context.Context, message string) error
+}
+
+// Scheduler 服务内定时备份调度器，按cron表达式创建全量和增量备份并记录运行结果
+type Scheduler struct {
+	config   *config.BackupConfig
+	manager  *database.BackupManager
+	runRepo  backupRepo.RunRepository
+	notifier AdminNotifier
+	cron     *cron.Cron
+
+	mu          sync.Mutex // 同一时间只运行一个备份
+	stateMu     sync.RWMutex
+	lastRun     *types.BackupRun
+	lastSuccess *time.Time
+}
+
+// NewScheduler 创建定时备份调度器
+func NewScheduler(cfg *config.BackupConfig, manager *database.BackupManager, runRepo backupRepo.RunRepository, notifier AdminNotifier) *Scheduler {
+	return &Scheduler{
+		config:   cfg,
+		manager:  manager,
+		runRepo:  runRepo,
+		notifier: notifier,
+		cron:     cron.New(),
+	}
+}
+
+// Start 注册cron任务并启动调度，cron表达式无效时返回错误
+func (s *Scheduler) Start(ctx context.Context) error {
+	schedule := s.config.Schedule
+	if _, err := s.cron.AddFunc(schedule.Cron, func() { s.RunOnce(ctx, false) }); err != nil {
+		return fmt.Errorf("invalid backup cron %q: %w", schedule.Cron, err)
+	}
+	if schedule.IncrementalCron != "" {
+		if _, err := s.cron.AddFunc(schedule.IncrementalCron, func() { s.RunOnce(ctx, true) }); err != nil {
+			return fmt.Errorf("invalid backup incremental cron %q: %w", schedule.IncrementalCron, err)
+		}
+	}
+	if err := os.MkdirAll(schedule.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// 服务重启前未完成的运行不会再结束，标记为失败
+	if count, err := s.runRepo.FailRunningRuns(ctx, "interrupted by server restart"); err != nil {
+		logger.Error("Failed to mark interrupted backup runs", err)
+	} else if count > 0 {
+		logger.Warn("Marked interrupted backup runs as failed", "count", count)
+	}
+	s.loadState(ctx)
+
+	s.cron.Start()
+	logger.Info("Backup scheduler started", "cron", schedule.Cron, "incremental_cron", schedule.IncrementalCron, "dir", schedule.Dir)
+	return nil
+}
+
+// Stop 停止调度并等待正在运行的备份结束
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+	logger.Info("Backup scheduler stopped")
+}
+
+// loadState 从运行记录恢复最近一次运行状态
+func (s *Scheduler) loadState(ctx context.Context) {
+	lastRun, err := s.runRepo.GetLatestRun(ctx)
+	if err != nil {
+		return
+	}
+	lastSuccess, err := s.runRepo.GetLatestSuccessfulRun(ctx)
+	if err != nil {
+		return
+	}
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.lastRun = lastRun
+	if lastSuccess != nil {
+		s.lastSuccess = &lastSuccess.StartedAt
+	}
+}
+
+// RunOnce 执行一次备份，上一次备份仍在运行时跳过
+func (s *Scheduler) RunOnce(ctx context.Context, incremental bool) {
+	if !s.mu.TryLock() {
+		logger.Warn("Previous backup still running, skip this run", "incremental", incremental)
+		return
+	}
+	defer s.mu.Unlock()
+
+	schedule := s.config.Schedule
+	backupType := database.BackupTypeFull
+	kind := "full"
+	if incremental {
+		backupType = database.BackupTypeIncremental
+		kind = "incr"
+	}
+	startedAt := time.Now()
+	path := filepath.Join(schedule.Dir, fmt.Sprintf("%s_%s_%s.tar", schedule.Prefix, kind, startedAt.Format("20060102_150405")))
+
+	run := &types.BackupRun{
+		BackupType: backupType,
+		Status:     types.BackupRunStatusRunning,
+		File:       path,
+		StartedAt:  startedAt,
+	}
+	if err := s.runRepo.CreateRun(ctx, run); err != nil {
+		logger.Error("Failed to record backup run", err)
+	}
+	s.setLastRun(run)
+
+	logger.Info("Starting scheduled backup", "type", backupType, "path", path)
+	entry, err := s.createBackup(ctx, path, incremental)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(startedAt).Milliseconds()
+	if err != nil {
+		message := err.Error()
+		run.Status = types.BackupRunStatusFailed
+		run.ErrorMessage = &message
+		logger.Error("Scheduled backup failed", err, "type", backupType, "duration", finishedAt.Sub(startedAt))
+	} else {
+		run.Status = types.BackupRunStatusSuccess
+		run.BackupID = entry.ID
+		run.SizeBytes = entry.Size
+		run.TableCount = len(entry.Tables)
+		run.RowCount = entry.Rows
+		run.Checksum = entry.SHA256
+		logger.Info("Scheduled backup completed", "id", entry.ID, "type", backupType, "size", entry.Size, "duration", finishedAt.Sub(startedAt))
+	}
+
+	// 记录结果时不使用可能已取消的服务context
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if run.ID > 0 {
+		if err := s.runRepo.UpdateRun(saveCtx, run); err != nil {
+			logger.Error("Failed to update backup run", err, "id", run.ID)
+		}
+	} else if err := s.runRepo.CreateRun(saveCtx, run); err != nil {
+		logger.Error("Failed to record backup run", err)
+	}
+	s.setLastRun(run)
+	metrics.ObserveBackupRun(backupType, run.Status, finishedAt.Sub(startedAt), run.SizeBytes)
+
+	if err != nil {
+		// 服务关闭导致的中断不告警
+		if ctx.Err() == nil {
+			s.notifyFailure(saveCtx, run)
+		}
+		return
+	}
+
+	if _, err := s.manager.ApplyRetention(ctx, schedule.Dir, s.config.Retention); err != nil {
+		logger.Error("Failed to apply backup retention", err)
+	}
+}
+
+// createBackup 在超时时间内创建备份
+func (s *Scheduler) createBackup(ctx context.Context, path string, incremental bool) (*database.BackupCatalogEntry, error) {
+	timeout := s.config.Schedule.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Hour
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	entry, err := s.manager.CreateBackup(ctx, path, database.BackupOptions{Incremental: incremental})
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("backup exceeded timeout %s: %w", timeout, err)
+	}
+	return entry, err
+}
+
+// notifyFailure 备份失败时通知平台管理员
+func (s *Scheduler) notifyFailure(ctx context.Context, run *types.BackupRun) {
+	if s.notifier == nil {
+		return
+	}
+	message := "━━━━━━━━━━━━━━━━\n"
+	message += "🚨 TimeLocker Backup Failed\n"
+	message += "━━━━━━━━━━━━━━━━\n"
+	message += fmt.Sprintf("Type     : %s\n", run.BackupType)
+	message += fmt.Sprintf("Started  : %s UTC\n", run.StartedAt.UTC().Format("2006-01-02 15:04:05"))
+	message += fmt.Sprintf("Duration : %s\n", time.Duration(run.DurationMs)*time.Millisecond)
+	if run.ErrorMessage != nil {
+		message += fmt.Sprintf("Error    : %s\n", *run.ErrorMessage)
+	}
+	if lastSuccess := s.Health().LastSuccessAt; lastSuccess != nil {
+		message += fmt.Sprintf("Last OK  : %s UTC\n", lastSuccess.UTC().Format("2006-01-02 15:04:05"))
+	}
+
+	if err := s.notifier.SendAdminAlert(ctx, message); err != nil {
+		logger.Error("Failed to notify admins of backup failure", err, "run_id", run.ID)
+	}
+}
+
+// setLastRun 更新内存中的最近运行状态
+func (s *Scheduler) setLastRun(run *types.BackupRun) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	snapshot := *run
+	s.lastRun = &snapshot
+	if run.Status == types.BackupRunStatusSuccess {
+		startedAt := run.StartedAt
+		s.lastSuccess = &startedAt
+	}
+}
+
+// Health 返回定时备份的健康状态，不查询数据库
+func (s *Scheduler) Health() *types.BackupHealth {
+	s.stateMu.RLock()
+	defer s.stateMu.RUnlock()
+
+	health := &types.BackupHealth{
+		Status:        types.BackupHealthPending,
+		LastRun:       s.lastRun,
+		LastSuccessAt: s.lastSuccess,
+	}
+	if next := s.nextRun(); !next.IsZero() {
+		health.NextRunAt = &next
+	}
+
+	switch {
+	case s.lastRun == nil:
+	case s.lastRun.Status == types.BackupRunStatusRunning:
+		health.Status = types.BackupHealthRunning
+	case s.lastRun.Status == types.BackupRunStatusFailed:
+		health.Status = types.BackupHealthFailed
+	case s.config.Schedule.MaxAge > 0 && s.lastSuccess != nil && time.Since(*s.lastSuccess) > s.config.Schedule.MaxAge:
+		health.Status = types.BackupHealthStale
+	default:
+		health.Status = types.BackupHealthOK
+	}
+	return health
+}
+
+// nextRun 返回下一次计划运行时间
+func (s *Scheduler) nextRun() time.Time {
+	var next time.Time
+	for _, entry := range s.cron.Entries() {
+		if !entry.Next.IsZero() && (next.IsZero() || entry.Next.Before(next)) {
+			next = entry.Next
+		}
+	}
+	return next
+}
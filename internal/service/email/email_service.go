@@ -9,16 +9,20 @@ import (
 	"html/template"
 	"math/big"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 	"timelocker-backend/internal/config"
 	chainRepo "timelocker-backend/internal/repository/chain"
 	emailRepo "timelocker-backend/internal/repository/email"
+	organizationRepo "timelocker-backend/internal/repository/organization"
 	"timelocker-backend/internal/repository/scanner"
 	timeLockRepo "timelocker-backend/internal/repository/timelock"
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/types"
 	emailPkg "timelocker-backend/pkg/email"
 	"timelocker-backend/pkg/logger"
+	notificationPkg "timelocker-backend/pkg/notification"
 	"timelocker-backend/pkg/utils"
 
 	"golang.org/x/text/cases"
@@ -32,15 +36,18 @@ type EmailService interface {
 	AddUserEmail(ctx context.Context, userID int64, emailAddr string, remark *string) (*types.UserEmailResponse, error)
 	GetUserEmails(ctx context.Context, userID int64, page, pageSize int) (*types.EmailListResponse, error)
 	UpdateEmailRemark(ctx context.Context, userEmailID int64, userID int64, remark *string) error
+	UpdateEmailFilters(ctx context.Context, userEmailID int64, userID int64, filters *types.NotificationFilter) error
+	UpdateEmailTemplate(ctx context.Context, userEmailID int64, userID int64, emailTemplate *string) error
+	UpdateEmailDigestMode(ctx context.Context, userEmailID int64, userID int64, digestMode string) error
 	DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64) error
 
 	// 邮箱验证
 	SendVerificationCode(ctx context.Context, userEmailID int64, userID int64) error
 	VerifyEmail(ctx context.Context, userEmailID int64, userID int64, code string) error
-	// 基于 email 发送验证码（创建/复用未验证记录，允许备注更新）
-	SendVerificationCodeByEmail(ctx context.Context, userID int64, emailAddr string, remark *string) error
+	// 基于 email 发送验证码（创建/复用未验证记录，允许备注更新），organizationID 不为0时添加为组织邮箱
+	SendVerificationCodeByEmail(ctx context.Context, userID int64, organizationID int64, emailAddr string, remark *string) error
 	// 基于 email 校验验证码
-	VerifyEmailByEmail(ctx context.Context, userID int64, emailAddr string, code string) error
+	VerifyEmailByEmail(ctx context.Context, userID int64, organizationID int64, emailAddr string, code string) error
 
 	// 通知发送
 	SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error
@@ -55,17 +62,21 @@ type emailService struct {
 	chainRepo       chainRepo.Repository
 	timeLockRepo    timeLockRepo.Repository
 	transactionRepo scanner.TransactionRepository
+	orgRepo         organizationRepo.Repository
+	auditor         audit.Recorder
 	config          *config.Config
 	sender          *emailPkg.SMTPSender
 }
 
 // NewEmailService 创建邮箱服务实例
-func NewEmailService(repo emailRepo.EmailRepository, chainRepo chainRepo.Repository, timeLockRepo timeLockRepo.Repository, transactionRepo scanner.TransactionRepository, cfg *config.Config) EmailService {
+func NewEmailService(repo emailRepo.EmailRepository, chainRepo chainRepo.Repository, timeLockRepo timeLockRepo.Repository, transactionRepo scanner.TransactionRepository, orgRepo organizationRepo.Repository, auditor audit.Recorder, cfg *config.Config) EmailService {
 	return &emailService{
 		repo:            repo,
 		chainRepo:       chainRepo,
 		timeLockRepo:    timeLockRepo,
 		transactionRepo: transactionRepo,
+		orgRepo:         orgRepo,
+		auditor:         auditor,
 		config:          cfg,
 		sender:          emailPkg.NewSMTPSender(&cfg.Email),
 	}
@@ -81,7 +92,7 @@ func (s *emailService) AddUserEmail(ctx context.Context, userID int64, emailAddr
 	}
 
 	// 检查用户是否已添加此邮箱
-	exists, err := s.repo.CheckUserEmailExists(ctx, userID, emailRecord.ID)
+	exists, err := s.repo.CheckUserEmailExists(ctx, userID, 0, emailRecord.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check user email exists: %w", err)
 	}
@@ -90,10 +101,12 @@ func (s *emailService) AddUserEmail(ctx context.Context, userID int64, emailAddr
 	}
 
 	// 添加用户邮箱关系
-	userEmail, err := s.repo.AddUserEmail(ctx, userID, emailRecord.ID, remark)
+	userEmail, err := s.repo.AddUserEmail(ctx, userID, 0, emailRecord.ID, remark)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add user email: %w", err)
 	}
+	after, _ := s.userEmailSnapshot(ctx, userEmail.ID, userID, nil)
+	s.recordEmailEvent(ctx, types.AuditActionEmailAdd, userEmail.ID, 0, nil, after)
 
 	return &types.UserEmailResponse{
 		ID:             userEmail.ID,
@@ -101,18 +114,24 @@ func (s *emailService) AddUserEmail(ctx context.Context, userID int64, emailAddr
 		Remark:         remark,
 		IsVerified:     false,
 		LastVerifiedAt: nil,
+		DigestMode:     types.DigestModeOff,
 		CreatedAt:      userEmail.CreatedAt,
 	}, nil
 }
 
-// GetUserEmails 获取用户邮箱
+// GetUserEmails 获取用户邮箱，包含用户所在组织的邮箱
 func (s *emailService) GetUserEmails(ctx context.Context, userID int64, page, pageSize int) (*types.EmailListResponse, error) {
 	offset := (page - 1) * pageSize
 	if offset < 0 {
 		offset = 0
 	}
 
-	userEmails, total, err := s.repo.GetUserEmails(ctx, userID, offset, pageSize)
+	organizationIDs, err := s.orgRepo.GetOrganizationIDsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+
+	userEmails, total, err := s.repo.GetUserEmails(ctx, userID, organizationIDs, offset, pageSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user emails: %w", err)
 	}
@@ -123,9 +142,13 @@ func (s *emailService) GetUserEmails(ctx context.Context, userID int64, page, pa
 		emails[i] = types.UserEmailResponse{
 			ID:             ue.ID,
 			Email:          ue.Email.Email,
+			OrganizationID: ue.OrganizationID,
 			Remark:         ue.Remark,
 			IsVerified:     ue.IsVerified,
 			LastVerifiedAt: ue.LastVerifiedAt,
+			Filters:        ue.Filters,
+			EmailTemplate:  ue.EmailTemplate,
+			DigestMode:     ue.DigestMode,
 			CreatedAt:      ue.CreatedAt,
 		}
 	}
@@ -145,33 +168,124 @@ func (s *emailService) UpdateEmailRemark(ctx context.Context, userEmailID int64,
 		}
 		remark = &trimmed
 	}
-	err := s.repo.UpdateUserEmailRemark(ctx, userEmailID, userID, remark)
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	err = s.repo.UpdateUserEmailRemark(ctx, userEmailID, userID, organizationIDs, remark)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("user email not found")
 		}
 		return fmt.Errorf("failed to update email remark: %w", err)
 	}
+	after, _ := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	s.recordEmailEvent(ctx, types.AuditActionEmailUpdateRemark, userEmailID, organizationID, before, after)
+	return nil
+}
+
+// UpdateEmailFilters 更新用户邮箱订阅过滤规则，filters为空时清除过滤
+func (s *emailService) UpdateEmailFilters(ctx context.Context, userEmailID int64, userID int64, filters *types.NotificationFilter) error {
+	encoded, err := utils.EncodeNotificationFilter(filters)
+	if err != nil {
+		return fmt.Errorf("invalid filters: %w", err)
+	}
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	err = s.repo.UpdateUserEmailFilters(ctx, userEmailID, userID, organizationIDs, encoded)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("user email not found")
+		}
+		return fmt.Errorf("failed to update email filters: %w", err)
+	}
+	after, _ := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	s.recordEmailEvent(ctx, types.AuditActionEmailUpdateFilters, userEmailID, organizationID, before, after)
+	return nil
+}
+
+// UpdateEmailTemplate 更新用户邮箱自定义邮件模板，模板为空时恢复默认模板
+func (s *emailService) UpdateEmailTemplate(ctx context.Context, userEmailID int64, userID int64, emailTemplate *string) error {
+	if emailTemplate != nil && strings.TrimSpace(*emailTemplate) == "" {
+		emailTemplate = nil
+	}
+	if emailTemplate != nil {
+		if _, err := notificationPkg.RenderHTMLTemplate(*emailTemplate, notificationPkg.SampleNotificationData()); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	err = s.repo.UpdateUserEmailTemplate(ctx, userEmailID, userID, organizationIDs, emailTemplate)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("user email not found")
+		}
+		return fmt.Errorf("failed to update email template: %w", err)
+	}
+	after, _ := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	s.recordEmailEvent(ctx, types.AuditActionEmailUpdateTemplate, userEmailID, organizationID, before, after)
+	return nil
+}
+
+// UpdateEmailDigestMode 更新用户邮箱摘要模式，摘要模式下不再逐条发送流程通知
+func (s *emailService) UpdateEmailDigestMode(ctx context.Context, userEmailID int64, userID int64, digestMode string) error {
+	digestMode = strings.ToLower(strings.TrimSpace(digestMode))
+	if !types.IsValidDigestMode(digestMode) {
+		return fmt.Errorf("invalid digest mode: %s", digestMode)
+	}
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	err = s.repo.UpdateUserEmailDigestMode(ctx, userEmailID, userID, organizationIDs, digestMode)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("user email not found")
+		}
+		return fmt.Errorf("failed to update email digest mode: %w", err)
+	}
+	after, _ := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	s.recordEmailEvent(ctx, types.AuditActionEmailUpdateDigest, userEmailID, organizationID, before, after)
 	return nil
 }
 
 // DeleteUserEmail 删除用户邮箱
 func (s *emailService) DeleteUserEmail(ctx context.Context, userEmailID int64, userID int64) error {
-	err := s.repo.DeleteUserEmail(ctx, userEmailID, userID)
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	err = s.repo.DeleteUserEmail(ctx, userEmailID, userID, organizationIDs)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("user email not found")
 		}
 		return fmt.Errorf("failed to delete user email: %w", err)
 	}
+	s.recordEmailEvent(ctx, types.AuditActionEmailDelete, userEmailID, organizationID, before, nil)
 	return nil
 }
 
 // ===== 邮箱验证方法 =====
 // SendVerificationCode 发送验证码
 func (s *emailService) SendVerificationCode(ctx context.Context, userEmailID int64, userID int64) error {
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
 	// 获取用户邮箱信息
-	userEmail, err := s.repo.GetUserEmailByID(ctx, userEmailID, userID)
+	userEmail, err := s.repo.GetUserEmailByID(ctx, userEmailID, userID, organizationIDs)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("user email not found")
@@ -208,7 +322,7 @@ func (s *emailService) SendVerificationCode(ctx context.Context, userEmailID int
 	}
 
 	// 发送邮件
-	if err := s.sendVerificationEmail(userEmail.Email.Email, code); err != nil {
+	if err := s.sendVerificationEmail(ctx, userEmail.Email.Email, code); err != nil {
 		logger.Error("Failed to send verification email", err, "email", userEmail.Email.Email)
 		return fmt.Errorf("failed to send verification email: %w", err)
 	}
@@ -218,7 +332,7 @@ func (s *emailService) SendVerificationCode(ctx context.Context, userEmailID int
 }
 
 // SendVerificationCodeByEmail 基于 email 发送验证码
-func (s *emailService) SendVerificationCodeByEmail(ctx context.Context, userID int64, emailAddr string, remark *string) error {
+func (s *emailService) SendVerificationCodeByEmail(ctx context.Context, userID int64, organizationID int64, emailAddr string, remark *string) error {
 	// 标准化
 	emailAddr = strings.ToLower(strings.TrimSpace(emailAddr))
 	if !utils.IsValidEmail(emailAddr) {
@@ -231,18 +345,23 @@ func (s *emailService) SendVerificationCodeByEmail(ctx context.Context, userID i
 		}
 		remark = &trimmed
 	}
+	if err := s.checkOrganizationEditor(ctx, organizationID, userID); err != nil {
+		return err
+	}
 	// 获取或创建邮箱记录
 	emailRecord, err := s.repo.GetOrCreateEmail(ctx, emailAddr)
 	if err != nil {
 		return fmt.Errorf("failed to get or create email: %w", err)
 	}
 	// 获取或创建 user_email 未验证记录
-	userEmail, err := s.repo.GetUserEmailByUserAndEmailID(ctx, userID, emailRecord.ID)
+	userEmail, err := s.repo.GetUserEmailByUserAndEmailID(ctx, userID, organizationID, emailRecord.ID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			if userEmail, err = s.repo.AddUserEmail(ctx, userID, emailRecord.ID, remark); err != nil {
+			if userEmail, err = s.repo.AddUserEmail(ctx, userID, organizationID, emailRecord.ID, remark); err != nil {
 				return fmt.Errorf("failed to add user email: %w", err)
 			}
+			after, _ := s.userEmailSnapshot(ctx, userEmail.ID, userID, organizationIDsOf(organizationID))
+			s.recordEmailEvent(ctx, types.AuditActionEmailAdd, userEmail.ID, organizationID, nil, after)
 		} else {
 			return fmt.Errorf("failed to get user email: %w", err)
 		}
@@ -250,7 +369,7 @@ func (s *emailService) SendVerificationCodeByEmail(ctx context.Context, userID i
 		if userEmail.IsVerified {
 			return fmt.Errorf("email already added by user")
 		}
-		if err := s.repo.UpdateUserEmailRemark(ctx, userEmail.ID, userID, remark); err != nil {
+		if err := s.repo.UpdateUserEmailRemark(ctx, userEmail.ID, userID, organizationIDsOf(organizationID), remark); err != nil {
 			return fmt.Errorf("failed to update remark: %w", err)
 		}
 	}
@@ -258,7 +377,7 @@ func (s *emailService) SendVerificationCodeByEmail(ctx context.Context, userID i
 }
 
 // VerifyEmailByEmail 基于 email 校验验证码
-func (s *emailService) VerifyEmailByEmail(ctx context.Context, userID int64, emailAddr string, code string) error {
+func (s *emailService) VerifyEmailByEmail(ctx context.Context, userID int64, organizationID int64, emailAddr string, code string) error {
 	// 标准化
 	emailAddr = strings.ToLower(strings.TrimSpace(emailAddr))
 	code = strings.TrimSpace(code)
@@ -268,13 +387,16 @@ func (s *emailService) VerifyEmailByEmail(ctx context.Context, userID int64, ema
 	if !utils.IsValidVerificationCode(code) {
 		return fmt.Errorf("invalid or expired verification code")
 	}
+	if err := s.checkOrganizationEditor(ctx, organizationID, userID); err != nil {
+		return err
+	}
 	// 获取邮箱
 	emailRecord, err := s.repo.GetEmailByAddress(ctx, emailAddr)
 	if err != nil {
 		return fmt.Errorf("failed to get email: %w", err)
 	}
 	// 获取 user_email
-	userEmail, err := s.repo.GetUserEmailByUserAndEmailID(ctx, userID, emailRecord.ID)
+	userEmail, err := s.repo.GetUserEmailByUserAndEmailID(ctx, userID, organizationID, emailRecord.ID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("user email not found")
@@ -291,23 +413,97 @@ func (s *emailService) VerifyEmail(ctx context.Context, userEmailID int64, userI
 		return fmt.Errorf("failed to verify code: %w", err)
 	}
 
+	organizationIDs, err := s.editableOrganizationIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
 	// 标记邮箱为已验证
-	if err := s.repo.VerifyUserEmail(ctx, userEmailID, userID); err != nil {
+	before, organizationID := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	if err := s.repo.VerifyUserEmail(ctx, userEmailID, userID, organizationIDs); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("user email not found")
 		}
 		return fmt.Errorf("failed to verify user email: %w", err)
 	}
+	after, _ := s.userEmailSnapshot(ctx, userEmailID, userID, organizationIDs)
+	s.recordEmailEvent(ctx, types.AuditActionEmailVerify, userEmailID, organizationID, before, after)
 
 	logger.Info("Email verified successfully", "userEmailID", userEmailID, "userID", userID)
 	return nil
 }
 
+// ===== 组织权限 =====
+// checkOrganizationEditor 管理组织邮箱需要编辑者及以上角色，个人邮箱不做检查
+func (s *emailService) checkOrganizationEditor(ctx context.Context, organizationID int64, userID int64) error {
+	if organizationID <= 0 {
+		return nil
+	}
+	role, err := s.orgRepo.GetMemberRoleByUserID(ctx, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if !types.HasOrganizationRole(role, types.OrganizationRoleEditor) {
+		return fmt.Errorf("insufficient organization role")
+	}
+	return nil
+}
+
+// editableOrganizationIDs 获取用户具有编辑者及以上角色的组织ID列表
+func (s *emailService) editableOrganizationIDs(ctx context.Context, userID int64) ([]int64, error) {
+	roles, err := s.orgRepo.GetMemberRolesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+	var organizationIDs []int64
+	for organizationID, role := range roles {
+		if types.HasOrganizationRole(role, types.OrganizationRoleEditor) {
+			organizationIDs = append(organizationIDs, organizationID)
+		}
+	}
+	return organizationIDs, nil
+}
+
+// ===== 审计 =====
+// userEmailSnapshot 获取用户邮箱的审计快照及所属组织ID；记录不存在时快照为nil
+func (s *emailService) userEmailSnapshot(ctx context.Context, userEmailID int64, userID int64, organizationIDs []int64) (map[string]interface{}, int64) {
+	userEmail, err := s.repo.GetUserEmailByID(ctx, userEmailID, userID, organizationIDs)
+	if err != nil || userEmail == nil {
+		return nil, 0
+	}
+	snapshot := map[string]interface{}{
+		"organization_id":  userEmail.OrganizationID,
+		"remark":           userEmail.Remark,
+		"is_verified":      userEmail.IsVerified,
+		"filters":          userEmail.Filters,
+		"email_template":   userEmail.EmailTemplate,
+		"digest_mode":      userEmail.DigestMode,
+		"last_verified_at": userEmail.LastVerifiedAt,
+	}
+	if userEmail.Email != nil {
+		snapshot["email"] = userEmail.Email.Email
+	}
+	return snapshot, userEmail.OrganizationID
+}
+
+// recordEmailEvent 记录邮箱变更审计事件
+func (s *emailService) recordEmailEvent(ctx context.Context, action string, userEmailID int64, organizationID int64, before, after map[string]interface{}) {
+	s.auditor.Record(ctx, action, types.AuditResourceEmail, strconv.FormatInt(userEmailID, 10), organizationID, before, after)
+}
+
+// organizationIDsOf 将单个组织ID转换为列表，0表示个人邮箱
+func organizationIDsOf(organizationID int64) []int64 {
+	if organizationID <= 0 {
+		return nil
+	}
+	return []int64{organizationID}
+}
+
 // ===== 通知发送方法 =====
 // SendFlowNotification 发送流程通知
 func (s *emailService) SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error {
 	// 获取与合约相关用户的已验证邮箱列表
-	emailIDs, err := s.repo.GetContractRelatedVerifiedEmailIDs(ctx, standard, chainID, contractAddress)
+	relatedEmails, err := s.repo.GetContractRelatedVerifiedEmails(ctx, standard, chainID, contractAddress)
 	if err != nil {
 		logger.Error("Failed to get related verified emails", err,
 			"standard", standard, "chainID", chainID, "contract", contractAddress,
@@ -315,6 +511,16 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 		return fmt.Errorf("failed to get related verified emails: %w", err)
 	}
 
+	// 同一邮箱可能被多个相关用户绑定，任一绑定的过滤规则匹配即发送
+	var emailIDs []int64
+	emailLinks := make(map[int64][]types.RelatedVerifiedEmail)
+	for _, related := range relatedEmails {
+		if _, ok := emailLinks[related.EmailID]; !ok {
+			emailIDs = append(emailIDs, related.EmailID)
+		}
+		emailLinks[related.EmailID] = append(emailLinks[related.EmailID], related)
+	}
+
 	if len(emailIDs) == 0 {
 		logger.Debug("No related verified emails found for notification",
 			"standard", standard, "chainID", chainID, "contract", contractAddress,
@@ -326,12 +532,14 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 		"count", len(emailIDs), "standard", standard, "chainID", chainID,
 		"contract", contractAddress, "statusTo", statusTo, "initiator", initiatorAddress)
 
-	// 对每个邮箱发送通知
+	// 对每个邮箱发送通知，单个失败不影响其他邮箱，最终汇总失败数以便整体重试（已成功的会被发送日志去重）
+	var failedCount int
 	for _, emailID := range emailIDs {
 		// 检查是否已发送过此通知
 		exists, err := s.repo.CheckSendLogExists(ctx, emailID, flowID, statusTo)
 		if err != nil {
 			logger.Error("Failed to check send log", err, "emailID", emailID, "flowID", flowID)
+			failedCount++
 			continue
 		}
 		if exists {
@@ -340,6 +548,11 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 		}
 
 		var emailData *types.NotificationData
+		filterEvent := &types.NotificationFilterEvent{
+			ChainID:         chainID,
+			ContractAddress: contractAddress,
+			StatusTo:        statusTo,
+		}
 
 		// 获取链信息
 		chainInfo, err := s.chainRepo.GetChainByChainID(ctx, int64(chainID))
@@ -397,6 +610,7 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 			compoundTimeLock, err := s.timeLockRepo.GetCompoundTimeLockByChainAndAddress(ctx, chainID, contractAddress)
 			if err != nil {
 				logger.Error("Failed to get compound time lock", err, "chainID", chainID, "contractAddress", contractAddress)
+				failedCount++
 				continue
 			}
 
@@ -404,6 +618,7 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 			transaction, err := s.transactionRepo.GetQueueCompoundTransactionByFlowID(ctx, flowID, contractAddress)
 			if err != nil {
 				logger.Error("Failed to get queue compound transaction", err, "flowID", flowID, "contractAddress", contractAddress)
+				failedCount++
 				continue
 			}
 			if transaction == nil {
@@ -439,6 +654,9 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 				value = fmt.Sprintf("0 %s", nativeToken)
 			}
 
+			filterEvent.FunctionSignature = transaction.EventFunctionSignature
+			filterEvent.Value = transaction.EventValue
+
 			emailData = &types.NotificationData{
 				Standard:       strings.ToUpper(standard),
 				Contract:       contractAddress,
@@ -459,6 +677,18 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 			return fmt.Errorf("invalid standard")
 		}
 
+		if emailData == nil {
+			logger.Warn("Flow notification email not supported for standard yet", "standard", standard, "flowID", flowID)
+			return nil
+		}
+
+		// 按订阅过滤规则判断是否发送
+		link := s.matchEmailLink(emailID, emailLinks[emailID], filterEvent)
+		if link == nil {
+			logger.Debug("Notification filtered out by email subscription rules", "emailID", emailID, "flowID", flowID, "status", statusTo)
+			continue
+		}
+
 		emailData.BgColorFrom = template.CSS(fromBg)
 		emailData.TextColorFrom = template.CSS(fromText)
 		emailData.BgColorTo = template.CSS(toBg)
@@ -471,7 +701,7 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 		emailData.DashboardUrl = s.config.Email.EmailURL
 
 		// 发送通知邮件
-		if err := s.sendFlowNotificationEmail(ctx, emailID, emailData); err != nil {
+		if err := s.sendFlowNotificationEmail(ctx, emailID, emailData, link.EmailTemplate); err != nil {
 			logger.Error("Failed to send notification email", err, "emailID", emailID, "flowID", flowID)
 
 			// 记录发送失败日志
@@ -488,7 +718,10 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 				ErrorMessage:     func() *string { s := err.Error(); return &s }(),
 				RetryCount:       0,
 			}
-			s.repo.CreateSendLog(ctx, sendLog)
+			if logErr := s.repo.CreateSendLog(ctx, sendLog); logErr != nil {
+				logger.Error("Failed to create send log", logErr, "emailID", emailID, "flowID", flowID)
+			}
+			failedCount++
 			continue
 		}
 
@@ -512,9 +745,38 @@ func (s *emailService) SendFlowNotification(ctx context.Context, standard string
 		logger.Info("Flow notification sent", "emailID", emailID, "flowID", flowID, "status", statusTo)
 	}
 
+	if failedCount > 0 {
+		return fmt.Errorf("failed to send %d notification emails for flow %s", failedCount, flowID)
+	}
 	return nil
 }
 
+// matchEmailLink 返回实时发送且过滤规则匹配的绑定关系，优先选择设置了自定义模板的绑定，均不匹配时返回nil，无法解析的规则视为不匹配
+func (s *emailService) matchEmailLink(emailID int64, links []types.RelatedVerifiedEmail, event *types.NotificationFilterEvent) *types.RelatedVerifiedEmail {
+	var matchedLink *types.RelatedVerifiedEmail
+	for i := range links {
+		// 摘要模式的绑定由摘要调度器统一发送
+		if !types.IsRealtimeDigestMode(links[i].DigestMode) {
+			continue
+		}
+		matched, err := utils.MatchNotificationFilterJSON(links[i].Filters, event)
+		if err != nil {
+			logger.Error("Failed to parse email notification filter", err, "emailID", emailID)
+			continue
+		}
+		if !matched {
+			continue
+		}
+		if links[i].EmailTemplate != nil && *links[i].EmailTemplate != "" {
+			return &links[i]
+		}
+		if matchedLink == nil {
+			matchedLink = &links[i]
+		}
+	}
+	return matchedLink
+}
+
 // ===== 工具方法 =====
 // CleanExpiredCodes 清理过期验证码
 func (s *emailService) CleanExpiredCodes(ctx context.Context) error {
@@ -537,7 +799,7 @@ func (s *emailService) generateVerificationCode() (string, error) {
 }
 
 // sendVerificationEmail 发送验证码邮件
-func (s *emailService) sendVerificationEmail(toEmail, code string) error {
+func (s *emailService) sendVerificationEmail(ctx context.Context, toEmail, code string) error {
 	subject := "TimeLocker - Verify Your Email Address"
 	// 用VerificationEmail.html 模板生成邮件内容
 	body, err := os.ReadFile("email_templates/VerificationEmail.html")
@@ -548,11 +810,11 @@ func (s *emailService) sendVerificationEmail(toEmail, code string) error {
 	bodyStr := strings.ReplaceAll(string(body), "{{CODE}}", code)
 	bodyStr = strings.ReplaceAll(bodyStr, "{{EXPIRE}}", s.config.Email.VerificationCodeExpiry.String())
 
-	return s.sender.SendHTMLEmail(toEmail, subject, bodyStr)
+	return s.sender.SendHTMLEmail(ctx, toEmail, subject, bodyStr)
 }
 
 // sendFlowNotificationEmail 发送流程通知邮件
-func (s *emailService) sendFlowNotificationEmail(ctx context.Context, emailID int64, emailData *types.NotificationData) error {
+func (s *emailService) sendFlowNotificationEmail(ctx context.Context, emailID int64, emailData *types.NotificationData, customTemplate *string) error {
 	// 获取邮箱地址
 	emailRecord, err := s.getEmailByID(ctx, emailID)
 	if err != nil {
@@ -562,6 +824,15 @@ func (s *emailService) sendFlowNotificationEmail(ctx context.Context, emailID in
 		cases.Title(language.English).String(emailData.StatusFrom),
 		cases.Title(language.English).String(emailData.StatusTo))
 
+	// 优先使用用户自定义模板，渲染失败时回退到默认模板，避免通知丢失
+	if customTemplate != nil && *customTemplate != "" {
+		body, err := notificationPkg.RenderHTMLTemplate(*customTemplate, emailData)
+		if err == nil {
+			return s.sender.SendHTMLEmail(ctx, emailRecord.Email, subject, body)
+		}
+		logger.Warn("Failed to render custom email template, falling back to default", "emailID", emailID, "error", err.Error())
+	}
+
 	tmpl, err := template.ParseFiles("email_templates/FlowNotificationEmail.html")
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
@@ -574,7 +845,7 @@ func (s *emailService) sendFlowNotificationEmail(ctx context.Context, emailID in
 
 	body := buf.String()
 
-	return s.sender.SendHTMLEmail(emailRecord.Email, subject, body)
+	return s.sender.SendHTMLEmail(ctx, emailRecord.Email, subject, body)
 }
 
 // getEmailByID 根据ID获取邮箱记录
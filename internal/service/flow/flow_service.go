@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"timelocker-backend/internal/repository/organization"
 	"timelocker-backend/internal/repository/scanner"
 	"timelocker-backend/internal/repository/timelock"
+	"timelocker-backend/internal/repository/user"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
 	"timelocker-backend/pkg/utils"
@@ -28,13 +30,17 @@ type FlowService interface {
 type flowService struct {
 	flowRepo     scanner.FlowRepository
 	timelockRepo timelock.Repository
+	orgRepo      organization.Repository
+	userRepo     user.Repository
 }
 
 // NewFlowService 创建流程服务实例
-func NewFlowService(flowRepo scanner.FlowRepository, timelockRepo timelock.Repository) FlowService {
+func NewFlowService(flowRepo scanner.FlowRepository, timelockRepo timelock.Repository, orgRepo organization.Repository, userRepo user.Repository) FlowService {
 	return &flowService{
 		flowRepo:     flowRepo,
 		timelockRepo: timelockRepo,
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
 	}
 }
 
@@ -84,7 +90,21 @@ func (s *flowService) GetCompoundFlowList(ctx context.Context, userAddress strin
 	}
 	offset := (page - 1) * pageSize
 
-	flows, total, err := s.flowRepo.GetUserRelatedCompoundFlows(ctx, userAddress, req.Status, req.Standard, offset, pageSize)
+	// 账户下任一钱包相关的流程均可见
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		logger.Error("Failed to get account wallets", err, "user", userAddress)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+
+	// 用户所在组织共享的合约的流程同样可见
+	orgIDs, err := s.orgRepo.GetOrganizationIDsByWallets(ctx, wallets)
+	if err != nil {
+		logger.Error("Failed to get user organizations", err, "user", userAddress)
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+
+	flows, total, err := s.flowRepo.GetUserRelatedCompoundFlows(ctx, wallets, orgIDs, req.Status, req.Standard, offset, pageSize)
 	if err != nil {
 		logger.Error("Failed to get user related compound flows", err, "user", userAddress)
 		return nil, fmt.Errorf("failed to get user related compound flows: %w", err)
@@ -119,8 +139,20 @@ func (s *flowService) GetCompoundFlowListCount(ctx context.Context, userAddress
 		}
 	}
 
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		logger.Error("Failed to get account wallets", err, "user", userAddress)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+
+	orgIDs, err := s.orgRepo.GetOrganizationIDsByWallets(ctx, wallets)
+	if err != nil {
+		logger.Error("Failed to get user organizations", err, "user", userAddress)
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+
 	// 调用repository层获取数量统计
-	flowCount, err := s.flowRepo.GetUserRelatedCompoundFlowsCount(ctx, userAddress, req.Standard)
+	flowCount, err := s.flowRepo.GetUserRelatedCompoundFlowsCount(ctx, wallets, orgIDs, req.Standard)
 	if err != nil {
 		logger.Error("Failed to get user related compound flows count", err, "user", userAddress)
 		return nil, fmt.Errorf("failed to get user related compound flows count: %w", err)
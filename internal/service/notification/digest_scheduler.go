@@ -0,0 +1,450 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+	"timelocker-backend/internal/config"
+	chainRepo "timelocker-backend/internal/repository/chain"
+	"timelocker-backend/internal/repository/notification"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	emailPkg "timelocker-backend/pkg/email"
+	"timelocker-backend/pkg/logger"
+	notificationPkg "timelocker-backend/pkg/notification"
+	"timelocker-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// digestSectionLimit 摘要每个分类最多列出的流程数量
+const digestSectionLimit = 20
+
+// digestRecipient 摘要接收方
+type digestRecipient struct {
+	channel        string
+	configID       int64
+	userAddress    string
+	organizationID int64 // 不为0时按组织关注的合约汇总
+	filters        *string
+	send           func(summary *types.DigestSummary) error
+}
+
+// DigestScheduler 通知摘要调度器，按周期为摘要模式的渠道配置和邮箱汇总发送流程状态
+type DigestScheduler struct {
+	config         *config.Config
+	digestRepo     notification.DigestRepository
+	chainRepo      chainRepo.Repository
+	telegramSender *notificationPkg.TelegramSender
+	larkSender     *notificationPkg.LarkSender
+	feishuSender   *notificationPkg.FeishuSender
+	emailSender    *emailPkg.SMTPSender
+	stopCh         chan struct{}
+	stopOnce       sync.Once
+	wg             sync.WaitGroup
+}
+
+// NewDigestScheduler 创建通知摘要调度器
+func NewDigestScheduler(cfg *config.Config, digestRepo notification.DigestRepository, chainRepo chainRepo.Repository, keyring *crypto.SecretKeyring) *DigestScheduler {
+	return &DigestScheduler{
+		config:         cfg,
+		digestRepo:     digestRepo,
+		chainRepo:      chainRepo,
+		telegramSender: notificationPkg.NewTelegramSender(keyring),
+		larkSender:     notificationPkg.NewLarkSender(keyring),
+		feishuSender:   notificationPkg.NewFeishuSender(keyring),
+		emailSender:    emailPkg.NewSMTPSender(&cfg.Email),
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Start 启动摘要调度
+func (s *DigestScheduler) Start(ctx context.Context) {
+	interval := s.config.Notification.DigestCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+
+	logger.Info("Starting notification digest scheduler", "check_interval", interval, "hour", s.digestHour(), "weekday", s.digestWeekday())
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		s.runOnce(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				s.runOnce(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// Stop 停止摘要调度并等待当前批次完成
+func (s *DigestScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+	logger.Info("Notification digest scheduler stopped")
+}
+
+// runOnce 处理所有摘要模式最近一个已结束的周期
+func (s *DigestScheduler) runOnce(ctx context.Context, now time.Time) {
+	for _, mode := range []string{types.DigestModeDaily, types.DigestModeWeekly} {
+		periodStart, periodEnd := s.digestPeriod(mode, now)
+		if err := s.processMode(ctx, mode, periodStart, periodEnd); err != nil {
+			logger.Error("Failed to process notification digest", err, "mode", mode, "period_start", periodStart)
+		}
+	}
+}
+
+// digestPeriod 计算最近一个已结束的摘要周期 [start, end)
+func (s *DigestScheduler) digestPeriod(mode string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), s.digestHour(), 0, 0, 0, time.UTC)
+	if end.After(now) {
+		end = end.AddDate(0, 0, -1)
+	}
+
+	if mode == types.DigestModeWeekly {
+		for end.Weekday() != s.digestWeekday() {
+			end = end.AddDate(0, 0, -1)
+		}
+		return end.AddDate(0, 0, -7), end
+	}
+	return end.AddDate(0, 0, -1), end
+}
+
+func (s *DigestScheduler) digestHour() int {
+	hour := s.config.Notification.DigestHour
+	if hour < 0 || hour > 23 {
+		return 9
+	}
+	return hour
+}
+
+func (s *DigestScheduler) digestWeekday() time.Weekday {
+	weekday := s.config.Notification.DigestWeekday
+	if weekday < 0 || weekday > 6 {
+		return time.Monday
+	}
+	return time.Weekday(weekday)
+}
+
+// processMode 为指定摘要模式的所有接收方发送摘要
+func (s *DigestScheduler) processMode(ctx context.Context, mode string, periodStart, periodEnd time.Time) error {
+	configs, err := s.digestRepo.GetDigestConfigs(ctx, mode)
+	if err != nil {
+		return fmt.Errorf("failed to get digest configs: %w", err)
+	}
+	emailRecipients, err := s.digestRepo.GetDigestEmailRecipients(ctx, mode)
+	if err != nil {
+		return fmt.Errorf("failed to get digest email recipients: %w", err)
+	}
+
+	var recipients []digestRecipient
+	for _, cfg := range configs.TelegramConfigs {
+		recipients = append(recipients, digestRecipient{
+			channel: string(types.ChannelTelegram), configID: int64(cfg.ID), userAddress: cfg.UserAddress, organizationID: cfg.OrganizationID, filters: cfg.Filters,
+			send: func(summary *types.DigestSummary) error {
+				return s.telegramSender.SendMessage(ctx, cfg.BotToken, cfg.ChatID, s.renderDigestText(ctx, summary))
+			},
+		})
+	}
+	for _, cfg := range configs.LarkConfigs {
+		recipients = append(recipients, digestRecipient{
+			channel: string(types.ChannelLark), configID: int64(cfg.ID), userAddress: cfg.UserAddress, organizationID: cfg.OrganizationID, filters: cfg.Filters,
+			send: func(summary *types.DigestSummary) error {
+				return s.larkSender.SendMessage(ctx, cfg.WebhookURL, cfg.Secret, s.renderDigestText(ctx, summary))
+			},
+		})
+	}
+	for _, cfg := range configs.FeishuConfigs {
+		recipients = append(recipients, digestRecipient{
+			channel: string(types.ChannelFeishu), configID: int64(cfg.ID), userAddress: cfg.UserAddress, organizationID: cfg.OrganizationID, filters: cfg.Filters,
+			send: func(summary *types.DigestSummary) error {
+				return s.feishuSender.SendMessage(ctx, cfg.WebhookURL, cfg.Secret, s.renderDigestText(ctx, summary))
+			},
+		})
+	}
+	for _, recipient := range emailRecipients {
+		recipients = append(recipients, digestRecipient{
+			channel: types.OutboxChannelEmail, configID: recipient.UserEmailID, userAddress: recipient.WalletAddress, organizationID: recipient.OrganizationID, filters: recipient.Filters,
+			send: func(summary *types.DigestSummary) error {
+				body, err := s.renderDigestHTML(ctx, summary)
+				if err != nil {
+					return err
+				}
+				subject := fmt.Sprintf("TimeLocker %s Digest: %d flows", digestTitle(summary.PeriodType), summary.Total())
+				return s.emailSender.SendHTMLEmail(ctx, recipient.Email, subject, body)
+			},
+		})
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	// 同一用户或组织的多个接收方共用流程查询结果
+	flowsByUser := make(map[string][]types.TimelockTransactionFlow)
+	var sent, skipped, failed int
+	for _, recipient := range recipients {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stopCh:
+			return nil
+		default:
+		}
+
+		status := s.deliver(ctx, mode, periodStart, periodEnd, recipient, flowsByUser)
+		switch status {
+		case types.DigestSendStatusSuccess:
+			sent++
+		case types.DigestSendStatusSkipped:
+			skipped++
+		case types.DigestSendStatusFailed:
+			failed++
+		}
+	}
+
+	if sent+skipped+failed > 0 {
+		logger.Info("Notification digest processed", "mode", mode, "period_start", periodStart, "period_end", periodEnd, "sent", sent, "skipped", skipped, "failed", failed)
+	}
+	return nil
+}
+
+// deliver 为单个接收方生成并发送摘要，返回本次记录的发送状态，已处理过或被其他实例认领的周期返回空字符串
+func (s *DigestScheduler) deliver(ctx context.Context, mode string, periodStart, periodEnd time.Time, recipient digestRecipient, flowsByUser map[string][]types.TimelockTransactionFlow) string {
+	existing, err := s.digestRepo.GetDigestSendLog(ctx, recipient.channel, recipient.configID, mode, periodStart)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ""
+	}
+	if existing != nil {
+		if existing.SendStatus != types.DigestSendStatusFailed || existing.RetryCount >= s.config.Notification.DigestMaxRetries {
+			return ""
+		}
+	}
+
+	flowKey := strings.ToLower(recipient.userAddress)
+	if recipient.organizationID > 0 {
+		flowKey = fmt.Sprintf("org:%d", recipient.organizationID)
+	}
+	flows, ok := flowsByUser[flowKey]
+	if !ok {
+		if recipient.organizationID > 0 {
+			flows, err = s.digestRepo.GetOrganizationDigestFlows(ctx, recipient.organizationID, periodStart, periodEnd)
+		} else {
+			flows, err = s.digestRepo.GetUserDigestFlows(ctx, flowKey, periodStart, periodEnd)
+		}
+		if err != nil {
+			return ""
+		}
+		flowsByUser[flowKey] = flows
+	}
+
+	summary := s.buildDigestSummary(mode, periodStart, periodEnd, flows, recipient)
+
+	log := &types.DigestSendLog{
+		Channel:     recipient.channel,
+		ConfigID:    recipient.configID,
+		PeriodType:  mode,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		FlowCount:   summary.Total(),
+		SentAt:      time.Now(),
+	}
+
+	// 发送前认领该周期的记录，其他调度实例已认领或已处理的周期不再发送
+	claimed, err := s.digestRepo.ClaimDigestSend(ctx, log, s.config.Notification.DigestMaxRetries)
+	if err != nil || !claimed {
+		return ""
+	}
+
+	if summary.Total() == 0 {
+		log.SendStatus = types.DigestSendStatusSkipped
+	} else if sendErr := recipient.send(summary); sendErr != nil {
+		errMsg := sendErr.Error()
+		log.SendStatus = types.DigestSendStatusFailed
+		log.ErrorMessage = &errMsg
+		logger.Error("Failed to send notification digest", sendErr, "channel", recipient.channel, "configID", recipient.configID, "mode", mode)
+	} else {
+		log.SendStatus = types.DigestSendStatusSuccess
+	}
+
+	if err := s.digestRepo.SaveDigestSendLog(ctx, log); err != nil {
+		logger.Error("Failed to save digest send log", err, "channel", recipient.channel, "configID", recipient.configID)
+	}
+	return log.SendStatus
+}
+
+// buildDigestSummary 按分类汇总流程，接收方的过滤规则仅按合约和链进行筛选
+func (s *DigestScheduler) buildDigestSummary(mode string, periodStart, periodEnd time.Time, flows []types.TimelockTransactionFlow, recipient digestRecipient) *types.DigestSummary {
+	summary := &types.DigestSummary{
+		PeriodType:  mode,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	}
+
+	filter, err := utils.ParseNotificationFilter(recipient.filters)
+	if err != nil {
+		logger.Error("Failed to parse notification filter for digest", err, "channel", recipient.channel, "configID", recipient.configID)
+		return summary
+	}
+
+	inPeriod := func(t *time.Time) bool {
+		return t != nil && !t.Before(periodStart) && t.Before(periodEnd)
+	}
+	expiringBefore := periodEnd.Add(periodEnd.Sub(periodStart))
+
+	for _, flow := range flows {
+		if !utils.MatchNotificationFilterScope(filter, flow.ChainID, flow.ContractAddress) {
+			continue
+		}
+		if inPeriod(flow.QueuedAt) {
+			summary.NewQueued = append(summary.NewQueued, flow)
+		}
+		if flow.Status == types.NotificationStatus.Ready {
+			summary.NowReady = append(summary.NowReady, flow)
+			if flow.ExpiredAt != nil && flow.ExpiredAt.Before(expiringBefore) {
+				summary.ExpiringSoon = append(summary.ExpiringSoon, flow)
+			}
+		}
+		if inPeriod(flow.ExecutedAt) {
+			summary.Executed = append(summary.Executed, flow)
+		}
+		if inPeriod(flow.CancelledAt) {
+			summary.Cancelled = append(summary.Cancelled, flow)
+		}
+	}
+	return summary
+}
+
+// digestSection 摘要渲染使用的分类
+type digestSection struct {
+	Title string
+	Emoji string
+	Items []string
+	More  int
+	Count int
+}
+
+// buildDigestSections 构建摘要分类及每个流程的展示文本
+func (s *DigestScheduler) buildDigestSections(ctx context.Context, summary *types.DigestSummary) []digestSection {
+	networks := make(map[int]string)
+	network := func(chainID int) string {
+		if name, ok := networks[chainID]; ok {
+			return name
+		}
+		name := fmt.Sprintf("Chain %d", chainID)
+		if chainInfo, err := s.chainRepo.GetChainByChainID(ctx, int64(chainID)); err == nil && chainInfo != nil {
+			name = chainInfo.DisplayName
+		}
+		networks[chainID] = name
+		return name
+	}
+
+	describe := func(flow types.TimelockTransactionFlow, timeLabel string, t *time.Time) string {
+		flowID := flow.FlowID
+		if len(flowID) > 18 {
+			flowID = fmt.Sprintf("%s...%s", flowID[:10], flowID[len(flowID)-6:])
+		}
+		item := fmt.Sprintf("[%s] %s | %s", network(flow.ChainID), flow.ContractAddress, flowID)
+		if t != nil {
+			item += fmt.Sprintf(" | %s %s", timeLabel, t.UTC().Format("2006-01-02 15:04"))
+		}
+		return item
+	}
+
+	build := func(title, emoji, timeLabel string, flows []types.TimelockTransactionFlow, timeOf func(types.TimelockTransactionFlow) *time.Time) digestSection {
+		section := digestSection{Title: title, Emoji: emoji, Count: len(flows)}
+		for i, flow := range flows {
+			if i >= digestSectionLimit {
+				section.More = len(flows) - digestSectionLimit
+				break
+			}
+			section.Items = append(section.Items, describe(flow, timeLabel, timeOf(flow)))
+		}
+		return section
+	}
+
+	return []digestSection{
+		build("New Queued", "🆕", "ETA", summary.NewQueued, func(f types.TimelockTransactionFlow) *time.Time { return f.Eta }),
+		build("Now Ready", "✅", "Expires", summary.NowReady, func(f types.TimelockTransactionFlow) *time.Time { return f.ExpiredAt }),
+		build("Expiring Soon", "⏰", "Expires", summary.ExpiringSoon, func(f types.TimelockTransactionFlow) *time.Time { return f.ExpiredAt }),
+		build("Executed", "🎯", "At", summary.Executed, func(f types.TimelockTransactionFlow) *time.Time { return f.ExecutedAt }),
+		build("Cancelled", "❌", "At", summary.Cancelled, func(f types.TimelockTransactionFlow) *time.Time { return f.CancelledAt }),
+	}
+}
+
+// renderDigestText 生成渠道摘要消息
+func (s *DigestScheduler) renderDigestText(ctx context.Context, summary *types.DigestSummary) string {
+	message := "━━━━━━━━━━━━━━━━\n"
+	message += fmt.Sprintf("📰 TimeLocker %s Digest\n", digestTitle(summary.PeriodType))
+	message += fmt.Sprintf("%s ~ %s UTC\n", summary.PeriodStart.UTC().Format("2006-01-02 15:04"), summary.PeriodEnd.UTC().Format("2006-01-02 15:04"))
+	message += "━━━━━━━━━━━━━━━━\n"
+	for _, section := range s.buildDigestSections(ctx, summary) {
+		message += fmt.Sprintf("%s %s (%d)\n", section.Emoji, section.Title, section.Count)
+		for _, item := range section.Items {
+			message += fmt.Sprintf("    • %s\n", item)
+		}
+		if section.More > 0 {
+			message += fmt.Sprintf("    … and %d more\n", section.More)
+		}
+	}
+	message += fmt.Sprintf("🔗 Dashboard : %s\n", s.config.Email.EmailURL)
+	return message
+}
+
+// digestEmailTemplate 摘要邮件模板
+var digestEmailTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #0f172a;">
+  <h2>TimeLocker {{.Title}} Digest</h2>
+  <p style="color: #64748b;">{{.PeriodStart}} ~ {{.PeriodEnd}} UTC</p>
+  {{range .Sections}}
+  <h3>{{.Emoji}} {{.Title}} ({{.Count}})</h3>
+  {{if .Items}}<ul>{{range .Items}}<li style="font-family: monospace;">{{.}}</li>{{end}}</ul>{{end}}
+  {{if .More}}<p style="color: #64748b;">… and {{.More}} more</p>{{end}}
+  {{end}}
+  <p><a href="{{.DashboardUrl}}">Open Dashboard</a></p>
+</body>
+</html>`))
+
+// renderDigestHTML 生成摘要邮件内容
+func (s *DigestScheduler) renderDigestHTML(ctx context.Context, summary *types.DigestSummary) (string, error) {
+	var buf bytes.Buffer
+	err := digestEmailTemplate.Execute(&buf, map[string]interface{}{
+		"Title":        digestTitle(summary.PeriodType),
+		"PeriodStart":  summary.PeriodStart.UTC().Format("2006-01-02 15:04"),
+		"PeriodEnd":    summary.PeriodEnd.UTC().Format("2006-01-02 15:04"),
+		"Sections":     s.buildDigestSections(ctx, summary),
+		"DashboardUrl": s.config.Email.EmailURL,
+	})
+	if err != nil {
+		return "", fmt.Errorf("execute digest template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// digestTitle 摘要周期标题
+func digestTitle(mode string) string {
+	if mode == types.DigestModeWeekly {
+		return "Weekly"
+	}
+	return "Daily"
+}
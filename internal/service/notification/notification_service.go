@@ -9,9 +9,13 @@ import (
 	"timelocker-backend/internal/config"
 	chainRepo "timelocker-backend/internal/repository/chain"
 	"timelocker-backend/internal/repository/notification"
+	organizationRepo "timelocker-backend/internal/repository/organization"
 	"timelocker-backend/internal/repository/scanner"
 	timelockRepo "timelocker-backend/internal/repository/timelock"
+	userRepo "timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
 	"timelocker-backend/pkg/logger"
 	notificationPkg "timelocker-backend/pkg/notification"
 	"timelocker-backend/pkg/utils"
@@ -31,112 +35,275 @@ type NotificationService interface {
 
 	// 通知发送
 	SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error
+
+	// 消息模板预览
+	PreviewTemplate(ctx context.Context, req *types.PreviewNotificationTemplateRequest) (*types.PreviewNotificationTemplateResponse, error)
+
+	// 通知发件箱
+	GetOutboxList(ctx context.Context, userAddress string, req *types.GetNotificationOutboxListRequest) (*types.GetNotificationOutboxListResponse, error)
+	RedeliverOutbox(ctx context.Context, userAddress string, id int64) error
+
+	// 平台运维告警
+	SendAdminAlert(ctx context.Context, message string) error
 }
 
 // notificationService 通知服务实现
 type notificationService struct {
 	repo            notification.NotificationRepository
+	outboxRepo      notification.OutboxRepository
 	chainRepo       chainRepo.Repository
 	timelockRepo    timelockRepo.Repository
 	transactionRepo scanner.TransactionRepository
+	orgRepo         organizationRepo.Repository
+	userRepo        userRepo.Repository
+	auditor         audit.Recorder
 	config          *config.Config
+	keyring         *crypto.SecretKeyring
 	telegramSender  *notificationPkg.TelegramSender
 	larkSender      *notificationPkg.LarkSender
 	feishuSender    *notificationPkg.FeishuSender
 }
 
 // NewNotificationService 创建通知服务实例
-func NewNotificationService(repo notification.NotificationRepository, chainRepo chainRepo.Repository, timelockRepo timelockRepo.Repository, transactionRepo scanner.TransactionRepository, config *config.Config) NotificationService {
+func NewNotificationService(repo notification.NotificationRepository, outboxRepo notification.OutboxRepository, chainRepo chainRepo.Repository, timelockRepo timelockRepo.Repository, transactionRepo scanner.TransactionRepository, orgRepo organizationRepo.Repository, userRepo userRepo.Repository, auditor audit.Recorder, config *config.Config, keyring *crypto.SecretKeyring) NotificationService {
 	return &notificationService{
 		repo:            repo,
+		outboxRepo:      outboxRepo,
 		chainRepo:       chainRepo,
 		timelockRepo:    timelockRepo,
 		transactionRepo: transactionRepo,
+		orgRepo:         orgRepo,
+		userRepo:        userRepo,
+		auditor:         auditor,
 		config:          config,
-		telegramSender:  notificationPkg.NewTelegramSender(),
-		larkSender:      notificationPkg.NewLarkSender(),
-		feishuSender:    notificationPkg.NewFeishuSender(),
+		keyring:         keyring,
+		telegramSender:  notificationPkg.NewTelegramSender(keyring),
+		larkSender:      notificationPkg.NewLarkSender(keyring),
+		feishuSender:    notificationPkg.NewFeishuSender(keyring),
 	}
 }
 
 // ===== 通用配置管理 =====
 // CreateNotificationConfig 创建通知配置
 func (s *notificationService) CreateNotificationConfig(ctx context.Context, userAddress string, req *types.CreateNotificationRequest) error {
+	if err := s.checkOrganizationEditor(ctx, req.OrganizationID, userAddress); err != nil {
+		return err
+	}
+	filters, err := utils.EncodeNotificationFilter(req.Filters)
+	if err != nil {
+		return fmt.Errorf("invalid filters: %w", err)
+	}
+	messageTemplate, err := validateMessageTemplate(&req.MessageTemplate)
+	if err != nil {
+		return err
+	}
+	digestMode := strings.ToLower(strings.TrimSpace(req.DigestMode))
+	if digestMode == "" {
+		digestMode = types.DigestModeOff
+	}
+	if !types.IsValidDigestMode(digestMode) {
+		return fmt.Errorf("invalid digest mode: %s", req.DigestMode)
+	}
+
 	switch strings.ToLower(req.Channel) {
 	case "telegram":
 		if req.BotToken == "" || req.ChatID == "" {
 			return fmt.Errorf("bot_token and chat_id are required")
 		}
-		err := s.createTelegramConfig(ctx, userAddress, req.Name, req.BotToken, req.ChatID)
-		if err != nil {
+		if err := s.createTelegramConfig(ctx, userAddress, req.OrganizationID, req.Name, req.BotToken, req.ChatID, filters, messageTemplate, digestMode); err != nil {
 			return err
 		}
-		return nil
 
 	case "lark":
 		if req.WebhookURL == "" {
 			return fmt.Errorf("webhook_url are required")
 		}
-		err := s.createLarkConfig(ctx, userAddress, req.Name, req.WebhookURL, req.Secret)
-		if err != nil {
+		if err := s.createLarkConfig(ctx, userAddress, req.OrganizationID, req.Name, req.WebhookURL, req.Secret, filters, messageTemplate, digestMode); err != nil {
 			return err
 		}
-		return nil
 
 	case "feishu":
 		if req.WebhookURL == "" {
 			return fmt.Errorf("webhook_url are required")
 		}
-		err := s.createFeishuConfig(ctx, userAddress, req.Name, req.WebhookURL, req.Secret)
-		if err != nil {
+		if err := s.createFeishuConfig(ctx, userAddress, req.OrganizationID, req.Name, req.WebhookURL, req.Secret, filters, messageTemplate, digestMode); err != nil {
 			return err
 		}
-		return nil
+	default:
+		return fmt.Errorf("invalid channel: %s", req.Channel)
 	}
-	return fmt.Errorf("invalid channel: %s", req.Channel)
+
+	channel := strings.ToLower(req.Channel)
+	s.auditor.Record(ctx, types.AuditActionNotificationCreate, types.AuditResourceNotificationConfig,
+		channel+":"+req.Name, req.OrganizationID, nil, s.configSnapshot(ctx, channel, userAddress, req.OrganizationID, req.Name))
+	return nil
 }
 
 // UpdateNotificationConfig 更新通知配置
 // 不需要更新的字段可以不填
 func (s *notificationService) UpdateNotificationConfig(ctx context.Context, userAddress string, req *types.UpdateNotificationRequest) error {
-	switch strings.ToLower(*req.Channel) {
+	if err := s.checkOrganizationEditor(ctx, req.OrganizationID, userAddress); err != nil {
+		return err
+	}
+	// 传入过滤规则时才更新，空规则表示清除过滤
+	var filters **string
+	if req.Filters != nil {
+		encoded, err := utils.EncodeNotificationFilter(req.Filters)
+		if err != nil {
+			return fmt.Errorf("invalid filters: %w", err)
+		}
+		filters = &encoded
+	}
+	var messageTemplate **string
+	if req.MessageTemplate != nil {
+		validated, err := validateMessageTemplate(req.MessageTemplate)
+		if err != nil {
+			return err
+		}
+		messageTemplate = &validated
+	}
+	var digestMode *string
+	if req.DigestMode != nil {
+		mode := strings.ToLower(strings.TrimSpace(*req.DigestMode))
+		if !types.IsValidDigestMode(mode) {
+			return fmt.Errorf("invalid digest mode: %s", *req.DigestMode)
+		}
+		digestMode = &mode
+	}
+
+	channel := strings.ToLower(*req.Channel)
+	before := s.configSnapshot(ctx, channel, userAddress, req.OrganizationID, *req.Name)
+
+	var err error
+	switch channel {
 	case "telegram":
-		if req.BotToken == nil && req.ChatID == nil && req.IsActive == nil {
+		if req.BotToken == nil && req.ChatID == nil && req.IsActive == nil && req.Filters == nil && req.MessageTemplate == nil && req.DigestMode == nil {
 			return fmt.Errorf("at least one field must be provided")
 		}
-		return s.updateTelegramConfig(ctx, userAddress, req.Name, req.BotToken, req.ChatID, req.IsActive)
+		err = s.updateTelegramConfig(ctx, userAddress, req.OrganizationID, req.Name, req.BotToken, req.ChatID, req.IsActive, filters, messageTemplate, digestMode)
 	case "lark":
-		if req.WebhookURL == nil && req.Secret == nil && req.IsActive == nil {
+		if req.WebhookURL == nil && req.Secret == nil && req.IsActive == nil && req.Filters == nil && req.MessageTemplate == nil && req.DigestMode == nil {
 			return fmt.Errorf("at least one field must be provided")
 		}
-		return s.updateLarkConfig(ctx, userAddress, req.Name, req.WebhookURL, req.Secret, req.IsActive)
+		err = s.updateLarkConfig(ctx, userAddress, req.OrganizationID, req.Name, req.WebhookURL, req.Secret, req.IsActive, filters, messageTemplate, digestMode)
 	case "feishu":
-		if req.WebhookURL == nil && req.Secret == nil && req.IsActive == nil {
+		if req.WebhookURL == nil && req.Secret == nil && req.IsActive == nil && req.Filters == nil && req.MessageTemplate == nil && req.DigestMode == nil {
 			return fmt.Errorf("at least one field must be provided")
 		}
-		return s.updateFeishuConfig(ctx, userAddress, req.Name, req.WebhookURL, req.Secret, req.IsActive)
+		err = s.updateFeishuConfig(ctx, userAddress, req.OrganizationID, req.Name, req.WebhookURL, req.Secret, req.IsActive, filters, messageTemplate, digestMode)
+	default:
+		return fmt.Errorf("invalid channel: %s", *req.Channel)
+	}
+	if err != nil {
+		return err
+	}
+
+	after := s.configSnapshot(ctx, channel, userAddress, req.OrganizationID, *req.Name)
+	if after != nil {
+		// 密钥类字段不进入快照，只标记是否被替换
+		after["secrets_updated"] = isSecretUpdated(req.BotToken) || isSecretUpdated(req.WebhookURL) || isSecretUpdated(req.Secret)
 	}
-	return fmt.Errorf("invalid channel: %s", *req.Channel)
+	s.auditor.Record(ctx, types.AuditActionNotificationUpdate, types.AuditResourceNotificationConfig,
+		channel+":"+*req.Name, req.OrganizationID, before, after)
+	return nil
 }
 
 // DeleteNotificationConfig 删除通知配置
 func (s *notificationService) DeleteNotificationConfig(ctx context.Context, userAddress string, req *types.DeleteNotificationRequest) error {
-	switch strings.ToLower(req.Channel) {
+	if err := s.checkOrganizationEditor(ctx, req.OrganizationID, userAddress); err != nil {
+		return err
+	}
+
+	channel := strings.ToLower(req.Channel)
+	before := s.configSnapshot(ctx, channel, userAddress, req.OrganizationID, req.Name)
+
+	var err error
+	switch channel {
 	case "telegram":
-		return s.deleteTelegramConfig(ctx, userAddress, req.Name)
+		err = s.deleteTelegramConfig(ctx, userAddress, req.OrganizationID, req.Name)
 	case "lark":
-		return s.deleteLarkConfig(ctx, userAddress, req.Name)
+		err = s.deleteLarkConfig(ctx, userAddress, req.OrganizationID, req.Name)
 	case "feishu":
-		return s.deleteFeishuConfig(ctx, userAddress, req.Name)
+		err = s.deleteFeishuConfig(ctx, userAddress, req.OrganizationID, req.Name)
+	default:
+		return fmt.Errorf("invalid channel: %s", req.Channel)
+	}
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("invalid channel: %s", req.Channel)
+
+	s.auditor.Record(ctx, types.AuditActionNotificationDelete, types.AuditResourceNotificationConfig,
+		channel+":"+req.Name, req.OrganizationID, before, nil)
+	return nil
+}
+
+// configSnapshot 获取通知配置的审计快照，不包含Bot Token、Webhook URL和签名密钥；配置不存在时返回nil
+func (s *notificationService) configSnapshot(ctx context.Context, channel, userAddress string, organizationID int64, name string) map[string]interface{} {
+	snapshot := map[string]interface{}{"channel": channel, "name": name}
+	switch channel {
+	case "telegram":
+		config, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
+		if err != nil || config == nil {
+			return nil
+		}
+		snapshot["chat_id"] = config.ChatID
+		snapshot["is_active"] = config.IsActive
+		snapshot["filters"] = config.Filters
+		snapshot["message_template"] = config.MessageTemplate
+		snapshot["digest_mode"] = config.DigestMode
+	case "lark":
+		config, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
+		if err != nil || config == nil {
+			return nil
+		}
+		snapshot["is_active"] = config.IsActive
+		snapshot["filters"] = config.Filters
+		snapshot["message_template"] = config.MessageTemplate
+		snapshot["digest_mode"] = config.DigestMode
+	case "feishu":
+		config, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
+		if err != nil || config == nil {
+			return nil
+		}
+		snapshot["is_active"] = config.IsActive
+		snapshot["filters"] = config.Filters
+		snapshot["message_template"] = config.MessageTemplate
+		snapshot["digest_mode"] = config.DigestMode
+	default:
+		return nil
+	}
+	return snapshot
+}
+
+// isSecretUpdated 请求中的密钥字段是否为新值（回传脱敏值表示不修改）
+func isSecretUpdated(value *string) bool {
+	return value != nil && *value != types.MaskedSecret
+}
+
+// checkOrganizationEditor 管理组织配置需要编辑者及以上角色，个人配置不做检查
+func (s *notificationService) checkOrganizationEditor(ctx context.Context, organizationID int64, userAddress string) error {
+	if organizationID <= 0 {
+		return nil
+	}
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, organizationID, wallets)
+	if err != nil {
+		return fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if !types.HasOrganizationRole(role, types.OrganizationRoleEditor) {
+		return fmt.Errorf("insufficient organization role")
+	}
+	return nil
 }
 
 // ===== 创建配置 =====
 // createTelegramConfig 创建Telegram配置
-func (s *notificationService) createTelegramConfig(ctx context.Context, userAddress string, name string, botToken string, chatID string) error {
+func (s *notificationService) createTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string, botToken string, chatID string, filters *string, messageTemplate *string, digestMode string) error {
 	// 检查是否已存在同名配置
-	existing, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, name)
+	existing, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return fmt.Errorf("failed to check existing telegram config: %w", err)
 	}
@@ -144,12 +311,21 @@ func (s *notificationService) createTelegramConfig(ctx context.Context, userAddr
 		return fmt.Errorf("telegram config with name '%s' already exists", name)
 	}
 
+	botToken, err = s.keyring.Encrypt(botToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt bot token: %w", err)
+	}
+
 	config := &types.TelegramConfig{
-		UserAddress: userAddress,
-		Name:        name,
-		BotToken:    botToken,
-		ChatID:      chatID,
-		IsActive:    true,
+		UserAddress:     userAddress,
+		OrganizationID:  organizationID,
+		Name:            name,
+		BotToken:        botToken,
+		ChatID:          chatID,
+		IsActive:        true,
+		Filters:         filters,
+		MessageTemplate: messageTemplate,
+		DigestMode:      digestMode,
 	}
 
 	if err := s.repo.CreateTelegramConfig(ctx, config); err != nil {
@@ -160,9 +336,9 @@ func (s *notificationService) createTelegramConfig(ctx context.Context, userAddr
 }
 
 // createLarkConfig 创建Lark配置
-func (s *notificationService) createLarkConfig(ctx context.Context, userAddress string, name string, webhookURL string, secret string) error {
+func (s *notificationService) createLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string, webhookURL string, secret string, filters *string, messageTemplate *string, digestMode string) error {
 	// 检查是否已存在同名配置
-	existing, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, name)
+	existing, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return fmt.Errorf("failed to check existing lark config: %w", err)
 	}
@@ -170,12 +346,25 @@ func (s *notificationService) createLarkConfig(ctx context.Context, userAddress
 		return fmt.Errorf("lark config with name '%s' already exists", name)
 	}
 
+	webhookURL, err = s.keyring.Encrypt(webhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook url: %w", err)
+	}
+	secret, err = s.keyring.Encrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
 	config := &types.LarkConfig{
-		UserAddress: userAddress,
-		Name:        name,
-		WebhookURL:  webhookURL,
-		Secret:      secret,
-		IsActive:    true,
+		UserAddress:     userAddress,
+		OrganizationID:  organizationID,
+		Name:            name,
+		WebhookURL:      webhookURL,
+		Secret:          secret,
+		IsActive:        true,
+		Filters:         filters,
+		MessageTemplate: messageTemplate,
+		DigestMode:      digestMode,
 	}
 
 	if err := s.repo.CreateLarkConfig(ctx, config); err != nil {
@@ -186,9 +375,9 @@ func (s *notificationService) createLarkConfig(ctx context.Context, userAddress
 }
 
 // createFeishuConfig 创建Feishu配置
-func (s *notificationService) createFeishuConfig(ctx context.Context, userAddress string, name string, webhookURL string, secret string) error {
+func (s *notificationService) createFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string, webhookURL string, secret string, filters *string, messageTemplate *string, digestMode string) error {
 	// 检查是否已存在同名配置
-	existing, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, name)
+	existing, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return fmt.Errorf("failed to check existing feishu config: %w", err)
 	}
@@ -196,12 +385,25 @@ func (s *notificationService) createFeishuConfig(ctx context.Context, userAddres
 		return fmt.Errorf("feishu config with name '%s' already exists", name)
 	}
 
+	webhookURL, err = s.keyring.Encrypt(webhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook url: %w", err)
+	}
+	secret, err = s.keyring.Encrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
 	config := &types.FeishuConfig{
-		UserAddress: userAddress,
-		Name:        name,
-		WebhookURL:  webhookURL,
-		Secret:      secret,
-		IsActive:    true,
+		UserAddress:     userAddress,
+		OrganizationID:  organizationID,
+		Name:            name,
+		WebhookURL:      webhookURL,
+		Secret:          secret,
+		IsActive:        true,
+		Filters:         filters,
+		MessageTemplate: messageTemplate,
+		DigestMode:      digestMode,
 	}
 
 	if err := s.repo.CreateFeishuConfig(ctx, config); err != nil {
@@ -213,9 +415,9 @@ func (s *notificationService) createFeishuConfig(ctx context.Context, userAddres
 
 // ===== 更新配置 =====
 // updateTelegramConfig 更新Telegram配置
-func (s *notificationService) updateTelegramConfig(ctx context.Context, userAddress string, name *string, botToken *string, chatID *string, isActive *bool) error {
+func (s *notificationService) updateTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name *string, botToken *string, chatID *string, isActive *bool, filters **string, messageTemplate **string, digestMode *string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, *name)
+	_, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, organizationID, *name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("telegram config not found")
@@ -225,8 +427,13 @@ func (s *notificationService) updateTelegramConfig(ctx context.Context, userAddr
 
 	// 构建更新字段
 	updates := make(map[string]interface{})
-	if botToken != nil {
-		updates["bot_token"] = *botToken
+	// 客户端回传脱敏值时表示不修改
+	if botToken != nil && *botToken != types.MaskedSecret {
+		encrypted, err := s.keyring.Encrypt(*botToken)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt bot token: %w", err)
+		}
+		updates["bot_token"] = encrypted
 	}
 	if chatID != nil {
 		updates["chat_id"] = *chatID
@@ -234,18 +441,27 @@ func (s *notificationService) updateTelegramConfig(ctx context.Context, userAddr
 	if isActive != nil {
 		updates["is_active"] = *isActive
 	}
+	if filters != nil {
+		updates["filters"] = *filters
+	}
+	if messageTemplate != nil {
+		updates["message_template"] = *messageTemplate
+	}
+	if digestMode != nil {
+		updates["digest_mode"] = *digestMode
+	}
 
 	if len(updates) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
 
-	return s.repo.UpdateTelegramConfig(ctx, userAddress, *name, updates)
+	return s.repo.UpdateTelegramConfig(ctx, userAddress, organizationID, *name, updates)
 }
 
 // updateLarkConfig 更新Lark配置
-func (s *notificationService) updateLarkConfig(ctx context.Context, userAddress string, name *string, webhookURL *string, secret *string, isActive *bool) error {
+func (s *notificationService) updateLarkConfig(ctx context.Context, userAddress string, organizationID int64, name *string, webhookURL *string, secret *string, isActive *bool, filters **string, messageTemplate **string, digestMode *string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, *name)
+	_, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, organizationID, *name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("lark config not found")
@@ -255,27 +471,45 @@ func (s *notificationService) updateLarkConfig(ctx context.Context, userAddress
 
 	// 构建更新字段
 	updates := make(map[string]interface{})
-	if webhookURL != nil {
-		updates["webhook_url"] = *webhookURL
+	// 客户端回传脱敏值时表示不修改
+	if webhookURL != nil && *webhookURL != types.MaskedSecret {
+		encrypted, err := s.keyring.Encrypt(*webhookURL)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook url: %w", err)
+		}
+		updates["webhook_url"] = encrypted
 	}
-	if secret != nil {
-		updates["secret"] = *secret
+	if secret != nil && *secret != types.MaskedSecret {
+		encrypted, err := s.keyring.Encrypt(*secret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt secret: %w", err)
+		}
+		updates["secret"] = encrypted
 	}
 	if isActive != nil {
 		updates["is_active"] = *isActive
 	}
+	if filters != nil {
+		updates["filters"] = *filters
+	}
+	if messageTemplate != nil {
+		updates["message_template"] = *messageTemplate
+	}
+	if digestMode != nil {
+		updates["digest_mode"] = *digestMode
+	}
 
 	if len(updates) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
 
-	return s.repo.UpdateLarkConfig(ctx, userAddress, *name, updates)
+	return s.repo.UpdateLarkConfig(ctx, userAddress, organizationID, *name, updates)
 }
 
 // updateFeishuConfig 更新Feishu配置
-func (s *notificationService) updateFeishuConfig(ctx context.Context, userAddress string, name *string, webhookURL *string, secret *string, isActive *bool) error {
+func (s *notificationService) updateFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name *string, webhookURL *string, secret *string, isActive *bool, filters **string, messageTemplate **string, digestMode *string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, *name)
+	_, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, organizationID, *name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("feishu config not found")
@@ -285,28 +519,46 @@ func (s *notificationService) updateFeishuConfig(ctx context.Context, userAddres
 
 	// 构建更新字段
 	updates := make(map[string]interface{})
-	if webhookURL != nil {
-		updates["webhook_url"] = *webhookURL
+	// 客户端回传脱敏值时表示不修改
+	if webhookURL != nil && *webhookURL != types.MaskedSecret {
+		encrypted, err := s.keyring.Encrypt(*webhookURL)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook url: %w", err)
+		}
+		updates["webhook_url"] = encrypted
 	}
-	if secret != nil {
-		updates["secret"] = *secret
+	if secret != nil && *secret != types.MaskedSecret {
+		encrypted, err := s.keyring.Encrypt(*secret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt secret: %w", err)
+		}
+		updates["secret"] = encrypted
 	}
 	if isActive != nil {
 		updates["is_active"] = *isActive
 	}
+	if filters != nil {
+		updates["filters"] = *filters
+	}
+	if messageTemplate != nil {
+		updates["message_template"] = *messageTemplate
+	}
+	if digestMode != nil {
+		updates["digest_mode"] = *digestMode
+	}
 
 	if len(updates) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
 
-	return s.repo.UpdateFeishuConfig(ctx, userAddress, *name, updates)
+	return s.repo.UpdateFeishuConfig(ctx, userAddress, organizationID, *name, updates)
 }
 
 // ===== 删除配置 =====
 // deleteTelegramConfig 删除Telegram配置
-func (s *notificationService) deleteTelegramConfig(ctx context.Context, userAddress string, name string) error {
+func (s *notificationService) deleteTelegramConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, name)
+	_, err := s.repo.GetTelegramConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("telegram config not found")
@@ -314,13 +566,13 @@ func (s *notificationService) deleteTelegramConfig(ctx context.Context, userAddr
 		return fmt.Errorf("failed to get telegram config: %w", err)
 	}
 
-	return s.repo.DeleteTelegramConfig(ctx, userAddress, name)
+	return s.repo.DeleteTelegramConfig(ctx, userAddress, organizationID, name)
 }
 
 // deleteLarkConfig 删除Lark配置
-func (s *notificationService) deleteLarkConfig(ctx context.Context, userAddress string, name string) error {
+func (s *notificationService) deleteLarkConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, name)
+	_, err := s.repo.GetLarkConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("lark config not found")
@@ -328,13 +580,13 @@ func (s *notificationService) deleteLarkConfig(ctx context.Context, userAddress
 		return fmt.Errorf("failed to get lark config: %w", err)
 	}
 
-	return s.repo.DeleteLarkConfig(ctx, userAddress, name)
+	return s.repo.DeleteLarkConfig(ctx, userAddress, organizationID, name)
 }
 
 // deleteFeishuConfig 删除Feishu配置
-func (s *notificationService) deleteFeishuConfig(ctx context.Context, userAddress string, name string) error {
+func (s *notificationService) deleteFeishuConfig(ctx context.Context, userAddress string, organizationID int64, name string) error {
 	// 检查配置是否存在
-	_, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, name)
+	_, err := s.repo.GetFeishuConfigByUserAddressAndName(ctx, userAddress, organizationID, name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("feishu config not found")
@@ -342,33 +594,54 @@ func (s *notificationService) deleteFeishuConfig(ctx context.Context, userAddres
 		return fmt.Errorf("failed to get feishu config: %w", err)
 	}
 
-	return s.repo.DeleteFeishuConfig(ctx, userAddress, name)
+	return s.repo.DeleteFeishuConfig(ctx, userAddress, organizationID, name)
 }
 
 // ===== 获取所有通知配置 =====
 // GetAllNotificationConfigs 获取所有通知配置
+// 包含用户个人配置以及用户所在组织的共享配置
 func (s *notificationService) GetAllNotificationConfigs(ctx context.Context, userAddress string) (*types.NotificationConfigListResponse, error) {
 	response := &types.NotificationConfigListResponse{}
 
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	organizationIDs, err := s.orgRepo.GetOrganizationIDsByWallets(ctx, wallets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+
 	// 获取Telegram配置
-	telegramConfigs, err := s.repo.GetTelegramConfigsByUserAddress(ctx, userAddress)
+	telegramConfigs, err := s.repo.GetTelegramConfigsByUserAddress(ctx, userAddress, organizationIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get telegram configs: %w", err)
 	}
+	for _, config := range telegramConfigs {
+		config.BotToken = types.MaskSecret(config.BotToken)
+	}
 	response.TelegramConfigs = telegramConfigs
 
 	// 获取Lark配置
-	larkConfigs, err := s.repo.GetLarkConfigsByUserAddress(ctx, userAddress)
+	larkConfigs, err := s.repo.GetLarkConfigsByUserAddress(ctx, userAddress, organizationIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get lark configs: %w", err)
 	}
+	for _, config := range larkConfigs {
+		config.WebhookURL = types.MaskSecret(config.WebhookURL)
+		config.Secret = types.MaskSecret(config.Secret)
+	}
 	response.LarkConfigs = larkConfigs
 
 	// 获取Feishu配置
-	feishuConfigs, err := s.repo.GetFeishuConfigsByUserAddress(ctx, userAddress)
+	feishuConfigs, err := s.repo.GetFeishuConfigsByUserAddress(ctx, userAddress, organizationIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get feishu configs: %w", err)
 	}
+	for _, config := range feishuConfigs {
+		config.WebhookURL = types.MaskSecret(config.WebhookURL)
+		config.Secret = types.MaskSecret(config.Secret)
+	}
 	response.FeishuConfigs = feishuConfigs
 
 	return response, nil
@@ -381,17 +654,31 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 	userAddresses, err := s.repo.GetContractRelatedUserAddresses(ctx, standard, chainID, contractAddress)
 	if err != nil {
 		logger.Error("Failed to get contract related users", err, "standard", standard, "chainID", chainID, "contract", contractAddress)
-		return nil // 不阻塞流程，只记录错误
+		return fmt.Errorf("failed to get contract related users: %w", err)
+	}
+
+	// 获取关注该合约的组织的共享通知配置
+	orgConfigs, err := s.repo.GetOrganizationActiveNotificationConfigs(ctx, standard, chainID, contractAddress)
+	if err != nil {
+		logger.Error("Failed to get organization notification configs", err, "standard", standard, "chainID", chainID, "contract", contractAddress)
+		return fmt.Errorf("failed to get organization notification configs: %w", err)
 	}
+	orgConfigCount := len(orgConfigs.TelegramConfigs) + len(orgConfigs.LarkConfigs) + len(orgConfigs.FeishuConfigs)
 
-	if len(userAddresses) == 0 {
+	if len(userAddresses) == 0 && orgConfigCount == 0 {
 		logger.Debug("No related users found for notification", "standard", standard, "chainID", chainID, "contract", contractAddress)
 		return nil
 	}
 
-	logger.Info("Found related users for notification", "count", len(userAddresses), "standard", standard, "chainID", chainID, "contract", contractAddress)
+	logger.Info("Found related users for notification", "count", len(userAddresses), "organizationConfigs", orgConfigCount, "standard", standard, "chainID", chainID, "contract", contractAddress)
 
 	var notificationData *types.NotificationData
+	filterEvent := &types.NotificationFilterEvent{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		StatusTo:        statusTo,
+	}
+
 	// 获取链信息
 	chainInfo, err := s.chainRepo.GetChainByChainID(ctx, int64(chainID))
 	if err != nil {
@@ -424,9 +711,13 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 
 	if standard == "compound" {
 		// 通过chainid、contractAddress获得该合约信息，拿到合约备注，GetCompoundTimeLockByChainAndAddress
+		// 合约已删除或查询失败时不影响通知发送，备注留空
+		var remark string
 		compoundTimeLock, err := s.timelockRepo.GetCompoundTimeLockByChainAndAddress(ctx, chainID, contractAddress)
 		if err != nil {
 			logger.Error("Failed to get compound time lock", err, "chainID", chainID, "contractAddress", contractAddress)
+		} else if compoundTimeLock != nil {
+			remark = compoundTimeLock.Remark
 		}
 
 		// 通过flowID去交易表中拿到交易信息
@@ -467,10 +758,13 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 			value = fmt.Sprintf("0 %s", nativeToken)
 		}
 
+		filterEvent.FunctionSignature = transaction.EventFunctionSignature
+		filterEvent.Value = transaction.EventValue
+
 		notificationData = &types.NotificationData{
 			Standard:       strings.ToUpper(standard),
 			Contract:       contractAddress,
-			Remark:         compoundTimeLock.Remark,
+			Remark:         remark,
 			Caller:         transaction.FromAddress,
 			Target:         *transaction.EventTarget,
 			Function:       functionName,
@@ -487,6 +781,11 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 		return fmt.Errorf("invalid standard")
 	}
 
+	if notificationData == nil {
+		logger.Warn("Flow notification not supported for standard yet", "standard", standard, "flowID", flowID)
+		return nil
+	}
+
 	notificationData.StatusFrom = strings.ToUpper(statusFrom)
 	notificationData.StatusTo = strings.ToUpper(statusTo)
 	notificationData.Network = chainInfo.DisplayName
@@ -498,16 +797,17 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 	message, err := s.generateNotificationMessage(ctx, notificationData)
 	if err != nil {
 		logger.Error("Failed to generate notification message", err, "flowID", flowID)
-		return nil // 不阻塞流程，只记录错误
+		return fmt.Errorf("failed to generate notification message: %w", err)
 	}
 
-	// 对每个相关用户发送通知
-	var totalSent int
+	// 对每个相关用户发送通知，单个失败不影响其他用户，最终汇总失败数以便整体重试（已成功的会被发送日志去重）
+	var totalSent, totalFailed int
 	for _, userAddress := range userAddresses {
 		// 获取用户的通知配置
 		configs, err := s.repo.GetUserActiveNotificationConfigs(ctx, userAddress)
 		if err != nil {
 			logger.Error("Failed to get user notification configs", err, "userAddress", userAddress)
+			totalFailed++
 			continue // 继续处理下一个用户
 		}
 
@@ -520,29 +820,248 @@ func (s *notificationService) SendFlowNotification(ctx context.Context, standard
 
 		logger.Debug("Processing user notification configs", "userAddress", userAddress, "telegram", len(configs.TelegramConfigs), "lark", len(configs.LarkConfigs), "feishu", len(configs.FeishuConfigs))
 
-		// 发送Telegram通知
-		for _, config := range configs.TelegramConfigs {
-			s.sendTelegramNotification(ctx, config, message, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash)
-			totalSent++
+		sent, failed := s.sendConfigsNotification(ctx, configs, notificationData, message, filterEvent, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash)
+		totalSent += sent
+		totalFailed += failed
+	}
+
+	// 发送关注该合约的组织的共享通知配置
+	if orgConfigCount > 0 {
+		logger.Debug("Processing organization notification configs", "telegram", len(orgConfigs.TelegramConfigs), "lark", len(orgConfigs.LarkConfigs), "feishu", len(orgConfigs.FeishuConfigs))
+		sent, failed := s.sendConfigsNotification(ctx, orgConfigs, notificationData, message, filterEvent, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash)
+		totalSent += sent
+		totalFailed += failed
+	}
+
+	logger.Info("Notification sending completed", "totalUsers", len(userAddresses), "totalNotificationsSent", totalSent, "totalNotificationsFailed", totalFailed)
+	if totalFailed > 0 {
+		return fmt.Errorf("failed to send %d notifications for flow %s", totalFailed, flowID)
+	}
+	return nil
+}
+
+// sendConfigsNotification 按配置逐个发送实时通知，返回成功与失败数
+func (s *notificationService) sendConfigsNotification(ctx context.Context, configs *types.UserNotificationConfigs, notificationData *types.NotificationData, message string, filterEvent *types.NotificationFilterEvent, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) (int, int) {
+	var sent, failed int
+
+	// 发送Telegram通知
+	for _, config := range configs.TelegramConfigs {
+		// 摘要模式的配置由摘要调度器统一发送
+		if !types.IsRealtimeDigestMode(config.DigestMode) {
+			continue
+		}
+		if !s.matchConfigFilters(types.ChannelTelegram, config.ID, config.Filters, filterEvent) {
+			continue
 		}
+		configMessage := s.renderConfigMessage(types.ChannelTelegram, config.ID, config.MessageTemplate, notificationData, message)
+		if err := s.sendTelegramNotification(ctx, config, configMessage, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash); err != nil {
+			failed++
+			continue
+		}
+		sent++
+	}
 
-		// 发送Lark通知
-		for _, config := range configs.LarkConfigs {
-			s.sendLarkNotification(ctx, config, message, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash)
-			totalSent++
+	// 发送Lark通知
+	for _, config := range configs.LarkConfigs {
+		// 摘要模式的配置由摘要调度器统一发送
+		if !types.IsRealtimeDigestMode(config.DigestMode) {
+			continue
+		}
+		if !s.matchConfigFilters(types.ChannelLark, config.ID, config.Filters, filterEvent) {
+			continue
 		}
+		configMessage := s.renderConfigMessage(types.ChannelLark, config.ID, config.MessageTemplate, notificationData, message)
+		if err := s.sendLarkNotification(ctx, config, configMessage, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash); err != nil {
+			failed++
+			continue
+		}
+		sent++
+	}
 
-		// 发送Feishu通知
-		for _, config := range configs.FeishuConfigs {
-			s.sendFeishuNotification(ctx, config, message, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash)
-			totalSent++
+	// 发送Feishu通知
+	for _, config := range configs.FeishuConfigs {
+		// 摘要模式的配置由摘要调度器统一发送
+		if !types.IsRealtimeDigestMode(config.DigestMode) {
+			continue
 		}
+		if !s.matchConfigFilters(types.ChannelFeishu, config.ID, config.Filters, filterEvent) {
+			continue
+		}
+		configMessage := s.renderConfigMessage(types.ChannelFeishu, config.ID, config.MessageTemplate, notificationData, message)
+		if err := s.sendFeishuNotification(ctx, config, configMessage, flowID, standard, chainID, contractAddress, statusFrom, statusTo, txHash); err != nil {
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	return sent, failed
+}
+
+// ===== 消息模板预览 =====
+// PreviewTemplate 使用示例流程数据渲染模板，telegram/lark/feishu 使用 text/template，email 使用 html/template
+func (s *notificationService) PreviewTemplate(ctx context.Context, req *types.PreviewNotificationTemplateRequest) (*types.PreviewNotificationTemplateResponse, error) {
+	sampleData := notificationPkg.SampleNotificationData()
+
+	var rendered string
+	var err error
+	switch strings.ToLower(req.Channel) {
+	case "telegram", "lark", "feishu":
+		rendered, err = notificationPkg.RenderTextTemplate(req.Template, sampleData)
+	case "email":
+		rendered, err = notificationPkg.RenderHTMLTemplate(req.Template, sampleData)
+	default:
+		return nil, fmt.Errorf("invalid channel: %s", req.Channel)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return &types.PreviewNotificationTemplateResponse{
+		Rendered:   rendered,
+		SampleData: sampleData,
+	}, nil
+}
 
-	logger.Info("Notification sending completed", "totalUsers", len(userAddresses), "totalNotificationsSent", totalSent)
+// ===== 通知发件箱 =====
+// GetOutboxList 获取与用户相关的通知发件箱记录
+func (s *notificationService) GetOutboxList(ctx context.Context, userAddress string, req *types.GetNotificationOutboxListRequest) (*types.GetNotificationOutboxListResponse, error) {
+	switch req.Status {
+	case "", types.OutboxStatusPending, types.OutboxStatusProcessing, types.OutboxStatusSent, types.OutboxStatusDead:
+	default:
+		return nil, fmt.Errorf("invalid status: %s", req.Status)
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	items, total, err := s.outboxRepo.GetUserOutboxList(ctx, userAddress, req.Status, offset, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox list: %w", err)
+	}
+
+	return &types.GetNotificationOutboxListResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// RedeliverOutbox 重新投递死信通知
+func (s *notificationService) RedeliverOutbox(ctx context.Context, userAddress string, id int64) error {
+	item, err := s.outboxRepo.GetUserOutboxByID(ctx, userAddress, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("outbox record not found")
+		}
+		return fmt.Errorf("failed to get outbox record: %w", err)
+	}
+
+	if item.Status != types.OutboxStatusDead {
+		return fmt.Errorf("outbox record is not in dead state: %s", item.Status)
+	}
+
+	if err := s.outboxRepo.ResetOutboxForRedelivery(ctx, item.ID); err != nil {
+		return fmt.Errorf("failed to reset outbox record: %w", err)
+	}
+
+	logger.Info("Outbox record scheduled for redelivery", "id", item.ID, "user_address", userAddress, "flow_id", item.FlowID, "channel", item.Channel)
+	return nil
+}
+
+// SendAdminAlert 通过平台管理员（admin.wallets）个人的激活通知渠道发送运维告警
+// 全部发送失败时返回错误，没有管理员或管理员未配置渠道时只记录警告
+func (s *notificationService) SendAdminAlert(ctx context.Context, message string) error {
+	if len(s.config.Admin.Wallets) == 0 {
+		logger.Warn("No platform admins configured, admin alert dropped")
+		return nil
+	}
+
+	var sent, failed int
+	var lastErr error
+	record := func(channel types.NotificationChannel, configID uint, err error) {
+		if err != nil {
+			logger.Error("Failed to send admin alert", err, "channel", channel, "configID", configID)
+			failed++
+			lastErr = err
+			return
+		}
+		sent++
+	}
+
+	for _, wallet := range s.config.Admin.Wallets {
+		configs, err := s.repo.GetUserActiveNotificationConfigs(ctx, wallet)
+		if err != nil {
+			failed++
+			lastErr = err
+			continue
+		}
+		for _, cfg := range configs.TelegramConfigs {
+			record(types.ChannelTelegram, cfg.ID, s.telegramSender.SendMessage(ctx, cfg.BotToken, cfg.ChatID, message))
+		}
+		for _, cfg := range configs.LarkConfigs {
+			record(types.ChannelLark, cfg.ID, s.larkSender.SendMessage(ctx, cfg.WebhookURL, cfg.Secret, message))
+		}
+		for _, cfg := range configs.FeishuConfigs {
+			record(types.ChannelFeishu, cfg.ID, s.feishuSender.SendMessage(ctx, cfg.WebhookURL, cfg.Secret, message))
+		}
+	}
+
+	if sent == 0 && failed == 0 {
+		logger.Warn("Platform admins have no active notification channels, admin alert dropped", "admins", len(s.config.Admin.Wallets))
+		return nil
+	}
+	logger.Info("Admin alert sent", "sent", sent, "failed", failed)
+	if sent == 0 {
+		return fmt.Errorf("failed to send admin alert: %w", lastErr)
+	}
 	return nil
 }
 
+// renderConfigMessage 使用通知配置的自定义模板渲染消息，未设置模板或渲染失败时使用默认消息
+func (s *notificationService) renderConfigMessage(channel types.NotificationChannel, configID uint, messageTemplate *string, notificationData *types.NotificationData, defaultMessage string) string {
+	if messageTemplate == nil || *messageTemplate == "" {
+		return defaultMessage
+	}
+	message, err := notificationPkg.RenderTextTemplate(*messageTemplate, notificationData)
+	if err != nil {
+		logger.Warn("Failed to render custom message template, falling back to default", "channel", channel, "configID", configID, "error", err.Error())
+		return defaultMessage
+	}
+	return message
+}
+
+// validateMessageTemplate 校验自定义消息模板，空模板返回nil表示使用默认格式
+func validateMessageTemplate(messageTemplate *string) (*string, error) {
+	if messageTemplate == nil || strings.TrimSpace(*messageTemplate) == "" {
+		return nil, nil
+	}
+	if _, err := notificationPkg.RenderTextTemplate(*messageTemplate, notificationPkg.SampleNotificationData()); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return messageTemplate, nil
+}
+
+// matchConfigFilters 判断通知配置的订阅过滤规则是否匹配，无法解析的规则视为不匹配
+func (s *notificationService) matchConfigFilters(channel types.NotificationChannel, configID uint, filters *string, event *types.NotificationFilterEvent) bool {
+	matched, err := utils.MatchNotificationFilterJSON(filters, event)
+	if err != nil {
+		logger.Error("Failed to parse notification filter", err, "channel", channel, "configID", configID)
+		return false
+	}
+	if !matched {
+		logger.Debug("Notification filtered out by subscription rules", "channel", channel, "configID", configID, "status", event.StatusTo)
+	}
+	return matched
+}
+
 // generateNotificationMessage 生成通知消息
 func (s *notificationService) generateNotificationMessage(ctx context.Context, notificationData *types.NotificationData) (string, error) {
 
@@ -587,28 +1106,28 @@ func (s *notificationService) generateNotificationMessage(ctx context.Context, n
 	return message, nil
 }
 
-// sendTelegramNotification 发送Telegram通知
-func (s *notificationService) sendTelegramNotification(ctx context.Context, config *types.TelegramConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) {
+// sendTelegramNotification 发送Telegram通知，发送失败时返回错误以便重试
+func (s *notificationService) sendTelegramNotification(ctx context.Context, config *types.TelegramConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) error {
 	// 检查是否已发送过此通知
 	exists, err := s.repo.CheckNotificationLogExists(ctx, types.ChannelTelegram, config.UserAddress, config.ID, flowID, statusTo)
 	if err != nil {
 		logger.Error("Failed to check telegram notification log", err, "configID", config.ID, "flowID", flowID)
-		return
+		return fmt.Errorf("failed to check telegram notification log: %w", err)
 	}
 	if exists {
 		logger.Info("Telegram notification already sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
-		return
+		return nil
 	}
 
 	// 发送消息
-	err = s.telegramSender.SendMessage(config.BotToken, config.ChatID, message)
+	sendErr := s.telegramSender.SendMessage(ctx, config.BotToken, config.ChatID, message)
 	sendStatus := "success"
 	var errorMessage *string
-	if err != nil {
+	if sendErr != nil {
 		sendStatus = "failed"
-		errMsg := err.Error()
+		errMsg := sendErr.Error()
 		errorMessage = &errMsg
-		logger.Error("Failed to send telegram notification", err, "configID", config.ID, "flowID", flowID)
+		logger.Error("Failed to send telegram notification", sendErr, "configID", config.ID, "flowID", flowID)
 	}
 
 	// 记录发送日志
@@ -642,33 +1161,36 @@ func (s *notificationService) sendTelegramNotification(ctx context.Context, conf
 		logger.Error("Failed to create telegram notification log", err, "configID", config.ID, "flowID", flowID)
 	}
 
-	if sendStatus == "success" {
-		logger.Info("Telegram notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	if sendErr != nil {
+		return fmt.Errorf("failed to send telegram notification to config %d: %w", config.ID, sendErr)
 	}
+
+	logger.Info("Telegram notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	return nil
 }
 
-// sendLarkNotification 发送Lark通知
-func (s *notificationService) sendLarkNotification(ctx context.Context, config *types.LarkConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) {
+// sendLarkNotification 发送Lark通知，发送失败时返回错误以便重试
+func (s *notificationService) sendLarkNotification(ctx context.Context, config *types.LarkConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) error {
 	// 检查是否已发送过此通知
 	exists, err := s.repo.CheckNotificationLogExists(ctx, types.ChannelLark, config.UserAddress, config.ID, flowID, statusTo)
 	if err != nil {
 		logger.Error("Failed to check lark notification log", err, "configID", config.ID, "flowID", flowID)
-		return
+		return fmt.Errorf("failed to check lark notification log: %w", err)
 	}
 	if exists {
 		logger.Info("Lark notification already sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
-		return
+		return nil
 	}
 
 	// 发送消息
-	err = s.larkSender.SendMessage(config.WebhookURL, config.Secret, message)
+	sendErr := s.larkSender.SendMessage(ctx, config.WebhookURL, config.Secret, message)
 	sendStatus := "success"
 	var errorMessage *string
-	if err != nil {
+	if sendErr != nil {
 		sendStatus = "failed"
-		errMsg := err.Error()
+		errMsg := sendErr.Error()
 		errorMessage = &errMsg
-		logger.Error("Failed to send lark notification", err, "configID", config.ID, "flowID", flowID)
+		logger.Error("Failed to send lark notification", sendErr, "configID", config.ID, "flowID", flowID)
 	}
 
 	// 记录发送日志
@@ -702,33 +1224,36 @@ func (s *notificationService) sendLarkNotification(ctx context.Context, config *
 		logger.Error("Failed to create lark notification log", err, "configID", config.ID, "flowID", flowID)
 	}
 
-	if sendStatus == "success" {
-		logger.Info("Lark notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	if sendErr != nil {
+		return fmt.Errorf("failed to send lark notification to config %d: %w", config.ID, sendErr)
 	}
+
+	logger.Info("Lark notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	return nil
 }
 
-// sendFeishuNotification 发送Feishu通知
-func (s *notificationService) sendFeishuNotification(ctx context.Context, config *types.FeishuConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) {
+// sendFeishuNotification 发送Feishu通知，发送失败时返回错误以便重试
+func (s *notificationService) sendFeishuNotification(ctx context.Context, config *types.FeishuConfig, message, flowID, standard string, chainID int, contractAddress, statusFrom, statusTo string, txHash *string) error {
 	// 检查是否已发送过此通知
 	exists, err := s.repo.CheckNotificationLogExists(ctx, types.ChannelFeishu, config.UserAddress, config.ID, flowID, statusTo)
 	if err != nil {
 		logger.Error("Failed to check feishu notification log", err, "configID", config.ID, "flowID", flowID)
-		return
+		return fmt.Errorf("failed to check feishu notification log: %w", err)
 	}
 	if exists {
 		logger.Info("Feishu notification already sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
-		return
+		return nil
 	}
 
 	// 发送消息
-	err = s.feishuSender.SendMessage(config.WebhookURL, config.Secret, message)
+	sendErr := s.feishuSender.SendMessage(ctx, config.WebhookURL, config.Secret, message)
 	sendStatus := "success"
 	var errorMessage *string
-	if err != nil {
+	if sendErr != nil {
 		sendStatus = "failed"
-		errMsg := err.Error()
+		errMsg := sendErr.Error()
 		errorMessage = &errMsg
-		logger.Error("Failed to send feishu notification", err, "configID", config.ID, "flowID", flowID)
+		logger.Error("Failed to send feishu notification", sendErr, "configID", config.ID, "flowID", flowID)
 	}
 
 	// 记录发送日志
@@ -762,7 +1287,10 @@ func (s *notificationService) sendFeishuNotification(ctx context.Context, config
 		logger.Error("Failed to create feishu notification log", err, "configID", config.ID, "flowID", flowID)
 	}
 
-	if sendStatus == "success" {
-		logger.Info("Feishu notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	if sendErr != nil {
+		return fmt.Errorf("failed to send feishu notification to config %d: %w", config.ID, sendErr)
 	}
+
+	logger.Info("Feishu notification sent", "configID", config.ID, "flowID", flowID, "status", statusTo)
+	return nil
 }
@@ -0,0 +1,189 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/repository/notification"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// FlowNotifier 流程通知发送接口（邮件服务与渠道通知服务均实现该接口）
+type FlowNotifier interface {
+	SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error
+}
+
+// OutboxWorker 通知发件箱投递器
+type OutboxWorker struct {
+	config     *config.NotificationConfig
+	outboxRepo notification.OutboxRepository
+	notifiers  map[string]FlowNotifier
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+}
+
+// NewOutboxWorker 创建通知发件箱投递器
+func NewOutboxWorker(cfg *config.NotificationConfig, outboxRepo notification.OutboxRepository, emailNotifier FlowNotifier, imNotifier FlowNotifier) *OutboxWorker {
+	return &OutboxWorker{
+		config:     cfg,
+		outboxRepo: outboxRepo,
+		notifiers: map[string]FlowNotifier{
+			types.OutboxChannelEmail: emailNotifier,
+			types.OutboxChannelIM:    imNotifier,
+		},
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start 启动投递worker
+func (w *OutboxWorker) Start(ctx context.Context) {
+	workers := w.config.OutboxWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	logger.Info("Starting notification outbox workers",
+		"workers", workers,
+		"poll_interval", w.config.OutboxPollInterval,
+		"batch_size", w.config.OutboxBatchSize)
+
+	for i := 0; i < workers; i++ {
+		w.wg.Add(1)
+		go w.run(ctx, i)
+	}
+}
+
+// Stop 停止投递worker并等待当前批次处理完成
+func (w *OutboxWorker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+	w.wg.Wait()
+	logger.Info("Notification outbox workers stopped")
+}
+
+// run 单个worker循环
+func (w *OutboxWorker) run(ctx context.Context, workerID int) {
+	defer w.wg.Done()
+
+	interval := w.config.OutboxPollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			if err := w.processBatch(ctx, workerID); err != nil {
+				logger.Error("Failed to process notification outbox batch", err, "worker", workerID)
+			}
+		}
+	}
+}
+
+// processBatch 领取并投递一批到期记录
+func (w *OutboxWorker) processBatch(ctx context.Context, workerID int) error {
+	items, err := w.outboxRepo.ClaimDueOutbox(ctx, time.Now(), w.config.OutboxBatchSize, w.config.OutboxLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to claim outbox: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	logger.Debug("Claimed notification outbox records", "worker", workerID, "count", len(items))
+
+	for i := range items {
+		select {
+		case <-ctx.Done():
+			// 未处理的记录会在领取超时后被重新领取
+			return ctx.Err()
+		default:
+		}
+		w.deliver(ctx, &items[i])
+	}
+	return nil
+}
+
+// deliver 投递单条记录，失败时按指数退避安排重试，超过最大次数进入死信状态
+func (w *OutboxWorker) deliver(ctx context.Context, item *types.NotificationOutbox) {
+	ctx, span := tracing.Start(ctx, "notification.deliver",
+		attribute.Int64("outbox_id", item.ID),
+		attribute.String("channel", item.Channel),
+		attribute.String("flow_id", item.FlowID),
+		attribute.Int("chain_id", item.ChainID),
+		attribute.String("status_to", item.StatusTo),
+		attribute.Int("attempt", item.Attempts+1),
+	)
+	defer span.End()
+
+	err := w.send(ctx, item)
+	if err == nil {
+		if err := w.outboxRepo.MarkOutboxSent(ctx, item.ID); err != nil {
+			logger.ErrorCtx(ctx, "Failed to mark outbox sent", err, "id", item.ID)
+		}
+		logger.InfoCtx(ctx, "Notification outbox delivered", "id", item.ID, "channel", item.Channel, "flow_id", item.FlowID, "status_to", item.StatusTo)
+		return
+	}
+	tracing.RecordError(span, err)
+
+	attempts := item.Attempts + 1
+	dead := attempts >= item.MaxAttempts
+	nextAttemptAt := time.Now().Add(w.backoff(attempts))
+	if err := w.outboxRepo.MarkOutboxFailed(ctx, item.ID, attempts, nextAttemptAt, err.Error(), dead); err != nil {
+		logger.ErrorCtx(ctx, "Failed to mark outbox failed", err, "id", item.ID)
+	}
+
+	if dead {
+		span.SetAttributes(attribute.Bool("dead_letter", true))
+		logger.ErrorCtx(ctx, "Notification outbox moved to dead letter", err, "id", item.ID, "channel", item.Channel, "flow_id", item.FlowID, "attempts", attempts)
+	} else {
+		logger.WarnCtx(ctx, "Notification outbox delivery failed, will retry", "id", item.ID, "channel", item.Channel, "flow_id", item.FlowID, "attempts", attempts, "next_attempt_at", nextAttemptAt, "error", err.Error())
+	}
+}
+
+// send 调用对应渠道发送通知
+func (w *OutboxWorker) send(ctx context.Context, item *types.NotificationOutbox) (err error) {
+	notifier, ok := w.notifiers[item.Channel]
+	if !ok || notifier == nil {
+		return fmt.Errorf("unsupported outbox channel: %s", item.Channel)
+	}
+
+	// 避免单条记录的异常导致worker退出
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while sending notification: %v", r)
+		}
+	}()
+
+	return notifier.SendFlowNotification(ctx, item.TimelockStandard, item.ChainID, item.ContractAddress, item.FlowID, item.StatusFrom, item.StatusTo, item.TxHash, item.InitiatorAddress)
+}
+
+// backoff 计算第attempts次失败后的等待时间：base * 2^(attempts-1)，不超过max
+func (w *OutboxWorker) backoff(attempts int) time.Duration {
+	delay := w.config.OutboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= w.config.OutboxMaxBackoff {
+			return w.config.OutboxMaxBackoff
+		}
+	}
+	if delay > w.config.OutboxMaxBackoff {
+		return w.config.OutboxMaxBackoff
+	}
+	return delay
+}
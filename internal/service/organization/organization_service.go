@@ -0,0 +1,546 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"timelocker-backend/internal/repository/organization"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/audit"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// invitationTTL 邀请有效期
+const invitationTTL = 7 * 24 * time.Hour
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrInvalidName          = errors.New("organization name is required")
+	ErrInvalidRole          = errors.New("invalid organization role")
+	ErrInvalidWallet        = errors.New("invalid wallet address")
+	ErrPermissionDenied     = errors.New("insufficient organization role")
+	ErrAlreadyMember        = errors.New("wallet is already a member")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrInvitationExists     = errors.New("pending invitation already exists")
+	ErrInvitationNotFound   = errors.New("invitation not found or no longer pending")
+	ErrLastOwner            = errors.New("organization must keep at least one owner")
+	ErrOrganizationNotEmpty = errors.New("organization still owns timelocks, ABIs, notification configs or emails")
+)
+
+// Service 组织服务接口
+type Service interface {
+	// 组织管理
+	CreateOrganization(ctx context.Context, userID int64, walletAddress string, req *types.CreateOrganizationRequest) (*types.OrganizationInfo, error)
+	GetOrganizationList(ctx context.Context, walletAddress string) (*types.OrganizationListResponse, error)
+	GetOrganizationDetail(ctx context.Context, walletAddress string, id int64) (*types.OrganizationDetailResponse, error)
+	UpdateOrganization(ctx context.Context, walletAddress string, req *types.UpdateOrganizationRequest) error
+	DeleteOrganization(ctx context.Context, walletAddress string, id int64) error
+
+	// 邀请
+	InviteMember(ctx context.Context, walletAddress string, req *types.InviteOrganizationMemberRequest) (*types.OrganizationInvitation, error)
+	GetMyInvitations(ctx context.Context, walletAddress string) (*types.OrganizationInvitationListResponse, error)
+	AcceptInvitation(ctx context.Context, userID int64, walletAddress string, id int64) error
+	DeclineInvitation(ctx context.Context, walletAddress string, id int64) error
+	RevokeInvitation(ctx context.Context, walletAddress string, id int64) error
+
+	// 成员管理
+	UpdateMemberRole(ctx context.Context, walletAddress string, req *types.UpdateOrganizationMemberRequest) error
+	RemoveMember(ctx context.Context, walletAddress string, req *types.RemoveOrganizationMemberRequest) error
+	LeaveOrganization(ctx context.Context, walletAddress string, id int64) error
+}
+
+type service struct {
+	orgRepo  organization.Repository
+	userRepo user.Repository
+	auditor  audit.Recorder
+}
+
+// NewService 创建组织服务实例
+func NewService(orgRepo organization.Repository, userRepo user.Repository, auditor audit.Recorder) Service {
+	return &service{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		auditor:  auditor,
+	}
+}
+
+// CreateOrganization 创建组织，创建者成为所有者
+func (s *service) CreateOrganization(ctx context.Context, userID int64, walletAddress string, req *types.CreateOrganizationRequest) (*types.OrganizationInfo, error) {
+	logger.Info("CreateOrganization", "wallet_address", walletAddress, "name", req.Name)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	normalizedWallet := crypto.NormalizeAddress(walletAddress)
+
+	org := &types.Organization{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		CreatedBy:   normalizedWallet,
+	}
+	owner := &types.OrganizationMember{
+		UserID:        userID,
+		WalletAddress: normalizedWallet,
+		Role:          types.OrganizationRoleOwner,
+	}
+	if err := s.orgRepo.CreateOrganization(ctx, org, owner); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationCreate, types.AuditResourceOrganization, organizationResourceID(org.ID), org.ID, nil, org)
+
+	return &types.OrganizationInfo{
+		Organization: *org,
+		Role:         types.OrganizationRoleOwner,
+		MemberCount:  1,
+	}, nil
+}
+
+// GetOrganizationList 获取用户账户所在的组织列表
+func (s *service) GetOrganizationList(ctx context.Context, walletAddress string) (*types.OrganizationListResponse, error) {
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := s.orgRepo.GetOrganizationsByWallets(ctx, wallets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	if orgs == nil {
+		orgs = []types.OrganizationInfo{}
+	}
+	return &types.OrganizationListResponse{Organizations: orgs}, nil
+}
+
+// GetOrganizationDetail 获取组织详情，成员可见成员列表，所有者可见待处理邀请
+func (s *service) GetOrganizationDetail(ctx context.Context, walletAddress string, id int64) (*types.OrganizationDetailResponse, error) {
+	org, role, err := s.getOrganizationWithRole(ctx, walletAddress, id, types.OrganizationRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	invitations := []types.OrganizationInvitation{}
+	if types.HasOrganizationRole(role, types.OrganizationRoleOwner) {
+		invitations, err = s.orgRepo.GetPendingInvitationsByOrganization(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invitations: %w", err)
+		}
+	}
+
+	return &types.OrganizationDetailResponse{
+		Organization: types.OrganizationInfo{
+			Organization: *org,
+			Role:         role,
+			MemberCount:  int64(len(members)),
+		},
+		Members:     members,
+		Invitations: invitations,
+	}, nil
+}
+
+// UpdateOrganization 更新组织信息，仅所有者可操作
+func (s *service) UpdateOrganization(ctx context.Context, walletAddress string, req *types.UpdateOrganizationRequest) error {
+	logger.Info("UpdateOrganization", "wallet_address", walletAddress, "id", req.ID)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ErrInvalidName
+	}
+	org, _, err := s.getOrganizationWithRole(ctx, walletAddress, req.ID, types.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if err := s.orgRepo.UpdateOrganization(ctx, req.ID, name, description); err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	before := *org
+	org.Name = name
+	org.Description = description
+	s.auditor.Record(ctx, types.AuditActionOrganizationUpdate, types.AuditResourceOrganization, organizationResourceID(req.ID), req.ID, before, org)
+	return nil
+}
+
+// DeleteOrganization 删除组织，仅所有者可操作，组织仍拥有资源时不允许删除
+func (s *service) DeleteOrganization(ctx context.Context, walletAddress string, id int64) error {
+	logger.Info("DeleteOrganization", "wallet_address", walletAddress, "id", id)
+
+	org, _, err := s.getOrganizationWithRole(ctx, walletAddress, id, types.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.orgRepo.CountOrganizationResources(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count organization resources: %w", err)
+	}
+	if count > 0 {
+		return ErrOrganizationNotEmpty
+	}
+
+	if err := s.orgRepo.DeleteOrganization(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationDelete, types.AuditResourceOrganization, organizationResourceID(id), id, org, nil)
+	return nil
+}
+
+// InviteMember 邀请钱包加入组织，仅所有者可操作
+func (s *service) InviteMember(ctx context.Context, walletAddress string, req *types.InviteOrganizationMemberRequest) (*types.OrganizationInvitation, error) {
+	logger.Info("InviteMember", "wallet_address", walletAddress, "organization_id", req.OrganizationID, "invitee", req.WalletAddress, "role", req.Role)
+
+	if !types.IsValidOrganizationRole(req.Role) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, req.Role)
+	}
+	if !crypto.ValidateEthereumAddress(req.WalletAddress) {
+		return nil, ErrInvalidWallet
+	}
+	if _, _, err := s.getOrganizationWithRole(ctx, walletAddress, req.OrganizationID, types.OrganizationRoleOwner); err != nil {
+		return nil, err
+	}
+
+	// 受邀钱包所属账户下任一钱包已是成员时不再邀请
+	invitee := crypto.NormalizeAddress(req.WalletAddress)
+	inviteeWallets, err := s.accountWallets(ctx, invitee)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, req.OrganizationID, inviteeWallets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if role != "" {
+		return nil, ErrAlreadyMember
+	}
+
+	if _, err := s.orgRepo.GetPendingInvitation(ctx, req.OrganizationID, invitee); err == nil {
+		return nil, ErrInvitationExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check invitation: %w", err)
+	}
+
+	invitation := &types.OrganizationInvitation{
+		OrganizationID: req.OrganizationID,
+		WalletAddress:  invitee,
+		Role:           req.Role,
+		InvitedBy:      crypto.NormalizeAddress(walletAddress),
+		Status:         types.InvitationStatusPending,
+		ExpiresAt:      time.Now().Add(invitationTTL),
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationInvite, types.AuditResourceOrganization, organizationResourceID(req.OrganizationID), req.OrganizationID, nil, invitation)
+	return invitation, nil
+}
+
+// GetMyInvitations 获取当前用户账户下各钱包收到的待处理邀请
+func (s *service) GetMyInvitations(ctx context.Context, walletAddress string) (*types.OrganizationInvitationListResponse, error) {
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := s.orgRepo.GetPendingInvitationsByWallets(ctx, wallets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return &types.OrganizationInvitationListResponse{Invitations: invitations}, nil
+}
+
+// AcceptInvitation 接受邀请成为组织成员，邀请可以发给账户下的任一钱包，成员身份记在受邀钱包上
+func (s *service) AcceptInvitation(ctx context.Context, userID int64, walletAddress string, id int64) error {
+	logger.Info("AcceptInvitation", "wallet_address", walletAddress, "id", id)
+
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	invitation, err := s.getPendingInvitationForWallets(ctx, wallets, id)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, invitation.OrganizationID, wallets)
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+	if role != "" {
+		return ErrAlreadyMember
+	}
+
+	member := &types.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		WalletAddress:  crypto.NormalizeAddress(invitation.WalletAddress),
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+	}
+	if err := s.orgRepo.AcceptInvitation(ctx, invitation, member); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvitationNotFound
+		}
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationAcceptInvite, types.AuditResourceOrganization, organizationResourceID(member.OrganizationID), member.OrganizationID, invitation, member)
+	return nil
+}
+
+// DeclineInvitation 拒绝邀请
+func (s *service) DeclineInvitation(ctx context.Context, walletAddress string, id int64) error {
+	logger.Info("DeclineInvitation", "wallet_address", walletAddress, "id", id)
+
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getPendingInvitationForWallets(ctx, wallets, id); err != nil {
+		return err
+	}
+	return s.updateInvitationStatus(ctx, id, types.InvitationStatusDeclined)
+}
+
+// RevokeInvitation 撤销组织发出的邀请，仅所有者可操作
+func (s *service) RevokeInvitation(ctx context.Context, walletAddress string, id int64) error {
+	logger.Info("RevokeInvitation", "wallet_address", walletAddress, "id", id)
+
+	invitation, err := s.orgRepo.GetInvitationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvitationNotFound
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if _, _, err := s.getOrganizationWithRole(ctx, walletAddress, invitation.OrganizationID, types.OrganizationRoleOwner); err != nil {
+		return err
+	}
+	if err := s.updateInvitationStatus(ctx, id, types.InvitationStatusRevoked); err != nil {
+		return err
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationRevokeInvite, types.AuditResourceOrganization, organizationResourceID(invitation.OrganizationID), invitation.OrganizationID, invitation, nil)
+	return nil
+}
+
+// UpdateMemberRole 修改成员角色，仅所有者可操作，不能降级最后一个所有者
+func (s *service) UpdateMemberRole(ctx context.Context, walletAddress string, req *types.UpdateOrganizationMemberRequest) error {
+	logger.Info("UpdateMemberRole", "wallet_address", walletAddress, "organization_id", req.OrganizationID, "member", req.WalletAddress, "role", req.Role)
+
+	if !types.IsValidOrganizationRole(req.Role) {
+		return fmt.Errorf("%w: %s", ErrInvalidRole, req.Role)
+	}
+	if _, _, err := s.getOrganizationWithRole(ctx, walletAddress, req.OrganizationID, types.OrganizationRoleOwner); err != nil {
+		return err
+	}
+
+	member, err := s.getMember(ctx, req.OrganizationID, req.WalletAddress)
+	if err != nil {
+		return err
+	}
+	if member.Role == types.OrganizationRoleOwner && req.Role != types.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(ctx, req.OrganizationID, member.WalletAddress, req.Role); err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	before := *member
+	member.Role = req.Role
+	s.auditor.Record(ctx, types.AuditActionOrganizationUpdateMember, types.AuditResourceOrganization, organizationResourceID(req.OrganizationID), req.OrganizationID, before, member)
+	return nil
+}
+
+// RemoveMember 移除成员，仅所有者可操作，不能移除最后一个所有者
+func (s *service) RemoveMember(ctx context.Context, walletAddress string, req *types.RemoveOrganizationMemberRequest) error {
+	logger.Info("RemoveMember", "wallet_address", walletAddress, "organization_id", req.OrganizationID, "member", req.WalletAddress)
+
+	if _, _, err := s.getOrganizationWithRole(ctx, walletAddress, req.OrganizationID, types.OrganizationRoleOwner); err != nil {
+		return err
+	}
+	return s.removeMember(ctx, req.OrganizationID, req.WalletAddress)
+}
+
+// LeaveOrganization 退出组织，移除账户下所有钱包的成员身份，组织中没有账户外的所有者时不能退出
+func (s *service) LeaveOrganization(ctx context.Context, walletAddress string, id int64) error {
+	logger.Info("LeaveOrganization", "wallet_address", walletAddress, "id", id)
+
+	if _, _, err := s.getOrganizationWithRole(ctx, walletAddress, id, types.OrganizationRoleViewer); err != nil {
+		return err
+	}
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+
+	var members []*types.OrganizationMember
+	var owners int64
+	for _, wallet := range wallets {
+		member, err := s.orgRepo.GetMember(ctx, id, wallet)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get member: %w", err)
+		}
+		if member.Role == types.OrganizationRoleOwner {
+			owners++
+		}
+		members = append(members, member)
+	}
+	if owners > 0 {
+		total, err := s.orgRepo.CountOwners(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count owners: %w", err)
+		}
+		if total <= owners {
+			return ErrLastOwner
+		}
+	}
+
+	for _, member := range members {
+		if err := s.orgRepo.RemoveMember(ctx, id, member.WalletAddress); err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+		s.auditor.Record(ctx, types.AuditActionOrganizationLeave, types.AuditResourceOrganization, organizationResourceID(id), id, member, nil)
+	}
+	return nil
+}
+
+// getOrganizationWithRole 获取组织并校验当前用户账户至少拥有required角色，非成员视为组织不存在
+func (s *service) getOrganizationWithRole(ctx context.Context, walletAddress string, id int64, required string) (*types.Organization, string, error) {
+	org, err := s.orgRepo.GetOrganizationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrOrganizationNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	wallets, err := s.accountWallets(ctx, walletAddress)
+	if err != nil {
+		return nil, "", err
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, id, wallets)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get member role: %w", err)
+	}
+	if role == "" {
+		return nil, "", ErrOrganizationNotFound
+	}
+	if !types.HasOrganizationRole(role, required) {
+		return nil, "", ErrPermissionDenied
+	}
+	return org, role, nil
+}
+
+// getMember 获取组织成员
+func (s *service) getMember(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, organizationID, walletAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	return member, nil
+}
+
+// removeMember 移除成员，成员为所有者时需保留至少一个其他所有者
+func (s *service) removeMember(ctx context.Context, organizationID int64, walletAddress string) error {
+	member, err := s.getMember(ctx, organizationID, walletAddress)
+	if err != nil {
+		return err
+	}
+	if member.Role == types.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, organizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, organizationID, member.WalletAddress); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	s.auditor.Record(ctx, types.AuditActionOrganizationRemoveMember, types.AuditResourceOrganization, organizationResourceID(organizationID), organizationID, member, nil)
+	return nil
+}
+
+// ensureAnotherOwner 确认组织除待变更的所有者外还有其他所有者
+func (s *service) ensureAnotherOwner(ctx context.Context, organizationID int64) error {
+	owners, err := s.orgRepo.CountOwners(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to count owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// getPendingInvitationForWallets 获取发给账户下任一钱包的未过期待处理邀请
+func (s *service) getPendingInvitationForWallets(ctx context.Context, wallets []string, id int64) (*types.OrganizationInvitation, error) {
+	invitation, err := s.orgRepo.GetInvitationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if !containsWallet(wallets, invitation.WalletAddress) ||
+		invitation.Status != types.InvitationStatusPending ||
+		time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// updateInvitationStatus 将待处理邀请更新为指定状态
+func (s *service) updateInvitationStatus(ctx context.Context, id int64, status string) error {
+	updated, err := s.orgRepo.UpdateInvitationStatus(ctx, id, types.InvitationStatusPending, status)
+	if err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+	if !updated {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// accountWallets 获取钱包所属账户的全部钱包，组织成员身份按账户下的任一钱包计算
+func (s *service) accountWallets(ctx context.Context, walletAddress string) ([]string, error) {
+	wallets, err := s.userRepo.GetAccountWallets(ctx, crypto.NormalizeAddress(walletAddress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// containsWallet 检查钱包地址是否在列表中（不区分大小写）
+func containsWallet(wallets []string, walletAddress string) bool {
+	for _, wallet := range wallets {
+		if strings.EqualFold(wallet, walletAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+// organizationResourceID 组织审计事件的资源标识
+func organizationResourceID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,459 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	orgRepo "timelocker-backend/internal/repository/organization"
+	userRepo "timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/types"
+
+	"gorm.io/gorm"
+)
+
+const (
+	testOrgID    = int64(1)
+	ownerWallet  = "0x0000000000000000000000000000000000000001"
+	editorWallet = "0x0000000000000000000000000000000000000002"
+	viewerWallet = "0x0000000000000000000000000000000000000003"
+	outsider     = "0x0000000000000000000000000000000000000004"
+	inviteeAddr  = "0x0000000000000000000000000000000000000005"
+	primaryAddr  = "0x0000000000000000000000000000000000000006" // 关联了linkedAddr的账户主钱包
+	linkedAddr   = "0x0000000000000000000000000000000000000007"
+)
+
+// stubOrgRepo 单个组织的内存仓库，成员和邀请按钱包地址索引
+type stubOrgRepo struct {
+	orgRepo.Repository
+	members     map[string]*types.OrganizationMember
+	invitations map[int64]*types.OrganizationInvitation
+}
+
+func newStubOrgRepo(roles map[string]string) *stubOrgRepo {
+	repo := &stubOrgRepo{members: map[string]*types.OrganizationMember{}, invitations: map[int64]*types.OrganizationInvitation{}}
+	for wallet, role := range roles {
+		repo.members[wallet] = &types.OrganizationMember{OrganizationID: testOrgID, WalletAddress: wallet, Role: role}
+	}
+	return repo
+}
+
+func (r *stubOrgRepo) GetOrganizationByID(ctx context.Context, id int64) (*types.Organization, error) {
+	if id != testOrgID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &types.Organization{ID: testOrgID, Name: "TimeLocker"}, nil
+}
+
+func (r *stubOrgRepo) GetMemberRoleByWallets(ctx context.Context, organizationID int64, walletAddresses []string) (string, error) {
+	role := ""
+	for _, wallet := range walletAddresses {
+		if member, ok := r.members[wallet]; ok && organizationID == testOrgID {
+			role = types.HigherOrganizationRole(role, member.Role)
+		}
+	}
+	return role, nil
+}
+
+func (r *stubOrgRepo) GetMember(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationMember, error) {
+	if member, ok := r.members[walletAddress]; ok && organizationID == testOrgID {
+		copied := *member
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubOrgRepo) GetMembers(ctx context.Context, organizationID int64) ([]types.OrganizationMember, error) {
+	members := make([]types.OrganizationMember, 0, len(r.members))
+	for _, member := range r.members {
+		members = append(members, *member)
+	}
+	return members, nil
+}
+
+func (r *stubOrgRepo) CountOwners(ctx context.Context, organizationID int64) (int64, error) {
+	var count int64
+	for _, member := range r.members {
+		if member.Role == types.OrganizationRoleOwner {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *stubOrgRepo) GetPendingInvitationsByOrganization(ctx context.Context, organizationID int64) ([]types.OrganizationInvitation, error) {
+	return []types.OrganizationInvitation{}, nil
+}
+
+func (r *stubOrgRepo) GetPendingInvitation(ctx context.Context, organizationID int64, walletAddress string) (*types.OrganizationInvitation, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubOrgRepo) CreateInvitation(ctx context.Context, invitation *types.OrganizationInvitation) error {
+	return nil
+}
+
+func (r *stubOrgRepo) UpdateOrganization(ctx context.Context, id int64, name, description string) error {
+	return nil
+}
+
+func (r *stubOrgRepo) CountOrganizationResources(ctx context.Context, id int64) (int64, error) {
+	return 0, nil
+}
+
+func (r *stubOrgRepo) DeleteOrganization(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *stubOrgRepo) UpdateMemberRole(ctx context.Context, organizationID int64, walletAddress, role string) error {
+	r.members[walletAddress].Role = role
+	return nil
+}
+
+func (r *stubOrgRepo) RemoveMember(ctx context.Context, organizationID int64, walletAddress string) error {
+	delete(r.members, walletAddress)
+	return nil
+}
+
+func (r *stubOrgRepo) GetInvitationByID(ctx context.Context, id int64) (*types.OrganizationInvitation, error) {
+	if invitation, ok := r.invitations[id]; ok {
+		copied := *invitation
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *stubOrgRepo) AcceptInvitation(ctx context.Context, invitation *types.OrganizationInvitation, member *types.OrganizationMember) error {
+	r.invitations[invitation.ID].Status = types.InvitationStatusAccepted
+	copied := *member
+	r.members[member.WalletAddress] = &copied
+	return nil
+}
+
+// stubAccountWallets primaryAddr和linkedAddr属于同一账户，其他钱包各自独立
+type stubAccountWallets struct {
+	userRepo.Repository
+}
+
+func (stubAccountWallets) GetAccountWallets(ctx context.Context, walletAddress string) ([]string, error) {
+	if walletAddress == primaryAddr || walletAddress == linkedAddr {
+		return []string{primaryAddr, linkedAddr}, nil
+	}
+	return []string{walletAddress}, nil
+}
+
+// nopRecorder 丢弃审计事件
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action, resourceType, resourceID string, organizationID int64, before, after interface{}) {
+}
+
+func newTestService(repo *stubOrgRepo) *service {
+	return &service{orgRepo: repo, userRepo: stubAccountWallets{}, auditor: nopRecorder{}}
+}
+
+func TestOrganizationRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	actions := []struct {
+		name string
+		run  func(s *service, wallet string) error
+		want map[string]error // 按调用者钱包的期望错误
+	}{
+		{
+			name: "view detail",
+			run: func(s *service, wallet string) error {
+				_, err := s.GetOrganizationDetail(ctx, wallet, testOrgID)
+				return err
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: nil, viewerWallet: nil, outsider: ErrOrganizationNotFound},
+		},
+		{
+			name: "update organization",
+			run: func(s *service, wallet string) error {
+				return s.UpdateOrganization(ctx, wallet, &types.UpdateOrganizationRequest{ID: testOrgID, Name: "Renamed"})
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: ErrPermissionDenied, viewerWallet: ErrPermissionDenied, outsider: ErrOrganizationNotFound},
+		},
+		{
+			name: "delete organization",
+			run: func(s *service, wallet string) error {
+				return s.DeleteOrganization(ctx, wallet, testOrgID)
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: ErrPermissionDenied, viewerWallet: ErrPermissionDenied, outsider: ErrOrganizationNotFound},
+		},
+		{
+			name: "invite member",
+			run: func(s *service, wallet string) error {
+				_, err := s.InviteMember(ctx, wallet, &types.InviteOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: inviteeAddr, Role: types.OrganizationRoleViewer})
+				return err
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: ErrPermissionDenied, viewerWallet: ErrPermissionDenied, outsider: ErrOrganizationNotFound},
+		},
+		{
+			name: "change member role",
+			run: func(s *service, wallet string) error {
+				return s.UpdateMemberRole(ctx, wallet, &types.UpdateOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: viewerWallet, Role: types.OrganizationRoleEditor})
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: ErrPermissionDenied, viewerWallet: ErrPermissionDenied, outsider: ErrOrganizationNotFound},
+		},
+		{
+			name: "remove member",
+			run: func(s *service, wallet string) error {
+				return s.RemoveMember(ctx, wallet, &types.RemoveOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: editorWallet})
+			},
+			want: map[string]error{ownerWallet: nil, editorWallet: ErrPermissionDenied, viewerWallet: ErrPermissionDenied, outsider: ErrOrganizationNotFound},
+		},
+	}
+
+	callers := []struct {
+		name   string
+		wallet string
+	}{
+		{"owner", ownerWallet},
+		{"editor", editorWallet},
+		{"viewer", viewerWallet},
+		{"non-member", outsider},
+	}
+
+	for _, action := range actions {
+		for _, caller := range callers {
+			wallet, wantErr := caller.wallet, action.want[caller.wallet]
+			t.Run(action.name+"/"+caller.name, func(t *testing.T) {
+				s := newTestService(newStubOrgRepo(map[string]string{
+					ownerWallet:  types.OrganizationRoleOwner,
+					editorWallet: types.OrganizationRoleEditor,
+					viewerWallet: types.OrganizationRoleViewer,
+				}))
+				err := action.run(s, wallet)
+				if wantErr == nil {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, wantErr) {
+					t.Fatalf("got error %v, want %v", err, wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestOrganizationKeepsLastOwner(t *testing.T) {
+	ctx := context.Background()
+	const secondOwner = inviteeAddr
+
+	tests := []struct {
+		name    string
+		roles   map[string]string
+		run     func(s *service) error
+		wantErr error
+	}{
+		{
+			name:  "demote only owner",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, editorWallet: types.OrganizationRoleEditor},
+			run: func(s *service) error {
+				return s.UpdateMemberRole(ctx, ownerWallet, &types.UpdateOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: ownerWallet, Role: types.OrganizationRoleEditor})
+			},
+			wantErr: ErrLastOwner,
+		},
+		{
+			name:  "demote one of two owners",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, secondOwner: types.OrganizationRoleOwner},
+			run: func(s *service) error {
+				return s.UpdateMemberRole(ctx, ownerWallet, &types.UpdateOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: secondOwner, Role: types.OrganizationRoleViewer})
+			},
+		},
+		{
+			name:  "remove only owner",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner},
+			run: func(s *service) error {
+				return s.RemoveMember(ctx, ownerWallet, &types.RemoveOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: ownerWallet})
+			},
+			wantErr: ErrLastOwner,
+		},
+		{
+			name:  "only owner leaves",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, viewerWallet: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				return s.LeaveOrganization(ctx, ownerWallet, testOrgID)
+			},
+			wantErr: ErrLastOwner,
+		},
+		{
+			name:  "owner leaves with another owner",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, secondOwner: types.OrganizationRoleOwner},
+			run: func(s *service) error {
+				return s.LeaveOrganization(ctx, ownerWallet, testOrgID)
+			},
+		},
+		{
+			name:  "viewer leaves",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, viewerWallet: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				return s.LeaveOrganization(ctx, viewerWallet, testOrgID)
+			},
+		},
+		{
+			name:  "invalid role",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, viewerWallet: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				return s.UpdateMemberRole(ctx, ownerWallet, &types.UpdateOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: viewerWallet, Role: "admin"})
+			},
+			wantErr: ErrInvalidRole,
+		},
+		{
+			name:  "invite existing member",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, viewerWallet: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				_, err := s.InviteMember(ctx, ownerWallet, &types.InviteOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: viewerWallet, Role: types.OrganizationRoleEditor})
+				return err
+			},
+			wantErr: ErrAlreadyMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newTestService(newStubOrgRepo(tt.roles)))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganizationLinkedWallets(t *testing.T) {
+	ctx := context.Background()
+	pendingInvitation := func(wallet string) *types.OrganizationInvitation {
+		return &types.OrganizationInvitation{
+			ID:             1,
+			OrganizationID: testOrgID,
+			WalletAddress:  wallet,
+			Role:           types.OrganizationRoleEditor,
+			Status:         types.InvitationStatusPending,
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		roles       map[string]string
+		invitation  *types.OrganizationInvitation
+		run         func(s *service) error
+		wantErr     error
+		wantMembers []string // 操作后的成员钱包，为nil时不检查
+	}{
+		{
+			name:  "role held by linked wallet",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, linkedAddr: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				_, err := s.GetOrganizationDetail(ctx, primaryAddr, testOrgID)
+				return err
+			},
+		},
+		{
+			name:  "linked viewer cannot act as owner",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, linkedAddr: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				return s.UpdateOrganization(ctx, primaryAddr, &types.UpdateOrganizationRequest{ID: testOrgID, Name: "Renamed"})
+			},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:  "highest role across wallets",
+			roles: map[string]string{primaryAddr: types.OrganizationRoleViewer, linkedAddr: types.OrganizationRoleOwner},
+			run: func(s *service) error {
+				return s.UpdateOrganization(ctx, primaryAddr, &types.UpdateOrganizationRequest{ID: testOrgID, Name: "Renamed"})
+			},
+		},
+		{
+			name:  "invite account whose linked wallet is a member",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, linkedAddr: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				_, err := s.InviteMember(ctx, ownerWallet, &types.InviteOrganizationMemberRequest{OrganizationID: testOrgID, WalletAddress: primaryAddr, Role: types.OrganizationRoleEditor})
+				return err
+			},
+			wantErr: ErrAlreadyMember,
+		},
+		{
+			name:       "accept invitation sent to linked wallet",
+			roles:      map[string]string{ownerWallet: types.OrganizationRoleOwner},
+			invitation: pendingInvitation(linkedAddr),
+			run: func(s *service) error {
+				return s.AcceptInvitation(ctx, 6, primaryAddr, 1)
+			},
+			wantMembers: []string{ownerWallet, linkedAddr},
+		},
+		{
+			name:       "accept while linked wallet is a member",
+			roles:      map[string]string{ownerWallet: types.OrganizationRoleOwner, linkedAddr: types.OrganizationRoleViewer},
+			invitation: pendingInvitation(primaryAddr),
+			run: func(s *service) error {
+				return s.AcceptInvitation(ctx, 6, primaryAddr, 1)
+			},
+			wantErr: ErrAlreadyMember,
+		},
+		{
+			name:       "invitation for another account",
+			roles:      map[string]string{ownerWallet: types.OrganizationRoleOwner},
+			invitation: pendingInvitation(inviteeAddr),
+			run: func(s *service) error {
+				return s.AcceptInvitation(ctx, 6, primaryAddr, 1)
+			},
+			wantErr: ErrInvitationNotFound,
+		},
+		{
+			name:  "leave removes every account membership",
+			roles: map[string]string{ownerWallet: types.OrganizationRoleOwner, primaryAddr: types.OrganizationRoleViewer, linkedAddr: types.OrganizationRoleOwner},
+			run: func(s *service) error {
+				return s.LeaveOrganization(ctx, primaryAddr, testOrgID)
+			},
+			wantMembers: []string{ownerWallet},
+		},
+		{
+			name:  "account holds the only owners",
+			roles: map[string]string{primaryAddr: types.OrganizationRoleOwner, linkedAddr: types.OrganizationRoleOwner, viewerWallet: types.OrganizationRoleViewer},
+			run: func(s *service) error {
+				return s.LeaveOrganization(ctx, linkedAddr, testOrgID)
+			},
+			wantErr:     ErrLastOwner,
+			wantMembers: []string{primaryAddr, linkedAddr, viewerWallet},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubOrgRepo(tt.roles)
+			if tt.invitation != nil {
+				repo.invitations[tt.invitation.ID] = tt.invitation
+			}
+			err := tt.run(newTestService(repo))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantMembers != nil {
+				if len(repo.members) != len(tt.wantMembers) {
+					t.Fatalf("members %v, want %v", repo.members, tt.wantMembers)
+				}
+				for _, wallet := range tt.wantMembers {
+					if _, ok := repo.members[wallet]; !ok {
+						t.Fatalf("member %s missing, members %v", wallet, repo.members)
+					}
+				}
+			}
+		})
+	}
+}
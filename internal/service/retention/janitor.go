@@ -0,0 +1,277 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"timelocker-backend/internal/config"
+	retentionRepo "timelocker-backend/internal/repository/retention"
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/database"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Janitor 历史数据清理器，按cron表达式依次执行每张表的保留策略并记录运行结果
+type Janitor struct {
+	config  *config.RetentionConfig
+	repo    retentionRepo.Repository
+	manager *database.BackupManager // 写入归档，没有策略需要归档时可为nil
+	cron    *cron.Cron
+
+	mu      sync.Mutex // 同一时间只运行一次清理
+	stateMu sync.RWMutex
+	running bool
+	stats   map[string]*types.RetentionTableStats
+}
+
+// NewJanitor 创建历史数据清理器
+func NewJanitor(cfg *config.RetentionConfig, repo retentionRepo.Repository, manager *database.BackupManager) *Janitor {
+	return &Janitor{
+		config:  cfg,
+		repo:    repo,
+		manager: manager,
+		cron:    cron.New(),
+		stats:   make(map[string]*types.RetentionTableStats),
+	}
+}
+
+// Start 校验保留策略、注册cron任务并启动调度，配置无效时返回错误
+func (j *Janitor) Start(ctx context.Context) error {
+	archive := false
+	for _, policy := range j.config.Policies {
+		if err := retentionRepo.CheckPolicy(policy.Table, policy.Statuses); err != nil {
+			return err
+		}
+		if policy.MaxAge <= 0 {
+			return fmt.Errorf("retention policy for %s requires a positive max_age", policy.Table)
+		}
+		if _, ok := j.stats[policy.Table]; ok {
+			return fmt.Errorf("duplicate retention policy for %s", policy.Table)
+		}
+		j.stats[policy.Table] = &types.RetentionTableStats{Table: policy.Table}
+		archive = archive || policy.Archive
+	}
+	if archive {
+		if j.manager == nil {
+			return errors.New("retention archive requires a backup manager")
+		}
+		if err := os.MkdirAll(j.config.ArchiveDir, 0755); err != nil {
+			return fmt.Errorf("failed to create retention archive directory: %w", err)
+		}
+	}
+	if _, err := j.cron.AddFunc(j.config.Cron, func() { j.RunOnce(ctx) }); err != nil {
+		return fmt.Errorf("invalid retention cron %q: %w", j.config.Cron, err)
+	}
+
+	// 服务重启前未完成的运行不会再结束，标记为失败
+	if count, err := j.repo.FailRunningRuns(ctx, "interrupted by server restart"); err != nil {
+		logger.Error("Failed to mark interrupted retention runs", err)
+	} else if count > 0 {
+		logger.Warn("Marked interrupted retention runs as failed", "count", count)
+	}
+	j.loadState(ctx)
+
+	j.cron.Start()
+	logger.Info("Retention janitor started", "cron", j.config.Cron, "policies", len(j.config.Policies), "archive_dir", j.config.ArchiveDir)
+	return nil
+}
+
+// Stop 停止调度并等待正在运行的清理结束
+func (j *Janitor) Stop() {
+	<-j.cron.Stop().Done()
+	logger.Info("Retention janitor stopped")
+}
+
+// loadState 从运行记录恢复每张表最近一次运行状态
+func (j *Janitor) loadState(ctx context.Context) {
+	runs, err := j.repo.GetLatestRuns(ctx)
+	if err != nil {
+		return
+	}
+
+	j.stateMu.Lock()
+	defer j.stateMu.Unlock()
+	for i := range runs {
+		if stats, ok := j.stats[runs[i].TargetTable]; ok {
+			stats.LastRun = &runs[i]
+			if runs[i].Status == types.RetentionRunStatusSuccess {
+				stats.LastSuccessAt = &runs[i].StartedAt
+			}
+		}
+	}
+}
+
+// RunOnce 依次执行全部保留策略，上一次清理仍在运行时跳过
+func (j *Janitor) RunOnce(ctx context.Context) {
+	if !j.mu.TryLock() {
+		logger.Warn("Previous retention run still running, skip this run")
+		return
+	}
+	defer j.mu.Unlock()
+
+	j.setRunning(true)
+	defer j.setRunning(false)
+
+	timeout := j.config.Timeout
+	if timeout <= 0 {
+		timeout = time.Hour
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, policy := range j.config.Policies {
+		if ctx.Err() != nil {
+			logger.Warn("Retention run stopped before all policies completed", "error", ctx.Err())
+			return
+		}
+		j.runPolicy(ctx, policy)
+	}
+}
+
+// runPolicy 执行一张表的保留策略并记录结果
+func (j *Janitor) runPolicy(ctx context.Context, policy config.RetentionPolicy) {
+	startedAt := time.Now()
+	run := &types.RetentionRun{
+		TargetTable: policy.Table,
+		Status:      types.RetentionRunStatusRunning,
+		Cutoff:      startedAt.Add(-policy.MaxAge),
+		StartedAt:   startedAt,
+	}
+	if err := j.repo.CreateRun(ctx, run); err != nil {
+		logger.Error("Failed to record retention run", err, "table", policy.Table)
+	}
+
+	err := j.prune(ctx, policy, run)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(startedAt).Milliseconds()
+	if err != nil {
+		message := err.Error()
+		run.Status = types.RetentionRunStatusFailed
+		run.ErrorMessage = &message
+		logger.Error("Retention policy failed", err, "table", policy.Table, "deleted", run.DeletedRows, "duration", finishedAt.Sub(startedAt))
+	} else {
+		run.Status = types.RetentionRunStatusSuccess
+		logger.Info("Retention policy completed", "table", policy.Table, "cutoff", run.Cutoff, "deleted", run.DeletedRows, "archived", run.ArchivedRows, "duration", finishedAt.Sub(startedAt))
+	}
+
+	// 记录结果时不使用可能已取消的context
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if run.ID > 0 {
+		if err := j.repo.UpdateRun(saveCtx, run); err != nil {
+			logger.Error("Failed to update retention run", err, "id", run.ID)
+		}
+	} else if err := j.repo.CreateRun(saveCtx, run); err != nil {
+		logger.Error("Failed to record retention run", err, "table", policy.Table)
+	}
+	j.recordRun(run)
+}
+
+// prune 删除超过保留期的行，需要归档时先写入归档，再只删除已归档的行
+func (j *Janitor) prune(ctx context.Context, policy config.RetentionPolicy, run *types.RetentionRun) error {
+	where, args, err := retentionRepo.PruneFilter(policy.Table, run.Cutoff, policy.Statuses)
+	if err != nil {
+		return err
+	}
+	batchSize := j.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	if !policy.Archive {
+		for {
+			deleted, err := j.repo.DeleteBatch(ctx, policy.Table, where, args, batchSize)
+			if err != nil {
+				return err
+			}
+			run.DeletedRows += deleted
+			if deleted < int64(batchSize) {
+				return nil
+			}
+		}
+	}
+
+	path := filepath.Join(j.config.ArchiveDir, fmt.Sprintf("retention_%s_%s.tar", policy.Table, run.StartedAt.Format("20060102_150405")))
+	archive, err := j.manager.ArchiveRows(ctx, path, policy.Table, where, args)
+	if err != nil {
+		return err
+	}
+	if archive.Rows == 0 {
+		return nil
+	}
+	run.ArchivedRows = archive.Rows
+	run.ArchiveFile = archive.Path
+
+	// 归档后状态发生变化的行仍满足保护条件，不会被删除
+	for start := 0; start < len(archive.IDs); start += batchSize {
+		end := start + batchSize
+		if end > len(archive.IDs) {
+			end = len(archive.IDs)
+		}
+		deleted, err := j.repo.DeleteByIDs(ctx, policy.Table, archive.IDs[start:end], where, args)
+		if err != nil {
+			return err
+		}
+		run.DeletedRows += deleted
+	}
+	return nil
+}
+
+func (j *Janitor) setRunning(running bool) {
+	j.stateMu.Lock()
+	defer j.stateMu.Unlock()
+	j.running = running
+}
+
+// recordRun 累加表的清理统计
+func (j *Janitor) recordRun(run *types.RetentionRun) {
+	j.stateMu.Lock()
+	defer j.stateMu.Unlock()
+	stats, ok := j.stats[run.TargetTable]
+	if !ok {
+		return
+	}
+	snapshot := *run
+	stats.LastRun = &snapshot
+	stats.Runs++
+	stats.DeletedRows += run.DeletedRows
+	stats.ArchivedRows += run.ArchivedRows
+	if run.Status == types.RetentionRunStatusFailed {
+		stats.Failures++
+	} else {
+		startedAt := run.StartedAt
+		stats.LastSuccessAt = &startedAt
+	}
+}
+
+// Health 返回历史数据清理状态和本次服务启动以来的累计统计，不查询数据库
+func (j *Janitor) Health() *types.RetentionHealth {
+	j.stateMu.RLock()
+	defer j.stateMu.RUnlock()
+
+	health := &types.RetentionHealth{
+		Running: j.running,
+		Tables:  make([]types.RetentionTableStats, 0, len(j.stats)),
+	}
+	for _, entry := range j.cron.Entries() {
+		if !entry.Next.IsZero() {
+			next := entry.Next
+			health.NextRunAt = &next
+		}
+	}
+	for _, stats := range j.stats {
+		health.Tables = append(health.Tables, *stats)
+	}
+	sort.Slice(health.Tables, func(a, b int) bool { return health.Tables[a].Table < health.Tables[b].Table })
+	return health
+}
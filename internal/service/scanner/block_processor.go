@@ -10,12 +10,14 @@ import (
 	"timelocker-backend/internal/config"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/tracing"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // BlockProcessor 区块处理器
@@ -121,9 +123,15 @@ func (bp *BlockProcessor) ScanBlockRange(ctx context.Context, client *ethclient.
 
 	// 处理每个日志
 	for _, log := range logs {
-		event, err := bp.processLog(ctx, client, &log)
+		logCtx, span := tracing.Start(ctx, "scanner.process_log",
+			attribute.String("tx_hash", log.TxHash.Hex()),
+			attribute.Int64("block", int64(log.BlockNumber)),
+			attribute.Int("log_index", int(log.Index)),
+		)
+		event, err := bp.processLog(logCtx, client, &log)
+		tracing.End(span, err)
 		if err != nil {
-			logger.Error("Failed to process log", err, "tx_hash", log.TxHash.Hex(), "block", log.BlockNumber)
+			logger.ErrorCtx(logCtx, "Failed to process log", err, "tx_hash", log.TxHash.Hex(), "block", log.BlockNumber)
 			continue
 		}
 		if event != nil {
@@ -206,7 +214,7 @@ func (bp *BlockProcessor) processLog(ctx context.Context, client *ethclient.Clie
 func (bp *BlockProcessor) getBlockTimestamp(ctx context.Context, client *ethclient.Client, blockNumber uint64) (uint64, error) {
 	header, err := client.HeaderByNumber(ctx, big.NewInt(int64(blockNumber)))
 	if err != nil {
-		logger.Warn("Failed to get block header by number", "block", blockNumber, "chain", bp.chainInfo.ChainName, "error", err)
+		logger.WarnCtx(ctx, "Failed to get block header by number", "block", blockNumber, "chain", bp.chainInfo.ChainName, "error", err)
 		return 0, err
 	}
 	return header.Time, nil
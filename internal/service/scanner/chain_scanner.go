@@ -11,20 +11,14 @@ import (
 	"timelocker-backend/internal/repository/timelock"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// EmailService 邮件服务接口（避免循环依赖）
-type EmailService interface {
-	SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error
-}
-
-// NotificationService 通知服务接口（避免循环依赖）
-type NotificationService interface {
-	SendFlowNotification(ctx context.Context, standard string, chainID int, contractAddress string, flowID string, statusFrom, statusTo string, txHash *string, initiatorAddress string) error
-}
-
 // ChainScanner 单链扫描器
 type ChainScanner struct {
 	config       *config.Config
@@ -68,8 +62,6 @@ func NewChainScanner(
 	progressRepo scanner.ProgressRepository,
 	txRepo scanner.TransactionRepository,
 	flowRepo scanner.FlowRepository,
-	emailService EmailService,
-	notificationService NotificationService,
 	timelockRepo timelock.Repository,
 ) *ChainScanner {
 	cs := &ChainScanner{
@@ -87,7 +79,7 @@ func NewChainScanner(
 
 	// 创建处理器
 	cs.blockProcessor = NewBlockProcessor(cfg, cs.chainInfo)
-	cs.eventProcessor = NewEventProcessor(cfg, txRepo, flowRepo, emailService, notificationService, timelockRepo)
+	cs.eventProcessor = NewEventProcessor(cfg, txRepo, flowRepo, timelockRepo)
 
 	return cs
 }
@@ -167,8 +159,17 @@ func (cs *ChainScanner) scanLoop(ctx context.Context) {
 				cs.updateProgressStatus("running", "")
 			}
 
-			if err := cs.scanBlocks(ctx); err != nil {
-				logger.Error("Scan blocks failed", err, "chain_id", cs.chainInfo.ChainID)
+			// 每轮扫描一个span，区块范围在scanBlocks中确定后补充
+			scanCtx, span := tracing.Start(ctx, "scanner.scan",
+				attribute.Int("chain_id", cs.chainInfo.ChainID),
+				attribute.String("chain_name", cs.chainInfo.ChainName),
+			)
+			start := time.Now()
+			err := cs.scanBlocks(scanCtx)
+			metrics.ObserveScan(cs.chainInfo.ChainID, cs.chainInfo.ChainName, time.Since(start), err)
+			tracing.End(span, err)
+			if err != nil {
+				logger.ErrorCtx(scanCtx, "Scan blocks failed", err, "chain_id", cs.chainInfo.ChainID)
 				cs.updateProgressStatus("error", err.Error())
 
 				// 发生错误时等待一段时间再重试
@@ -204,7 +205,7 @@ func (cs *ChainScanner) scanBlocks(ctx context.Context) error {
 	var latestBlock uint64
 
 	// 使用RPC管理器的重试机制获取最新区块号
-	err := cs.rpcManager.ExecuteWithRetry(ctx, cs.chainInfo.ChainID, func(client *ethclient.Client) error {
+	err := cs.rpcManager.ExecuteWithRetry(ctx, cs.chainInfo.ChainID, func(ctx context.Context, client *ethclient.Client) error {
 		var err error
 		latestBlock, err = client.BlockNumber(ctx)
 		return err
@@ -215,10 +216,16 @@ func (cs *ChainScanner) scanBlocks(ctx context.Context) error {
 
 	// 更新最新网络区块号
 	cs.progress.LatestNetworkBlock = int64(latestBlock)
+	metrics.SetScanProgress(cs.chainInfo.ChainID, cs.chainInfo.ChainName, cs.progress.LastScannedBlock, int64(latestBlock))
 
 	// 计算需要扫描的区块范围
 	fromBlock := cs.progress.LastScannedBlock + 1
 	toBlock := cs.calculateToBlock(fromBlock, int64(latestBlock))
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int64("latest_block", int64(latestBlock)),
+		attribute.Int64("from_block", fromBlock),
+		attribute.Int64("to_block", toBlock),
+	)
 
 	if fromBlock > int64(latestBlock) {
 		logger.Debug("No new blocks to scan", "chain_id", cs.chainInfo.ChainID, "latest", latestBlock)
@@ -235,7 +242,7 @@ func (cs *ChainScanner) scanBlocks(ctx context.Context) error {
 		var events []TimelockEvent
 
 		// 使用RPC管理器的重试机制扫描区块范围
-		err := cs.rpcManager.ExecuteWithRetry(ctx, cs.chainInfo.ChainID, func(client *ethclient.Client) error {
+		err := cs.rpcManager.ExecuteWithRetry(ctx, cs.chainInfo.ChainID, func(ctx context.Context, client *ethclient.Client) error {
 			var err error
 			events, err = cs.blockProcessor.ScanBlockRange(ctx, client, fromBlock, toBlock)
 			return err
@@ -244,6 +251,7 @@ func (cs *ChainScanner) scanBlocks(ctx context.Context) error {
 			return fmt.Errorf("failed to scan block range %d-%d: %w", fromBlock, toBlock, err)
 		}
 
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int("events", len(events)))
 		if len(events) > 0 {
 			// 处理事件
 			if err := cs.eventProcessor.ProcessEvents(ctx, cs.chainInfo.ChainID, cs.chainInfo.ChainName, events); err != nil {
@@ -255,9 +263,11 @@ func (cs *ChainScanner) scanBlocks(ctx context.Context) error {
 		cs.progress.LastScannedBlock = toBlock
 		cs.progress.LastUpdateTime = time.Now()
 		cs.lastUpdate = time.Now()
+		metrics.AddBlocksScanned(cs.chainInfo.ChainID, cs.chainInfo.ChainName, toBlock-fromBlock+1)
+		metrics.SetScanProgress(cs.chainInfo.ChainID, cs.chainInfo.ChainName, toBlock, int64(latestBlock))
 
 		if err := cs.progressRepo.UpdateProgressBlock(ctx, cs.chainInfo.ChainID, toBlock, int64(latestBlock)); err != nil {
-			logger.Error("Failed to update progress", err, "chain_id", cs.chainInfo.ChainID, "block", toBlock)
+			logger.ErrorCtx(ctx, "Failed to update progress", err, "chain_id", cs.chainInfo.ChainID, "block", toBlock)
 		}
 	}
 
@@ -16,12 +16,10 @@ import (
 
 // EventProcessor 事件处理器
 type EventProcessor struct {
-	config              *config.Config
-	txRepo              scanner.TransactionRepository
-	flowRepo            scanner.FlowRepository
-	emailService        EmailService        // 邮件服务接口
-	notificationService NotificationService // 通知服务接口
-	timelockRepo        timelock.Repository
+	config       *config.Config
+	txRepo       scanner.TransactionRepository
+	flowRepo     scanner.FlowRepository
+	timelockRepo timelock.Repository
 }
 
 // NewEventProcessor 创建新的事件处理器
@@ -29,20 +27,44 @@ func NewEventProcessor(
 	cfg *config.Config,
 	txRepo scanner.TransactionRepository,
 	flowRepo scanner.FlowRepository,
-	emailService EmailService,
-	notificationService NotificationService,
 	timelockRepo timelock.Repository,
 ) *EventProcessor {
 	return &EventProcessor{
-		config:              cfg,
-		txRepo:              txRepo,
-		flowRepo:            flowRepo,
-		emailService:        emailService,
-		notificationService: notificationService,
-		timelockRepo:        timelockRepo,
+		config:       cfg,
+		txRepo:       txRepo,
+		flowRepo:     flowRepo,
+		timelockRepo: timelockRepo,
 	}
 }
 
+// buildFlowOutbox 构建流程状态变更的通知发件箱记录（邮件通知与渠道通知各一条）
+func buildFlowOutbox(cfg *config.Config, standard string, chainID int, contractAddress, flowID, statusFrom, statusTo string, txHash *string, initiatorAddress string) []types.NotificationOutbox {
+	maxAttempts := cfg.Notification.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	now := time.Now()
+
+	outbox := make([]types.NotificationOutbox, 0, 2)
+	for _, channel := range []string{types.OutboxChannelEmail, types.OutboxChannelIM} {
+		outbox = append(outbox, types.NotificationOutbox{
+			Channel:          channel,
+			FlowID:           flowID,
+			TimelockStandard: standard,
+			ChainID:          chainID,
+			ContractAddress:  contractAddress,
+			StatusFrom:       statusFrom,
+			StatusTo:         statusTo,
+			TxHash:           txHash,
+			InitiatorAddress: initiatorAddress,
+			Status:           types.OutboxStatusPending,
+			MaxAttempts:      maxAttempts,
+			NextAttemptAt:    now,
+		})
+	}
+	return outbox
+}
+
 // ProcessEvents 处理事件列表
 func (ep *EventProcessor) ProcessEvents(ctx context.Context, chainID int, chainName string, events []TimelockEvent) error {
 	if len(events) == 0 {
@@ -221,14 +243,16 @@ func (ep *EventProcessor) processCompoundFlow(ctx context.Context, event *types.
 			Value:            event.EventValue,
 		}
 
-		if err := ep.flowRepo.CreateFlow(ctx, flow); err != nil {
-			return fmt.Errorf("failed to create flow: %w", err)
-		}
-
 		statusFrom = ""
 		statusTo = "waiting"
 		txHash = &event.TxHash
 
+		// 流程与通知发件箱在同一事务中写入
+		outbox := buildFlowOutbox(ep.config, "compound", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress)
+		if err := ep.flowRepo.CreateFlow(ctx, flow, outbox); err != nil {
+			return fmt.Errorf("failed to create flow: %w", err)
+		}
+
 		logger.Info("Created new Compound flow", "flow_id", flowID, "status", statusTo)
 	} else {
 		// 更新现有流程
@@ -283,7 +307,9 @@ func (ep *EventProcessor) processCompoundFlow(ctx context.Context, event *types.
 		}
 
 		if statusFrom != statusTo {
-			if err := ep.flowRepo.UpdateFlow(ctx, flow); err != nil {
+			// 流程与通知发件箱在同一事务中写入
+			outbox := buildFlowOutbox(ep.config, "compound", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress)
+			if err := ep.flowRepo.UpdateFlow(ctx, flow, outbox); err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
 
@@ -291,22 +317,6 @@ func (ep *EventProcessor) processCompoundFlow(ctx context.Context, event *types.
 		}
 	}
 
-	// 发送邮件通知
-	if statusFrom != statusTo && ep.emailService != nil {
-		if err := ep.emailService.SendFlowNotification(ctx, "compound", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress); err != nil {
-			logger.Error("Failed to send email notification", err, "flow_id", flowID, "status_change", statusFrom+"->"+statusTo)
-			// 不返回错误，因为邮件发送失败不应该影响流程状态更新
-		}
-	}
-
-	// 发送渠道通知
-	if statusFrom != statusTo && ep.notificationService != nil {
-		if err := ep.notificationService.SendFlowNotification(ctx, "compound", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress); err != nil {
-			logger.Error("Failed to send channel notification", err, "flow_id", flowID, "status_change", statusFrom+"->"+statusTo)
-			// 不返回错误，因为通知发送失败不应该影响流程状态更新
-		}
-	}
-
 	return nil
 }
 
@@ -364,14 +374,16 @@ func (ep *EventProcessor) processOpenZeppelinFlow(ctx context.Context, event *ty
 			Value:            event.EventValue,
 		}
 
-		if err := ep.flowRepo.CreateFlow(ctx, flow); err != nil {
-			return fmt.Errorf("failed to create flow: %w", err)
-		}
-
 		statusFrom = ""
 		statusTo = "waiting"
 		txHash = &event.TxHash
 
+		// 流程与通知发件箱在同一事务中写入
+		outbox := buildFlowOutbox(ep.config, "openzeppelin", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress)
+		if err := ep.flowRepo.CreateFlow(ctx, flow, outbox); err != nil {
+			return fmt.Errorf("failed to create flow: %w", err)
+		}
+
 		logger.Info("Created new OpenZeppelin flow", "flow_id", flowID, "status", statusTo)
 	} else {
 		// 更新现有流程
@@ -413,7 +425,9 @@ func (ep *EventProcessor) processOpenZeppelinFlow(ctx context.Context, event *ty
 		}
 
 		if statusFrom != statusTo {
-			if err := ep.flowRepo.UpdateFlow(ctx, flow); err != nil {
+			// 流程与通知发件箱在同一事务中写入
+			outbox := buildFlowOutbox(ep.config, "openzeppelin", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress)
+			if err := ep.flowRepo.UpdateFlow(ctx, flow, outbox); err != nil {
 				return fmt.Errorf("failed to update flow: %w", err)
 			}
 
@@ -421,21 +435,5 @@ func (ep *EventProcessor) processOpenZeppelinFlow(ctx context.Context, event *ty
 		}
 	}
 
-	// 发送邮件通知
-	if statusFrom != statusTo && ep.emailService != nil {
-		if err := ep.emailService.SendFlowNotification(ctx, "openzeppelin", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress); err != nil {
-			logger.Error("Failed to send email notification", err, "flow_id", flowID, "status_change", statusFrom+"->"+statusTo)
-			// 不返回错误，因为邮件发送失败不应该影响流程状态更新
-		}
-	}
-
-	// 发送渠道通知
-	if statusFrom != statusTo && ep.notificationService != nil {
-		if err := ep.notificationService.SendFlowNotification(ctx, "openzeppelin", event.ChainID, normalizedContract, flowID, statusFrom, statusTo, txHash, event.FromAddress); err != nil {
-			logger.Error("Failed to send channel notification", err, "flow_id", flowID, "status_change", statusFrom+"->"+statusTo)
-			// 不返回错误，因为通知发送失败不应该影响流程状态更新
-		}
-	}
-
 	return nil
 }
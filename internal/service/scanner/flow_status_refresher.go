@@ -13,12 +13,10 @@ import (
 
 // FlowStatusRefresher 流程状态刷新器
 type FlowStatusRefresher struct {
-	config              *config.Config
-	flowRepo            scanner.FlowRepository
-	timelockRepo        timelock.Repository
-	emailService        EmailService
-	notificationService NotificationService
-	stopCh              chan struct{}
+	config       *config.Config
+	flowRepo     scanner.FlowRepository
+	timelockRepo timelock.Repository
+	stopCh       chan struct{}
 }
 
 // NewFlowStatusRefresher 创建新的流程状态刷新器
@@ -26,16 +24,12 @@ func NewFlowStatusRefresher(
 	cfg *config.Config,
 	flowRepo scanner.FlowRepository,
 	timelockRepo timelock.Repository,
-	emailService EmailService,
-	notificationService NotificationService,
 ) *FlowStatusRefresher {
 	return &FlowStatusRefresher{
-		config:              cfg,
-		flowRepo:            flowRepo,
-		timelockRepo:        timelockRepo,
-		emailService:        emailService,
-		notificationService: notificationService,
-		stopCh:              make(chan struct{}),
+		config:       cfg,
+		flowRepo:     flowRepo,
+		timelockRepo: timelockRepo,
+		stopCh:       make(chan struct{}),
 	}
 }
 
@@ -100,57 +94,23 @@ func (fsr *FlowStatusRefresher) processWaitingToReady(ctx context.Context, now t
 
 	logger.Info("Processing waiting->ready transitions", "count", len(flows))
 
-	// 批量更新状态
-	if err := fsr.flowRepo.BatchUpdateFlowStatus(ctx, flows, "ready"); err != nil {
-		return err
-	}
-
-	// 发送通知（过滤导入前的历史交易）
+	// 构建通知发件箱（过滤导入前的历史交易）
+	var outbox []types.NotificationOutbox
 	for _, flow := range flows {
-		if flow.InitiatorAddress != nil {
-			if fsr.shouldSuppressNotification(ctx, &flow) {
-				logger.Debug("Skip notification for historical flow (waiting->ready)", "flow_id", flow.FlowID)
-				continue
-			}
-
-			// 发送邮件通知
-			if fsr.emailService != nil {
-				if err := fsr.emailService.SendFlowNotification(
-					ctx,
-					flow.TimelockStandard,
-					flow.ChainID,
-					flow.ContractAddress,
-					flow.FlowID,
-					"waiting",
-					"ready",
-					nil, // 无交易hash，因为是定时任务触发的状态变更
-					*flow.InitiatorAddress,
-				); err != nil {
-					logger.Error("Failed to send ready email notification", err,
-						"flow_id", flow.FlowID, "standard", flow.TimelockStandard)
-					// 不影响其他流程的处理，继续执行
-				}
-			}
-
-			// 发送渠道通知
-			if fsr.notificationService != nil {
-				if err := fsr.notificationService.SendFlowNotification(
-					ctx,
-					flow.TimelockStandard,
-					flow.ChainID,
-					flow.ContractAddress,
-					flow.FlowID,
-					"waiting",
-					"ready",
-					nil, // 无交易hash，因为是定时任务触发的状态变更
-					*flow.InitiatorAddress,
-				); err != nil {
-					logger.Error("Failed to send ready channel notification", err,
-						"flow_id", flow.FlowID, "standard", flow.TimelockStandard)
-					// 不影响其他流程的处理，继续执行
-				}
-			}
+		if flow.InitiatorAddress == nil {
+			continue
+		}
+		if fsr.shouldSuppressNotification(ctx, &flow) {
+			logger.Debug("Skip notification for historical flow (waiting->ready)", "flow_id", flow.FlowID)
+			continue
 		}
+		// 无交易hash，因为是定时任务触发的状态变更
+		outbox = append(outbox, buildFlowOutbox(fsr.config, flow.TimelockStandard, flow.ChainID, flow.ContractAddress, flow.FlowID, "waiting", "ready", nil, *flow.InitiatorAddress)...)
+	}
+
+	// 批量更新状态，与通知发件箱在同一事务中写入
+	if err := fsr.flowRepo.BatchUpdateFlowStatus(ctx, flows, "ready", outbox); err != nil {
+		return err
 	}
 
 	logger.Info("Completed waiting->ready transitions", "updated", len(flows))
@@ -171,58 +131,24 @@ func (fsr *FlowStatusRefresher) processCompoundExpired(ctx context.Context, now
 
 	logger.Info("Processing compound expired transitions", "count", len(flows))
 
-	// 批量更新状态
-	if err := fsr.flowRepo.BatchUpdateFlowStatus(ctx, flows, "expired"); err != nil {
-		return err
-	}
-
-	// 发送通知（过滤导入前的历史交易）
+	// 构建通知发件箱（过滤导入前的历史交易）
+	var outbox []types.NotificationOutbox
 	for _, flow := range flows {
-		if flow.InitiatorAddress != nil {
-			if fsr.shouldSuppressNotification(ctx, &flow) {
-				logger.Debug("Skip notification for historical flow (expired)", "flow_id", flow.FlowID)
-				continue
-			}
-			statusFrom := flow.Status // 记录原状态用于通知
-
-			// 发送邮件通知
-			if fsr.emailService != nil {
-				if err := fsr.emailService.SendFlowNotification(
-					ctx,
-					flow.TimelockStandard,
-					flow.ChainID,
-					flow.ContractAddress,
-					flow.FlowID,
-					statusFrom,
-					"expired",
-					nil, // 无交易hash，因为是定时任务触发的状态变更
-					*flow.InitiatorAddress,
-				); err != nil {
-					logger.Error("Failed to send expired email notification", err,
-						"flow_id", flow.FlowID, "standard", flow.TimelockStandard)
-					// 不影响其他流程的处理，继续执行
-				}
-			}
-
-			// 发送渠道通知
-			if fsr.notificationService != nil {
-				if err := fsr.notificationService.SendFlowNotification(
-					ctx,
-					flow.TimelockStandard,
-					flow.ChainID,
-					flow.ContractAddress,
-					flow.FlowID,
-					statusFrom,
-					"expired",
-					nil, // 无交易hash，因为是定时任务触发的状态变更
-					*flow.InitiatorAddress,
-				); err != nil {
-					logger.Error("Failed to send expired channel notification", err,
-						"flow_id", flow.FlowID, "standard", flow.TimelockStandard)
-					// 不影响其他流程的处理，继续执行
-				}
-			}
+		if flow.InitiatorAddress == nil {
+			continue
+		}
+		if fsr.shouldSuppressNotification(ctx, &flow) {
+			logger.Debug("Skip notification for historical flow (expired)", "flow_id", flow.FlowID)
+			continue
 		}
+		statusFrom := flow.Status // 记录原状态用于通知
+		// 无交易hash，因为是定时任务触发的状态变更
+		outbox = append(outbox, buildFlowOutbox(fsr.config, flow.TimelockStandard, flow.ChainID, flow.ContractAddress, flow.FlowID, statusFrom, "expired", nil, *flow.InitiatorAddress)...)
+	}
+
+	// 批量更新状态，与通知发件箱在同一事务中写入
+	if err := fsr.flowRepo.BatchUpdateFlowStatus(ctx, flows, "expired", outbox); err != nil {
+		return err
 	}
 
 	logger.Info("Completed compound expired transitions", "updated", len(flows))
@@ -16,21 +16,20 @@ import (
 
 // Manager 扫链管理器
 type Manager struct {
-	config              *config.Config
-	chainRepo           chain.Repository
-	timelockRepo        timelock.Repository
-	progressRepo        scanner.ProgressRepository
-	txRepo              scanner.TransactionRepository
-	flowRepo            scanner.FlowRepository
-	rpcManager          *RPCManager
-	chainScanners       map[int]*ChainScanner
-	flowRefresher       *FlowStatusRefresher
-	emailService        EmailService
-	notificationService NotificationService
-	mutex               sync.RWMutex
-	stopCh              chan struct{}
-	wg                  sync.WaitGroup
-	isRunning           bool
+	config        *config.Config
+	chainRepo     chain.Repository
+	timelockRepo  timelock.Repository
+	progressRepo  scanner.ProgressRepository
+	txRepo        scanner.TransactionRepository
+	flowRepo      scanner.FlowRepository
+	rpcManager    *RPCManager
+	chainScanners map[int]*ChainScanner
+	flowRefresher *FlowStatusRefresher
+	mutex         sync.RWMutex
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+	isRunning     bool
+	runCtx        context.Context // Start时传入的上下文，运行期热启动的扫描器沿用它
 }
 
 // NewManager 创建扫链管理器
@@ -42,25 +41,21 @@ func NewManager(
 	txRepo scanner.TransactionRepository,
 	flowRepo scanner.FlowRepository,
 	rpcManager *RPCManager,
-	emailService EmailService,
-	notificationService NotificationService,
 ) *Manager {
 	// 创建流程状态刷新器
-	flowRefresher := NewFlowStatusRefresher(cfg, flowRepo, timelockRepo, emailService, notificationService)
+	flowRefresher := NewFlowStatusRefresher(cfg, flowRepo, timelockRepo)
 
 	return &Manager{
-		config:              cfg,
-		chainRepo:           chainRepo,
-		timelockRepo:        timelockRepo,
-		progressRepo:        progressRepo,
-		txRepo:              txRepo,
-		flowRepo:            flowRepo,
-		rpcManager:          rpcManager,
-		chainScanners:       make(map[int]*ChainScanner),
-		flowRefresher:       flowRefresher,
-		emailService:        emailService,
-		notificationService: notificationService,
-		stopCh:              make(chan struct{}),
+		config:        cfg,
+		chainRepo:     chainRepo,
+		timelockRepo:  timelockRepo,
+		progressRepo:  progressRepo,
+		txRepo:        txRepo,
+		flowRepo:      flowRepo,
+		rpcManager:    rpcManager,
+		chainScanners: make(map[int]*ChainScanner),
+		flowRefresher: flowRefresher,
+		stopCh:        make(chan struct{}),
 	}
 }
 
@@ -74,6 +69,7 @@ func (m *Manager) Start(ctx context.Context) error {
 	}
 
 	logger.Info("Starting Scanner Manager")
+	m.runCtx = ctx
 
 	// 获取启用RPC的链
 	chains, err := m.chainRepo.GetRPCEnabledChains(ctx, m.config.RPC.IncludeTestnets)
@@ -193,8 +189,6 @@ func (m *Manager) startChainScanner(ctx context.Context, chainInfo *types.ChainR
 		m.progressRepo,
 		m.txRepo,
 		m.flowRepo,
-		m.emailService,
-		m.notificationService,
 		m.timelockRepo,
 	)
 
@@ -332,6 +326,53 @@ func (m *Manager) RestartChainScanner(ctx context.Context, chainID int) error {
 	return m.startChainScanner(ctx, targetChain)
 }
 
+// StartChainScanner 运行期热启动指定链的扫描器（链需处于激活且启用RPC状态）
+func (m *Manager) StartChainScanner(ctx context.Context, chainID int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.isRunning {
+		return fmt.Errorf("scanner manager is not running")
+	}
+
+	if _, exists := m.chainScanners[chainID]; exists {
+		logger.Info("Chain scanner already running", "chain_id", chainID)
+		return nil
+	}
+
+	chains, err := m.chainRepo.GetRPCEnabledChains(ctx, m.config.RPC.IncludeTestnets)
+	if err != nil {
+		return fmt.Errorf("failed to get chain info: %w", err)
+	}
+
+	for _, chainInfo := range chains {
+		if chainInfo.ChainID == chainID {
+			// 扫描协程的生命周期跟随管理器，而不是发起请求的上下文
+			if err := m.startChainScanner(m.runCtx, &chainInfo); err != nil {
+				return err
+			}
+			logger.Info("Chain scanner started at runtime", "chain_id", chainID, "chain_name", chainInfo.ChainName)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("chain %d not found or not enabled", chainID)
+}
+
+// StopChainScanner 运行期停止指定链的扫描器并释放其RPC连接
+func (m *Manager) StopChainScanner(chainID int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if scanner, exists := m.chainScanners[chainID]; exists {
+		scanner.Stop()
+		delete(m.chainScanners, chainID)
+		logger.Info("Chain scanner stopped at runtime", "chain_id", chainID)
+	}
+
+	m.rpcManager.ResetClient(chainID)
+}
+
 // RescanFromBlock 从指定区块重新扫描
 func (m *Manager) RescanFromBlock(ctx context.Context, chainID int, fromBlock int64) error {
 	m.mutex.Lock()
@@ -11,8 +11,11 @@ import (
 	"timelocker-backend/internal/repository/chain"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
+	"timelocker-backend/pkg/tracing"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RPCManager RPC管理器，只使用Alchemy RPC
@@ -143,12 +146,13 @@ func (rm *RPCManager) createClient(ctx context.Context, chainID int, rpcURL stri
 	dialCtx, cancel := context.WithTimeout(ctx, rm.config.RPCTimeout)
 	defer cancel()
 
-	// 创建RPC客户端
-	client, err := ethclient.DialContext(dialCtx, rpcURL)
+	// 创建RPC客户端，HTTP请求按方法记录指标
+	rpcClient, err := rpc.DialOptions(dialCtx, rpcURL, rpc.WithHTTPClient(newRPCHTTPClient(chainID, rpcProviderAlchemy)))
 	if err != nil {
 		logger.Error("Failed to dial Alchemy RPC", err, "chain_id", chainID, "url", rpcURL)
 		return nil, fmt.Errorf("failed to dial RPC %s: %w", rpcURL, err)
 	}
+	client := ethclient.NewClient(rpcClient)
 
 	// 测试连接
 	_, err = client.ChainID(dialCtx)
@@ -168,15 +172,22 @@ func (rm *RPCManager) createClient(ctx context.Context, chainID int, rpcURL stri
 }
 
 // ExecuteWithRetry 带重试的RPC调用执行
-func (rm *RPCManager) ExecuteWithRetry(ctx context.Context, chainID int, fn func(*ethclient.Client) error) error {
+// 每次尝试创建一个span，fn收到的ctx携带该span，其中的RPC请求会记录在本次尝试之下
+func (rm *RPCManager) ExecuteWithRetry(ctx context.Context, chainID int, fn func(context.Context, *ethclient.Client) error) error {
 	var lastErr error
 	retryDelay := rm.config.RPCRetryDelay
 
 	for i := 0; i < rm.config.RPCRetryMax; i++ {
-		client, err := rm.GetOrCreateClient(ctx, chainID)
+		attemptCtx, span := tracing.Start(ctx, "rpc.attempt",
+			attribute.Int("chain_id", chainID),
+			attribute.Int("attempt", i+1),
+		)
+
+		client, err := rm.GetOrCreateClient(attemptCtx, chainID)
 		if err != nil {
 			lastErr = err
-			logger.Warn("Failed to get RPC client", "chain_id", chainID, "attempt", i+1, "error", err)
+			tracing.End(span, err)
+			logger.WarnCtx(attemptCtx, "Failed to get RPC client", "chain_id", chainID, "attempt", i+1, "error", err)
 
 			// 等待重试延迟
 			if i < rm.config.RPCRetryMax-1 {
@@ -191,9 +202,10 @@ func (rm *RPCManager) ExecuteWithRetry(ctx context.Context, chainID int, fn func
 		}
 
 		// 执行RPC调用
-		if err := fn(client); err != nil {
+		if err := fn(attemptCtx, client); err != nil {
 			lastErr = err
-			logger.Warn("RPC call failed", "chain_id", chainID, "attempt", i+1, "error", err)
+			tracing.End(span, err)
+			logger.WarnCtx(attemptCtx, "RPC call failed", "chain_id", chainID, "attempt", i+1, "error", err)
 
 			// 如果是连接错误，移除客户端以便下次重新创建
 			rm.removeClient(chainID)
@@ -211,10 +223,11 @@ func (rm *RPCManager) ExecuteWithRetry(ctx context.Context, chainID int, fn func
 		}
 
 		// 成功执行
+		span.End()
 		return nil
 	}
 
-	logger.Error("RPC call failed after all retries", lastErr, "chain_id", chainID, "max_retries", rm.config.RPCRetryMax)
+	logger.ErrorCtx(ctx, "RPC call failed after all retries", lastErr, "chain_id", chainID, "max_retries", rm.config.RPCRetryMax)
 	return fmt.Errorf("RPC call failed after %d retries: %w", rm.config.RPCRetryMax, lastErr)
 }
 
@@ -230,6 +243,11 @@ func (rm *RPCManager) removeClient(chainID int) {
 	}
 }
 
+// ResetClient 关闭并移除指定链的客户端，下次调用时按最新链配置重新创建
+func (rm *RPCManager) ResetClient(chainID int) {
+	rm.removeClient(chainID)
+}
+
 // GetStatus 获取RPC管理器状态
 func (rm *RPCManager) GetStatus() map[string]interface{} {
 	rm.mutex.RLock()
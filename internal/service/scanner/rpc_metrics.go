@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rpcProviderAlchemy 当前唯一的RPC提供方
+const rpcProviderAlchemy = "alchemy"
+
+// rpcMetricsTransport 按JSON-RPC方法记录请求数、耗时和失败，并为每个请求创建span，调用方无需逐个埋点
+// 网络错误和非2xx响应计为失败，JSON-RPC层的业务错误（例如合约调用revert）不计入
+// 请求头不注入traceparent，追踪上下文不会传给第三方RPC服务
+type rpcMetricsTransport struct {
+	chainID  int
+	provider string
+	next     http.RoundTripper
+}
+
+// newRPCHTTPClient 创建带指标记录的RPC HTTP客户端
+func newRPCHTTPClient(chainID int, provider string) *http.Client {
+	return &http.Client{
+		Transport: &rpcMetricsTransport{
+			chainID:  chainID,
+			provider: provider,
+			next:     http.DefaultTransport,
+		},
+	}
+}
+
+func (t *rpcMetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := "unknown"
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			method = rpcMethod(body)
+			body.Close()
+		}
+	}
+
+	_, span := tracing.StartKind(req.Context(), "rpc "+method, trace.SpanKindClient,
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.provider", t.provider),
+		attribute.Int("chain_id", t.chainID),
+	)
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		statusErr := fmt.Errorf("http status %d", resp.StatusCode)
+		metrics.ObserveRPC(t.chainID, t.provider, method, time.Since(start), statusErr)
+		tracing.RecordError(span, statusErr)
+		return resp, nil
+	}
+	metrics.ObserveRPC(t.chainID, t.provider, method, time.Since(start), err)
+	tracing.RecordError(span, err)
+	return resp, err
+}
+
+// rpcMethod 从请求体解析JSON-RPC方法名，批量请求返回batch
+func rpcMethod(body io.Reader) string {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "unknown"
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return "batch"
+	}
+	var msg struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Method == "" {
+		return "unknown"
+	}
+	return msg.Method
+}
@@ -13,8 +13,11 @@ import (
 
 	"timelocker-backend/internal/config"
 	"timelocker-backend/internal/repository/chain"
+	"timelocker-backend/internal/repository/organization"
 	scannerRepo "timelocker-backend/internal/repository/scanner"
 	"timelocker-backend/internal/repository/timelock"
+	"timelocker-backend/internal/repository/user"
+	"timelocker-backend/internal/service/audit"
 	"timelocker-backend/internal/service/scanner"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/crypto"
@@ -69,17 +72,23 @@ type service struct {
 	timeLockRepo timelock.Repository
 	chainRepo    chain.Repository
 	flowRepo     scannerRepo.FlowRepository
+	orgRepo      organization.Repository
+	userRepo     user.Repository
 	rpcManager   *scanner.RPCManager
+	auditor      audit.Recorder
 	config       *config.Config
 }
 
 // NewService 创建timelock服务实例
-func NewService(timeLockRepo timelock.Repository, chainRepo chain.Repository, flowRepo scannerRepo.FlowRepository, rpcManager *scanner.RPCManager, config *config.Config) Service {
+func NewService(timeLockRepo timelock.Repository, chainRepo chain.Repository, flowRepo scannerRepo.FlowRepository, orgRepo organization.Repository, userRepo user.Repository, rpcManager *scanner.RPCManager, auditor audit.Recorder, config *config.Config) Service {
 	return &service{
 		timeLockRepo: timeLockRepo,
 		chainRepo:    chainRepo,
 		flowRepo:     flowRepo,
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
 		rpcManager:   rpcManager,
+		auditor:      auditor,
 		config:       config,
 	}
 }
@@ -96,8 +105,16 @@ func (s *service) CreateOrImportTimeLock(ctx context.Context, userAddress string
 		return nil, err
 	}
 
-	// 检查合约是否已存在
-	if err := s.checkContractExists(ctx, req.Standard, req.ChainID, normalizedContract, userAddress); err != nil {
+	// 添加到组织监控列表需要编辑者角色
+	if req.OrganizationID > 0 {
+		if _, err := s.requireOrganizationRole(ctx, normalizedUser, req.OrganizationID, types.OrganizationRoleEditor); err != nil {
+			logger.Error("CreateOrImportTimeLock organization role error", err, "user_address", normalizedUser, "organization_id", req.OrganizationID)
+			return nil, err
+		}
+	}
+
+	// 检查合约是否已存在（个人合约按创建者、组织合约按组织判断）
+	if err := s.checkContractExists(ctx, req.Standard, req.ChainID, normalizedContract, userAddress, req.OrganizationID); err != nil {
 		logger.Error("CreateOrImportTimeLock check exists error", err, "user_address", normalizedUser)
 		return nil, err
 	}
@@ -110,15 +127,29 @@ func (s *service) CreateOrImportTimeLock(ctx context.Context, userAddress string
 	}
 
 	// 从链上读取合约数据并验证
+	var result interface{}
 	switch req.Standard {
 	case "compound":
-		return s.createOrImportCompoundTimeLock(ctx, normalizedUser, normalizedContract, req, chainInfo)
+		result, err = s.createOrImportCompoundTimeLock(ctx, normalizedUser, normalizedContract, req, chainInfo)
 	case "openzeppelin":
-		return s.createOrImportOpenzeppelinTimeLock(ctx, normalizedUser, normalizedContract, req, chainInfo)
+		result, err = s.createOrImportOpenzeppelinTimeLock(ctx, normalizedUser, normalizedContract, req, chainInfo)
 	default:
 		logger.Error("Invalid standard", fmt.Errorf("invalid standard: %s", req.Standard))
 		return nil, ErrInvalidStandard
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, types.AuditActionTimelockImport, types.AuditResourceTimelock,
+		timelockResourceID(req.Standard, req.ChainID, normalizedContract), req.OrganizationID, nil, map[string]interface{}{
+			"standard":         req.Standard,
+			"chain_id":         req.ChainID,
+			"contract_address": normalizedContract,
+			"remark":           html.EscapeString(strings.TrimSpace(req.Remark)),
+			"is_imported":      req.IsImported,
+		})
+	return result, nil
 }
 
 // GetTimeLockList 获取timelock列表（根据用户权限筛选）
@@ -128,13 +159,47 @@ func (s *service) GetTimeLockList(ctx context.Context, userAddress string, req *
 	// 标准化地址
 	normalizedUser := crypto.NormalizeAddress(userAddress)
 
-	// 查询所有有权限的timelock
-	compoundList, openzeppelinList, total, err := s.timeLockRepo.GetTimeLocksByUserPermissions(ctx, normalizedUser, req)
+	// 账户下的全部钱包，任一钱包有权限的timelock和所在组织均计入
+	wallets, err := s.userRepo.GetAccountWallets(ctx, normalizedUser)
+	if err != nil {
+		logger.Error("GetTimeLockList get account wallets error", err, "user_address", normalizedUser)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+
+	// 用户所在组织及角色，组织合约对成员可见
+	orgRoles, err := s.orgRepo.GetMemberRolesByWallets(ctx, wallets)
+	if err != nil {
+		logger.Error("GetTimeLockList get organization roles error", err, "user_address", normalizedUser)
+		return nil, fmt.Errorf("failed to get organization roles: %w", err)
+	}
+	if req.OrganizationID > 0 && orgRoles[req.OrganizationID] == "" {
+		logger.Error("GetTimeLockList unauthorized", ErrUnauthorized, "user_address", normalizedUser, "organization_id", req.OrganizationID)
+		return nil, ErrUnauthorized
+	}
+	orgIDs := make([]int64, 0, len(orgRoles))
+	for orgID := range orgRoles {
+		orgIDs = append(orgIDs, orgID)
+	}
+
+	// 查询账户下任一钱包有权限的timelock
+	compoundList, openzeppelinList, total, err := s.timeLockRepo.GetTimeLocksByUserPermissions(ctx, wallets, orgIDs, req)
 	if err != nil {
 		logger.Error("GetTimeLockList error", err, "user_address", normalizedUser)
 		return nil, fmt.Errorf("failed to get timelock list: %w", err)
 	}
 
+	// 组织合约附加当前用户的组织角色
+	for i := range compoundList {
+		if role := orgRoles[compoundList[i].OrganizationID]; role != "" {
+			compoundList[i].UserPermissions = append(compoundList[i].UserPermissions, types.OrgPermissionPrefix+role)
+		}
+	}
+	for i := range openzeppelinList {
+		if role := orgRoles[openzeppelinList[i].OrganizationID]; role != "" {
+			openzeppelinList[i].UserPermissions = append(openzeppelinList[i].UserPermissions, types.OrgPermissionPrefix+role)
+		}
+	}
+
 	response := &types.GetTimeLockListResponse{
 		CompoundTimeLocks:     compoundList,
 		OpenzeppelinTimeLocks: openzeppelinList,
@@ -155,9 +220,9 @@ func (s *service) GetTimeLockDetail(ctx context.Context, userAddress string, req
 
 	switch req.Standard {
 	case "compound":
-		return s.getCompoundTimeLockDetail(ctx, normalizedUser, req.ChainID, normalizedContract)
+		return s.getCompoundTimeLockDetail(ctx, normalizedUser, req.ChainID, normalizedContract, req.OrganizationID)
 	case "openzeppelin":
-		return s.getOpenzeppelinTimeLockDetail(ctx, normalizedUser, req.ChainID, normalizedContract)
+		return s.getOpenzeppelinTimeLockDetail(ctx, normalizedUser, req.ChainID, normalizedContract, req.OrganizationID)
 	default:
 		logger.Error("Invalid standard", fmt.Errorf("invalid standard: %s", req.Standard))
 		return nil, ErrInvalidStandard
@@ -180,10 +245,20 @@ func (s *service) UpdateTimeLock(ctx context.Context, userAddress string, req *t
 
 	sanitizedRemark := html.EscapeString(strings.TrimSpace(req.Remark))
 
+	// 组织合约需要编辑者角色
+	if req.OrganizationID > 0 {
+		if _, err := s.requireOrganizationRole(ctx, normalizedUser, req.OrganizationID, types.OrganizationRoleEditor); err != nil {
+			logger.Error("UpdateTimeLock organization role error", err, "user_address", normalizedUser, "organization_id", req.OrganizationID)
+			return err
+		}
+	}
+
+	oldRemark := s.getOwnedRemark(ctx, req.Standard, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
+
 	switch req.Standard {
 	case "compound":
-		// 验证所有权（创建者或导入者）
-		isOwner, err := s.timeLockRepo.ValidateCompoundOwnership(ctx, req.ChainID, normalizedContract, normalizedUser)
+		// 验证所有权（个人合约的创建者或导入者，组织合约属于该组织）
+		isOwner, err := s.timeLockRepo.ValidateCompoundOwnership(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
 		if err != nil {
 			logger.Error("UpdateTimeLock validate ownership error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to validate ownership: %w", err)
@@ -193,14 +268,14 @@ func (s *service) UpdateTimeLock(ctx context.Context, userAddress string, req *t
 			return ErrUnauthorized
 		}
 
-		if err := s.timeLockRepo.UpdateCompoundTimeLockRemark(ctx, req.ChainID, normalizedContract, normalizedUser, sanitizedRemark); err != nil {
+		if err := s.timeLockRepo.UpdateCompoundTimeLockRemark(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID, sanitizedRemark); err != nil {
 			logger.Error("UpdateTimeLock repository error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to update timelock: %w", err)
 		}
 
 	case "openzeppelin":
-		// 验证所有权（创建者或导入者）
-		isOwner, err := s.timeLockRepo.ValidateOpenzeppelinOwnership(ctx, req.ChainID, normalizedContract, normalizedUser)
+		// 验证所有权（个人合约的创建者或导入者，组织合约属于该组织）
+		isOwner, err := s.timeLockRepo.ValidateOpenzeppelinOwnership(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
 		if err != nil {
 			logger.Error("UpdateTimeLock validate ownership error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to validate ownership: %w", err)
@@ -210,7 +285,7 @@ func (s *service) UpdateTimeLock(ctx context.Context, userAddress string, req *t
 			return ErrUnauthorized
 		}
 
-		if err := s.timeLockRepo.UpdateOpenzeppelinTimeLockRemark(ctx, req.ChainID, normalizedContract, normalizedUser, sanitizedRemark); err != nil {
+		if err := s.timeLockRepo.UpdateOpenzeppelinTimeLockRemark(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID, sanitizedRemark); err != nil {
 			logger.Error("UpdateTimeLock repository error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to update timelock: %w", err)
 		}
@@ -219,6 +294,10 @@ func (s *service) UpdateTimeLock(ctx context.Context, userAddress string, req *t
 		return ErrInvalidStandard
 	}
 
+	s.auditor.Record(ctx, types.AuditActionTimelockUpdateRemark, types.AuditResourceTimelock,
+		timelockResourceID(req.Standard, req.ChainID, normalizedContract), req.OrganizationID,
+		map[string]interface{}{"remark": oldRemark}, map[string]interface{}{"remark": sanitizedRemark})
+
 	logger.Info("UpdateTimeLock success", "user_address", normalizedUser)
 	return nil
 }
@@ -231,10 +310,20 @@ func (s *service) DeleteTimeLock(ctx context.Context, userAddress string, req *t
 	normalizedUser := crypto.NormalizeAddress(userAddress)
 	normalizedContract := crypto.NormalizeAddress(req.ContractAddress)
 
+	// 组织合约需要编辑者角色
+	if req.OrganizationID > 0 {
+		if _, err := s.requireOrganizationRole(ctx, normalizedUser, req.OrganizationID, types.OrganizationRoleEditor); err != nil {
+			logger.Error("DeleteTimeLock organization role error", err, "user_address", normalizedUser, "organization_id", req.OrganizationID)
+			return err
+		}
+	}
+
+	oldRemark := s.getOwnedRemark(ctx, req.Standard, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
+
 	switch req.Standard {
 	case "compound":
-		// 验证所有权（创建者或导入者）
-		isOwner, err := s.timeLockRepo.ValidateCompoundOwnership(ctx, req.ChainID, normalizedContract, normalizedUser)
+		// 验证所有权（个人合约的创建者或导入者，组织合约属于该组织）
+		isOwner, err := s.timeLockRepo.ValidateCompoundOwnership(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
 		if err != nil {
 			logger.Error("DeleteTimeLock validate ownership error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to validate ownership: %w", err)
@@ -244,14 +333,14 @@ func (s *service) DeleteTimeLock(ctx context.Context, userAddress string, req *t
 			return ErrUnauthorized
 		}
 
-		if err := s.timeLockRepo.DeleteCompoundTimeLock(ctx, req.ChainID, normalizedContract, normalizedUser); err != nil {
+		if err := s.timeLockRepo.DeleteCompoundTimeLock(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID); err != nil {
 			logger.Error("DeleteTimeLock repository error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to delete timelock: %w", err)
 		}
 
 	case "openzeppelin":
-		// 验证所有权（创建者或导入者）
-		isOwner, err := s.timeLockRepo.ValidateOpenzeppelinOwnership(ctx, req.ChainID, normalizedContract, normalizedUser)
+		// 验证所有权（个人合约的创建者或导入者，组织合约属于该组织）
+		isOwner, err := s.timeLockRepo.ValidateOpenzeppelinOwnership(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID)
 		if err != nil {
 			logger.Error("DeleteTimeLock validate ownership error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to validate ownership: %w", err)
@@ -261,7 +350,7 @@ func (s *service) DeleteTimeLock(ctx context.Context, userAddress string, req *t
 			return ErrUnauthorized
 		}
 
-		if err := s.timeLockRepo.DeleteOpenzeppelinTimeLock(ctx, req.ChainID, normalizedContract, normalizedUser); err != nil {
+		if err := s.timeLockRepo.DeleteOpenzeppelinTimeLock(ctx, req.ChainID, normalizedContract, normalizedUser, req.OrganizationID); err != nil {
 			logger.Error("DeleteTimeLock repository error", err, "user_address", normalizedUser)
 			return fmt.Errorf("failed to delete timelock: %w", err)
 		}
@@ -270,6 +359,15 @@ func (s *service) DeleteTimeLock(ctx context.Context, userAddress string, req *t
 		return ErrInvalidStandard
 	}
 
+	s.auditor.Record(ctx, types.AuditActionTimelockDelete, types.AuditResourceTimelock,
+		timelockResourceID(req.Standard, req.ChainID, normalizedContract), req.OrganizationID,
+		map[string]interface{}{
+			"standard":         req.Standard,
+			"chain_id":         req.ChainID,
+			"contract_address": normalizedContract,
+			"remark":           oldRemark,
+		}, nil)
+
 	logger.Info("DeleteTimeLock success", "user_address", normalizedUser)
 	return nil
 }
@@ -371,6 +469,7 @@ func (s *service) createOrImportCompoundTimeLock(ctx context.Context, userAddres
 		GracePeriod:     contractData.GracePeriod,
 		MinimumDelay:    contractData.MinimumDelay,
 		MaximumDelay:    contractData.MaximumDelay,
+		OrganizationID:  req.OrganizationID,
 		Remark:          html.EscapeString(strings.TrimSpace(req.Remark)),
 		Status:          "active",
 		IsImported:      req.IsImported,
@@ -425,6 +524,7 @@ func (s *service) createOrImportOpenzeppelinTimeLock(ctx context.Context, userAd
 		Admin:           adminAddr,
 		Proposers:       string(proposersJSON),
 		Executors:       string(executorsJSON),
+		OrganizationID:  req.OrganizationID,
 		Remark:          html.EscapeString(strings.TrimSpace(req.Remark)),
 		Status:          "active",
 		IsImported:      req.IsImported,
@@ -440,8 +540,20 @@ func (s *service) createOrImportOpenzeppelinTimeLock(ctx context.Context, userAd
 }
 
 // 私有方法 - 获取Compound timelock详情
-func (s *service) getCompoundTimeLockDetail(ctx context.Context, userAddress string, chainID int, contractAddress string) (*types.GetTimeLockDetailResponse, error) {
-	timeLock, err := s.timeLockRepo.GetCompoundTimeLockByChainAndAddress(ctx, chainID, contractAddress)
+func (s *service) getCompoundTimeLockDetail(ctx context.Context, userAddress string, chainID int, contractAddress string, organizationID int64) (*types.GetTimeLockDetailResponse, error) {
+	var timeLock *types.CompoundTimeLock
+	var orgRole string
+	var err error
+	if organizationID > 0 {
+		// 组织合约对所有成员可见
+		orgRole, err = s.requireOrganizationRole(ctx, userAddress, organizationID, types.OrganizationRoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		timeLock, err = s.timeLockRepo.GetCompoundTimeLockByOrganization(ctx, organizationID, chainID, contractAddress)
+	} else {
+		timeLock, err = s.timeLockRepo.GetCompoundTimeLockByChainAndAddress(ctx, chainID, contractAddress)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrTimeLockNotFound
@@ -450,15 +562,23 @@ func (s *service) getCompoundTimeLockDetail(ctx context.Context, userAddress str
 		return nil, fmt.Errorf("failed to get timelock: %w", err)
 	}
 
-	// 检查用户是否有权限查看
-	hasPermission := s.checkCompoundPermission(timeLock, userAddress)
+	// 检查用户是否有权限查看（账户下任一钱包有权限即可）
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		logger.Error("Failed to get account wallets", err, "user_address", userAddress)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	hasPermission := orgRole != "" || s.checkCompoundPermission(timeLock, wallets)
 	if !hasPermission {
 		logger.Error("User has no permission to view timelock", ErrUnauthorized)
 		return nil, ErrUnauthorized
 	}
 
 	// 构建权限信息
-	permissions := s.buildCompoundPermissions(timeLock, userAddress)
+	permissions := s.buildCompoundPermissions(timeLock, wallets)
+	if orgRole != "" {
+		permissions = append(permissions, types.OrgPermissionPrefix+orgRole)
+	}
 
 	compoundData := &types.CompoundTimeLockWithPermission{
 		CompoundTimeLock: *timeLock,
@@ -472,8 +592,20 @@ func (s *service) getCompoundTimeLockDetail(ctx context.Context, userAddress str
 }
 
 // 私有方法 - 获取OpenZeppelin timelock详情
-func (s *service) getOpenzeppelinTimeLockDetail(ctx context.Context, userAddress string, chainID int, contractAddress string) (*types.GetTimeLockDetailResponse, error) {
-	timeLock, err := s.timeLockRepo.GetOpenzeppelinTimeLockByChainAndAddress(ctx, chainID, contractAddress)
+func (s *service) getOpenzeppelinTimeLockDetail(ctx context.Context, userAddress string, chainID int, contractAddress string, organizationID int64) (*types.GetTimeLockDetailResponse, error) {
+	var timeLock *types.OpenzeppelinTimeLock
+	var orgRole string
+	var err error
+	if organizationID > 0 {
+		// 组织合约对所有成员可见
+		orgRole, err = s.requireOrganizationRole(ctx, userAddress, organizationID, types.OrganizationRoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		timeLock, err = s.timeLockRepo.GetOpenzeppelinTimeLockByOrganization(ctx, organizationID, chainID, contractAddress)
+	} else {
+		timeLock, err = s.timeLockRepo.GetOpenzeppelinTimeLockByChainAndAddress(ctx, chainID, contractAddress)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrTimeLockNotFound
@@ -482,15 +614,23 @@ func (s *service) getOpenzeppelinTimeLockDetail(ctx context.Context, userAddress
 		return nil, fmt.Errorf("failed to get timelock: %w", err)
 	}
 
-	// 检查用户是否有权限查看
-	hasPermission := s.checkOpenzeppelinPermission(timeLock, userAddress)
+	// 检查用户是否有权限查看（账户下任一钱包有权限即可）
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		logger.Error("Failed to get account wallets", err, "user_address", userAddress)
+		return nil, fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	hasPermission := orgRole != "" || s.checkOpenzeppelinPermission(timeLock, wallets)
 	if !hasPermission {
 		logger.Error("User has no permission to view timelock", ErrUnauthorized)
 		return nil, ErrUnauthorized
 	}
 
 	// 构建权限信息
-	permissions := s.buildOpenzeppelinPermissions(timeLock, userAddress)
+	permissions := s.buildOpenzeppelinPermissions(timeLock, wallets)
+	if orgRole != "" {
+		permissions = append(permissions, types.OrgPermissionPrefix+orgRole)
+	}
 
 	openzeppelinData := &types.OpenzeppelinTimeLockWithPermission{
 		OpenzeppelinTimeLock: *timeLock,
@@ -794,45 +934,41 @@ func (s *service) refreshOpenzeppelinTimeLockData(ctx context.Context, timeLock
 	return s.timeLockRepo.UpdateOpenzeppelinTimeLock(ctx, timeLock)
 }
 
-// 私有方法 - 检查Compound权限
-func (s *service) checkCompoundPermission(timeLock *types.CompoundTimeLock, userAddress string) bool {
-	return timeLock.CreatorAddress == userAddress ||
-		timeLock.Admin == userAddress ||
-		(timeLock.PendingAdmin != nil && *timeLock.PendingAdmin == userAddress)
+// 私有方法 - 检查Compound权限，userAddresses为账户下的全部钱包
+func (s *service) checkCompoundPermission(timeLock *types.CompoundTimeLock, userAddresses []string) bool {
+	return len(s.buildCompoundPermissions(timeLock, userAddresses)) > 0
 }
 
-// 私有方法 - 构建Compound权限列表
-func (s *service) buildCompoundPermissions(timeLock *types.CompoundTimeLock, userAddress string) []string {
+// 私有方法 - 构建Compound权限列表，任一钱包满足即拥有该权限
+func (s *service) buildCompoundPermissions(timeLock *types.CompoundTimeLock, userAddresses []string) []string {
 	var permissions []string
-	if timeLock.CreatorAddress == userAddress {
+	if s.equalsAnyAddress(timeLock.CreatorAddress, userAddresses) {
 		permissions = append(permissions, "creator")
 	}
-	if timeLock.Admin == userAddress {
+	if s.equalsAnyAddress(timeLock.Admin, userAddresses) {
 		permissions = append(permissions, "admin")
 	}
-	if timeLock.PendingAdmin != nil && *timeLock.PendingAdmin == userAddress {
+	if timeLock.PendingAdmin != nil && s.equalsAnyAddress(*timeLock.PendingAdmin, userAddresses) {
 		permissions = append(permissions, "pending_admin")
 	}
 	return permissions
 }
 
-// 私有方法 - 检查OpenZeppelin权限
-func (s *service) checkOpenzeppelinPermission(timeLock *types.OpenzeppelinTimeLock, userAddress string) bool {
-	return timeLock.CreatorAddress == userAddress ||
-		s.containsAddress(timeLock.Proposers, userAddress) ||
-		s.containsAddress(timeLock.Executors, userAddress)
+// 私有方法 - 检查OpenZeppelin权限，userAddresses为账户下的全部钱包
+func (s *service) checkOpenzeppelinPermission(timeLock *types.OpenzeppelinTimeLock, userAddresses []string) bool {
+	return len(s.buildOpenzeppelinPermissions(timeLock, userAddresses)) > 0
 }
 
-// 私有方法 - 构建OpenZeppelin权限列表
-func (s *service) buildOpenzeppelinPermissions(timeLock *types.OpenzeppelinTimeLock, userAddress string) []string {
+// 私有方法 - 构建OpenZeppelin权限列表，任一钱包满足即拥有该权限
+func (s *service) buildOpenzeppelinPermissions(timeLock *types.OpenzeppelinTimeLock, userAddresses []string) []string {
 	var permissions []string
-	if timeLock.CreatorAddress == userAddress {
+	if s.equalsAnyAddress(timeLock.CreatorAddress, userAddresses) {
 		permissions = append(permissions, "creator")
 	}
-	if s.containsAddress(timeLock.Proposers, userAddress) {
+	if s.containsAnyAddress(timeLock.Proposers, userAddresses) {
 		permissions = append(permissions, "proposer")
 	}
-	if s.containsAddress(timeLock.Executors, userAddress) {
+	if s.containsAnyAddress(timeLock.Executors, userAddresses) {
 		permissions = append(permissions, "executor")
 	}
 	return permissions
@@ -859,10 +995,10 @@ func (s *service) validateCreateOrImportRequest(req *types.CreateOrImportTimeloc
 }
 
 // checkContractExists 检查合约是否存在
-func (s *service) checkContractExists(ctx context.Context, standard string, chainID int, contractAddress string, userAddress string) error {
+func (s *service) checkContractExists(ctx context.Context, standard string, chainID int, contractAddress string, userAddress string, organizationID int64) error {
 	switch standard {
 	case "compound":
-		exists, err := s.timeLockRepo.CheckCompoundTimeLockExists(ctx, chainID, contractAddress, userAddress)
+		exists, err := s.timeLockRepo.CheckCompoundTimeLockExists(ctx, chainID, contractAddress, userAddress, organizationID)
 		if err != nil {
 			return fmt.Errorf("failed to check compound timelock existence: %w", err)
 		}
@@ -870,7 +1006,7 @@ func (s *service) checkContractExists(ctx context.Context, standard string, chai
 			return ErrTimeLockExists
 		}
 	case "openzeppelin":
-		exists, err := s.timeLockRepo.CheckOpenzeppelinTimeLockExists(ctx, chainID, contractAddress, userAddress)
+		exists, err := s.timeLockRepo.CheckOpenzeppelinTimeLockExists(ctx, chainID, contractAddress, userAddress, organizationID)
 		if err != nil {
 			return fmt.Errorf("failed to check openzeppelin timelock existence: %w", err)
 		}
@@ -881,6 +1017,39 @@ func (s *service) checkContractExists(ctx context.Context, standard string, chai
 	return nil
 }
 
+// requireOrganizationRole 校验用户账户在组织中至少拥有required角色，非成员返回ErrUnauthorized，角色不足返回ErrInvalidPermissions
+func (s *service) requireOrganizationRole(ctx context.Context, userAddress string, organizationID int64, required string) (string, error) {
+	wallets, err := s.userRepo.GetAccountWallets(ctx, userAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to get account wallets: %w", err)
+	}
+	role, err := s.orgRepo.GetMemberRoleByWallets(ctx, organizationID, wallets)
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if role == "" {
+		return "", ErrUnauthorized
+	}
+	if !types.HasOrganizationRole(role, required) {
+		return "", ErrInvalidPermissions
+	}
+	return role, nil
+}
+
+// getOwnedRemark 获取合约当前备注作为审计快照，查询失败时返回空字符串
+func (s *service) getOwnedRemark(ctx context.Context, standard string, chainID int, contractAddress, userAddress string, organizationID int64) string {
+	remark, err := s.timeLockRepo.GetOwnedTimeLockRemark(ctx, standard, chainID, contractAddress, userAddress, organizationID)
+	if err != nil {
+		return ""
+	}
+	return remark
+}
+
+// timelockResourceID 审计事件中timelock合约的资源标识
+func timelockResourceID(standard string, chainID int, contractAddress string) string {
+	return fmt.Sprintf("%s:%d:%s", standard, chainID, contractAddress)
+}
+
 // validateRemark 验证备注
 func (s *service) validateRemark(remark string) error {
 	if len(remark) > 500 {
@@ -908,15 +1077,25 @@ func (s *service) validateRemark(remark string) error {
 	return nil
 }
 
-// containsAddress 检查地址是否在列表中
-func (s *service) containsAddress(jsonAddresses, address string) bool {
-	var addresses []string
-	if err := json.Unmarshal([]byte(jsonAddresses), &addresses); err != nil {
+// equalsAnyAddress 检查地址是否与任一地址相同（忽略大小写）
+func (s *service) equalsAnyAddress(address string, addresses []string) bool {
+	for _, candidate := range addresses {
+		if strings.EqualFold(address, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyAddress 检查列表中是否包含任一地址
+func (s *service) containsAnyAddress(jsonAddresses string, addresses []string) bool {
+	var list []string
+	if err := json.Unmarshal([]byte(jsonAddresses), &list); err != nil {
 		return false
 	}
 
-	for _, addr := range addresses {
-		if strings.EqualFold(addr, address) {
+	for _, addr := range list {
+		if s.equalsAnyAddress(addr, addresses) {
 			return true
 		}
 	}
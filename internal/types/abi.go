@@ -6,14 +6,15 @@ import (
 
 // ABI ABI库模型
 type ABI struct {
-	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string    `json:"name" gorm:"size:200;not null"`           // ABI名称
-	ABIContent  string    `json:"abi_content" gorm:"type:text;not null"`   // ABI JSON内容
-	Owner       string    `json:"owner" gorm:"size:42;not null;index"`     // 所有者地址，全0表示共享ABI
-	Description string    `json:"description" gorm:"size:500;default:''"`  // ABI描述
-	IsShared    bool      `json:"is_shared" gorm:"not null;default:false"` // 是否为共享ABI
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID             int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name           string    `json:"name" gorm:"size:200;not null"`                   // ABI名称
+	ABIContent     string    `json:"abi_content" gorm:"type:text;not null"`           // ABI JSON内容
+	Owner          string    `json:"owner" gorm:"size:42;not null;index"`             // 所有者地址，全0表示共享ABI
+	Description    string    `json:"description" gorm:"size:500;default:''"`          // ABI描述
+	IsShared       bool      `json:"is_shared" gorm:"not null;default:false"`         // 是否为共享ABI
+	OrganizationID int64     `json:"organization_id" gorm:"not null;default:0;index"` // 所属组织ID，0表示个人ABI
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName 设置表名
@@ -23,9 +24,10 @@ func (ABI) TableName() string {
 
 // CreateABIRequest 创建ABI请求
 type CreateABIRequest struct {
-	Name        string `json:"name" binding:"required,min=1,max=200"`
-	ABIContent  string `json:"abi_content" binding:"required"`
-	Description string `json:"description" binding:"max=500"`
+	Name           string `json:"name" binding:"required,min=1,max=200"`
+	ABIContent     string `json:"abi_content" binding:"required"`
+	Description    string `json:"description" binding:"max=500"`
+	OrganizationID int64  `json:"organization_id"` // 创建到组织的ABI库，0表示个人
 }
 
 // UpdateABIRequest 更新ABI请求
@@ -37,19 +39,20 @@ type UpdateABIRequest struct {
 
 // ABIListResponse ABI列表响应
 type ABIListResponse struct {
-	ABIs []ABI `json:"abis"` // 用户创建的ABI及平台共享的ABI
+	ABIs []ABI `json:"abis"` // 用户创建的ABI、所在组织的ABI及平台共享的ABI
 }
 
 // ABIResponse ABI详情响应
 type ABIResponse struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	ABIContent  string    `json:"abi_content"`
-	Owner       string    `json:"owner"`
-	Description string    `json:"description"`
-	IsShared    bool      `json:"is_shared"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	ABIContent     string    `json:"abi_content"`
+	Owner          string    `json:"owner"`
+	Description    string    `json:"description"`
+	IsShared       bool      `json:"is_shared"`
+	OrganizationID int64     `json:"organization_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 // ABIValidationResult ABI验证结果
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"time"
+)
+
+// 管理员操作的资源类型
+const (
+	AdminResourceChain     = "chain"      // 支持链
+	AdminResourceSponsor   = "sponsor"    // 赞助方/生态伙伴
+	AdminResourceSharedABI = "shared_abi" // 平台共享ABI
+)
+
+// 管理员操作类型
+const (
+	AdminActionChainCreate     = "chain.create"
+	AdminActionChainUpdate     = "chain.update"
+	AdminActionChainEnable     = "chain.enable"
+	AdminActionChainDisable    = "chain.disable"
+	AdminActionChainDelete     = "chain.delete"
+	AdminActionSponsorCreate   = "sponsor.create"
+	AdminActionSponsorUpdate   = "sponsor.update"
+	AdminActionSponsorDelete   = "sponsor.delete"
+	AdminActionSponsorReorder  = "sponsor.reorder"
+	AdminActionSharedABICreate = "shared_abi.create"
+	AdminActionSharedABIUpdate = "shared_abi.update"
+	AdminActionSharedABIDelete = "shared_abi.delete"
+)
+
+// AdminAuditLog 管理员操作审计日志
+type AdminAuditLog struct {
+	ID           int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	AdminAddress string    `json:"admin_address" gorm:"size:42;not null;index"` // 执行操作的管理员钱包地址
+	Action       string    `json:"action" gorm:"size:50;not null"`              // 操作类型，如 chain.create
+	ResourceType string    `json:"resource_type" gorm:"size:50;not null"`       // 资源类型
+	ResourceID   int64     `json:"resource_id" gorm:"not null;default:0"`       // 资源ID，批量操作为0
+	Details      string    `json:"details" gorm:"type:jsonb"`                   // 操作详情（请求内容/变更字段）
+	IPAddress    string    `json:"ip_address" gorm:"size:64"`
+	UserAgent    string    `json:"user_agent" gorm:"type:text"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
+
+// AdminAuditMeta 审计日志中记录的请求来源信息
+type AdminAuditMeta struct {
+	AdminAddress string
+	IPAddress    string
+	UserAgent    string
+}
+
+// CreateChainRequest 管理员创建支持链请求
+type CreateChainRequest struct {
+	ChainName              string   `json:"chain_name" binding:"required,max=50"`
+	DisplayName            string   `json:"display_name" binding:"required,max=100"`
+	ChainID                int64    `json:"chain_id" binding:"required,min=1"`
+	NativeCurrencyName     string   `json:"native_currency_name" binding:"required,max=50"`
+	NativeCurrencySymbol   string   `json:"native_currency_symbol" binding:"required,max=10"`
+	NativeCurrencyDecimals int      `json:"native_currency_decimals" binding:"omitempty,min=0,max=36"` // 默认18
+	LogoURL                string   `json:"logo_url" binding:"omitempty,url"`
+	IsTestnet              bool     `json:"is_testnet"`
+	IsActive               bool     `json:"is_active"`
+	AlchemyRPCTemplate     string   `json:"alchemy_rpc_template"` // 包含 {API_KEY} 占位符
+	InfuraRPCTemplate      string   `json:"infura_rpc_template"`  // 包含 {API_KEY} 占位符
+	OfficialRPCUrls        []string `json:"official_rpc_urls" binding:"omitempty,dive,url"`
+	BlockExplorerUrls      []string `json:"block_explorer_urls" binding:"omitempty,dive,url"`
+	RPCEnabled             bool     `json:"rpc_enabled"`
+}
+
+// AdminChainListResponse 管理员支持链列表响应，返回数据库中的原始配置
+type AdminChainListResponse struct {
+	Chains []SupportChain `json:"chains"`
+	Total  int64          `json:"total"`
+}
+
+// AdminChainResponse 管理员修改支持链后的响应，附带该链扫描器的运行状态
+type AdminChainResponse struct {
+	Chain          SupportChain `json:"chain"`
+	ScannerRunning bool         `json:"scanner_running"`         // 该链扫描器当前是否在运行
+	ScannerError   string       `json:"scanner_error,omitempty"` // 扫描器启动失败原因，配置已保存
+}
+
+// UpdateChainRequest 管理员更新支持链请求，未传字段保持不变
+type UpdateChainRequest struct {
+	ID                     int64     `json:"id" binding:"required"`
+	DisplayName            *string   `json:"display_name,omitempty" binding:"omitempty,max=100"`
+	NativeCurrencyName     *string   `json:"native_currency_name,omitempty" binding:"omitempty,max=50"`
+	NativeCurrencySymbol   *string   `json:"native_currency_symbol,omitempty" binding:"omitempty,max=10"`
+	NativeCurrencyDecimals *int      `json:"native_currency_decimals,omitempty" binding:"omitempty,min=0,max=36"`
+	LogoURL                *string   `json:"logo_url,omitempty" binding:"omitempty,url"`
+	IsTestnet              *bool     `json:"is_testnet,omitempty"`
+	AlchemyRPCTemplate     *string   `json:"alchemy_rpc_template,omitempty"`
+	InfuraRPCTemplate      *string   `json:"infura_rpc_template,omitempty"`
+	OfficialRPCUrls        *[]string `json:"official_rpc_urls,omitempty" binding:"omitempty,dive,url"`
+	BlockExplorerUrls      *[]string `json:"block_explorer_urls,omitempty" binding:"omitempty,dive,url"`
+	RPCEnabled             *bool     `json:"rpc_enabled,omitempty"`
+}
+
+// SetChainStatusRequest 启用/停用支持链请求，扫描器随之热启动/停止
+type SetChainStatusRequest struct {
+	ID       int64 `json:"id" binding:"required"`
+	IsActive bool  `json:"is_active"`
+}
+
+// DeleteChainRequest 删除支持链请求
+type DeleteChainRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// UpdateSponsorWithIDRequest 管理员更新赞助方请求
+type UpdateSponsorWithIDRequest struct {
+	ID int64 `json:"id" binding:"required"`
+	UpdateSponsorRequest
+}
+
+// DeleteSponsorRequest 删除赞助方请求
+type DeleteSponsorRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// ReorderSponsorsRequest 批量调整赞助方排序请求
+type ReorderSponsorsRequest struct {
+	Items []SponsorSortItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// SponsorSortItem 单个赞助方的排序权重
+type SponsorSortItem struct {
+	ID        int64 `json:"id" binding:"required"`
+	SortOrder int   `json:"sort_order"`
+}
+
+// CreateSharedABIRequest 创建平台共享ABI请求
+type CreateSharedABIRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=200"`
+	ABIContent  string `json:"abi_content" binding:"required"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// GetAdminAuditLogsRequest 查询管理员审计日志请求
+type GetAdminAuditLogsRequest struct {
+	AdminAddress string `json:"admin_address"`
+	ResourceType string `json:"resource_type" binding:"omitempty,oneof=chain sponsor shared_abi"`
+	Page         int    `json:"page" binding:"omitempty,min=1"`
+	PageSize     int    `json:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// GetAdminAuditLogsResponse 查询管理员审计日志响应
+type GetAdminAuditLogsResponse struct {
+	Logs     []AdminAuditLog `json:"logs"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
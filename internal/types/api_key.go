@@ -0,0 +1,118 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// API Key 权限范围
+const (
+	APIKeyScopeFlowsRead           = "flows:read"           // 查询流程
+	APIKeyScopeTimelocksRead       = "timelocks:read"       // 查询timelock合约
+	APIKeyScopeTimelocksWrite      = "timelocks:write"      // 创建、导入、更新、删除timelock合约
+	APIKeyScopeABIRead             = "abi:read"             // 查询ABI
+	APIKeyScopeABIWrite            = "abi:write"            // 创建、更新、删除ABI
+	APIKeyScopeNotificationsManage = "notifications:manage" // 管理通知渠道配置
+	APIKeyScopeEmailsManage        = "emails:manage"        // 管理通知邮箱
+)
+
+// APIKeyScopes 所有可授予的权限范围
+var APIKeyScopes = []string{
+	APIKeyScopeFlowsRead,
+	APIKeyScopeTimelocksRead,
+	APIKeyScopeTimelocksWrite,
+	APIKeyScopeABIRead,
+	APIKeyScopeABIWrite,
+	APIKeyScopeNotificationsManage,
+	APIKeyScopeEmailsManage,
+}
+
+// IsValidAPIKeyScope 检查权限范围是否有效
+func IsValidAPIKeyScope(scope string) bool {
+	for _, candidate := range APIKeyScopes {
+		if candidate == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey 用户个人API Key模型，仅存储哈希
+type APIKey struct {
+	ID         int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     int64      `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	KeyPrefix  string     `json:"key_prefix" gorm:"size:16;not null"` // 明文前缀，用于识别
+	KeyHash    string     `json:"-" gorm:"size:64;not null;unique"`   // SHA-256哈希
+	Scopes     string     `json:"-" gorm:"type:text;not null"`        // 逗号分隔的权限范围
+	ChainID    int        `json:"chain_id" gorm:"not null;default:0"` // Safe会话创建的Key绑定到会话链，0为不限
+	ExpiresAt  *time.Time `json:"expires_at"`                         // 过期时间，为空表示永不过期
+	LastUsedAt *time.Time `json:"last_used_at"`                       // 最后使用时间
+	LastUsedIP string     `json:"last_used_ip" gorm:"column:last_used_ip;size:64"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 设置表名
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList 返回权限范围列表
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// HasScope 检查是否拥有指定权限范围，写权限包含同一资源的读权限
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.ScopeList() {
+		if granted == scope {
+			return true
+		}
+		if strings.HasSuffix(scope, ":read") && granted == strings.TrimSuffix(scope, ":read")+":write" {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPIKeyRequest 创建API Key请求
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // 有效天数，为空表示永不过期
+}
+
+// RevokeAPIKeyRequest 撤销API Key请求
+type RevokeAPIKeyRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// APIKeyInfo API Key信息（不含密钥）
+type APIKeyInfo struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	ChainID    int        `json:"chain_id,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse 创建API Key响应，明文密钥仅返回一次
+type CreateAPIKeyResponse struct {
+	Key    string     `json:"key"`
+	APIKey APIKeyInfo `json:"api_key"`
+}
+
+// APIKeyListResponse API Key列表响应
+type APIKeyListResponse struct {
+	APIKeys []APIKeyInfo `json:"api_keys"`
+}
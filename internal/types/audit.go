@@ -0,0 +1,149 @@
+package types
+
+import (
+	"time"
+)
+
+// 审计事件的资源类型
+const (
+	AuditResourceTimelock           = "timelock"            // timelock合约，资源ID格式 standard:chain_id:contract_address
+	AuditResourceNotificationConfig = "notification_config" // 通知渠道配置，资源ID格式 channel:name
+	AuditResourceEmail              = "email"               // 通知邮箱，资源ID为user_emails.id
+	AuditResourceABI                = "abi"                 // ABI，资源ID为abis.id
+	AuditResourceOrganization       = "organization"        // 组织及成员，资源ID为organizations.id
+	AuditResourceLinkedWallet       = "linked_wallet"       // 账户关联钱包，资源ID为钱包地址
+)
+
+// 审计事件的操作类型
+const (
+	AuditActionTimelockImport       = "timelock.import"
+	AuditActionTimelockUpdateRemark = "timelock.update_remark"
+	AuditActionTimelockDelete       = "timelock.delete"
+
+	AuditActionNotificationCreate = "notification_config.create"
+	AuditActionNotificationUpdate = "notification_config.update"
+	AuditActionNotificationDelete = "notification_config.delete"
+
+	AuditActionEmailAdd            = "email.add"
+	AuditActionEmailVerify         = "email.verify"
+	AuditActionEmailUpdateRemark   = "email.update_remark"
+	AuditActionEmailUpdateFilters  = "email.update_filters"
+	AuditActionEmailUpdateTemplate = "email.update_template"
+	AuditActionEmailUpdateDigest   = "email.update_digest_mode"
+	AuditActionEmailDelete         = "email.delete"
+
+	AuditActionABICreate = "abi.create"
+	AuditActionABIUpdate = "abi.update"
+	AuditActionABIDelete = "abi.delete"
+
+	AuditActionOrganizationCreate       = "organization.create"
+	AuditActionOrganizationUpdate       = "organization.update"
+	AuditActionOrganizationDelete       = "organization.delete"
+	AuditActionOrganizationInvite       = "organization.invite"
+	AuditActionOrganizationRevokeInvite = "organization.revoke_invitation"
+	AuditActionOrganizationAcceptInvite = "organization.accept_invitation"
+	AuditActionOrganizationUpdateMember = "organization.update_member_role"
+	AuditActionOrganizationRemoveMember = "organization.remove_member"
+	AuditActionOrganizationLeave        = "organization.leave"
+
+	AuditActionLinkedWalletLink   = "linked_wallet.link"
+	AuditActionLinkedWalletUnlink = "linked_wallet.unlink"
+)
+
+// 管理员视图中操作记录的来源
+const (
+	AuditScopeUser  = "user"  // 用户操作，记录在audit_events
+	AuditScopeAdmin = "admin" // 管理员后台操作，记录在admin_audit_logs
+)
+
+// AuditEvent 用户操作审计事件，由各服务在状态变更成功后写入
+type AuditEvent struct {
+	ID             int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ActorUserID    int64     `json:"actor_user_id" gorm:"not null;default:0"`         // 操作者用户ID
+	ActorAddress   string    `json:"actor_address" gorm:"size:42;not null;index"`     // 操作者钱包地址
+	AuthType       string    `json:"auth_type" gorm:"size:20"`                        // 认证方式：access（钱包会话）或 api_key
+	OrganizationID int64     `json:"organization_id" gorm:"not null;default:0;index"` // 资源所属组织ID，0表示个人资源
+	Action         string    `json:"action" gorm:"size:64;not null"`                  // 操作类型，如 timelock.delete
+	ResourceType   string    `json:"resource_type" gorm:"size:32;not null"`           // 资源类型
+	ResourceID     string    `json:"resource_id" gorm:"size:200;not null"`            // 资源标识
+	Before         *string   `json:"before" gorm:"type:jsonb"`                        // 变更前状态(JSON)，新建时为空
+	After          *string   `json:"after" gorm:"type:jsonb"`                         // 变更后状态(JSON)，删除时为空
+	IPAddress      string    `json:"ip_address" gorm:"size:64"`
+	UserAgent      string    `json:"user_agent" gorm:"type:text"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// AuditActor 发起请求的操作者信息，由认证中间件写入请求上下文
+type AuditActor struct {
+	UserID        int64
+	WalletAddress string
+	AuthType      string
+	IPAddress     string
+	UserAgent     string
+}
+
+// AuditEventFilter 审计事件查询条件（仓库层使用）
+type AuditEventFilter struct {
+	ActorAddress   string
+	OrganizationID *int64
+	ResourceType   string
+	ResourceID     string
+	Action         string
+	Scope          string // 仅管理员视图使用，空表示用户操作和管理员操作都查询
+	StartTime      *time.Time
+	EndTime        *time.Time
+	Page           int
+	PageSize       int
+}
+
+// GetAuditEventsRequest 查询审计事件请求
+type GetAuditEventsRequest struct {
+	ActorAddress string     `json:"actor_address"` // 仅组织和管理员视图有效，个人视图固定为当前用户
+	ResourceType string     `json:"resource_type" binding:"omitempty,oneof=timelock notification_config email abi organization linked_wallet chain sponsor shared_abi"`
+	ResourceID   string     `json:"resource_id"`
+	Action       string     `json:"action"`
+	StartTime    *time.Time `json:"start_time"`
+	EndTime      *time.Time `json:"end_time"`
+	Page         int        `json:"page" binding:"omitempty,min=1"`
+	PageSize     int        `json:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// GetOrganizationAuditEventsRequest 查询组织审计事件请求
+type GetOrganizationAuditEventsRequest struct {
+	OrganizationID int64 `json:"organization_id" binding:"required"`
+	GetAuditEventsRequest
+}
+
+// GetAdminAuditEventsRequest 管理员查询全部审计事件请求，包含用户操作和管理员后台操作（资源类型chain、sponsor、shared_abi）
+type GetAdminAuditEventsRequest struct {
+	OrganizationID *int64 `json:"organization_id"`                            // 不传表示不按组织筛选，0表示只看个人资源；按组织筛选时不包含管理员操作
+	Scope          string `json:"scope" binding:"omitempty,oneof=user admin"` // 不传表示用户操作和管理员操作都查询
+	GetAuditEventsRequest
+}
+
+// GetAuditEventsResponse 查询审计事件响应
+type GetAuditEventsResponse struct {
+	Events   []AuditEvent `json:"events"`
+	Total    int64        `json:"total"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"page_size"`
+}
+
+// AdminAuditEvent 管理员视图中的操作记录，管理员操作的资源ID为字符串形式的记录ID，变更内容记录在after中
+type AdminAuditEvent struct {
+	Scope string `json:"scope"` // 记录来源：user 或 admin，同一来源内ID唯一
+	AuditEvent
+}
+
+// GetAdminAuditEventsResponse 管理员查询全部审计事件响应
+type GetAdminAuditEventsResponse struct {
+	Events   []AdminAuditEvent `json:"events"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+}
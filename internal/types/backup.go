@@ -0,0 +1,50 @@
+package types
+
+import "time"
+
+// 备份运行状态
+const (
+	BackupRunStatusRunning = "running"
+	BackupRunStatusSuccess = "success"
+	BackupRunStatusFailed  = "failed"
+)
+
+// 备份健康状态
+const (
+	BackupHealthOK      = "ok"
+	BackupHealthRunning = "running"
+	BackupHealthFailed  = "failed"
+	BackupHealthStale   = "stale"
+	BackupHealthPending = "pending"
+)
+
+// BackupRun 定时备份运行记录
+type BackupRun struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	BackupID     string     `json:"backup_id" gorm:"size:64"`              // 备份目录中的备份ID，失败时可能为空
+	BackupType   string     `json:"backup_type" gorm:"size:20;not null"`   // full 或 incremental
+	Status       string     `json:"status" gorm:"size:20;not null;index"`  // running, success, failed
+	File         string     `json:"file" gorm:"type:text"`                 // 本地备份文件路径
+	StartedAt    time.Time  `json:"started_at" gorm:"not null"`            // 开始时间
+	FinishedAt   *time.Time `json:"finished_at"`                           // 结束时间
+	DurationMs   int64      `json:"duration_ms" gorm:"not null;default:0"` // 耗时（毫秒）
+	SizeBytes    int64      `json:"size_bytes" gorm:"not null;default:0"`  // 归档大小
+	TableCount   int        `json:"table_count" gorm:"not null;default:0"` // 备份的表数量
+	RowCount     int64      `json:"row_count" gorm:"not null;default:0"`   // 备份的记录数
+	Checksum     string     `json:"checksum" gorm:"size:64"`               // 归档SHA-256
+	ErrorMessage *string    `json:"error_message,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (BackupRun) TableName() string {
+	return "backup_runs"
+}
+
+// BackupHealth 健康检查中的定时备份状态
+type BackupHealth struct {
+	Status        string     `json:"status"` // ok, running, failed, stale, pending
+	LastRun       *BackupRun `json:"last_run,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+}
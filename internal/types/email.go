@@ -23,9 +23,13 @@ type UserEmail struct {
 	ID             int64      `json:"id" gorm:"primaryKey;autoIncrement"`
 	UserID         int64      `json:"user_id" gorm:"not null"`
 	EmailID        int64      `json:"email_id" gorm:"not null"`
+	OrganizationID int64      `json:"organization_id" gorm:"not null;default:0;index"` // 所属组织ID，0表示个人邮箱
 	Remark         *string    `json:"remark" gorm:"size:200"`
 	IsVerified     bool       `json:"is_verified" gorm:"not null;default:false"`
 	LastVerifiedAt *time.Time `json:"last_verified_at"`
+	Filters        *string    `json:"filters" gorm:"type:jsonb"`                         // 订阅过滤规则(JSON)
+	EmailTemplate  *string    `json:"email_template" gorm:"type:text"`                   // 自定义邮件模板(html/template)
+	DigestMode     string     `json:"digest_mode" gorm:"size:10;not null;default:'off'"` // 摘要模式,off,daily,weekly
 	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -96,16 +100,36 @@ type UpdateEmailRemarkWithIDRequest struct {
 	Remark *string `json:"remark"`
 }
 
+// UpdateEmailFiltersRequest 更新邮箱订阅过滤规则（带ID）
+type UpdateEmailFiltersRequest struct {
+	ID      int64               `json:"id" binding:"required"`
+	Filters *NotificationFilter `json:"filters"` // 不填或传空对象表示清除过滤
+}
+
+// UpdateEmailTemplateRequest 更新邮箱自定义邮件模板（带ID）
+type UpdateEmailTemplateRequest struct {
+	ID       int64   `json:"id" binding:"required"`
+	Template *string `json:"template"` // html/template 模板，不填或传空字符串表示恢复默认模板
+}
+
+// UpdateEmailDigestRequest 更新邮箱摘要模式（带ID）
+type UpdateEmailDigestRequest struct {
+	ID         int64  `json:"id" binding:"required"`
+	DigestMode string `json:"digest_mode" binding:"required"` // off,daily,weekly
+}
+
 // SendVerificationCodeRequest 发送验证码请求
 type SendVerificationCodeRequest struct {
-	Email  string  `json:"email"`
-	Remark *string `json:"remark"`
+	Email          string  `json:"email"`
+	Remark         *string `json:"remark"`
+	OrganizationID int64   `json:"organization_id"` // 所属组织ID，不填或0表示个人邮箱，需要组织编辑者及以上角色
 }
 
 // VerifyEmailRequest 验证邮箱请求
 type VerifyEmailRequest struct {
-	Email string `json:"email"`
-	Code  string `json:"code"`
+	Email          string `json:"email"`
+	Code           string `json:"code"`
+	OrganizationID int64  `json:"organization_id"` // 所属组织ID，需与发送验证码时一致
 }
 
 // DeleteEmailRequest 删除邮箱请求
@@ -123,9 +147,13 @@ type GetEmailsRequest struct {
 type UserEmailResponse struct {
 	ID             int64      `json:"id"`
 	Email          string     `json:"email"`
+	OrganizationID int64      `json:"organization_id"` // 所属组织ID，0表示个人邮箱
 	Remark         *string    `json:"remark"`
 	IsVerified     bool       `json:"is_verified"`
 	LastVerifiedAt *time.Time `json:"last_verified_at"`
+	Filters        *string    `json:"filters"`
+	EmailTemplate  *string    `json:"email_template"`
+	DigestMode     string     `json:"digest_mode"`
 	CreatedAt      time.Time  `json:"created_at"`
 }
 
@@ -135,6 +163,14 @@ type EmailListResponse struct {
 	Total  int64               `json:"total"`
 }
 
+// RelatedVerifiedEmail 与合约相关的已验证邮箱及其订阅过滤规则
+type RelatedVerifiedEmail struct {
+	EmailID       int64   `json:"email_id"`
+	Filters       *string `json:"filters"`
+	EmailTemplate *string `json:"email_template"`
+	DigestMode    string  `json:"digest_mode"`
+}
+
 // NotificationStatus 通知状态枚举
 var NotificationStatus = struct {
 	Waiting   string
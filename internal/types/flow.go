@@ -50,6 +50,13 @@ type FlowStatusCount struct {
 	Expired   int64 `json:"expired"`   // 已过期
 }
 
+// FlowStatusTotal 按标准和状态汇总的流程数量
+type FlowStatusTotal struct {
+	TimelockStandard string `json:"timelock_standard"`
+	Status           string `json:"status"`
+	Count            int64  `json:"count"`
+}
+
 type GetCompoundFlowListCountRequest struct {
 	Standard *string `json:"standard" form:"standard"` // 标准compound, openzeppelin
 }
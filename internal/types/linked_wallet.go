@@ -0,0 +1,54 @@
+package types
+
+import (
+	"time"
+)
+
+// MaxLinkedWallets 每个账户最多关联的钱包数量
+const MaxLinkedWallets = 20
+
+// LinkedWallet 账户关联钱包模型
+// 关联钱包通过签名证明控制权后挂到主钱包账户下，可直接登录该账户，timelock权限按账户下所有钱包合并计算
+type LinkedWallet struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        int64     `json:"user_id" gorm:"not null;index"`                 // 所属账户（主钱包用户ID）
+	WalletAddress string    `json:"wallet_address" gorm:"size:42;not null;unique"` // 关联的钱包地址，全局唯一
+	WalletType    string    `json:"wallet_type" gorm:"size:10;not null;default:'eoa'"`
+	ChainID       int       `json:"chain_id" gorm:"not null;default:0"` // 验证签名时的链ID，Safe钱包登录时必须使用该链
+	Label         string    `json:"label" gorm:"size:100;not null;default:''"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (LinkedWallet) TableName() string {
+	return "user_linked_wallets"
+}
+
+// LinkWalletNonceRequest 获取关联钱包签名消息请求
+type LinkWalletNonceRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required,len=42"` // 待关联的钱包地址
+	ChainID       int    `json:"chain_id" binding:"required,gt=0"`         // 签名消息绑定的链ID，Safe钱包为Safe所在链
+	Domain        string `json:"domain,omitempty"`                         // 发起关联的前端域名，为空时使用默认域名
+	URI           string `json:"uri,omitempty"`                            // 为空时为 https://<domain>
+}
+
+// LinkWalletRequest 关联钱包请求
+type LinkWalletRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required,len=42"`
+	WalletType    string `json:"wallet_type,omitempty" binding:"omitempty,oneof=eoa safe"` // 默认为eoa
+	Message       string `json:"message" binding:"required"`                               // 获取关联签名消息时返回的消息
+	Signature     string `json:"signature" binding:"required"`                             // 待关联钱包对消息的签名，Safe链上signMessage时为"0x"
+	Nonce         string `json:"nonce" binding:"required"`
+	Label         string `json:"label,omitempty" binding:"max=100"` // 钱包备注，如"硬件钱包"
+}
+
+// UnlinkWalletRequest 解除关联钱包请求
+type UnlinkWalletRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required,len=42"`
+}
+
+// LinkedWalletListResponse 账户钱包列表响应
+type LinkedWalletListResponse struct {
+	PrimaryWallet string         `json:"primary_wallet"` // 账户主钱包地址
+	Wallets       []LinkedWallet `json:"wallets"`        // 已关联的钱包
+}
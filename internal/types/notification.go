@@ -14,16 +14,31 @@ const (
 	ChannelFeishu   NotificationChannel = "feishu"
 )
 
+// MaskedSecret 接口返回时替代Bot Token、Webhook URL、签名密钥的脱敏值
+const MaskedSecret = "********"
+
+// MaskSecret 对已配置的密钥进行脱敏，未配置时返回空字符串
+func MaskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return MaskedSecret
+}
+
 // TelegramConfig Telegram通知配置
 type TelegramConfig struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`                       // ID
-	UserAddress string    `json:"user_address" gorm:"not null;index;size:42"` // 用户地址
-	Name        string    `json:"name" gorm:"size:100"`                       // 名称
-	BotToken    string    `json:"bot_token" gorm:"not null;size:500"`         // 机器人token
-	ChatID      string    `json:"chat_id" gorm:"not null;size:100"`           // 聊天ID
-	IsActive    bool      `json:"is_active" gorm:"default:true"`              // 是否激活
-	CreatedAt   time.Time `json:"created_at"`                                 // 创建时间
-	UpdatedAt   time.Time `json:"updated_at"`                                 // 更新时间
+	ID              uint      `json:"id" gorm:"primaryKey"`                              // ID
+	UserAddress     string    `json:"user_address" gorm:"not null;index;size:42"`        // 用户地址
+	OrganizationID  int64     `json:"organization_id" gorm:"not null;default:0;index"`   // 所属组织ID，0表示个人配置
+	Name            string    `json:"name" gorm:"size:100"`                              // 名称
+	BotToken        string    `json:"bot_token" gorm:"not null;type:text"`               // 机器人token（加密存储）
+	ChatID          string    `json:"chat_id" gorm:"not null;size:100"`                  // 聊天ID
+	IsActive        bool      `json:"is_active" gorm:"default:true"`                     // 是否激活
+	Filters         *string   `json:"filters" gorm:"type:jsonb"`                         // 订阅过滤规则(JSON)
+	MessageTemplate *string   `json:"message_template" gorm:"type:text"`                 // 自定义消息模板(text/template)
+	DigestMode      string    `json:"digest_mode" gorm:"size:10;not null;default:'off'"` // 摘要模式,off实时发送,daily每日摘要,weekly每周摘要
+	CreatedAt       time.Time `json:"created_at"`                                        // 创建时间
+	UpdatedAt       time.Time `json:"updated_at"`                                        // 更新时间
 }
 
 func (TelegramConfig) TableName() string {
@@ -32,14 +47,18 @@ func (TelegramConfig) TableName() string {
 
 // LarkConfig Lark通知配置
 type LarkConfig struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`                       // ID
-	UserAddress string    `json:"user_address" gorm:"not null;index;size:42"` // 用户地址
-	Name        string    `json:"name" gorm:"size:100"`                       // 名称
-	WebhookURL  string    `json:"webhook_url" gorm:"not null;size:1000"`      // 网络钩子URL
-	Secret      string    `json:"secret" gorm:"size:500"`                     // 签名验证时的密钥
-	IsActive    bool      `json:"is_active" gorm:"default:true"`              // 是否激活
-	CreatedAt   time.Time `json:"created_at"`                                 // 创建时间
-	UpdatedAt   time.Time `json:"updated_at"`                                 // 更新时间
+	ID              uint      `json:"id" gorm:"primaryKey"`                              // ID
+	UserAddress     string    `json:"user_address" gorm:"not null;index;size:42"`        // 用户地址
+	OrganizationID  int64     `json:"organization_id" gorm:"not null;default:0;index"`   // 所属组织ID，0表示个人配置
+	Name            string    `json:"name" gorm:"size:100"`                              // 名称
+	WebhookURL      string    `json:"webhook_url" gorm:"not null;type:text"`             // 网络钩子URL（加密存储）
+	Secret          string    `json:"secret" gorm:"type:text"`                           // 签名验证时的密钥（加密存储）
+	IsActive        bool      `json:"is_active" gorm:"default:true"`                     // 是否激活
+	Filters         *string   `json:"filters" gorm:"type:jsonb"`                         // 订阅过滤规则(JSON)
+	MessageTemplate *string   `json:"message_template" gorm:"type:text"`                 // 自定义消息模板(text/template)
+	DigestMode      string    `json:"digest_mode" gorm:"size:10;not null;default:'off'"` // 摘要模式,off实时发送,daily每日摘要,weekly每周摘要
+	CreatedAt       time.Time `json:"created_at"`                                        // 创建时间
+	UpdatedAt       time.Time `json:"updated_at"`                                        // 更新时间
 }
 
 func (LarkConfig) TableName() string {
@@ -48,14 +67,18 @@ func (LarkConfig) TableName() string {
 
 // FeishuConfig Feishu通知配置
 type FeishuConfig struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`                       // ID
-	UserAddress string    `json:"user_address" gorm:"not null;index;size:42"` // 用户地址
-	Name        string    `json:"name" gorm:"size:100"`                       // 名称
-	WebhookURL  string    `json:"webhook_url" gorm:"not null;size:1000"`      // 网络钩子URL
-	Secret      string    `json:"secret" gorm:"size:500"`                     // 签名验证时的密钥
-	IsActive    bool      `json:"is_active" gorm:"default:true"`              // 是否激活
-	CreatedAt   time.Time `json:"created_at"`                                 // 创建时间
-	UpdatedAt   time.Time `json:"updated_at"`                                 // 更新时间
+	ID              uint      `json:"id" gorm:"primaryKey"`                              // ID
+	UserAddress     string    `json:"user_address" gorm:"not null;index;size:42"`        // 用户地址
+	OrganizationID  int64     `json:"organization_id" gorm:"not null;default:0;index"`   // 所属组织ID，0表示个人配置
+	Name            string    `json:"name" gorm:"size:100"`                              // 名称
+	WebhookURL      string    `json:"webhook_url" gorm:"not null;type:text"`             // 网络钩子URL（加密存储）
+	Secret          string    `json:"secret" gorm:"type:text"`                           // 签名验证时的密钥（加密存储）
+	IsActive        bool      `json:"is_active" gorm:"default:true"`                     // 是否激活
+	Filters         *string   `json:"filters" gorm:"type:jsonb"`                         // 订阅过滤规则(JSON)
+	MessageTemplate *string   `json:"message_template" gorm:"type:text"`                 // 自定义消息模板(text/template)
+	DigestMode      string    `json:"digest_mode" gorm:"size:10;not null;default:'off'"` // 摘要模式,off实时发送,daily每日摘要,weekly每周摘要
+	CreatedAt       time.Time `json:"created_at"`                                        // 创建时间
+	UpdatedAt       time.Time `json:"updated_at"`                                        // 更新时间
 }
 
 func (FeishuConfig) TableName() string {
@@ -104,35 +127,73 @@ type NotificationConfig struct {
 // CreateNotificationRequest 创建通知通用请求
 type CreateNotificationRequest struct {
 	// 通用
-	Name    string `json:"name" binding:"required"`    // 名称
-	Channel string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
+	Name           string `json:"name" binding:"required"`    // 名称
+	Channel        string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
+	OrganizationID int64  `json:"organization_id"`            // 所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色
 	// telegram
 	BotToken string `json:"bot_token"` // 机器人token
 	ChatID   string `json:"chat_id"`   // 聊天ID
 	// lark feishu
 	WebhookURL string `json:"webhook_url"` // 网络钩子URL
 	Secret     string `json:"secret"`      // 签名验证时的密钥
+	// 订阅过滤规则，不填表示接收全部通知
+	Filters *NotificationFilter `json:"filters"`
+	// 自定义消息模板(text/template)，不填使用默认格式
+	MessageTemplate string `json:"message_template"`
+	// 摘要模式,off,daily,weekly，不填为off（逐条实时发送）
+	DigestMode string `json:"digest_mode"`
 }
 
 // UpdateNotificationRequest 更新通知通用请求
 type UpdateNotificationRequest struct {
 	// 通用
-	Name     *string `json:"name" binding:"required"`    // 名称
-	Channel  *string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
-	IsActive *bool   `json:"is_active"`                  // 是否激活
+	Name           *string `json:"name" binding:"required"`    // 名称
+	Channel        *string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
+	OrganizationID int64   `json:"organization_id"`            // 所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色
+	IsActive       *bool   `json:"is_active"`                  // 是否激活
 	// telegram
 	BotToken *string `json:"bot_token"` // 机器人token
 	ChatID   *string `json:"chat_id"`   // 聊天ID
 	// lark feishu
 	WebhookURL *string `json:"webhook_url"` // 网络钩子URL
 	Secret     *string `json:"secret"`      // 签名验证时的密钥
+	// 订阅过滤规则，传空对象表示清除过滤
+	Filters *NotificationFilter `json:"filters"`
+	// 自定义消息模板(text/template)，传空字符串表示恢复默认格式
+	MessageTemplate *string `json:"message_template"`
+	// 摘要模式,off,daily,weekly
+	DigestMode *string `json:"digest_mode"`
 }
 
 // DeleteNotificationRequest 删除通知通用请求
 type DeleteNotificationRequest struct {
 	// 通用
-	Name    string `json:"name" binding:"required"`    // 名称
-	Channel string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
+	Name           string `json:"name" binding:"required"`    // 名称
+	Channel        string `json:"channel" binding:"required"` // 渠道,telegram,lark,feishu
+	OrganizationID int64  `json:"organization_id"`            // 所属组织ID，不填或0表示个人配置，需要组织编辑者及以上角色
+}
+
+// NotificationFilter 通知订阅过滤规则，各字段为空表示不限制，多个字段同时满足才发送
+type NotificationFilter struct {
+	ContractAddresses []string `json:"contract_addresses,omitempty"` // 合约地址
+	ChainIDs          []int    `json:"chain_ids,omitempty"`          // 链ID
+	StatusTo          []string `json:"status_to,omitempty"`          // 目标状态,waiting,ready,executed,cancelled,expired
+	Functions         []string `json:"functions,omitempty"`          // 函数选择器(0xa9059cbb)或函数签名(transfer(address,uint256))
+	MinValue          string   `json:"min_value,omitempty"`          // 最小原生代币数量(wei)
+}
+
+// IsEmpty 是否未设置任何过滤条件
+func (f *NotificationFilter) IsEmpty() bool {
+	return f == nil || (len(f.ContractAddresses) == 0 && len(f.ChainIDs) == 0 && len(f.StatusTo) == 0 && len(f.Functions) == 0 && f.MinValue == "")
+}
+
+// NotificationFilterEvent 参与过滤匹配的流程事件信息
+type NotificationFilterEvent struct {
+	ChainID           int     // 链ID
+	ContractAddress   string  // 合约地址
+	StatusTo          string  // 目标状态
+	FunctionSignature *string // 函数签名，未知时为nil
+	Value             string  // 原生代币数量(wei)
 }
 
 // UserNotificationConfigs 用户通知配置集合
@@ -156,22 +217,163 @@ type CalldataParam struct {
 }
 
 type NotificationData struct {
-	BgColorFrom    template.CSS    `json:"bg_color_from"`
-	TextColorFrom  template.CSS    `json:"text_color_from"`
-	StatusFrom     string          `json:"status_from"`
-	BgColorTo      template.CSS    `json:"bg_color_to"`
-	TextColorTo    template.CSS    `json:"text_color_to"`
-	StatusTo       string          `json:"status_to"`
-	Standard       string          `json:"standard"`
-	Network        string          `json:"network"`
-	Contract       string          `json:"contract"`
-	Remark         string          `json:"remark"`
-	Caller         string          `json:"caller"`
-	Target         string          `json:"target"`
-	Value          string          `json:"value"`
-	Function       string          `json:"function"`
-	CalldataParams []CalldataParam `json:"calldata_params"`
-	TxUrl          string          `json:"tx_url"`
-	TxHash         string          `json:"tx_hash"`
-	DashboardUrl   string          `json:"dashboard_url"`
+	BgColorFrom    template.CSS    `json:"bg_color_from" swaggertype:"string"`   // 原状态背景色（邮件使用）
+	TextColorFrom  template.CSS    `json:"text_color_from" swaggertype:"string"` // 原状态文字颜色（邮件使用）
+	StatusFrom     string          `json:"status_from"`                          // 原状态，如 WAITING
+	BgColorTo      template.CSS    `json:"bg_color_to" swaggertype:"string"`     // 目标状态背景色（邮件使用）
+	TextColorTo    template.CSS    `json:"text_color_to" swaggertype:"string"`   // 目标状态文字颜色（邮件使用）
+	StatusTo       string          `json:"status_to"`                            // 目标状态，如 READY
+	Standard       string          `json:"standard"`                             // 时间锁标准，COMPOUND / OPENZEPPELIN
+	Network        string          `json:"network"`                              // 网络名称
+	Contract       string          `json:"contract"`                             // 时间锁合约地址
+	Remark         string          `json:"remark"`                               // 合约备注
+	Caller         string          `json:"caller"`                               // 发起地址
+	Target         string          `json:"target"`                               // 调用目标地址
+	Value          string          `json:"value"`                                // 原生代币数量（已格式化，如 0.100000 ETH）
+	Function       string          `json:"function"`                             // 函数签名
+	CalldataParams []CalldataParam `json:"calldata_params"`                      // 解析后的调用参数，每项包含 Name / Type / Value
+	TxUrl          string          `json:"tx_url"`                               // 区块浏览器交易链接
+	TxHash         string          `json:"tx_hash"`                              // 交易哈希（简化显示）
+	DashboardUrl   string          `json:"dashboard_url"`                        // 控制台地址
+}
+
+// PreviewNotificationTemplateRequest 预览通知模板请求
+type PreviewNotificationTemplateRequest struct {
+	Channel  string `json:"channel" binding:"required"`  // 渠道,telegram,lark,feishu使用text/template,email使用html/template
+	Template string `json:"template" binding:"required"` // 模板内容
+}
+
+// PreviewNotificationTemplateResponse 预览通知模板响应
+type PreviewNotificationTemplateResponse struct {
+	Rendered   string            `json:"rendered"`    // 渲染结果
+	SampleData *NotificationData `json:"sample_data"` // 渲染使用的示例数据，即模板可用的变量
+}
+
+// 摘要模式
+const (
+	DigestModeOff    = "off"    // 逐条实时发送
+	DigestModeDaily  = "daily"  // 每日摘要
+	DigestModeWeekly = "weekly" // 每周摘要
+)
+
+// 摘要发送状态
+const (
+	DigestSendStatusPending = "pending" // 已认领，正在发送
+	DigestSendStatusSuccess = "success" // 发送成功
+	DigestSendStatusFailed  = "failed"  // 发送失败，下次调度时重试
+	DigestSendStatusSkipped = "skipped" // 周期内无活动，未发送
+)
+
+// IsValidDigestMode 是否为有效的摘要模式
+func IsValidDigestMode(mode string) bool {
+	return mode == DigestModeOff || mode == DigestModeDaily || mode == DigestModeWeekly
+}
+
+// IsRealtimeDigestMode 是否为逐条实时发送（未设置摘要模式）
+func IsRealtimeDigestMode(mode string) bool {
+	return mode == "" || mode == DigestModeOff
+}
+
+// DigestSendLog 摘要发送日志，同一接收方同一周期只发送一次
+type DigestSendLog struct {
+	ID           int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Channel      string    `json:"channel" gorm:"size:20;not null"`     // 渠道,telegram,lark,feishu,email
+	ConfigID     int64     `json:"config_id" gorm:"not null"`           // 配置ID（邮件为user_emails.id）
+	PeriodType   string    `json:"period_type" gorm:"size:10;not null"` // 周期类型,daily,weekly
+	PeriodStart  time.Time `json:"period_start" gorm:"not null"`        // 周期开始时间
+	PeriodEnd    time.Time `json:"period_end" gorm:"not null"`          // 周期结束时间
+	FlowCount    int       `json:"flow_count" gorm:"not null;default:0"`
+	SendStatus   string    `json:"send_status" gorm:"size:20;not null"`
+	ErrorMessage *string   `json:"error_message" gorm:"type:text"`
+	RetryCount   int       `json:"retry_count" gorm:"not null;default:0"`
+	SentAt       time.Time `json:"sent_at" gorm:"not null"`
+}
+
+func (DigestSendLog) TableName() string {
+	return "digest_send_logs"
+}
+
+// DigestEmailRecipient 摘要邮件接收方（用户与已验证邮箱的绑定关系）
+type DigestEmailRecipient struct {
+	UserEmailID    int64   `json:"user_email_id"`
+	Email          string  `json:"email"`
+	WalletAddress  string  `json:"wallet_address"`
+	OrganizationID int64   `json:"organization_id"` // 组织邮箱按组织关注的合约汇总
+	Filters        *string `json:"filters"`
+}
+
+// DigestSummary 一个周期内的流程摘要
+type DigestSummary struct {
+	PeriodType   string                    `json:"period_type"`
+	PeriodStart  time.Time                 `json:"period_start"`
+	PeriodEnd    time.Time                 `json:"period_end"`
+	NewQueued    []TimelockTransactionFlow `json:"new_queued"`    // 周期内新排队
+	NowReady     []TimelockTransactionFlow `json:"now_ready"`     // 当前可执行
+	ExpiringSoon []TimelockTransactionFlow `json:"expiring_soon"` // 下一周期内即将过期
+	Executed     []TimelockTransactionFlow `json:"executed"`      // 周期内已执行
+	Cancelled    []TimelockTransactionFlow `json:"cancelled"`     // 周期内已取消
+}
+
+// Total 摘要中的流程总数
+func (d *DigestSummary) Total() int {
+	return len(d.NewQueued) + len(d.NowReady) + len(d.ExpiringSoon) + len(d.Executed) + len(d.Cancelled)
+}
+
+// 通知发件箱投递渠道
+const (
+	OutboxChannelEmail = "email" // 邮件通知
+	OutboxChannelIM    = "im"    // 渠道通知（telegram/lark/feishu）
+)
+
+// 通知发件箱状态
+const (
+	OutboxStatusPending    = "pending"    // 等待投递
+	OutboxStatusProcessing = "processing" // 投递中
+	OutboxStatusSent       = "sent"       // 已投递
+	OutboxStatusDead       = "dead"       // 超过最大重试次数（死信）
+)
+
+// NotificationOutbox 通知发件箱，与流程状态变更在同一事务中写入，由后台worker异步投递
+type NotificationOutbox struct {
+	ID               int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Channel          string     `json:"channel" gorm:"size:20;not null"`                  // 投递渠道 email / im
+	FlowID           string     `json:"flow_id" gorm:"size:128;not null;index"`           // 流程ID
+	TimelockStandard string     `json:"timelock_standard" gorm:"size:20;not null"`        // 时间锁标准
+	ChainID          int        `json:"chain_id" gorm:"not null"`                         // 链ID
+	ContractAddress  string     `json:"contract_address" gorm:"size:42;not null"`         // 合约地址
+	StatusFrom       string     `json:"status_from" gorm:"size:20"`                       // 状态从
+	StatusTo         string     `json:"status_to" gorm:"size:20;not null"`                // 状态到
+	TxHash           *string    `json:"tx_hash" gorm:"size:66"`                           // 交易哈希
+	InitiatorAddress string     `json:"initiator_address" gorm:"size:42"`                 // 发起者地址
+	Status           string     `json:"status" gorm:"size:20;not null;default:'pending'"` // 投递状态
+	Attempts         int        `json:"attempts" gorm:"not null;default:0"`               // 已尝试次数
+	MaxAttempts      int        `json:"max_attempts" gorm:"not null"`                     // 最大尝试次数
+	NextAttemptAt    time.Time  `json:"next_attempt_at" gorm:"not null"`                  // 下次尝试时间
+	LastError        *string    `json:"last_error" gorm:"type:text"`                      // 最近一次错误
+	LockedAt         *time.Time `json:"locked_at"`                                        // 被worker领取的时间
+	SentAt           *time.Time `json:"sent_at"`                                          // 投递成功时间
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (NotificationOutbox) TableName() string {
+	return "notification_outbox"
+}
+
+// GetNotificationOutboxListRequest 获取通知发件箱列表请求
+type GetNotificationOutboxListRequest struct {
+	Status   string `json:"status" form:"status"`       // 状态 pending, processing, sent, dead，为空表示全部
+	Page     int    `json:"page" form:"page"`           // 页码，默认为1
+	PageSize int    `json:"page_size" form:"page_size"` // 每页大小，默认为10，最大100
+}
+
+// GetNotificationOutboxListResponse 获取通知发件箱列表响应
+type GetNotificationOutboxListResponse struct {
+	Items []NotificationOutbox `json:"items"` // 发件箱记录
+	Total int64                `json:"total"` // 总数
+}
+
+// RedeliverNotificationRequest 重新投递通知请求
+type RedeliverNotificationRequest struct {
+	ID int64 `json:"id" binding:"required"` // 发件箱记录ID
 }
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"time"
+)
+
+// 组织成员角色
+const (
+	OrganizationRoleOwner  = "owner"  // 所有者：管理组织信息、成员和邀请，拥有编辑权限
+	OrganizationRoleEditor = "editor" // 编辑者：管理组织的timelock合约、ABI、通知配置和邮箱
+	OrganizationRoleViewer = "viewer" // 查看者：只读访问组织的timelock合约、流程和ABI
+)
+
+// 组织邀请状态
+const (
+	InvitationStatusPending  = "pending"  // 等待受邀者处理
+	InvitationStatusAccepted = "accepted" // 已接受
+	InvitationStatusDeclined = "declined" // 已拒绝
+	InvitationStatusRevoked  = "revoked"  // 已被组织撤销
+)
+
+// OrgPermissionPrefix timelock权限列表中组织角色的前缀，如 org_editor
+const OrgPermissionPrefix = "org_"
+
+// organizationRoleRank 角色等级，等级高的角色包含等级低的角色的权限
+var organizationRoleRank = map[string]int{
+	OrganizationRoleViewer: 1,
+	OrganizationRoleEditor: 2,
+	OrganizationRoleOwner:  3,
+}
+
+// IsValidOrganizationRole 检查角色是否有效
+func IsValidOrganizationRole(role string) bool {
+	_, ok := organizationRoleRank[role]
+	return ok
+}
+
+// HasOrganizationRole 检查角色是否满足所需角色，非成员（空角色）始终返回false
+func HasOrganizationRole(role, required string) bool {
+	rank, ok := organizationRoleRank[role]
+	if !ok {
+		return false
+	}
+	return rank >= organizationRoleRank[required]
+}
+
+// HigherOrganizationRole 返回两个角色中等级较高的一个，用于合并账户下多个钱包的成员角色
+func HigherOrganizationRole(a, b string) string {
+	if organizationRoleRank[b] > organizationRoleRank[a] {
+		return b
+	}
+	return a
+}
+
+// Organization 组织模型，成员共享组织的timelock合约、ABI、通知配置和邮箱
+type Organization struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:100;not null"`
+	Description string    `json:"description" gorm:"size:500;default:''"`
+	CreatedBy   string    `json:"created_by" gorm:"size:42;not null"` // 创建者钱包地址
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 设置表名
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// OrganizationMember 组织成员模型
+type OrganizationMember struct {
+	ID             int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrganizationID int64     `json:"organization_id" gorm:"not null;uniqueIndex:idx_org_member_wallet,priority:1"`
+	UserID         int64     `json:"user_id" gorm:"not null;index"`
+	WalletAddress  string    `json:"wallet_address" gorm:"size:42;not null;index;uniqueIndex:idx_org_member_wallet,priority:2"`
+	Role           string    `json:"role" gorm:"size:20;not null"` // owner, editor, viewer
+	InvitedBy      string    `json:"invited_by" gorm:"size:42"`    // 邀请者钱包地址，创建者为空
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 设置表名
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// OrganizationInvitation 组织邀请模型，受邀钱包登录后接受邀请成为成员
+type OrganizationInvitation struct {
+	ID             int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrganizationID int64      `json:"organization_id" gorm:"not null;index"`
+	WalletAddress  string     `json:"wallet_address" gorm:"size:42;not null;index"` // 受邀钱包地址
+	Role           string     `json:"role" gorm:"size:20;not null"`                 // 接受后获得的角色
+	InvitedBy      string     `json:"invited_by" gorm:"size:42;not null"`           // 邀请者钱包地址
+	Status         string     `json:"status" gorm:"size:20;not null;default:'pending'"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	RespondedAt    *time.Time `json:"responded_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// 关联
+	Organization *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+}
+
+// TableName 设置表名
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+// CreateOrganizationRequest 创建组织请求
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// UpdateOrganizationRequest 更新组织请求
+type UpdateOrganizationRequest struct {
+	ID          int64  `json:"id" binding:"required"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// OrganizationIDRequest 按ID操作组织的请求（详情、删除、退出）
+type OrganizationIDRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// InviteOrganizationMemberRequest 邀请成员请求
+type InviteOrganizationMemberRequest struct {
+	OrganizationID int64  `json:"organization_id" binding:"required"`
+	WalletAddress  string `json:"wallet_address" binding:"required,len=42"`
+	Role           string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+// OrganizationInvitationIDRequest 按ID处理邀请的请求（接受、拒绝、撤销）
+type OrganizationInvitationIDRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// UpdateOrganizationMemberRequest 修改成员角色请求
+type UpdateOrganizationMemberRequest struct {
+	OrganizationID int64  `json:"organization_id" binding:"required"`
+	WalletAddress  string `json:"wallet_address" binding:"required,len=42"`
+	Role           string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+// RemoveOrganizationMemberRequest 移除成员请求
+type RemoveOrganizationMemberRequest struct {
+	OrganizationID int64  `json:"organization_id" binding:"required"`
+	WalletAddress  string `json:"wallet_address" binding:"required,len=42"`
+}
+
+// OrganizationInfo 组织信息及当前用户的角色
+type OrganizationInfo struct {
+	Organization
+	Role        string `json:"role"`         // 当前用户在组织中的角色
+	MemberCount int64  `json:"member_count"` // 成员数量
+}
+
+// OrganizationListResponse 组织列表响应
+type OrganizationListResponse struct {
+	Organizations []OrganizationInfo `json:"organizations"`
+}
+
+// OrganizationDetailResponse 组织详情响应
+type OrganizationDetailResponse struct {
+	Organization OrganizationInfo         `json:"organization"`
+	Members      []OrganizationMember     `json:"members"`
+	Invitations  []OrganizationInvitation `json:"invitations"` // 待处理的邀请，仅所有者可见
+}
+
+// OrganizationInvitationListResponse 当前用户收到的待处理邀请列表响应
+type OrganizationInvitationListResponse struct {
+	Invitations []OrganizationInvitation `json:"invitations"`
+}
@@ -0,0 +1,67 @@
+package types
+
+import "testing"
+
+func TestHasOrganizationRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		required string
+		want     bool
+	}{
+		{OrganizationRoleOwner, OrganizationRoleOwner, true},
+		{OrganizationRoleOwner, OrganizationRoleEditor, true},
+		{OrganizationRoleOwner, OrganizationRoleViewer, true},
+		{OrganizationRoleEditor, OrganizationRoleOwner, false},
+		{OrganizationRoleEditor, OrganizationRoleEditor, true},
+		{OrganizationRoleEditor, OrganizationRoleViewer, true},
+		{OrganizationRoleViewer, OrganizationRoleOwner, false},
+		{OrganizationRoleViewer, OrganizationRoleEditor, false},
+		{OrganizationRoleViewer, OrganizationRoleViewer, true},
+		{"", OrganizationRoleViewer, false},
+		{"admin", OrganizationRoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+"/"+tt.required, func(t *testing.T) {
+			if got := HasOrganizationRole(tt.role, tt.required); got != tt.want {
+				t.Fatalf("HasOrganizationRole(%q, %q) = %v, want %v", tt.role, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidOrganizationRole(t *testing.T) {
+	for role, want := range map[string]bool{
+		OrganizationRoleOwner:  true,
+		OrganizationRoleEditor: true,
+		OrganizationRoleViewer: true,
+		"":                     false,
+		"Owner":                false,
+		"admin":                false,
+	} {
+		if got := IsValidOrganizationRole(role); got != want {
+			t.Fatalf("IsValidOrganizationRole(%q) = %v, want %v", role, got, want)
+		}
+	}
+}
+
+func TestHigherOrganizationRole(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", ""},
+		{"", OrganizationRoleViewer, OrganizationRoleViewer},
+		{OrganizationRoleViewer, "", OrganizationRoleViewer},
+		{OrganizationRoleViewer, OrganizationRoleOwner, OrganizationRoleOwner},
+		{OrganizationRoleOwner, OrganizationRoleEditor, OrganizationRoleOwner},
+		{OrganizationRoleEditor, OrganizationRoleEditor, OrganizationRoleEditor},
+		{"admin", OrganizationRoleViewer, OrganizationRoleViewer},
+	}
+
+	for _, tt := range tests {
+		if got := HigherOrganizationRole(tt.a, tt.b); got != tt.want {
+			t.Fatalf("HigherOrganizationRole(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import "time"
+
+// 历史数据清理运行状态
+const (
+	RetentionRunStatusRunning = "running"
+	RetentionRunStatusSuccess = "success"
+	RetentionRunStatusFailed  = "failed"
+)
+
+// RetentionRun 历史数据清理运行记录，每次运行每张表一条
+type RetentionRun struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	TargetTable  string     `json:"table" gorm:"column:target_table;size:64;not null"` // 清理的表
+	Status       string     `json:"status" gorm:"size:20;not null"`                    // running, success, failed
+	Cutoff       time.Time  `json:"cutoff" gorm:"not null"`                            // 早于该时间的行被清理
+	StartedAt    time.Time  `json:"started_at" gorm:"not null"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	DurationMs   int64      `json:"duration_ms" gorm:"not null;default:0"`
+	DeletedRows  int64      `json:"deleted_rows" gorm:"not null;default:0"`
+	ArchivedRows int64      `json:"archived_rows" gorm:"not null;default:0"`
+	ArchiveFile  string     `json:"archive_file,omitempty" gorm:"type:text"` // 删除前写入的归档，未归档时为空
+	ErrorMessage *string    `json:"error_message,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (RetentionRun) TableName() string {
+	return "retention_runs"
+}
+
+// RetentionTableStats 单张表的累计清理统计，服务重启后从零开始
+type RetentionTableStats struct {
+	Table         string        `json:"table"`
+	Runs          int64         `json:"runs"`
+	Failures      int64         `json:"failures"`
+	DeletedRows   int64         `json:"deleted_rows"`
+	ArchivedRows  int64         `json:"archived_rows"`
+	LastRun       *RetentionRun `json:"last_run,omitempty"`
+	LastSuccessAt *time.Time    `json:"last_success_at,omitempty"`
+}
+
+// RetentionHealth 健康检查中的历史数据清理状态
+type RetentionHealth struct {
+	Running   bool                  `json:"running"`
+	NextRunAt *time.Time            `json:"next_run_at,omitempty"`
+	Tables    []RetentionTableStats `json:"tables"`
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+)
+
+// 会话撤销原因
+const (
+	SessionRevokeLogout    = "logout"     // 用户登出
+	SessionRevokeLogoutAll = "logout_all" // 用户登出所有设备
+	SessionRevokeReuse     = "reuse"      // 检测到刷新令牌重放，撤销整个令牌族
+)
+
+// UserSession 登录会话模型
+// 每个刷新令牌对应一条记录（以刷新令牌的jti为键），同一次登录轮换产生的刷新令牌属于同一令牌族
+type UserSession struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID       int64      `json:"user_id" gorm:"not null;index"`
+	FamilyID     string     `json:"family_id" gorm:"size:64;not null;index"`             // 令牌族ID，写入访问令牌的sid声明
+	RefreshJTI   string     `json:"-" gorm:"column:refresh_jti;size:64;not null;unique"` // 刷新令牌jti
+	ParentJTI    string     `json:"-" gorm:"column:parent_jti;size:64"`                  // 轮换前的刷新令牌jti
+	ChainID      int        `json:"chain_id" gorm:"not null;default:0"`
+	Domain       string     `json:"domain" gorm:"size:255"`
+	IsSafe       bool       `json:"is_safe" gorm:"default:false"`
+	UserAgent    string     `json:"user_agent" gorm:"size:512"`
+	IPAddress    string     `json:"ip_address" gorm:"size:64"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+	RotatedAt    *time.Time `json:"rotated_at"`                   // 刷新令牌已被轮换（使用）的时间
+	RevokedAt    *time.Time `json:"revoked_at"`                   // 会话被撤销的时间
+	RevokeReason string     `json:"revoke_reason" gorm:"size:32"` // logout, logout_all, reuse
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 设置表名
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// RevokedToken 访问令牌黑名单（数据库存储），TokenID为访问令牌的jti或令牌族ID
+type RevokedToken struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	TokenID   string    `json:"token_id" gorm:"size:128;not null;unique"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// SessionClient 发起登录或刷新的客户端信息
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// LogoutResponse 登出响应
+type LogoutResponse struct {
+	RevokedSessions int64 `json:"revoked_sessions"` // 撤销的会话（令牌族）数量
+}
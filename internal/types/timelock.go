@@ -11,6 +11,7 @@ type CompoundTimeLock struct {
 	ChainID         int       `json:"chain_id" gorm:"not null;index;uniqueIndex:idx_compound_creator_chain_address,priority:2"`                 // 所在链ID
 	ChainName       string    `json:"chain_name" gorm:"size:50;not null;index"`                                                                 // 链名称
 	ContractAddress string    `json:"contract_address" gorm:"size:42;not null;index;uniqueIndex:idx_compound_creator_chain_address,priority:3"` // 合约地址
+	OrganizationID  int64     `json:"organization_id" gorm:"not null;default:0;index"`                                                          // 所属组织ID，0表示个人合约
 	Delay           int64     `json:"delay" gorm:"not null"`                                                                                    // 延迟时间（秒），从链上读取
 	Admin           string    `json:"admin" gorm:"size:42;not null;index"`                                                                      // 管理员地址，从链上读取
 	PendingAdmin    *string   `json:"pending_admin" gorm:"size:42;index"`                                                                       // 待定管理员地址，从链上读取
@@ -36,6 +37,7 @@ type OpenzeppelinTimeLock struct {
 	ChainID         int       `json:"chain_id" gorm:"not null;index;uniqueIndex:idx_oz_creator_chain_address,priority:2"`                 // 所在链ID
 	ChainName       string    `json:"chain_name" gorm:"size:50;not null;index"`                                                           // 链名称
 	ContractAddress string    `json:"contract_address" gorm:"size:42;not null;index;uniqueIndex:idx_oz_creator_chain_address,priority:3"` // 合约地址
+	OrganizationID  int64     `json:"organization_id" gorm:"not null;default:0;index"`                                                    // 所属组织ID，0表示个人合约
 	Delay           int64     `json:"delay" gorm:"not null"`                                                                              // 延迟时间（秒），从链上读取
 	Admin           string    `json:"admin" gorm:"size:42;not null;index"`                                                                // 管理员地址，从链上读取
 	Proposers       string    `json:"proposers" gorm:"type:text;not null"`                                                                // 提议者地址列表（JSON），从链上读取
@@ -59,6 +61,7 @@ type CreateOrImportTimelockContractRequest struct {
 	ChainID         int    `json:"chain_id" binding:"required"`
 	IsImported      bool   `json:"is_imported"`
 	Remark          string `json:"remark" binding:"max=500"`
+	OrganizationID  int64  `json:"organization_id"` // 添加到组织的共享监控列表，0表示个人
 }
 
 // UpdateTimeLockRequest 更新timelock合约请求
@@ -67,6 +70,7 @@ type UpdateTimeLockRequest struct {
 	ChainID         int    `json:"chain_id" binding:"required"`
 	ContractAddress string `json:"contract_address" binding:"required"`
 	Remark          string `json:"remark" binding:"max=500"`
+	OrganizationID  int64  `json:"organization_id"` // 组织合约所属组织，0表示个人
 }
 
 // DeleteTimeLockRequest 删除timelock合约请求
@@ -74,12 +78,14 @@ type DeleteTimeLockRequest struct {
 	Standard        string `json:"standard" binding:"required,oneof=compound openzeppelin"`
 	ChainID         int    `json:"chain_id" binding:"required"`
 	ContractAddress string `json:"contract_address" binding:"required"`
+	OrganizationID  int64  `json:"organization_id"` // 组织合约所属组织，0表示个人
 }
 
 // GetTimeLockListRequest 获取timelock列表请求
 type GetTimeLockListRequest struct {
-	Standard string `json:"standard" form:"standard"`
-	Status   string `json:"status" form:"status"`
+	Standard       string `json:"standard" form:"standard"`
+	Status         string `json:"status" form:"status"`
+	OrganizationID int64  `json:"organization_id" form:"organization_id"` // 仅返回指定组织的合约，0表示不过滤
 }
 
 // GetTimeLockListResponse 获取timelock列表响应
@@ -94,6 +100,7 @@ type GetTimeLockDetailRequest struct {
 	Standard        string `json:"standard" form:"standard" binding:"required,oneof=compound openzeppelin"`
 	ChainID         int    `json:"chain_id" form:"chain_id" binding:"required"`
 	ContractAddress string `json:"contract_address" form:"contract_address" binding:"required"`
+	OrganizationID  int64  `json:"organization_id" form:"organization_id"` // 查看组织共享的合约，0表示个人或有链上权限的合约
 }
 
 // GetTimeLockDetailResponse timelock详情响应
@@ -106,11 +113,11 @@ type GetTimeLockDetailResponse struct {
 // CompoundTimeLockWithPermission Compound timelock with permission info
 type CompoundTimeLockWithPermission struct {
 	CompoundTimeLock
-	UserPermissions []string `json:"user_permissions"` // creator, admin, pending_admin
+	UserPermissions []string `json:"user_permissions"` // creator, admin, pending_admin, org_owner, org_editor, org_viewer
 }
 
 // OpenzeppelinTimeLockWithPermission OpenZeppelin timelock with permission info
 type OpenzeppelinTimeLockWithPermission struct {
 	OpenzeppelinTimeLock
-	UserPermissions []string `json:"user_permissions"` // creator, proposer, executor, canceller
+	UserPermissions []string `json:"user_permissions"` // creator, proposer, executor, canceller, org_owner, org_editor, org_viewer
 }
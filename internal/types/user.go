@@ -12,6 +12,8 @@ type AuthNonce struct {
 	Message       string    `json:"message" gorm:"type:text;not null"`
 	ExpiresAt     time.Time `json:"expires_at" gorm:"not null;index"`
 	IsUsed        bool      `json:"is_used" gorm:"default:false;index"`
+	ChainID       int       `json:"chain_id" gorm:"not null;default:0"`            // 签名消息绑定的链ID
+	Purpose       string    `json:"purpose" gorm:"size:16;not null;default:login"` // nonce用途：login/link
 	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
@@ -20,6 +22,12 @@ func (AuthNonce) TableName() string {
 	return "auth_nonces"
 }
 
+// nonce用途，登录和关联钱包的签名消息不能互相使用
+const (
+	AuthNoncePurposeLogin = "login"
+	AuthNoncePurposeLink  = "link"
+)
+
 // User 用户模型 - 支持链切换功能
 type User struct {
 	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -41,22 +49,28 @@ func (User) TableName() string {
 // GetNonceRequest 获取nonce请求
 type GetNonceRequest struct {
 	WalletAddress string `json:"wallet_address" form:"wallet_address" binding:"required,len=42"`
+	ChainID       int    `json:"chain_id" form:"chain_id" binding:"required,gt=0"` // 签名消息绑定的链ID，必须在支持链列表中
+	Domain        string `json:"domain,omitempty" form:"domain"`                   // 发起登录的前端域名（可含端口），为空时使用默认域名
+	URI           string `json:"uri,omitempty" form:"uri"`                         // 登录主体的URI，为空时为 https://<domain>
 }
 
 // GetNonceResponse 获取nonce响应
 type GetNonceResponse struct {
-	Message string `json:"message"`
-	Nonce   string `json:"nonce"`
+	Message   string    `json:"message"`    // SIWE (EIP-4361) 格式的待签名消息
+	Nonce     string    `json:"nonce"`      // 随机nonce
+	ExpiresAt time.Time `json:"expires_at"` // 签名消息过期时间
 }
 
 // WalletConnectRequest 钱包连接请求
 type WalletConnectRequest struct {
-	ChainID       int    `json:"chain_id,omitempty"` // Safe需要指定链ID
+	ChainID       int    `json:"chain_id,omitempty"` // 可选，填写时需与签名消息中的链ID一致
 	WalletAddress string `json:"wallet_address" binding:"required,len=42"`
-	Signature     string `json:"signature,omitempty"`                                  // EOA钱包需要，Safe钱包可选
-	Message       string `json:"message,omitempty"`                                    // EOA钱包需要，Safe钱包可选
-	WalletType    string `json:"wallet_type,omitempty"`                                // "eoa", "safe"
-	Nonce         string `json:"nonce" binding:"required_if=WalletType eoa,omitempty"` // EOA钱包需要nonce，Safe钱包不需要
+	Signature     string `json:"signature,omitempty"`   // EOA为owner签名；Safe为owner拼接签名，链上signMessage时为"0x"
+	Message       string `json:"message,omitempty"`     // 获取nonce时返回的签名消息
+	WalletType    string `json:"wallet_type,omitempty"` // "eoa", "safe"
+	Nonce         string `json:"nonce"`                 // 获取nonce时返回的nonce，EOA和Safe均需要
+
+	Client SessionClient `json:"-"` // 客户端信息，由处理器填充
 }
 
 // WalletConnectResponse 钱包连接响应
@@ -70,20 +84,56 @@ type WalletConnectResponse struct {
 // RefreshTokenRequest 刷新令牌请求
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+
+	Client SessionClient `json:"-"` // 客户端信息，由处理器填充
 }
 
 // UserProfile 用户资料
 type UserProfile struct {
-	WalletAddress string     `json:"wallet_address"`
-	CreatedAt     time.Time  `json:"created_at"`
-	LastLogin     *time.Time `json:"last_login"`
+	WalletAddress string         `json:"wallet_address"`
+	CreatedAt     time.Time      `json:"created_at"`
+	LastLogin     *time.Time     `json:"last_login"`
+	LinkedWallets []LinkedWallet `json:"linked_wallets"` // 关联到该账户的其他钱包
 }
 
 // JWTClaims JWT声明
 type JWTClaims struct {
-	UserID        int64  `json:"user_id"`
-	WalletAddress string `json:"wallet_address"`
-	Type          string `json:"type"` // access or refresh
+	UserID        int64     `json:"user_id"`
+	WalletAddress string    `json:"wallet_address"`
+	ChainID       int       `json:"chain_id,omitempty"`   // 签名登录时的链ID
+	Domain        string    `json:"domain,omitempty"`     // 签名登录时的域名
+	Safe          bool      `json:"safe,omitempty"`       // Safe会话，仅能访问ChainID所在链
+	Signer        string    `json:"signer,omitempty"`     // 使用关联钱包登录时的签名钱包地址，主钱包登录时为空
+	Type          string    `json:"type"`                 // access or refresh
+	ID            string    `json:"jti,omitempty"`        // 令牌ID
+	SessionID     string    `json:"sid,omitempty"`        // 令牌族ID，同一次登录轮换产生的令牌共享
+	ExpiresAt     time.Time `json:"expires_at,omitempty"` // 令牌过期时间
+}
+
+// SessionBinding 登录会话绑定信息，写入JWT声明
+type SessionBinding struct {
+	ChainID int    // SIWE消息中的链ID
+	Domain  string // SIWE消息中的域名
+	Safe    bool   // 是否为Safe会话
+	Signer  string // 使用关联钱包登录时的签名钱包地址
+
+	SessionID string // 令牌族ID，为空时生成新的令牌族
+}
+
+// JWK 公开的令牌验证公钥 (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`         // 密钥类型：EC 或 OKP
+	Crv string `json:"crv"`         // 曲线：P-256 或 Ed25519
+	X   string `json:"x"`           // 公钥坐标（base64url）
+	Y   string `json:"y,omitempty"` // EC公钥的Y坐标（base64url）
+	Kid string `json:"kid"`         // 密钥ID，与令牌头中的kid对应
+	Alg string `json:"alg"`         // 签名算法：ES256 或 EdDSA
+	Use string `json:"use"`         // 固定为sig
+}
+
+// JWKSet 令牌验证公钥集合，供其他服务验证本服务签发的令牌
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
 }
 
 // APIResponse 统一API响应格式
@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix 个人API Key前缀
+const APIKeyPrefix = "tlk_"
+
+// apiKeyDisplayLength 保存用于识别的明文前缀长度（含 tlk_）
+const apiKeyDisplayLength = 12
+
+// GenerateAPIKey 生成新的API Key，返回明文密钥、用于展示的前缀和哈希
+func GenerateAPIKey() (string, string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := APIKeyPrefix + hex.EncodeToString(b)
+	return key, key[:apiKeyDisplayLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey 计算API Key的SHA-256哈希（hex）
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKeyFormat 检查是否为API Key格式
+func IsAPIKeyFormat(key string) bool {
+	return strings.HasPrefix(key, APIKeyPrefix) && len(key) == len(APIKeyPrefix)+64
+}
@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !IsAPIKeyFormat(key) {
+		t.Fatalf("generated key %q is not in api key format", key)
+	}
+	if !strings.HasPrefix(key, prefix) || len(prefix) != apiKeyDisplayLength {
+		t.Fatalf("prefix %q is not the first %d characters of the key", prefix, apiKeyDisplayLength)
+	}
+	if hash != HashAPIKey(key) {
+		t.Fatalf("hash does not match HashAPIKey(key)")
+	}
+	if strings.Contains(hash, key[len(APIKeyPrefix):]) {
+		t.Fatalf("hash contains the plaintext key")
+	}
+
+	other, _, otherHash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if other == key || otherHash == hash {
+		t.Fatalf("generated the same key twice")
+	}
+}
+
+func TestHashAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"empty", "", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"abc", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HashAPIKey(tt.key)
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+			if len(got) != 64 {
+				t.Fatalf("hash length %d, want 64", len(got))
+			}
+		})
+	}
+
+	if HashAPIKey(APIKeyPrefix+strings.Repeat("0", 64)) == HashAPIKey(APIKeyPrefix+strings.Repeat("0", 63)+"1") {
+		t.Fatalf("different keys hashed to the same value")
+	}
+}
+
+func TestIsAPIKeyFormat(t *testing.T) {
+	valid := APIKeyPrefix + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{"valid", valid, true},
+		{"empty", "", false},
+		{"prefix only", APIKeyPrefix, false},
+		{"missing prefix", strings.Repeat("ab", 32), false},
+		{"wrong prefix", "tlx_" + strings.Repeat("ab", 32), false},
+		{"too short", valid[:len(valid)-1], false},
+		{"too long", valid + "a", false},
+		{"jwt", "eyJhbGciOiJIUzI1NiJ9.e30.signature", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAPIKeyFormat(tt.key); got != tt.want {
+				t.Fatalf("IsAPIKeyFormat(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"timelocker-backend/internal/config"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+)
+
+// 备份加密：每个备份使用随机文件密钥，文件密钥分别由口令（scrypt）或X25519接收方公钥包装后写入清单
+// 表数据按64KiB分块使用AES-256-GCM加密，nonce为块序号加末块标记，可检测篡改、截断和块重排
+const (
+	BackupKeyScrypt = "scrypt"
+	BackupKeyX25519 = "x25519"
+
+	BackupRecipientPrefix = "x25519-pub:"
+	BackupIdentityPrefix  = "x25519-sec:"
+
+	backupFileKeySize = 32
+	backupChunkSize   = 64 * 1024
+	backupTagSize     = 16
+	backupNonceSize   = 12
+
+	scryptLogN = 15
+	scryptR    = 8
+	scryptP    = 1
+	// 解密时接受的最大scrypt成本，避免被构造的清单耗尽内存
+	scryptMaxLogN = 20
+)
+
+var (
+	ErrBackupKeyNotFound = errors.New("no passphrase or identity can decrypt this backup")
+	ErrBackupTampered    = errors.New("backup data has been tampered with or corrupted")
+)
+
+// BackupKeyStanza 清单中包装后的文件密钥
+type BackupKeyStanza struct {
+	Type         string `json:"type"`                    // scrypt 或 x25519
+	Salt         string `json:"salt,omitempty"`          // scrypt盐值
+	LogN         int    `json:"log_n,omitempty"`         // scrypt成本参数 N=2^log_n
+	R            int    `json:"r,omitempty"`             // scrypt块大小
+	P            int    `json:"p,omitempty"`             // scrypt并行度
+	Recipient    string `json:"recipient,omitempty"`     // x25519接收方公钥
+	EphemeralKey string `json:"ephemeral_key,omitempty"` // x25519临时公钥
+	WrappedKey   string `json:"wrapped_key"`             // 被包装的文件密钥
+}
+
+// BackupEncryption 备份加密配置，口令或接收方公钥用于加密，口令或私钥用于解密
+type BackupEncryption struct {
+	passphrase string
+	recipients []*ecdh.PublicKey
+	identities []*ecdh.PrivateKey
+}
+
+// NewBackupEncryption 创建备份加密配置，全部为空时表示不加密
+func NewBackupEncryption(passphrase string, recipients, identities []string) (*BackupEncryption, error) {
+	enc := &BackupEncryption{passphrase: passphrase}
+	for _, recipient := range recipients {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" {
+			continue
+		}
+		publicKey, err := ParseBackupRecipient(recipient)
+		if err != nil {
+			return nil, err
+		}
+		enc.recipients = append(enc.recipients, publicKey)
+	}
+	for _, identity := range identities {
+		privateKey, err := parseBackupIdentity(identity)
+		if err != nil {
+			return nil, err
+		}
+		enc.identities = append(enc.identities, privateKey)
+	}
+	return enc, nil
+}
+
+// LoadBackupEncryption 从环境变量、口令文件和私钥文件加载备份加密配置
+func LoadBackupEncryption(cfg *config.BackupEncryptionConfig) (*BackupEncryption, error) {
+	passphrase := ""
+	if cfg.PassphraseEnv != "" {
+		passphrase = os.Getenv(cfg.PassphraseEnv)
+	}
+	if passphrase == "" && cfg.PassphraseFile != "" {
+		data, err := os.ReadFile(cfg.PassphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup passphrase file: %w", err)
+		}
+		passphrase = strings.TrimRight(string(data), "\r\n")
+	}
+
+	var identities []string
+	for _, path := range cfg.IdentityFiles {
+		keys, err := readBackupIdentityFile(path)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, keys...)
+	}
+
+	return NewBackupEncryption(passphrase, cfg.Recipients, identities)
+}
+
+// readBackupIdentityFile 读取私钥文件，忽略空行和#开头的注释
+func readBackupIdentityFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup identity file: %w", err)
+	}
+	defer file.Close()
+
+	var identities []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identities = append(identities, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read backup identity file: %w", err)
+	}
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no identity found in %s", path)
+	}
+	return identities, nil
+}
+
+// GenerateBackupIdentity 生成X25519密钥对，返回私钥和对应的接收方公钥
+func GenerateBackupIdentity() (string, string, error) {
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate backup identity: %w", err)
+	}
+	return BackupIdentityPrefix + base64.RawURLEncoding.EncodeToString(privateKey.Bytes()),
+		formatBackupRecipient(privateKey.PublicKey()), nil
+}
+
+// ParseBackupRecipient 解析接收方公钥
+func ParseBackupRecipient(recipient string) (*ecdh.PublicKey, error) {
+	if !strings.HasPrefix(recipient, BackupRecipientPrefix) {
+		return nil, fmt.Errorf("invalid backup recipient: missing %s prefix", BackupRecipientPrefix)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(recipient, BackupRecipientPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup recipient: %w", err)
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup recipient: %w", err)
+	}
+	return publicKey, nil
+}
+
+// parseBackupIdentity 解析私钥
+func parseBackupIdentity(identity string) (*ecdh.PrivateKey, error) {
+	if !strings.HasPrefix(identity, BackupIdentityPrefix) {
+		return nil, fmt.Errorf("invalid backup identity: missing %s prefix", BackupIdentityPrefix)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(identity, BackupIdentityPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup identity: %w", err)
+	}
+	privateKey, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup identity: %w", err)
+	}
+	return privateKey, nil
+}
+
+// formatBackupRecipient 格式化接收方公钥
+func formatBackupRecipient(publicKey *ecdh.PublicKey) string {
+	return BackupRecipientPrefix + base64.RawURLEncoding.EncodeToString(publicKey.Bytes())
+}
+
+// CanEncrypt 是否配置了口令或接收方公钥
+func (e *BackupEncryption) CanEncrypt() bool {
+	return e.passphrase != "" || len(e.recipients) > 0
+}
+
+// CanDecrypt 是否配置了口令或私钥
+func (e *BackupEncryption) CanDecrypt() bool {
+	return e.passphrase != "" || len(e.identities) > 0
+}
+
+// NewFileKey 生成随机文件密钥，并为口令和每个接收方分别包装
+func (e *BackupEncryption) NewFileKey() ([]byte, []BackupKeyStanza, error) {
+	if !e.CanEncrypt() {
+		return nil, nil, errors.New("backup encryption not configured")
+	}
+
+	fileKey := make([]byte, backupFileKeySize)
+	if _, err := io.ReadFull(rand.Reader, fileKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate file key: %w", err)
+	}
+
+	var stanzas []BackupKeyStanza
+	if e.passphrase != "" {
+		stanza, err := wrapWithPassphrase(e.passphrase, fileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		stanzas = append(stanzas, stanza)
+	}
+	for _, recipient := range e.recipients {
+		stanza, err := wrapForRecipient(recipient, fileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		stanzas = append(stanzas, stanza)
+	}
+	return fileKey, stanzas, nil
+}
+
+// UnwrapFileKey 使用口令或私钥解出文件密钥
+func (e *BackupEncryption) UnwrapFileKey(stanzas []BackupKeyStanza) ([]byte, error) {
+	for _, stanza := range stanzas {
+		switch stanza.Type {
+		case BackupKeyScrypt:
+			if e.passphrase == "" {
+				continue
+			}
+			if fileKey, err := unwrapWithPassphrase(e.passphrase, stanza); err == nil {
+				return fileKey, nil
+			}
+		case BackupKeyX25519:
+			for _, identity := range e.identities {
+				if stanza.Recipient != formatBackupRecipient(identity.PublicKey()) {
+					continue
+				}
+				if fileKey, err := unwrapWithIdentity(identity, stanza); err == nil {
+					return fileKey, nil
+				}
+			}
+		}
+	}
+	return nil, ErrBackupKeyNotFound
+}
+
+// wrapWithPassphrase 使用scrypt从口令派生密钥包装文件密钥
+func wrapWithPassphrase(passphrase string, fileKey []byte) (BackupKeyStanza, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	wrappingKey, err := scrypt.Key([]byte(passphrase), salt, 1<<scryptLogN, scryptR, scryptP, backupFileKeySize)
+	if err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to derive key from passphrase: %w", err)
+	}
+	wrapped, err := seal(wrappingKey, fileKey, []byte(BackupKeyScrypt))
+	if err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to wrap file key: %w", err)
+	}
+	return BackupKeyStanza{
+		Type:       BackupKeyScrypt,
+		Salt:       base64.RawURLEncoding.EncodeToString(salt),
+		LogN:       scryptLogN,
+		R:          scryptR,
+		P:          scryptP,
+		WrappedKey: base64.RawURLEncoding.EncodeToString(wrapped),
+	}, nil
+}
+
+// unwrapWithPassphrase 使用口令解出文件密钥
+func unwrapWithPassphrase(passphrase string, stanza BackupKeyStanza) ([]byte, error) {
+	if stanza.LogN <= 0 || stanza.LogN > scryptMaxLogN || stanza.R <= 0 || stanza.P <= 0 {
+		return nil, errors.New("invalid scrypt parameters")
+	}
+	salt, err := base64.RawURLEncoding.DecodeString(stanza.Salt)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(stanza.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	wrappingKey, err := scrypt.Key([]byte(passphrase), salt, 1<<stanza.LogN, stanza.R, stanza.P, backupFileKeySize)
+	if err != nil {
+		return nil, err
+	}
+	return open(wrappingKey, wrapped, []byte(BackupKeyScrypt))
+}
+
+// wrapForRecipient 使用临时X25519密钥与接收方公钥协商出的密钥包装文件密钥
+func wrapForRecipient(recipient *ecdh.PublicKey, fileKey []byte) (BackupKeyStanza, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	sharedSecret, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+	wrappingKey, err := x25519WrappingKey(sharedSecret, ephemeral.PublicKey().Bytes(), recipient.Bytes())
+	if err != nil {
+		return BackupKeyStanza{}, err
+	}
+	wrapped, err := seal(wrappingKey, fileKey, []byte(BackupKeyX25519))
+	if err != nil {
+		return BackupKeyStanza{}, fmt.Errorf("failed to wrap file key: %w", err)
+	}
+	return BackupKeyStanza{
+		Type:         BackupKeyX25519,
+		Recipient:    formatBackupRecipient(recipient),
+		EphemeralKey: base64.RawURLEncoding.EncodeToString(ephemeral.PublicKey().Bytes()),
+		WrappedKey:   base64.RawURLEncoding.EncodeToString(wrapped),
+	}, nil
+}
+
+// unwrapWithIdentity 使用私钥解出文件密钥
+func unwrapWithIdentity(identity *ecdh.PrivateKey, stanza BackupKeyStanza) ([]byte, error) {
+	ephemeralRaw, err := base64.RawURLEncoding.DecodeString(stanza.EphemeralKey)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(ephemeralRaw)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(stanza.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := identity.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	wrappingKey, err := x25519WrappingKey(sharedSecret, ephemeralRaw, identity.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return open(wrappingKey, wrapped, []byte(BackupKeyX25519))
+}
+
+// x25519WrappingKey 从共享密钥派生包装密钥，绑定临时公钥和接收方公钥
+func x25519WrappingKey(sharedSecret, ephemeral, recipient []byte) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeral...), recipient...)
+	return deriveKey(sharedSecret, salt, "timelocker-backup x25519")
+}
+
+// DeriveBackupKey 从文件密钥派生用途独立的子密钥（表数据、清单MAC）
+func DeriveBackupKey(fileKey, salt []byte, info string) ([]byte, error) {
+	return deriveKey(fileKey, salt, "timelocker-backup "+info)
+}
+
+// deriveKey HKDF-SHA256派生32字节密钥
+func deriveKey(secret, salt []byte, info string) ([]byte, error) {
+	key := make([]byte, backupFileKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
+
+// BackupMAC 计算清单的HMAC-SHA256
+func BackupMAC(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// VerifyBackupMAC 校验清单的HMAC-SHA256
+func VerifyBackupMAC(key, data, expected []byte) bool {
+	return hmac.Equal(BackupMAC(key, data), expected)
+}
+
+// backupNonce 块nonce：11字节大端块序号 + 1字节末块标记
+func backupNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, backupNonceSize)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// backupEncryptWriter 分块加密写入器，Close时写入末块
+type backupEncryptWriter struct {
+	w       io.Writer
+	key     []byte
+	buf     []byte
+	counter uint64
+	closed  bool
+}
+
+// NewBackupEncryptWriter 创建分块加密写入器，key需为每个数据流独立派生
+func NewBackupEncryptWriter(w io.Writer, key []byte) io.WriteCloser {
+	return &backupEncryptWriter{w: w, key: key, buf: make([]byte, 0, backupChunkSize)}
+}
+
+func (e *backupEncryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed backup encrypt writer")
+	}
+	written := 0
+	for len(p) > 0 {
+		// 缓冲区已满且仍有数据时才写出，保证最后一块在Close时以末块标记写入
+		if len(e.buf) == backupChunkSize {
+			if err := e.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(e.buf[len(e.buf):backupChunkSize], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close 写入末块，不关闭底层写入器
+func (e *backupEncryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+func (e *backupEncryptWriter) flush(last bool) error {
+	aead, err := newGCM(e.key)
+	if err != nil {
+		return err
+	}
+	ciphertext := aead.Seal(nil, backupNonce(e.counter, last), e.buf, nil)
+	if _, err := e.w.Write(ciphertext); err != nil {
+		return err
+	}
+	e.counter++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// backupDecryptReader 分块解密读取器，任何块认证失败、缺少末块或末块后有多余数据都返回ErrBackupTampered
+type backupDecryptReader struct {
+	r       io.Reader
+	key     []byte
+	chunk   []byte // 待解密的密文，多读1字节用于判断是否为末块
+	plain   []byte
+	counter uint64
+	done    bool
+	err     error
+}
+
+// NewBackupDecryptReader 创建分块解密读取器
+func NewBackupDecryptReader(r io.Reader, key []byte) io.Reader {
+	return &backupDecryptReader{r: r, key: key}
+}
+
+func (d *backupDecryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.err = d.readChunk()
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *backupDecryptReader) readChunk() error {
+	encryptedSize := backupChunkSize + backupTagSize
+	if d.chunk == nil {
+		d.chunk = make([]byte, 0, encryptedSize+1)
+	}
+
+	// 沿用上一块多读的1字节
+	buf := d.chunk[:cap(d.chunk)]
+	n, err := io.ReadFull(d.r, buf[len(d.chunk):])
+	n += len(d.chunk)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	last := n <= encryptedSize
+	size := n
+	if !last {
+		size = encryptedSize
+	}
+	if size < backupTagSize {
+		return ErrBackupTampered
+	}
+
+	aead, err := newGCM(d.key)
+	if err != nil {
+		return err
+	}
+	plain, err := aead.Open(nil, backupNonce(d.counter, last), buf[:size], nil)
+	if err != nil {
+		return ErrBackupTampered
+	}
+	if !last && len(plain) != backupChunkSize {
+		return ErrBackupTampered
+	}
+	d.plain = plain
+	d.counter++
+
+	if last {
+		d.done = true
+		d.chunk = d.chunk[:0]
+	} else {
+		d.chunk = append(d.chunk[:0], buf[encryptedSize])
+	}
+	return nil
+}
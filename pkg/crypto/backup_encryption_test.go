@@ -0,0 +1,269 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+)
+
+// encryptBackupStream 使用key加密data并返回密文
+func encryptBackupStream(t *testing.T, key, data []byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w := NewBackupEncryptWriter(&out, key)
+	// 分多次写入以覆盖跨块缓冲
+	for len(data) > 0 {
+		n := 1000
+		if n > len(data) {
+			n = len(data)
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return out.Bytes()
+}
+
+func decryptBackupStream(key, ciphertext []byte) ([]byte, error) {
+	return io.ReadAll(NewBackupDecryptReader(bytes.NewReader(ciphertext), key))
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+	return b
+}
+
+func TestBackupStreamRoundTrip(t *testing.T) {
+	key := randomBytes(t, backupFileKeySize)
+	sizes := []struct {
+		name string
+		size int
+	}{
+		{"empty", 0},
+		{"one byte", 1},
+		{"chunk minus one", backupChunkSize - 1},
+		{"exact chunk", backupChunkSize},
+		{"chunk plus one", backupChunkSize + 1},
+		{"exact multiple chunks", 3 * backupChunkSize},
+		{"multiple chunks", 3*backupChunkSize + 17},
+	}
+
+	for _, tt := range sizes {
+		t.Run(tt.name, func(t *testing.T) {
+			data := randomBytes(t, tt.size)
+			ciphertext := encryptBackupStream(t, key, data)
+
+			chunks := tt.size/backupChunkSize + 1
+			if tt.size > 0 && tt.size%backupChunkSize == 0 {
+				chunks--
+			}
+			if want := tt.size + chunks*backupTagSize; len(ciphertext) != want {
+				t.Fatalf("ciphertext size %d, want %d", len(ciphertext), want)
+			}
+
+			got, err := decryptBackupStream(key, ciphertext)
+			if err != nil {
+				t.Fatalf("decrypt: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("round trip mismatch")
+			}
+		})
+	}
+}
+
+func TestBackupStreamDetectsTampering(t *testing.T) {
+	key := randomBytes(t, backupFileKeySize)
+	data := randomBytes(t, 3*backupChunkSize+100)
+	ciphertext := encryptBackupStream(t, key, data)
+	encryptedChunk := backupChunkSize + backupTagSize
+
+	modify := func(f func(c []byte) []byte) []byte {
+		return f(append([]byte(nil), ciphertext...))
+	}
+	chunk := func(c []byte, i int) []byte {
+		end := (i + 1) * encryptedChunk
+		if end > len(c) {
+			end = len(c)
+		}
+		return append([]byte(nil), c[i*encryptedChunk:end]...)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		key        []byte
+	}{
+		{"flipped byte in first chunk", modify(func(c []byte) []byte { c[10] ^= 0x01; return c }), key},
+		{"flipped byte in middle chunk", modify(func(c []byte) []byte { c[encryptedChunk+10] ^= 0x01; return c }), key},
+		{"flipped tag of final chunk", modify(func(c []byte) []byte { c[len(c)-1] ^= 0x01; return c }), key},
+		{"truncated inside final chunk", ciphertext[:len(ciphertext)-10], key},
+		{"truncated inside middle chunk", ciphertext[:encryptedChunk+100], key},
+		{"missing final chunk", ciphertext[:3*encryptedChunk], key},
+		{"only first chunk", ciphertext[:encryptedChunk], key},
+		{"shorter than a tag", ciphertext[:backupTagSize-1], key},
+		{"empty", nil, key},
+		{"reordered chunks", modify(func(c []byte) []byte {
+			first, second := chunk(c, 0), chunk(c, 1)
+			copy(c, second)
+			copy(c[encryptedChunk:], first)
+			return c
+		}), key},
+		{"duplicated chunk", modify(func(c []byte) []byte {
+			return append(append(chunk(c, 0), chunk(c, 0)...), c[encryptedChunk:]...)
+		}), key},
+		{"trailing data after final chunk", append(append([]byte(nil), ciphertext...), 0x00), key},
+		// 每个数据流使用独立派生的密钥，其他流的块无法通过认证
+		{"final chunk from another stream", modify(func(c []byte) []byte {
+			other := encryptBackupStream(t, randomBytes(t, backupFileKeySize), randomBytes(t, 3*backupChunkSize+100))
+			return append(c[:3*encryptedChunk], other[3*encryptedChunk:]...)
+		}), key},
+		{"wrong key", ciphertext, randomBytes(t, backupFileKeySize)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decryptBackupStream(tt.key, tt.ciphertext)
+			if !errors.Is(err, ErrBackupTampered) {
+				t.Fatalf("expected ErrBackupTampered, got %v (%d bytes)", err, len(got))
+			}
+		})
+	}
+}
+
+func TestBackupFileKeyRoundTrip(t *testing.T) {
+	identity, recipient, err := GenerateBackupIdentity()
+	if err != nil {
+		t.Fatalf("generate identity: %v", err)
+	}
+	otherIdentity, _, err := GenerateBackupIdentity()
+	if err != nil {
+		t.Fatalf("generate identity: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		encrypt *BackupEncryption
+		decrypt *BackupEncryption
+		wantErr bool
+	}{
+		{"passphrase", mustBackupEncryption(t, "correct horse", nil, nil), mustBackupEncryption(t, "correct horse", nil, nil), false},
+		{"recipient", mustBackupEncryption(t, "", []string{recipient}, nil), mustBackupEncryption(t, "", nil, []string{identity}), false},
+		{"passphrase and recipient decrypted by identity", mustBackupEncryption(t, "correct horse", []string{recipient}, nil), mustBackupEncryption(t, "", nil, []string{identity}), false},
+		{"passphrase and recipient decrypted by passphrase", mustBackupEncryption(t, "correct horse", []string{recipient}, nil), mustBackupEncryption(t, "correct horse", nil, nil), false},
+		{"one of several identities", mustBackupEncryption(t, "", []string{recipient}, nil), mustBackupEncryption(t, "", nil, []string{otherIdentity, identity}), false},
+		{"wrong passphrase", mustBackupEncryption(t, "correct horse", nil, nil), mustBackupEncryption(t, "battery staple", nil, nil), true},
+		{"wrong identity", mustBackupEncryption(t, "", []string{recipient}, nil), mustBackupEncryption(t, "", nil, []string{otherIdentity}), true},
+		{"identity for passphrase backup", mustBackupEncryption(t, "correct horse", nil, nil), mustBackupEncryption(t, "", nil, []string{identity}), true},
+		{"passphrase for recipient backup", mustBackupEncryption(t, "", []string{recipient}, nil), mustBackupEncryption(t, "correct horse", nil, nil), true},
+		{"no key", mustBackupEncryption(t, "correct horse", nil, nil), mustBackupEncryption(t, "", nil, nil), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileKey, stanzas, err := tt.encrypt.NewFileKey()
+			if err != nil {
+				t.Fatalf("new file key: %v", err)
+			}
+			got, err := tt.decrypt.UnwrapFileKey(stanzas)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBackupKeyNotFound) {
+					t.Fatalf("expected ErrBackupKeyNotFound, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unwrap: %v", err)
+			}
+			if !bytes.Equal(got, fileKey) {
+				t.Fatalf("unwrapped file key mismatch")
+			}
+		})
+	}
+}
+
+func TestBackupFileKeyRejectsTamperedStanza(t *testing.T) {
+	enc := mustBackupEncryption(t, "correct horse", nil, nil)
+	_, stanzas, err := enc.NewFileKey()
+	if err != nil {
+		t.Fatalf("new file key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *BackupKeyStanza)
+	}{
+		{"wrapped key", func(s *BackupKeyStanza) {
+			s.WrappedKey = base64Flip(t, s.WrappedKey)
+		}},
+		{"salt", func(s *BackupKeyStanza) {
+			s.Salt = base64Flip(t, s.Salt)
+		}},
+		{"excessive scrypt cost", func(s *BackupKeyStanza) { s.LogN = scryptMaxLogN + 1 }},
+		{"zero scrypt cost", func(s *BackupKeyStanza) { s.LogN = 0 }},
+		{"unknown type", func(s *BackupKeyStanza) { s.Type = "plain" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stanza := stanzas[0]
+			tt.modify(&stanza)
+			if _, err := enc.UnwrapFileKey([]BackupKeyStanza{stanza}); !errors.Is(err, ErrBackupKeyNotFound) {
+				t.Fatalf("expected ErrBackupKeyNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewBackupEncryptionRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipients []string
+		identities []string
+	}{
+		{"recipient without prefix", []string{"AAAA"}, nil},
+		{"recipient with bad encoding", []string{BackupRecipientPrefix + "!!!"}, nil},
+		{"recipient with wrong length", []string{BackupRecipientPrefix + "AAAA"}, nil},
+		{"identity without prefix", nil, []string{"AAAA"}},
+		{"identity with wrong length", nil, []string{BackupIdentityPrefix + "AAAA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBackupEncryption("", tt.recipients, tt.identities); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func mustBackupEncryption(t *testing.T, passphrase string, recipients, identities []string) *BackupEncryption {
+	t.Helper()
+	enc, err := NewBackupEncryption(passphrase, recipients, identities)
+	if err != nil {
+		t.Fatalf("new backup encryption: %v", err)
+	}
+	return enc
+}
+
+// base64Flip 翻转base64编码值中第一个字节的最低位
+func base64Flip(t *testing.T, value string) string {
+	t.Helper()
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	raw[0] ^= 0x01
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
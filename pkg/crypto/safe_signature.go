@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EIP1271MagicValue isValidSignature(bytes32,bytes) 校验通过时的返回值
+var EIP1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// safeMessageTypeHash keccak256("SafeMessage(bytes message)")
+var safeMessageTypeHash = crypto.Keccak256Hash([]byte("SafeMessage(bytes message)"))
+
+// SafeMessageHash 计算Safe owner对消息签名时使用的EIP-712哈希
+// 与 CompatibilityFallbackHandler.isValidSignature(bytes32,bytes) 一致：message = abi.encode(messageHash)
+func SafeMessageHash(domainSeparator common.Hash, messageHash common.Hash) common.Hash {
+	structHash := crypto.Keccak256Hash(safeMessageTypeHash.Bytes(), crypto.Keccak256(messageHash.Bytes()))
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// RecoverSafeSigners 从Safe拼接的owner签名中恢复签名者地址
+// 支持ECDSA签名（v=27/28）和eth_sign签名（v=31/32），签名者必须按地址升序排列且不重复
+func RecoverSafeSigners(safeHash common.Hash, signatures []byte) ([]common.Address, error) {
+	if len(signatures) == 0 || len(signatures)%65 != 0 {
+		return nil, errors.New("safe signatures must be a non-empty multiple of 65 bytes")
+	}
+
+	signers := make([]common.Address, 0, len(signatures)/65)
+	var lastSigner common.Address
+	for i := 0; i < len(signatures); i += 65 {
+		sig := make([]byte, 65)
+		copy(sig, signatures[i:i+65])
+
+		hash := safeHash.Bytes()
+		v := sig[64]
+		switch {
+		case v == 27 || v == 28:
+			sig[64] = v - 27
+		case v == 31 || v == 32:
+			// eth_sign签名对带以太坊前缀的safeHash签名
+			hash = accounts.TextHash(safeHash.Bytes())
+			sig[64] = v - 31
+		default:
+			// v=0为合约签名，v=1为链上approveHash，离线校验不支持
+			return nil, fmt.Errorf("unsupported safe signature type v=%d", v)
+		}
+
+		publicKey, err := crypto.SigToPub(hash, sig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover safe signer: %w", err)
+		}
+		signer := crypto.PubkeyToAddress(*publicKey)
+		if bytes.Compare(signer.Bytes(), lastSigner.Bytes()) <= 0 {
+			return nil, errors.New("safe signers must be unique and sorted in ascending order")
+		}
+		lastSigner = signer
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
@@ -0,0 +1,180 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// safeTestOwner 测试用的Safe owner
+type safeTestOwner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// safeTestOwners 返回两个固定私钥的owner，按地址升序排列
+func safeTestOwners(t *testing.T) (safeTestOwner, safeTestOwner) {
+	t.Helper()
+	var owners []safeTestOwner
+	for _, hex := range []string{
+		"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+		"8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f",
+	} {
+		key, err := crypto.HexToECDSA(hex)
+		if err != nil {
+			t.Fatalf("load key: %v", err)
+		}
+		owners = append(owners, safeTestOwner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)})
+	}
+	if bytes.Compare(owners[0].address.Bytes(), owners[1].address.Bytes()) > 0 {
+		owners[0], owners[1] = owners[1], owners[0]
+	}
+	return owners[0], owners[1]
+}
+
+// signSafe 生成Safe格式的ECDSA签名（v=27/28），ethSign为true时生成eth_sign签名（v=31/32）
+func signSafe(t *testing.T, owner safeTestOwner, safeHash common.Hash, ethSign bool) []byte {
+	t.Helper()
+	hash := safeHash.Bytes()
+	offset := byte(27)
+	if ethSign {
+		hash = accounts.TextHash(safeHash.Bytes())
+		offset = 31
+	}
+	sig, err := crypto.Sign(hash, owner.key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	sig[64] += offset
+	return sig
+}
+
+func concatSignatures(signatures ...[]byte) []byte {
+	var out []byte
+	for _, sig := range signatures {
+		out = append(out, sig...)
+	}
+	return out
+}
+
+func TestRecoverSafeSigners(t *testing.T) {
+	low, high := safeTestOwners(t)
+	safeHash := SafeMessageHash(common.HexToHash("0x01"), crypto.Keccak256Hash([]byte("timelocker")))
+
+	withV := func(sig []byte, v byte) []byte {
+		out := append([]byte(nil), sig...)
+		out[64] = v
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		signatures []byte
+		want       []common.Address
+		wantErr    bool
+	}{
+		{
+			name:       "single ecdsa",
+			signatures: signSafe(t, low, safeHash, false),
+			want:       []common.Address{low.address},
+		},
+		{
+			name:       "single eth_sign",
+			signatures: signSafe(t, high, safeHash, true),
+			want:       []common.Address{high.address},
+		},
+		{
+			name:       "sorted owners",
+			signatures: concatSignatures(signSafe(t, low, safeHash, false), signSafe(t, high, safeHash, true)),
+			want:       []common.Address{low.address, high.address},
+		},
+		{
+			name:       "unsorted owners",
+			signatures: concatSignatures(signSafe(t, high, safeHash, false), signSafe(t, low, safeHash, false)),
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate owner",
+			signatures: concatSignatures(signSafe(t, low, safeHash, false), signSafe(t, low, safeHash, false)),
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate owner with different signature types",
+			signatures: concatSignatures(signSafe(t, low, safeHash, false), signSafe(t, low, safeHash, true)),
+			wantErr:    true,
+		},
+		{
+			name:       "empty",
+			signatures: nil,
+			wantErr:    true,
+		},
+		{
+			name:       "truncated",
+			signatures: signSafe(t, low, safeHash, false)[:64],
+			wantErr:    true,
+		},
+		{
+			name:       "trailing bytes",
+			signatures: append(signSafe(t, low, safeHash, false), 0x00),
+			wantErr:    true,
+		},
+		{
+			name:       "contract signature",
+			signatures: withV(signSafe(t, low, safeHash, false), 0),
+			wantErr:    true,
+		},
+		{
+			name:       "approved hash",
+			signatures: withV(signSafe(t, low, safeHash, false), 1),
+			wantErr:    true,
+		},
+		{
+			name:       "invalid v",
+			signatures: withV(signSafe(t, low, safeHash, false), 29),
+			wantErr:    true,
+		},
+		{
+			name:       "zero signature",
+			signatures: withV(make([]byte, 65), 27),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecoverSafeSigners(safeHash, tt.signatures)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got signers %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("recover: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d signers, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("signer %d: got %s, want %s", i, got[i].Hex(), tt.want[i].Hex())
+				}
+			}
+		})
+	}
+}
+
+func TestRecoverSafeSignersWrongHash(t *testing.T) {
+	low, _ := safeTestOwners(t)
+	safeHash := SafeMessageHash(common.HexToHash("0x01"), crypto.Keccak256Hash([]byte("timelocker")))
+	otherHash := SafeMessageHash(common.HexToHash("0x02"), crypto.Keccak256Hash([]byte("timelocker")))
+
+	got, err := RecoverSafeSigners(otherHash, signSafe(t, low, safeHash, false))
+	if err == nil && len(got) == 1 && got[0] == low.address {
+		t.Fatalf("signature over a different hash recovered the owner")
+	}
+}
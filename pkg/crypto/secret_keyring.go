@@ -0,0 +1,283 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"timelocker-backend/internal/config"
+)
+
+// 加密值格式: enc:v1:<key_id>:<被主密钥加密的数据密钥>:<被数据密钥加密的密文>
+const (
+	secretPrefix    = "enc:v1:"
+	dataKeySize     = 32
+	masterKeySize   = 32
+	secretPartCount = 5
+)
+
+var (
+	ErrSecretKeyNotFound = errors.New("secret key not found")
+	ErrInvalidSecret     = errors.New("invalid encrypted secret")
+)
+
+// SecretKeyring 通知密钥的信封加密密钥环
+// 每个值使用随机数据密钥加密，数据密钥再由当前主密钥加密，主密钥通过key_id区分以支持轮换
+type SecretKeyring struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// NewSecretKeyring 创建密钥环，keys为空时表示未启用加密
+func NewSecretKeyring(activeKeyID string, keys map[string][]byte) (*SecretKeyring, error) {
+	for keyID, key := range keys {
+		if keyID == "" || strings.Contains(keyID, ":") {
+			return nil, fmt.Errorf("invalid secret key id: %q", keyID)
+		}
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("secret key %s must be %d bytes", keyID, masterKeySize)
+		}
+	}
+	if len(keys) > 0 {
+		if _, ok := keys[activeKeyID]; !ok {
+			return nil, fmt.Errorf("active secret key %q not found", activeKeyID)
+		}
+	}
+	return &SecretKeyring{activeKeyID: activeKeyID, keys: keys}, nil
+}
+
+// LoadSecretKeyring 从密钥文件和环境变量加载主密钥
+// 每个密钥格式为 key_id:base64(32字节)，文件中每行一个，环境变量中以逗号分隔
+func LoadSecretKeyring(cfg *config.SecretsConfig) (*SecretKeyring, error) {
+	keys := make(map[string][]byte)
+
+	if cfg.KeyFile != "" {
+		file, err := os.Open(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open secret key file: %w", err)
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if err := parseSecretKey(line, keys); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read secret key file: %w", err)
+		}
+	}
+
+	if cfg.KeyEnv != "" {
+		for _, entry := range strings.Split(os.Getenv(cfg.KeyEnv), ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if err := parseSecretKey(entry, keys); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return NewSecretKeyring(cfg.ActiveKeyID, keys)
+}
+
+// parseSecretKey 解析 key_id:base64 格式的主密钥
+func parseSecretKey(entry string, keys map[string][]byte) error {
+	keyID, encoded, ok := strings.Cut(entry, ":")
+	if !ok {
+		return errors.New("invalid secret key entry, expected key_id:base64")
+	}
+	keyID = strings.TrimSpace(keyID)
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return fmt.Errorf("invalid secret key %s: %w", keyID, err)
+	}
+	if _, exists := keys[keyID]; exists {
+		return fmt.Errorf("duplicate secret key id: %s", keyID)
+	}
+	keys[keyID] = key
+	return nil
+}
+
+// Enabled 是否配置了主密钥
+func (k *SecretKeyring) Enabled() bool {
+	return k != nil && len(k.keys) > 0
+}
+
+// ActiveKeyID 当前用于加密的主密钥ID
+func (k *SecretKeyring) ActiveKeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.activeKeyID
+}
+
+// IsEncryptedSecret 判断值是否为加密格式
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, secretPrefix)
+}
+
+// Encrypt 使用当前主密钥加密，空值或已加密的值原样返回，未启用加密时返回明文
+func (k *SecretKeyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" || IsEncryptedSecret(plaintext) || !k.Enabled() {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrappedKey, err := seal(k.keys[k.activeKeyID], dataKey, []byte(k.activeKeyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	ciphertext, err := seal(dataKey, []byte(plaintext), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	return secretPrefix + k.activeKeyID + ":" +
+		base64.RawURLEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt 解密加密值，非加密格式的值（历史明文数据）原样返回
+func (k *SecretKeyring) Decrypt(value string) (string, error) {
+	if !IsEncryptedSecret(value) {
+		return value, nil
+	}
+
+	keyID, wrappedKey, ciphertext, err := splitSecret(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := k.unwrapDataKey(keyID, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRekey 判断值是否需要使用当前主密钥重新加密（明文或使用旧密钥加密）
+func (k *SecretKeyring) NeedsRekey(value string) bool {
+	if value == "" || !k.Enabled() {
+		return false
+	}
+	if !IsEncryptedSecret(value) {
+		return true
+	}
+	keyID, _, _, err := splitSecret(value)
+	return err == nil && keyID != k.activeKeyID
+}
+
+// Rekey 使用当前主密钥重新加密：明文直接加密，旧密钥加密的值只重新包装数据密钥
+func (k *SecretKeyring) Rekey(value string) (string, error) {
+	if !k.NeedsRekey(value) {
+		return value, nil
+	}
+	if !IsEncryptedSecret(value) {
+		return k.Encrypt(value)
+	}
+
+	keyID, wrappedKey, ciphertext, err := splitSecret(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := k.unwrapDataKey(keyID, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+	rewrapped, err := seal(k.keys[k.activeKeyID], dataKey, []byte(k.activeKeyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return secretPrefix + k.activeKeyID + ":" +
+		base64.RawURLEncoding.EncodeToString(rewrapped) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// unwrapDataKey 使用对应的主密钥解密数据密钥
+func (k *SecretKeyring) unwrapDataKey(keyID string, wrappedKey []byte) ([]byte, error) {
+	if k == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSecretKeyNotFound, keyID)
+	}
+	masterKey, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretKeyNotFound, keyID)
+	}
+	dataKey, err := open(masterKey, wrappedKey, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+	return dataKey, nil
+}
+
+// splitSecret 拆分加密值为 key_id、加密的数据密钥和密文
+func splitSecret(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != secretPartCount {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	wrappedKey, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	return parts[2], wrappedKey, ciphertext, nil
+}
+
+// seal 使用AES-256-GCM加密，输出为 nonce||ciphertext
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open 解密 seal 的输出
+func open(key, data, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
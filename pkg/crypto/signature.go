@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -122,9 +124,12 @@ func NormalizeAddress(address string) string {
 	return strings.ToLower(address)
 }
 
-// GenerateNonce 生成用于签名的随机nonce
+// GenerateNonce 生成用于签名的随机nonce（符合EIP-4361要求的字母数字格式）
 func GenerateNonce() string {
-	// 基于时间戳给一个随机的字符串
-	random := fmt.Sprintf("%d", time.Now().UnixNano())
-	return fmt.Sprintf("TimeLocker Login Nonce: %d", crypto.Keccak256Hash([]byte(fmt.Sprintf("%d", crypto.Keccak256Hash([]byte(random)).Big().Int64()))).Big().Int64())
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		// 系统随机源不可用时退化为基于时间戳的哈希
+		return crypto.Keccak256Hash([]byte(fmt.Sprintf("%d", time.Now().UnixNano()))).Hex()[2:34]
+	}
+	return hex.EncodeToString(random)
 }
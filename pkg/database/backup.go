@@ -1,16 +1,21 @@
 package database
 
 import (
+	"archive/tar"
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/database/migrations"
 	"timelocker-backend/pkg/logger"
 
 	"gorm.io/gorm"
@@ -18,15 +23,19 @@ import (
 
 // BackupManager 备份管理器
 type BackupManager struct {
-	db *gorm.DB
+	db         *gorm.DB
+	keyring    *crypto.SecretKeyring
+	encryption *crypto.BackupEncryption
+	storage    BackupStorage
 }
 
-// NewBackupManager 创建备份管理器
-func NewBackupManager(db *gorm.DB) *BackupManager {
-	return &BackupManager{db: db}
+// NewBackupManager 创建备份管理器，keyring用于保证备份中的通知密钥为加密值，encryption用于加密和解密整个备份
+// storage为备份上传的远程存储，为nil时备份只保存在本地目录
+func NewBackupManager(db *gorm.DB, keyring *crypto.SecretKeyring, encryption *crypto.BackupEncryption, storage BackupStorage) *BackupManager {
+	return &BackupManager{db: db, keyring: keyring, encryption: encryption, storage: storage}
 }
 
-// BackupData 备份数据结构
+// BackupData 旧版单文件JSON备份数据结构，新备份使用归档格式，此结构仅用于读取旧备份
 type BackupData struct {
 	Version   string    `json:"version"`
 	Timestamp time.Time `json:"timestamp"`
@@ -62,446 +71,488 @@ type BackupData struct {
 	Sponsors []map[string]interface{} `json:"sponsors"`
 }
 
-// CreateBackup 创建完整数据备份
-func (bm *BackupManager) CreateBackup(backupPath string) error {
-	ctx := context.Background()
-	logger.Info("Starting database backup creation", "path", backupPath)
-
-	// 确保备份目录存在
-	if err := os.MkdirAll(filepath.Dir(backupPath), 0755); err != nil {
-		logger.Error("Failed to create backup directory", err)
-		return fmt.Errorf("failed to create backup directory: %w", err)
-	}
-
-	backup := BackupData{
-		Version:   "2.0.0", // 升级版本号
-		Timestamp: time.Now(),
-	}
-
-	// === 用户相关表备份 ===
-	// 备份用户数据
-	if err := bm.backupTable(ctx, "users", &backup.Users); err != nil {
-		return fmt.Errorf("failed to backup users: %w", err)
-	}
-
-	// 备份支持的区块链数据
-	if err := bm.backupTable(ctx, "support_chains", &backup.SupportChains); err != nil {
-		return fmt.Errorf("failed to backup support_chains: %w", err)
-	}
-
-	// 备份邮箱数据
-	if err := bm.backupTable(ctx, "emails", &backup.Emails); err != nil {
-		return fmt.Errorf("failed to backup emails: %w", err)
+// legacyTableRecords 返回旧版备份中各表的记录
+func legacyTableRecords(backup *BackupData) map[string][]map[string]interface{} {
+	return map[string][]map[string]interface{}{
+		"users":                              backup.Users,
+		"emails":                             backup.Emails,
+		"user_emails":                        backup.UserEmails,
+		"email_verification_codes":           backup.EmailVerificationCodes,
+		"auth_nonces":                        backup.AuthNonces,
+		"safe_wallets":                       backup.SafeWallets,
+		"support_chains":                     backup.SupportChains,
+		"abis":                               backup.ABIs,
+		"compound_timelocks":                 backup.CompoundTimelocks,
+		"openzeppelin_timelocks":             backup.OpenzeppelinTimelocks,
+		"compound_timelock_transactions":     backup.CompoundTimelockTransactions,
+		"openzeppelin_timelock_transactions": backup.OpenzeppelinTimelockTransactions,
+		"timelock_transaction_flows":         backup.TimelockTransactionFlows,
+		"email_send_logs":                    backup.EmailSendLogs,
+		"telegram_configs":                   backup.TelegramConfigs,
+		"lark_configs":                       backup.LarkConfigs,
+		"feishu_configs":                     backup.FeishuConfigs,
+		"notification_logs":                  backup.NotificationLogs,
+		"block_scan_progress":                backup.BlockScanProgress,
+		"sponsors":                           backup.Sponsors,
 	}
+}
 
-	// 备份用户邮箱关联数据
-	if err := bm.backupTable(ctx, "user_emails", &backup.UserEmails); err != nil {
-		return fmt.Errorf("failed to backup user_emails: %w", err)
+// readLegacyBackup 读取旧版JSON备份文件
+func readLegacyBackup(backupPath string) (*BackupData, error) {
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup file: %w", err)
 	}
+	defer file.Close()
 
-	// 备份邮箱验证码（可选，通常验证码是临时的）
-	if err := bm.backupTable(ctx, "email_verification_codes", &backup.EmailVerificationCodes); err != nil {
-		return fmt.Errorf("failed to backup email_verification_codes: %w", err)
+	var backup BackupData
+	if err := json.NewDecoder(file).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("failed to decode backup data: %w", err)
 	}
+	return &backup, nil
+}
 
-	// 备份认证nonce（可选，通常nonce是临时的）
-	if err := bm.backupTable(ctx, "auth_nonces", &backup.AuthNonces); err != nil {
-		return fmt.Errorf("failed to backup auth_nonces: %w", err)
-	}
+// BackupOptions 备份选项
+type BackupOptions struct {
+	Incremental bool           // 增量备份：以备份目录中最近一次备份的水位为起点
+	Selection   TableSelection // 要备份的表，默认全部
+}
 
-	// === Safe钱包相关表备份 ===
-	// 备份Safe钱包数据
-	if err := bm.backupTable(ctx, "safe_wallets", &backup.SafeWallets); err != nil {
-		return fmt.Errorf("failed to backup safe_wallets: %w", err)
-	}
+// incrementalOverlap 增量备份时间水位的回溯量，覆盖快照开始时仍未提交的长事务写入的行
+const incrementalOverlap = 5 * time.Minute
 
-	// === ABI相关表备份 ===
-	// 备份用户自定义ABI（不包含系统共享ABI）
-	if err := bm.backupTable(ctx, "abis", &backup.ABIs); err != nil {
-		return fmt.Errorf("failed to backup abis: %w", err)
-	}
+// CreateBackup 创建数据备份
+// 备份为tar归档，每张表按主键游标分页读取并写成gzip压缩的NDJSON文件，末尾附带记录行数和校验和的清单
+// 配置了备份口令或接收方公钥时，表数据使用随机文件密钥加密，清单保持明文并附带HMAC
+// 所有表在同一个可重复读快照中读取，完成后在备份目录的catalog.json中记录归档和各表水位，配置了远程存储时再上传
+// 返回目录中记录的归档信息（ID、大小、SHA-256等）
+func (bm *BackupManager) CreateBackup(ctx context.Context, backupPath string, options BackupOptions) (*BackupCatalogEntry, error) {
+	logger.Info("Starting database backup creation", "path", backupPath, "incremental", options.Incremental)
 
-	// === Timelock合约相关表备份 ===
-	// 备份Compound timelock合约
-	if err := bm.backupTable(ctx, "compound_timelocks", &backup.CompoundTimelocks); err != nil {
-		return fmt.Errorf("failed to backup compound_timelocks: %w", err)
+	tables, err := options.Selection.resolve()
+	if err != nil {
+		return nil, err
 	}
 
-	// 备份OpenZeppelin timelock合约
-	if err := bm.backupTable(ctx, "openzeppelin_timelocks", &backup.OpenzeppelinTimelocks); err != nil {
-		return fmt.Errorf("failed to backup openzeppelin_timelocks: %w", err)
+	// 确保备份目录存在
+	backupDir := filepath.Dir(backupPath)
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		logger.Error("Failed to create backup directory", err)
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	// === 交易记录相关表备份 ===
-	// 备份Compound Timelock交易记录
-	if err := bm.backupTable(ctx, "compound_timelock_transactions", &backup.CompoundTimelockTransactions); err != nil {
-		return fmt.Errorf("failed to backup compound_timelock_transactions: %w", err)
+	catalog, err := LoadBackupCatalog(backupDir)
+	if err != nil {
+		return nil, err
 	}
 
-	// 备份OpenZeppelin Timelock交易记录
-	if err := bm.backupTable(ctx, "openzeppelin_timelock_transactions", &backup.OpenzeppelinTimelockTransactions); err != nil {
-		return fmt.Errorf("failed to backup openzeppelin_timelock_transactions: %w", err)
+	if !bm.keyring.Enabled() {
+		logger.Warn("Secret encryption key not configured, notification secrets in backup are not encrypted")
 	}
 
-	// 备份Timelock交易流程记录
-	if err := bm.backupTable(ctx, "timelock_transaction_flows", &backup.TimelockTransactionFlows); err != nil {
-		return fmt.Errorf("failed to backup timelock_transaction_flows: %w", err)
+	schemaVersion, err := migrations.CurrentVersion(ctx, bm.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database schema version: %w", err)
 	}
 
-	// === 邮件通知相关表备份 ===
-	// 备份邮件发送记录
-	if err := bm.backupTable(ctx, "email_send_logs", &backup.EmailSendLogs); err != nil {
-		return fmt.Errorf("failed to backup email_send_logs: %w", err)
+	now := time.Now()
+	manifest := BackupManifest{
+		Format:        backupArchiveFormat,
+		FormatVersion: backupFormatVersion,
+		Version:       backupDataVersion,
+		SchemaVersion: schemaVersion,
+		ID:            newBackupID(now),
+		Type:          BackupTypeFull,
+		Timestamp:     now,
+		Compression:   backupCompression,
 	}
 
-	// === 其他通知渠道配置表备份 ===
-	// 备份Telegram配置
-	if err := bm.backupTable(ctx, "telegram_configs", &backup.TelegramConfigs); err != nil {
-		return fmt.Errorf("failed to backup telegram_configs: %w", err)
+	var parent *BackupCatalogEntry
+	if options.Incremental {
+		if parent = catalog.Latest(); parent == nil {
+			return nil, fmt.Errorf("no previous backup in %s, create a full backup first", filepath.Join(backupDir, BackupCatalogName))
+		}
+		manifest.Type = BackupTypeIncremental
+		manifest.ParentID = parent.ID
+		logger.Info("Creating incremental backup", "parent_id", parent.ID)
 	}
 
-	// 备份Lark配置
-	if err := bm.backupTable(ctx, "lark_configs", &backup.LarkConfigs); err != nil {
-		return fmt.Errorf("failed to backup lark_configs: %w", err)
+	var fileKey []byte
+	if bm.encryption.CanEncrypt() {
+		key, stanzas, err := bm.encryption.NewFileKey()
+		if err != nil {
+			logger.Error("Failed to create backup file key", err)
+			return nil, fmt.Errorf("failed to create backup file key: %w", err)
+		}
+		fileKey = key
+		manifest.Encryption = &BackupEncryptionInfo{Cipher: backupCipher, Keys: stanzas}
+	} else {
+		logger.Warn("Backup encryption not configured, backup archive is not encrypted")
 	}
 
-	// 备份Feishu配置
-	if err := bm.backupTable(ctx, "feishu_configs", &backup.FeishuConfigs); err != nil {
-		return fmt.Errorf("failed to backup feishu_configs: %w", err)
-	}
+	var file archiveFileInfo
+	err = bm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var snapshotTime time.Time
+		if err := tx.Raw("SELECT NOW()").Scan(&snapshotTime).Error; err != nil {
+			return fmt.Errorf("failed to get snapshot time: %w", err)
+		}
 
-	// 备份通知发送记录
-	if err := bm.backupTable(ctx, "notification_logs", &backup.NotificationLogs); err != nil {
-		return fmt.Errorf("failed to backup notification_logs: %w", err)
-	}
+		// 全量选择时数据库中的表必须都已登记或排除，避免新增表的迁移漏登记后被静默跳过
+		if options.Selection.isAll() {
+			unregistered, err := unregisteredTables(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if len(unregistered) > 0 {
+				return fmt.Errorf("tables not registered for backup: %s, add them to backupTableDependencies or backupExcludedTables", strings.Join(unregistered, ", "))
+			}
+		}
 
-	// 备份区块扫描进度
-	if err := bm.backupTable(ctx, "block_scan_progress", &backup.BlockScanProgress); err != nil {
-		return fmt.Errorf("failed to backup block_scan_progress: %w", err)
+		var err error
+		file, err = writeArchiveFile(backupPath, func(tw *tar.Writer) error {
+			for _, table := range tables {
+				entry, err := bm.backupTable(ctx, tx, tw, backupDir, table, fileKey, parent, snapshotTime)
+				if err != nil {
+					logger.Error("Failed to backup table", err, "table", table)
+					return fmt.Errorf("failed to backup %s: %w", table, err)
+				}
+				manifest.Tables = append(manifest.Tables, entry)
+			}
+			return writeManifest(tw, &manifest, fileKey)
+		})
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
 	}
 
-	// === 系统表备份（可选）===
-	// 备份赞助方数据（系统表，可选择是否备份）
-	if err := bm.backupTable(ctx, "sponsors", &backup.Sponsors); err != nil {
-		return fmt.Errorf("failed to backup sponsors: %w", err)
+	entry := catalogEntryFromManifest(backupPath, &manifest, file)
+	catalog.put(entry)
+	if err := catalog.save(ctx); err != nil {
+		logger.Error("Failed to update backup catalog", err, "path", backupPath)
+		return nil, err
 	}
 
-	// 写入备份文件
-	file, err := os.Create(backupPath)
-	if err != nil {
-		logger.Error("Failed to create backup file", err, "path", backupPath)
-		return fmt.Errorf("failed to create backup file: %w", err)
+	if bm.storage != nil {
+		if err := bm.uploadBackup(ctx, backupPath, entry); err != nil {
+			logger.Error("Failed to upload backup, local copy kept", err, "path", backupPath, "storage", bm.storage.Location())
+			return nil, fmt.Errorf("failed to upload backup: %w", err)
+		}
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(backup); err != nil {
-		logger.Error("Failed to encode backup data", err)
-		return fmt.Errorf("failed to encode backup data: %w", err)
+	var totalRows int64
+	for _, entry := range manifest.Tables {
+		totalRows += entry.Rows
 	}
-
 	logger.Info("Database backup created successfully",
 		"path", backupPath,
-		"version", backup.Version,
-		"users", len(backup.Users),
-		"support_chains", len(backup.SupportChains),
-		"emails", len(backup.Emails),
-		"user_emails", len(backup.UserEmails),
-		"safe_wallets", len(backup.SafeWallets),
-		"abis", len(backup.ABIs),
-		"compound_timelocks", len(backup.CompoundTimelocks),
-		"openzeppelin_timelocks", len(backup.OpenzeppelinTimelocks),
-		"compound_transactions", len(backup.CompoundTimelockTransactions),
-		"openzeppelin_transactions", len(backup.OpenzeppelinTimelockTransactions),
-		"transaction_flows", len(backup.TimelockTransactionFlows),
-		"email_send_logs", len(backup.EmailSendLogs),
-		"telegram_configs", len(backup.TelegramConfigs),
-		"lark_configs", len(backup.LarkConfigs),
-		"feishu_configs", len(backup.FeishuConfigs),
-		"notification_logs", len(backup.NotificationLogs),
-		"block_scan_progress", len(backup.BlockScanProgress),
-		"sponsors", len(backup.Sponsors),
+		"id", manifest.ID,
+		"type", manifest.Type,
+		"version", manifest.Version,
+		"tables", len(manifest.Tables),
+		"records", totalRows,
+		"size", file.Size,
+		"encrypted", fileKey != nil,
 	)
-
-	return nil
+	return &entry, nil
 }
 
-// RestoreBackup 从备份文件恢复数据
-func (bm *BackupManager) RestoreBackup(backupPath string, options RestoreOptions) error {
-	ctx := context.Background()
-	logger.Info("Starting database restore from backup", "path", backupPath)
+// backupTable 将单张表写入归档，增量备份只读取父备份水位之后变化的行，并记录新的水位
+func (bm *BackupManager) backupTable(ctx context.Context, tx *gorm.DB, tw *tar.Writer, dir, table string, fileKey []byte, parent *BackupCatalogEntry, snapshotTime time.Time) (BackupTableManifest, error) {
+	column := bm.watermarkColumn(tx, table)
 
-	// 读取备份文件
-	file, err := os.Open(backupPath)
+	var (
+		filter   *tableFilter
+		previous TableWatermark
+	)
+	if parent != nil {
+		// 父备份中没有的表做全量备份
+		if watermark, ok := parent.Watermarks[table]; ok {
+			previous = watermark
+			filter = incrementalFilter(watermark, column)
+		}
+	}
+	logger.Info("Backing up table", "table", table, "incremental", filter != nil)
+
+	var maxID int64
+	entry, err := writeTableEntry(tw, dir, table, fileKey, func(emit backupRecordEmitter) error {
+		var err error
+		maxID, err = bm.streamTable(ctx, tx, table, filter, func(record map[string]interface{}) error {
+			// 历史明文的通知密钥在写入备份前加密
+			if err := bm.encryptRecordSecrets(table, record); err != nil {
+				return fmt.Errorf("failed to encrypt backup secrets: %w", err)
+			}
+			return emit(record)
+		})
+		return err
+	})
 	if err != nil {
-		logger.Error("Failed to open backup file", err, "path", backupPath)
-		return fmt.Errorf("failed to open backup file: %w", err)
+		return entry, err
 	}
-	defer file.Close()
 
-	var backup BackupData
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&backup); err != nil {
-		logger.Error("Failed to decode backup data", err)
-		return fmt.Errorf("failed to decode backup data: %w", err)
+	if maxID < previous.MaxID {
+		maxID = previous.MaxID
 	}
+	entry.Watermark = TableWatermark{MaxID: maxID}
+	if column != "" {
+		entry.Watermark.Column = column
+		entry.Watermark.Since = snapshotTime
+	}
+	logger.Info("Table backup completed", "table", table, "records", entry.Rows)
+	return entry, nil
+}
 
-	logger.Info("Backup file loaded",
-		"version", backup.Version,
-		"timestamp", backup.Timestamp,
-		"users", len(backup.Users),
-	)
-
-	// 在事务中执行恢复操作
-	return bm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 如果需要清空现有数据
-		if options.ClearExisting {
-			if err := bm.clearUserData(ctx, tx); err != nil {
-				return fmt.Errorf("failed to clear existing data: %w", err)
-			}
-		}
-
-		// 按照外键依赖关系的正确顺序恢复数据
-		// 这确保了外键约束的正确性
-
-		// 第一层：基础独立表（被其他表外键引用）
-		// 1. 用户表（被其他表外键引用）
-		if err := bm.restoreTable(ctx, tx, "users", backup.Users, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore users: %w", err)
-		}
-
-		// 2. 邮箱表（被其他表外键引用）
-		if err := bm.restoreTable(ctx, tx, "emails", backup.Emails, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore emails: %w", err)
+// watermarkColumn 返回表中用于增量判断的时间列，没有时返回空字符串
+func (bm *BackupManager) watermarkColumn(tx *gorm.DB, table string) string {
+	for _, column := range []string{"updated_at", "created_at"} {
+		if tx.Migrator().HasColumn(table, column) {
+			return column
 		}
+	}
+	return ""
+}
 
-		// 3. 支持的区块链表（独立表）
-		if err := bm.restoreTable(ctx, tx, "support_chains", backup.SupportChains, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore support_chains: %w", err)
+// incrementalFilter 生成增量读取条件：时间列在父备份快照之后（含回溯量）或id大于父备份最大id
+func incrementalFilter(watermark TableWatermark, column string) *tableFilter {
+	if column != "" && watermark.Column == column && !watermark.Since.IsZero() {
+		return &tableFilter{
+			where: fmt.Sprintf("(%s >= ? OR id > ?)", column),
+			args:  []interface{}{watermark.Since.Add(-incrementalOverlap), watermark.MaxID},
 		}
+	}
+	return &tableFilter{where: "id > ?", args: []interface{}{watermark.MaxID}}
+}
 
-		// 4. 赞助方表（独立表）
-		if err := bm.restoreTable(ctx, tx, "sponsors", backup.Sponsors, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore sponsors: %w", err)
-		}
+// RestoreBackup 从备份文件恢复数据，支持归档格式和旧版JSON格式
+// 恢复增量备份时会根据备份目录的catalog.json依次读取全量备份和之后的每个增量备份，同一行以最新的备份为准
+// 备份数据先按备份的schema版本转换到数据库当前版本并写入临时表，校验非空、检查约束、唯一约束和外键全部通过后才写入
+// 校验失败时返回ErrRestoreValidation，报告中列出问题；DryRun时只校验不写入
+// 暂存、校验和写入都在ctx下的同一事务中执行，ctx取消时事务回滚，不会留下部分写入的数据
+func (bm *BackupManager) RestoreBackup(ctx context.Context, backupPath string, options RestoreOptions) (*RestoreReport, error) {
+	logger.Info("Starting database restore from backup", "path", backupPath, "dry_run", options.DryRun)
 
-		// 5. 区块扫描进度表（独立表）
-		if err := bm.restoreTable(ctx, tx, "block_scan_progress", backup.BlockScanProgress, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore block_scan_progress: %w", err)
-		}
+	tables, err := options.tables()
+	if err != nil {
+		return nil, err
+	}
+	for table, parents := range missingDependencies(tables) {
+		logger.Warn("Restoring table without its dependencies, referenced rows must already exist", "table", table, "depends_on", parents)
+	}
 
-		// 6. Safe钱包表（独立表）
-		if err := bm.restoreTable(ctx, tx, "safe_wallets", backup.SafeWallets, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore safe_wallets: %w", err)
-		}
+	schemaVersion, err := migrations.CurrentVersion(ctx, bm.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database schema version: %w", err)
+	}
+	if schemaVersion == 0 {
+		return nil, errors.New("database has no applied migrations, run migrations before restoring")
+	}
+	report := &RestoreReport{SchemaVersion: schemaVersion, DryRun: options.DryRun}
 
-		// 7. 认证nonce表（独立表）
-		if err := bm.restoreTable(ctx, tx, "auth_nonces", backup.AuthNonces, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore auth_nonces: %w", err)
-		}
+	format, err := detectBackupFormat(backupPath)
+	if err != nil {
+		logger.Error("Failed to detect backup format", err, "path", backupPath)
+		return nil, err
+	}
 
-		// 第二层：依赖用户表的表
-		// 8. ABI库表（外键：owner → users.wallet_address）
-		if err := bm.restoreTable(ctx, tx, "abis", backup.ABIs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore abis: %w", err)
+	// stage 将备份数据写入暂存表；latest为最新备份的转换计划，备份之后新增的表以它为准
+	var (
+		stage  func(plan *restorePlan) error
+		latest *schemaPlan
+	)
+	if format == BackupFormatLegacyJSON {
+		backup, err := readLegacyBackup(backupPath)
+		if err != nil {
+			logger.Error("Failed to decode backup data", err)
+			return nil, err
 		}
+		logger.Info("Legacy backup file loaded", "version", backup.Version, "timestamp", backup.Timestamp, "users", len(backup.Users))
 
-		// 9. Compound标准Timelock合约表（外键：creator_address → users.wallet_address）
-		if err := bm.restoreTable(ctx, tx, "compound_timelocks", backup.CompoundTimelocks, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore compound_timelocks: %w", err)
+		if latest, err = newSchemaPlan(0, schemaVersion); err != nil {
+			return nil, err
 		}
-
-		// 10. OpenZeppelin标准Timelock合约表（外键：creator_address → users.wallet_address）
-		if err := bm.restoreTable(ctx, tx, "openzeppelin_timelocks", backup.OpenzeppelinTimelocks, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore openzeppelin_timelocks: %w", err)
+		stage = func(plan *restorePlan) error {
+			return plan.stageLegacy(backup, latest)
 		}
-
-		// 11. 用户邮箱关联表（外键：user_id → users.id, email_id → emails.id）
-		if err := bm.restoreTable(ctx, tx, "user_emails", backup.UserEmails, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore user_emails: %w", err)
+	} else {
+		archives, err := bm.openRestoreChain(backupPath)
+		defer func() {
+			for _, archive := range archives {
+				archive.Close()
+			}
+		}()
+		if err != nil {
+			logger.Error("Failed to open backup archive", err, "path", backupPath)
+			return nil, err
 		}
 
-		// 第三层：事务相关表（无直接外键约束）
-		// 12. Compound Timelock 交易记录表
-		if err := bm.restoreTable(ctx, tx, "compound_timelock_transactions", backup.CompoundTimelockTransactions, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore compound_timelock_transactions: %w", err)
+		schemas := make([]*schemaPlan, len(archives))
+		for i, archive := range archives {
+			if schemas[i], err = newSchemaPlan(archive.manifest.SchemaVersion, schemaVersion); err != nil {
+				return nil, fmt.Errorf("backup %s: %w", archive.manifest.ID, err)
+			}
 		}
-
-		// 13. OpenZeppelin Timelock 交易记录表
-		if err := bm.restoreTable(ctx, tx, "openzeppelin_timelock_transactions", backup.OpenzeppelinTimelockTransactions, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore openzeppelin_timelock_transactions: %w", err)
+		latest = schemas[len(schemas)-1]
+		stage = func(plan *restorePlan) error {
+			for i, archive := range archives {
+				if err := plan.stageArchive(archive, schemas[i]); err != nil {
+					return err
+				}
+			}
+			return nil
 		}
+	}
 
-		// 14. Timelock 交易流程关联表
-		if err := bm.restoreTable(ctx, tx, "timelock_transaction_flows", backup.TimelockTransactionFlows, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore timelock_transaction_flows: %w", err)
-		}
+	report.BackupSchemaVersion = latest.from
+	report.Transforms = latest.descriptions()
 
-		// 第四层：依赖user_emails和emails表的表
-		// 15. 邮箱验证码表（外键：user_email_id → user_emails.id）
-		if err := bm.restoreTable(ctx, tx, "email_verification_codes", backup.EmailVerificationCodes, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore email_verification_codes: %w", err)
+	// 暂存、校验和写入在同一事务中进行，校验失败或试运行时回滚，暂存表随事务结束删除
+	err = bm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		plan, err := bm.newRestorePlan(ctx, tx, tables, options, latest, report)
+		if err != nil {
+			return err
 		}
-
-		// 16. 邮件发送日志表（外键：email_id → emails.id）
-		if err := bm.restoreTable(ctx, tx, "email_send_logs", backup.EmailSendLogs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore email_send_logs: %w", err)
+		if err := stage(plan); err != nil {
+			return err
 		}
+		plan.keepMissing()
 
-		// 第五层：通知配置表（无外键约束，但逻辑上依赖用户）
-		// 17. Telegram配置表
-		if err := bm.restoreTable(ctx, tx, "telegram_configs", backup.TelegramConfigs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore telegram_configs: %w", err)
+		if err := plan.validate(); err != nil {
+			return err
 		}
-
-		// 18. Lark配置表
-		if err := bm.restoreTable(ctx, tx, "lark_configs", backup.LarkConfigs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore lark_configs: %w", err)
+		if !report.Valid() {
+			return ErrRestoreValidation
 		}
-
-		// 19. 飞书配置表
-		if err := bm.restoreTable(ctx, tx, "feishu_configs", backup.FeishuConfigs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore feishu_configs: %w", err)
+		if options.DryRun {
+			return errRestoreDryRun
 		}
 
-		// 20. 通知日志表（最后恢复，依赖配置表）
-		if err := bm.restoreTable(ctx, tx, "notification_logs", backup.NotificationLogs, options.OnConflict); err != nil {
-			return fmt.Errorf("failed to restore notification_logs: %w", err)
+		if options.ClearExisting {
+			if err := bm.clearTables(ctx, tx, plan.clearTables(), options); err != nil {
+				return fmt.Errorf("failed to clear existing data: %w", err)
+			}
 		}
-
-		return nil
+		return plan.write()
 	})
+	switch {
+	case errors.Is(err, errRestoreDryRun):
+		logger.Info("Restore dry run completed", "path", backupPath, "backup_schema", report.BackupSchemaVersion, "schema", report.SchemaVersion)
+		return report, nil
+	case errors.Is(err, ErrRestoreValidation):
+		logger.Warn("Restore validation failed, no data written", "path", backupPath, "issues", report.IssueMessages())
+		return report, err
+	case err != nil:
+		return report, err
+	}
+	logger.Info("Database restore completed", "path", backupPath, "backup_schema", report.BackupSchemaVersion, "schema", report.SchemaVersion)
+	return report, nil
 }
 
-// RestoreOptions 恢复选项
-type RestoreOptions struct {
-	ClearExisting bool           // 是否清空现有用户数据
-	OnConflict    ConflictAction // 冲突处理策略
-}
-
-// ConflictAction 冲突处理策略
-type ConflictAction string
-
-const (
-	ConflictSkip    ConflictAction = "skip"    // 跳过冲突记录
-	ConflictReplace ConflictAction = "replace" // 替换冲突记录
-	ConflictError   ConflictAction = "error"   // 遇到冲突报错
-)
-
-// backupTable 备份指定表的所有数据
-func (bm *BackupManager) backupTable(ctx context.Context, tableName string, result interface{}) error {
-	logger.Info("Backing up table", "table", tableName)
-
-	rows, err := bm.db.WithContext(ctx).Table(tableName).Rows()
+// openRestoreChain 打开恢复所需的全部归档并解锁，增量备份按目录中的父备份链从全量备份开始排列
+func (bm *BackupManager) openRestoreChain(backupPath string) ([]*backupArchive, error) {
+	target, err := openBackupArchive(backupPath)
 	if err != nil {
-		return fmt.Errorf("failed to query table %s: %w", tableName, err)
+		return nil, err
+	}
+	// 加密备份需先解出文件密钥并验证清单，表数据在读取时逐块认证
+	if err := target.unlock(bm.encryption); err != nil {
+		return []*backupArchive{target}, err
+	}
+	if target.manifest.Type != BackupTypeIncremental {
+		return []*backupArchive{target}, nil
 	}
-	defer rows.Close()
 
-	columns, err := rows.Columns()
+	catalog, err := LoadBackupCatalog(filepath.Dir(backupPath))
 	if err != nil {
-		return fmt.Errorf("failed to get columns for table %s: %w", tableName, err)
+		return []*backupArchive{target}, err
+	}
+	chain, err := catalog.Chain(target.manifest.ParentID)
+	if err != nil {
+		return []*backupArchive{target}, fmt.Errorf("failed to resolve backup chain: %w", err)
 	}
 
-	var records []map[string]interface{}
-	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+	archives := make([]*backupArchive, 0, len(chain)+1)
+	for _, entry := range chain {
+		archive, err := openBackupArchive(catalog.Path(entry))
+		if err != nil {
+			return append(archives, target), fmt.Errorf("failed to open backup %s: %w", entry.ID, err)
 		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return fmt.Errorf("failed to scan row from table %s: %w", tableName, err)
+		archives = append(archives, archive)
+		if archive.manifest.ID != entry.ID {
+			return append(archives, target), fmt.Errorf("backup file %s does not contain backup %s", entry.File, entry.ID)
 		}
-
-		record := make(map[string]interface{})
-		for i, col := range columns {
-			val := values[i]
-			if b, ok := val.([]byte); ok {
-				record[col] = string(b)
-			} else {
-				record[col] = val
-			}
+		if err := archive.unlock(bm.encryption); err != nil {
+			return append(archives, target), fmt.Errorf("failed to unlock backup %s: %w", entry.ID, err)
 		}
-		records = append(records, record)
 	}
+	logger.Info("Resolved incremental backup chain", "backups", len(archives)+1)
+	return append(archives, target), nil
+}
 
-	// 使用反射设置结果
-	switch v := result.(type) {
-	case *[]map[string]interface{}:
-		*v = records
-	default:
-		return fmt.Errorf("unsupported result type for table %s", tableName)
+// matchesOwner 按用户恢复时判断记录是否属于该用户
+func matchesOwner(table string, record map[string]interface{}, userAddress string) bool {
+	if userAddress == "" {
+		return true
 	}
+	owner, _ := record[backupTableOwnerColumns[table]].(string)
+	return strings.EqualFold(owner, userAddress)
+}
 
-	logger.Info("Table backup completed", "table", tableName, "records", len(records))
-	return nil
+// RestoreOptions 恢复选项
+type RestoreOptions struct {
+	ClearExisting bool           // 是否清空现有用户数据，只清空所选的表
+	OnConflict    ConflictAction // 冲突处理策略
+	Selection     TableSelection // 要恢复的表，默认全部
+	UserAddress   string         // 只恢复该钱包地址的数据，仅支持有所属用户列的表
+	DryRun        bool           // 只转换和校验备份数据并返回报告，不写入
 }
 
-// restoreTable 恢复表数据
-func (bm *BackupManager) restoreTable(ctx context.Context, tx *gorm.DB, tableName string, records []map[string]interface{}, onConflict ConflictAction) error {
-	if len(records) == 0 {
-		logger.Info("No records to restore for table", "table", tableName)
-		return nil
+// tables 返回按依赖顺序排列的待恢复表
+func (o RestoreOptions) tables() ([]string, error) {
+	if o.UserAddress == "" {
+		return o.Selection.resolve()
 	}
 
-	logger.Info("Restoring table", "table", tableName, "records", len(records))
-
-	for _, record := range records {
-		if err := bm.insertRecord(ctx, tx, tableName, record, onConflict); err != nil {
-			// 检查是否是事务已中止的错误
-			if strings.Contains(err.Error(), "current transaction is aborted") {
-				logger.Error("Transaction aborted, cannot continue", err, "table", tableName)
-				return fmt.Errorf("transaction aborted during %s restore: %w", tableName, err)
-			}
-
-			if onConflict == ConflictError {
-				return fmt.Errorf("failed to insert record into %s: %w", tableName, err)
-			}
-			logger.Warn("Skipped record due to conflict", "table", tableName, "error", err)
+	// 按用户恢复时默认选择所有有所属用户列的表
+	selection := o.Selection
+	if len(selection.Tables) == 0 {
+		for table := range backupTableOwnerColumns {
+			selection.Tables = append(selection.Tables, table)
 		}
 	}
-
-	logger.Info("Table restore completed", "table", tableName)
-	return nil
-}
-
-// insertRecord 插入单条记录，处理冲突
-func (bm *BackupManager) insertRecord(ctx context.Context, tx *gorm.DB, tableName string, record map[string]interface{}, onConflict ConflictAction) error {
-	// 构建列名和占位符
-	var columns []string
-	var values []interface{}
-	var placeholders []string
-
-	for col, val := range record {
-		// 处理BYTEA字段的二进制数据
-		if bm.isByteaField(tableName, col) {
-			val = bm.processByteaField(val)
+	tables, err := selection.resolve()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if _, ok := backupTableOwnerColumns[table]; !ok {
+			return nil, fmt.Errorf("table %s cannot be restored for a single user", table)
 		}
-
-		columns = append(columns, col)
-		values = append(values, val)
-		placeholders = append(placeholders, "?")
 	}
+	return tables, nil
+}
 
-	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
-		tableName,
-		strings.Join(columns, ", "),
-		strings.Join(placeholders, ", "))
+// ConflictAction 冲突处理策略
+type ConflictAction string
 
-	// 根据冲突策略调整SQL
+const (
+	ConflictSkip    ConflictAction = "skip"    // 跳过冲突记录
+	ConflictReplace ConflictAction = "replace" // 替换冲突记录
+	ConflictError   ConflictAction = "error"   // 遇到冲突报错
+)
+
+// conflictClause 根据冲突策略生成INSERT语句的ON CONFLICT子句，predicate为冲突检测列对应部分唯一索引的条件
+func (bm *BackupManager) conflictClause(tableName string, columns []string, onConflict ConflictAction, predicate string) string {
 	switch onConflict {
 	case ConflictSkip:
-		sql += " ON CONFLICT DO NOTHING"
+		return " ON CONFLICT DO NOTHING"
 	case ConflictReplace:
 		conflictCols := bm.getConflictColumns(tableName)
-		sql += fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET ", conflictCols)
-		var updates []string
-		primaryKeyCols := bm.getPrimaryKeyColumns(tableName)
+		target := fmt.Sprintf("(%s)", conflictCols)
+		if predicate != "" {
+			target += " WHERE " + predicate
+		}
 		primaryKeyMap := make(map[string]bool)
-		for _, pk := range primaryKeyCols {
+		for _, pk := range bm.getPrimaryKeyColumns(tableName) {
 			primaryKeyMap[pk] = true
 		}
 
@@ -511,26 +562,21 @@ func (bm *BackupManager) insertRecord(ctx context.Context, tx *gorm.DB, tableNam
 			primaryKeyMap[col] = true
 		}
 
+		var updates []string
 		for _, col := range columns {
 			if !primaryKeyMap[col] { // 不更新主键列和冲突检测列
 				updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
 			}
 		}
-		if len(updates) > 0 {
-			sql += strings.Join(updates, ", ")
-		} else {
+		if len(updates) == 0 {
 			// 如果没有可更新的列，改用DO NOTHING
-			sql = strings.Replace(sql, " DO UPDATE SET ", " DO NOTHING", 1)
+			return fmt.Sprintf(" ON CONFLICT %s DO NOTHING", target)
 		}
-	case ConflictError:
-		// 默认行为，遇到冲突会报错
-	}
-
-	err := tx.WithContext(ctx).Exec(sql, values...).Error
-	if err != nil {
-		logger.Error("Failed to execute SQL", err, "table", tableName, "sql", sql, "values", values)
+		return fmt.Sprintf(" ON CONFLICT %s DO UPDATE SET %s", target, strings.Join(updates, ", "))
+	default:
+		// ConflictError: 默认行为，遇到冲突会报错
+		return ""
 	}
-	return err
 }
 
 // getConflictColumns 获取表的冲突检测列（用于ON CONFLICT）
@@ -582,6 +628,20 @@ func (bm *BackupManager) getConflictColumns(tableName string) string {
 		return "user_address, name" // 复合唯一键
 	case "notification_logs":
 		return "channel, config_id, flow_id, status_to" // 复合唯一键
+	case "digest_send_logs":
+		return "channel, config_id, period_type, period_start" // 复合唯一键
+
+	// 会话、API Key、组织和关联钱包
+	case "user_sessions":
+		return "refresh_jti" // 唯一键
+	case "revoked_tokens":
+		return "token_id" // 唯一键
+	case "api_keys":
+		return "key_hash" // 唯一键
+	case "organization_members":
+		return "organization_id, wallet_address" // 复合唯一键
+	case "user_linked_wallets":
+		return "wallet_address" // 唯一键
 
 	// 无唯一约束的表，使用主键
 	case "sponsors", "email_verification_codes":
@@ -676,76 +736,115 @@ func (bm *BackupManager) processByteaField(val interface{}) interface{} {
 	return val
 }
 
-// clearUserData 清空用户相关数据（保留系统数据）
-func (bm *BackupManager) clearUserData(ctx context.Context, tx *gorm.DB) error {
-	logger.Warn("Clearing existing user data")
-
-	// 按依赖关系顺序删除（从子表到父表），与init_tables.go中删除顺序一致
-	tables := []string{
-		// 通知相关表（最后创建的，最先删除）
-		"notification_logs",
-		"feishu_configs",
-		"lark_configs",
-		"telegram_configs",
-		"email_send_logs",
-		"email_verification_codes",
-		"user_emails",
-		"emails",
-
-		// Safe钱包表
-		"safe_wallets",
-
-		// 认证相关表
-		"auth_nonces",
-
-		// 交易流程表
-		"timelock_transaction_flows",
-
-		// 交易记录表
-		"openzeppelin_timelock_transactions",
-		"compound_timelock_transactions",
-
-		// 扫描进度表
-		"block_scan_progress",
+// referencingKey 引用被清空表的外键
+type referencingKey struct {
+	Name       string
+	Child      string
+	Columns    string
+	RefColumns string
+}
 
-		// Timelock合约表
-		"openzeppelin_timelocks",
-		"compound_timelocks",
+// checkClearDependents 检查其他表通过外键对待清空行的引用
+// 未清空的表仍引用待清空的行时返回错误，避免清空后留下悬空引用；contained表示所有引用表都在清空范围内
+func (bm *BackupManager) checkClearDependents(ctx context.Context, tx *gorm.DB, tables []string, options RestoreOptions) (contained bool, err error) {
+	clearing := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		clearing[table] = true
+	}
 
-		// 赞助方表
-		"sponsors",
+	contained = true
+	for _, table := range tables {
+		var keys []referencingKey
+		if err := tx.WithContext(ctx).Raw(`SELECT c.conname AS name, c.conrelid::regclass::text AS child,
+				array_to_string(ARRAY(
+					SELECT a.attname FROM unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord)
+					JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+					ORDER BY k.ord), ',') AS columns,
+				array_to_string(ARRAY(
+					SELECT a.attname FROM unnest(c.confkey) WITH ORDINALITY AS k(attnum, ord)
+					JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum
+					ORDER BY k.ord), ',') AS ref_columns
+			FROM pg_constraint c
+			WHERE c.confrelid = ?::regclass AND c.contype = 'f' AND c.conrelid <> c.confrelid
+			ORDER BY c.conname`, table).Scan(&keys).Error; err != nil {
+			return false, fmt.Errorf("failed to read foreign keys referencing %s: %w", table, err)
+		}
+
+		for _, key := range keys {
+			if clearing[key.Child] {
+				continue
+			}
+			contained = false
 
-		// ABI表
-		"abis",
+			// 只有实际引用待清空行的数据才会受影响
+			condition, args := "TRUE", []interface{}(nil)
+			if options.UserAddress != "" {
+				condition = fmt.Sprintf("LOWER(p.%s) = LOWER(?)", backupTableOwnerColumns[table])
+				args = append(args, options.UserAddress)
+			}
+			var count int64
+			query := fmt.Sprintf("SELECT COUNT(*) FROM %s c WHERE EXISTS (SELECT 1 FROM %s p WHERE %s AND %s)",
+				key.Child, table, joinColumns("c", "p", strings.Split(key.Columns, ","), strings.Split(key.RefColumns, ",")), condition)
+			if err := tx.WithContext(ctx).Raw(query, args...).Scan(&count).Error; err != nil {
+				return false, fmt.Errorf("failed to check rows referencing %s: %w", table, err)
+			}
+			if count > 0 {
+				return false, fmt.Errorf("%d rows in %s reference %s via %s, include %s in the restore or restore without -clear",
+					count, key.Child, table, key.Name, key.Child)
+			}
+		}
+	}
+	return contained, nil
+}
 
-		// 支持链表
-		"support_chains",
+// clearTables 清空所选的表，按依赖关系逆序删除（从子表到父表）
+// 未清空的表仍引用待清空的行时拒绝清空；选择全部表且引用表都在清空范围内时使用一条不带CASCADE的TRUNCATE，
+// 其他情况使用DELETE，不会波及未选择的表
+func (bm *BackupManager) clearTables(ctx context.Context, tx *gorm.DB, tables []string, options RestoreOptions) error {
+	logger.Warn("Clearing existing user data", "tables", len(tables), "user", options.UserAddress)
 
-		// 用户表（最先创建的，最后删除）
-		"users",
+	contained, err := bm.checkClearDependents(ctx, tx, tables, options)
+	if err != nil {
+		return err
 	}
 
-	for _, table := range tables {
-		// 禁用外键约束检查
-		if err := tx.WithContext(ctx).Exec("SET session_replication_role = replica").Error; err != nil {
-			logger.Warn("Failed to disable foreign key constraints", "error", err)
-		}
-
-		if err := tx.WithContext(ctx).Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)).Error; err != nil {
-			// 如果TRUNCATE失败，尝试DELETE
-			if err := tx.WithContext(ctx).Exec(fmt.Sprintf("DELETE FROM %s", table)).Error; err != nil {
-				logger.Error("Failed to clear table", err, "table", table)
-				// 恢复外键约束检查
-				tx.WithContext(ctx).Exec("SET session_replication_role = DEFAULT")
-				return fmt.Errorf("failed to clear table %s: %w", table, err)
+	if options.Selection.isAll() && options.UserAddress == "" && contained {
+		if len(tables) > 0 {
+			if err := tx.WithContext(ctx).Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY", strings.Join(tables, ", "))).Error; err != nil {
+				logger.Error("Failed to truncate tables", err, "tables", tables)
+				return fmt.Errorf("failed to truncate tables: %w", err)
 			}
 		}
-		logger.Info("Cleared table", "table", table)
+		logger.Info("User data cleared successfully", "tables", len(tables))
+		return nil
 	}
 
+	// 禁用外键约束检查
+	if err := tx.WithContext(ctx).Exec("SET session_replication_role = replica").Error; err != nil {
+		logger.Warn("Failed to disable foreign key constraints", "error", err)
+	}
 	// 恢复外键约束检查
-	if err := tx.WithContext(ctx).Exec("SET session_replication_role = DEFAULT").Error; err != nil {
-		logger.Warn("Failed to re-enable foreign key constraints", "error", err)
+	defer func() {
+		if err := tx.WithContext(ctx).Exec("SET session_replication_role = DEFAULT").Error; err != nil {
+			logger.Warn("Failed to re-enable foreign key constraints", "error", err)
+		}
+	}()
+
+	for i := len(tables) - 1; i >= 0; i-- {
+		table := tables[i]
+
+		var err error
+		if options.UserAddress != "" {
+			column := backupTableOwnerColumns[table]
+			err = tx.WithContext(ctx).Exec(fmt.Sprintf("DELETE FROM %s WHERE LOWER(%s) = LOWER(?)", table, column), options.UserAddress).Error
+		} else {
+			err = tx.WithContext(ctx).Exec(fmt.Sprintf("DELETE FROM %s", table)).Error
+		}
+		if err != nil {
+			logger.Error("Failed to clear table", err, "table", table)
+			return fmt.Errorf("failed to clear table %s: %w", table, err)
+		}
+		logger.Info("Cleared table", "table", table)
 	}
 
 	logger.Info("User data cleared successfully")
@@ -753,19 +852,73 @@ func (bm *BackupManager) clearUserData(ctx context.Context, tx *gorm.DB) error {
 }
 
 // ValidateBackup 验证备份文件的完整性
+// 归档格式会校验清单、每张表的SHA-256和行数；旧版JSON格式只做基本检查
+// 加密备份只校验清单HMAC和存储内容的SHA-256，不解密表数据；未配置解密密钥时无法验证清单HMAC
 func (bm *BackupManager) ValidateBackup(backupPath string) error {
 	logger.Info("Validating backup file", "path", backupPath)
 
-	file, err := os.Open(backupPath)
+	format, err := detectBackupFormat(backupPath)
 	if err != nil {
-		return fmt.Errorf("failed to open backup file: %w", err)
+		return err
+	}
+	if format == BackupFormatLegacyJSON {
+		return bm.validateLegacyBackup(backupPath)
 	}
-	defer file.Close()
 
-	var backup BackupData
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&backup); err != nil {
-		return fmt.Errorf("failed to decode backup data: %w", err)
+	archive, err := openBackupArchive(backupPath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	if archive.manifest.Version == "" {
+		return errors.New("backup version is missing")
+	}
+	if archive.manifest.Timestamp.IsZero() {
+		return errors.New("backup timestamp is missing")
+	}
+
+	if archive.encrypted() {
+		if bm.encryption.CanDecrypt() {
+			if err := archive.unlock(bm.encryption); err != nil {
+				return err
+			}
+		} else {
+			logger.Warn("Backup is encrypted and no decryption key is configured, manifest authenticity not verified")
+		}
+		// 清单中的SHA-256已由HMAC认证，校验存储内容即可确认表数据完整
+		err = archive.eachEntry(func(entry *BackupTableManifest, raw io.Reader) error {
+			return nil
+		})
+	} else {
+		err = archive.eachTable(func(entry *BackupTableManifest, records func(emit backupRecordEmitter) error) error {
+			return records(func(record map[string]interface{}) error {
+				if _, ok := toInt64(record["id"]); !ok {
+					return fmt.Errorf("record without integer id in table %s", entry.Name)
+				}
+				return nil
+			})
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Backup validation completed successfully",
+		"version", archive.manifest.Version,
+		"tables", len(archive.manifest.Tables),
+		"timestamp", archive.manifest.Timestamp,
+		"encrypted", archive.encrypted(),
+		"authenticated", archive.fileKey != nil,
+	)
+	return nil
+}
+
+// validateLegacyBackup 验证旧版JSON备份文件
+func (bm *BackupManager) validateLegacyBackup(backupPath string) error {
+	backup, err := readLegacyBackup(backupPath)
+	if err != nil {
+		return err
 	}
 
 	// 基本验证
@@ -777,14 +930,6 @@ func (bm *BackupManager) ValidateBackup(backupPath string) error {
 		return errors.New("backup timestamp is missing")
 	}
 
-	// 数据完整性检查
-	userAddresses := make(map[string]bool)
-	for _, user := range backup.Users {
-		if addr, ok := user["wallet_address"].(string); ok {
-			userAddresses[addr] = true
-		}
-	}
-
 	// 检查用户邮箱数据是否有对应的用户
 	for _, userEmail := range backup.UserEmails {
 		if userID, ok := userEmail["user_id"].(float64); ok {
@@ -804,41 +949,53 @@ func (bm *BackupManager) ValidateBackup(backupPath string) error {
 	return nil
 }
 
-// GetBackupInfo 获取备份文件信息
-func (bm *BackupManager) GetBackupInfo(backupPath string) (*BackupData, error) {
-	file, err := os.Open(backupPath)
+// GetBackupInfo 获取备份文件信息，归档格式只读取清单，加密备份无需密钥
+func (bm *BackupManager) GetBackupInfo(backupPath string) (*BackupInfo, error) {
+	format, err := detectBackupFormat(backupPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open backup file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	var backup BackupData
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&backup); err != nil {
-		return nil, fmt.Errorf("failed to decode backup data: %w", err)
+	if format == BackupFormatLegacyJSON {
+		backup, err := readLegacyBackup(backupPath)
+		if err != nil {
+			return nil, err
+		}
+		info := &BackupInfo{Format: format, Type: BackupTypeFull, Version: backup.Version, Timestamp: backup.Timestamp}
+		records := legacyTableRecords(backup)
+		for _, table := range backupTables {
+			info.Tables = append(info.Tables, BackupTableInfo{Name: table, Rows: int64(len(records[table]))})
+		}
+		return info, nil
 	}
 
-	// 清空实际数据，只返回元信息
-	backup.Users = nil
-	backup.SupportChains = nil
-	backup.Emails = nil
-	backup.UserEmails = nil
-	backup.EmailVerificationCodes = nil
-	backup.AuthNonces = nil
-	backup.SafeWallets = nil
-	backup.ABIs = nil
-	backup.CompoundTimelocks = nil
-	backup.OpenzeppelinTimelocks = nil
-	backup.CompoundTimelockTransactions = nil
-	backup.OpenzeppelinTimelockTransactions = nil
-	backup.TimelockTransactionFlows = nil
-	backup.EmailSendLogs = nil
-	backup.TelegramConfigs = nil
-	backup.LarkConfigs = nil
-	backup.FeishuConfigs = nil
-	backup.NotificationLogs = nil
-	backup.BlockScanProgress = nil
-	backup.Sponsors = nil
+	archive, err := openBackupArchive(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
 
-	return &backup, nil
+	info := &BackupInfo{
+		Format:        format,
+		ID:            archive.manifest.ID,
+		Type:          archive.manifest.Type,
+		ParentID:      archive.manifest.ParentID,
+		Version:       archive.manifest.Version,
+		SchemaVersion: archive.manifest.SchemaVersion,
+		Timestamp:     archive.manifest.Timestamp,
+		Encrypted:     archive.encrypted(),
+	}
+	if archive.encrypted() {
+		for _, key := range archive.manifest.Encryption.Keys {
+			info.KeyTypes = append(info.KeyTypes, key.Type)
+		}
+	}
+	// 早期归档的清单没有类型字段，均为全量备份
+	if info.Type == "" {
+		info.Type = BackupTypeFull
+	}
+	for _, entry := range archive.manifest.Tables {
+		info.Tables = append(info.Tables, BackupTableInfo{Name: entry.Name, Rows: entry.Rows})
+	}
+	return info, nil
 }
@@ -0,0 +1,816 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// BackupFormatArchive tar归档格式：每张表一个gzip压缩的NDJSON文件，外加清单文件
+	BackupFormatArchive = "archive"
+	// BackupFormatLegacyJSON 旧版单文件JSON格式，仅支持恢复、校验和查看信息
+	BackupFormatLegacyJSON = "legacy-json"
+
+	backupArchiveFormat   = "timelocker-backup"
+	backupFormatVersion   = 1
+	backupDataVersion     = "2.0.0" // 表数据结构版本，与旧版JSON备份一致
+	backupManifestName    = "manifest.json"
+	backupManifestMACName = "manifest.mac" // 加密备份的清单HMAC，清单本身保持明文以便无需解密即可查看
+	backupCipher          = "aes-256-gcm-stream"
+	backupCompression     = "gzip"
+	backupReadBatchSize   = 1000  // 游标分页读取每页行数
+	backupInsertBatchSize = 500   // 恢复时每条INSERT的最大行数
+	maxInsertParams       = 65535 // PostgreSQL单条语句的参数上限
+
+	// BackupTypeFull 全量备份
+	BackupTypeFull = "full"
+	// BackupTypeIncremental 增量备份，只包含父备份水位之后新增或更新的行
+	BackupTypeIncremental = "incremental"
+	// BackupTypeArchive 历史数据清理前写入的归档，只包含被清理的行，不记录在备份目录中
+	BackupTypeArchive = "archive"
+)
+
+// BackupManifest 备份清单，记录每张表的行数和校验和
+type BackupManifest struct {
+	Format        string                `json:"format"`
+	FormatVersion int                   `json:"format_version"`
+	Version       string                `json:"version"`                  // 表数据结构版本
+	SchemaVersion int64                 `json:"schema_version,omitempty"` // 备份时数据库的迁移版本，恢复时据此转换数据
+	ID            string                `json:"id"`
+	Type          string                `json:"type"`                // full、incremental 或 archive
+	ParentID      string                `json:"parent_id,omitempty"` // 增量备份的父备份ID
+	Timestamp     time.Time             `json:"timestamp"`
+	Compression   string                `json:"compression"`
+	Encryption    *BackupEncryptionInfo `json:"encryption,omitempty"` // 未加密时为空
+	Tables        []BackupTableManifest `json:"tables"`               // 按恢复顺序排列
+}
+
+// BackupEncryptionInfo 加密备份的算法和包装后的文件密钥
+type BackupEncryptionInfo struct {
+	Cipher string                   `json:"cipher"`
+	Keys   []crypto.BackupKeyStanza `json:"keys"`
+}
+
+// BackupTableManifest 单张表在归档中的记录
+type BackupTableManifest struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`            // 归档内的文件名
+	Rows   int64  `json:"rows"`            // NDJSON行数
+	Size   int64  `json:"size"`            // 压缩后字节数
+	SHA256 string `json:"sha256"`          // 归档内实际存储内容（压缩或加密后）的SHA-256
+	Nonce  string `json:"nonce,omitempty"` // 加密备份中派生该表数据密钥的随机盐值
+
+	Watermark TableWatermark `json:"watermark"` // 备份完成时的水位，下一次增量备份从此处开始
+}
+
+// TableWatermark 表的增量备份水位
+// 有时间列的表按快照时间和最大ID判断变化，否则只按最大ID判断新增行；删除操作不会体现在增量备份中
+type TableWatermark struct {
+	Column string    `json:"column,omitempty"` // 用于判断更新的时间列，updated_at 或 created_at
+	Since  time.Time `json:"since,omitempty"`  // 备份快照开始时间
+	MaxID  int64     `json:"max_id"`
+}
+
+// BackupInfo 备份文件元信息
+type BackupInfo struct {
+	Format        string            `json:"format"`
+	Version       string            `json:"version"`
+	SchemaVersion int64             `json:"schema_version,omitempty"` // 为0时按旧版备份处理
+	ID            string            `json:"id,omitempty"`
+	Type          string            `json:"type"`
+	ParentID      string            `json:"parent_id,omitempty"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Encrypted     bool              `json:"encrypted"`
+	KeyTypes      []string          `json:"key_types,omitempty"` // 可解密的方式：scrypt口令或x25519接收方
+	Tables        []BackupTableInfo `json:"tables"`
+}
+
+// BackupTableInfo 备份中单张表的行数
+type BackupTableInfo struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// tableEntryName 表数据在归档内的文件名
+func tableEntryName(table string, encrypted bool) string {
+	name := path.Join("tables", table+".ndjson.gz")
+	if encrypted {
+		name += ".enc"
+	}
+	return name
+}
+
+// tableDataKey 派生表数据的加密密钥，每次写入使用新的随机盐值，避免重写时复用nonce
+func tableDataKey(fileKey []byte, entry *BackupTableManifest) ([]byte, error) {
+	salt, err := hex.DecodeString(entry.Nonce)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("invalid nonce for table %s", entry.Name)
+	}
+	return crypto.DeriveBackupKey(fileKey, salt, "table "+entry.Name)
+}
+
+// detectBackupFormat 根据文件头判断备份格式
+func detectBackupFormat(backupPath string) (string, error) {
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read backup file: %w", err)
+	}
+	header = header[:n]
+
+	// tar头部偏移257处为"ustar"魔数
+	if len(header) >= 262 && string(header[257:262]) == "ustar" {
+		return BackupFormatArchive, nil
+	}
+	if trimmed := bytes.TrimLeft(header, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+		return BackupFormatLegacyJSON, nil
+	}
+	return "", errors.New("unrecognized backup file format")
+}
+
+// backupRecordEmitter 逐行输出表记录
+type backupRecordEmitter func(record map[string]interface{}) error
+
+// writeTableEntry 将表记录写入压缩（fileKey非空时再加密）的临时文件并计算校验和，再追加到归档中
+// tar头需要预先知道文件大小，因此先落盘到与备份文件同目录的临时文件，内存占用与表大小无关
+func writeTableEntry(tw *tar.Writer, dir, table string, fileKey []byte, produce func(emit backupRecordEmitter) error) (BackupTableManifest, error) {
+	entry := BackupTableManifest{Name: table, File: tableEntryName(table, fileKey != nil)}
+
+	spool, err := os.CreateTemp(dir, ".backup-"+table+"-*.ndjson.gz")
+	if err != nil {
+		return entry, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	var out io.Writer = io.MultiWriter(spool, hash)
+	var encrypter io.WriteCloser
+	if fileKey != nil {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return entry, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		entry.Nonce = hex.EncodeToString(salt)
+		dataKey, err := tableDataKey(fileKey, &entry)
+		if err != nil {
+			return entry, err
+		}
+		encrypter = crypto.NewBackupEncryptWriter(out, dataKey)
+		out = encrypter
+	}
+
+	gz := gzip.NewWriter(out)
+	encoder := json.NewEncoder(gz)
+	err = produce(func(record map[string]interface{}) error {
+		entry.Rows++
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		return entry, err
+	}
+	if err := gz.Close(); err != nil {
+		return entry, fmt.Errorf("failed to compress table data: %w", err)
+	}
+	if encrypter != nil {
+		if err := encrypter.Close(); err != nil {
+			return entry, fmt.Errorf("failed to encrypt table data: %w", err)
+		}
+	}
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return entry, fmt.Errorf("failed to get temp file size: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return entry, fmt.Errorf("failed to rewind temp file: %w", err)
+	}
+	entry.Size = size
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    entry.File,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return entry, fmt.Errorf("failed to write archive header: %w", err)
+	}
+	if _, err := io.Copy(tw, spool); err != nil {
+		return entry, fmt.Errorf("failed to write archive entry: %w", err)
+	}
+	return entry, nil
+}
+
+// writeManifest 将清单写入归档末尾，加密备份再附加清单的HMAC
+func writeManifest(tw *tar.Writer, manifest *BackupManifest, fileKey []byte) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeArchiveEntry(tw, backupManifestName, data); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if fileKey == nil {
+		return nil
+	}
+
+	macKey, err := crypto.DeriveBackupKey(fileKey, nil, "manifest")
+	if err != nil {
+		return err
+	}
+	mac := hex.EncodeToString(crypto.BackupMAC(macKey, data))
+	if err := writeArchiveEntry(tw, backupManifestMACName, []byte(mac)); err != nil {
+		return fmt.Errorf("failed to write manifest mac: %w", err)
+	}
+	return nil
+}
+
+// writeArchiveEntry 写入一个小文件
+func writeArchiveEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// archiveFileInfo 写入完成的归档文件大小和SHA-256
+type archiveFileInfo struct {
+	Size   int64
+	SHA256 string
+}
+
+// writeArchiveFile 先写临时文件再替换目标文件，避免写入中断留下损坏的备份
+func writeArchiveFile(backupPath string, write func(tw *tar.Writer) error) (archiveFileInfo, error) {
+	var info archiveFileInfo
+	dir := filepath.Dir(backupPath)
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(backupPath)+".tmp-*")
+	if err != nil {
+		return info, fmt.Errorf("failed to create temp backup file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(tmpFile, hash)}
+	tw := tar.NewWriter(counter)
+	if err := write(tw); err != nil {
+		tmpFile.Close()
+		return info, err
+	}
+	if err := tw.Close(); err != nil {
+		tmpFile.Close()
+		return info, fmt.Errorf("failed to finalize backup archive: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return info, fmt.Errorf("failed to sync backup file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return info, fmt.Errorf("failed to close backup file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), backupPath); err != nil {
+		return info, fmt.Errorf("failed to replace backup file: %w", err)
+	}
+	info.Size = counter.n
+	info.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return info, nil
+}
+
+// countingWriter 统计写入字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// backupArchive 已打开的备份归档，加密备份需调用unlock后才能读取表数据
+type backupArchive struct {
+	file        *os.File
+	manifest    *BackupManifest
+	rawManifest []byte
+	manifestMAC []byte
+	fileKey     []byte
+}
+
+// openBackupArchive 打开备份归档并读取清单
+func openBackupArchive(backupPath string) (*backupArchive, error) {
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup file: %w", err)
+	}
+
+	archive := &backupArchive{file: file}
+	if err := archive.readManifest(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return archive, nil
+}
+
+// encrypted 备份是否加密
+func (a *backupArchive) encrypted() bool {
+	return a.manifest.Encryption != nil
+}
+
+// unlock 解出文件密钥并校验清单HMAC，清单被篡改时拒绝读取；未加密的备份直接返回
+func (a *backupArchive) unlock(encryption *crypto.BackupEncryption) error {
+	if !a.encrypted() || a.fileKey != nil {
+		return nil
+	}
+	if !encryption.CanDecrypt() {
+		return errors.New("backup is encrypted, configure a backup passphrase or identity file")
+	}
+	if a.manifestMAC == nil {
+		return fmt.Errorf("encrypted backup has no manifest mac: %w", crypto.ErrBackupTampered)
+	}
+
+	fileKey, err := encryption.UnwrapFileKey(a.manifest.Encryption.Keys)
+	if err != nil {
+		return err
+	}
+	macKey, err := crypto.DeriveBackupKey(fileKey, nil, "manifest")
+	if err != nil {
+		return err
+	}
+	if !crypto.VerifyBackupMAC(macKey, a.rawManifest, a.manifestMAC) {
+		return fmt.Errorf("manifest authentication failed: %w", crypto.ErrBackupTampered)
+	}
+	a.fileKey = fileKey
+	return nil
+}
+
+// Close 关闭归档文件
+func (a *backupArchive) Close() error {
+	return a.file.Close()
+}
+
+// readManifest 查找并解析清单及其HMAC，tar读取器对文件会直接跳过表数据
+func (a *backupArchive) readManifest() error {
+	tr := tar.NewReader(a.file)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+
+		switch header.Name {
+		case backupManifestName:
+			if a.rawManifest, err = io.ReadAll(tr); err != nil {
+				return fmt.Errorf("failed to read backup manifest: %w", err)
+			}
+		case backupManifestMACName:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read backup manifest mac: %w", err)
+			}
+			if a.manifestMAC, err = hex.DecodeString(strings.TrimSpace(string(data))); err != nil {
+				return fmt.Errorf("invalid backup manifest mac: %w", err)
+			}
+		}
+	}
+	if a.rawManifest == nil {
+		return errors.New("backup manifest not found")
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(a.rawManifest, &manifest); err != nil {
+		return fmt.Errorf("failed to decode backup manifest: %w", err)
+	}
+	if manifest.Format != backupArchiveFormat {
+		return fmt.Errorf("unsupported backup format %q", manifest.Format)
+	}
+	if manifest.FormatVersion > backupFormatVersion {
+		return fmt.Errorf("backup format version %d is newer than supported version %d", manifest.FormatVersion, backupFormatVersion)
+	}
+	if manifest.Compression != backupCompression {
+		return fmt.Errorf("unsupported backup compression %q", manifest.Compression)
+	}
+	if manifest.Encryption != nil && manifest.Encryption.Cipher != backupCipher {
+		return fmt.Errorf("unsupported backup cipher %q", manifest.Encryption.Cipher)
+	}
+	a.manifest = &manifest
+	return nil
+}
+
+// eachEntry 按归档顺序遍历表数据，fn读取的是压缩后的原始内容
+// 条目读完后才校验SHA-256，因此调用方需在事务中写入，校验失败时返回的错误会回滚已写入的数据
+func (a *backupArchive) eachEntry(fn func(entry *BackupTableManifest, raw io.Reader) error) error {
+	if _, err := a.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind backup file: %w", err)
+	}
+
+	entries := make(map[string]*BackupTableManifest, len(a.manifest.Tables))
+	for i := range a.manifest.Tables {
+		entries[a.manifest.Tables[i].File] = &a.manifest.Tables[i]
+	}
+	seen := make(map[string]bool, len(entries))
+
+	tr := tar.NewReader(a.file)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+		if header.Name == backupManifestName || header.Name == backupManifestMACName {
+			continue
+		}
+
+		entry, ok := entries[header.Name]
+		if !ok {
+			return fmt.Errorf("unexpected file in backup archive: %s", header.Name)
+		}
+		if seen[header.Name] {
+			return fmt.Errorf("duplicate file in backup archive: %s", header.Name)
+		}
+		seen[header.Name] = true
+		if header.Size != entry.Size {
+			return fmt.Errorf("size mismatch for table %s: manifest %d, archive %d", entry.Name, entry.Size, header.Size)
+		}
+
+		hash := sha256.New()
+		raw := io.TeeReader(tr, hash)
+		if err := fn(entry, raw); err != nil {
+			return err
+		}
+		// 调用方可能未读完全部内容，补齐后再计算校验和
+		if _, err := io.Copy(io.Discard, raw); err != nil {
+			return fmt.Errorf("failed to read table %s: %w", entry.Name, err)
+		}
+		if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != entry.SHA256 {
+			return fmt.Errorf("checksum mismatch for table %s", entry.Name)
+		}
+	}
+
+	for _, entry := range a.manifest.Tables {
+		if !seen[entry.File] {
+			return fmt.Errorf("table %s listed in manifest is missing from archive", entry.Name)
+		}
+	}
+	return nil
+}
+
+// eachTable 按归档顺序遍历表数据，逐行回调解密、解压后的记录，并校验行数
+func (a *backupArchive) eachTable(fn func(entry *BackupTableManifest, records func(emit backupRecordEmitter) error) error) error {
+	if a.encrypted() && a.fileKey == nil {
+		return errors.New("backup is encrypted and has not been unlocked")
+	}
+	return a.eachEntry(func(entry *BackupTableManifest, raw io.Reader) error {
+		return fn(entry, func(emit backupRecordEmitter) error {
+			rows, err := a.readEntryRecords(entry, raw, emit)
+			if err != nil {
+				return fmt.Errorf("failed to read table %s: %w", entry.Name, err)
+			}
+			if rows != entry.Rows {
+				return fmt.Errorf("row count mismatch for table %s: manifest %d, archive %d", entry.Name, entry.Rows, rows)
+			}
+			return nil
+		})
+	})
+}
+
+// readEntryRecords 按需解密后读取表记录
+func (a *backupArchive) readEntryRecords(entry *BackupTableManifest, raw io.Reader, emit backupRecordEmitter) (int64, error) {
+	if a.fileKey != nil {
+		dataKey, err := tableDataKey(a.fileKey, entry)
+		if err != nil {
+			return 0, err
+		}
+		raw = crypto.NewBackupDecryptReader(raw, dataKey)
+	}
+	return readTableRecords(raw, emit)
+}
+
+// readTableRecords 解压并逐行解析NDJSON记录，返回行数
+func readTableRecords(raw io.Reader, emit backupRecordEmitter) (int64, error) {
+	gz, err := gzip.NewReader(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decompress: %w", err)
+	}
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+	decoder.UseNumber()
+	var rows int64
+	for {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if err != nil {
+			return rows, fmt.Errorf("invalid record at line %d: %w", rows+1, err)
+		}
+		normalizeRecordNumbers(record)
+		rows++
+		if err := emit(record); err != nil {
+			return rows, err
+		}
+	}
+}
+
+// normalizeRecordNumbers 将json.Number转换为int64或float64，避免大整数ID丢失精度
+func normalizeRecordNumbers(record map[string]interface{}) {
+	for column, value := range record {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if i, err := number.Int64(); err == nil {
+			record[column] = i
+		} else if f, err := number.Float64(); err == nil {
+			record[column] = f
+		} else {
+			record[column] = number.String()
+		}
+	}
+}
+
+// tableFilter 增量备份时的行过滤条件
+type tableFilter struct {
+	where string
+	args  []interface{}
+}
+
+// streamTable 按主键游标分页读取表数据，逐行回调，返回读取到的最大id
+func (bm *BackupManager) streamTable(ctx context.Context, db *gorm.DB, tableName string, filter *tableFilter, emit backupRecordEmitter) (int64, error) {
+	var lastID int64
+	for {
+		rows, nextID, err := bm.readTablePage(ctx, db, tableName, filter, lastID, emit)
+		if err != nil {
+			return lastID, err
+		}
+		lastID = nextID
+		if rows < backupReadBatchSize {
+			return lastID, nil
+		}
+	}
+}
+
+// readTablePage 读取id大于lastID的一页数据，返回行数和最后一行的id
+func (bm *BackupManager) readTablePage(ctx context.Context, db *gorm.DB, tableName string, filter *tableFilter, lastID int64, emit backupRecordEmitter) (int, int64, error) {
+	query := db.WithContext(ctx).Table(tableName).Where("id > ?", lastID)
+	if filter != nil {
+		query = query.Where(filter.where, filter.args...)
+	}
+	rows, err := query.Order("id").Limit(backupReadBatchSize).Rows()
+	if err != nil {
+		return 0, lastID, fmt.Errorf("failed to query table %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, lastID, fmt.Errorf("failed to get columns for table %s: %w", tableName, err)
+	}
+
+	count := 0
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return count, lastID, fmt.Errorf("failed to scan row from table %s: %w", tableName, err)
+		}
+
+		record := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			record[col] = bm.encodeColumnValue(tableName, col, values[i])
+		}
+		id, ok := toInt64(record["id"])
+		if !ok {
+			return count, lastID, fmt.Errorf("table %s has no integer id column", tableName)
+		}
+		lastID = id
+		count++
+
+		if err := emit(record); err != nil {
+			return count, lastID, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return count, lastID, fmt.Errorf("failed to read table %s: %w", tableName, err)
+	}
+	return count, lastID, nil
+}
+
+// encodeColumnValue 转换扫描出的列值，BYTEA字段编码为0x前缀的十六进制字符串
+func (bm *BackupManager) encodeColumnValue(tableName, column string, val interface{}) interface{} {
+	b, ok := val.([]byte)
+	if !ok {
+		return val
+	}
+	if bm.isByteaField(tableName, column) {
+		return "0x" + hex.EncodeToString(b)
+	}
+	return string(b)
+}
+
+// decodeByteaValue 解析归档中0x前缀的十六进制BYTEA值
+func decodeByteaValue(val interface{}) (interface{}, error) {
+	s, ok := val.(string)
+	if !ok {
+		return val, nil
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("invalid bytea value: missing 0x prefix")
+	}
+	decoded, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytea value: %w", err)
+	}
+	return decoded, nil
+}
+
+// toInt64 将扫描或解析出的整数值转换为int64
+func toInt64(val interface{}) (int64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// tableRestorer 按列集合把记录攒成多行INSERT批量写入暂存表，同一id的记录以最后写入的为准
+type tableRestorer struct {
+	bm          *BackupManager
+	ctx         context.Context
+	tx          *gorm.DB
+	tableName   string
+	into        string // 暂存表
+	decodeBytea func(val interface{}) (interface{}, error)
+
+	columns []string
+	rows    [][]interface{}
+	maxRows int
+	staged  int64
+}
+
+// newTableRestorer 创建表恢复器，decodeBytea为BYTEA字段的解码方式
+func (bm *BackupManager) newTableRestorer(ctx context.Context, tx *gorm.DB, table *restoreTable, decodeBytea func(val interface{}) (interface{}, error)) *tableRestorer {
+	return &tableRestorer{
+		bm:          bm,
+		ctx:         ctx,
+		tx:          tx,
+		tableName:   table.name,
+		into:        table.staging,
+		decodeBytea: decodeBytea,
+	}
+}
+
+// add 添加一条记录，列集合变化或批次已满时先写入当前批次
+func (r *tableRestorer) add(record map[string]interface{}) error {
+	if len(record) == 0 {
+		return fmt.Errorf("empty record in table %s", r.tableName)
+	}
+	columns := make([]string, 0, len(record))
+	for col := range record {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	if !sameColumns(r.columns, columns) || len(r.rows) >= r.maxRows {
+		if err := r.flush(); err != nil {
+			return err
+		}
+		r.columns = columns
+		r.maxRows = backupInsertBatchSize
+		if limit := maxInsertParams / len(columns); limit < r.maxRows {
+			r.maxRows = limit
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, col := range columns {
+		val := record[col]
+		if r.bm.isByteaField(r.tableName, col) {
+			decoded, err := r.decodeBytea(val)
+			if err != nil {
+				return fmt.Errorf("table %s column %s: %w", r.tableName, col, err)
+			}
+			val = decoded
+		}
+		values[i] = val
+	}
+	r.rows = append(r.rows, values)
+	return nil
+}
+
+// flush 写入当前批次
+func (r *tableRestorer) flush() error {
+	if len(r.rows) == 0 {
+		return nil
+	}
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(r.columns)), ", ") + ")"
+	valueGroups := make([]string, len(r.rows))
+	args := make([]interface{}, 0, len(r.rows)*len(r.columns))
+	for i, row := range r.rows {
+		valueGroups[i] = placeholders
+		args = append(args, row...)
+	}
+
+	// 增量备份中更新过的行覆盖之前归档中的版本
+	var updates []string
+	for _, col := range r.columns {
+		if col != "id" {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
+		}
+	}
+	conflict := " ON CONFLICT (id) DO NOTHING"
+	if len(updates) > 0 {
+		conflict = " ON CONFLICT (id) DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s%s",
+		r.into,
+		strings.Join(r.columns, ", "),
+		strings.Join(valueGroups, ", "),
+		conflict)
+
+	if err := r.tx.WithContext(r.ctx).Exec(sql, args...).Error; err != nil {
+		logger.Error("Failed to stage batch", err, "table", r.tableName, "rows", len(r.rows))
+		return fmt.Errorf("failed to stage rows of %s: %w", r.tableName, err)
+	}
+	r.staged += int64(len(r.rows))
+	r.rows = r.rows[:0]
+	return nil
+}
+
+// sameColumns 判断两组已排序的列是否一致
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyTableEntry 将未修改的表数据原样复制到新归档
+func copyTableEntry(tw *tar.Writer, entry *BackupTableManifest, raw io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    entry.File,
+		Mode:    0600,
+		Size:    entry.Size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write archive header: %w", err)
+	}
+	if _, err := io.Copy(tw, raw); err != nil {
+		return fmt.Errorf("failed to copy table %s: %w", entry.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// BackupCatalogName 备份目录中的备份目录文件名
+const BackupCatalogName = "catalog.json"
+
+// BackupCatalog 备份目录，记录备份目录下每个归档的类型、父备份和各表水位，用于增量备份和链式恢复
+// 本地备份目录和远程存储中各有一份，远程目录只由备份任务更新，不支持多个备份任务并发写入
+type BackupCatalog struct {
+	storage BackupStorage
+	dir     string               // 本地备份目录，远程目录为空
+	Backups []BackupCatalogEntry `json:"backups"`
+}
+
+// BackupCatalogEntry 备份目录中的一个归档
+type BackupCatalogEntry struct {
+	ID         string                    `json:"id"`
+	File       string                    `json:"file"` // 相对备份目录的文件名
+	Type       string                    `json:"type"`
+	ParentID   string                    `json:"parent_id,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	Tables     []string                  `json:"tables"`
+	Rows       int64                     `json:"rows"`
+	Size       int64                     `json:"size"`
+	SHA256     string                    `json:"sha256"`
+	Encrypted  bool                      `json:"encrypted"`
+	Watermarks map[string]TableWatermark `json:"watermarks"`
+}
+
+// newBackupID 生成备份ID：UTC时间加随机后缀
+func newBackupID(now time.Time) string {
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	return now.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
+
+// LoadBackupCatalog 读取本地备份目录中的目录文件，文件不存在时返回空目录
+func LoadBackupCatalog(dir string) (*BackupCatalog, error) {
+	catalog, err := LoadStorageCatalog(context.Background(), NewLocalBackupStorage(dir))
+	if err != nil {
+		return nil, err
+	}
+	catalog.dir = dir
+	return catalog, nil
+}
+
+// LoadStorageCatalog 读取备份存储中的目录文件，文件不存在时返回空目录
+func LoadStorageCatalog(ctx context.Context, storage BackupStorage) (*BackupCatalog, error) {
+	catalog := &BackupCatalog{storage: storage}
+	reader, err := storage.Get(ctx, BackupCatalogName)
+	if errors.Is(err, ErrBackupObjectNotFound) {
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup catalog: %w", err)
+	}
+	defer reader.Close()
+
+	if err := json.NewDecoder(reader).Decode(catalog); err != nil {
+		return nil, fmt.Errorf("failed to decode backup catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// save 按创建时间排序后写回存储
+func (c *BackupCatalog) save(ctx context.Context) error {
+	sort.Slice(c.Backups, func(i, j int) bool {
+		return c.Backups[i].CreatedAt.Before(c.Backups[j].CreatedAt)
+	})
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode backup catalog: %w", err)
+	}
+	data = append(data, '\n')
+	if err := c.storage.Put(ctx, BackupCatalogName, bytes.NewReader(data), int64(len(data))); err != nil {
+		return fmt.Errorf("failed to save backup catalog: %w", err)
+	}
+	return nil
+}
+
+// put 添加或替换归档记录
+func (c *BackupCatalog) put(entry BackupCatalogEntry) {
+	for i := range c.Backups {
+		if c.Backups[i].ID == entry.ID {
+			c.Backups[i] = entry
+			return
+		}
+	}
+	c.Backups = append(c.Backups, entry)
+}
+
+// Find 按ID查找归档
+func (c *BackupCatalog) Find(id string) *BackupCatalogEntry {
+	for i := range c.Backups {
+		if c.Backups[i].ID == id {
+			return &c.Backups[i]
+		}
+	}
+	return nil
+}
+
+// Latest 返回最近创建的归档，目录为空时返回nil
+func (c *BackupCatalog) Latest() *BackupCatalogEntry {
+	var latest *BackupCatalogEntry
+	for i := range c.Backups {
+		if latest == nil || c.Backups[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &c.Backups[i]
+		}
+	}
+	return latest
+}
+
+// Chain 返回恢复指定归档所需的归档链，从全量备份开始按顺序排列
+func (c *BackupCatalog) Chain(id string) ([]*BackupCatalogEntry, error) {
+	var chain []*BackupCatalogEntry
+	seen := make(map[string]bool)
+	for current := id; current != ""; {
+		if seen[current] {
+			return nil, fmt.Errorf("backup chain of %s contains a cycle", id)
+		}
+		seen[current] = true
+
+		entry := c.Find(current)
+		if entry == nil {
+			return nil, fmt.Errorf("backup %s not found in catalog %s", current, c.location())
+		}
+		chain = append([]*BackupCatalogEntry{entry}, chain...)
+		if entry.Type != BackupTypeIncremental {
+			break
+		}
+		if entry.ParentID == "" {
+			return nil, fmt.Errorf("incremental backup %s has no parent", entry.ID)
+		}
+		current = entry.ParentID
+	}
+	return chain, nil
+}
+
+// remove 删除归档记录
+func (c *BackupCatalog) remove(id string) {
+	for i := range c.Backups {
+		if c.Backups[i].ID == id {
+			c.Backups = append(c.Backups[:i], c.Backups[i+1:]...)
+			return
+		}
+	}
+}
+
+// Path 返回本地目录中归档文件的完整路径
+func (c *BackupCatalog) Path(entry *BackupCatalogEntry) string {
+	return filepath.Join(c.dir, entry.File)
+}
+
+// catalogEntryFromManifest 根据清单生成目录记录
+func catalogEntryFromManifest(backupPath string, manifest *BackupManifest, file archiveFileInfo) BackupCatalogEntry {
+	entry := BackupCatalogEntry{
+		ID:         manifest.ID,
+		File:       filepath.Base(backupPath),
+		Type:       manifest.Type,
+		ParentID:   manifest.ParentID,
+		CreatedAt:  manifest.Timestamp,
+		Size:       file.Size,
+		SHA256:     file.SHA256,
+		Encrypted:  manifest.Encryption != nil,
+		Watermarks: make(map[string]TableWatermark, len(manifest.Tables)),
+	}
+	for _, table := range manifest.Tables {
+		entry.Tables = append(entry.Tables, table.Name)
+		entry.Rows += table.Rows
+		entry.Watermarks[table.Name] = table.Watermark
+	}
+	return entry
+}
+
+// location 返回目录文件位置，用于错误信息
+func (c *BackupCatalog) location() string {
+	if c.dir != "" {
+		return filepath.Join(c.dir, BackupCatalogName)
+	}
+	return c.storage.Location() + "/" + BackupCatalogName
+}
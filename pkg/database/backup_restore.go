@@ -0,0 +1,645 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// ErrRestoreValidation 恢复前校验未通过，数据库未被修改
+var ErrRestoreValidation = errors.New("restore validation failed")
+
+// errRestoreDryRun 试运行校验完成后用于回滚事务
+var errRestoreDryRun = errors.New("restore dry run")
+
+// restoreSampleSize 每个问题在报告中列出的示例行数
+const restoreSampleSize = 5
+
+// 恢复校验问题类型
+const (
+	RestoreIssueUnknownColumn = "unknown_column" // 备份中有数据库不存在的列，需要登记对应的schema转换
+	RestoreIssueNotNull       = "not_null"       // 非空列没有值
+	RestoreIssueCheck         = "check"          // 违反检查约束
+	RestoreIssueDuplicate     = "duplicate"      // 备份数据内部违反唯一约束
+	RestoreIssueConflict      = "conflict"       // 与保留的现有数据违反唯一约束，且冲突策略无法处理
+	RestoreIssueForeignKey    = "foreign_key"    // 引用的行既不在备份中也不在保留的现有数据中
+	RestoreIssueArbiter       = "arbiter"        // replace策略的冲突检测列没有对应的唯一索引
+)
+
+// RestoreReport 恢复前的校验报告，存在问题时不写入任何数据
+type RestoreReport struct {
+	BackupSchemaVersion int64                `json:"backup_schema_version"`
+	SchemaVersion       int64                `json:"schema_version"`       // 数据库当前schema版本
+	Transforms          []string             `json:"transforms,omitempty"` // 应用的schema转换
+	DryRun              bool                 `json:"dry_run"`
+	Tables              []RestoreTableReport `json:"tables"`
+}
+
+// RestoreTableReport 单张表的校验和写入结果
+type RestoreTableReport struct {
+	Name      string            `json:"name"`
+	Rows      int64             `json:"rows"`    // 备份中待写入的行数，同一id只计最新版本
+	Written   int64             `json:"written"` // 实际写入或替换的行数，试运行时为0
+	Note      string            `json:"note,omitempty"`
+	Conflicts []RestoreConflict `json:"conflicts,omitempty"`
+	Issues    []RestoreIssue    `json:"issues,omitempty"`
+}
+
+// RestoreConflict 与现有数据冲突、按冲突策略跳过或替换的行，不阻止恢复
+type RestoreConflict struct {
+	Constraint string         `json:"constraint"`
+	Action     ConflictAction `json:"action"`
+	Rows       int64          `json:"rows"`
+}
+
+// RestoreIssue 阻止恢复的问题
+type RestoreIssue struct {
+	Kind       string  `json:"kind"`
+	Constraint string  `json:"constraint"` // 约束名或列名
+	Rows       int64   `json:"rows"`
+	SampleIDs  []int64 `json:"sample_ids,omitempty"` // 备份中有问题的行id示例
+	Message    string  `json:"message"`
+}
+
+// Valid 报告中没有阻止恢复的问题
+func (r *RestoreReport) Valid() bool {
+	for _, table := range r.Tables {
+		if len(table.Issues) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IssueMessages 按"表: 问题"格式列出全部问题
+func (r *RestoreReport) IssueMessages() []string {
+	var messages []string
+	for _, table := range r.Tables {
+		for _, issue := range table.Issues {
+			messages = append(messages, fmt.Sprintf("%s: %s (%d rows)", table.Name, issue.Message, issue.Rows))
+		}
+	}
+	return messages
+}
+
+// restoreColumn 目标表的列
+type restoreColumn struct {
+	Name     string
+	Nullable bool
+}
+
+// uniqueIndex 目标表的唯一索引（含主键），Predicate为部分索引的条件
+type uniqueIndex struct {
+	Name      string
+	Columns   string
+	Predicate string
+}
+
+func (u uniqueIndex) columns() []string {
+	return strings.Split(u.Columns, ",")
+}
+
+// where 部分索引的条件，普通索引返回TRUE
+func (u uniqueIndex) where() string {
+	if u.Predicate == "" {
+		return "TRUE"
+	}
+	return u.Predicate
+}
+
+// checkConstraint 目标表的检查约束
+type checkConstraint struct {
+	Name       string
+	Expression string
+}
+
+// foreignKey 目标表的外键
+type foreignKey struct {
+	Name       string
+	Columns    string
+	RefTable   string
+	RefColumns string
+}
+
+// restoreTable 一张待恢复表的结构、暂存表和校验结果
+type restoreTable struct {
+	name        string
+	staging     string
+	columns     []restoreColumn
+	known       map[string]bool
+	uniques     []uniqueIndex
+	checks      []checkConstraint
+	foreignKeys []foreignKey
+	arbiter     *uniqueIndex     // replace策略使用的唯一索引
+	unknown     map[string]int64 // 备份中有但数据库没有的列及行数
+	clear       bool             // 使用-clear时是否清空
+	inBackup    bool             // 备份中是否包含该表
+	report      *RestoreTableReport
+}
+
+// filter 移除数据库中不存在的列并计数，记录仍会写入暂存表以便继续校验其他约束
+func (t *restoreTable) filter(record map[string]interface{}) map[string]interface{} {
+	for column := range record {
+		if !t.known[column] {
+			t.unknown[column]++
+			delete(record, column)
+		}
+	}
+	return record
+}
+
+// restorePlan 恢复过程：先把备份数据写入同结构的临时表，在数据库中完成校验后再写入目标表
+type restorePlan struct {
+	bm      *BackupManager
+	ctx     context.Context
+	tx      *gorm.DB
+	options RestoreOptions
+	tables  []*restoreTable
+	byName  map[string]*restoreTable
+}
+
+// newRestorePlan 读取待恢复表的结构并创建暂存表，暂存表在事务结束时删除
+func (bm *BackupManager) newRestorePlan(ctx context.Context, tx *gorm.DB, tables []string, options RestoreOptions, schema *schemaPlan, report *RestoreReport) (*restorePlan, error) {
+	plan := &restorePlan{
+		bm:      bm,
+		ctx:     ctx,
+		tx:      tx,
+		options: options,
+		byName:  make(map[string]*restoreTable, len(tables)),
+	}
+	report.Tables = make([]RestoreTableReport, len(tables))
+	for i, name := range tables {
+		report.Tables[i].Name = name
+		table, err := plan.loadTable(name)
+		if err != nil {
+			return nil, err
+		}
+		table.report = &report.Tables[i]
+		// 备份之后才新增的表在备份中没有数据，保留现有数据
+		if version := schema.newTable(name); version > 0 {
+			table.clear = false
+			table.report.Note = fmt.Sprintf("added in schema %04d, not in backup; existing rows are kept", version)
+		}
+		if err := plan.createStaging(table); err != nil {
+			return nil, err
+		}
+		plan.tables = append(plan.tables, table)
+		plan.byName[name] = table
+	}
+	return plan, nil
+}
+
+// loadTable 查询表的列、唯一索引、检查约束和外键
+func (p *restorePlan) loadTable(name string) (*restoreTable, error) {
+	table := &restoreTable{
+		name:    name,
+		staging: "restore_staging_" + name,
+		known:   make(map[string]bool),
+		unknown: make(map[string]int64),
+		clear:   true,
+	}
+	db := p.tx.WithContext(p.ctx)
+
+	if err := db.Raw(`SELECT column_name AS name, is_nullable = 'YES' AS nullable
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ?
+		ORDER BY ordinal_position`, name).Scan(&table.columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", name, err)
+	}
+	if len(table.columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist, run migrations first", name)
+	}
+	for _, column := range table.columns {
+		table.known[column.Name] = true
+	}
+
+	if err := db.Raw(`SELECT i.relname AS name,
+			array_to_string(ARRAY(
+				SELECT a.attname FROM unnest(x.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = x.indrelid AND a.attnum = k.attnum
+				ORDER BY k.ord), ',') AS columns,
+			COALESCE(pg_get_expr(x.indpred, x.indrelid), '') AS predicate
+		FROM pg_index x JOIN pg_class i ON i.oid = x.indexrelid
+		WHERE x.indrelid = ?::regclass AND x.indisunique AND x.indexprs IS NULL
+		ORDER BY i.relname`, name).Scan(&table.uniques).Error; err != nil {
+		return nil, fmt.Errorf("failed to read unique indexes of %s: %w", name, err)
+	}
+
+	if err := db.Raw(`SELECT conname AS name, pg_get_expr(conbin, conrelid) AS expression
+		FROM pg_constraint WHERE conrelid = ?::regclass AND contype = 'c'
+		ORDER BY conname`, name).Scan(&table.checks).Error; err != nil {
+		return nil, fmt.Errorf("failed to read check constraints of %s: %w", name, err)
+	}
+
+	if err := db.Raw(`SELECT c.conname AS name, c.confrelid::regclass::text AS ref_table,
+			array_to_string(ARRAY(
+				SELECT a.attname FROM unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+				ORDER BY k.ord), ',') AS columns,
+			array_to_string(ARRAY(
+				SELECT a.attname FROM unnest(c.confkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum
+				ORDER BY k.ord), ',') AS ref_columns
+		FROM pg_constraint c WHERE c.conrelid = ?::regclass AND c.contype = 'f'
+		ORDER BY c.conname`, name).Scan(&table.foreignKeys).Error; err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys of %s: %w", name, err)
+	}
+
+	if p.options.OnConflict == ConflictReplace {
+		table.arbiter = findArbiter(table.uniques, p.bm.getConflictColumns(name))
+	}
+	return table, nil
+}
+
+// findArbiter 查找与冲突检测列一致的唯一索引，优先使用普通索引
+func findArbiter(uniques []uniqueIndex, conflictColumns string) *uniqueIndex {
+	want := strings.Split(strings.ReplaceAll(conflictColumns, " ", ""), ",")
+	sort.Strings(want)
+
+	var partial *uniqueIndex
+	for i := range uniques {
+		columns := uniques[i].columns()
+		sort.Strings(columns)
+		if !sameColumns(columns, want) {
+			continue
+		}
+		if uniques[i].Predicate == "" {
+			return &uniques[i]
+		}
+		if partial == nil {
+			partial = &uniques[i]
+		}
+	}
+	return partial
+}
+
+// createStaging 创建与目标表同结构的临时表，只保留列默认值和id主键，其余约束由校验检查
+func (p *restorePlan) createStaging(table *restoreTable) error {
+	db := p.tx.WithContext(p.ctx)
+	if err := db.Exec(fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP", table.staging, table.name)).Error; err != nil {
+		return fmt.Errorf("failed to create staging table for %s: %w", table.name, err)
+	}
+
+	var alters []string
+	for _, column := range table.columns {
+		if !column.Nullable && column.Name != "id" {
+			alters = append(alters, fmt.Sprintf("ALTER COLUMN %s DROP NOT NULL", column.Name))
+		}
+	}
+	alters = append(alters, "ADD PRIMARY KEY (id)")
+	if err := db.Exec(fmt.Sprintf("ALTER TABLE %s %s", table.staging, strings.Join(alters, ", "))).Error; err != nil {
+		return fmt.Errorf("failed to prepare staging table for %s: %w", table.name, err)
+	}
+	return nil
+}
+
+// stageArchive 将归档中所选表的数据转换后写入暂存表，增量备份中的行覆盖之前的同id行
+func (p *restorePlan) stageArchive(archive *backupArchive, schema *schemaPlan) error {
+	logger.Info("Staging backup archive",
+		"id", archive.manifest.ID,
+		"type", archive.manifest.Type,
+		"schema_version", schema.from,
+		"timestamp", archive.manifest.Timestamp,
+	)
+
+	return archive.eachTable(func(entry *BackupTableManifest, records func(emit backupRecordEmitter) error) error {
+		// 未选择的表仍会被读完以校验校验和
+		table, ok := p.byName[entry.Name]
+		if !ok {
+			return nil
+		}
+		table.inBackup = true
+		logger.Info("Staging table", "table", entry.Name, "records", entry.Rows)
+
+		restorer := p.bm.newTableRestorer(p.ctx, p.tx, table, decodeByteaValue)
+		err := records(func(record map[string]interface{}) error {
+			if !matchesOwner(entry.Name, record, p.options.UserAddress) {
+				return nil
+			}
+			schema.apply(entry.Name, record)
+			return restorer.add(table.filter(record))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stage %s: %w", entry.Name, err)
+		}
+		if err := restorer.flush(); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", entry.Name, err)
+		}
+		logger.Info("Table staged", "table", entry.Name, "staged", restorer.staged)
+		return nil
+	})
+}
+
+// stageLegacy 将旧版JSON备份中所选表的数据转换后写入暂存表
+func (p *restorePlan) stageLegacy(backup *BackupData, schema *schemaPlan) error {
+	tableRecords := legacyTableRecords(backup)
+	for _, table := range p.tables {
+		records, ok := tableRecords[table.name]
+		table.inBackup = ok
+		if len(records) == 0 {
+			continue
+		}
+		logger.Info("Staging table", "table", table.name, "records", len(records))
+
+		// 旧版备份的BYTEA字段编码不统一，沿用兼容解析
+		restorer := p.bm.newTableRestorer(p.ctx, p.tx, table, func(val interface{}) (interface{}, error) {
+			return p.bm.processByteaField(val), nil
+		})
+		for _, record := range records {
+			if !matchesOwner(table.name, record, p.options.UserAddress) {
+				continue
+			}
+			schema.apply(table.name, record)
+			if err := restorer.add(table.filter(record)); err != nil {
+				return fmt.Errorf("failed to stage %s: %w", table.name, err)
+			}
+		}
+		if err := restorer.flush(); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", table.name, err)
+		}
+		logger.Info("Table staged", "table", table.name, "staged", restorer.staged)
+	}
+	return nil
+}
+
+// keepMissing 备份中没有的表（例如登记到备份之前创建的备份）不清空，保留现有数据
+func (p *restorePlan) keepMissing() {
+	for _, table := range p.tables {
+		if table.clear && !table.inBackup {
+			table.clear = false
+			table.report.Note = "not in backup; existing rows are kept"
+		}
+	}
+}
+
+// validate 在暂存数据上检查列、非空、检查约束、唯一约束、与现有数据的冲突和外键，结果写入报告
+func (p *restorePlan) validate() error {
+	for _, table := range p.tables {
+		if err := p.tx.WithContext(p.ctx).Raw(fmt.Sprintf("SELECT count(*) FROM %s", table.staging)).Scan(&table.report.Rows).Error; err != nil {
+			return fmt.Errorf("failed to count staged rows of %s: %w", table.name, err)
+		}
+
+		columns := make([]string, 0, len(table.unknown))
+		for column := range table.unknown {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		for _, column := range columns {
+			table.report.Issues = append(table.report.Issues, RestoreIssue{
+				Kind:       RestoreIssueUnknownColumn,
+				Constraint: column,
+				Rows:       table.unknown[column],
+				Message:    fmt.Sprintf("column %s does not exist in the database schema", column),
+			})
+		}
+		if table.report.Rows == 0 {
+			continue
+		}
+
+		checks := []func(*restoreTable) error{p.checkNotNull, p.checkConstraints, p.checkUnique, p.checkConflicts, p.checkForeignKeys}
+		for _, check := range checks {
+			if err := check(table); err != nil {
+				return fmt.Errorf("failed to validate %s: %w", table.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkNotNull 非空列在暂存数据中不能为NULL（备份缺少该列且没有默认值时也会出现）
+func (p *restorePlan) checkNotNull(table *restoreTable) error {
+	for _, column := range table.columns {
+		if column.Nullable || column.Name == "id" {
+			continue
+		}
+		query := fmt.Sprintf("SELECT id FROM %s WHERE %s IS NULL", table.staging, column.Name)
+		if err := p.addIssue(table, RestoreIssueNotNull, column.Name, fmt.Sprintf("column %s is required but missing", column.Name), query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConstraints 检查约束，表达式结果为NULL时与PostgreSQL一致视为通过
+func (p *restorePlan) checkConstraints(table *restoreTable) error {
+	for _, check := range table.checks {
+		query := fmt.Sprintf("SELECT id FROM %s WHERE NOT (%s)", table.staging, check.Expression)
+		if err := p.addIssue(table, RestoreIssueCheck, check.Name, fmt.Sprintf("violates check constraint %s", check.Name), query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnique 备份数据内部的唯一约束，部分索引只检查满足条件的行，含NULL的键不冲突
+func (p *restorePlan) checkUnique(table *restoreTable) error {
+	for _, unique := range table.uniques {
+		if unique.Columns == "id" {
+			continue
+		}
+		columns := unique.columns()
+		notNull := make([]string, len(columns))
+		for i, column := range columns {
+			notNull[i] = column + " IS NOT NULL"
+		}
+		query := fmt.Sprintf("SELECT min(id) AS id FROM %s WHERE (%s) AND %s GROUP BY %s HAVING count(*) > 1",
+			table.staging, unique.where(), strings.Join(notNull, " AND "), strings.Join(columns, ", "))
+		message := fmt.Sprintf("backup contains duplicate (%s) for unique index %s", unique.Columns, unique.Name)
+		if err := p.addIssue(table, RestoreIssueDuplicate, unique.Name, message, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConflicts 与写入后仍保留的现有数据比较唯一约束
+// skip策略跳过所有冲突行；replace策略替换冲突检测索引匹配的行，其他唯一索引与另一行冲突时报错；error策略任何冲突都报错
+func (p *restorePlan) checkConflicts(table *restoreTable) error {
+	existing, args, ok := p.existingRows(table.name, "e")
+	if !ok {
+		return nil
+	}
+
+	action := p.options.OnConflict
+	if action == ConflictReplace && table.arbiter == nil {
+		table.report.Issues = append(table.report.Issues, RestoreIssue{
+			Kind:       RestoreIssueArbiter,
+			Constraint: p.bm.getConflictColumns(table.name),
+			Rows:       table.report.Rows,
+			Message:    fmt.Sprintf("no unique index on (%s) to replace conflicting rows, use -conflict=skip or -clear", p.bm.getConflictColumns(table.name)),
+		})
+		return nil
+	}
+
+	for _, unique := range table.uniques {
+		// 部分索引的条件不带表名，分别在只有一张表的子查询中过滤
+		query := fmt.Sprintf("SELECT s.id FROM (SELECT * FROM %s WHERE %s) s JOIN (SELECT * FROM %s e WHERE (%s) AND %s) e ON %s",
+			table.staging, unique.where(), table.name, unique.where(), existing, joinColumns("s", "e", unique.columns(), unique.columns()))
+
+		switch {
+		case action == ConflictSkip || (action == ConflictReplace && unique.Name == table.arbiter.Name):
+			count, _, err := p.collect(query, args...)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				table.report.Conflicts = append(table.report.Conflicts, RestoreConflict{Constraint: unique.Name, Action: action, Rows: count})
+			}
+		case action == ConflictReplace:
+			// 冲突的现有行不是替换目标时，替换后仍然违反该唯一索引；备份行不满足部分索引条件时也不会替换
+			arbiterRows, arbiterArgs, _ := p.existingRows(table.name, "a")
+			query += fmt.Sprintf(" WHERE NOT (s.id IN (SELECT id FROM %s WHERE %s) AND EXISTS (SELECT 1 FROM %s a WHERE (%s) AND %s AND %s AND a.id = e.id))",
+				table.staging, table.arbiter.where(), table.name, table.arbiter.where(), arbiterRows, joinColumns("s", "a", table.arbiter.columns(), table.arbiter.columns()))
+			message := fmt.Sprintf("conflicts on %s with an existing row other than the one replaced by %s", unique.Name, table.arbiter.Name)
+			if err := p.addIssue(table, RestoreIssueConflict, unique.Name, message, query, append(append([]interface{}{}, args...), arbiterArgs...)...); err != nil {
+				return err
+			}
+		default:
+			message := fmt.Sprintf("conflicts with existing rows on %s", unique.Name)
+			if err := p.addIssue(table, RestoreIssueConflict, unique.Name, message, query, args...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkForeignKeys 外键引用的行必须在备份中或在写入后仍保留的现有数据中
+func (p *restorePlan) checkForeignKeys(table *restoreTable) error {
+	for _, fk := range table.foreignKeys {
+		columns := strings.Split(fk.Columns, ",")
+		refColumns := strings.Split(fk.RefColumns, ",")
+		conditions := make([]string, 0, len(columns)+2)
+		for _, column := range columns {
+			conditions = append(conditions, "s."+column+" IS NOT NULL")
+		}
+
+		var args []interface{}
+		if existing, existingArgs, ok := p.existingRows(fk.RefTable, "r"); ok {
+			conditions = append(conditions, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s r WHERE %s AND %s)",
+				fk.RefTable, joinColumns("s", "r", columns, refColumns), existing))
+			args = append(args, existingArgs...)
+		}
+		if ref, ok := p.byName[fk.RefTable]; ok {
+			conditions = append(conditions, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s r WHERE %s)",
+				ref.staging, joinColumns("s", "r", columns, refColumns)))
+		}
+
+		query := fmt.Sprintf("SELECT s.id FROM %s s WHERE %s", table.staging, strings.Join(conditions, " AND "))
+		message := fmt.Sprintf("references missing rows in %s(%s) via %s", fk.RefTable, fk.RefColumns, fk.Name)
+		if err := p.addIssue(table, RestoreIssueForeignKey, fk.Name, message, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// existingRows 返回写入后仍保留的现有行的条件（alias为表别名），表会被整体清空时ok为false
+func (p *restorePlan) existingRows(tableName, alias string) (string, []interface{}, bool) {
+	table, selected := p.byName[tableName]
+	if !p.options.ClearExisting || !selected || !table.clear {
+		return "TRUE", nil, true
+	}
+	if p.options.UserAddress == "" {
+		return "", nil, false
+	}
+	return fmt.Sprintf("LOWER(%s.%s) <> LOWER(?)", alias, backupTableOwnerColumns[tableName]), []interface{}{p.options.UserAddress}, true
+}
+
+// addIssue 执行返回暂存表id的查询，有结果时记录问题
+func (p *restorePlan) addIssue(table *restoreTable, kind, constraint, message, query string, args ...interface{}) error {
+	count, samples, err := p.collect(query, args...)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		table.report.Issues = append(table.report.Issues, RestoreIssue{
+			Kind:       kind,
+			Constraint: constraint,
+			Rows:       count,
+			SampleIDs:  samples,
+			Message:    message,
+		})
+	}
+	return nil
+}
+
+// collect 统计查询返回的不同id数并取前几个作为示例
+func (p *restorePlan) collect(query string, args ...interface{}) (int64, []int64, error) {
+	rows, err := p.tx.WithContext(p.ctx).
+		Raw(fmt.Sprintf("SELECT count(*) OVER () AS total, id FROM (SELECT DISTINCT id FROM (%s) q) d ORDER BY id LIMIT %d", query, restoreSampleSize), args...).
+		Rows()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		total   int64
+		samples []int64
+	)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&total, &id); err != nil {
+			return 0, nil, err
+		}
+		samples = append(samples, id)
+	}
+	return total, samples, rows.Err()
+}
+
+// joinColumns 生成两个别名之间按列相等的条件
+func joinColumns(left, right string, leftColumns, rightColumns []string) string {
+	conditions := make([]string, len(leftColumns))
+	for i := range leftColumns {
+		conditions[i] = fmt.Sprintf("%s.%s = %s.%s", left, leftColumns[i], right, rightColumns[i])
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// clearTables 使用-clear时需要清空的表
+func (p *restorePlan) clearTables() []string {
+	tables := make([]string, 0, len(p.tables))
+	for _, table := range p.tables {
+		if table.clear {
+			tables = append(tables, table.name)
+		}
+	}
+	return tables
+}
+
+// write 按依赖顺序把暂存数据写入目标表
+func (p *restorePlan) write() error {
+	for _, table := range p.tables {
+		if table.report.Rows == 0 {
+			continue
+		}
+		columns := make([]string, len(table.columns))
+		for i, column := range table.columns {
+			columns[i] = column.Name
+		}
+
+		var predicate string
+		if table.arbiter != nil {
+			predicate = table.arbiter.Predicate
+		}
+		list := strings.Join(columns, ", ")
+		sql := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s ORDER BY id%s",
+			table.name, list, list, table.staging, p.bm.conflictClause(table.name, columns, p.options.OnConflict, predicate))
+
+		result := p.tx.WithContext(p.ctx).Exec(sql)
+		if result.Error != nil {
+			logger.Error("Failed to restore table", result.Error, "table", table.name)
+			return fmt.Errorf("failed to restore %s: %w", table.name, result.Error)
+		}
+		table.report.Written = result.RowsAffected
+		logger.Info("Table restore completed", "table", table.name, "rows", table.report.Rows, "written", result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/logger"
+)
+
+// retentionEnabled 是否配置了保留策略
+func retentionEnabled(retention config.BackupRetentionConfig) bool {
+	return retention.Daily > 0 || retention.Weekly > 0 || retention.Monthly > 0
+}
+
+// retainedBackups 按保留策略计算需要保留的备份ID
+// 每个日/周/月周期保留该周期内最新的备份，保留最近N个有备份的周期；最新备份始终保留
+// 被保留的增量备份所在的备份链（直到全量备份）全部保留，否则无法恢复
+func retainedBackups(entries []BackupCatalogEntry, retention config.BackupRetentionConfig) map[string]bool {
+	sorted := make([]BackupCatalogEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	keep := make(map[string]bool)
+	if len(sorted) > 0 {
+		keep[sorted[0].ID] = true
+	}
+
+	periods := []struct {
+		count  int
+		period func(t time.Time) string
+	}{
+		{retention.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{retention.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{retention.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, p := range periods {
+		seen := make(map[string]bool)
+		for _, entry := range sorted {
+			if len(seen) >= p.count {
+				break
+			}
+			key := p.period(entry.CreatedAt.UTC())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keep[entry.ID] = true
+		}
+	}
+
+	byID := make(map[string]BackupCatalogEntry, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	for id := range keep {
+		for parent := byID[id].ParentID; parent != "" && !keep[parent]; parent = byID[parent].ParentID {
+			keep[parent] = true
+		}
+	}
+	return keep
+}
+
+// ApplyRetention 按保留策略清理旧备份，配置了远程存储时清理远程存储，否则清理本地备份目录
+// 先更新目录文件再删除归档，中途失败只会留下未被引用的归档文件
+func (bm *BackupManager) ApplyRetention(ctx context.Context, backupDir string, retention config.BackupRetentionConfig) ([]BackupCatalogEntry, error) {
+	if !retentionEnabled(retention) {
+		logger.Info("Backup retention policy not configured, skip pruning")
+		return nil, nil
+	}
+
+	storage := bm.storage
+	if storage == nil {
+		storage = NewLocalBackupStorage(backupDir)
+	}
+	logger.Info("Applying backup retention policy",
+		"storage", storage.Location(),
+		"daily", retention.Daily,
+		"weekly", retention.Weekly,
+		"monthly", retention.Monthly,
+	)
+
+	catalog, err := LoadStorageCatalog(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := retainedBackups(catalog.Backups, retention)
+	var removed []BackupCatalogEntry
+	for _, entry := range catalog.Backups {
+		if !keep[entry.ID] {
+			removed = append(removed, entry)
+		}
+	}
+	if len(removed) == 0 {
+		logger.Info("No backups to prune", "storage", storage.Location(), "backups", len(catalog.Backups))
+		return nil, nil
+	}
+
+	for _, entry := range removed {
+		catalog.remove(entry.ID)
+	}
+	if err := catalog.save(ctx); err != nil {
+		logger.Error("Failed to update backup catalog", err, "storage", storage.Location())
+		return nil, err
+	}
+
+	for _, entry := range removed {
+		if err := storage.Delete(ctx, entry.File); err != nil {
+			logger.Error("Failed to delete pruned backup", err, "id", entry.ID, "file", entry.File)
+			return removed, err
+		}
+		logger.Info("Pruned backup", "id", entry.ID, "file", entry.File, "created_at", entry.CreatedAt)
+	}
+
+	logger.Info("Backup retention applied", "storage", storage.Location(), "removed", len(removed), "kept", len(catalog.Backups))
+	return removed, nil
+}
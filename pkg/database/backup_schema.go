@@ -0,0 +1,233 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// legacyBackupSchemaVersion 没有记录schema版本的备份（旧版JSON备份和早期归档）按数据结构版本2.0.0对应的迁移版本处理
+// 之后登记的转换都可以重复应用，备份实际来自更新的schema时结果不变
+const legacyBackupSchemaVersion int64 = 5
+
+// 列转换类型
+const (
+	columnAdd    = "add"
+	columnRename = "rename"
+	columnDrop   = "drop"
+)
+
+// columnTransform 对备份记录中一列的转换
+type columnTransform struct {
+	kind   string
+	table  string
+	column string
+	to     string      // rename的新列名
+	value  interface{} // add时的默认值
+}
+
+// addColumn 新增列，记录中没有该列时写入value
+func addColumn(table, column string, value interface{}) columnTransform {
+	return columnTransform{kind: columnAdd, table: table, column: column, value: value}
+}
+
+// renameColumn 列改名，记录中已有新列名时保留新列的值
+func renameColumn(table, from, to string) columnTransform {
+	return columnTransform{kind: columnRename, table: table, column: from, to: to}
+}
+
+// dropColumn 删除列
+func dropColumn(table, column string) columnTransform {
+	return columnTransform{kind: columnDrop, table: table, column: column}
+}
+
+// schemaTransform 一个迁移版本对备份数据的转换，备份的schema版本低于Version时应用
+type schemaTransform struct {
+	Version     int64
+	Description string
+	Columns     []columnTransform
+	NewTables   []string // 该版本新增的备份表，更早的备份中没有这些表的数据
+}
+
+// backupSchemaTransforms 按迁移版本升序登记，修改备份表结构的迁移需要在此登记对应的转换
+var backupSchemaTransforms = []schemaTransform{
+	{
+		Version:     6,
+		Description: "add notification outbox",
+		NewTables:   []string{"notification_outbox"},
+	},
+	{
+		Version:     7,
+		Description: "add notification filters",
+		Columns: []columnTransform{
+			addColumn("telegram_configs", "filters", nil),
+			addColumn("lark_configs", "filters", nil),
+			addColumn("feishu_configs", "filters", nil),
+			addColumn("user_emails", "filters", nil),
+		},
+	},
+	{
+		Version:     8,
+		Description: "add notification templates",
+		Columns: []columnTransform{
+			addColumn("telegram_configs", "message_template", nil),
+			addColumn("lark_configs", "message_template", nil),
+			addColumn("feishu_configs", "message_template", nil),
+			addColumn("user_emails", "email_template", nil),
+		},
+	},
+	{
+		Version:     9,
+		Description: "add notification digest mode",
+		Columns: []columnTransform{
+			addColumn("telegram_configs", "digest_mode", "off"),
+			addColumn("lark_configs", "digest_mode", "off"),
+			addColumn("feishu_configs", "digest_mode", "off"),
+			addColumn("user_emails", "digest_mode", "off"),
+		},
+		NewTables: []string{"digest_send_logs"},
+	},
+	{
+		Version:     11,
+		Description: "add auth nonce chain id",
+		Columns: []columnTransform{
+			addColumn("auth_nonces", "chain_id", int64(0)),
+		},
+	},
+	{
+		Version:     12,
+		Description: "add session tables",
+		NewTables:   []string{"user_sessions", "revoked_tokens"},
+	},
+	{
+		Version:     13,
+		Description: "add api keys",
+		NewTables:   []string{"api_keys"},
+	},
+	{
+		Version:     14,
+		Description: "add organization ownership",
+		Columns: []columnTransform{
+			addColumn("compound_timelocks", "organization_id", int64(0)),
+			addColumn("openzeppelin_timelocks", "organization_id", int64(0)),
+			addColumn("abis", "organization_id", int64(0)),
+			addColumn("telegram_configs", "organization_id", int64(0)),
+			addColumn("lark_configs", "organization_id", int64(0)),
+			addColumn("feishu_configs", "organization_id", int64(0)),
+			addColumn("user_emails", "organization_id", int64(0)),
+		},
+		NewTables: []string{"organizations", "organization_members", "organization_invitations"},
+	},
+	{
+		Version:     15,
+		Description: "add admin audit logs",
+		NewTables:   []string{"admin_audit_logs"},
+	},
+	{
+		Version:     16,
+		Description: "add audit events",
+		NewTables:   []string{"audit_events"},
+	},
+	{
+		Version:     17,
+		Description: "add linked wallets",
+		NewTables:   []string{"user_linked_wallets"},
+	},
+	{
+		Version:     18,
+		Description: "add backup runs",
+		NewTables:   []string{"backup_runs"},
+	},
+	{
+		Version:     19,
+		Description: "add retention runs",
+		NewTables:   []string{"retention_runs"},
+	},
+	{
+		Version:     20,
+		Description: "add auth nonce purpose",
+		Columns: []columnTransform{
+			addColumn("auth_nonces", "purpose", "login"),
+		},
+	},
+}
+
+// schemaPlan 备份数据从备份的schema版本转换到数据库当前版本需要应用的转换
+type schemaPlan struct {
+	from       int64
+	to         int64
+	transforms []schemaTransform
+	columns    map[string][]columnTransform // 按表索引的列转换，按版本顺序排列
+}
+
+// newSchemaPlan 选出版本在(from, to]之间的转换，备份版本比数据库新时返回错误
+func newSchemaPlan(from, to int64) (*schemaPlan, error) {
+	if from <= 0 {
+		from = legacyBackupSchemaVersion
+	}
+	if from > to {
+		return nil, fmt.Errorf("backup schema version %d is newer than database schema version %d, run migrations first", from, to)
+	}
+
+	plan := &schemaPlan{from: from, to: to, columns: make(map[string][]columnTransform)}
+	for _, transform := range backupSchemaTransforms {
+		if transform.Version <= from || transform.Version > to {
+			continue
+		}
+		plan.transforms = append(plan.transforms, transform)
+		for _, column := range transform.Columns {
+			plan.columns[column.table] = append(plan.columns[column.table], column)
+		}
+	}
+	return plan, nil
+}
+
+// apply 原地转换一条记录
+func (p *schemaPlan) apply(table string, record map[string]interface{}) {
+	for _, t := range p.columns[table] {
+		switch t.kind {
+		case columnAdd:
+			if _, ok := record[t.column]; !ok {
+				record[t.column] = t.value
+			}
+		case columnRename:
+			if value, ok := record[t.column]; ok {
+				if _, exists := record[t.to]; !exists {
+					record[t.to] = value
+				}
+				delete(record, t.column)
+			}
+		case columnDrop:
+			delete(record, t.column)
+		}
+	}
+}
+
+// newTable 返回表是在备份之后的哪个版本新增的，备份时已存在的表返回0
+func (p *schemaPlan) newTable(table string) int64 {
+	for _, transform := range p.transforms {
+		for _, name := range transform.NewTables {
+			if name == table {
+				return transform.Version
+			}
+		}
+	}
+	return 0
+}
+
+// descriptions 返回应用的转换说明
+func (p *schemaPlan) descriptions() []string {
+	descriptions := make([]string, 0, len(p.transforms))
+	for _, transform := range p.transforms {
+		tables := make(map[string]bool)
+		var names []string
+		for _, column := range transform.Columns {
+			if !tables[column.table] {
+				tables[column.table] = true
+				names = append(names, column.table)
+			}
+		}
+		names = append(names, transform.NewTables...)
+		descriptions = append(descriptions, fmt.Sprintf("%04d %s (%s)", transform.Version, transform.Description, strings.Join(names, ", ")))
+	}
+	return descriptions
+}
@@ -0,0 +1,257 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// secretColumns 需要加密存储的通知密钥字段
+var secretColumns = map[string][]string{
+	"telegram_configs": {"bot_token"},
+	"lark_configs":     {"webhook_url", "secret"},
+	"feishu_configs":   {"webhook_url", "secret"},
+}
+
+// secretTables 按固定顺序处理的通知密钥表
+var secretTables = []string{"telegram_configs", "lark_configs", "feishu_configs"}
+
+// encryptRecordSecrets 加密备份记录中仍为明文的通知密钥
+func (bm *BackupManager) encryptRecordSecrets(table string, record map[string]interface{}) error {
+	if !bm.keyring.Enabled() {
+		return nil
+	}
+	for _, column := range secretColumns[table] {
+		value, ok := record[column].(string)
+		if !ok {
+			continue
+		}
+		encrypted, err := bm.keyring.Encrypt(value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %s.%s: %w", table, column, err)
+		}
+		record[column] = encrypted
+	}
+	return nil
+}
+
+// RekeySecrets 使用当前主密钥重新加密数据库中的通知密钥（包括历史明文和旧密钥加密的值），返回更新的记录数
+func (bm *BackupManager) RekeySecrets(ctx context.Context) (int, error) {
+	if !bm.keyring.Enabled() {
+		return 0, errors.New("secret encryption key not configured")
+	}
+	logger.Info("Starting notification secrets rekey", "active_key_id", bm.keyring.ActiveKeyID())
+
+	updated := 0
+	err := bm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range secretTables {
+			columns := secretColumns[table]
+
+			var records []map[string]interface{}
+			if err := tx.Table(table).Select(append([]string{"id"}, columns...)).Find(&records).Error; err != nil {
+				return fmt.Errorf("failed to query %s: %w", table, err)
+			}
+
+			for _, record := range records {
+				updates, err := bm.rekeyRecord(table, record)
+				if err != nil {
+					return fmt.Errorf("failed to rekey %s id=%v: %w", table, record["id"], err)
+				}
+				if len(updates) == 0 {
+					continue
+				}
+				if err := tx.Table(table).Where("id = ?", record["id"]).Updates(updates).Error; err != nil {
+					return fmt.Errorf("failed to update %s id=%v: %w", table, record["id"], err)
+				}
+				updated++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("RekeySecrets error", err)
+		return 0, err
+	}
+
+	logger.Info("Notification secrets rekey completed", "updated", updated)
+	return updated, nil
+}
+
+// RekeyBackup 使用当前主密钥重新加密备份文件中的通知密钥，返回更新的记录数
+func (bm *BackupManager) RekeyBackup(backupPath string) (int, error) {
+	if !bm.keyring.Enabled() {
+		return 0, errors.New("secret encryption key not configured")
+	}
+	logger.Info("Starting backup secrets rekey", "path", backupPath, "active_key_id", bm.keyring.ActiveKeyID())
+
+	format, err := detectBackupFormat(backupPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int
+	if format == BackupFormatLegacyJSON {
+		updated, err = bm.rekeyLegacyBackup(backupPath)
+	} else {
+		updated, err = bm.rekeyArchive(backupPath)
+	}
+	if err != nil {
+		logger.Error("RekeyBackup error", err, "path", backupPath)
+		return 0, err
+	}
+
+	logger.Info("Backup secrets rekey completed", "path", backupPath, "updated", updated)
+	return updated, nil
+}
+
+// rekeyArchive 重写归档备份，只重新编码通知密钥表，其他表原样复制；加密备份沿用原文件密钥
+func (bm *BackupManager) rekeyArchive(backupPath string) (int, error) {
+	archive, err := openBackupArchive(backupPath)
+	if err != nil {
+		return 0, err
+	}
+	defer archive.Close()
+	if err := archive.unlock(bm.encryption); err != nil {
+		return 0, err
+	}
+
+	manifest := *archive.manifest
+	manifest.Tables = nil
+	updated := 0
+	file, err := writeArchiveFile(backupPath, func(tw *tar.Writer) error {
+		err := archive.eachEntry(func(entry *BackupTableManifest, raw io.Reader) error {
+			if _, ok := secretColumns[entry.Name]; !ok {
+				manifest.Tables = append(manifest.Tables, *entry)
+				return copyTableEntry(tw, entry, raw)
+			}
+
+			rekeyed, err := writeTableEntry(tw, filepath.Dir(backupPath), entry.Name, archive.fileKey, func(emit backupRecordEmitter) error {
+				rows, err := archive.readEntryRecords(entry, raw, func(record map[string]interface{}) error {
+					updates, err := bm.rekeyRecord(entry.Name, record)
+					if err != nil {
+						return fmt.Errorf("failed to rekey %s id=%v: %w", entry.Name, record["id"], err)
+					}
+					if len(updates) > 0 {
+						for column, value := range updates {
+							record[column] = value
+						}
+						updated++
+					}
+					return emit(record)
+				})
+				if err != nil {
+					return err
+				}
+				if rows != entry.Rows {
+					return fmt.Errorf("row count mismatch for table %s: manifest %d, archive %d", entry.Name, entry.Rows, rows)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			rekeyed.Watermark = entry.Watermark
+			manifest.Tables = append(manifest.Tables, rekeyed)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return writeManifest(tw, &manifest, archive.fileKey)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// 归档内容变化后同步目录中记录的大小和校验和
+	catalog, err := LoadBackupCatalog(filepath.Dir(backupPath))
+	if err != nil {
+		return 0, err
+	}
+	if catalog.Find(manifest.ID) != nil {
+		catalog.put(catalogEntryFromManifest(backupPath, &manifest, file))
+		if err := catalog.save(context.Background()); err != nil {
+			return 0, err
+		}
+	}
+	return updated, nil
+}
+
+// rekeyLegacyBackup 重新加密旧版JSON备份中的通知密钥，保持旧版格式
+func (bm *BackupManager) rekeyLegacyBackup(backupPath string) (int, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read backup file: %w", err)
+	}
+	var backup BackupData
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return 0, fmt.Errorf("failed to decode backup data: %w", err)
+	}
+
+	updated := 0
+	records := legacyTableRecords(&backup)
+	for _, table := range secretTables {
+		for _, record := range records[table] {
+			updates, err := bm.rekeyRecord(table, record)
+			if err != nil {
+				return 0, fmt.Errorf("failed to rekey %s id=%v: %w", table, record["id"], err)
+			}
+			if len(updates) == 0 {
+				continue
+			}
+			for column, value := range updates {
+				record[column] = value
+			}
+			updated++
+		}
+	}
+
+	// 先写临时文件再替换，避免写入中断损坏备份
+	output, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode backup data: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(backupPath), filepath.Base(backupPath)+".rekey-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temp backup file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(append(output, '\n')); err != nil {
+		tmpFile.Close()
+		return 0, fmt.Errorf("failed to write temp backup file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write temp backup file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), backupPath); err != nil {
+		return 0, fmt.Errorf("failed to replace backup file: %w", err)
+	}
+
+	return updated, nil
+}
+
+// rekeyRecord 返回记录中需要更新的密钥字段
+func (bm *BackupManager) rekeyRecord(table string, record map[string]interface{}) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+	for _, column := range secretColumns[table] {
+		value, ok := record[column].(string)
+		if !ok || !bm.keyring.NeedsRekey(value) {
+			continue
+		}
+		rekeyed, err := bm.keyring.Rekey(value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column, err)
+		}
+		updates[column] = rekeyed
+	}
+	return updates, nil
+}
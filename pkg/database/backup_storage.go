@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/logger"
+)
+
+// 备份存储类型
+const (
+	BackupStorageNone  = "none"
+	BackupStorageLocal = "local"
+	BackupStorageS3    = "s3"
+)
+
+// ErrBackupObjectNotFound 存储中不存在指定的备份对象
+var ErrBackupObjectNotFound = errors.New("backup object not found")
+
+// BackupStorage 备份存储，对象名为相对存储根目录的文件名（归档文件名或catalog.json）
+type BackupStorage interface {
+	// Location 返回用于日志和提示的存储位置
+	Location() string
+	// Put 写入对象，已存在时覆盖；写入完成前其他读取方看到的仍是旧对象
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+	// Get 读取对象，不存在时返回ErrBackupObjectNotFound
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete 删除对象，不存在时不报错
+	Delete(ctx context.Context, name string) error
+}
+
+// NewBackupStorage 根据配置创建备份存储，未配置时返回nil
+func NewBackupStorage(cfg *config.BackupStorageConfig) (BackupStorage, error) {
+	switch strings.ToLower(cfg.Type) {
+	case "", BackupStorageNone:
+		return nil, nil
+	case BackupStorageLocal:
+		if cfg.Local.Dir == "" {
+			return nil, errors.New("backup.storage.local.dir is required for local storage")
+		}
+		if err := os.MkdirAll(cfg.Local.Dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create backup storage directory: %w", err)
+		}
+		return NewLocalBackupStorage(cfg.Local.Dir), nil
+	case BackupStorageS3:
+		return NewS3BackupStorage(&cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported backup storage type: %s", cfg.Type)
+	}
+}
+
+// LocalBackupStorage 本地目录存储
+type LocalBackupStorage struct {
+	dir string
+}
+
+// NewLocalBackupStorage 创建本地目录存储
+func NewLocalBackupStorage(dir string) *LocalBackupStorage {
+	return &LocalBackupStorage{dir: dir}
+}
+
+// Location 返回目录路径
+func (s *LocalBackupStorage) Location() string {
+	return s.dir
+}
+
+// Put 先写临时文件再替换，避免读取到不完整的文件
+func (s *LocalBackupStorage) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(s.dir, filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	written, err := io.Copy(tmpFile, r)
+	if err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("short write for %s: expected %d bytes, wrote %d", name, size, written)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get 打开文件
+func (s *LocalBackupStorage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrBackupObjectNotFound, name)
+	}
+	return file, err
+}
+
+// Delete 删除文件
+func (s *LocalBackupStorage) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// path 对象名只能是目录下的文件名，防止目录穿越
+func (s *LocalBackupStorage) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid backup object name: %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// uploadBackup 上传归档并在远程目录中记录
+func (bm *BackupManager) uploadBackup(ctx context.Context, backupPath string, entry BackupCatalogEntry) error {
+	logger.Info("Uploading backup", "id", entry.ID, "storage", bm.storage.Location(), "size", entry.Size)
+
+	catalog, err := LoadStorageCatalog(ctx, bm.storage)
+	if err != nil {
+		return err
+	}
+	if entry.ParentID != "" && catalog.Find(entry.ParentID) == nil {
+		logger.Warn("Parent backup not found in remote storage, incremental backup cannot be restored from remote", "id", entry.ID, "parent_id", entry.ParentID)
+	}
+
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+	if err := bm.storage.Put(ctx, entry.File, file, entry.Size); err != nil {
+		return err
+	}
+
+	catalog.put(entry)
+	if err := catalog.save(ctx); err != nil {
+		return err
+	}
+	logger.Info("Backup uploaded", "id", entry.ID, "storage", bm.storage.Location(), "file", entry.File)
+	return nil
+}
+
+// FetchBackup 从远程存储下载备份及其备份链到本地目录，name可以是备份ID或文件名
+// 下载的文件按目录中记录的大小和SHA-256校验，返回目标归档的本地路径，可直接用于RestoreBackup
+func (bm *BackupManager) FetchBackup(ctx context.Context, name string, dir string) (string, error) {
+	if bm.storage == nil {
+		return "", errors.New("backup storage not configured")
+	}
+
+	remote, err := LoadStorageCatalog(ctx, bm.storage)
+	if err != nil {
+		return "", err
+	}
+	target := remote.Find(name)
+	if target == nil {
+		for i := range remote.Backups {
+			if remote.Backups[i].File == name {
+				target = &remote.Backups[i]
+				break
+			}
+		}
+	}
+	if target == nil {
+		return "", fmt.Errorf("backup %s not found in %s", name, remote.location())
+	}
+	chain, err := remote.Chain(target.ID)
+	if err != nil {
+		return "", err
+	}
+
+	local, err := LoadBackupCatalog(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range chain {
+		if err := bm.downloadBackup(ctx, entry, filepath.Join(dir, entry.File)); err != nil {
+			return "", err
+		}
+		local.put(*entry)
+	}
+	// 写入本地目录，恢复增量备份时据此找到备份链
+	if err := local.save(ctx); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, target.File), nil
+}
+
+// downloadBackup 下载单个归档并校验大小和SHA-256
+func (bm *BackupManager) downloadBackup(ctx context.Context, entry *BackupCatalogEntry, path string) error {
+	logger.Info("Downloading backup", "id", entry.ID, "storage", bm.storage.Location(), "size", entry.Size)
+
+	reader, err := bm.storage.Get(ctx, entry.File)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to download %s: %w", entry.File, err)
+	}
+
+	if written != entry.Size || hex.EncodeToString(hash.Sum(nil)) != entry.SHA256 {
+		os.Remove(path)
+		return fmt.Errorf("downloaded backup %s does not match catalog checksum", entry.File)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"timelocker-backend/internal/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3BackupStorage S3兼容对象存储（AWS S3、MinIO等）
+type S3BackupStorage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3BackupStorage 创建S3兼容对象存储，访问密钥从配置的环境变量读取
+func NewS3BackupStorage(cfg *config.BackupS3StorageConfig) (*S3BackupStorage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("backup.storage.s3.endpoint and backup.storage.s3.bucket are required for s3 storage")
+	}
+
+	var accessKey, secretKey string
+	if cfg.AccessKeyEnv != "" {
+		accessKey = os.Getenv(cfg.AccessKeyEnv)
+	}
+	if cfg.SecretKeyEnv != "" {
+		secretKey = os.Getenv(cfg.SecretKeyEnv)
+	}
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("s3 credentials not set, export %s and %s", cfg.AccessKeyEnv, cfg.SecretKeyEnv)
+	}
+
+	options := &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	}
+	if cfg.ForcePathStyle {
+		options.BucketLookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(cfg.Endpoint, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	return &S3BackupStorage{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: strings.Trim(cfg.Prefix, "/"),
+	}, nil
+}
+
+// Location 返回 s3://bucket/prefix
+func (s *S3BackupStorage) Location() string {
+	return "s3://" + path.Join(s.bucket, s.prefix)
+}
+
+// Put 上传对象，大文件由客户端自动分片上传，完成前对象不可见
+func (s *S3BackupStorage) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	contentType := "application/x-tar"
+	if name == BackupCatalogName {
+		contentType = "application/json"
+	}
+	if _, err := s.client.PutObject(ctx, s.bucket, s.key(name), r, size, minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get 下载对象
+func (s *S3BackupStorage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	// GetObject不会立即发起请求，通过Stat确认对象存在
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %s", ErrBackupObjectNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return object, nil
+}
+
+// Delete 删除对象
+func (s *S3BackupStorage) Delete(ctx context.Context, name string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, s.key(name), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// key 返回带前缀的对象键
+func (s *S3BackupStorage) key(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "/" + name
+}
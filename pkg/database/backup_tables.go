@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"timelocker-backend/pkg/database/migrations"
+
+	"gorm.io/gorm"
+)
+
+// backupTableDependencies 备份的表及其依赖的表（外键或逻辑依赖），恢复时被依赖的表先写入
+// 新增表的迁移需要同时在此登记，未登记的表不会被备份，创建全量备份时会检查并报错
+var backupTableDependencies = map[string][]string{
+	// 基础独立表（被其他表外键引用）
+	"users":               nil,
+	"emails":              nil,
+	"support_chains":      nil,
+	"sponsors":            nil,
+	"block_scan_progress": nil,
+	"safe_wallets":        nil,
+	"auth_nonces":         nil,
+	// 依赖用户表的表
+	"abis":                   {"users"},           // owner → users.wallet_address
+	"compound_timelocks":     {"users"},           // creator_address → users.wallet_address
+	"openzeppelin_timelocks": {"users"},           // creator_address → users.wallet_address
+	"user_emails":            {"users", "emails"}, // user_id → users.id, email_id → emails.id
+	// 事务相关表（无直接外键约束）
+	"compound_timelock_transactions":     nil,
+	"openzeppelin_timelock_transactions": nil,
+	"timelock_transaction_flows":         nil,
+	// 依赖user_emails和emails表的表
+	"email_verification_codes": {"user_emails"}, // user_email_id → user_emails.id
+	"email_send_logs":          {"emails"},      // email_id → emails.id
+	// 通知配置表（无外键约束，但逻辑上依赖用户），通知日志依赖配置表
+	"telegram_configs":  {"users"},
+	"lark_configs":      {"users"},
+	"feishu_configs":    {"users"},
+	"notification_logs": {"telegram_configs", "lark_configs", "feishu_configs"},
+	// 通知发件箱和摘要发送记录，摘要记录的config_id指向各渠道配置或user_emails
+	"notification_outbox": nil,
+	"digest_send_logs":    {"telegram_configs", "lark_configs", "feishu_configs", "user_emails"},
+	// 登录会话、访问令牌黑名单和API Key
+	"user_sessions":  {"users"}, // user_id → users.id（无外键约束）
+	"revoked_tokens": nil,
+	"api_keys":       {"users"}, // user_id → users.id ON DELETE CASCADE
+	// 组织
+	"organizations":            nil,
+	"organization_members":     {"organizations", "users"}, // organization_id, user_id 外键 ON DELETE CASCADE
+	"organization_invitations": {"organizations"},          // organization_id → organizations.id ON DELETE CASCADE
+	// 审计日志
+	"admin_audit_logs": nil,
+	"audit_events":     nil,
+	// 关联钱包
+	"user_linked_wallets": {"users"}, // user_id → users.id ON DELETE CASCADE
+	// 备份和数据清理的运行记录
+	"backup_runs":    nil,
+	"retention_runs": nil,
+}
+
+// backupTableOwnerColumns 表中标识所属用户钱包地址的列，用于按用户恢复
+// 只通过user_id关联用户的表（api_keys、user_sessions、user_linked_wallets等）不支持按用户恢复
+var backupTableOwnerColumns = map[string]string{
+	"users":                    "wallet_address",
+	"auth_nonces":              "wallet_address",
+	"abis":                     "owner",
+	"compound_timelocks":       "creator_address",
+	"openzeppelin_timelocks":   "creator_address",
+	"telegram_configs":         "user_address",
+	"lark_configs":             "user_address",
+	"feishu_configs":           "user_address",
+	"notification_logs":        "user_address",
+	"organization_members":     "wallet_address",
+	"organization_invitations": "wallet_address",
+	"audit_events":             "actor_address",
+}
+
+// backupExcludedTables 数据库中有意不备份的表，其余表都必须在backupTableDependencies中登记
+var backupExcludedTables = map[string]bool{
+	migrations.Migration{}.TableName(): true, // 迁移记录由恢复目标库自己的迁移决定
+}
+
+// unregisteredTables 返回当前schema中既未登记备份也未排除的表
+func unregisteredTables(ctx context.Context, db *gorm.DB) ([]string, error) {
+	var names []string
+	if err := db.WithContext(ctx).Raw(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'
+		ORDER BY table_name`).Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("failed to list database tables: %w", err)
+	}
+
+	var unregistered []string
+	for _, name := range names {
+		if _, ok := backupTableDependencies[name]; !ok && !backupExcludedTables[name] {
+			unregistered = append(unregistered, name)
+		}
+	}
+	return unregistered, nil
+}
+
+// backupTables 全部备份表，按依赖顺序排列
+var backupTables = mustSortTables(allBackupTables())
+
+// allBackupTables 返回全部备份表名
+func allBackupTables() []string {
+	tables := make([]string, 0, len(backupTableDependencies))
+	for table := range backupTableDependencies {
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// mustSortTables 对静态表清单排序，存在循环依赖属于编码错误
+func mustSortTables(tables []string) []string {
+	sorted, err := sortTablesByDependency(tables)
+	if err != nil {
+		panic(err)
+	}
+	return sorted
+}
+
+// sortTablesByDependency 按依赖关系拓扑排序，被依赖的表在前；同层按表名排序保证结果稳定
+// 未包含在tables中的依赖表不会被加入结果
+func sortTablesByDependency(tables []string) ([]string, error) {
+	included := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		included[table] = true
+	}
+	names := make([]string, 0, len(included))
+	for table := range included {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(names))
+	sorted := make([]string, 0, len(names))
+
+	var visit func(table string, path []string) error
+	visit = func(table string, path []string) error {
+		switch state[table] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular table dependency: %s", strings.Join(append(path, table), " -> "))
+		}
+		state[table] = visiting
+
+		parents := append([]string(nil), backupTableDependencies[table]...)
+		sort.Strings(parents)
+		for _, parent := range parents {
+			if !included[parent] {
+				continue
+			}
+			if err := visit(parent, append(path, table)); err != nil {
+				return err
+			}
+		}
+
+		state[table] = visited
+		sorted = append(sorted, table)
+		return nil
+	}
+
+	for _, table := range names {
+		if err := visit(table, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// TableSelection 表选择条件，Tables为空表示全部表
+type TableSelection struct {
+	Tables        []string
+	ExcludeTables []string
+}
+
+// resolve 校验表名并返回按依赖顺序排列的表
+func (s TableSelection) resolve() ([]string, error) {
+	for _, table := range append(append([]string{}, s.Tables...), s.ExcludeTables...) {
+		if _, ok := backupTableDependencies[table]; !ok {
+			return nil, fmt.Errorf("unknown table: %s", table)
+		}
+	}
+
+	selected := s.Tables
+	if len(selected) == 0 {
+		selected = allBackupTables()
+	}
+	excluded := make(map[string]bool, len(s.ExcludeTables))
+	for _, table := range s.ExcludeTables {
+		excluded[table] = true
+	}
+
+	var tables []string
+	for _, table := range selected {
+		if !excluded[table] {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables selected")
+	}
+	return sortTablesByDependency(tables)
+}
+
+// isAll 是否选择了全部表
+func (s TableSelection) isAll() bool {
+	return len(s.Tables) == 0 && len(s.ExcludeTables) == 0
+}
+
+// missingDependencies 返回所选表依赖但未被选择的表，恢复时这些表中必须已有被引用的数据
+func missingDependencies(tables []string) map[string][]string {
+	selected := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		selected[table] = true
+	}
+	missing := make(map[string][]string)
+	for _, table := range tables {
+		for _, parent := range backupTableDependencies[table] {
+			if !selected[parent] {
+				missing[table] = append(missing[table], parent)
+			}
+		}
+	}
+	return missing
+}
+
+// ParseTableList 解析逗号分隔的表名列表
+func ParseTableList(value string) []string {
+	var tables []string
+	for _, table := range strings.Split(value, ",") {
+		if table = strings.TrimSpace(table); table != "" {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
@@ -0,0 +1,163 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var migrationFiles embed.FS
+
+// migrationFileRe 迁移文件名格式：<版本号>_<名称>.<up|down>.sql
+var migrationFileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// migrationNameRe 迁移名称只允许小写字母、数字和下划线
+var migrationNameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration 表示一个数据库迁移版本的执行记录
+type Migration struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement"`
+	Version     string `gorm:"unique;size:50;not null"`
+	Description string `gorm:"size:200;not null"`
+	Checksum    string `gorm:"size:64"`
+	Applied     bool   `gorm:"not null;default:false"`
+	AppliedAt   *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName 设置表名
+func (Migration) TableName() string {
+	return "schema_migrations"
+}
+
+// SQLMigration 一个版本的up/down迁移脚本
+type SQLMigration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string // up脚本的SHA-256，已执行的脚本被修改时拒绝继续迁移
+}
+
+// VersionString 迁移记录中使用的版本号
+func (m SQLMigration) VersionString() string {
+	return formatVersion(m.Version)
+}
+
+// FileName 不含up/down后缀的迁移文件名
+func (m SQLMigration) FileName() string {
+	return fmt.Sprintf("%s_%s", m.VersionString(), m.Name)
+}
+
+func formatVersion(version int64) string {
+	return fmt.Sprintf("%04d", version)
+}
+
+// LoadMigrations 读取内嵌的迁移脚本，按版本号升序返回
+func LoadMigrations() ([]SQLMigration, error) {
+	sub, err := fs.Sub(migrationFiles, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	return loadMigrationsFS(sub)
+}
+
+func loadMigrationsFS(fsys fs.FS) ([]SQLMigration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*SQLMigration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &SQLMigration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %s: %s and %s", match[1], m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.UpSQL = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]SQLMigration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.UpSQL) == "" {
+			return nil, fmt.Errorf("migration %s has no up script", m.FileName())
+		}
+		if strings.TrimSpace(m.DownSQL) == "" {
+			return nil, fmt.Errorf("migration %s has no down script", m.FileName())
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// CreateMigration 在dir下创建下一个版本的空up/down迁移文件，返回两个文件路径
+func CreateMigration(dir, name string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	if !migrationNameRe.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q: use lowercase letters, digits and underscores", name)
+	}
+
+	var next int64 = 1
+	if _, err := os.Stat(dir); err == nil {
+		existing, err := loadMigrationsFS(os.DirFS(dir))
+		if err != nil {
+			return "", "", err
+		}
+		if len(existing) > 0 {
+			next = existing[len(existing)-1].Version + 1
+		}
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	base := fmt.Sprintf("%s_%s", formatVersion(next), name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := os.WriteFile(upPath, []byte(fmt.Sprintf("-- %s (up)\n", base)), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write %s: %w", upPath, err)
+	}
+	if err := os.WriteFile(downPath, []byte(fmt.Sprintf("-- %s (down)\n", base)), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write %s: %w", downPath, err)
+	}
+	return upPath, downPath, nil
+}
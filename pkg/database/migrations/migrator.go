@@ -0,0 +1,455 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockKey 迁移使用的PostgreSQL advisory lock键，多个实例同时启动时串行执行迁移
+const migrationLockKey int64 = 0x74696d656c6f636b
+
+// ResetConfirmEnv 执行reset前必须将该环境变量设置为目标数据库名
+const ResetConfirmEnv = "TIMELOCKER_RESET_CONFIRM"
+
+// ErrResetNotConfirmed reset未通过环境变量确认
+var ErrResetNotConfirmed = errors.New("database reset is not confirmed")
+
+// legacyVersions 旧版Go迁移的版本号到SQL迁移版本号的映射，已有数据库升级后按新版本号记录
+var legacyVersions = map[string]int64{
+	"v1.0.0":  1,
+	"v1.0.1":  2,
+	"v1.0.2":  3,
+	"v1.0.3":  4,
+	"v1.0.4":  5,
+	"v1.0.5":  6,
+	"v1.0.6":  7,
+	"v1.0.7":  8,
+	"v1.0.8":  9,
+	"v1.0.9":  10,
+	"v1.0.10": 11,
+	"v1.0.11": 12,
+	"v1.0.12": 13,
+	"v1.0.13": 14,
+	"v1.0.14": 15,
+	"v1.0.15": 16,
+	"v1.0.16": 17,
+	"v1.0.17": 18,
+}
+
+// 迁移状态
+const (
+	MigrationStateApplied  = "applied"
+	MigrationStatePending  = "pending"
+	MigrationStateModified = "modified" // 已执行的up脚本被修改
+	MigrationStateMissing  = "missing"  // 数据库中已执行但当前版本没有对应脚本
+)
+
+// MigrationStatus 单个迁移的执行状态
+type MigrationStatus struct {
+	Version   string     `json:"version"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator 执行内嵌的SQL迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []SQLMigration
+}
+
+// NewMigrator 创建迁移执行器
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// InitTables 安全的数据库初始化 - 执行所有待执行的迁移，不会删除现有数据
+func InitTables(db *gorm.DB) error {
+	ctx := context.Background()
+	logger.Info("Starting safe database initialization...")
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		logger.Error("Failed to load migrations", err)
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		logger.Error("Failed to run migrations", err)
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	logger.Info("Database initialization completed successfully")
+	return nil
+}
+
+// CurrentVersion 返回数据库已执行的最新迁移版本，未执行过迁移时返回0，不获取迁移锁
+func CurrentVersion(ctx context.Context, db *gorm.DB) (int64, error) {
+	if !db.Migrator().HasTable(&Migration{}) {
+		return 0, nil
+	}
+	var versions []string
+	if err := db.WithContext(ctx).Model(&Migration{}).Where("applied = ?", true).Pluck("version", &versions).Error; err != nil {
+		return 0, fmt.Errorf("failed to query schema version: %w", err)
+	}
+
+	var current int64
+	for _, v := range versions {
+		version, ok := legacyVersions[v]
+		if !ok {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				continue
+			}
+			version = parsed
+		}
+		if version > current {
+			current = version
+		}
+	}
+	return current, nil
+}
+
+// Up 执行待执行的迁移，steps为0时执行全部，返回执行的数量
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verifyChecksums(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if steps > 0 && count >= steps {
+				break
+			}
+			if _, ok := applied[migration.VersionString()]; ok {
+				continue
+			}
+			if err := m.applyUp(conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err == nil && count == 0 {
+		logger.Info("No pending migrations")
+	}
+	return count, err
+}
+
+// Down 按版本倒序回滚最近执行的steps个迁移，返回回滚的数量
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("invalid down steps: %d", steps)
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		targets, err := m.lastApplied(applied, steps)
+		if err != nil {
+			return err
+		}
+		for _, migration := range targets {
+			if err := m.applyDown(conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Redo 回滚并重新执行最近一个迁移
+func (m *Migrator) Redo(ctx context.Context) (*SQLMigration, error) {
+	var redone *SQLMigration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		targets, err := m.lastApplied(applied, 1)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return errors.New("no applied migration to redo")
+		}
+
+		migration := targets[0]
+		if err := m.applyDown(conn, migration); err != nil {
+			return err
+		}
+		if err := m.applyUp(conn, migration); err != nil {
+			return err
+		}
+		redone = &migration
+		return nil
+	})
+	return redone, err
+}
+
+// Reset 回滚全部迁移，删除所有表和数据
+// 必须将环境变量TIMELOCKER_RESET_CONFIRM设置为当前数据库名，防止误操作其他环境的数据库
+func (m *Migrator) Reset(ctx context.Context) (int, error) {
+	var database string
+	if err := m.db.WithContext(ctx).Raw("SELECT current_database()").Scan(&database).Error; err != nil {
+		return 0, fmt.Errorf("failed to query current database: %w", err)
+	}
+	if confirm := os.Getenv(ResetConfirmEnv); confirm == "" || confirm != database {
+		return 0, fmt.Errorf("%w: set %s=%s to reset this database", ErrResetNotConfirmed, ResetConfirmEnv, database)
+	}
+
+	logger.Warn("DANGEROUS: Starting database reset - ALL DATA WILL BE LOST!", "database", database)
+	count, err := m.Down(ctx, len(m.migrations))
+	if err != nil {
+		return count, err
+	}
+	logger.Warn("Database reset completed - all migrations rolled back", "database", database, "count", count)
+	return count, nil
+}
+
+// Status 返回所有迁移的执行状态，按版本号升序
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[string]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			version := migration.VersionString()
+			known[version] = true
+
+			status := MigrationStatus{Version: version, Name: migration.Name, State: MigrationStatePending}
+			if record, ok := applied[version]; ok {
+				status.State = MigrationStateApplied
+				status.AppliedAt = record.AppliedAt
+				if record.Checksum != migration.Checksum {
+					status.State = MigrationStateModified
+				}
+			}
+			statuses = append(statuses, status)
+		}
+
+		for version, record := range applied {
+			if !known[version] {
+				statuses = append(statuses, MigrationStatus{
+					Version:   version,
+					Name:      record.Description,
+					State:     MigrationStateMissing,
+					AppliedAt: record.AppliedAt,
+				})
+			}
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// withLock 在同一个连接上持有advisory lock执行fn，并确保迁移记录表存在且旧版本号已转换
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		logger.Info("Acquiring migration lock...")
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			// 使用不会被取消的context释放锁，避免连接归还连接池后仍持有锁
+			unlock := conn.WithContext(context.WithoutCancel(ctx))
+			if err := unlock.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				logger.Error("Failed to release migration lock", err)
+			}
+		}()
+
+		if err := m.ensureMigrationTable(conn); err != nil {
+			return err
+		}
+		if err := m.adoptLegacyVersions(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// ensureMigrationTable 确保迁移记录表存在并包含校验和字段
+func (m *Migrator) ensureMigrationTable(conn *gorm.DB) error {
+	if err := conn.AutoMigrate(&Migration{}); err != nil {
+		return fmt.Errorf("failed to create migration table: %w", err)
+	}
+	return nil
+}
+
+// adoptLegacyVersions 将旧版迁移记录（v1.0.x）转换为对应的SQL迁移版本号
+func (m *Migrator) adoptLegacyVersions(conn *gorm.DB) error {
+	var records []Migration
+	if err := conn.Where("version LIKE ?", "v%").Find(&records).Error; err != nil {
+		return fmt.Errorf("failed to query legacy migrations: %w", err)
+	}
+
+	for _, record := range records {
+		version, ok := legacyVersions[record.Version]
+		if !ok {
+			continue
+		}
+		migration := m.find(version)
+		if migration == nil {
+			continue
+		}
+
+		updates := map[string]interface{}{
+			"version":     migration.VersionString(),
+			"description": migration.Name,
+			"checksum":    migration.Checksum,
+		}
+		if err := conn.Model(&Migration{}).Where("id = ?", record.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to convert legacy migration %s: %w", record.Version, err)
+		}
+		logger.Info("Converted legacy migration record", "legacy_version", record.Version, "version", migration.VersionString())
+	}
+	return nil
+}
+
+// appliedMigrations 查询已执行的迁移记录，按版本号索引
+func (m *Migrator) appliedMigrations(conn *gorm.DB) (map[string]Migration, error) {
+	var records []Migration
+	if err := conn.Where("applied = ?", true).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+
+	applied := make(map[string]Migration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// verifyChecksums 已执行的迁移脚本不允许再修改，新的变更应写入新的迁移
+func (m *Migrator) verifyChecksums(applied map[string]Migration) error {
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.VersionString()]
+		if !ok || record.Checksum == migration.Checksum {
+			continue
+		}
+		return fmt.Errorf("migration %s was modified after it was applied (checksum %s, recorded %s)",
+			migration.FileName(), migration.Checksum, record.Checksum)
+	}
+
+	for version := range applied {
+		if m.findVersion(version) == nil {
+			logger.Warn("Applied migration not found in this build", "version", version)
+		}
+	}
+	return nil
+}
+
+// lastApplied 返回最近执行的n个迁移，按版本号倒序
+func (m *Migrator) lastApplied(applied map[string]Migration, n int) ([]SQLMigration, error) {
+	versions := make([]string, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+	var targets []SQLMigration
+	for _, version := range versions {
+		if len(targets) >= n {
+			break
+		}
+		migration := m.findVersion(version)
+		if migration == nil {
+			return nil, fmt.Errorf("applied migration %s not found in this build, cannot roll back", version)
+		}
+		targets = append(targets, *migration)
+	}
+	return targets, nil
+}
+
+// applyUp 在事务中执行up脚本并记录迁移
+func (m *Migrator) applyUp(conn *gorm.DB, migration SQLMigration) error {
+	logger.Info("Running migration", "version", migration.VersionString(), "name", migration.Name)
+	start := time.Now()
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.UpSQL).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Create(&Migration{
+			Version:     migration.VersionString(),
+			Description: migration.Name,
+			Checksum:    migration.Checksum,
+			Applied:     true,
+			AppliedAt:   &now,
+		}).Error
+	})
+	if err != nil {
+		logger.Error("Migration failed", err, "version", migration.VersionString())
+		return fmt.Errorf("failed to run migration %s: %w", migration.FileName(), err)
+	}
+
+	logger.Info("Migration completed successfully", "version", migration.VersionString(), "duration", time.Since(start))
+	return nil
+}
+
+// applyDown 在事务中执行down脚本并删除迁移记录
+func (m *Migrator) applyDown(conn *gorm.DB, migration SQLMigration) error {
+	logger.Info("Rolling back migration", "version", migration.VersionString(), "name", migration.Name)
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.DownSQL).Error; err != nil {
+			return err
+		}
+		return tx.Where("version = ?", migration.VersionString()).Delete(&Migration{}).Error
+	})
+	if err != nil {
+		logger.Error("Migration rollback failed", err, "version", migration.VersionString())
+		return fmt.Errorf("failed to roll back migration %s: %w", migration.FileName(), err)
+	}
+
+	logger.Info("Migration rolled back successfully", "version", migration.VersionString())
+	return nil
+}
+
+func (m *Migrator) find(version int64) *SQLMigration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) findVersion(version string) *SQLMigration {
+	for i := range m.migrations {
+		if m.migrations[i].VersionString() == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS notification_logs CASCADE;
+DROP TABLE IF EXISTS feishu_configs CASCADE;
+DROP TABLE IF EXISTS lark_configs CASCADE;
+DROP TABLE IF EXISTS telegram_configs CASCADE;
+DROP TABLE IF EXISTS auth_nonces CASCADE;
+DROP TABLE IF EXISTS safe_wallets CASCADE;
+DROP TABLE IF EXISTS email_send_logs CASCADE;
+DROP TABLE IF EXISTS email_verification_codes CASCADE;
+DROP TABLE IF EXISTS user_emails CASCADE;
+DROP TABLE IF EXISTS emails CASCADE;
+DROP TABLE IF EXISTS timelock_transaction_flows CASCADE;
+DROP TABLE IF EXISTS openzeppelin_timelock_transactions CASCADE;
+DROP TABLE IF EXISTS compound_timelock_transactions CASCADE;
+DROP TABLE IF EXISTS block_scan_progress CASCADE;
+DROP TABLE IF EXISTS sponsors CASCADE;
+DROP TABLE IF EXISTS openzeppelin_timelocks CASCADE;
+DROP TABLE IF EXISTS compound_timelocks CASCADE;
+DROP TABLE IF EXISTS abis CASCADE;
+DROP TABLE IF EXISTS support_chains CASCADE;
+DROP TABLE IF EXISTS users CASCADE;
@@ -0,0 +1,327 @@
+-- 用户表
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    wallet_address VARCHAR(42) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_login TIMESTAMP WITH TIME ZONE,
+    status INTEGER DEFAULT 1,
+    is_safe_wallet BOOLEAN DEFAULT FALSE,
+    safe_threshold INTEGER,
+    safe_owners TEXT
+);
+
+-- 支持的区块链表
+CREATE TABLE IF NOT EXISTS support_chains (
+    id BIGSERIAL PRIMARY KEY,
+    chain_name VARCHAR(100) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    chain_id BIGINT NOT NULL,
+    native_currency_name VARCHAR(50) NOT NULL,
+    native_currency_symbol VARCHAR(10) NOT NULL,
+    native_currency_decimals INTEGER NOT NULL DEFAULT 18,
+    logo_url TEXT,
+    is_testnet BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    alchemy_rpc_template TEXT,
+    infura_rpc_template TEXT,
+    official_rpc_urls TEXT NOT NULL,
+    block_explorer_urls TEXT NOT NULL,
+    rpc_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- ABI库表
+CREATE TABLE IF NOT EXISTS abis (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    abi_content TEXT NOT NULL,
+    owner VARCHAR(42) NOT NULL,
+    description VARCHAR(500) DEFAULT '',
+    is_shared BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(name, owner)
+);
+
+-- Compound标准Timelock合约表
+CREATE TABLE IF NOT EXISTS compound_timelocks (
+    id BIGSERIAL PRIMARY KEY,
+    creator_address VARCHAR(42) NOT NULL REFERENCES users(wallet_address) ON DELETE CASCADE,
+    chain_id INTEGER NOT NULL,
+    chain_name VARCHAR(100) NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    delay BIGINT NOT NULL,
+    admin VARCHAR(42) NOT NULL,
+    pending_admin VARCHAR(42),
+    grace_period BIGINT NOT NULL,
+    minimum_delay BIGINT NOT NULL,
+    maximum_delay BIGINT NOT NULL,
+    remark VARCHAR(500) DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'deleted')),
+    is_imported BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(creator_address, chain_id, contract_address)
+);
+
+-- OpenZeppelin标准Timelock合约表
+CREATE TABLE IF NOT EXISTS openzeppelin_timelocks (
+    id BIGSERIAL PRIMARY KEY,
+    creator_address VARCHAR(42) NOT NULL REFERENCES users(wallet_address) ON DELETE CASCADE,
+    chain_id INTEGER NOT NULL,
+    chain_name VARCHAR(100) NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    delay BIGINT NOT NULL,
+    admin VARCHAR(42),
+    proposers TEXT NOT NULL,
+    executors TEXT NOT NULL,
+    remark VARCHAR(500) DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'deleted')),
+    is_imported BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(creator_address, chain_id, contract_address)
+);
+
+-- 赞助方和生态伙伴表
+CREATE TABLE IF NOT EXISTS sponsors (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    logo_url TEXT NOT NULL,
+    link TEXT NOT NULL,
+    description TEXT NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('sponsor', 'partner')),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- 区块扫描进度表
+CREATE TABLE IF NOT EXISTS block_scan_progress (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id INTEGER NOT NULL UNIQUE,
+    chain_name VARCHAR(50) NOT NULL,
+    last_scanned_block BIGINT NOT NULL DEFAULT 0,
+    latest_network_block BIGINT DEFAULT 0,
+    scan_status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (scan_status IN ('running', 'paused', 'error')),
+    error_message TEXT,
+    last_update_time TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Compound Timelock 交易记录表
+CREATE TABLE IF NOT EXISTS compound_timelock_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    tx_hash VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    chain_id INTEGER NOT NULL,
+    chain_name VARCHAR(100) NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    tx_status VARCHAR(20) NOT NULL DEFAULT 'failed' CHECK (tx_status IN ('success', 'failed')),
+    event_type VARCHAR(50) NOT NULL CHECK (event_type IN ('QueueTransaction', 'ExecuteTransaction', 'CancelTransaction')),
+    event_data JSONB NOT NULL,
+    event_tx_hash VARCHAR(128),
+    event_target VARCHAR(42),
+    event_value DECIMAL(200,0) DEFAULT 0,
+    event_function_signature VARCHAR(500),
+    event_call_data BYTEA,
+    event_eta BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(tx_hash, contract_address, event_type)
+);
+
+-- OpenZeppelin Timelock 交易记录表
+CREATE TABLE IF NOT EXISTS openzeppelin_timelock_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    tx_hash VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    chain_id INTEGER NOT NULL,
+    chain_name VARCHAR(100) NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    tx_status VARCHAR(20) NOT NULL DEFAULT 'failed' CHECK (tx_status IN ('success', 'failed')),
+    event_type VARCHAR(50) NOT NULL CHECK (event_type IN ('CallScheduled', 'CallExecuted', 'Cancelled')),
+    event_data JSONB NOT NULL,
+    event_id VARCHAR(66),
+    event_index INTEGER,
+    event_target VARCHAR(42),
+    event_value DECIMAL(200,0) DEFAULT 0,
+    event_call_data BYTEA,
+    event_predecessor VARCHAR(66),
+    event_delay BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(tx_hash, contract_address, event_type)
+);
+
+-- Timelock 交易流程关联表
+CREATE TABLE IF NOT EXISTS timelock_transaction_flows (
+    id BIGSERIAL PRIMARY KEY,
+    flow_id VARCHAR(128) NOT NULL,
+    timelock_standard VARCHAR(20) NOT NULL CHECK (timelock_standard IN ('compound', 'openzeppelin')),
+    chain_id INTEGER NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'ready', 'executed', 'cancelled', 'expired')),
+    propose_tx_hash VARCHAR(66),
+    queue_tx_hash VARCHAR(66),
+    execute_tx_hash VARCHAR(66),
+    cancel_tx_hash VARCHAR(66),
+    initiator_address VARCHAR(42),
+    queued_at TIMESTAMP WITH TIME ZONE,
+    executed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    eta TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE,
+    target_address VARCHAR(42),
+    call_data BYTEA,
+    value DECIMAL(200,0) DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(flow_id, timelock_standard, chain_id, contract_address)
+);
+
+-- emails 表
+CREATE TABLE IF NOT EXISTS emails (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(200) NOT NULL UNIQUE,
+    is_deliverable BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- user_emails 表
+CREATE TABLE IF NOT EXISTS user_emails (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    remark VARCHAR(500),
+    is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    last_verified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_id, email_id)
+);
+
+-- email_verification_codes 表
+CREATE TABLE IF NOT EXISTS email_verification_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_email_id BIGINT NOT NULL REFERENCES user_emails(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    is_used BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- email_send_logs 表（按邮箱去重）
+CREATE TABLE IF NOT EXISTS email_send_logs (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    flow_id VARCHAR(128) NOT NULL,
+    timelock_standard VARCHAR(20) NOT NULL,
+    chain_id INTEGER NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    status_from VARCHAR(20),
+    status_to VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66),
+    send_status VARCHAR(20) NOT NULL CHECK (send_status IN ('success','failed')),
+    error_message TEXT,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(email_id, flow_id, status_to)
+);
+
+-- safe_wallets 表
+CREATE TABLE IF NOT EXISTS safe_wallets (
+    id BIGSERIAL PRIMARY KEY,
+    safe_address VARCHAR(42) NOT NULL,
+    chain_id INTEGER NOT NULL,
+    chain_name VARCHAR(50) NOT NULL,
+    threshold INTEGER NOT NULL,
+    owners TEXT NOT NULL,
+    version VARCHAR(20),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive')),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(safe_address, chain_id)
+);
+
+-- auth_nonces 表
+CREATE TABLE IF NOT EXISTS auth_nonces (
+    id BIGSERIAL PRIMARY KEY,
+    wallet_address VARCHAR(42) NOT NULL,
+    nonce VARCHAR(128) NOT NULL,
+    message TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    is_used BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(wallet_address, nonce)
+);
+
+-- telegram_configs 表
+CREATE TABLE IF NOT EXISTS telegram_configs (
+    id BIGSERIAL PRIMARY KEY,
+    user_address VARCHAR(42) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    bot_token VARCHAR(500) NOT NULL,
+    chat_id VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_address, name)
+);
+
+-- lark_configs 表
+CREATE TABLE IF NOT EXISTS lark_configs (
+    id BIGSERIAL PRIMARY KEY,
+    user_address VARCHAR(42) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    webhook_url VARCHAR(1000) NOT NULL,
+    secret VARCHAR(500) DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_address, name)
+);
+
+-- feishu_configs 表
+CREATE TABLE IF NOT EXISTS feishu_configs (
+    id BIGSERIAL PRIMARY KEY,
+    user_address VARCHAR(42) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    webhook_url VARCHAR(1000) NOT NULL,
+    secret VARCHAR(500) DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_address, name)
+);
+
+-- notification_logs 表
+CREATE TABLE IF NOT EXISTS notification_logs (
+    id BIGSERIAL PRIMARY KEY,
+    user_address VARCHAR(42) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    config_id BIGINT NOT NULL,
+    flow_id VARCHAR(128) NOT NULL,
+    timelock_standard VARCHAR(20) NOT NULL,
+    chain_id INTEGER NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    status_from VARCHAR(20),
+    status_to VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66),
+    send_status VARCHAR(20) NOT NULL CHECK (send_status IN ('success','failed')),
+    error_message TEXT,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(channel, config_id, flow_id, status_to)
+);
@@ -0,0 +1,73 @@
+DROP INDEX IF EXISTS idx_notification_logs_unique_check;
+DROP INDEX IF EXISTS idx_notification_logs_flow_status;
+DROP INDEX IF EXISTS idx_notification_logs_contract;
+DROP INDEX IF EXISTS idx_notification_logs_sent_at;
+DROP INDEX IF EXISTS idx_notification_logs_send_status;
+DROP INDEX IF EXISTS idx_notification_logs_config;
+DROP INDEX IF EXISTS idx_notification_logs_channel;
+DROP INDEX IF EXISTS idx_notification_logs_flow;
+DROP INDEX IF EXISTS idx_notification_logs_user;
+DROP INDEX IF EXISTS idx_feishu_configs_user_name;
+DROP INDEX IF EXISTS idx_feishu_configs_active;
+DROP INDEX IF EXISTS idx_feishu_configs_user;
+DROP INDEX IF EXISTS idx_lark_configs_user_name;
+DROP INDEX IF EXISTS idx_lark_configs_active;
+DROP INDEX IF EXISTS idx_lark_configs_user;
+DROP INDEX IF EXISTS idx_telegram_configs_user_name;
+DROP INDEX IF EXISTS idx_telegram_configs_active;
+DROP INDEX IF EXISTS idx_telegram_configs_user;
+DROP INDEX IF EXISTS idx_auth_nonces_wallet_nonce;
+DROP INDEX IF EXISTS idx_auth_nonces_used;
+DROP INDEX IF EXISTS idx_auth_nonces_expires;
+DROP INDEX IF EXISTS idx_auth_nonces_wallet;
+DROP INDEX IF EXISTS idx_safe_wallets_address_chain;
+DROP INDEX IF EXISTS idx_safe_wallets_status;
+DROP INDEX IF EXISTS idx_safe_wallets_chain;
+DROP INDEX IF EXISTS idx_safe_wallets_address;
+DROP INDEX IF EXISTS idx_send_logs_contract;
+DROP INDEX IF EXISTS idx_send_logs_flow_status;
+DROP INDEX IF EXISTS idx_verification_codes_expires;
+DROP INDEX IF EXISTS idx_verification_codes_user_email;
+DROP INDEX IF EXISTS idx_user_emails_email;
+DROP INDEX IF EXISTS idx_user_emails_user;
+DROP INDEX IF EXISTS idx_emails_email;
+DROP INDEX IF EXISTS idx_flows_initiator;
+DROP INDEX IF EXISTS idx_flows_expired_at;
+DROP INDEX IF EXISTS idx_flows_eta;
+DROP INDEX IF EXISTS idx_flows_status;
+DROP INDEX IF EXISTS idx_flows_standard_chain_contract;
+DROP INDEX IF EXISTS idx_flows_chain_contract;
+DROP INDEX IF EXISTS idx_oztt_event_data_gin;
+DROP INDEX IF EXISTS idx_oztt_event_value;
+DROP INDEX IF EXISTS idx_oztt_event_id;
+DROP INDEX IF EXISTS idx_oztt_tx_hash;
+DROP INDEX IF EXISTS idx_oztt_event_type;
+DROP INDEX IF EXISTS idx_oztt_block_number;
+DROP INDEX IF EXISTS idx_oztt_chain_contract;
+DROP INDEX IF EXISTS idx_ctt_event_data_gin;
+DROP INDEX IF EXISTS idx_ctt_event_value;
+DROP INDEX IF EXISTS idx_ctt_event_tx_hash;
+DROP INDEX IF EXISTS idx_ctt_tx_hash;
+DROP INDEX IF EXISTS idx_ctt_event_type;
+DROP INDEX IF EXISTS idx_ctt_block_number;
+DROP INDEX IF EXISTS idx_ctt_chain_contract;
+DROP INDEX IF EXISTS idx_scan_progress_last_update;
+DROP INDEX IF EXISTS idx_scan_progress_status;
+DROP INDEX IF EXISTS idx_sponsors_is_active;
+DROP INDEX IF EXISTS idx_oz_timelocks_creator_chain_address;
+DROP INDEX IF EXISTS idx_oz_timelocks_chain_address;
+DROP INDEX IF EXISTS idx_oz_timelocks_status;
+DROP INDEX IF EXISTS idx_oz_timelocks_admin;
+DROP INDEX IF EXISTS idx_oz_timelocks_creator;
+DROP INDEX IF EXISTS idx_compound_timelocks_creator_chain_address;
+DROP INDEX IF EXISTS idx_compound_timelocks_chain_address;
+DROP INDEX IF EXISTS idx_compound_timelocks_status;
+DROP INDEX IF EXISTS idx_compound_timelocks_pending_admin;
+DROP INDEX IF EXISTS idx_compound_timelocks_admin;
+DROP INDEX IF EXISTS idx_compound_timelocks_creator;
+DROP INDEX IF EXISTS idx_abis_is_shared;
+DROP INDEX IF EXISTS idx_abis_owner;
+DROP INDEX IF EXISTS idx_support_chains_testnet;
+DROP INDEX IF EXISTS idx_support_chains_active_rpc;
+DROP INDEX IF EXISTS idx_support_chains_chain_id;
+DROP INDEX IF EXISTS idx_users_status;
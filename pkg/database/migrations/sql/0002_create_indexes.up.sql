@@ -0,0 +1,100 @@
+-- Users
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
+
+-- Support Chains
+CREATE INDEX IF NOT EXISTS idx_support_chains_chain_id ON support_chains(chain_id);
+CREATE INDEX IF NOT EXISTS idx_support_chains_active_rpc ON support_chains(is_active, rpc_enabled);
+CREATE INDEX IF NOT EXISTS idx_support_chains_testnet ON support_chains(is_testnet);
+
+-- ABIs
+CREATE INDEX IF NOT EXISTS idx_abis_owner ON abis(owner);
+CREATE INDEX IF NOT EXISTS idx_abis_is_shared ON abis(is_shared);
+
+-- Compound Timelocks
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_creator ON compound_timelocks(creator_address);
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_admin ON compound_timelocks(admin);
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_pending_admin ON compound_timelocks(pending_admin);
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_status ON compound_timelocks(status);
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_chain_address ON compound_timelocks(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_compound_timelocks_creator_chain_address ON compound_timelocks(creator_address, chain_id, contract_address);
+
+-- OpenZeppelin Timelocks
+CREATE INDEX IF NOT EXISTS idx_oz_timelocks_creator ON openzeppelin_timelocks(creator_address);
+CREATE INDEX IF NOT EXISTS idx_oz_timelocks_admin ON openzeppelin_timelocks(admin);
+CREATE INDEX IF NOT EXISTS idx_oz_timelocks_status ON openzeppelin_timelocks(status);
+CREATE INDEX IF NOT EXISTS idx_oz_timelocks_chain_address ON openzeppelin_timelocks(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_oz_timelocks_creator_chain_address ON openzeppelin_timelocks(creator_address, chain_id, contract_address);
+
+-- Sponsors
+CREATE INDEX IF NOT EXISTS idx_sponsors_is_active ON sponsors(is_active);
+
+-- Block Scan Progress
+CREATE INDEX IF NOT EXISTS idx_scan_progress_status ON block_scan_progress(scan_status);
+CREATE INDEX IF NOT EXISTS idx_scan_progress_last_update ON block_scan_progress(last_update_time);
+
+-- Compound Timelock Transactions
+CREATE INDEX IF NOT EXISTS idx_ctt_chain_contract ON compound_timelock_transactions(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_ctt_block_number ON compound_timelock_transactions(block_number);
+CREATE INDEX IF NOT EXISTS idx_ctt_event_type ON compound_timelock_transactions(event_type);
+CREATE INDEX IF NOT EXISTS idx_ctt_tx_hash ON compound_timelock_transactions(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_ctt_event_tx_hash ON compound_timelock_transactions(event_tx_hash);
+CREATE INDEX IF NOT EXISTS idx_ctt_event_value ON compound_timelock_transactions(event_value);
+CREATE INDEX IF NOT EXISTS idx_ctt_event_data_gin ON compound_timelock_transactions USING GIN (event_data);
+
+-- OpenZeppelin Timelock Transactions
+CREATE INDEX IF NOT EXISTS idx_oztt_chain_contract ON openzeppelin_timelock_transactions(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_oztt_block_number ON openzeppelin_timelock_transactions(block_number);
+CREATE INDEX IF NOT EXISTS idx_oztt_event_type ON openzeppelin_timelock_transactions(event_type);
+CREATE INDEX IF NOT EXISTS idx_oztt_tx_hash ON openzeppelin_timelock_transactions(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_oztt_event_id ON openzeppelin_timelock_transactions(event_id);
+CREATE INDEX IF NOT EXISTS idx_oztt_event_value ON openzeppelin_timelock_transactions(event_value);
+CREATE INDEX IF NOT EXISTS idx_oztt_event_data_gin ON openzeppelin_timelock_transactions USING GIN (event_data);
+
+-- Timelock Transaction Flows
+CREATE INDEX IF NOT EXISTS idx_flows_chain_contract ON timelock_transaction_flows(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_flows_standard_chain_contract ON timelock_transaction_flows(timelock_standard, chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_flows_status ON timelock_transaction_flows(status);
+CREATE INDEX IF NOT EXISTS idx_flows_eta ON timelock_transaction_flows(eta);
+CREATE INDEX IF NOT EXISTS idx_flows_expired_at ON timelock_transaction_flows(expired_at);
+CREATE INDEX IF NOT EXISTS idx_flows_initiator ON timelock_transaction_flows(initiator_address);
+
+-- Email & Notification
+CREATE INDEX IF NOT EXISTS idx_emails_email ON emails(email);
+CREATE INDEX IF NOT EXISTS idx_user_emails_user ON user_emails(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_emails_email ON user_emails(email_id);
+CREATE INDEX IF NOT EXISTS idx_verification_codes_user_email ON email_verification_codes(user_email_id, is_used);
+CREATE INDEX IF NOT EXISTS idx_verification_codes_expires ON email_verification_codes(expires_at);
+CREATE INDEX IF NOT EXISTS idx_send_logs_flow_status ON email_send_logs(flow_id, status_to);
+CREATE INDEX IF NOT EXISTS idx_send_logs_contract ON email_send_logs(timelock_standard, chain_id, contract_address);
+
+-- Safe Wallets
+CREATE INDEX IF NOT EXISTS idx_safe_wallets_address ON safe_wallets(safe_address);
+CREATE INDEX IF NOT EXISTS idx_safe_wallets_chain ON safe_wallets(chain_id);
+CREATE INDEX IF NOT EXISTS idx_safe_wallets_status ON safe_wallets(status);
+CREATE INDEX IF NOT EXISTS idx_safe_wallets_address_chain ON safe_wallets(safe_address, chain_id);
+
+-- Auth Nonces
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_wallet ON auth_nonces(wallet_address);
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_expires ON auth_nonces(expires_at);
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_used ON auth_nonces(is_used);
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_wallet_nonce ON auth_nonces(wallet_address, nonce);
+
+-- Notification Channels
+CREATE INDEX IF NOT EXISTS idx_telegram_configs_user ON telegram_configs(user_address);
+CREATE INDEX IF NOT EXISTS idx_telegram_configs_active ON telegram_configs(is_active);
+CREATE INDEX IF NOT EXISTS idx_telegram_configs_user_name ON telegram_configs(user_address, name);
+CREATE INDEX IF NOT EXISTS idx_lark_configs_user ON lark_configs(user_address);
+CREATE INDEX IF NOT EXISTS idx_lark_configs_active ON lark_configs(is_active);
+CREATE INDEX IF NOT EXISTS idx_lark_configs_user_name ON lark_configs(user_address, name);
+CREATE INDEX IF NOT EXISTS idx_feishu_configs_user ON feishu_configs(user_address);
+CREATE INDEX IF NOT EXISTS idx_feishu_configs_active ON feishu_configs(is_active);
+CREATE INDEX IF NOT EXISTS idx_feishu_configs_user_name ON feishu_configs(user_address, name);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_user ON notification_logs(user_address);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_flow ON notification_logs(flow_id);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_channel ON notification_logs(channel);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_config ON notification_logs(config_id);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_send_status ON notification_logs(send_status);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_sent_at ON notification_logs(sent_at);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_contract ON notification_logs(timelock_standard, chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_flow_status ON notification_logs(flow_id, status_to);
+CREATE INDEX IF NOT EXISTS idx_notification_logs_unique_check ON notification_logs(channel, config_id, flow_id, status_to);
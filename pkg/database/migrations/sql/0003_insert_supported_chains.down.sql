@@ -0,0 +1,23 @@
+DELETE FROM support_chains WHERE chain_name IN (
+    'eth-mainnet',
+    'bsc-mainnet',
+    'arbitrum-mainnet',
+    'optimism-mainnet',
+    'base-mainnet',
+    'linea-mainnet',
+    'scroll-mainnet',
+    'xlayer-mainnet',
+    'bitlayer-mainnet',
+    'mode-mainnet',
+    'plume-mainnet',
+    'core-mainnet',
+    'hemi-mainnet',
+    'goat-mainnet',
+    'b2-mainnet',
+    'ailayer-mainnet',
+    'zklink-mainnet',
+    'merlin-mainnet',
+    'hashkey-mainnet',
+    'eth-sepolia',
+    'bsc-testnet'
+);
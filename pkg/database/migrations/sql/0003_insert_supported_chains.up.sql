@@ -0,0 +1,26 @@
+-- 主网
+INSERT INTO support_chains (chain_name, display_name, chain_id, native_currency_name, native_currency_symbol, native_currency_decimals, logo_url, is_testnet, is_active, alchemy_rpc_template, infura_rpc_template, official_rpc_urls, block_explorer_urls, rpc_enabled) VALUES
+    ('eth-mainnet', 'Ethereum', 1, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/eth-mainnet.png', FALSE, TRUE, 'https://eth.llamarpc.com', '', '["https://ethereum.publicnode.com","https://rpc.ankr.com/eth"]', '["https://etherscan.io"]', TRUE),
+    ('bsc-mainnet', 'BNB Chain', 56, 'BNB', 'BNB', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/bsc-mainnet.png', FALSE, TRUE, 'https://binance.llamarpc.com', '', '["https://bsc.drpc.org", "https://bsc.blockrazor.xyz"]', '["https://bscscan.com"]', TRUE),
+    ('arbitrum-mainnet', 'Arbitrum', 42161, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/arbitrum-mainnet.png', FALSE, TRUE, 'https://arb1.arbitrum.io/rpc', '', '["https://arbitrum.drpc.org", "https://arb-pokt.nodies.app"]', '["https://arbiscan.io"]', TRUE),
+    ('optimism-mainnet', 'Optimism', 10, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/optimism-mainnet.png', FALSE, TRUE, 'https://mainnet.optimism.io', '', '["https://mainnet.optimism.io","https://optimism.drpc.org"]', '["https://optimistic.etherscan.io"]', TRUE),
+    ('base-mainnet', 'Base', 8453, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/base-mainnet.png', FALSE, TRUE, 'https://mainnet.base.org', '', '["https://mainnet.base.org","https://base.llamarpc.com"]', '["https://basescan.org"]', TRUE),
+    ('linea-mainnet', 'Linea', 59144, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/linea-mainnet.png', FALSE, TRUE, 'https://rpc.linea.build', '', '["https://rpc.linea.build", "https://linea.drpc.org"]', '["https://lineascan.build"]', TRUE),
+    ('scroll-mainnet', 'Scroll', 534352, 'SCROLL', 'SCROLL', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/scroll-mainnet.png', FALSE, TRUE, 'https://rpc.scroll.io', '', '["https://rpc.scroll.io"]', '["https://scrollscan.com"]', TRUE),
+    ('xlayer-mainnet', 'X Layer', 196, 'OKB', 'OKB', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/xlayer-mainnet.png', FALSE, TRUE, 'https://rpc.xlayer.tech', '', '["https://rpc.xlayer.tech", "https://xlayerrpc.okx.com"]', '["https://web3.okx.com/explorer/x-layer"]', TRUE),
+    ('bitlayer-mainnet', 'Bitlayer', 200901, 'BTC', 'BTC', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/bitlayer-mainnet.jpg', FALSE, TRUE, 'https://rpc.ankr.com/bitlayer', '', '["https://rpc.ankr.com/bitlayer", "https://rpc-bitlayer.rockx.com"]', '["https://www.btrscan.com"]', TRUE),
+    ('mode-mainnet', 'Mode', 34443, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/mode-mainnet.png', FALSE, TRUE, 'https://mainnet.mode.network', '', '["https://mainnet.mode.network", "https://mode.drpc.org"]', '["https://explorer.mode.network"]', TRUE),
+    ('plume-mainnet', 'Plume', 98866, 'Plume', 'PLUME', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/plume-mainnet.jpg', FALSE, TRUE, 'https://rpc.plume.org', '', '["https://rpc.plume.org"]', '["https://explorer.plumenetwork.xyz"]', TRUE),
+    ('core-mainnet', 'Core', 1116, 'Core', 'CORE', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/core-mainnet.png', FALSE, TRUE, 'https://rpc.ankr.com/core', '', '["https://rpc.ankr.com/core", "https://core.drpc.org"]', '["https://scan.coredao.org"]', TRUE),
+    ('hemi-mainnet', 'Hemi', 43111, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/hemi-mainnet.jpg', FALSE, TRUE, 'https://rpc.hemi.network/rpc', '', '["https://rpc.hemi.network/rpc", "https://hemi.drpc.org"]', '["https://explorer.hemi.xyz"]', TRUE),
+    ('goat-mainnet', 'GOAT', 2345, 'BTC', 'BTC', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/goat-mainnet.jpg', FALSE, TRUE, 'https://rpc.ankr.com/goat_mainnet', '', '["https://rpc.ankr.com/goat_mainnet", "https://rpc.goat.network"]', '["https://explorer.goat.network"]', TRUE),
+    ('b2-mainnet', 'B2', 223, 'BTC', 'BTC', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/b2-mainnet.jpg', FALSE, TRUE, 'https://rpc.ankr.com/b2', '', '["https://rpc.bsquared.network", "https://rpc.ankr.com/b2"]', '["https://explorer.bsquared.network"]', TRUE),
+    ('ailayer-mainnet', 'AILayer', 2649, 'BTC', 'BTC', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/ailayer-mainnet.jpg', FALSE, TRUE, 'https://mainnet-rpc.ailayer.xyz', '', '["https://mainnet-rpc.ailayer.xyz"]', '["https://mainnet-explorer.ailayer.xyz"]', TRUE),
+    ('zklink-mainnet', 'zkLink', 810180, 'Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/zklink-mainnet.jpg', FALSE, TRUE, 'https://rpc.zklink.io', '', '["https://rpc.zklink.io"]', '["https://explorer.zklink.io"]', TRUE),
+    ('merlin-mainnet', 'Merlin', 4200, 'BTC', 'BTC', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/merlin-mainnet.jpg', FALSE, TRUE, 'https://rpc.merlinchain.io', '', '["https://rpc.merlinchain.io", "https://merlin.drpc.org"]', '["https://scan.merlinchain.io"]', TRUE),
+    ('hashkey-mainnet', 'HashKey', 177, 'HSK', 'HSK', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/hashkey-mainnet.jpg', FALSE, TRUE, 'https://mainnet.hsk.xyz', '', '["https://mainnet.hsk.xyz", "https://hashkey.drpc.org"]', '["https://hashkey.blockscout.com"]', TRUE);
+
+-- 测试网
+INSERT INTO support_chains (chain_name, display_name, chain_id, native_currency_name, native_currency_symbol, native_currency_decimals, logo_url, is_testnet, is_active, alchemy_rpc_template, infura_rpc_template, official_rpc_urls, block_explorer_urls, rpc_enabled) VALUES
+    ('eth-sepolia', 'Sepolia', 11155111, 'Sepolia Ether', 'ETH', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/eth-sepolia.png', TRUE, TRUE, 'https://rpc.sepolia.ethpandaops.io', '', '["https://ethereum-sepolia-rpc.publicnode.com","https://1rpc.io/sepolia"]', '["https://sepolia.etherscan.io"]', TRUE),
+    ('bsc-testnet', 'BNB Testnet', 97, 'Test BNB', 'BNB', 18, 'https://raw.githubusercontent.com/timelock-labs/assets/main/chains/bsc-testnet.png', TRUE, TRUE, 'https://api.zan.top/bsc-testnet', '', '["https://bsc-testnet-rpc.publicnode.com","https://bsc-testnet.drpc.org"]', '["https://testnet.bscscan.com"]', TRUE);
@@ -0,0 +1,8 @@
+DELETE FROM abis
+WHERE is_shared = TRUE
+  AND owner = '0x0000000000000000000000000000000000000000'
+  AND name IN (
+    'ERC20 Token',
+    'ERC721 NFT',
+    'Compound Timelock'
+  );
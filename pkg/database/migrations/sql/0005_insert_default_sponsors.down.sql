@@ -0,0 +1,9 @@
+DELETE FROM sponsors WHERE name IN (
+    'AAVE',
+    'Lido',
+    'EigenLayer',
+    'Ethena',
+    'Uniswap',
+    'Compound',
+    'OpenZeppelin'
+);
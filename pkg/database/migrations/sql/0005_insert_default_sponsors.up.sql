@@ -0,0 +1,12 @@
+-- 赞助方
+INSERT INTO sponsors (name, logo_url, link, description, type, sort_order, is_active) VALUES
+    ('AAVE', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/AAVE.png', 'https://aave.com', 'Decentralized lending and borrowing protocol.', 'sponsor', 100, TRUE),
+    ('Lido', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/Lido.jpg', 'https://lido.fi', 'Liquid staking solution for Ethereum.', 'sponsor', 90, TRUE),
+    ('EigenLayer', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/EigenLayer.jpg', 'https://www.eigenlayer.xyz', 'Restaking protocol for Ethereum.', 'sponsor', 80, TRUE);
+
+-- 生态伙伴
+INSERT INTO sponsors (name, logo_url, link, description, type, sort_order, is_active) VALUES
+    ('Ethena', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/Ethena.png', 'https://ethena.fi', 'Synthetic dollar protocol.', 'partner', 100, TRUE),
+    ('Uniswap', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/Uniswap.jpg', 'https://uniswap.org', 'Decentralized exchange protocol.', 'partner', 90, TRUE),
+    ('Compound', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/Compound.png', 'https://compound.finance', 'Decentralized lending protocol.', 'partner', 50, TRUE),
+    ('OpenZeppelin', 'https://raw.githubusercontent.com/timelock-labs/assets/main/sponsors/OpenZeppelin.png', 'https://openzeppelin.com', 'Smart contract development platform.', 'partner', 40, TRUE);
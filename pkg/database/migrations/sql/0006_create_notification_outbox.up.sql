@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email','im')),
+    flow_id VARCHAR(128) NOT NULL,
+    timelock_standard VARCHAR(20) NOT NULL,
+    chain_id INTEGER NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    status_from VARCHAR(20),
+    status_to VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66),
+    initiator_address VARCHAR(42),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','processing','sent','dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    locked_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_due ON notification_outbox(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_flow ON notification_outbox(flow_id, status_to);
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_contract ON notification_outbox(timelock_standard, chain_id, contract_address);
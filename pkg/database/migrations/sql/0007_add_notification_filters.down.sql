@@ -0,0 +1,4 @@
+ALTER TABLE telegram_configs DROP COLUMN IF EXISTS filters;
+ALTER TABLE lark_configs DROP COLUMN IF EXISTS filters;
+ALTER TABLE feishu_configs DROP COLUMN IF EXISTS filters;
+ALTER TABLE user_emails DROP COLUMN IF EXISTS filters;
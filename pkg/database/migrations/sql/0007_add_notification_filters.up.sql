@@ -0,0 +1,4 @@
+ALTER TABLE telegram_configs ADD COLUMN IF NOT EXISTS filters JSONB;
+ALTER TABLE lark_configs ADD COLUMN IF NOT EXISTS filters JSONB;
+ALTER TABLE feishu_configs ADD COLUMN IF NOT EXISTS filters JSONB;
+ALTER TABLE user_emails ADD COLUMN IF NOT EXISTS filters JSONB;
@@ -0,0 +1,4 @@
+ALTER TABLE telegram_configs DROP COLUMN IF EXISTS message_template;
+ALTER TABLE lark_configs DROP COLUMN IF EXISTS message_template;
+ALTER TABLE feishu_configs DROP COLUMN IF EXISTS message_template;
+ALTER TABLE user_emails DROP COLUMN IF EXISTS email_template;
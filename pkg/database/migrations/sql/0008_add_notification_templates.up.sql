@@ -0,0 +1,4 @@
+ALTER TABLE telegram_configs ADD COLUMN IF NOT EXISTS message_template TEXT;
+ALTER TABLE lark_configs ADD COLUMN IF NOT EXISTS message_template TEXT;
+ALTER TABLE feishu_configs ADD COLUMN IF NOT EXISTS message_template TEXT;
+ALTER TABLE user_emails ADD COLUMN IF NOT EXISTS email_template TEXT;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS digest_send_logs;
+
+ALTER TABLE telegram_configs DROP COLUMN IF EXISTS digest_mode;
+ALTER TABLE lark_configs DROP COLUMN IF EXISTS digest_mode;
+ALTER TABLE feishu_configs DROP COLUMN IF EXISTS digest_mode;
+ALTER TABLE user_emails DROP COLUMN IF EXISTS digest_mode;
@@ -0,0 +1,19 @@
+ALTER TABLE telegram_configs ADD COLUMN IF NOT EXISTS digest_mode VARCHAR(10) NOT NULL DEFAULT 'off' CHECK (digest_mode IN ('off','daily','weekly'));
+ALTER TABLE lark_configs ADD COLUMN IF NOT EXISTS digest_mode VARCHAR(10) NOT NULL DEFAULT 'off' CHECK (digest_mode IN ('off','daily','weekly'));
+ALTER TABLE feishu_configs ADD COLUMN IF NOT EXISTS digest_mode VARCHAR(10) NOT NULL DEFAULT 'off' CHECK (digest_mode IN ('off','daily','weekly'));
+ALTER TABLE user_emails ADD COLUMN IF NOT EXISTS digest_mode VARCHAR(10) NOT NULL DEFAULT 'off' CHECK (digest_mode IN ('off','daily','weekly'));
+
+CREATE TABLE IF NOT EXISTS digest_send_logs (
+    id BIGSERIAL PRIMARY KEY,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('telegram','lark','feishu','email')),
+    config_id BIGINT NOT NULL,
+    period_type VARCHAR(10) NOT NULL CHECK (period_type IN ('daily','weekly')),
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    flow_count INTEGER NOT NULL DEFAULT 0,
+    send_status VARCHAR(20) NOT NULL CHECK (send_status IN ('success','failed','skipped')),
+    error_message TEXT,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (channel, config_id, period_type, period_start)
+);
@@ -0,0 +1,6 @@
+-- 存在超过原长度的加密值时回滚失败，不会截断数据
+ALTER TABLE telegram_configs ALTER COLUMN bot_token TYPE VARCHAR(500);
+ALTER TABLE lark_configs ALTER COLUMN webhook_url TYPE VARCHAR(1000);
+ALTER TABLE lark_configs ALTER COLUMN secret TYPE VARCHAR(500);
+ALTER TABLE feishu_configs ALTER COLUMN webhook_url TYPE VARCHAR(1000);
+ALTER TABLE feishu_configs ALTER COLUMN secret TYPE VARCHAR(500);
@@ -0,0 +1,6 @@
+-- 加密后的密钥长度超过原字段长度
+ALTER TABLE telegram_configs ALTER COLUMN bot_token TYPE TEXT;
+ALTER TABLE lark_configs ALTER COLUMN webhook_url TYPE TEXT;
+ALTER TABLE lark_configs ALTER COLUMN secret TYPE TEXT;
+ALTER TABLE feishu_configs ALTER COLUMN webhook_url TYPE TEXT;
+ALTER TABLE feishu_configs ALTER COLUMN secret TYPE TEXT;
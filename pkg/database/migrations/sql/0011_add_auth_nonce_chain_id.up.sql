@@ -0,0 +1 @@
+ALTER TABLE auth_nonces ADD COLUMN IF NOT EXISTS chain_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,39 @@
+-- 组织资源回到个人唯一约束下，存在同名个人资源时回滚失败
+DROP INDEX IF EXISTS idx_user_emails_org_unique;
+DROP INDEX IF EXISTS idx_user_emails_personal_unique;
+ALTER TABLE user_emails DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE user_emails ADD UNIQUE (user_id, email_id);
+
+DROP INDEX IF EXISTS idx_feishu_configs_org_unique;
+DROP INDEX IF EXISTS idx_feishu_configs_personal_unique;
+ALTER TABLE feishu_configs DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE feishu_configs ADD UNIQUE (user_address, name);
+
+DROP INDEX IF EXISTS idx_lark_configs_org_unique;
+DROP INDEX IF EXISTS idx_lark_configs_personal_unique;
+ALTER TABLE lark_configs DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE lark_configs ADD UNIQUE (user_address, name);
+
+DROP INDEX IF EXISTS idx_telegram_configs_org_unique;
+DROP INDEX IF EXISTS idx_telegram_configs_personal_unique;
+ALTER TABLE telegram_configs DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE telegram_configs ADD UNIQUE (user_address, name);
+
+DROP INDEX IF EXISTS idx_abis_org_unique;
+DROP INDEX IF EXISTS idx_abis_personal_unique;
+ALTER TABLE abis DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE abis ADD UNIQUE (name, owner);
+
+DROP INDEX IF EXISTS idx_openzeppelin_timelocks_org_unique;
+DROP INDEX IF EXISTS idx_openzeppelin_timelocks_personal_unique;
+ALTER TABLE openzeppelin_timelocks DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE openzeppelin_timelocks ADD UNIQUE (creator_address, chain_id, contract_address);
+
+DROP INDEX IF EXISTS idx_compound_timelocks_org_unique;
+DROP INDEX IF EXISTS idx_compound_timelocks_personal_unique;
+ALTER TABLE compound_timelocks DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE compound_timelocks ADD UNIQUE (creator_address, chain_id, contract_address);
+
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
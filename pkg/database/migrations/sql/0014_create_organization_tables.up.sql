@@ -0,0 +1,68 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) DEFAULT '',
+    created_by VARCHAR(42) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(42) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    invited_by VARCHAR(42),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(organization_id, wallet_address)
+);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(42) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    invited_by VARCHAR(42) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'revoked')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 共享资源表增加组织归属，原唯一约束仅对个人资源生效，组织资源在组织内唯一
+-- 原表级唯一约束名由PostgreSQL自动生成（可能被截断），按表查询后删除
+DO $$
+DECLARE
+    t TEXT;
+    c TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['compound_timelocks', 'openzeppelin_timelocks', 'abis', 'telegram_configs', 'lark_configs', 'feishu_configs', 'user_emails'] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS organization_id BIGINT NOT NULL DEFAULT 0', t);
+        FOR c IN SELECT conname FROM pg_constraint WHERE conrelid = t::regclass AND contype = 'u' LOOP
+            EXECUTE format('ALTER TABLE %I DROP CONSTRAINT IF EXISTS %I', t, c);
+        END LOOP;
+    END LOOP;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_compound_timelocks_personal_unique ON compound_timelocks(creator_address, chain_id, contract_address) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_compound_timelocks_org_unique ON compound_timelocks(organization_id, chain_id, contract_address) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_openzeppelin_timelocks_personal_unique ON openzeppelin_timelocks(creator_address, chain_id, contract_address) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_openzeppelin_timelocks_org_unique ON openzeppelin_timelocks(organization_id, chain_id, contract_address) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abis_personal_unique ON abis(name, owner) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abis_org_unique ON abis(organization_id, name) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_telegram_configs_personal_unique ON telegram_configs(user_address, name) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_telegram_configs_org_unique ON telegram_configs(organization_id, name) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lark_configs_personal_unique ON lark_configs(user_address, name) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lark_configs_org_unique ON lark_configs(organization_id, name) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feishu_configs_personal_unique ON feishu_configs(user_address, name) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feishu_configs_org_unique ON feishu_configs(organization_id, name) WHERE organization_id <> 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_emails_personal_unique ON user_emails(user_id, email_id) WHERE organization_id = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_emails_org_unique ON user_emails(organization_id, email_id) WHERE organization_id <> 0;
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_wallet ON organization_members(wallet_address);
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org ON organization_invitations(organization_id);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_wallet_status ON organization_invitations(wallet_address, status);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id BIGINT NOT NULL DEFAULT 0,
+    actor_address VARCHAR(42) NOT NULL,
+    auth_type VARCHAR(20),
+    organization_id BIGINT NOT NULL DEFAULT 0,
+    action VARCHAR(64) NOT NULL,
+    resource_type VARCHAR(32) NOT NULL,
+    resource_id VARCHAR(200) NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor_address, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_organization ON audit_events(organization_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_resource ON audit_events(resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_linked_wallets (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(42) NOT NULL UNIQUE,
+    wallet_type VARCHAR(10) NOT NULL DEFAULT 'eoa',
+    chain_id INTEGER NOT NULL DEFAULT 0,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_linked_wallets_user_id ON user_linked_wallets(user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS backup_runs (
+    id BIGSERIAL PRIMARY KEY,
+    backup_id VARCHAR(64),
+    backup_type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    file TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    table_count INTEGER NOT NULL DEFAULT 0,
+    row_count BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64),
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_backup_runs_started_at ON backup_runs(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_backup_runs_status ON backup_runs(status, started_at DESC);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id BIGSERIAL PRIMARY KEY,
+    target_table VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    cutoff TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    deleted_rows BIGINT NOT NULL DEFAULT 0,
+    archived_rows BIGINT NOT NULL DEFAULT 0,
+    archive_file TEXT,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started_at ON retention_runs(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_retention_runs_table ON retention_runs(target_table, started_at DESC);
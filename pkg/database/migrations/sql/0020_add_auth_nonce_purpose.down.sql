@@ -0,0 +1 @@
+ALTER TABLE auth_nonces DROP COLUMN IF EXISTS purpose;
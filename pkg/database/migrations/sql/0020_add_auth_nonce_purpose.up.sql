@@ -0,0 +1,2 @@
+-- 区分登录和关联钱包的nonce，防止关联签名被当作登录签名使用
+ALTER TABLE auth_nonces ADD COLUMN IF NOT EXISTS purpose VARCHAR(16) NOT NULL DEFAULT 'login';
@@ -0,0 +1,3 @@
+UPDATE digest_send_logs SET send_status = 'failed' WHERE send_status = 'pending';
+ALTER TABLE digest_send_logs DROP CONSTRAINT IF EXISTS digest_send_logs_send_status_check;
+ALTER TABLE digest_send_logs ADD CONSTRAINT digest_send_logs_send_status_check CHECK (send_status IN ('success','failed','skipped'));
@@ -0,0 +1,3 @@
+-- 摘要发送前先认领记录，认领中的记录状态为pending
+ALTER TABLE digest_send_logs DROP CONSTRAINT IF EXISTS digest_send_logs_send_status_check;
+ALTER TABLE digest_send_logs ADD CONSTRAINT digest_send_logs_send_status_check CHECK (send_status IN ('pending','success','failed','skipped'));
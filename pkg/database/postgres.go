@@ -13,8 +13,25 @@ import (
 	gormLogger "gorm.io/gorm/logger"
 )
 
-// NewPostgresConnection 创建PostgreSQL数据库连接
+// NewPostgresConnection 创建PostgreSQL数据库连接并执行待执行的迁移
 func NewPostgresConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := OpenPostgres(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// 运行数据库迁移
+	if err := migrations.InitTables(db); err != nil {
+		logger.Error("Failed to run database migrations", err)
+		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	}
+
+	logger.Info("Database connected successfully")
+	return db, nil
+}
+
+// OpenPostgres 创建PostgreSQL数据库连接，不执行迁移（供迁移工具使用）
+func OpenPostgres(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
@@ -40,12 +57,5 @@ func NewPostgresConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	// 运行数据库迁移
-	if err := migrations.InitTables(db); err != nil {
-		logger.Error("Failed to run database migrations", err)
-		return nil, fmt.Errorf("failed to run database migrations: %w", err)
-	}
-
-	logger.Info("Database connected successfully")
 	return db, nil
 }
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"timelocker-backend/pkg/database/migrations"
+	"timelocker-backend/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// RowArchive 历史数据清理前写入的归档
+type RowArchive struct {
+	Path   string  // 本地归档路径
+	Table  string  // 归档的表
+	Rows   int64   // 归档的行数
+	IDs    []int64 // 归档行的id，按升序排列，只应删除这些行
+	Size   int64
+	SHA256 string
+}
+
+// ArchiveRows 将表中满足条件的行写入归档，格式与备份相同（类型为archive），可用RestoreBackup按表恢复
+// 归档按备份配置加密，配置了远程存储时上传到存储根目录，但不记录在备份目录中，不受备份保留策略影响
+// 没有满足条件的行时不写入文件，返回的Rows为0
+func (bm *BackupManager) ArchiveRows(ctx context.Context, archivePath, table, where string, args []interface{}) (*RowArchive, error) {
+	if _, ok := backupTableDependencies[table]; !ok {
+		return nil, fmt.Errorf("unknown archive table: %s", table)
+	}
+
+	archiveDir := filepath.Dir(archivePath)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	schemaVersion, err := migrations.CurrentVersion(ctx, bm.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database schema version: %w", err)
+	}
+
+	now := time.Now()
+	manifest := BackupManifest{
+		Format:        backupArchiveFormat,
+		FormatVersion: backupFormatVersion,
+		Version:       backupDataVersion,
+		SchemaVersion: schemaVersion,
+		ID:            newBackupID(now),
+		Type:          BackupTypeArchive,
+		Timestamp:     now,
+		Compression:   backupCompression,
+	}
+
+	var fileKey []byte
+	if bm.encryption.CanEncrypt() {
+		key, stanzas, err := bm.encryption.NewFileKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive file key: %w", err)
+		}
+		fileKey = key
+		manifest.Encryption = &BackupEncryptionInfo{Cipher: backupCipher, Keys: stanzas}
+	}
+
+	result := &RowArchive{Path: archivePath, Table: table}
+	filter := &tableFilter{where: where, args: args}
+	var file archiveFileInfo
+	err = bm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		file, err = writeArchiveFile(archivePath, func(tw *tar.Writer) error {
+			var maxID int64
+			entry, err := writeTableEntry(tw, archiveDir, table, fileKey, func(emit backupRecordEmitter) error {
+				var err error
+				maxID, err = bm.streamTable(ctx, tx, table, filter, func(record map[string]interface{}) error {
+					id, _ := toInt64(record["id"])
+					result.IDs = append(result.IDs, id)
+					if err := bm.encryptRecordSecrets(table, record); err != nil {
+						return fmt.Errorf("failed to encrypt archive secrets: %w", err)
+					}
+					return emit(record)
+				})
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			entry.Watermark = TableWatermark{MaxID: maxID}
+			manifest.Tables = append(manifest.Tables, entry)
+			return writeManifest(tw, &manifest, fileKey)
+		})
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %w", table, err)
+	}
+
+	result.Rows = int64(len(result.IDs))
+	result.Size = file.Size
+	result.SHA256 = file.SHA256
+	if result.Rows == 0 {
+		os.Remove(archivePath)
+		return result, nil
+	}
+
+	if bm.storage != nil {
+		f, err := os.Open(archivePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive file: %w", err)
+		}
+		defer f.Close()
+		if err := bm.storage.Put(ctx, filepath.Base(archivePath), f, file.Size); err != nil {
+			logger.Error("Failed to upload archive, local copy kept", err, "path", archivePath, "storage", bm.storage.Location())
+			return nil, fmt.Errorf("failed to upload archive: %w", err)
+		}
+	}
+
+	logger.Info("Rows archived", "table", table, "path", archivePath, "rows", result.Rows, "size", file.Size, "encrypted", fileKey != nil)
+	return result, nil
+}
@@ -1,9 +1,16 @@
 package email
 
 import (
+	"context"
 	"fmt"
 	"net/smtp"
+	"time"
 	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SMTPSender SMTP邮件发送器
@@ -19,7 +26,7 @@ func NewSMTPSender(config *config.EmailConfig) *SMTPSender {
 }
 
 // SendEmail 发送邮件
-func (s *SMTPSender) SendEmail(to, subject, body string) error {
+func (s *SMTPSender) SendEmail(ctx context.Context, to, subject, body string) error {
 	// 配置SMTP认证
 	auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword, s.config.SMTPHost)
 
@@ -29,7 +36,7 @@ func (s *SMTPSender) SendEmail(to, subject, body string) error {
 
 	// 发送邮件
 	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
-	err := smtp.SendMail(addr, auth, s.config.FromEmail, []string{to}, []byte(msg))
+	err := s.sendMail(ctx, addr, auth, to, msg)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -38,12 +45,12 @@ func (s *SMTPSender) SendEmail(to, subject, body string) error {
 }
 
 // SendHTMLEmail 发送HTML格式邮件
-func (s *SMTPSender) SendHTMLEmail(to, subject, htmlBody string) error {
-	return s.SendEmail(to, subject, htmlBody)
+func (s *SMTPSender) SendHTMLEmail(ctx context.Context, to, subject, htmlBody string) error {
+	return s.SendEmail(ctx, to, subject, htmlBody)
 }
 
 // SendTextEmail 发送纯文本邮件
-func (s *SMTPSender) SendTextEmail(to, subject, textBody string) error {
+func (s *SMTPSender) SendTextEmail(ctx context.Context, to, subject, textBody string) error {
 	// 配置SMTP认证
 	auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword, s.config.SMTPHost)
 
@@ -53,10 +60,23 @@ func (s *SMTPSender) SendTextEmail(to, subject, textBody string) error {
 
 	// 发送邮件
 	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
-	err := smtp.SendMail(addr, auth, s.config.FromEmail, []string{to}, []byte(msg))
+	err := s.sendMail(ctx, addr, auth, to, msg)
 	if err != nil {
 		return fmt.Errorf("failed to send text email: %w", err)
 	}
 
 	return nil
 }
+
+// sendMail 通过SMTP发送并记录发送指标和span
+func (s *SMTPSender) sendMail(ctx context.Context, addr string, auth smtp.Auth, to, msg string) error {
+	_, span := tracing.StartKind(ctx, "notification.send", trace.SpanKindClient,
+		attribute.String("channel", "email"),
+		attribute.String("smtp.host", s.config.SMTPHost),
+	)
+	start := time.Now()
+	err := smtp.SendMail(addr, auth, s.config.FromEmail, []string{to}, []byte(msg))
+	metrics.ObserveNotification("email", time.Since(start), err)
+	tracing.End(span, err)
+	return err
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"sync"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -438,6 +440,66 @@ func Fatal(msg string, err error, fields ...interface{}) {
 	globalLogger.Fatal(msg, zapFields...)
 }
 
+// DebugCtx 调试日志，附带上下文中的trace_id和span_id
+func DebugCtx(ctx context.Context, msg string, fields ...interface{}) {
+	if !DebugEnabled {
+		return
+	}
+	logWithContext(ctx, zapcore.DebugLevel, msg, nil, fields)
+}
+
+// InfoCtx 信息日志，附带上下文中的trace_id和span_id
+func InfoCtx(ctx context.Context, msg string, fields ...interface{}) {
+	logWithContext(ctx, zapcore.InfoLevel, msg, nil, fields)
+}
+
+// WarnCtx 警告日志，附带上下文中的trace_id和span_id
+func WarnCtx(ctx context.Context, msg string, fields ...interface{}) {
+	logWithContext(ctx, zapcore.WarnLevel, msg, nil, fields)
+}
+
+// ErrorCtx 错误日志，附带上下文中的trace_id和span_id
+func ErrorCtx(ctx context.Context, msg string, err error, fields ...interface{}) {
+	logWithContext(ctx, zapcore.ErrorLevel, msg, err, fields)
+}
+
+// logWithContext 上下文日志的公共实现，调用层级比Info等多一层
+func logWithContext(ctx context.Context, level zapcore.Level, msg string, err error, fields []interface{}) {
+	if !LogEnabled {
+		return
+	}
+	ensureLogger()
+
+	zapFields := []zap.Field{
+		zap.String("caller", getCaller(1)),
+		zap.String("function", getFunction(1)),
+	}
+
+	// 没有有效span时（例如未启用追踪）不输出追踪字段
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		zapFields = append(zapFields,
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	if err != nil {
+		zapFields = append(zapFields, zap.Error(err))
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 < len(fields) {
+			key := fmt.Sprintf("%v", fields[i])
+			value := fields[i+1]
+			zapFields = append(zapFields, zap.Any(key, value))
+		}
+	}
+
+	if ce := globalLogger.WithOptions(zap.AddCallerSkip(1)).Check(level, msg); ce != nil {
+		ce.Write(zapFields...)
+	}
+}
+
 // SetLevel 动态设置日志级别
 func SetLevel(level LogLevel) {
 	// 这里可以根据需要重新初始化logger
@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"timelocker-backend/internal/types"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "timelocker"
+
+// 结果标签取值
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+)
+
+// Registry 应用指标注册表，包含Go运行时和进程指标，指标未启用时记录的数据不会被导出
+var Registry = prometheus.NewRegistry()
+
+var (
+	blocksScanned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "blocks_scanned_total",
+		Help:      "Blocks scanned per chain.",
+	}, []string{"chain_id", "chain_name"})
+
+	scanLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "lag_blocks",
+		Help:      "Blocks between the latest network block and the last scanned block per chain.",
+	}, []string{"chain_id", "chain_name"})
+
+	lastScannedBlock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "last_scanned_block",
+		Help:      "Last scanned block number per chain.",
+	}, []string{"chain_id", "chain_name"})
+
+	scanDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "scan_duration_seconds",
+		Help:      "Duration of one scan iteration per chain, including RPC calls, event processing and progress updates.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"chain_id", "chain_name"})
+
+	scanErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "scan_errors_total",
+		Help:      "Failed scan iterations per chain.",
+	}, []string{"chain_id", "chain_name"})
+
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "JSON-RPC requests per chain, provider, method and result.",
+	}, []string{"chain_id", "provider", "method", "result"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "JSON-RPC request latency per chain, provider and method.",
+		Buckets:   []float64{0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"chain_id", "provider", "method"})
+
+	notificationSends = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "sends_total",
+		Help:      "Notification sends per channel and result.",
+	}, []string{"channel", "result"})
+
+	notificationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "send_duration_seconds",
+		Help:      "Notification send latency per channel.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"channel"})
+
+	backupRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "runs_total",
+		Help:      "Scheduled backup runs per type and status.",
+	}, []string{"type", "status"})
+
+	backupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "duration_seconds",
+		Help:      "Scheduled backup duration per type.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200},
+	}, []string{"type"})
+
+	backupLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful scheduled backup per type.",
+	}, []string{"type"})
+
+	backupSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "last_size_bytes",
+		Help:      "Size of the last successful scheduled backup per type.",
+	}, []string{"type"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request duration per method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	flowStatus = &flowCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "flow", "total"),
+			"Timelock transaction flows per standard and status, counted when scraped.",
+			[]string{"standard", "status"}, nil,
+		),
+	}
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		blocksScanned, scanLag, lastScannedBlock, scanDuration, scanErrors,
+		rpcRequests, rpcDuration,
+		notificationSends, notificationDuration,
+		backupRuns, backupDuration, backupLastSuccess, backupSize,
+		httpDuration,
+		flowStatus,
+	)
+}
+
+// result 根据错误返回结果标签
+func result(err error) string {
+	if err != nil {
+		return ResultFailed
+	}
+	return ResultSuccess
+}
+
+// ObserveScan 记录一次扫描迭代的耗时和结果
+func ObserveScan(chainID int, chainName string, duration time.Duration, err error) {
+	id := strconv.Itoa(chainID)
+	scanDuration.WithLabelValues(id, chainName).Observe(duration.Seconds())
+	if err != nil {
+		scanErrors.WithLabelValues(id, chainName).Inc()
+	}
+}
+
+// AddBlocksScanned 累加已扫描的区块数
+func AddBlocksScanned(chainID int, chainName string, blocks int64) {
+	if blocks > 0 {
+		blocksScanned.WithLabelValues(strconv.Itoa(chainID), chainName).Add(float64(blocks))
+	}
+}
+
+// SetScanProgress 更新链的扫描进度和落后区块数
+func SetScanProgress(chainID int, chainName string, lastScanned, latest int64) {
+	id := strconv.Itoa(chainID)
+	lastScannedBlock.WithLabelValues(id, chainName).Set(float64(lastScanned))
+	scanLag.WithLabelValues(id, chainName).Set(float64(latest - lastScanned))
+}
+
+// ObserveRPC 记录一次JSON-RPC请求，批量请求的method为batch
+func ObserveRPC(chainID int, provider, method string, duration time.Duration, err error) {
+	id := strconv.Itoa(chainID)
+	rpcRequests.WithLabelValues(id, provider, method, result(err)).Inc()
+	rpcDuration.WithLabelValues(id, provider, method).Observe(duration.Seconds())
+}
+
+// ObserveNotification 记录一次通知发送，channel为telegram、lark、feishu或email
+func ObserveNotification(channel string, duration time.Duration, err error) {
+	notificationSends.WithLabelValues(channel, result(err)).Inc()
+	notificationDuration.WithLabelValues(channel).Observe(duration.Seconds())
+}
+
+// ObserveBackupRun 记录一次定时备份，size为成功时的备份大小
+func ObserveBackupRun(backupType, status string, duration time.Duration, size int64) {
+	backupRuns.WithLabelValues(backupType, status).Inc()
+	backupDuration.WithLabelValues(backupType).Observe(duration.Seconds())
+	if status == types.BackupRunStatusSuccess {
+		backupLastSuccess.WithLabelValues(backupType).SetToCurrentTime()
+		backupSize.WithLabelValues(backupType).Set(float64(size))
+	}
+}
+
+// ObserveHTTP 记录一次HTTP请求，route为路由模板，未匹配的请求为unmatched
+func ObserveHTTP(method, route string, status int, duration time.Duration) {
+	httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// FlowStatusSource 按标准和状态统计流程数量
+type FlowStatusSource func(ctx context.Context) ([]types.FlowStatusTotal, error)
+
+// SetFlowStatusSource 设置流程数量的统计来源，每次抓取指标时查询
+func SetFlowStatusSource(source FlowStatusSource) {
+	flowStatus.mu.Lock()
+	defer flowStatus.mu.Unlock()
+	flowStatus.source = source
+}
+
+// flowCollector 抓取时查询数据库的流程数量指标，查询失败时不输出该指标
+type flowCollector struct {
+	desc   *prometheus.Desc
+	mu     sync.RWMutex
+	source FlowStatusSource
+}
+
+func (c *flowCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *flowCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	source := c.source
+	c.mu.RUnlock()
+	if source == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	totals, err := source(ctx)
+	if err != nil {
+		logger.Error("Failed to collect flow status metrics", err)
+		return
+	}
+	for _, total := range totals {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(total.Count), total.TimelockStandard, total.Status)
+	}
+}
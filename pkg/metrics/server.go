@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server 独立端口上的指标服务
+type Server struct {
+	config *config.MetricsConfig
+	srv    *http.Server
+}
+
+// NewServer 创建指标服务
+func NewServer(cfg *config.MetricsConfig) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry:          Registry,
+		ErrorHandling:     promhttp.ContinueOnError,
+		EnableOpenMetrics: true,
+	}))
+
+	return &Server{
+		config: cfg,
+		srv: &http.Server{
+			Addr:              cfg.Address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start 在后台启动监听，监听失败只记录错误，不影响API服务
+func (s *Server) Start() {
+	go func() {
+		logger.Info("Starting metrics server", "address", s.config.Address, "path", s.config.Path)
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server error", err, "address", s.config.Address)
+		}
+	}()
+}
+
+// Shutdown 停止指标服务
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -2,22 +2,32 @@ package notification
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // FeishuSender 飞书消息发送器
-type FeishuSender struct{}
+type FeishuSender struct {
+	keyring *crypto.SecretKeyring
+}
 
 // NewFeishuSender 创建飞书发送器实例
-func NewFeishuSender() *FeishuSender {
-	return &FeishuSender{}
+func NewFeishuSender(keyring *crypto.SecretKeyring) *FeishuSender {
+	return &FeishuSender{keyring: keyring}
 }
 
 // FeishuMessage 飞书消息结构
@@ -33,8 +43,28 @@ type FeishuMessageContent struct {
 	Text string `json:"text"`
 }
 
-// SendMessage 发送飞书消息
-func (s *FeishuSender) SendMessage(webhookURL, secret, message string) error {
+// SendMessage 发送飞书消息并记录发送指标和span
+func (s *FeishuSender) SendMessage(ctx context.Context, webhookURL, secret, message string) error {
+	_, span := tracing.StartKind(ctx, "notification.send", trace.SpanKindClient, attribute.String("channel", "feishu"))
+	start := time.Now()
+	err := s.sendMessage(webhookURL, secret, message)
+	metrics.ObserveNotification("feishu", time.Since(start), err)
+	tracing.End(span, err)
+	return err
+}
+
+// sendMessage 发送飞书消息
+func (s *FeishuSender) sendMessage(webhookURL, secret, message string) error {
+	// 存储的Webhook URL和密钥为加密值，仅在发送时解密
+	webhookURL, err := s.keyring.Decrypt(webhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt feishu webhook url: %w", err)
+	}
+	secret, err = s.keyring.Decrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt feishu secret: %w", err)
+	}
+
 	feishuMsg := FeishuMessage{
 		MsgType: "text",
 		Content: FeishuMessageContent{
@@ -63,6 +93,10 @@ func (s *FeishuSender) SendMessage(webhookURL, secret, message string) error {
 	// 发送请求
 	resp, err := client.Post(webhookURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
+		// Webhook URL中包含访问令牌，错误信息中去掉URL避免泄露
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
 		return fmt.Errorf("failed to send feishu message: %w", err)
 	}
 	defer resp.Body.Close()
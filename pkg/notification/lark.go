@@ -2,22 +2,32 @@ package notification
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // LarkSender Lark消息发送器
-type LarkSender struct{}
+type LarkSender struct {
+	keyring *crypto.SecretKeyring
+}
 
 // NewLarkSender 创建Lark发送器实例
-func NewLarkSender() *LarkSender {
-	return &LarkSender{}
+func NewLarkSender(keyring *crypto.SecretKeyring) *LarkSender {
+	return &LarkSender{keyring: keyring}
 }
 
 // LarkMessage Lark消息结构
@@ -33,8 +43,28 @@ type LarkMessageContent struct {
 	Text string `json:"text"`
 }
 
-// SendMessage 发送Lark消息
-func (s *LarkSender) SendMessage(webhookURL, secret, message string) error {
+// SendMessage 发送Lark消息并记录发送指标和span
+func (s *LarkSender) SendMessage(ctx context.Context, webhookURL, secret, message string) error {
+	_, span := tracing.StartKind(ctx, "notification.send", trace.SpanKindClient, attribute.String("channel", "lark"))
+	start := time.Now()
+	err := s.sendMessage(webhookURL, secret, message)
+	metrics.ObserveNotification("lark", time.Since(start), err)
+	tracing.End(span, err)
+	return err
+}
+
+// sendMessage 发送Lark消息
+func (s *LarkSender) sendMessage(webhookURL, secret, message string) error {
+	// 存储的Webhook URL和密钥为加密值，仅在发送时解密
+	webhookURL, err := s.keyring.Decrypt(webhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt lark webhook url: %w", err)
+	}
+	secret, err = s.keyring.Decrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt lark secret: %w", err)
+	}
+
 	larkMsg := LarkMessage{
 		MsgType: "text",
 		Content: LarkMessageContent{
@@ -63,6 +93,10 @@ func (s *LarkSender) SendMessage(webhookURL, secret, message string) error {
 	// 发送请求
 	resp, err := client.Post(webhookURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
+		// Webhook URL中包含访问令牌，错误信息中去掉URL避免泄露
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
 		return fmt.Errorf("failed to send lark message: %w", err)
 	}
 	defer resp.Body.Close()
@@ -2,18 +2,28 @@ package notification
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
+	"timelocker-backend/pkg/crypto"
+	"timelocker-backend/pkg/metrics"
+	"timelocker-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TelegramSender Telegram消息发送器
-type TelegramSender struct{}
+type TelegramSender struct {
+	keyring *crypto.SecretKeyring
+}
 
 // NewTelegramSender 创建Telegram发送器实例
-func NewTelegramSender() *TelegramSender {
-	return &TelegramSender{}
+func NewTelegramSender(keyring *crypto.SecretKeyring) *TelegramSender {
+	return &TelegramSender{keyring: keyring}
 }
 
 // TelegramMessage Telegram消息结构
@@ -23,9 +33,24 @@ type TelegramMessage struct {
 	ParseMode string `json:"parse_mode,omitempty"`
 }
 
-// SendMessage 发送Telegram消息
-func (s *TelegramSender) SendMessage(botToken, chatID, message string) error {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+// SendMessage 发送Telegram消息并记录发送指标和span
+func (s *TelegramSender) SendMessage(ctx context.Context, botToken, chatID, message string) error {
+	_, span := tracing.StartKind(ctx, "notification.send", trace.SpanKindClient, attribute.String("channel", "telegram"))
+	start := time.Now()
+	err := s.sendMessage(botToken, chatID, message)
+	metrics.ObserveNotification("telegram", time.Since(start), err)
+	tracing.End(span, err)
+	return err
+}
+
+// sendMessage 发送Telegram消息
+func (s *TelegramSender) sendMessage(botToken, chatID, message string) error {
+	// 存储的Bot Token为加密值，仅在发送时解密
+	botToken, err := s.keyring.Decrypt(botToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt telegram bot token: %w", err)
+	}
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
 
 	telegramMsg := TelegramMessage{
 		ChatID:    chatID,
@@ -44,8 +69,12 @@ func (s *TelegramSender) SendMessage(botToken, chatID, message string) error {
 	}
 
 	// 发送请求
-	resp, err := client.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := client.Post(apiURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
+		// 请求URL中包含Bot Token，错误信息中去掉URL避免泄露
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
 		return fmt.Errorf("failed to send telegram message: %w", err)
 	}
 	defer resp.Body.Close()
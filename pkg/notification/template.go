@@ -0,0 +1,301 @@
+package notification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"timelocker-backend/internal/types"
+)
+
+// 用户自定义模板的沙箱限制
+// 模板只能对数据中的字段进行range，且限制嵌套层数、range个数和每个集合展开的元素数，
+// 渲染的总迭代次数在解析阶段即有上限，不依赖超时中止执行
+const (
+	MaxTemplateSize    = 16 * 1024       // 模板源码最大长度
+	MaxRenderedSize    = 64 * 1024       // 渲染结果最大长度
+	TemplateRenderTime = 2 * time.Second // 渲染超时时间，超时后下一次输出即中止
+	MaxRangeItems      = 64              // 每个range最多展开的元素数，超出部分在渲染时截断
+	maxRangeDepth      = 2               // range 最大嵌套层数
+	maxRangeCount      = 8               // 模板中range的最大个数
+	maxFormatWidth     = 256             // printf 格式中宽度和精度的最大值
+)
+
+var (
+	ErrTemplateTooLarge = errors.New("template too large")
+	ErrRenderedTooLarge = errors.New("rendered message too large")
+	ErrRenderTimeout    = errors.New("template rendering timed out")
+)
+
+// limitedWriter 限制输出长度和执行时间的Writer
+type limitedWriter struct {
+	buf      bytes.Buffer
+	limit    int
+	deadline time.Time
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if time.Now().After(w.deadline) {
+		return 0, ErrRenderTimeout
+	}
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, ErrRenderedTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// ParseTextTemplate 解析并校验文本模板（Telegram/Lark/Feishu）
+func ParseTextTemplate(src string) (*template.Template, error) {
+	if len(src) > MaxTemplateSize {
+		return nil, ErrTemplateTooLarge
+	}
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if err := checkTemplateTree(len(tmpl.Templates()), tmpl.Tree); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// ParseHTMLTemplate 解析并校验HTML模板（邮件）
+func ParseHTMLTemplate(src string) (*htmlTemplate.Template, error) {
+	if len(src) > MaxTemplateSize {
+		return nil, ErrTemplateTooLarge
+	}
+	tmpl, err := htmlTemplate.New("email").Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if err := checkTemplateTree(len(tmpl.Templates()), tmpl.Tree); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// RenderTextTemplate 在沙箱限制下渲染文本模板
+func RenderTextTemplate(src string, data *types.NotificationData) (string, error) {
+	tmpl, err := ParseTextTemplate(src)
+	if err != nil {
+		return "", err
+	}
+	data = limitRangeItems(data)
+	return execute(func(w *limitedWriter) error { return tmpl.Execute(w, data) })
+}
+
+// RenderHTMLTemplate 在沙箱限制下渲染HTML模板
+func RenderHTMLTemplate(src string, data *types.NotificationData) (string, error) {
+	tmpl, err := ParseHTMLTemplate(src)
+	if err != nil {
+		return "", err
+	}
+	data = limitRangeItems(data)
+	return execute(func(w *limitedWriter) error { return tmpl.Execute(w, data) })
+}
+
+// limitRangeItems 返回集合字段不超过 MaxRangeItems 的数据副本，不修改调用方的数据
+func limitRangeItems(data *types.NotificationData) *types.NotificationData {
+	if data == nil || len(data.CalldataParams) <= MaxRangeItems {
+		return data
+	}
+	limited := *data
+	limited.CalldataParams = data.CalldataParams[:MaxRangeItems]
+	return &limited
+}
+
+// execute 在当前goroutine中执行模板，输出过大或超过渲染时间时返回错误
+// 模板的迭代次数在解析时已受限，执行一定会结束
+func execute(run func(w *limitedWriter) error) (result string, err error) {
+	w := &limitedWriter{limit: MaxRenderedSize, deadline: time.Now().Add(TemplateRenderTime)}
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = "", fmt.Errorf("template panic: %v", r)
+		}
+	}()
+
+	if err := run(w); err != nil {
+		if errors.Is(err, ErrRenderTimeout) || errors.Is(err, ErrRenderedTooLarge) {
+			return "", err
+		}
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	return w.buf.String(), nil
+}
+
+// checkTemplateTree 限制模板结构：禁止 define/template/block，range 只能遍历字段，
+// 限制 range 嵌套层数和个数，限制 printf 的宽度和精度
+func checkTemplateTree(templateCount int, tree *parse.Tree) error {
+	if templateCount > 1 {
+		return errors.New("invalid template: define/block is not allowed")
+	}
+	if tree == nil || tree.Root == nil {
+		return nil
+	}
+	rangeCount := 0
+	return checkNode(tree.Root, 0, &rangeCount)
+}
+
+func checkNode(node parse.Node, rangeDepth int, rangeCount *int) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkNode(child, rangeDepth, rangeCount); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkPipe(n.Pipe)
+	case *parse.TemplateNode:
+		return errors.New("invalid template: template invocation is not allowed")
+	case *parse.IfNode:
+		if err := checkPipe(n.Pipe); err != nil {
+			return err
+		}
+		return checkBranch(&n.BranchNode, rangeDepth, rangeCount)
+	case *parse.WithNode:
+		if err := checkPipe(n.Pipe); err != nil {
+			return err
+		}
+		return checkBranch(&n.BranchNode, rangeDepth, rangeCount)
+	case *parse.RangeNode:
+		*rangeCount++
+		if *rangeCount > maxRangeCount {
+			return fmt.Errorf("invalid template: more than %d range actions", maxRangeCount)
+		}
+		if rangeDepth+1 > maxRangeDepth {
+			return fmt.Errorf("invalid template: range nesting exceeds %d", maxRangeDepth)
+		}
+		if err := checkRangePipe(n.Pipe); err != nil {
+			return err
+		}
+		return checkBranch(&n.BranchNode, rangeDepth+1, rangeCount)
+	}
+	return nil
+}
+
+func checkBranch(branch *parse.BranchNode, rangeDepth int, rangeCount *int) error {
+	if err := checkNode(branch.List, rangeDepth, rangeCount); err != nil {
+		return err
+	}
+	if branch.ElseList != nil {
+		return checkNode(branch.ElseList, rangeDepth, rangeCount)
+	}
+	return nil
+}
+
+// checkRangePipe range 只允许遍历单个字段，例如 .CalldataParams 或 $.CalldataParams
+// 禁止函数调用、括号表达式和裸变量，避免通过 len/printf 等构造任意次数的循环
+func checkRangePipe(pipe *parse.PipeNode) error {
+	errRange := errors.New("invalid template: range must iterate over a field such as .CalldataParams")
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return errRange
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return nil
+	case *parse.VariableNode:
+		// $.Field 或 $v.Field，裸变量可能是任意值（例如数字）
+		if len(arg.Ident) > 1 {
+			return nil
+		}
+	}
+	return errRange
+}
+
+// checkPipe 检查管道中的函数调用，递归检查括号内的子管道
+func checkPipe(pipe *parse.PipeNode) error {
+	if pipe == nil {
+		return nil
+	}
+	for _, cmd := range pipe.Cmds {
+		if len(cmd.Args) == 0 {
+			continue
+		}
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "printf" {
+			if err := checkPrintfFormat(cmd.Args[1:]); err != nil {
+				return err
+			}
+		}
+		for _, arg := range cmd.Args {
+			if sub, ok := arg.(*parse.PipeNode); ok {
+				if err := checkPipe(sub); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkPrintfFormat printf 的格式必须是字符串常量，宽度和精度不超过 maxFormatWidth
+func checkPrintfFormat(args []parse.Node) error {
+	if len(args) == 0 {
+		return nil
+	}
+	format, ok := args[0].(*parse.StringNode)
+	if !ok {
+		return errors.New("invalid template: printf format must be a string literal")
+	}
+	f := format.Text
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' {
+			continue
+		}
+		for i++; i < len(f); i++ {
+			c := f[i]
+			if c == '*' {
+				return errors.New("invalid template: printf width from arguments is not allowed")
+			}
+			if c >= '0' && c <= '9' {
+				n := 0
+				for ; i < len(f) && f[i] >= '0' && f[i] <= '9'; i++ {
+					n = n*10 + int(f[i]-'0')
+					if n > maxFormatWidth {
+						return fmt.Errorf("invalid template: printf width exceeds %d", maxFormatWidth)
+					}
+				}
+				i--
+				continue
+			}
+			if c == '+' || c == '-' || c == '#' || c == ' ' || c == '.' || c == '[' || c == ']' {
+				continue
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// SampleNotificationData 模板预览使用的示例流程数据
+func SampleNotificationData() *types.NotificationData {
+	return &types.NotificationData{
+		BgColorFrom:   "rgba(251, 146, 60, 0.15)",
+		TextColorFrom: "#fb923c",
+		StatusFrom:    "WAITING",
+		BgColorTo:     "rgba(34, 197, 94, 0.15)",
+		TextColorTo:   "#22c55e",
+		StatusTo:      "READY",
+		Standard:      "COMPOUND",
+		Network:       "Ethereum Mainnet",
+		Contract:      "0x1a9C8182C09F50C8318d769245beA52c32BE35BC",
+		Remark:        "Treasury Timelock",
+		Caller:        "0x2b1Ad6184a6B0fac06bD225ed37C2AbC04415fF4",
+		Target:        "0xc00e94Cb662C3520282E6f5717214004A7f26888",
+		Value:         "0.000000 ETH",
+		Function:      "transfer(address,uint256)",
+		CalldataParams: []types.CalldataParam{
+			{Name: "param[0]", Type: "address", Value: "0x6d903f6003cca6255D85CcA4D3B5E5146dC33925"},
+			{Name: "param[1]", Type: "uint256", Value: "1000000000000000000"},
+		},
+		TxHash:       "0x5f7c3a9b...e41d2c",
+		TxUrl:        "https://etherscan.io/tx/0x5f7c3a9b0d4e2f1a8c6b3d9e7f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5de41d2c",
+		DashboardUrl: "https://app.timelock.live",
+	}
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"timelocker-backend/internal/types"
+)
+
+func TestParseTextTemplateRejectsUnboundedRange(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"range over number", `{{range 1000000}}x{{end}}`},
+		{"range over len of printf", `{{range (len (printf "%1000000s" "x"))}}{{range (len (printf "%1000000s" "x"))}}{{end}}{{end}}`},
+		{"range over function call", `{{range len .CalldataParams}}x{{end}}`},
+		{"range over bare variable", `{{$n := len (printf "%200s" "x")}}{{range $n}}x{{end}}`},
+		{"range over pipeline", `{{range .CalldataParams | len}}x{{end}}`},
+		{"range nesting too deep", `{{range .CalldataParams}}{{range $.CalldataParams}}{{range $.CalldataParams}}{{end}}{{end}}{{end}}`},
+		{"too many ranges", strings.Repeat(`{{range .CalldataParams}}{{end}}`, maxRangeCount+1)},
+		{"printf width too large", `{{printf "%1000000s" "x"}}`},
+		{"printf precision too large", `{{printf "%.1000000f" 1.0}}`},
+		{"printf width from argument", `{{printf "%*s" 100000 "x"}}`},
+		{"printf dynamic format", `{{printf .Remark "x"}}`},
+		{"printf inside parentheses", `{{len (printf "%999999s" "x")}}`},
+		{"define", `{{define "x"}}{{end}}`},
+		{"template invocation", `{{template "message"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTextTemplate(tt.src); err == nil {
+				t.Fatalf("expected template to be rejected: %s", tt.src)
+			}
+			if _, err := ParseHTMLTemplate(tt.src); err == nil {
+				t.Fatalf("expected html template to be rejected: %s", tt.src)
+			}
+		})
+	}
+}
+
+func TestRenderTextTemplateAllowsFieldRange(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"field range", `{{range .CalldataParams}}{{.Name}};{{end}}`, "param[0];param[1];"},
+		{"declared range", `{{range $i, $p := .CalldataParams}}{{$i}}={{$p.Type}};{{end}}`, "0=address;1=uint256;"},
+		{"nested root range", `{{range .CalldataParams}}{{range $.CalldataParams}}x{{end}}{{end}}`, "xxxx"},
+		{"small printf width", `{{printf "%-8s|%5.2f" .StatusTo 1.5}}`, "READY   | 1.50"},
+		{"escaped percent", `{{printf "100%%"}}`, "100%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTextTemplate(tt.src, SampleNotificationData())
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTextTemplateLimitsRangeItems(t *testing.T) {
+	data := SampleNotificationData()
+	data.CalldataParams = nil
+	for i := 0; i < MaxRangeItems*4; i++ {
+		data.CalldataParams = append(data.CalldataParams, types.CalldataParam{Name: fmt.Sprintf("p%d", i)})
+	}
+
+	got, err := RenderTextTemplate(`{{range .CalldataParams}}x{{end}}`, data)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if len(got) != MaxRangeItems {
+		t.Fatalf("rendered %d items, want %d", len(got), MaxRangeItems)
+	}
+	if len(data.CalldataParams) != MaxRangeItems*4 {
+		t.Fatalf("caller data was modified")
+	}
+}
+
+func TestRenderTextTemplateBoundedWorstCase(t *testing.T) {
+	data := SampleNotificationData()
+	data.CalldataParams = make([]types.CalldataParam, MaxRangeItems)
+	src := strings.Repeat(`{{range .CalldataParams}}{{range $.CalldataParams}}{{end}}{{end}}`, maxRangeCount/2)
+
+	start := time.Now()
+	if _, err := RenderTextTemplate(src, data); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > TemplateRenderTime {
+		t.Fatalf("worst case render took %s", elapsed)
+	}
+}
+
+func TestRenderTextTemplateOutputLimit(t *testing.T) {
+	src := strings.Repeat(`{{printf "%256s" "x"}}`, MaxRenderedSize/256+1)
+	if _, err := RenderTextTemplate(src, SampleNotificationData()); !errors.Is(err, ErrRenderedTooLarge) {
+		t.Fatalf("expected ErrRenderedTooLarge, got %v", err)
+	}
+}
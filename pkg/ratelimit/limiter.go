@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Rule 令牌桶规则
+type Rule struct {
+	Rate  float64 // 每秒补充的令牌数
+	Burst int     // 桶容量，即允许的最大突发请求数
+}
+
+// Result 限流判定结果
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration // 被限流时距离下一个令牌可用的等待时间
+}
+
+// Limiter 令牌桶限流器，key为限流维度（如路由+IP），每次调用消耗一个令牌
+type Limiter interface {
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// retryAfter 计算补足一个令牌所需的时间
+func retryAfter(tokens float64, rule Rule) time.Duration {
+	if rule.Rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+}
+
+// memorySweepInterval 内存限流器清理空闲令牌桶的间隔
+const memorySweepInterval = 5 * time.Minute
+
+// bucket 单个令牌桶
+type bucket struct {
+	tokens   float64
+	updated  time.Time
+	capacity float64
+	rate     float64
+}
+
+// memoryLimiter 进程内限流器，仅适用于单实例部署
+type memoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time // 时钟，测试时可替换
+}
+
+// NewMemoryLimiter 创建进程内限流器
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	now := l.now()
+	capacity := float64(rule.Burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= memorySweepInterval {
+		l.sweep(now)
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+	b.capacity = capacity
+	b.rate = rule.Rate
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rule.Rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return Result{Allowed: true}, nil
+	}
+	return Result{Allowed: false, RetryAfter: retryAfter(b.tokens, rule)}, nil
+}
+
+// sweep 删除已补满的令牌桶，补满后的桶与新建的桶等价
+func (l *memoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.rate >= b.capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeClock 测试时钟，只在advance时前进
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// limiterStep 推进时钟后对key调用一次Allow的期望结果
+type limiterStep struct {
+	advance    time.Duration
+	key        string
+	allowed    bool
+	retryAfter time.Duration // 仅在被限流时比较
+}
+
+// allowed/denied 构造测试步骤，key为空时使用默认键
+func allowed(advance time.Duration) limiterStep {
+	return limiterStep{advance: advance, allowed: true}
+}
+
+func denied(advance, retryAfter time.Duration) limiterStep {
+	return limiterStep{advance: advance, retryAfter: retryAfter}
+}
+
+func onKey(key string, step limiterStep) limiterStep {
+	step.key = key
+	return step
+}
+
+func TestLimiterTokenBucket(t *testing.T) {
+	rule := Rule{Rate: 2, Burst: 3}
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		steps []limiterStep
+	}{
+		{
+			name: "burst then limited",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				denied(0, 500*time.Millisecond),
+				denied(0, 500*time.Millisecond),
+			},
+		},
+		{
+			name: "refill one token",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				denied(499*time.Millisecond, time.Millisecond),
+				allowed(time.Millisecond),
+				denied(0, 500*time.Millisecond),
+			},
+		},
+		{
+			name: "partial refill",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				denied(250*time.Millisecond, 250*time.Millisecond),
+				denied(100*time.Millisecond, 150*time.Millisecond),
+				allowed(150 * time.Millisecond),
+			},
+		},
+		{
+			name: "refill capped at burst",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				allowed(10 * time.Second), allowed(0), allowed(0),
+				denied(0, 500*time.Millisecond),
+			},
+		},
+		{
+			name: "steady rate",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				allowed(500 * time.Millisecond),
+				allowed(500 * time.Millisecond),
+				allowed(500 * time.Millisecond),
+				denied(0, 500*time.Millisecond),
+			},
+		},
+		{
+			name: "idle bucket expires as full",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				allowed(time.Hour), allowed(0), allowed(0),
+				denied(0, 500*time.Millisecond),
+			},
+		},
+		{
+			name: "keys are independent",
+			rule: rule,
+			steps: []limiterStep{
+				allowed(0), allowed(0), allowed(0),
+				denied(0, 500*time.Millisecond),
+				onKey("other", allowed(0)),
+				onKey("other", allowed(0)),
+				onKey("other", allowed(0)),
+				onKey("other", denied(0, 500*time.Millisecond)),
+			},
+		},
+		{
+			name: "single token burst",
+			rule: Rule{Rate: 0.5, Burst: 1},
+			steps: []limiterStep{
+				allowed(0),
+				denied(time.Second, time.Second),
+				allowed(time.Second),
+				denied(0, 2*time.Second),
+			},
+		},
+	}
+
+	backends := []struct {
+		name string
+		new  func(t *testing.T, clock *fakeClock) (Limiter, func(time.Duration))
+	}{
+		{"memory", func(t *testing.T, clock *fakeClock) (Limiter, func(time.Duration)) {
+			l := NewMemoryLimiter().(*memoryLimiter)
+			l.now = clock.Now
+			l.lastSweep = clock.Now()
+			return l, func(time.Duration) {}
+		}},
+		{"redis", func(t *testing.T, clock *fakeClock) (Limiter, func(time.Duration)) {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { client.Close() })
+			l := NewRedisLimiter(client).(*redisLimiter)
+			l.now = clock.Now
+			// 键过期时间按Redis服务端时钟计算，与测试时钟同步推进
+			return l, server.FastForward
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, backend := range backends {
+			t.Run(tt.name+"/"+backend.name, func(t *testing.T) {
+				clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+				limiter, advance := backend.new(t, clock)
+
+				for i, step := range tt.steps {
+					clock.now = clock.now.Add(step.advance)
+					advance(step.advance)
+
+					key := step.key
+					if key == "" {
+						key = "default"
+					}
+					result, err := limiter.Allow(context.Background(), key, tt.rule)
+					if err != nil {
+						t.Fatalf("step %d: allow: %v", i, err)
+					}
+					if result.Allowed != step.allowed {
+						t.Fatalf("step %d: allowed=%v, want %v", i, result.Allowed, step.allowed)
+					}
+					if !step.allowed {
+						// Redis脚本以毫秒计时并以字符串返回令牌数，允许1ms误差
+						if diff := result.RetryAfter - step.retryAfter; diff < -time.Millisecond || diff > time.Millisecond {
+							t.Fatalf("step %d: retry after %s, want %s", i, result.RetryAfter, step.retryAfter)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestMemoryLimiterSweepsFullBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewMemoryLimiter().(*memoryLimiter)
+	l.now = clock.Now
+	l.lastSweep = clock.Now()
+	rule := Rule{Rate: 1, Burst: 2}
+
+	for _, key := range []string{"a", "b"} {
+		if _, err := l.Allow(context.Background(), key, rule); err != nil {
+			t.Fatalf("allow: %v", err)
+		}
+	}
+	clock.now = clock.now.Add(memorySweepInterval)
+	if _, err := l.Allow(context.Background(), "c", rule); err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+	if len(l.buckets) != 1 {
+		t.Fatalf("got %d buckets after sweep, want 1", len(l.buckets))
+	}
+}
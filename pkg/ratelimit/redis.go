@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix Redis限流键前缀
+const redisKeyPrefix = "timelocker:ratelimit:"
+
+// tokenBucketScript 原子地补充并消耗令牌，返回 {是否允许, 剩余令牌}
+// KEYS[1] 令牌桶键；ARGV: 每毫秒补充令牌数, 桶容量, 当前毫秒时间戳, 键过期毫秒数
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// redisLimiter 基于Redis的限流器，多实例部署时共享令牌桶
+type redisLimiter struct {
+	client *redis.Client
+	now    func() time.Time // 时钟，测试时可替换
+}
+
+// NewRedisLimiter 创建Redis存储的限流器
+func NewRedisLimiter(client *redis.Client) Limiter {
+	return &redisLimiter{client: client, now: time.Now}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	if rule.Rate <= 0 {
+		return Result{}, fmt.Errorf("invalid rate limit rule: rate must be positive")
+	}
+
+	// 令牌桶从空补满所需时间之后，键与新建的桶等价，可以过期
+	ttl := int64(math.Ceil(float64(rule.Burst)/rule.Rate*1000)) + 1000
+	now := l.now().UnixMilli()
+
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{redisKeyPrefix + key},
+		rule.Rate/1000, rule.Burst, now, ttl).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	if allowed == 1 {
+		return Result{Allowed: true}, nil
+	}
+
+	tokensStr, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid rate limit script tokens %q: %w", tokensStr, err)
+	}
+	return Result{Allowed: false, RetryAfter: retryAfter(tokens, rule)}, nil
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey span在Statement实例变量中的键
+const gormSpanKey = "tracing:span"
+
+// gormPlugin 为每条GORM语句创建span，父span取自 db.WithContext 传入的上下文
+type gormPlugin struct{}
+
+// NewGormPlugin 创建GORM追踪插件，通过 db.Use 注册
+func NewGormPlugin() gorm.Plugin {
+	return &gormPlugin{}
+}
+
+func (p *gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("tracing:before_create", beforeStatement("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", afterStatement),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", beforeStatement("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", afterStatement),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", beforeStatement("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", afterStatement),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", beforeStatement("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", afterStatement),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", beforeStatement("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", afterStatement),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", beforeStatement("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", afterStatement),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func beforeStatement(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := StartKind(db.Statement.Context, "db."+op, trace.SpanKindClient,
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", op),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func afterStatement(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	// 查询不到记录是正常的业务结果，不标记为失败
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		RecordError(span, db.Error)
+	}
+	span.End()
+}
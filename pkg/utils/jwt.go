@@ -1,67 +1,185 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
+	"timelocker-backend/internal/config"
 	"timelocker-backend/internal/types"
 	"timelocker-backend/pkg/logger"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTManager 令牌签发和验证
+// 配置了签名密钥时使用非对称算法签名并在令牌头写入kid，否则使用HS256密钥
 type JWTManager struct {
 	secret        []byte
+	acceptSecret  bool               // 是否接受HS256签名的令牌
+	signingKey    *jwtKey            // 当前签名密钥，为空时使用HS256密钥
+	keys          map[string]*jwtKey // 所有验证密钥，按kid索引
+	issuer        string
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 }
 
-func NewJWTManager(secret string, accessExpiry, refreshExpiry time.Duration) *JWTManager {
-	return &JWTManager{
-		secret:        []byte(secret),
-		accessExpiry:  accessExpiry,
-		refreshExpiry: refreshExpiry,
+// NewJWTManager 根据配置创建令牌管理器，加载签名和验证密钥
+func NewJWTManager(cfg *config.JWTConfig) (*JWTManager, error) {
+	keys, err := loadJWTKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := &JWTManager{
+		secret:        []byte(cfg.Secret),
+		acceptSecret:  cfg.ActiveKeyID == "" || cfg.AcceptLegacySecret,
+		keys:          keys,
+		issuer:        cfg.Issuer,
+		accessExpiry:  cfg.AccessExpiry,
+		refreshExpiry: cfg.RefreshExpiry,
+	}
+	if cfg.ActiveKeyID != "" {
+		key, ok := keys[cfg.ActiveKeyID]
+		if !ok || key.privateKey == nil {
+			return nil, fmt.Errorf("jwt active key %q has no private key", cfg.ActiveKeyID)
+		}
+		manager.signingKey = key
+	}
+	if manager.acceptSecret && len(manager.secret) == 0 {
+		return nil, errors.New("jwt secret is required when hs256 tokens are accepted")
+	}
+	return manager, nil
+}
+
+// SigningAlgorithm 当前签名算法
+func (j *JWTManager) SigningAlgorithm() string {
+	if j.signingKey != nil {
+		return j.signingKey.method.Alg()
+	}
+	return jwt.SigningMethodHS256.Alg()
+}
+
+// JWKS 公开所有非对称验证密钥，HS256密钥不会公开
+func (j *JWTManager) JWKS() *types.JWKSet {
+	set := &types.JWKSet{Keys: make([]types.JWK, 0, len(j.keys))}
+	ids := make([]string, 0, len(j.keys))
+	for id := range j.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		set.Keys = append(set.Keys, j.keys[id].jwk())
+	}
+	return set
+}
+
+// sign 使用当前签名密钥签名
+func (j *JWTManager) sign(claims jwt.MapClaims) (string, error) {
+	if j.issuer != "" {
+		claims["iss"] = j.issuer
+	}
+	if j.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secret)
+	}
+	token := jwt.NewWithClaims(j.signingKey.method, claims)
+	token.Header["kid"] = j.signingKey.id
+	return token.SignedString(j.signingKey.privateKey)
+}
+
+// verificationKey 根据令牌算法和kid选择验证密钥
+func (j *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if !j.acceptSecret {
+			return nil, errors.New("hs256 tokens are no longer accepted")
+		}
+		return j.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key id %q", kid)
+	}
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("signing method %s does not match key %s", token.Method.Alg(), kid)
 	}
+	return key.publicKey, nil
 }
 
-// GenerateTokens 生成访问令牌和刷新令牌
-func (j *JWTManager) GenerateTokens(userID int64, walletAddress string) (string, string, time.Time, error) {
+// TokenPair 生成的访问令牌和刷新令牌
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessJTI        string    // 访问令牌ID
+	RefreshJTI       string    // 刷新令牌ID
+	SessionID        string    // 令牌族ID
+	ExpiresAt        time.Time // 访问令牌过期时间
+	RefreshExpiresAt time.Time // 刷新令牌过期时间
+}
+
+// GenerateTokens 生成访问令牌和刷新令牌，令牌中记录登录时签名消息的链ID、域名和令牌族ID
+func (j *JWTManager) GenerateTokens(userID int64, walletAddress string, binding types.SessionBinding) (*TokenPair, error) {
+	now := time.Now()
+	pair := &TokenPair{
+		AccessJTI:        newTokenID(),
+		RefreshJTI:       newTokenID(),
+		SessionID:        binding.SessionID,
+		ExpiresAt:        now.Add(j.accessExpiry),
+		RefreshExpiresAt: now.Add(j.refreshExpiry),
+	}
+	if pair.SessionID == "" {
+		pair.SessionID = newTokenID()
+	}
+
 	// 生成访问令牌
 	accessClaims := jwt.MapClaims{
 		"user_id":        userID,
 		"wallet_address": walletAddress,
 		"type":           "access",
-		"exp":            time.Now().Add(j.accessExpiry).Unix(),
-		"iat":            time.Now().Unix(),
+		"jti":            pair.AccessJTI,
+		"sid":            pair.SessionID,
+		"exp":            pair.ExpiresAt.Unix(),
+		"iat":            now.Unix(),
 	}
+	applySessionBinding(accessClaims, binding)
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString(j.secret)
+	accessTokenString, err := j.sign(accessClaims)
 	if err != nil {
 		logger.Error("GenerateTokens Error: ", errors.New("failed to generate access token"), "error: ", err)
-		return "", "", time.Time{}, err
+		return nil, err
 	}
+	pair.AccessToken = accessTokenString
 
 	// 生成刷新令牌
 	refreshClaims := jwt.MapClaims{
 		"user_id":        userID,
 		"wallet_address": walletAddress,
 		"type":           "refresh",
-		"exp":            time.Now().Add(j.refreshExpiry).Unix(),
-		"iat":            time.Now().Unix(),
+		"jti":            pair.RefreshJTI,
+		"sid":            pair.SessionID,
+		"exp":            pair.RefreshExpiresAt.Unix(),
+		"iat":            now.Unix(),
 	}
+	applySessionBinding(refreshClaims, binding)
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString(j.secret)
+	refreshTokenString, err := j.sign(refreshClaims)
 	if err != nil {
 		logger.Error("GenerateTokens Error: ", errors.New("failed to generate refresh token"), "error: ", err)
-		return "", "", time.Time{}, err
+		return nil, err
 	}
+	pair.RefreshToken = refreshTokenString
 
-	expiresAt := time.Now().Add(j.accessExpiry)
+	logger.Info("GenerateTokens Success: ", "generate jwt token success", "user_id: ", userID, "wallet_address: ", walletAddress, "sid", pair.SessionID)
+	return pair, nil
+}
 
-	logger.Info("GenerateTokens Success: ", "generate jwt token success", "user_id: ", userID, "wallet_address: ", walletAddress)
-	return accessTokenString, refreshTokenString, expiresAt, nil
+// AccessExpiry 访问令牌有效期
+func (j *JWTManager) AccessExpiry() time.Duration {
+	return j.accessExpiry
 }
 
 // VerifyAccessToken 验证访问令牌
@@ -78,13 +196,8 @@ func (j *JWTManager) VerifyRefreshToken(tokenString string) (*types.JWTClaims, e
 
 // verifyToken 验证令牌
 func (j *JWTManager) verifyToken(tokenString, expectedType string) (*types.JWTClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			logger.Error("verifyToken Error: ", errors.New("unexpected signing method"))
-			return nil, errors.New("unexpected signing method")
-		}
-		return j.secret, nil
-	})
+	token, err := jwt.Parse(tokenString, j.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
 		logger.Error("verifyToken Error: ", errors.New("failed to parse token"), "error: ", err)
@@ -102,6 +215,14 @@ func (j *JWTManager) verifyToken(tokenString, expectedType string) (*types.JWTCl
 		return nil, errors.New("invalid token claims")
 	}
 
+	// 非对称密钥签发的令牌必须带有本服务的签发者
+	rawIssuer, hasIssuer := claims["iss"]
+	issuer, _ := rawIssuer.(string)
+	if (hasIssuer || token.Method.Alg() != jwt.SigningMethodHS256.Alg()) && issuer != j.issuer {
+		logger.Error("verifyToken Error: ", errors.New("invalid token issuer"), "iss", rawIssuer)
+		return nil, errors.New("invalid token issuer")
+	}
+
 	tokenType, ok := claims["type"].(string)
 	if !ok || tokenType != expectedType {
 		logger.Error("verifyToken Error: ", errors.New("invalid token type"))
@@ -120,10 +241,65 @@ func (j *JWTManager) verifyToken(tokenString, expectedType string) (*types.JWTCl
 		return nil, errors.New("invalid wallet_address in token")
 	}
 
-	logger.Info("verifyToken Success: ", "token verified successfully", "user_id", userID, "wallet_address", walletAddress, "token_type", tokenType)
+	// 登录绑定信息（旧令牌可能不存在）
+	var chainID int
+	if value, exists := claims["chain_id"]; exists {
+		chainIDFloat, ok := value.(float64)
+		if !ok {
+			logger.Error("verifyToken Error: ", errors.New("invalid chain_id in token"))
+			return nil, errors.New("invalid chain_id in token")
+		}
+		chainID = int(chainIDFloat)
+	}
+	domain, _ := claims["domain"].(string)
+	safe, _ := claims["safe"].(bool)
+	signer, _ := claims["signer"].(string)
+
+	// 令牌ID和令牌族ID（旧令牌可能不存在）
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+	var expiresAt time.Time
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
+	logger.Info("verifyToken Success: ", "token verified successfully", "user_id", userID, "wallet_address", walletAddress, "chain_id", chainID, "domain", domain, "token_type", tokenType)
 	return &types.JWTClaims{
 		UserID:        int64(userID),
 		WalletAddress: walletAddress,
+		ChainID:       chainID,
+		Domain:        domain,
+		Safe:          safe,
+		Signer:        signer,
 		Type:          tokenType,
+		ID:            jti,
+		SessionID:     sid,
+		ExpiresAt:     expiresAt,
 	}, nil
 }
+
+// applySessionBinding 将登录绑定信息写入JWT声明
+func applySessionBinding(claims jwt.MapClaims, binding types.SessionBinding) {
+	if binding.ChainID != 0 {
+		claims["chain_id"] = binding.ChainID
+	}
+	if binding.Domain != "" {
+		claims["domain"] = binding.Domain
+	}
+	if binding.Safe {
+		claims["safe"] = true
+	}
+	if binding.Signer != "" {
+		claims["signer"] = binding.Signer
+	}
+}
+
+// newTokenID 生成随机令牌ID
+func newTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// 随机源不可用时退化为时间戳
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/types"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKey 非对称签名密钥，仅配置公钥时只能用于验证
+type jwtKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+// loadJWTKeys 加载配置的签名和验证密钥
+func loadJWTKeys(keyConfigs []config.JWTKeyConfig) (map[string]*jwtKey, error) {
+	keys := make(map[string]*jwtKey, len(keyConfigs))
+	for _, keyConfig := range keyConfigs {
+		if keyConfig.ID == "" {
+			return nil, errors.New("jwt key id is required")
+		}
+		if _, exists := keys[keyConfig.ID]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id: %s", keyConfig.ID)
+		}
+
+		key, err := loadJWTKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt key %s: %w", keyConfig.ID, err)
+		}
+		keys[keyConfig.ID] = key
+	}
+	return keys, nil
+}
+
+// loadJWTKey 从PEM文件加载单个密钥，配置私钥时从私钥推导公钥
+func loadJWTKey(keyConfig config.JWTKeyConfig) (*jwtKey, error) {
+	key := &jwtKey{id: keyConfig.ID}
+
+	switch {
+	case keyConfig.PrivateKeyFile != "":
+		block, err := readPEMBlock(keyConfig.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		key.privateKey = privateKey
+		key.publicKey = privateKey.(crypto.Signer).Public()
+	case keyConfig.PublicKeyFile != "":
+		block, err := readPEMBlock(keyConfig.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		key.publicKey = publicKey
+	default:
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+
+	method, err := signingMethodFor(key.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	key.method = method
+	return key, nil
+}
+
+// readPEMBlock 读取PEM文件中的第一个块
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	return block, nil
+}
+
+// parsePrivateKey 解析PKCS#8私钥，兼容openssl ecparam生成的SEC1格式EC私钥
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if block.Type == "EC PRIVATE KEY" {
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EC private key: %w", err)
+		}
+		return privateKey, nil
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return privateKey, nil
+}
+
+// signingMethodFor 根据公钥类型确定签名算法，仅支持ECDSA P-256 (ES256) 和 Ed25519 (EdDSA)
+func signingMethodFor(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s, only P-256 is supported", key.Curve.Params().Name)
+		}
+		return jwt.SigningMethodES256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, only ECDSA P-256 and Ed25519 are supported", publicKey)
+	}
+}
+
+// jwk 将公钥转换为JWK
+func (k *jwtKey) jwk() types.JWK {
+	jwk := types.JWK{
+		Kid: k.id,
+		Alg: k.method.Alg(),
+		Use: "sig",
+	}
+
+	switch key := k.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		jwk.Kty = "EC"
+		jwk.Crv = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	}
+	return jwk
+}
@@ -0,0 +1,382 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"timelocker-backend/internal/config"
+	"timelocker-backend/internal/types"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testKeyFiles 测试密钥的PEM文件路径
+type testKeyFiles struct {
+	privateKey string
+	publicKey  string
+}
+
+// writeTestKey 生成密钥并写入PEM文件，sec1为true时EC私钥使用openssl ecparam的SEC1格式
+func writeTestKey(t *testing.T, name string, key crypto.Signer, sec1 bool) testKeyFiles {
+	t.Helper()
+	dir := t.TempDir()
+
+	var block *pem.Block
+	if ecKey, ok := key.(*ecdsa.PrivateKey); ok && sec1 {
+		der, err := x509.MarshalECPrivateKey(ecKey)
+		if err != nil {
+			t.Fatalf("marshal ec key: %v", err)
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	} else {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("marshal pkcs8 key: %v", err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	files := testKeyFiles{
+		privateKey: filepath.Join(dir, name+".pem"),
+		publicKey:  filepath.Join(dir, name+".pub.pem"),
+	}
+	if err := os.WriteFile(files.privateKey, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("write private key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	if err := os.WriteFile(files.publicKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+	return files
+}
+
+func newECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+	return key
+}
+
+func newTestManager(t *testing.T, cfg config.JWTConfig) *JWTManager {
+	t.Helper()
+	if cfg.AccessExpiry == 0 {
+		cfg.AccessExpiry = 15 * time.Minute
+		cfg.RefreshExpiry = 24 * time.Hour
+	}
+	if cfg.Issuer == "" {
+		cfg.Issuer = "timelocker-test"
+	}
+	manager, err := NewJWTManager(&cfg)
+	if err != nil {
+		t.Fatalf("new jwt manager: %v", err)
+	}
+	return manager
+}
+
+// jwkPublicKey 从JWK还原公钥，模拟其他服务通过JWKS验证令牌
+func jwkPublicKey(t *testing.T, jwk types.JWK) crypto.PublicKey {
+	t.Helper()
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		t.Fatalf("decode x: %v", err)
+	}
+	switch jwk.Kty {
+	case "EC":
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			t.Fatalf("decode y: %v", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	case "OKP":
+		return ed25519.PublicKey(x)
+	default:
+		t.Fatalf("unexpected kty %s", jwk.Kty)
+		return nil
+	}
+}
+
+func TestJWTManagerSignAndVerify(t *testing.T) {
+	ecKey := writeTestKey(t, "es256", newECKey(t, elliptic.P256()), false)
+	sec1Key := writeTestKey(t, "es256-sec1", newECKey(t, elliptic.P256()), true)
+	edKey := writeTestKey(t, "eddsa", newEd25519Key(t), false)
+
+	tests := []struct {
+		name    string
+		cfg     config.JWTConfig
+		wantAlg string
+		wantKid string
+		wantKty string
+		wantCrv string
+	}{
+		{
+			name:    "hs256 secret",
+			cfg:     config.JWTConfig{Secret: "test-secret"},
+			wantAlg: "HS256",
+		},
+		{
+			name: "es256 pkcs8",
+			cfg: config.JWTConfig{
+				ActiveKeyID: "es-1",
+				Keys:        []config.JWTKeyConfig{{ID: "es-1", PrivateKeyFile: ecKey.privateKey}},
+			},
+			wantAlg: "ES256", wantKid: "es-1", wantKty: "EC", wantCrv: "P-256",
+		},
+		{
+			name: "es256 sec1",
+			cfg: config.JWTConfig{
+				ActiveKeyID: "es-sec1",
+				Keys:        []config.JWTKeyConfig{{ID: "es-sec1", PrivateKeyFile: sec1Key.privateKey}},
+			},
+			wantAlg: "ES256", wantKid: "es-sec1", wantKty: "EC", wantCrv: "P-256",
+		},
+		{
+			name: "eddsa",
+			cfg: config.JWTConfig{
+				ActiveKeyID: "ed-1",
+				Keys:        []config.JWTKeyConfig{{ID: "ed-1", PrivateKeyFile: edKey.privateKey}},
+			},
+			wantAlg: "EdDSA", wantKid: "ed-1", wantKty: "OKP", wantCrv: "Ed25519",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager(t, tt.cfg)
+			if got := manager.SigningAlgorithm(); got != tt.wantAlg {
+				t.Fatalf("signing algorithm %s, want %s", got, tt.wantAlg)
+			}
+
+			pair, err := manager.GenerateTokens(7, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", types.SessionBinding{ChainID: 1, Domain: "app.timelocker.io"})
+			if err != nil {
+				t.Fatalf("generate tokens: %v", err)
+			}
+
+			// 令牌头
+			token, _, err := jwt.NewParser().ParseUnverified(pair.AccessToken, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("parse unverified: %v", err)
+			}
+			if token.Method.Alg() != tt.wantAlg {
+				t.Fatalf("token alg %s, want %s", token.Method.Alg(), tt.wantAlg)
+			}
+			if kid, _ := token.Header["kid"].(string); kid != tt.wantKid {
+				t.Fatalf("token kid %q, want %q", kid, tt.wantKid)
+			}
+
+			// 本服务验证
+			claims, err := manager.VerifyAccessToken(pair.AccessToken)
+			if err != nil {
+				t.Fatalf("verify access token: %v", err)
+			}
+			if claims.UserID != 7 || claims.ChainID != 1 || claims.SessionID != pair.SessionID {
+				t.Fatalf("unexpected claims: %+v", claims)
+			}
+			if _, err := manager.VerifyRefreshToken(pair.RefreshToken); err != nil {
+				t.Fatalf("verify refresh token: %v", err)
+			}
+			if _, err := manager.VerifyRefreshToken(pair.AccessToken); err == nil {
+				t.Fatalf("access token accepted as refresh token")
+			}
+
+			// JWKS：HS256密钥不公开，非对称公钥可供其他服务验证令牌
+			jwks := manager.JWKS()
+			if tt.wantKid == "" {
+				if len(jwks.Keys) != 0 {
+					t.Fatalf("jwks exposes %d keys for hs256", len(jwks.Keys))
+				}
+				return
+			}
+			if len(jwks.Keys) != 1 {
+				t.Fatalf("jwks has %d keys, want 1", len(jwks.Keys))
+			}
+			jwk := jwks.Keys[0]
+			if jwk.Kid != tt.wantKid || jwk.Alg != tt.wantAlg || jwk.Kty != tt.wantKty || jwk.Crv != tt.wantCrv || jwk.Use != "sig" {
+				t.Fatalf("unexpected jwk: %+v", jwk)
+			}
+			verified, err := jwt.Parse(pair.AccessToken, func(*jwt.Token) (interface{}, error) {
+				return jwkPublicKey(t, jwk), nil
+			}, jwt.WithValidMethods([]string{tt.wantAlg}), jwt.WithIssuer("timelocker-test"))
+			if err != nil || !verified.Valid {
+				t.Fatalf("verify with jwks key: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTManagerKeyRotation(t *testing.T) {
+	oldKey := writeTestKey(t, "old", newECKey(t, elliptic.P256()), false)
+	newKey := writeTestKey(t, "new", newEd25519Key(t), false)
+	otherKey := writeTestKey(t, "other", newECKey(t, elliptic.P256()), false)
+
+	hsManager := newTestManager(t, config.JWTConfig{Secret: "test-secret"})
+	oldManager := newTestManager(t, config.JWTConfig{
+		ActiveKeyID: "old",
+		Keys:        []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: oldKey.privateKey}},
+	})
+	forgedManager := newTestManager(t, config.JWTConfig{
+		ActiveKeyID: "old",
+		Keys:        []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: otherKey.privateKey}},
+	})
+	foreignIssuer := newTestManager(t, config.JWTConfig{
+		ActiveKeyID: "old",
+		Issuer:      "another-service",
+		Keys:        []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: oldKey.privateKey}},
+	})
+	// 轮换后：新密钥签名，旧密钥仅保留公钥用于验证
+	rotatedCfg := config.JWTConfig{
+		ActiveKeyID: "new",
+		Keys: []config.JWTKeyConfig{
+			{ID: "new", PrivateKeyFile: newKey.privateKey},
+			{ID: "old", PublicKeyFile: oldKey.publicKey},
+		},
+	}
+	legacyCfg := rotatedCfg
+	legacyCfg.Secret = "test-secret"
+	legacyCfg.AcceptLegacySecret = true
+	retiredCfg := config.JWTConfig{
+		ActiveKeyID: "new",
+		Keys:        []config.JWTKeyConfig{{ID: "new", PrivateKeyFile: newKey.privateKey}},
+	}
+
+	rotated := newTestManager(t, rotatedCfg)
+	if got := len(rotated.JWKS().Keys); got != 2 {
+		t.Fatalf("rotated jwks has %d keys, want 2", got)
+	}
+
+	tests := []struct {
+		name     string
+		signer   *JWTManager
+		verifier *JWTManager
+		wantErr  bool
+	}{
+		{"new key", rotated, rotated, false},
+		{"token signed before rotation", oldManager, rotated, false},
+		{"old key retired", oldManager, newTestManager(t, retiredCfg), true},
+		{"hs256 rejected after rotation", hsManager, rotated, true},
+		{"hs256 accepted during migration", hsManager, newTestManager(t, legacyCfg), false},
+		{"same kid signed by another key", forgedManager, rotated, true},
+		{"unknown kid", rotated, oldManager, true},
+		{"another issuer", foreignIssuer, rotated, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := tt.signer.GenerateTokens(1, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", types.SessionBinding{})
+			if err != nil {
+				t.Fatalf("generate tokens: %v", err)
+			}
+			_, err = tt.verifier.VerifyAccessToken(pair.AccessToken)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected verification error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTManagerRejectsAlgorithmMismatch(t *testing.T) {
+	edKey := writeTestKey(t, "ed", newEd25519Key(t), false)
+	ecPrivate := newECKey(t, elliptic.P256())
+
+	manager := newTestManager(t, config.JWTConfig{
+		ActiveKeyID: "ed",
+		Keys:        []config.JWTKeyConfig{{ID: "ed", PrivateKeyFile: edKey.privateKey}},
+	})
+
+	// 使用Ed25519密钥的kid但以ES256签名
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"user_id":        1,
+		"wallet_address": "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"type":           "access",
+		"iss":            "timelocker-test",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "ed"
+	signed, err := token.SignedString(ecPrivate)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if _, err := manager.VerifyAccessToken(signed); err == nil {
+		t.Fatalf("token with mismatched algorithm was accepted")
+	}
+}
+
+func TestNewJWTManagerInvalidConfig(t *testing.T) {
+	ecKey := writeTestKey(t, "es256", newECKey(t, elliptic.P256()), false)
+	p384Key := writeTestKey(t, "p384", newECKey(t, elliptic.P384()), false)
+
+	tests := []struct {
+		name string
+		cfg  config.JWTConfig
+	}{
+		{"no secret", config.JWTConfig{}},
+		{"active key not configured", config.JWTConfig{ActiveKeyID: "missing"}},
+		{"active key without private key", config.JWTConfig{
+			ActiveKeyID: "es",
+			Keys:        []config.JWTKeyConfig{{ID: "es", PublicKeyFile: ecKey.publicKey}},
+		}},
+		{"key without id", config.JWTConfig{
+			Secret: "test-secret",
+			Keys:   []config.JWTKeyConfig{{PrivateKeyFile: ecKey.privateKey}},
+		}},
+		{"duplicate key id", config.JWTConfig{
+			Secret: "test-secret",
+			Keys: []config.JWTKeyConfig{
+				{ID: "es", PrivateKeyFile: ecKey.privateKey},
+				{ID: "es", PublicKeyFile: ecKey.publicKey},
+			},
+		}},
+		{"key without file", config.JWTConfig{
+			Secret: "test-secret",
+			Keys:   []config.JWTKeyConfig{{ID: "es"}},
+		}},
+		{"missing key file", config.JWTConfig{
+			Secret: "test-secret",
+			Keys:   []config.JWTKeyConfig{{ID: "es", PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		}},
+		{"unsupported curve", config.JWTConfig{
+			ActiveKeyID: "p384",
+			Keys:        []config.JWTKeyConfig{{ID: "p384", PrivateKeyFile: p384Key.privateKey}},
+		}},
+		{"legacy secret accepted without secret", config.JWTConfig{
+			ActiveKeyID:        "es",
+			AcceptLegacySecret: true,
+			Keys:               []config.JWTKeyConfig{{ID: "es", PrivateKeyFile: ecKey.privateKey}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJWTManager(&tt.cfg); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
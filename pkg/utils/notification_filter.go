@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"timelocker-backend/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	functionSelectorRegex = regexp.MustCompile(`^0x[0-9a-f]{8}$`)
+	validFilterStatuses   = map[string]bool{
+		types.NotificationStatus.Waiting:   true,
+		types.NotificationStatus.Ready:     true,
+		types.NotificationStatus.Executed:  true,
+		types.NotificationStatus.Cancelled: true,
+		types.NotificationStatus.Expired:   true,
+	}
+)
+
+// NormalizeNotificationFilter 校验并规范化过滤规则（地址、状态、函数选择器统一小写，函数签名转换为选择器）
+func NormalizeNotificationFilter(filter *types.NotificationFilter) error {
+	if filter == nil {
+		return nil
+	}
+
+	for i, address := range filter.ContractAddresses {
+		address = strings.TrimSpace(address)
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid contract address in filter: %s", address)
+		}
+		filter.ContractAddresses[i] = strings.ToLower(address)
+	}
+
+	for _, chainID := range filter.ChainIDs {
+		if chainID <= 0 {
+			return fmt.Errorf("invalid chain id in filter: %d", chainID)
+		}
+	}
+
+	for i, status := range filter.StatusTo {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !validFilterStatuses[status] {
+			return fmt.Errorf("invalid status in filter: %s", status)
+		}
+		filter.StatusTo[i] = status
+	}
+
+	for i, function := range filter.Functions {
+		selector, err := normalizeFunctionSelector(function)
+		if err != nil {
+			return err
+		}
+		filter.Functions[i] = selector
+	}
+
+	filter.MinValue = strings.TrimSpace(filter.MinValue)
+	if filter.MinValue != "" {
+		minValue, ok := new(big.Int).SetString(filter.MinValue, 10)
+		if !ok || minValue.Sign() < 0 {
+			return fmt.Errorf("invalid min_value in filter: %s", filter.MinValue)
+		}
+	}
+
+	return nil
+}
+
+// EncodeNotificationFilter 校验过滤规则并序列化为JSON用于存储，未设置任何条件时返回nil
+func EncodeNotificationFilter(filter *types.NotificationFilter) (*string, error) {
+	if filter.IsEmpty() {
+		return nil, nil
+	}
+	if err := NormalizeNotificationFilter(filter); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification filter: %w", err)
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// ParseNotificationFilter 解析数据库中存储的过滤规则，为空表示不过滤
+func ParseNotificationFilter(raw *string) (*types.NotificationFilter, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+
+	var filter types.NotificationFilter
+	if err := json.Unmarshal([]byte(*raw), &filter); err != nil {
+		return nil, fmt.Errorf("failed to parse notification filter: %w", err)
+	}
+	return &filter, nil
+}
+
+// MatchNotificationFilter 判断流程事件是否满足过滤规则
+// 规则要求函数或金额但事件缺少对应信息时视为不匹配
+func MatchNotificationFilter(filter *types.NotificationFilter, event *types.NotificationFilterEvent) bool {
+	if filter == nil {
+		return true
+	}
+
+	if len(filter.ContractAddresses) > 0 && !containsString(filter.ContractAddresses, strings.ToLower(event.ContractAddress)) {
+		return false
+	}
+
+	if len(filter.ChainIDs) > 0 {
+		matched := false
+		for _, chainID := range filter.ChainIDs {
+			if chainID == event.ChainID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(filter.StatusTo) > 0 && !containsString(filter.StatusTo, strings.ToLower(event.StatusTo)) {
+		return false
+	}
+
+	if len(filter.Functions) > 0 {
+		if event.FunctionSignature == nil {
+			return false
+		}
+		selector, err := normalizeFunctionSelector(*event.FunctionSignature)
+		if err != nil || !containsString(filter.Functions, selector) {
+			return false
+		}
+	}
+
+	if filter.MinValue != "" {
+		minValue, ok := new(big.Int).SetString(filter.MinValue, 10)
+		if !ok {
+			return false
+		}
+		value, ok := new(big.Int).SetString(event.Value, 10)
+		if !ok || value.Cmp(minValue) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchNotificationFilterScope 仅按合约地址和链ID判断是否匹配（用于摘要等不针对单次状态变化的场景）
+func MatchNotificationFilterScope(filter *types.NotificationFilter, chainID int, contractAddress string) bool {
+	if filter == nil {
+		return true
+	}
+	return MatchNotificationFilter(&types.NotificationFilter{
+		ContractAddresses: filter.ContractAddresses,
+		ChainIDs:          filter.ChainIDs,
+	}, &types.NotificationFilterEvent{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+	})
+}
+
+// MatchNotificationFilterJSON 使用数据库中存储的过滤规则进行匹配，规则无法解析时视为不匹配
+func MatchNotificationFilterJSON(raw *string, event *types.NotificationFilterEvent) (bool, error) {
+	filter, err := ParseNotificationFilter(raw)
+	if err != nil {
+		return false, err
+	}
+	return MatchNotificationFilter(filter, event), nil
+}
+
+// normalizeFunctionSelector 将函数选择器或函数签名统一转换为小写的4字节选择器
+func normalizeFunctionSelector(function string) (string, error) {
+	function = strings.TrimSpace(function)
+	if strings.HasPrefix(function, "0x") || strings.HasPrefix(function, "0X") {
+		selector := strings.ToLower(function)
+		if !functionSelectorRegex.MatchString(selector) {
+			return "", fmt.Errorf("invalid function selector in filter: %s", function)
+		}
+		return selector, nil
+	}
+
+	funcName, paramTypes, err := parseAndValidateFunctionSig(function)
+	if err != nil {
+		return "", fmt.Errorf("invalid function signature in filter: %w", err)
+	}
+	canonical := fmt.Sprintf("%s(%s)", funcName, strings.Join(paramTypes, ","))
+	return hexutil.Encode(crypto.Keccak256([]byte(canonical))[:4]), nil
+}
+
+// containsString 判断字符串切片中是否包含指定值
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
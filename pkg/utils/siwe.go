@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SIWE (EIP-4361) 消息格式常量
+const (
+	siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+	siweVersion      = "1"
+)
+
+var (
+	siweNonceRegex  = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+	siweDomainRegex = regexp.MustCompile(`^[a-zA-Z0-9.\-]+(:[0-9]{1,5})?$`)
+)
+
+// SiweMessage Sign-In with Ethereum 消息
+type SiweMessage struct {
+	Domain         string     // 请求签名的域名（可含端口）
+	Address        string     // EIP-55格式的钱包地址
+	Statement      string     // 可选的说明文字，单行
+	URI            string     // 登录主体的URI
+	Version        string     // 固定为1
+	ChainID        int        // 链ID
+	Nonce          string     // 至少8位字母数字
+	IssuedAt       time.Time  // 签发时间
+	ExpirationTime *time.Time // 过期时间
+	NotBefore      *time.Time // 生效时间
+	RequestID      string     // 可选请求ID
+	Resources      []string   // 可选资源列表
+}
+
+// String 按EIP-4361格式生成待签名消息
+func (m *SiweMessage) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + siweHeaderSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.Itoa(m.ChainID) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+	return b.String()
+}
+
+// ParseSiweMessage 严格按EIP-4361格式解析消息
+func ParseSiweMessage(raw string) (*SiweMessage, error) {
+	lines := strings.Split(raw, "\n")
+	if len(lines) < 8 {
+		return nil, errors.New("invalid siwe message: too few lines")
+	}
+
+	msg := &SiweMessage{}
+
+	// 头部：<domain> wants you to sign in with your Ethereum account:
+	if !strings.HasSuffix(lines[0], siweHeaderSuffix) {
+		return nil, errors.New("invalid siwe message: missing header")
+	}
+	msg.Domain = strings.TrimSuffix(lines[0], siweHeaderSuffix)
+	if !siweDomainRegex.MatchString(msg.Domain) {
+		return nil, fmt.Errorf("invalid siwe message: invalid domain %q", msg.Domain)
+	}
+
+	// 地址必须为EIP-55校验和格式
+	msg.Address = lines[1]
+	if !common.IsHexAddress(msg.Address) || common.HexToAddress(msg.Address).Hex() != msg.Address {
+		return nil, errors.New("invalid siwe message: address must be EIP-55 checksummed")
+	}
+
+	if lines[2] != "" {
+		return nil, errors.New("invalid siwe message: expected empty line after address")
+	}
+	i := 3
+	if lines[i] != "" && !strings.HasPrefix(lines[i], "URI: ") {
+		msg.Statement = lines[i]
+		i++
+	}
+	if i >= len(lines) || lines[i] != "" {
+		return nil, errors.New("invalid siwe message: expected empty line after statement")
+	}
+	i++
+
+	// 必填字段按固定顺序出现
+	next := func(prefix string, required bool) (string, bool, error) {
+		if i < len(lines) && strings.HasPrefix(lines[i], prefix) {
+			value := strings.TrimPrefix(lines[i], prefix)
+			i++
+			return value, true, nil
+		}
+		if required {
+			return "", false, fmt.Errorf("invalid siwe message: missing %q", strings.TrimSuffix(prefix, ": "))
+		}
+		return "", false, nil
+	}
+
+	var err error
+	if msg.URI, _, err = next("URI: ", true); err != nil {
+		return nil, err
+	}
+	if _, err := url.ParseRequestURI(msg.URI); err != nil {
+		return nil, fmt.Errorf("invalid siwe message: invalid uri: %w", err)
+	}
+
+	if msg.Version, _, err = next("Version: ", true); err != nil {
+		return nil, err
+	}
+	if msg.Version != siweVersion {
+		return nil, fmt.Errorf("invalid siwe message: unsupported version %q", msg.Version)
+	}
+
+	chainID, _, err := next("Chain ID: ", true)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ChainID, err = strconv.Atoi(chainID); err != nil || msg.ChainID <= 0 {
+		return nil, fmt.Errorf("invalid siwe message: invalid chain id %q", chainID)
+	}
+
+	if msg.Nonce, _, err = next("Nonce: ", true); err != nil {
+		return nil, err
+	}
+	if !siweNonceRegex.MatchString(msg.Nonce) {
+		return nil, errors.New("invalid siwe message: nonce must be at least 8 alphanumeric characters")
+	}
+
+	issuedAt, _, err := next("Issued At: ", true)
+	if err != nil {
+		return nil, err
+	}
+	if msg.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, fmt.Errorf("invalid siwe message: invalid issued at: %w", err)
+	}
+
+	if value, ok, _ := next("Expiration Time: ", false); ok {
+		expirationTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid siwe message: invalid expiration time: %w", err)
+		}
+		msg.ExpirationTime = &expirationTime
+	}
+	if value, ok, _ := next("Not Before: ", false); ok {
+		notBefore, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid siwe message: invalid not before: %w", err)
+		}
+		msg.NotBefore = &notBefore
+	}
+	if value, ok, _ := next("Request ID: ", false); ok {
+		msg.RequestID = value
+	}
+	if i < len(lines) && lines[i] == "Resources:" {
+		i++
+		for i < len(lines) && strings.HasPrefix(lines[i], "- ") {
+			msg.Resources = append(msg.Resources, strings.TrimPrefix(lines[i], "- "))
+			i++
+		}
+	}
+
+	if i != len(lines) {
+		return nil, fmt.Errorf("invalid siwe message: unexpected content at line %d", i+1)
+	}
+	return msg, nil
+}
+
+// ValidateTime 校验签发时间、过期时间和生效时间，clockSkew为允许的时钟偏差
+func (m *SiweMessage) ValidateTime(now time.Time, clockSkew time.Duration) error {
+	if m.IssuedAt.After(now.Add(clockSkew)) {
+		return errors.New("siwe message issued in the future")
+	}
+	if m.ExpirationTime == nil {
+		return errors.New("siwe message has no expiration time")
+	}
+	if !now.Before(m.ExpirationTime.Add(clockSkew)) {
+		return errors.New("siwe message expired")
+	}
+	if m.NotBefore != nil && now.Add(clockSkew).Before(*m.NotBefore) {
+		return errors.New("siwe message not yet valid")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const siweTestAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+func siweTestMessage() *SiweMessage {
+	expiration := time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC)
+	return &SiweMessage{
+		Domain:         "app.timelocker.io",
+		Address:        siweTestAddress,
+		Statement:      "Sign in to TimeLocker",
+		URI:            "https://app.timelocker.io/login",
+		Version:        siweVersion,
+		ChainID:        1,
+		Nonce:          "a1b2c3d4e5f6",
+		IssuedAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpirationTime: &expiration,
+	}
+}
+
+func TestParseSiweMessageRoundTrip(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		modify func(m *SiweMessage)
+	}{
+		{"required fields", func(m *SiweMessage) {}},
+		{"without statement", func(m *SiweMessage) { m.Statement = "" }},
+		{"domain with port", func(m *SiweMessage) {
+			m.Domain = "localhost:3000"
+			m.URI = "http://localhost:3000"
+		}},
+		{"optional fields", func(m *SiweMessage) {
+			m.NotBefore = &notBefore
+			m.RequestID = "req-1"
+			m.Resources = []string{"https://app.timelocker.io/a", "ipfs://bafy"}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := siweTestMessage()
+			tt.modify(want)
+
+			got, err := ParseSiweMessage(want.String())
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got.String() != want.String() {
+				t.Fatalf("round trip mismatch:\n%s\nwant:\n%s", got.String(), want.String())
+			}
+			if got.Domain != want.Domain || got.URI != want.URI || got.ChainID != want.ChainID || got.Nonce != want.Nonce {
+				t.Fatalf("parsed fields mismatch: %+v", got)
+			}
+		})
+	}
+}
+
+func TestParseSiweMessageRejectsMalformed(t *testing.T) {
+	valid := siweTestMessage().String()
+	replace := func(old, new string) string {
+		if !strings.Contains(valid, old) {
+			t.Fatalf("test message does not contain %q", old)
+		}
+		return strings.Replace(valid, old, new, 1)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"too few lines", strings.Join(strings.Split(valid, "\n")[:6], "\n")},
+		{"missing header", replace(siweHeaderSuffix, " wants you to sign in:")},
+		{"invalid domain", replace("app.timelocker.io wants", "app/timelocker.io wants")},
+		{"domain with scheme", replace("app.timelocker.io wants", "https://app.timelocker.io wants")},
+		{"lowercase address", replace(siweTestAddress, strings.ToLower(siweTestAddress))},
+		{"short address", replace(siweTestAddress, siweTestAddress[:40])},
+		{"missing empty line after address", replace(siweTestAddress+"\n\n", siweTestAddress+"\n")},
+		{"multi-line statement", replace("Sign in to TimeLocker", "Sign in\nto TimeLocker")},
+		{"missing uri", replace("URI: https://app.timelocker.io/login\n", "")},
+		{"relative uri", replace("URI: https://app.timelocker.io/login", "URI: login")},
+		{"unsupported version", replace("Version: 1", "Version: 2")},
+		{"missing version", replace("Version: 1\n", "")},
+		{"non-numeric chain id", replace("Chain ID: 1", "Chain ID: one")},
+		{"zero chain id", replace("Chain ID: 1", "Chain ID: 0")},
+		{"negative chain id", replace("Chain ID: 1", "Chain ID: -1")},
+		{"short nonce", replace("Nonce: a1b2c3d4e5f6", "Nonce: a1b2c3")},
+		{"non-alphanumeric nonce", replace("Nonce: a1b2c3d4e5f6", "Nonce: a1b2-c3d4-e5f6")},
+		{"missing nonce", replace("Nonce: a1b2c3d4e5f6\n", "")},
+		{"fields out of order", replace("Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1")},
+		{"invalid issued at", replace("Issued At: 2024-01-01T00:00:00Z", "Issued At: 2024-01-01 00:00:00")},
+		{"invalid expiration time", replace("Expiration Time: 2024-01-01T00:10:00Z", "Expiration Time: tomorrow")},
+		{"unknown field", valid + "\nFoo: bar"},
+		{"trailing empty line", valid + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg, err := ParseSiweMessage(tt.raw); err == nil {
+				t.Fatalf("expected error, got %+v", msg)
+			}
+		})
+	}
+}
+
+func TestSiweMessageValidateTime(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	skew := time.Minute
+	at := func(d time.Duration) *time.Time {
+		v := issuedAt.Add(d)
+		return &v
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		expiration *time.Time
+		notBefore  *time.Time
+		wantErr    bool
+	}{
+		{"valid", issuedAt.Add(time.Minute), at(10 * time.Minute), nil, false},
+		{"issued within clock skew", issuedAt.Add(-30 * time.Second), at(10 * time.Minute), nil, false},
+		{"issued in the future", issuedAt.Add(-2 * time.Minute), at(10 * time.Minute), nil, true},
+		{"no expiration time", issuedAt.Add(time.Minute), nil, nil, true},
+		{"expired", issuedAt.Add(12 * time.Minute), at(10 * time.Minute), nil, true},
+		{"expired at boundary", issuedAt.Add(11 * time.Minute), at(10 * time.Minute), nil, true},
+		{"expiration within clock skew", issuedAt.Add(10*time.Minute + 30*time.Second), at(10 * time.Minute), nil, false},
+		{"not yet valid", issuedAt.Add(time.Minute), at(10 * time.Minute), at(5 * time.Minute), true},
+		{"not before reached", issuedAt.Add(5 * time.Minute), at(10 * time.Minute), at(5 * time.Minute), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := siweTestMessage()
+			msg.IssuedAt = issuedAt
+			msg.ExpirationTime = tt.expiration
+			msg.NotBefore = tt.notBefore
+
+			err := msg.ValidateTime(tt.now, skew)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
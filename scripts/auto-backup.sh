@@ -83,6 +83,11 @@ Examples:
   # simulate run
   $0 --dry-run
 
+Remote Storage:
+  When backup.storage is configured in config.yaml, each backup is also uploaded to
+  the storage (local directory or S3-compatible) and backup.retention is applied
+  there. The local cleanup below only affects files in the backup directory.
+
 Cron Job Examples:
   # execute backup every day at 2:00
   0 2 * * * /path/to/auto-backup.sh
@@ -111,7 +116,7 @@ cleanup_old_backups() {
     
     # 按时间清理：删除超过指定天数的备份
     local old_files
-    old_files=$(find . -name "${BACKUP_PREFIX}_*.json" -type f -mtime +$BACKUP_RETENTION_DAYS 2>/dev/null || true)
+    old_files=$(find . \( -name "${BACKUP_PREFIX}_*.tar" -o -name "${BACKUP_PREFIX}_*.json" \) -type f -mtime +$BACKUP_RETENTION_DAYS 2>/dev/null || true)
     
     if [ -n "$old_files" ]; then
         log "found $(echo "$old_files" | wc -l) backup files over $BACKUP_RETENTION_DAYS days"
@@ -129,7 +134,7 @@ cleanup_old_backups() {
     
     # 按数量清理：保留最新的N个备份
     local all_backups
-    all_backups=$(ls -t ${BACKUP_PREFIX}_*.json 2>/dev/null || true)
+    all_backups=$(ls -t ${BACKUP_PREFIX}_*.tar ${BACKUP_PREFIX}_*.json 2>/dev/null || true)
     
     if [ -n "$all_backups" ]; then
         local backup_count
@@ -162,7 +167,7 @@ cleanup_old_backups() {
 create_backup() {
     local dry_run=$1
     local timestamp=$(date '+%Y%m%d_%H%M%S')
-    local backup_filename="${BACKUP_PREFIX}_${timestamp}.json"
+    local backup_filename="${BACKUP_PREFIX}_${timestamp}.tar"
     
     log "start creating auto backup..."
     log "backup file name: $backup_filename"
@@ -48,7 +48,7 @@ Usage:
 
 Options:
   -h, --help              show help
-  -a, --action ACTION     action type (backup|restore|validate|info|reset)
+  -a, --action ACTION     action type (backup|restore|validate|info|list|prune|reset)
   -f, --file FILE         backup file path
   -c, --clear              clear existing data when restore
   --conflict STRATEGY      conflict resolution strategy (skip|replace|error)
@@ -59,20 +59,26 @@ Examples:
   $0 --action backup
   
   # create backup to specified file
-  $0 --action backup --file ./my_backup.json
+  $0 --action backup --file ./my_backup.tar
   
   # restore from backup (skip conflicts)
-  $0 --action restore --file ./my_backup.json --conflict skip
+  $0 --action restore --file ./my_backup.tar --conflict skip
   
   # restore from backup (clear existing data)
-  $0 --action restore --file ./my_backup.json --clear --conflict replace
+  $0 --action restore --file ./my_backup.tar --clear --conflict replace
   
   # validate backup file
-  $0 --action validate --file ./my_backup.json
+  $0 --action validate --file ./my_backup.tar
   
   # view backup file info
-  $0 --action info --file ./my_backup.json
+  $0 --action info --file ./my_backup.tar
   
+  # list backups recorded in the backup directory catalog
+  $0 --action list
+
+  # prune old backups with backup.retention (remote storage when backup.storage is configured)
+  $0 --action prune
+
   # reset database (dangerous operation)
   $0 --action reset
 
@@ -233,9 +239,9 @@ main() {
     if [ -n "$file" ]; then
         # 确保文件名格式正确
         local filename=$(basename "$file")
-        # 如果没有扩展名，添加.json
-        if [[ "$filename" != *.json ]]; then
-            filename="${filename}.json"
+        # 如果没有扩展名，添加.tar（旧版备份文件保留.json扩展名）
+        if [[ "$filename" != *.tar && "$filename" != *.json ]]; then
+            filename="${filename}.tar"
         fi
         # 容器内的备份文件路径
         container_file="/app/backups/$filename"
@@ -361,6 +367,15 @@ main() {
             # 重新构建备份命令，使用容器内的文件路径
             backup_cmd="/app/backup -action=$action -file=$container_file"
             
+            if execute_backup_command "$backup_cmd"; then
+                log_success "$action operation completed"
+            else
+                exit 1
+            fi
+            ;;
+        list|prune)
+            backup_cmd="/app/backup -action=$action -file=/app/backups"
+
             if execute_backup_command "$backup_cmd"; then
                 log_success "$action operation completed"
             else
@@ -376,7 +391,9 @@ main() {
                     exit 0
                 fi
             fi
-            
+
+            # 重置由迁移工具执行，需通过环境变量确认目标数据库名
+            backup_cmd="env TIMELOCKER_RESET_CONFIRM=${POSTGRES_DB:-timelocker_db} /app/migrate reset"
             if execute_backup_command "$backup_cmd"; then
                 log_success "database reset successfully"
             else
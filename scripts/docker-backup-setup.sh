@@ -166,7 +166,7 @@ test_backup() {
     
     # 测试备份命令
     log "testing backup creation..."
-    local test_file="test_backup_$(date +%Y%m%d_%H%M%S).json"
+    local test_file="test_backup_$(date +%Y%m%d_%H%M%S).tar"
     if ./scripts/backup.sh --action backup --file "$test_file" --auto; then
         log_success "backup creation test passed"
         
@@ -210,13 +210,13 @@ show_config() {
     echo "Available commands:"
     echo "  make backup                       # 手动创建备份"
     echo "  make backup-status               # 检查备份系统状态"
-    echo "  make restore FILE=backup.json    # 完全恢复数据"
-    echo "  make restore-safe FILE=backup.json # 安全恢复数据"
+    echo "  make restore FILE=backup.tar    # 完全恢复数据"
+    echo "  make restore-safe FILE=backup.tar # 安全恢复数据"
     echo "  make test-backup-restore         # 测试备份恢复流程"
     echo ""
     echo "Direct script commands:"
-    echo "  ./scripts/backup.sh --action backup --file mybackup.json"
-    echo "  ./scripts/backup.sh --action restore --file mybackup.json"
+    echo "  ./scripts/backup.sh --action backup --file mybackup.tar"
+    echo "  ./scripts/backup.sh --action restore --file mybackup.tar"
     echo "  ./scripts/auto-backup.sh"
     echo ""
 }